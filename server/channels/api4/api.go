@@ -326,6 +326,9 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitUsage()
 	api.InitHostedCustomer()
 	api.InitDrafts()
+	api.InitScheduledPosts()
+	api.InitWebAuthn()
+	api.InitMfaRecovery()
 	api.InitIPFiltering()
 	api.InitChannelBookmarks()
 	api.InitReports()
@@ -4,14 +4,19 @@
 package api4
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 )
 
 func (api *API) InitBleve() {
 	api.BaseRoutes.Bleve.Handle("/purge_indexes", api.APISessionRequired(purgeBleveIndexes)).Methods("POST")
+	api.BaseRoutes.Bleve.Handle("/indexes", api.APISessionRequired(getBleveIndexStats)).Methods("GET")
+	api.BaseRoutes.Bleve.Handle("/indexes/{index_name:[a-z]+}", api.APISessionRequired(verifyBleveIndex)).Methods("GET")
+	api.BaseRoutes.Bleve.Handle("/reindex", api.APISessionRequired(createBleveReindexJob)).Methods("POST")
 }
 
 func purgeBleveIndexes(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -28,7 +33,9 @@ func purgeBleveIndexes(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := c.App.PurgeBleveIndexes(c.AppContext); err != nil {
+	specifiedIndexesQuery := r.URL.Query()["index"]
+	audit.AddEventParameter(auditRec, "indexes", specifiedIndexesQuery)
+	if err := c.App.PurgeBleveIndexes(c.AppContext, specifiedIndexesQuery); err != nil {
 		c.Err = err
 		return
 	}
@@ -37,3 +44,76 @@ func purgeBleveIndexes(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+func getBleveIndexStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadExperimentalBleve) {
+		c.SetPermissionError(model.PermissionSysconsoleReadExperimentalBleve)
+		return
+	}
+
+	stats, err := c.App.GetBleveIndexStats()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func verifyBleveIndex(c *Context, w http.ResponseWriter, r *http.Request) {
+	if c.Params.IndexName == "" {
+		c.SetInvalidURLParam("index_name")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadExperimentalBleve) {
+		c.SetPermissionError(model.PermissionSysconsoleReadExperimentalBleve)
+		return
+	}
+
+	stats, err := c.App.VerifyBleveIndex(c.Params.IndexName)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createBleveReindexJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	var req model.BleveReindexRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&req); jsonErr != nil {
+		c.SetInvalidParamWithErr("reindex", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createBleveReindexJob", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "channel_id", req.ChannelId)
+	audit.AddEventParameter(auditRec, "start_time", req.StartTime)
+	audit.AddEventParameter(auditRec, "end_time", req.EndTime)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreatePostBleveIndexesJob) {
+		c.SetPermissionError(model.PermissionCreatePostBleveIndexesJob)
+		return
+	}
+
+	job, err := c.App.CreateBleveReindexJob(c.AppContext, &req)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(job)
+	auditRec.AddEventObjectType("job")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
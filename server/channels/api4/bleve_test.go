@@ -44,3 +44,137 @@ func TestBlevePurgeIndexes(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func setupBleveIndexes(t *testing.T, th *TestHelper) {
+	indexDir := t.TempDir()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.BleveSettings.IndexDir = indexDir
+		*cfg.BleveSettings.EnableIndexing = true
+	})
+	t.Cleanup(func() {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.BleveSettings.EnableIndexing = false
+			*cfg.BleveSettings.IndexDir = ""
+		})
+	})
+}
+
+func TestGetBleveIndexStats(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := th.Client.GetBleveIndexStats(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin with indexing disabled", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetBleveIndexStats(context.Background())
+		require.Error(t, err)
+		CheckServiceUnavailableStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		setupBleveIndexes(t, th)
+
+		stats, resp, err := th.SystemAdminClient.GetBleveIndexStats(context.Background())
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, stats, 4)
+		for _, indexStats := range stats {
+			require.True(t, indexStats.Healthy, indexStats.Error)
+		}
+	})
+}
+
+func TestVerifyBleveIndex(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	setupBleveIndexes(t, th)
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := th.Client.VerifyBleveIndex(context.Background(), "posts")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		stats, resp, err := th.SystemAdminClient.VerifyBleveIndex(context.Background(), "posts")
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, "posts", stats.Name)
+		require.True(t, stats.Healthy, stats.Error)
+	})
+
+	t.Run("unknown index", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.VerifyBleveIndex(context.Background(), "unknown")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestCreateBleveReindexJob(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := th.Client.CreateBleveReindexJob(context.Background(), &model.BleveReindexRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateBleveReindexJob(context.Background(), &model.BleveReindexRequest{StartTime: 2000, EndTime: 1000})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateBleveReindexJob(context.Background(), &model.BleveReindexRequest{ChannelId: model.NewId()})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("channel and time range", func(t *testing.T) {
+		job, resp, err := th.SystemAdminClient.CreateBleveReindexJob(context.Background(), &model.BleveReindexRequest{
+			ChannelId: th.BasicChannel.Id,
+			StartTime: 1000,
+			EndTime:   2000,
+		})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		defer th.App.Srv().Store().Job().Delete(job.Id)
+
+		require.Equal(t, model.JobTypeBlevePostIndexing, job.Type)
+		require.Equal(t, th.BasicChannel.Id, job.Data["channel_id"])
+		require.Equal(t, "1000", job.Data["start_time"])
+		require.Equal(t, "2000", job.Data["end_time"])
+	})
+}
+
+func TestBlevePurgeIndexList(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	setupBleveIndexes(t, th)
+
+	t.Run("as system user", func(t *testing.T) {
+		resp, err := th.Client.PurgeBleveIndexList(context.Background(), []string{"posts"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.PurgeBleveIndexList(context.Background(), []string{"posts", "files"})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+	})
+
+	t.Run("unknown index", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.PurgeBleveIndexList(context.Background(), []string{"unknown"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -219,10 +219,20 @@ func (api *API) APILocal(h handlerFunc, opts ...APIHandlerOption) http.Handler {
 	return handler
 }
 
-func (api *API) RateLimitedHandler(apiHandler http.Handler, settings model.RateLimitSettings) http.Handler {
+// RateLimitedHandler rate limits the requests to a single route with the
+// given settings. The limits are kept under name in the rate limit store of
+// the server, so that they are shared by the nodes of a cluster when it is Redis.
+func (api *API) RateLimitedHandler(name string, apiHandler http.Handler, settings model.RateLimitSettings) http.Handler {
 	settings.SetDefaults()
 
-	rateLimiter, err := app.NewRateLimiter(&settings, []string{})
+	var rateLimiter *app.RateLimiter
+	store, err := api.srv.RateLimitStore()
+	if err == nil {
+		rateLimiter, err = app.NewRouteRateLimiter(name, &settings, store, []string{})
+	} else {
+		api.srv.Log().Warn("Unable to use the rate limit store, falling back to a local one", mlog.Err(err))
+		rateLimiter, err = app.NewRateLimiter(&settings, []string{})
+	}
 	if err != nil {
 		api.srv.Log().Error("getRateLimitedHandler", mlog.Err(err))
 		return nil
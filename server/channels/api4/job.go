@@ -19,10 +19,14 @@ import (
 func (api *API) InitJob() {
 	api.BaseRoutes.Jobs.Handle("", api.APISessionRequired(getJobs)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("", api.APISessionRequired(createJob)).Methods("POST")
+	api.BaseRoutes.Jobs.Handle("/schedules", api.APISessionRequired(getJobSchedules)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}", api.APISessionRequired(getJob)).Methods("GET")
+	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}/lineage", api.APISessionRequired(getJobLineage)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}/download", api.APISessionRequiredTrustRequester(downloadJob)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}/cancel", api.APISessionRequired(cancelJob)).Methods("POST")
 	api.BaseRoutes.Jobs.Handle("/type/{job_type:[A-Za-z0-9_-]+}", api.APISessionRequired(getJobsByType)).Methods("GET")
+	api.BaseRoutes.Jobs.Handle("/type/{job_type:[A-Za-z0-9_-]+}/pause", api.APISessionRequired(pauseJobType)).Methods("POST")
+	api.BaseRoutes.Jobs.Handle("/type/{job_type:[A-Za-z0-9_-]+}/resume", api.APISessionRequired(resumeJobType)).Methods("POST")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}/status", api.APISessionRequired(updateJobStatus)).Methods("PATCH")
 }
 
@@ -53,6 +57,48 @@ func getJob(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getJobLineage returns the jobs of the lineage of the given job, such as its retries and
+// the jobs following up on it, leaving out those the user isn't allowed to read.
+func getJobLineage(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	job, appErr := c.App.GetJob(c.AppContext, c.Params.JobId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	hasPermission, permissionRequired := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), job.Type)
+	if permissionRequired == nil {
+		c.Err = model.NewAppError("getJobLineage", "api.job.retrieve.nopermissions", nil, "", http.StatusBadRequest)
+		return
+	}
+	if !hasPermission {
+		c.SetPermissionError(permissionRequired)
+		return
+	}
+
+	lineage, appErr := c.App.GetJobLineage(c.AppContext, job)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	jobs := make([]*model.Job, 0, len(lineage))
+	for _, j := range lineage {
+		if hasPermission, _ := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), j.Type); hasPermission {
+			jobs = append(jobs, j)
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(jobs); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func downloadJob(c *Context, w http.ResponseWriter, r *http.Request) {
 	config := c.App.Config()
 	const FilePath = "export"
@@ -63,23 +109,42 @@ func downloadJob(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !*config.MessageExportSettings.DownloadExportResults {
+	job, err := c.App.GetJob(c.AppContext, c.Params.JobId)
+
+	// The results of message exports can't be downloaded unless enabled, which is reported
+	// before revealing whether the job exists.
+	if (err != nil || job.Type == model.JobTypeMessageExport) && !*config.MessageExportSettings.DownloadExportResults {
 		c.Err = model.NewAppError("downloadExportResultsNotEnabled", "app.job.download_export_results_not_enabled", nil, "", http.StatusNotImplemented)
 		return
 	}
-
-	job, err := c.App.GetJob(c.AppContext, c.Params.JobId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	// Currently, this endpoint only supports downloading the compliance report.
-	// If you need to download another job type, you will need to alter this section of the code to accommodate it.
-	if job.Type == model.JobTypeMessageExport && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDownloadComplianceExportResult) {
-		c.SetPermissionError(model.PermissionDownloadComplianceExportResult)
-		return
-	} else if job.Type != model.JobTypeMessageExport {
+	// Currently, this endpoint only supports downloading the compliance report and the report of
+	// an import dry run. If you need to download another job type, you will need to alter this
+	// section of the code to accommodate it.
+	fileName := job.Id + ".zip"
+	filePath := filepath.Join(FilePath, fileName)
+	fileMime := FileMime
+	switch job.Type {
+	case model.JobTypeMessageExport:
+		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDownloadComplianceExportResult) {
+			c.SetPermissionError(model.PermissionDownloadComplianceExportResult)
+			return
+		}
+	case model.JobTypeImportProcess:
+		if hasPermission, permission := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), job.Type); !hasPermission {
+			c.SetPermissionError(permission)
+			return
+		}
+		// The path is built from the job id rather than read from the job data, so that a job
+		// can't be made to serve any other file of the file store.
+		fileName = job.Id + "_report.json"
+		filePath = filepath.Join(*c.App.Config().ImportSettings.Directory, fileName)
+		fileMime = "application/json"
+	default:
 		c.Err = model.NewAppError("unableToDownloadJob", "api.job.unable_to_download_job.incorrect_job_type", nil, "", http.StatusBadRequest)
 		return
 	}
@@ -90,8 +155,6 @@ func downloadJob(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileName := job.Id + ".zip"
-	filePath := filepath.Join(FilePath, fileName)
 	fileReader, err := c.App.FileReader(filePath)
 	if err != nil {
 		c.Err = err
@@ -102,7 +165,7 @@ func downloadJob(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	// We are able to pass 0 for content size due to the fact that Golang's serveContent (https://golang.org/src/net/http/fs.go)
 	// already sets that for us
-	web.WriteFileResponse(fileName, FileMime, 0, time.Unix(0, job.LastActivityAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, true, w, r)
+	web.WriteFileResponse(fileName, fileMime, 0, time.Unix(0, job.LastActivityAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, true, w, r)
 }
 
 func createJob(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -341,3 +404,71 @@ func updateJobStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+func getJobSchedules(c *Context, w http.ResponseWriter, r *http.Request) {
+	schedules, appErr := c.App.GetJobSchedules()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// The job types without a read permission of their own are only listed to the
+	// system admins.
+	readable := make([]*model.JobSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		hasPermission, permissionRequired := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), schedule.JobType)
+		if permissionRequired == nil {
+			hasPermission = c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
+		}
+		if hasPermission {
+			readable = append(readable, schedule)
+		}
+	}
+
+	if len(readable) == 0 && len(schedules) > 0 {
+		c.SetPermissionError(model.PermissionReadJobs)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(readable); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func pauseJobType(c *Context, w http.ResponseWriter, r *http.Request) {
+	setJobTypePaused(c, w, "pauseJobType", true)
+}
+
+func resumeJobType(c *Context, w http.ResponseWriter, r *http.Request) {
+	setJobTypePaused(c, w, "resumeJobType", false)
+}
+
+func setJobTypePaused(c *Context, w http.ResponseWriter, event string, paused bool) {
+	c.RequireJobType()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "job_type", c.Params.JobType)
+
+	hasPermission, permissionRequired := c.App.SessionHasPermissionToManageJob(*c.AppContext.Session(), &model.Job{Type: c.Params.JobType})
+	if permissionRequired == nil {
+		permissionRequired = model.PermissionManageSystem
+		hasPermission = c.App.SessionHasPermissionTo(*c.AppContext.Session(), permissionRequired)
+	}
+	if !hasPermission {
+		c.SetPermissionError(permissionRequired)
+		return
+	}
+
+	if appErr := c.App.SetJobTypePaused(c.Params.JobType, paused); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
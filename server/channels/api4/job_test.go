@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -321,6 +322,65 @@ func TestDownloadJob(t *testing.T) {
 	CheckBadRequestStatus(t, resp)
 }
 
+func TestDownloadJobImportReport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	job := &model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeImportProcess,
+		Data:   map[string]string{"dry_run": "true"},
+		Status: model.JobStatusSuccess,
+	}
+	_, err := th.App.Srv().Store().Job().Save(job)
+	require.NoError(t, err)
+	defer th.App.Srv().Store().Job().Delete(job.Id)
+
+	// The report of an import doesn't depend on the download of export results being enabled.
+	_, resp, err := th.SystemAdminClient.DownloadJob(context.Background(), job.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	reportPath := filepath.Join(*th.App.Config().ImportSettings.Directory, job.Id+"_report.json")
+	_, appErr := th.App.WriteFile(strings.NewReader(`{"lines":1}`), reportPath)
+	require.Nil(t, appErr)
+	defer th.App.RemoveFile(reportPath)
+
+	job.Data["report_file"] = reportPath
+	job.Data["is_downloadable"] = "true"
+	_, err = th.App.Srv().Store().Job().UpdateOptimistically(job, model.JobStatusSuccess)
+	require.NoError(t, err)
+
+	_, resp, err = th.Client.DownloadJob(context.Background(), job.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	data, _, err := th.SystemAdminClient.DownloadJob(context.Background(), job.Id)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"lines":1}`, string(data))
+
+	t.Run("report file from the job data is ignored", func(t *testing.T) {
+		forgedJob := &model.Job{
+			Id:   model.NewId(),
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"dry_run":         "true",
+				"is_downloadable": "true",
+				"report_file":     reportPath,
+			},
+			Status: model.JobStatusSuccess,
+		}
+		_, err := th.App.Srv().Store().Job().Save(forgedJob)
+		require.NoError(t, err)
+		defer th.App.Srv().Store().Job().Delete(forgedJob.Id)
+
+		data, resp, err := th.SystemAdminClient.DownloadJob(context.Background(), forgedJob.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+		require.Empty(t, data)
+	})
+}
+
 func TestCancelJob(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -434,3 +494,110 @@ func TestUpdateJobStatus(t *testing.T) {
 		})
 	})
 }
+
+func TestGetJobSchedules(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.JobSettings.CronSchedules = map[string]string{model.JobTypeActiveUsers: "0 3 * * *"}
+	})
+
+	_, resp, err := th.Client.GetJobSchedules(context.Background())
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	schedules, _, err := th.SystemAdminClient.GetJobSchedules(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, schedule := range schedules {
+		if schedule.JobType == model.JobTypeActiveUsers {
+			found = true
+			assert.Equal(t, "0 3 * * *", schedule.CronSchedule)
+			assert.Greater(t, schedule.NextRunTime, model.GetMillis())
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestPauseAndResumeJobType(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	jobType := model.JobTypeActiveUsers
+
+	resp, err := th.Client.PauseJobType(context.Background(), jobType)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = th.SystemAdminClient.PauseJobType(context.Background(), "unknown_job_type")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, err = th.SystemAdminClient.PauseJobType(context.Background(), jobType)
+	require.NoError(t, err)
+
+	paused, appErr := th.App.Srv().Jobs.IsJobTypePaused(jobType)
+	require.Nil(t, appErr)
+	assert.True(t, paused)
+
+	_, err = th.SystemAdminClient.ResumeJobType(context.Background(), jobType)
+	require.NoError(t, err)
+
+	paused, appErr = th.App.Srv().Jobs.IsJobTypePaused(jobType)
+	require.Nil(t, appErr)
+	assert.False(t, paused)
+}
+
+func TestGetJobLineage(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	root := &model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeExportProcess,
+		CreateAt: model.GetMillis(),
+		Status:   model.JobStatusError,
+	}
+	retry := &model.Job{
+		Id:         model.NewId(),
+		Type:       model.JobTypeExportProcess,
+		CreateAt:   root.CreateAt + 1,
+		Status:     model.JobStatusSuccess,
+		ParentId:   root.Id,
+		RootId:     root.Id,
+		RetryCount: 1,
+	}
+	followUp := &model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeExportDelete,
+		CreateAt: root.CreateAt + 2,
+		Status:   model.JobStatusPending,
+		ParentId: retry.Id,
+		RootId:   root.Id,
+	}
+
+	for _, job := range []*model.Job{root, retry, followUp} {
+		_, err := th.App.Srv().Store().Job().Save(job)
+		require.NoError(t, err)
+		defer th.App.Srv().Store().Job().Delete(job.Id)
+	}
+
+	_, resp, err := th.Client.GetJobLineage(context.Background(), retry.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	lineage, _, err := th.SystemAdminClient.GetJobLineage(context.Background(), followUp.Id)
+	require.NoError(t, err)
+	require.Len(t, lineage, 3)
+	assert.Equal(t, root.Id, lineage[0].Id)
+	assert.Equal(t, retry.Id, lineage[1].Id)
+	assert.Equal(t, 1, lineage[1].RetryCount)
+	assert.Equal(t, followUp.Id, lineage[2].Id)
+	assert.Equal(t, retry.Id, lineage[2].ParentId)
+
+	_, resp, err = th.SystemAdminClient.GetJobLineage(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
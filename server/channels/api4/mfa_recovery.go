@@ -0,0 +1,195 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitMfaRecovery() {
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.APISessionRequiredMfa(getMfaRecoveryCodesStatus)).Methods("GET")
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.APISessionRequiredMfa(generateMfaRecoveryCodes)).Methods("POST")
+
+	api.BaseRoutes.Users.Handle("/mfa/reset/request", api.RateLimitedHandler("mfa_reset_request", api.APIHandler(requestMfaReset), model.RateLimitSettings{PerSec: model.NewInt(1), MaxBurst: model.NewInt(3)})).Methods("POST")
+	api.BaseRoutes.Users.Handle("/mfa/reset/confirm", api.APIHandler(confirmMfaResetRequest)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/mfa/reset/requests", api.APISessionRequired(getMfaResetRequests)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/mfa/reset/requests/{mfa_reset_request_id:[A-Za-z0-9]+}/approve", api.APISessionRequired(approveMfaResetRequest)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/mfa/reset/requests/{mfa_reset_request_id:[A-Za-z0-9]+}/deny", api.APISessionRequired(denyMfaResetRequest)).Methods("POST")
+}
+
+func getMfaRecoveryCodesStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	status, appErr := c.App.GetMfaRecoveryCodesStatus(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func generateMfaRecoveryCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("generateMfaRecoveryCodes", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+
+	codes, appErr := c.App.GenerateMfaRecoveryCodes(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(map[string]any{"recovery_codes": codes}); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func requestMfaReset(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+	email := props["email"]
+	if email == "" {
+		c.SetInvalidParam("email")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("requestMfaReset", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "email", email)
+
+	if appErr := c.App.RequestMfaReset(c.AppContext, email); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func confirmMfaResetRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+	token := props["token"]
+	if len(token) != model.TokenSize {
+		c.SetInvalidParam("token")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("confirmMfaResetRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	request, appErr := c.App.ConfirmMfaResetRequest(c.AppContext, token)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(request)
+	auditRec.AddEventObjectType("mfa_reset_request")
+
+	ReturnStatusOK(w)
+}
+
+func getMfaResetRequests(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementUsers) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementUsers)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.MfaResetRequestStatusPending, model.MfaResetRequestStatusApproved, model.MfaResetRequestStatusDenied:
+	default:
+		c.SetInvalidURLParam("status")
+		return
+	}
+
+	requests, appErr := c.App.GetMfaResetRequests(status, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(requests); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func approveMfaResetRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	resolveMfaResetRequest(c, w, true)
+}
+
+func denyMfaResetRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	resolveMfaResetRequest(c, w, false)
+}
+
+func resolveMfaResetRequest(c *Context, w http.ResponseWriter, approve bool) {
+	c.RequireMfaResetRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("resolveMfaResetRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "mfa_reset_request_id", c.Params.MfaResetRequestId)
+	audit.AddEventParameter(auditRec, "approve", approve)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementUsers) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementUsers)
+		return
+	}
+
+	request, appErr := c.App.GetMfaResetRequest(c.Params.MfaResetRequestId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// Resetting MFA on a system admin takes over their account, so it needs the
+	// permissions of one.
+	user, appErr := c.App.GetUser(request.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if user.IsSystemAdmin() && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	request, appErr = c.App.ResolveMfaResetRequest(c.AppContext, c.Params.MfaResetRequestId, approve, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(request)
+	auditRec.AddEventObjectType("mfa_reset_request")
+	c.LogAuditWithUserId(request.UserId, "status="+request.Status)
+
+	if err := json.NewEncoder(w).Encode(request); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, resp, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	require.NoError(t, th.Server.Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	codes, _, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Len(t, codes, model.MfaRecoveryCodeCount)
+
+	status, _, err := th.Client.GetMfaRecoveryCodesStatus(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MfaRecoveryCodeCount, status.Remaining)
+
+	_, resp, err = th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.Client.GetMfaRecoveryCodesStatus(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("login with a recovery code", func(t *testing.T) {
+		client := th.CreateClient()
+
+		_, resp, err := client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, "")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+
+		_, _, err = client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes[0])
+		require.NoError(t, err)
+
+		_, resp, err = th.CreateClient().LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes[0])
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestMfaResetRequests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	client := th.CreateClient()
+
+	_, err := client.RequestMfaReset(context.Background(), th.BasicUser.Email)
+	require.NoError(t, err)
+
+	_, err = client.RequestMfaReset(context.Background(), "unknown@example.com")
+	require.NoError(t, err)
+
+	resp, err := client.RequestMfaReset(context.Background(), "")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	resp, err = client.ConfirmMfaResetRequest(context.Background(), model.NewRandomString(model.TokenSize))
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	request, err := th.App.Srv().Store().MfaResetRequest().Save(&model.MfaResetRequest{UserId: th.BasicUser.Id})
+	require.NoError(t, err)
+
+	_, resp, err = th.Client.GetMfaResetRequests(context.Background(), "", 0, 100)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.Client.ApproveMfaResetRequest(context.Background(), request.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.GetMfaResetRequests(context.Background(), "unknown", 0, 100)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	requests, _, err := th.SystemAdminClient.GetMfaResetRequests(context.Background(), model.MfaResetRequestStatusPending, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, request.Id, requests[0].Id)
+
+	denied, _, err := th.SystemAdminClient.DenyMfaResetRequest(context.Background(), request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MfaResetRequestStatusDenied, denied.Status)
+	assert.Equal(t, th.SystemAdminUser.Id, denied.ResolvedBy)
+
+	_, resp, err = th.SystemAdminClient.ApproveMfaResetRequest(context.Background(), request.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.ApproveMfaResetRequest(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+	t.Run("a user manager can't reset a system admin", func(t *testing.T) {
+		th.AddPermissionToRole(model.PermissionSysconsoleWriteUserManagementUsers.Id, model.SystemUserManagerRoleId)
+		_, appErr := th.App.UpdateUserRoles(th.Context, th.BasicUser2.Id, model.SystemUserRoleId+" "+model.SystemUserManagerRoleId, false)
+		require.Nil(t, appErr)
+		client := th.CreateClient()
+		_, _, err := client.Login(context.Background(), th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		adminRequest, err := th.App.Srv().Store().MfaResetRequest().Save(&model.MfaResetRequest{UserId: th.SystemAdminUser.Id})
+		require.NoError(t, err)
+
+		_, resp, err := client.ApproveMfaResetRequest(context.Background(), adminRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.DenyMfaResetRequest(context.Background(), adminRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		userRequest, err := th.App.Srv().Store().MfaResetRequest().Save(&model.MfaResetRequest{UserId: th.BasicUser.Id})
+		require.NoError(t, err)
+
+		denied, _, err := client.DenyMfaResetRequest(context.Background(), userRequest.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.MfaResetRequestStatusDenied, denied.Status)
+	})
+}
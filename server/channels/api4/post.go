@@ -784,9 +784,18 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 		includeDeletedChannels = *params.IncludeDeletedChannels
 	}
 
+	orderBy := ""
+	if params.OrderBy != nil {
+		orderBy = *params.OrderBy
+		if orderBy != model.SearchOrderByDate && orderBy != model.SearchOrderByRelevance {
+			c.SetInvalidParam("order_by")
+			return
+		}
+	}
+
 	startTime := time.Now()
 
-	results, err := c.App.SearchPostsForUser(c.AppContext, terms, c.AppContext.Session().UserId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	results, err := c.App.SearchPostsForUser(c.AppContext, terms, c.AppContext.Session().UserId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, orderBy, page, perPage)
 
 	elapsedTime := float64(time.Since(startTime)) / float64(time.Second)
 	metrics := c.App.Metrics()
@@ -807,7 +816,9 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 		return
 	}
 
+	highlights := results.Highlights
 	results = model.MakePostSearchResults(clientPostList, results.Matches)
+	results.Highlights = highlights
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	if err := results.EncodeJSON(w); err != nil {
@@ -18,6 +18,8 @@ func (api *API) InitReports() {
 	api.BaseRoutes.Reports.Handle("/users", api.APISessionRequired(getUsersForReporting)).Methods("GET")
 	api.BaseRoutes.Reports.Handle("/users/count", api.APISessionRequired(getUserCountForReporting)).Methods("GET")
 	api.BaseRoutes.Reports.Handle("/users/export", api.APISessionRequired(startUsersBatchExport)).Methods("POST")
+	api.BaseRoutes.Reports.Handle("/channel_membership/export", api.APISessionRequired(startChannelMembershipReport)).Methods("POST")
+	api.BaseRoutes.Reports.Handle("/team_activity/export", api.APISessionRequired(startTeamActivityReport)).Methods("POST")
 }
 
 func getUsersForReporting(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -86,7 +88,47 @@ func startUsersBatchExport(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	startAt, endAt := model.GetReportDateRange(dateRange, time.Now())
-	if err := c.App.StartUsersBatchExport(c.AppContext, dateRange, startAt, endAt); err != nil {
+	if err := c.App.StartUsersBatchExport(c.AppContext, r.URL.Query().Get("format"), dateRange, startAt, endAt); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func startChannelMembershipReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !(c.IsSystemAdmin()) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementChannels)
+		return
+	}
+
+	teamID := r.URL.Query().Get("team_id")
+	if !(teamID == "" || model.IsValidId(teamID)) {
+		c.SetInvalidURLParam("team_id")
+		return
+	}
+
+	if err := c.App.StartChannelMembershipReport(c.AppContext, r.URL.Query().Get("format"), teamID); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func startTeamActivityReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !(c.IsSystemAdmin()) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementTeams)
+		return
+	}
+
+	dateRange := r.URL.Query().Get("date_range")
+	if dateRange == "" {
+		dateRange = "all_time"
+	}
+
+	startAt, endAt := model.GetReportDateRange(dateRange, time.Now())
+	if err := c.App.StartTeamActivityReport(c.AppContext, r.URL.Query().Get("format"), dateRange, startAt, endAt); err != nil {
 		c.Err = err
 		return
 	}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitScheduledPosts() {
+	api.BaseRoutes.Posts.Handle("/schedule", api.APISessionRequired(createScheduledPost)).Methods("POST")
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(updateScheduledPost)).Methods("PUT")
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteScheduledPost)).Methods("DELETE")
+	api.BaseRoutes.Posts.Handle("/scheduled/team/{team_id:[A-Za-z0-9]+}", api.APISessionRequired(getUserScheduledPosts)).Methods("GET")
+}
+
+// checkScheduledPostPermissions checks that the user of the session can post the scheduled
+// post. The permissions are checked again when the post is sent.
+func checkScheduledPostPermissions(c *Context, scheduledPost *model.ScheduledPost) {
+	hasPermission := false
+	if c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), scheduledPost.ChannelId, model.PermissionCreatePost) {
+		hasPermission = true
+	} else if channel, err := c.App.GetChannel(c.AppContext, scheduledPost.ChannelId); err == nil {
+		if channel.Type == model.ChannelTypeOpen && c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), channel.TeamId, model.PermissionCreatePostPublic) {
+			hasPermission = true
+		}
+	}
+
+	if !hasPermission {
+		c.SetPermissionError(model.PermissionCreatePost)
+		return
+	}
+
+	if len(scheduledPost.Priority) > 0 {
+		if !c.App.IsPostPriorityEnabled() {
+			c.Err = model.NewAppError("checkScheduledPostPermissions", "api.post.post_priority.priority_post_not_allowed_for_user.request_error", nil, "userId="+c.AppContext.Session().UserId, http.StatusForbidden)
+			return
+		}
+
+		if scheduledPost.RootId != "" {
+			c.Err = model.NewAppError("checkScheduledPostPermissions", "api.post.post_priority.priority_post_only_allowed_for_root_post.request_error", nil, "", http.StatusBadRequest)
+			return
+		}
+	}
+}
+
+func createScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scheduledPost model.ScheduledPost
+	if jsonErr := json.NewDecoder(r.Body).Decode(&scheduledPost); jsonErr != nil {
+		c.SetInvalidParamWithErr("scheduled_post", jsonErr)
+		return
+	}
+	scheduledPost.UserId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createScheduledPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "scheduled_post", &scheduledPost)
+
+	checkScheduledPostPermissions(c, &scheduledPost)
+	if c.Err != nil {
+		return
+	}
+
+	saved, appErr := c.App.SaveScheduledPost(c.AppContext, &scheduledPost, r.Header.Get(model.ConnectionId))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(saved)
+	auditRec.AddEventObjectType("scheduled_post")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireScheduledPostId()
+	if c.Err != nil {
+		return
+	}
+
+	var scheduledPost model.ScheduledPost
+	if jsonErr := json.NewDecoder(r.Body).Decode(&scheduledPost); jsonErr != nil {
+		c.SetInvalidParamWithErr("scheduled_post", jsonErr)
+		return
+	}
+
+	if scheduledPost.Id != c.Params.ScheduledPostId {
+		c.SetInvalidParam("id")
+		return
+	}
+	scheduledPost.UserId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("updateScheduledPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "scheduled_post", &scheduledPost)
+
+	existing, appErr := c.App.GetScheduledPost(c.Params.ScheduledPostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if existing.UserId != c.AppContext.Session().UserId {
+		c.SetPermissionError(model.PermissionEditPost)
+		return
+	}
+	auditRec.AddEventPriorState(existing)
+
+	scheduledPost.ChannelId = existing.ChannelId
+	scheduledPost.RootId = existing.RootId
+	checkScheduledPostPermissions(c, &scheduledPost)
+	if c.Err != nil {
+		return
+	}
+
+	updated, appErr := c.App.UpdateScheduledPost(c.AppContext, &scheduledPost, r.Header.Get(model.ConnectionId))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updated)
+	auditRec.AddEventObjectType("scheduled_post")
+
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireScheduledPostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteScheduledPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "scheduled_post_id", c.Params.ScheduledPostId)
+
+	deleted, appErr := c.App.DeleteScheduledPost(c.AppContext, c.AppContext.Session().UserId, c.Params.ScheduledPostId, r.Header.Get(model.ConnectionId))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventPriorState(deleted)
+	auditRec.AddEventObjectType("scheduled_post")
+
+	if err := json.NewEncoder(w).Encode(deleted); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getUserScheduledPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionViewTeam) {
+		c.SetPermissionError(model.PermissionViewTeam)
+		return
+	}
+
+	scheduledPosts, appErr := c.App.GetScheduledPostsForUser(c.AppContext, c.AppContext.Session().UserId, c.Params.TeamId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(scheduledPosts); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestScheduledPost(channelID string, scheduledAt int64) *model.ScheduledPost {
+	return &model.ScheduledPost{
+		Draft: model.Draft{
+			ChannelId: channelID,
+			Message:   "scheduled message",
+		},
+		ScheduledAt: scheduledAt,
+	}
+}
+
+func TestCreateScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	scheduledPost := newTestScheduledPost(th.BasicChannel.Id, model.GetMillis()+60*60*1000)
+
+	t.Run("create scheduled post", func(t *testing.T) {
+		created, resp, err := client.CreateScheduledPost(context.Background(), scheduledPost)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.NotEmpty(t, created.Id)
+		assert.Equal(t, th.BasicUser.Id, created.UserId)
+		assert.Equal(t, scheduledPost.Message, created.Message)
+		assert.Equal(t, scheduledPost.ScheduledAt, created.ScheduledAt)
+	})
+
+	t.Run("schedule in the past", func(t *testing.T) {
+		past := newTestScheduledPost(th.BasicChannel.Id, model.GetMillis()-60*60*1000)
+		_, resp, err := client.CreateScheduledPost(context.Background(), past)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel without permission", func(t *testing.T) {
+		private := th.CreatePrivateChannel()
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		noPermission := newTestScheduledPost(private.Id, model.GetMillis()+60*60*1000)
+		_, resp, err := client.CreateScheduledPost(context.Background(), noPermission)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("feature disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = true })
+
+		_, resp, err := client.CreateScheduledPost(context.Background(), scheduledPost)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestUpdateAndDeleteScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	created, _, err := client.CreateScheduledPost(context.Background(), newTestScheduledPost(th.BasicChannel.Id, model.GetMillis()+60*60*1000))
+	require.NoError(t, err)
+
+	t.Run("list scheduled posts", func(t *testing.T) {
+		scheduledPosts, _, err := client.GetUserScheduledPosts(context.Background(), th.BasicTeam.Id)
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Equal(t, created.Id, scheduledPosts[0].Id)
+	})
+
+	t.Run("update another user's scheduled post", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		update := newTestScheduledPost(th.BasicChannel.Id, created.ScheduledAt)
+		update.Id = created.Id
+		update.Message = "updated message"
+		_, resp, err := client.UpdateScheduledPost(context.Background(), update)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.DeleteScheduledPost(context.Background(), created.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("update scheduled post", func(t *testing.T) {
+		update := newTestScheduledPost(th.BasicChannel.Id, model.GetMillis()+2*60*60*1000)
+		update.Id = created.Id
+		update.Message = "updated message"
+
+		updated, _, err := client.UpdateScheduledPost(context.Background(), update)
+		require.NoError(t, err)
+		assert.Equal(t, "updated message", updated.Message)
+		assert.Equal(t, update.ScheduledAt, updated.ScheduledAt)
+	})
+
+	t.Run("delete scheduled post", func(t *testing.T) {
+		deleted, _, err := client.DeleteScheduledPost(context.Background(), created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, created.Id, deleted.Id)
+
+		scheduledPosts, _, err := client.GetUserScheduledPosts(context.Background(), th.BasicTeam.Id)
+		require.NoError(t, err)
+		assert.Empty(t, scheduledPosts)
+	})
+}
@@ -43,6 +43,9 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/timezones", api.APISessionRequired(getSupportedTimezones)).Methods("GET")
 
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/audits/records", api.APISessionRequired(getAuditRecords)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/audits/checkpoints", api.APISessionRequired(getAuditCheckpoints)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/audits/signing_key", api.APISessionRequired(getAuditSigningKey)).Methods("GET")
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods("POST")
 	api.BaseRoutes.APIRoot.Handle("/site_url/test", api.APISessionRequired(testSiteURL)).Methods("POST")
 	api.BaseRoutes.APIRoot.Handle("/file/s3_test", api.APISessionRequired(testS3)).Methods("POST")
@@ -296,6 +299,102 @@ func getAudits(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getAuditRecords(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := &model.AuditRecordFilter{
+		ChainId:   query.Get("chain_id"),
+		UserId:    query.Get("user_id"),
+		EventName: query.Get("event_name"),
+		Page:      c.Params.Page,
+		PerPage:   c.Params.PerPage,
+	}
+
+	if filter.ChainId != "" && !model.IsValidId(filter.ChainId) {
+		c.SetInvalidURLParam("chain_id")
+		return
+	}
+
+	for param, value := range map[string]*int64{"since": &filter.Since, "until": &filter.Until} {
+		if query.Get(param) == "" {
+			continue
+		}
+
+		millis, err := strconv.ParseInt(query.Get(param), 10, 64)
+		if err != nil || millis < 0 {
+			c.SetInvalidURLParam(param)
+			return
+		}
+		*value = millis
+	}
+
+	auditRec := c.MakeAuditRecord("getAuditRecords", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "chain_id", filter.ChainId)
+	audit.AddEventParameter(auditRec, "user_id", filter.UserId)
+	audit.AddEventParameter(auditRec, "event_name", filter.EventName)
+	audit.AddEventParameter(auditRec, "since", filter.Since)
+	audit.AddEventParameter(auditRec, "until", filter.Until)
+	audit.AddEventParameter(auditRec, "page", filter.Page)
+	audit.AddEventParameter(auditRec, "per_page", filter.PerPage)
+
+	records, appErr := c.App.GetAuditRecords(filter)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(records); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getAuditCheckpoints(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	chainID := r.URL.Query().Get("chain_id")
+	if chainID != "" && !model.IsValidId(chainID) {
+		c.SetInvalidURLParam("chain_id")
+		return
+	}
+
+	checkpoints, appErr := c.App.GetAuditCheckpoints(chainID, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(checkpoints); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getAuditSigningKey(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	signingKey, appErr := c.App.GetAuditSigningKey()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(signingKey); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func databaseRecycle(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionRecycleDatabaseConnections) {
 		c.SetPermissionError(model.PermissionRecycleDatabaseConnections)
@@ -557,6 +656,13 @@ func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	if *cfg.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		cfg.FileSettings.AmazonS3SecretAccessKey = c.App.Config().FileSettings.AmazonS3SecretAccessKey
 	}
+	if *cfg.FileSettings.AzureAccessKey == model.FakeSetting {
+		cfg.FileSettings.AzureAccessKey = c.App.Config().FileSettings.AzureAccessKey
+	}
+	if *cfg.FileSettings.EncryptionAtRestKey == model.FakeSetting {
+		cfg.FileSettings.EncryptionAtRestKey = c.App.Config().FileSettings.EncryptionAtRestKey
+	}
+	cfg.FileSettings.EncryptionAtRestRetiredKeys = c.App.Config().FileSettings.EncryptionAtRestRetiredKeys
 
 	appErr = c.App.TestFileStoreConnectionWithConfig(&cfg.FileSettings)
 	if appErr != nil {
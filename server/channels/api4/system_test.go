@@ -6,8 +6,13 @@ package api4
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
@@ -125,6 +130,104 @@ func TestGetAudits(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetAuditRecords(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("requires the database sink", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetAuditRecords(context.Background(), &model.AuditRecordFilter{PerPage: 100})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "audit_signing_key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ExperimentalAuditSettings.DatabaseEnabled = true
+		*cfg.ExperimentalAuditSettings.CheckpointInterval = 2
+		*cfg.ExperimentalAuditSettings.CheckpointSigningKeyFile = keyFile
+	})
+	chainID := th.App.Srv().Audit.ChainID()
+	require.NotEmpty(t, chainID)
+
+	since := model.GetMillis()
+	_, err = th.SystemAdminClient.UpdateUserActive(context.Background(), th.BasicUser2.Id, false)
+	require.NoError(t, err)
+	_, err = th.SystemAdminClient.UpdateUserActive(context.Background(), th.BasicUser2.Id, true)
+	require.NoError(t, err)
+	require.NoError(t, th.App.Srv().Audit.Flush())
+
+	t.Run("filters the records", func(t *testing.T) {
+		records, _, err := th.SystemAdminClient.GetAuditRecords(context.Background(), &model.AuditRecordFilter{
+			ChainId:   chainID,
+			UserId:    th.SystemAdminUser.Id,
+			EventName: "updateUserActive",
+			Since:     since,
+			PerPage:   100,
+		})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		for _, record := range records {
+			assert.Equal(t, "updateUserActive", record.EventName)
+			assert.Equal(t, th.SystemAdminUser.Id, record.UserId)
+		}
+
+		records, _, err = th.SystemAdminClient.GetAuditRecords(context.Background(), &model.AuditRecordFilter{
+			EventName: "updateUserActive",
+			Until:     since - 1,
+			PerPage:   100,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("the records and checkpoints verify", func(t *testing.T) {
+		// Checkpoints are fetched first, for the records to include those they were taken of.
+		checkpoints, _, err := th.SystemAdminClient.GetAuditCheckpoints(context.Background(), chainID, 0, 200)
+		require.NoError(t, err)
+		require.NotEmpty(t, checkpoints)
+		records, _, err := th.SystemAdminClient.GetAuditRecords(context.Background(), &model.AuditRecordFilter{ChainId: chainID, PerPage: 200})
+		require.NoError(t, err)
+
+		// The checkpoints are signed with the key of the configured file.
+		signingKey, _, err := th.SystemAdminClient.GetAuditSigningKey(context.Background())
+		require.NoError(t, err)
+		publicKey, err := signingKey.ECDSAPublicKey()
+		require.NoError(t, err)
+		require.True(t, key.PublicKey.Equal(publicKey))
+
+		reports := model.VerifyAuditChains(records, checkpoints, &key.PublicKey)
+		require.Len(t, reports, 1)
+		assert.True(t, reports[0].IsValid(), reports[0].Errors)
+		assert.True(t, reports[0].Complete)
+	})
+
+	t.Run("rejects invalid filters", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetAuditRecords(context.Background(), &model.AuditRecordFilter{ChainId: "junk"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetAuditRecords(context.Background(), &model.AuditRecordFilter{PerPage: 100})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetAuditCheckpoints(context.Background(), "", 0, 100)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetAuditSigningKey(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestEmailTest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
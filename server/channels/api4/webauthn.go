@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitWebAuthn() {
+	api.BaseRoutes.User.Handle("/webauthn/register/begin", api.APISessionRequiredMfa(beginWebAuthnRegistration)).Methods("POST")
+	api.BaseRoutes.User.Handle("/webauthn/register/finish", api.APISessionRequiredMfa(finishWebAuthnRegistration)).Methods("POST")
+	api.BaseRoutes.User.Handle("/webauthn/credentials", api.APISessionRequiredMfa(getWebAuthnCredentials)).Methods("GET")
+	api.BaseRoutes.User.Handle("/webauthn/credentials/{credential_id:[A-Za-z0-9]+}", api.APISessionRequiredMfa(deleteWebAuthnCredential)).Methods("DELETE")
+
+	api.BaseRoutes.Users.Handle("/webauthn/login/begin", api.RateLimitedHandler("webauthn_login_begin", api.APIHandler(beginWebAuthnLogin), model.RateLimitSettings{PerSec: model.NewInt(2), MaxBurst: model.NewInt(5)})).Methods("POST")
+}
+
+// checkMfaPermissions checks that the session, which must not be that of an OAuth
+// app, can manage the second factors of the user in the URL.
+func checkMfaPermissions(c *Context) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+}
+
+func beginWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnRegistration(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func finishWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	var registration model.WebAuthnRegistration
+	if jsonErr := json.NewDecoder(r.Body).Decode(&registration); jsonErr != nil {
+		c.SetInvalidParamWithErr("registration", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("finishWebAuthnRegistration", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+	audit.AddEventParameter(auditRec, "name", registration.Name)
+
+	credential, appErr := c.App.FinishWebAuthnRegistration(c.AppContext, c.Params.UserId, &registration)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(credential)
+	auditRec.AddEventObjectType("webauthn_credential")
+	c.LogAudit("success")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(credential); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getWebAuthnCredentials(c *Context, w http.ResponseWriter, r *http.Request) {
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	credentials, appErr := c.App.GetWebAuthnCredentials(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(credentials); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteWebAuthnCredential(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireWebAuthnCredentialId()
+	checkMfaPermissions(c)
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteWebAuthnCredential", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+	audit.AddEventParameter(auditRec, "credential_id", c.Params.WebAuthnCredentialId)
+
+	credential, appErr := c.App.DeleteWebAuthnCredential(c.Params.UserId, c.Params.WebAuthnCredentialId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventPriorState(credential)
+	auditRec.AddEventObjectType("webauthn_credential")
+	c.LogAudit("success")
+
+	ReturnStatusOK(w)
+}
+
+func beginWebAuthnLogin(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+	loginID := props["login_id"]
+	if loginID == "" {
+		c.SetInvalidParam("login_id")
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnLogin(c.AppContext, loginID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
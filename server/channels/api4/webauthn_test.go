@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBeginWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = false })
+
+	_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	})
+
+	options, _, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", options.Rp.Id)
+	assert.NotEmpty(t, options.Challenge)
+	assert.NotEmpty(t, options.PubKeyCredParams)
+
+	_, resp, err = th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, _, err = th.SystemAdminClient.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+
+	session, _ := th.App.GetSession(th.Client.AuthToken)
+	session.IsOAuth = true
+	th.App.AddSessionToCache(session)
+
+	_, resp, err = th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.Client.Logout(context.Background())
+
+	_, resp, err = th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestFinishWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	})
+
+	registration := &model.WebAuthnRegistration{
+		Name: "Key",
+		Response: model.WebAuthnAttestationResponse{
+			ClientDataJSON:    model.EncodeWebAuthnBase64([]byte(`{"type":"webauthn.create","challenge":"unknown","origin":"http://localhost:8065"}`)),
+			AttestationObject: model.EncodeWebAuthnBase64([]byte{0xa0}),
+		},
+	}
+
+	_, resp, err := th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser.Id, registration)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser2.Id, registration)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestWebAuthnCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	credential, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       th.BasicUser.Id,
+		Name:         "Key",
+		CredentialId: model.EncodeWebAuthnBase64([]byte(model.NewId())),
+		PublicKey:    []byte{0xa0},
+	})
+	require.NoError(t, err)
+
+	credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	assert.Equal(t, credential.Id, credentials[0].Id)
+	assert.Empty(t, credentials[0].PublicKey)
+
+	_, resp, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser2.Id, credential.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = th.SystemAdminClient.DeleteWebAuthnCredential(context.Background(), th.BasicUser2.Id, credential.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, err = th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser.Id, credential.Id)
+	require.NoError(t, err)
+
+	credentials, _, err = th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func TestBeginWebAuthnLogin(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	})
+
+	_, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       th.BasicUser.Id,
+		Name:         "Key",
+		CredentialId: model.EncodeWebAuthnBase64([]byte(model.NewId())),
+		PublicKey:    []byte{0xa0},
+	})
+	require.NoError(t, err)
+
+	client := th.CreateClient()
+
+	options, _, err := client.BeginWebAuthnLogin(context.Background(), th.BasicUser.Email)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", options.RpId)
+	assert.Len(t, options.AllowCredentials, 1)
+
+	options, _, err = client.BeginWebAuthnLogin(context.Background(), th.BasicUser2.Email)
+	require.NoError(t, err)
+	assert.NotEmpty(t, options.Challenge)
+	assert.Empty(t, options.AllowCredentials)
+
+	_, resp, err := client.BeginWebAuthnLogin(context.Background(), "")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	t.Run("login requires the security key", func(t *testing.T) {
+		_, resp, err := client.Login(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
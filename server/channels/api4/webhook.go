@@ -25,6 +25,8 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(updateOutgoingHook)).Methods("PUT")
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(deleteOutgoingHook)).Methods("DELETE")
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.APISessionRequired(regenOutgoingHookToken)).Methods("POST")
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.APISessionRequired(getOutgoingHookDeliveries)).Methods("GET")
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries/{delivery_id:[A-Za-z0-9]+}/replay", api.APISessionRequired(replayOutgoingHookDelivery)).Methods("POST")
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -603,6 +605,94 @@ func regenOutgoingHookToken(c *Context, w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func getOutgoingHookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return
+	}
+
+	deliveries, err := c.App.GetOutgoingWebhookDeliveries(hook.Id, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func replayOutgoingHookDelivery(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId().RequireDeliveryId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("replayOutgoingHookDelivery", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "hook_id", c.Params.HookId)
+	audit.AddEventParameter(auditRec, "delivery_id", c.Params.DeliveryId)
+	auditRec.AddMeta("hook_display", hook.DisplayName)
+	auditRec.AddMeta("channel_id", hook.ChannelId)
+	auditRec.AddMeta("team_id", hook.TeamId)
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.LogAudit("fail - inappropriate permissions")
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return
+	}
+
+	delivery, err := c.App.GetOutgoingWebhookDelivery(c.Params.DeliveryId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	replay, err := c.App.ReplayOutgoingWebhookDelivery(c.AppContext, hook, delivery)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(replay)
+	auditRec.AddEventObjectType("outgoing_webhook_delivery")
+	auditRec.Success()
+	c.LogAudit("success")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(replay); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
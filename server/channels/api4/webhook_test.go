@@ -994,6 +994,67 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestOutgoingHookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	delivery, err := th.App.Srv().Store().Webhook().SaveOutgoingDelivery(&model.OutgoingWebhookDelivery{
+		HookId:      rhook.Id,
+		ChannelId:   th.BasicChannel.Id,
+		CallbackURL: "http://nowhere.com",
+		ContentType: "application/json",
+		Payload:     "{}",
+		Status:      model.OutgoingWebhookDeliveryStatusFailed,
+		Attempts:    6,
+	})
+	require.NoError(t, err)
+
+	t.Run("get deliveries", func(t *testing.T) {
+		deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, delivery.Id, deliveries[0].Id)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status)
+
+		_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), "junk", 0, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("replay delivery", func(t *testing.T) {
+		_, resp, err := client.ReplayOutgoingWebhookDelivery(context.Background(), rhook.Id, delivery.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), rhook.Id, model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		replay, resp, err := th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), rhook.Id, delivery.Id)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.NotEqual(t, delivery.Id, replay.Id)
+		assert.Equal(t, delivery.Payload, replay.Payload)
+		assert.Equal(t, rhook.Id, replay.HookId)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
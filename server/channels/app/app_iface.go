@@ -37,8 +37,56 @@ import (
 
 // AppIface is extracted from App struct and contains all it's exported methods. It's provided to allow partial interface passing and app layers creation.
 type AppIface interface {
+	// BeginWebAuthnLogin returns the options for a user to sign in with one of their security
+	// keys. The same options are returned for users without security keys, and for unknown
+	// users, so as not to disclose which of them exist.
+	BeginWebAuthnLogin(rctx request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError)
+	// BeginWebAuthnRegistration returns the options for the user to register a new security
+	// key with.
+	BeginWebAuthnRegistration(userID string) (*model.WebAuthnCreationOptions, *model.AppError)
+	// BulkImportReport resolves every line of an import file against the existing data and
+	// reports what importing it would change, without writing anything. Invalid lines and
+	// missing references are part of the report rather than errors, so that a single run
+	// finds all of them.
+	BulkImportReport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.BulkImportReport, *model.AppError)
+	// ConfirmMfaResetRequest creates the MFA reset request of the user the token was emailed
+	// to, pending until an admin approves or denies it.
+	ConfirmMfaResetRequest(rctx request.CTX, tokenString string) (*model.MfaResetRequest, *model.AppError)
+	// CreateBleveReindexJob creates a Bleve indexing job restricted to the time range and, when
+	// set, to the channel of the request.
+	CreateBleveReindexJob(c request.CTX, req *model.BleveReindexRequest) (*model.Job, *model.AppError)
+	// DeleteWebAuthnCredential removes one of the security keys of a user.
+	DeleteWebAuthnCredential(userID, credentialID string) (*model.WebAuthnCredential, *model.AppError)
 	// @openTracingParams args
 	ExecuteCommand(c request.CTX, args *model.CommandArgs) (*model.CommandResponse, *model.AppError)
+	// FinishWebAuthnRegistration verifies the credential created by a security key in
+	// response to BeginWebAuthnRegistration and saves it.
+	FinishWebAuthnRegistration(rctx request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError)
+	// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones, returned
+	// in clear for the user to write down: only their hashes are kept.
+	GenerateMfaRecoveryCodes(rctx request.CTX, userID string) ([]string, *model.AppError)
+	// GetAuditCheckpoints returns the checkpoints of the audit records written to the
+	// database, of a chain or of all of them if chainID is empty.
+	GetAuditCheckpoints(chainID string, page, perPage int) ([]*model.AuditCheckpoint, *model.AppError)
+	// GetAuditRecords returns the audit records written to the database matching the filter.
+	GetAuditRecords(filter *model.AuditRecordFilter) ([]*model.AuditRecord, *model.AppError)
+	// GetAuditSigningKey returns the public key audit checkpoints are signed with.
+	GetAuditSigningKey() (*model.AuditSigningKey, *model.AppError)
+	// GetMfaRecoveryCodesStatus returns how many unused recovery codes a user has left.
+	GetMfaRecoveryCodesStatus(userID string) (*model.MfaRecoveryCodesStatus, *model.AppError)
+	// GetMfaResetRequest returns an MFA reset request.
+	GetMfaResetRequest(requestID string) (*model.MfaResetRequest, *model.AppError)
+	// GetMfaResetRequests returns the MFA reset requests with the given status, or all of them
+	// if it's empty, most recent first.
+	GetMfaResetRequests(status string, page, perPage int) ([]*model.MfaResetRequest, *model.AppError)
+	// GetWebAuthnCredentials returns the security keys registered by a user.
+	GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError)
+	// HandleInboundEmail posts a reply to a notification email as a reply to the thread of
+	// the post it notified of. The reply is posted by the user the notification was sent to,
+	// who must also be the author of the reply.
+	HandleInboundEmail(c request.CTX, recipients []string, data []byte) *model.AppError
+	// HasWebAuthnCredentials returns whether a user has registered a security key.
+	HasWebAuthnCredentials(userID string) (bool, *model.AppError)
 	// @openTracingParams teamID
 	// previous ListCommands now ListAutocompleteCommands
 	ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
@@ -87,6 +135,21 @@ type AppIface interface {
 	ConvertBotToUser(c request.CTX, bot *model.Bot, userPatch *model.UserPatch, sysadmin bool) (*model.User, *model.AppError)
 	// ConvertUserToBot converts a user to bot.
 	ConvertUserToBot(rctx request.CTX, user *model.User) (*model.Bot, *model.AppError)
+	// RegenerateFilePreviews generates again the thumbnail, preview and mini preview of an
+	// image file, setting the preview paths of images uploaded before they could be decoded.
+	RegenerateFilePreviews(rctx request.CTX, fileInfo *model.FileInfo) error
+	// RemoveRemoteUserFromChannel removes a user belonging to a remote cluster from a shared channel.
+	// Unlike RemoveUserFromChannel no system message is posted; the remote's own message is
+	// synchronized along with the channel's posts.
+	RemoveRemoteUserFromChannel(c request.CTX, userID string, channel *model.Channel) *model.AppError
+	// RequestMfaReset emails a user who lost their second factor a link to confirm they want
+	// an admin to reset MFA on their account. Nothing is disclosed about whether the email
+	// matches a user with MFA.
+	RequestMfaReset(rctx request.CTX, email string) *model.AppError
+	// ResolveMfaResetRequest approves or denies a pending MFA reset request. Approving it
+	// removes every second factor of the user: their TOTP secret, recovery codes and security
+	// keys.
+	ResolveMfaResetRequest(rctx request.CTX, requestID string, approve bool, resolvedBy string) (*model.MfaResetRequest, *model.AppError)
 	// Create/ Update a subscription history event
 	SendSubscriptionHistoryEvent(userID string) (*model.SubscriptionHistory, error)
 	// CreateBot creates the given bot and corresponding user.
@@ -302,6 +365,11 @@ type AppIface interface {
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	// ProcessPendingOutgoingWebhookDeliveries retries the outgoing webhook deliveries that
+	// are due, and deletes the delivery history past its retention period.
+	ProcessPendingOutgoingWebhookDeliveries(c request.CTX) *model.AppError
+	// ProcessScheduledPosts sends the scheduled posts that are due.
+	ProcessScheduledPosts(rctx request.CTX) *model.AppError
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c request.CTX, user *model.User, requestorId string) *model.AppError
@@ -313,6 +381,9 @@ type AppIface interface {
 	RenameChannel(c request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// ReplayOutgoingWebhookDelivery sends the payload of a past delivery again, as a new
+	// delivery retried like any other, so that the history of the original one is kept.
+	ReplayOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError)
 	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
 	// Post-owner can only delete the original post to stop the notifications.
 	ResolvePersistentNotification(c request.CTX, post *model.Post, loggedInUserID string) *model.AppError
@@ -335,6 +406,8 @@ type AppIface interface {
 	SessionHasPermissionToTeams(c request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
+	// SetJobTypePaused pauses or resumes the given job type on the whole cluster.
+	SetJobTypePaused(jobType string, paused bool) *model.AppError
 	// SetSessionExpireInHours sets the session's expiry the specified number of hours
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
@@ -395,6 +468,9 @@ type AppIface interface {
 	UpdateDNDStatusOfUsers()
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
+	// UpdateScheduledPost edits the message, files and schedule of a scheduled post. A post
+	// which could not be sent is scheduled again.
+	UpdateScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError)
 	// UpdateSharedChannelCursor updates the cursor for the specified channelID and remoteID.
 	// This can be used to manually set the point of last sync, either forward to skip older posts,
 	// or backward to re-sync history.
@@ -579,6 +655,7 @@ type AppIface interface {
 	DeleteReactionForPost(c request.CTX, reaction *model.Reaction) *model.AppError
 	DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError)
 	DeleteRetentionPolicy(policyID string) *model.AppError
+	DeleteScheduledPost(rctx request.CTX, userID, scheduledPostID, connectionID string) (*model.ScheduledPost, *model.AppError)
 	DeleteScheme(schemeId string) (*model.Scheme, *model.AppError)
 	DeleteSharedChannelRemote(id string) (bool, error)
 	DeleteSidebarCategory(c request.CTX, userID, teamID, categoryId string) *model.AppError
@@ -638,6 +715,7 @@ type AppIface interface {
 	GetAuditsPage(rctx request.CTX, userID string, page int, perPage int) (model.Audits, *model.AppError)
 	GetAuthorizationCode(c request.CTX, w http.ResponseWriter, r *http.Request, service string, props map[string]string, loginHint string) (string, *model.AppError)
 	GetAuthorizedAppsForUser(userID string, page, perPage int) ([]*model.OAuthApp, *model.AppError)
+	GetBleveIndexStats() ([]*model.BleveIndexStats, *model.AppError)
 	GetBookmark(bookmarkId string, includeDeleted bool) (*model.ChannelBookmarkWithFileInfo, *model.AppError)
 	GetBrandImage(rctx request.CTX) ([]byte, *model.AppError)
 	GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError)
@@ -656,6 +734,7 @@ type AppIface interface {
 	GetChannelMembersPage(c request.CTX, channelID string, page, perPage int) (model.ChannelMembers, *model.AppError)
 	GetChannelMembersTimezones(c request.CTX, channelID string) ([]string, *model.AppError)
 	GetChannelMembersWithTeamDataForUserWithPagination(c request.CTX, userID string, page, perPage int) (model.ChannelMembersWithTeamData, *model.AppError)
+	GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, *model.AppError)
 	GetChannelPinnedPostCount(c request.CTX, channelID string) (int64, *model.AppError)
 	GetChannelPoliciesForUser(userID string, offset, limit int) (*model.RetentionPolicyForChannelList, *model.AppError)
 	GetChannelUnread(c request.CTX, channelID, userID string) (*model.ChannelUnread, *model.AppError)
@@ -719,6 +798,8 @@ type AppIface interface {
 	GetIncomingWebhooksPage(page, perPage int) ([]*model.IncomingWebhook, *model.AppError)
 	GetIncomingWebhooksPageByUser(userID string, page, perPage int) ([]*model.IncomingWebhook, *model.AppError)
 	GetJob(c request.CTX, id string) (*model.Job, *model.AppError)
+	GetJobLineage(c request.CTX, job *model.Job) ([]*model.Job, *model.AppError)
+	GetJobSchedules() ([]*model.JobSchedule, *model.AppError)
 	GetJobsByType(c request.CTX, jobType string, offset int, limit int) ([]*model.Job, *model.AppError)
 	GetJobsByTypeAndStatus(c request.CTX, jobTypes []string, status string, page int, perPage int) ([]*model.Job, *model.AppError)
 	GetJobsByTypePage(c request.CTX, jobType string, page int, perPage int) ([]*model.Job, *model.AppError)
@@ -749,6 +830,8 @@ type AppIface interface {
 	GetOpenGraphMetadata(requestURL string) ([]byte, error)
 	GetOrCreateDirectChannel(c request.CTX, userID, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
 	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhookDeliveries(hookID string, page, perPage int) ([]*model.OutgoingWebhookDelivery, *model.AppError)
+	GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError)
 	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPage(teamID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -801,6 +884,8 @@ type AppIface interface {
 	GetSamlMetadata(c request.CTX) (string, *model.AppError)
 	GetSamlMetadataFromIdp(idpMetadataURL string) (*model.SamlMetadataResponse, *model.AppError)
 	GetSanitizeOptions(asAdmin bool) map[string]bool
+	GetScheduledPost(scheduledPostID string) (*model.ScheduledPost, *model.AppError)
+	GetScheduledPostsForUser(rctx request.CTX, userID, teamID string) ([]*model.ScheduledPost, *model.AppError)
 	GetScheme(id string) (*model.Scheme, *model.AppError)
 	GetSchemeByName(name string) (*model.Scheme, *model.AppError)
 	GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError)
@@ -827,6 +912,7 @@ type AppIface interface {
 	GetStatusFromCache(userID string) *model.Status
 	GetSystemBot(rctx request.CTX) (*model.Bot, *model.AppError)
 	GetTeam(teamID string) (*model.Team, *model.AppError)
+	GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, *model.AppError)
 	GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError)
 	GetTeamByName(name string) (*model.Team, *model.AppError)
 	GetTeamIcon(team *model.Team) ([]byte, *model.AppError)
@@ -996,7 +1082,7 @@ type AppIface interface {
 	ProcessSlackText(text string) string
 	Publish(message *model.WebSocketEvent)
 	PublishUserTyping(userID, channelID, parentId string) *model.AppError
-	PurgeBleveIndexes(c request.CTX) *model.AppError
+	PurgeBleveIndexes(c request.CTX, indexes []string) *model.AppError
 	PurgeElasticsearchIndexes(c request.CTX, indexes []string) *model.AppError
 	QueryLogs(rctx request.CTX, page, perPage int, logFilter *model.LogFilter) (map[string][]string, *model.AppError)
 	ReadFile(path string) ([]byte, *model.AppError)
@@ -1056,6 +1142,7 @@ type AppIface interface {
 	SaveComplianceReport(rctx request.CTX, job *model.Compliance) (*model.Compliance, *model.AppError)
 	SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError
+	SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError)
 	SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error)
 	SaveUserTermsOfService(userID, termsOfServiceId string, accepted bool) *model.AppError
 	SchemesIterator(scope string, batchSize int) func() []*model.Scheme
@@ -1067,7 +1154,7 @@ type AppIface interface {
 	SearchEngine() *searchengine.Broker
 	SearchFilesInTeamForUser(c request.CTX, terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.FileInfoList, *model.AppError)
 	SearchGroupChannels(c request.CTX, userID, term string) (model.ChannelList, *model.AppError)
-	SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError)
+	SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, orderBy string, page, perPage int) (*model.PostSearchResults, *model.AppError)
 	SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) (*model.PostList, *model.AppError)
 	SearchPrivateTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError)
 	SearchPublicTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError)
@@ -1136,7 +1223,9 @@ type AppIface interface {
 	SlackImport(c request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer)
 	SoftDeleteTeam(teamID string) *model.AppError
 	Srv() *Server
-	StartUsersBatchExport(rctx request.CTX, dateRange string, startAt int64, endAt int64) *model.AppError
+	StartChannelMembershipReport(rctx request.CTX, format string, teamID string) *model.AppError
+	StartTeamActivityReport(rctx request.CTX, format string, dateRange string, startAt int64, endAt int64) *model.AppError
+	StartUsersBatchExport(rctx request.CTX, format string, dateRange string, startAt int64, endAt int64) *model.AppError
 	SubmitInteractiveDialog(c request.CTX, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError)
 	SwitchEmailToLdap(c request.CTX, email, password, code, ldapLoginId, ldapPassword string) (string, *model.AppError)
 	SwitchEmailToOAuth(c request.CTX, w http.ResponseWriter, r *http.Request, email, password, code, service string) (string, *model.AppError)
@@ -1221,6 +1310,7 @@ type AppIface interface {
 	UserCanSeeOtherUser(c request.CTX, userID string, otherUserId string) (bool, *model.AppError)
 	UserIsFirstAdmin(rctx request.CTX, user *model.User) bool
 	ValidateDesktopToken(token string, expiryTime int64) (*model.User, *model.AppError)
+	VerifyBleveIndex(indexName string) (*model.BleveIndexStats, *model.AppError)
 	VerifyEmailFromToken(c request.CTX, userSuppliedTokenString string) *model.AppError
 	VerifyUserEmail(userID, email string) *model.AppError
 	ViewChannel(c request.CTX, view *model.ChannelView, userID string, currentSessionId string, collapsedThreadsSupported bool) (map[string]int64, *model.AppError)
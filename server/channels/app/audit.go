@@ -4,7 +4,10 @@
 package app
 
 import (
+	"crypto/ecdsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/http"
@@ -145,7 +148,133 @@ func (s *Server) configureAudit(adt *audit.Audit, bAllowAdvancedLogging bool) er
 		cfg.Append(cfgAdditional)
 	}
 
-	return adt.Configure(cfg)
+	if err := adt.Configure(cfg); err != nil {
+		return err
+	}
+
+	s.configureAuditSinks(adt)
+	return nil
+}
+
+// configureAuditSinks enables the hash chain of audit records and the database sink as
+// configured.
+func (s *Server) configureAuditSinks(adt *audit.Audit) {
+	auditSettings := s.platform.Config().ExperimentalAuditSettings
+	if *auditSettings.DatabaseEnabled && !adt.HasSink(auditDatabaseSinkName) {
+		adt.AddSink(auditDatabaseSinkName, &auditDatabaseSink{store: s.Store().AuditRecord()})
+	}
+
+	if auditSettings.IsHashChainEnabled() {
+		key, err := loadAuditSigningKey(*auditSettings.CheckpointSigningKeyFile)
+		if err != nil {
+			s.Log().Error("Cannot load the audit checkpoint signing key, checkpoints won't be signed.", mlog.Err(err))
+		}
+		adt.EnableHashChain(*auditSettings.CheckpointInterval, func(checkpoint *model.AuditCheckpoint) error {
+			if key == nil {
+				return errors.New("no audit checkpoint signing key")
+			}
+			return checkpoint.Sign(key)
+		})
+	} else {
+		adt.DisableHashChain()
+	}
+
+	// The sink is removed last for the checkpoint of a disabled hash chain to be written.
+	if !*auditSettings.DatabaseEnabled {
+		adt.RemoveSink(auditDatabaseSinkName)
+	}
+}
+
+// loadAuditSigningKey reads the ECDSA private key audit checkpoints are signed with from a
+// PEM file. The key is kept out of the database, so that whoever can write the audit records
+// can't sign the checkpoints of a rewritten chain.
+func loadAuditSigningKey(keyFile string) (*ecdsa.PrivateKey, error) {
+	if keyFile == "" {
+		return nil, errors.New("ExperimentalAuditSettings.CheckpointSigningKeyFile isn't set")
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signing key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("the signing key file isn't a PEM file")
+	}
+
+	if block.Type == "EC PRIVATE KEY" {
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the signing key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected signing key type %T", key)
+	}
+	return ecdsaKey, nil
+}
+
+const auditDatabaseSinkName = "database"
+
+// auditDatabaseSink writes the chained audit records to the database, for them to be
+// queried through the API.
+type auditDatabaseSink struct {
+	store store.AuditRecordStore
+}
+
+func (s *auditDatabaseSink) WriteRecord(rec *model.AuditRecord) error {
+	_, err := s.store.Save(rec)
+	return err
+}
+
+func (s *auditDatabaseSink) WriteCheckpoint(checkpoint *model.AuditCheckpoint) error {
+	_, err := s.store.SaveCheckpoint(checkpoint)
+	return err
+}
+
+// GetAuditRecords returns the audit records written to the database matching the filter.
+func (a *App) GetAuditRecords(filter *model.AuditRecordFilter) ([]*model.AuditRecord, *model.AppError) {
+	if !*a.Config().ExperimentalAuditSettings.DatabaseEnabled {
+		return nil, model.NewAppError("GetAuditRecords", "app.audit_record.database_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	records, err := a.Srv().Store().AuditRecord().GetAll(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetAuditRecords", "app.audit_record.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return records, nil
+}
+
+// GetAuditCheckpoints returns the checkpoints of the audit records written to the
+// database, of a chain or of all of them if chainID is empty.
+func (a *App) GetAuditCheckpoints(chainID string, page, perPage int) ([]*model.AuditCheckpoint, *model.AppError) {
+	if !*a.Config().ExperimentalAuditSettings.DatabaseEnabled {
+		return nil, model.NewAppError("GetAuditCheckpoints", "app.audit_record.database_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	checkpoints, err := a.Srv().Store().AuditRecord().GetCheckpoints(chainID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetAuditCheckpoints", "app.audit_checkpoint.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return checkpoints, nil
+}
+
+// GetAuditSigningKey returns the public key audit checkpoints are signed with.
+func (a *App) GetAuditSigningKey() (*model.AuditSigningKey, *model.AppError) {
+	key, err := loadAuditSigningKey(*a.Config().ExperimentalAuditSettings.CheckpointSigningKeyFile)
+	if err != nil {
+		return nil, model.NewAppError("GetAuditSigningKey", "app.audit_signing_key.missing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	signingKey, err := model.NewAuditSigningKey(&key.PublicKey)
+	if err != nil {
+		return nil, model.NewAppError("GetAuditSigningKey", "app.audit_signing_key.missing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return signingKey, nil
 }
 
 func (s *Server) onAuditTargetQueueFull(qname string, maxQSize int) bool {
@@ -204,7 +204,23 @@ func (a *App) CheckUserPostflightAuthenticationCriteria(rctx request.CTX, user *
 }
 
 func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *model.AppError {
-	if !user.MfaActive || !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil
+	}
+
+	// Users with security keys may use any of them instead of their TOTP code.
+	if model.IsWebAuthnAssertion(token) {
+		return a.checkWebAuthnAssertion(rctx, user, token)
+	}
+
+	if !user.MfaActive {
+		hasCredentials, appErr := a.HasWebAuthnCredentials(user.Id)
+		if appErr != nil {
+			return appErr
+		}
+		if hasCredentials {
+			return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+		}
 		return nil
 	}
 
@@ -212,6 +228,11 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	// Users who lost their TOTP device may use one of their recovery codes instead.
+	if model.IsMfaRecoveryCode(token) {
+		return a.checkMfaRecoveryCode(rctx, user, token)
+	}
+
 	ok, err := mfa.New(a.Srv().Store().User()).ValidateToken(user.MfaSecret, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
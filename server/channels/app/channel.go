@@ -2609,6 +2609,13 @@ func (a *App) RemoveUserFromChannel(c request.CTX, userIDToRemove string, remove
 	return nil
 }
 
+// RemoveRemoteUserFromChannel removes a user belonging to a remote cluster from a shared channel.
+// Unlike RemoveUserFromChannel no system message is posted; the remote's own message is
+// synchronized along with the channel's posts.
+func (a *App) RemoveRemoteUserFromChannel(c request.CTX, userID string, channel *model.Channel) *model.AppError {
+	return a.removeUserFromChannel(c, userID, userID, channel)
+}
+
 func (a *App) GetNumberOfChannelsOnTeam(c request.CTX, teamID string) (int, *model.AppError) {
 	// Get total number of channels on current team
 	list, err := a.Srv().Store().Channel().GetTeamChannels(teamID)
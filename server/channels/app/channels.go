@@ -76,12 +76,15 @@ type Channels struct {
 
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
+
+	emailBatchingMut  sync.Mutex
+	emailBatchingTask *model.ScheduledTask
 }
 
 func NewChannels(s *Server) (*Channels, error) {
 	ch := &Channels{
 		srv:             s,
-		imageProxy:      imageproxy.MakeImageProxy(s.platform, s.httpService, s.Log()),
+		imageProxy:      imageproxy.MakeImageProxy(s.platform, s.httpService, s.FileBackend(), s.Log()),
 		uploadLockMap:   map[string]bool{},
 		filestore:       s.FileBackend(),
 		exportFilestore: s.ExportFileBackend(),
@@ -204,6 +207,8 @@ func (ch *Channels) Stop() error {
 	}
 	ch.dndTaskMut.Unlock()
 
+	cancelTask(&ch.emailBatchingMut, &ch.emailBatchingTask)
+
 	return nil
 }
 
@@ -360,6 +360,36 @@ func (es *Service) SendPasswordResetEmail(email string, token *model.Token, loca
 	return true, nil
 }
 
+func (es *Service) SendMfaResetRequestEmail(email string, token *model.Token, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	link := fmt.Sprintf("%s/mfa/reset?token=%s", siteURL, url.QueryEscape(token.Token))
+
+	subject := T("api.templates.mfa_reset_request_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.mfa_reset_request_body.title")
+	data.Props["SubTitle"] = T("api.templates.mfa_reset_request_body.subTitle")
+	data.Props["Info"] = T("api.templates.mfa_reset_request_body.info")
+	data.Props["ButtonURL"] = link
+	data.Props["Button"] = T("api.templates.mfa_reset_request_body.button")
+	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+	data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
+
+	body, err := es.templatesContainer.RenderToString("reset_body", data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "MfaResetRequestEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, siteURL string) error {
 	T := i18n.GetUserTranslations(locale)
 
@@ -832,22 +862,17 @@ func (es *Service) sendMailWithCC(to, subject, htmlBody, ccMail, category string
 	return mail.SendMailUsingConfig(to, subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, "", "", "", ccMail, category)
 }
 
-func (es *Service) SendMailWithEmbeddedFilesAndCustomReplyTo(to, subject, htmlBody, replyToAddress string, embeddedFiles map[string]io.Reader, category string) error {
+func (es *Service) SendMailWithEmbeddedFilesAndCustomReplyTo(to, subject, htmlBody, replyToAddress string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error {
 	license := es.license()
 	mailConfig := es.mailServiceConfig(replyToAddress)
 
 	category = getSendGridCategory(category, license.IsCloud())
 
-	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", category)
+	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, messageID, inReplyTo, references, "", category)
 }
 
 func (es *Service) SendMailWithEmbeddedFiles(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error {
-	license := es.license()
-	mailConfig := es.mailServiceConfig("")
-
-	category = getSendGridCategory(category, license.IsCloud())
-
-	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, messageID, inReplyTo, references, "", category)
+	return es.SendMailWithEmbeddedFilesAndCustomReplyTo(to, subject, htmlBody, "", embeddedFiles, messageID, inReplyTo, references, category)
 }
 
 func (es *Service) InvalidateVerifyEmailTokensForUser(userID string) *model.AppError {
@@ -9,19 +9,21 @@ import (
 	"html/template"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
 
 const (
-	EmailBatchingTaskName = "Email Batching"
+	// The users whose batched notifications are checked at once by the email batching task.
+	emailBatchingUsersPerPage = 100
 )
 
 type postData struct {
@@ -37,26 +39,20 @@ type postData struct {
 	MessageAttachments       []*EmailMessageAttachment
 }
 
-func (es *Service) InitEmailBatching() {
-	if *es.config().EmailSettings.EnableEmailBatching {
-		if es.EmailBatching == nil {
-			es.EmailBatching = NewEmailBatchingJob(es, *es.config().EmailSettings.EmailBatchingBufferSize)
-		}
-
-		// note that we don't support changing EmailBatchingBufferSize without restarting the server
-
-		es.EmailBatching.Start()
-	}
-}
-
 func (es *Service) AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError {
 	if !*es.config().EmailSettings.EnableEmailBatching {
 		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if !es.EmailBatching.Add(user, post, team) {
-		mlog.Error("Email batching job's receiving buffer was full. Please increase the EmailBatchingBufferSize. Falling back to sending immediate mail.")
-		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.channel_full.app_error", nil, "", http.StatusInternalServerError)
+	notification := &model.BatchedNotification{
+		UserId:   user.Id,
+		PostId:   post.Id,
+		TeamName: team.Name,
+		CreateAt: post.CreateAt,
+	}
+	if _, err := es.store.BatchedNotification().Save(notification); err != nil {
+		mlog.Error("Unable to save the batched email notification. Falling back to sending immediate mail.", mlog.String("user_id", user.Id), mlog.Err(err))
+		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
 	return nil
@@ -68,173 +64,154 @@ type batchedNotification struct {
 	teamName string
 }
 
-type EmailBatchingJob struct {
-	config  func() *model.Config
-	service *Service
-
-	newNotifications     chan *batchedNotification
-	pendingNotifications map[string][]*batchedNotification
-	task                 *model.ScheduledTask
-	taskMutex            sync.Mutex
-}
-
-func NewEmailBatchingJob(es *Service, bufferSize int) *EmailBatchingJob {
-	return &EmailBatchingJob{
-		config:               es.config,
-		service:              es,
-		newNotifications:     make(chan *batchedNotification, bufferSize),
-		pendingNotifications: make(map[string][]*batchedNotification),
-	}
+// ProcessBatchedNotifications sends the digest emails which are due to the users with
+// batched notifications. It is run by the cluster leader only, so that each digest is
+// sent by a single node of the cluster, and the notifications are only deleted once
+// their digest is sent.
+func (es *Service) ProcessBatchedNotifications() error {
+	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
+	// without actually sending emails
+	return es.processBatchedNotifications(time.Now(), es.sendBatchedEmailNotification)
 }
 
-func (job *EmailBatchingJob) Start() {
-	mlog.Debug("Email batching job starting. Checking for pending emails periodically.", mlog.Int("interval_in_seconds", *job.config().EmailSettings.EmailBatchingInterval))
-	newTask := model.CreateRecurringTask(EmailBatchingTaskName, job.CheckPendingEmails, time.Duration(*job.config().EmailSettings.EmailBatchingInterval)*time.Second)
+func (es *Service) processBatchedNotifications(now time.Time, handler func(string, []*batchedNotification) error) error {
+	afterUserID := ""
+	for {
+		userIDs, err := es.store.BatchedNotification().GetUsersWithPending(afterUserID, emailBatchingUsersPerPage)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the users with batched notifications")
+		}
 
-	job.taskMutex.Lock()
-	oldTask := job.task
-	job.task = newTask
-	job.taskMutex.Unlock()
+		for _, userID := range userIDs {
+			es.checkPendingNotifications(now, userID, handler)
+		}
 
-	if oldTask != nil {
-		oldTask.Cancel()
+		if len(userIDs) < emailBatchingUsersPerPage {
+			mlog.Debug("Email batching job ran. Notifications might be still pending.")
+			return nil
+		}
+		afterUserID = userIDs[len(userIDs)-1]
 	}
 }
 
-// Stop will cancel the task properly, flushing out any pending notifications.
-// Although this still won't send those notifications which are yet to be sent
-// due to a user's PreferenceNameEmailInterval.
-func (job *EmailBatchingJob) Stop() {
-	job.taskMutex.Lock()
-	if task := job.task; task != nil {
-		task.Cancel()
+// getEmailDigestSchedule returns when the user receives their digest emails, using the
+// default batching interval and digest time unless set in their preferences.
+func (es *Service) getEmailDigestSchedule(user *model.User) *model.EmailDigestSchedule {
+	var interval, digestTime string
+	if preference, err := es.store.Preference().Get(user.Id, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval); err == nil {
+		interval = preference.Value
 	}
-	job.taskMutex.Unlock()
-}
-
-func (job *EmailBatchingJob) Add(user *model.User, post *model.Post, team *model.Team) bool {
-	notification := &batchedNotification{
-		userID:   user.Id,
-		post:     post,
-		teamName: team.Name,
+	if preference, err := es.store.Preference().Get(user.Id, model.PreferenceCategoryNotifications, model.PreferenceNameEmailDigestTime); err == nil {
+		digestTime = preference.Value
 	}
 
-	select {
-	case job.newNotifications <- notification:
-		return true
-	default:
-		// return false if we couldn't queue the email notification so that we can send an immediate email
-		return false
-	}
+	return model.NewEmailDigestSchedule(interval, digestTime, user.GetTimezoneLocation())
 }
 
-func (job *EmailBatchingJob) CheckPendingEmails() {
-	job.handleNewNotifications()
-
-	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
-	// without actually sending emails
-	job.checkPendingNotifications(time.Now(), job.service.sendBatchedEmailNotification)
+func (es *Service) checkPendingNotifications(now time.Time, userID string, handler func(string, []*batchedNotification) error) {
+	pending, err := es.store.BatchedNotification().GetForUser(userID)
+	if err != nil {
+		mlog.Error("Unable to get the batched notifications of the user", mlog.String("user_id", userID), mlog.Err(err))
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
 
-	mlog.Debug("Email batching job ran. Notifications might be still pending.", mlog.Int("number_of_users", len(job.pendingNotifications)))
-}
+	ids := make([]string, 0, len(pending))
+	for _, notification := range pending {
+		ids = append(ids, notification.Id)
+	}
+	deletePending := func() {
+		if err := es.store.BatchedNotification().Delete(ids); err != nil {
+			mlog.Error("Unable to delete the batched notifications of the user", mlog.String("user_id", userID), mlog.Err(err))
+		}
+	}
 
-func (job *EmailBatchingJob) handleNewNotifications() {
-	receiving := true
+	user, err := es.userService.GetUser(userID)
+	if err != nil {
+		mlog.Warn("Unable to find recipient for batched email notification. Deleting its notifications.", mlog.String("user_id", userID), mlog.Err(err))
+		deletePending()
+		return
+	}
 
-	// read in new notifications to send
-	for receiving {
-		select {
-		case notification := <-job.newNotifications:
-			userID := notification.userID
+	batchStartTime := pending[0].CreateAt
+	// Ignore if it isn't time yet to send.
+	if !now.After(es.getEmailDigestSchedule(user).NextDigestTime(time.UnixMilli(batchStartTime))) {
+		return
+	}
 
-			if _, ok := job.pendingNotifications[userID]; !ok {
-				job.pendingNotifications[userID] = []*batchedNotification{notification}
-			} else {
-				job.pendingNotifications[userID] = append(job.pendingNotifications[userID], notification)
-			}
-		default:
-			receiving = false
+	// If the user has viewed any channels in this team since the notification was queued, delete
+	// all queued notifications
+	inspectedTeamNames := make(map[string]string)
+	for _, notification := range pending {
+		// at most, we'll do one check for each team that notifications were sent for
+		if inspectedTeamNames[notification.TeamName] != "" {
+			continue
 		}
-	}
-}
 
-func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(string, []*batchedNotification)) {
-	for userID, notifications := range job.pendingNotifications {
-		// Defensive code.
-		if len(notifications) == 0 {
-			mlog.Warn("Unexpected result. Got 0 pending notifications for batched email.", mlog.String("user_id", userID))
+		team, nErr := es.store.Team().GetByName(notification.TeamName)
+		if nErr != nil {
+			mlog.Error("Unable to find Team id for notification", mlog.Err(nErr))
 			continue
 		}
 
-		// get how long we need to wait to send notifications to the user
-		var interval int64
-		preference, err := job.service.store.Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval)
-		if err != nil {
-			// use the default batching interval if an error occurs while fetching user preferences
-			interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
-		} else {
-			if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
-				// // use the default batching interval if an error occurs while deserializing user preferences
-				interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
-			} else {
-				interval = value
-			}
+		if team != nil {
+			inspectedTeamNames[notification.TeamName] = team.Id
 		}
 
-		batchStartTime := notifications[0].post.CreateAt
-		// Ignore if it isn't time yet to send.
-		if now.Sub(time.UnixMilli(batchStartTime)) <= time.Duration(interval)*time.Second {
+		channelMembers, err := es.store.Channel().GetMembersForUser(inspectedTeamNames[notification.TeamName], userID)
+		if err != nil {
+			mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
 			continue
 		}
 
-		// If the user has viewed any channels in this team since the notification was queued, delete
-		// all queued notifications
-		inspectedTeamNames := make(map[string]string)
-		for _, notification := range notifications {
-			// at most, we'll do one check for each team that notifications were sent for
-			if inspectedTeamNames[notification.teamName] != "" {
-				continue
-			}
-
-			team, nErr := job.service.store.Team().GetByName(notifications[0].teamName)
-			if nErr != nil {
-				mlog.Error("Unable to find Team id for notification", mlog.Err(nErr))
-				continue
-			}
-
-			if team != nil {
-				inspectedTeamNames[notification.teamName] = team.Id
-			}
-
-			channelMembers, err := job.service.store.Channel().GetMembersForUser(inspectedTeamNames[notification.teamName], userID)
-			if err != nil {
-				mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
-				continue
-			}
-
-			deleted := false
-			for _, channelMember := range channelMembers {
-				if channelMember.LastViewedAt >= batchStartTime {
-					mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
-					delete(job.pendingNotifications, userID)
-					deleted = true
-					break
-				}
-			}
-			if deleted {
-				break
+		for _, channelMember := range channelMembers {
+			if channelMember.LastViewedAt >= batchStartTime {
+				mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
+				deletePending()
+				return
 			}
 		}
+	}
+
+	postIDs := make([]string, 0, len(pending))
+	for _, notification := range pending {
+		postIDs = append(postIDs, notification.PostId)
+	}
+	posts, err := es.store.Post().GetPostsByIds(postIDs)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		mlog.Error("Unable to get the posts of the batched notifications", mlog.String("user_id", userID), mlog.Err(err))
+		return
+	}
+	postsByID := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.Id] = post
+	}
 
-		// The notifications might have been cleared from the above step.
-		// We need to check again.
-		if len(job.pendingNotifications[userID]) == 0 {
+	// The notifications of the posts deleted since they were queued are left out.
+	notifications := make([]*batchedNotification, 0, len(pending))
+	for _, notification := range pending {
+		post, ok := postsByID[notification.PostId]
+		if !ok || post.DeleteAt != 0 {
 			continue
 		}
+		notifications = append(notifications, &batchedNotification{
+			userID:   userID,
+			post:     post,
+			teamName: notification.TeamName,
+		})
+	}
 
-		handler(userID, job.pendingNotifications[userID])
-		delete(job.pendingNotifications, userID)
+	if len(notifications) > 0 {
+		// The notifications are kept to retry on the next run if their digest can't be sent.
+		if err := handler(userID, notifications); err != nil {
+			mlog.Warn("Unable to send batched email notification. Keeping its notifications.", mlog.String("user_id", userID), mlog.Err(err))
+			return
+		}
 	}
+	deletePending()
 }
 
 /**
@@ -249,11 +226,10 @@ func truncateUserNames(name string, i int) string {
 	return name
 }
 
-func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) {
+func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) error {
 	user, err := es.userService.GetUser(userID)
 	if err != nil {
-		mlog.Warn("Unable to find recipient for batched email notification")
-		return
+		return errors.Wrap(err, "unable to find recipient for batched email notification")
 	}
 
 	translateFunc := i18n.GetUserTranslations(user.Locale)
@@ -370,10 +346,12 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 
 	renderedPage, renderErr := es.templatesContainer.RenderToString("messages_notification", data)
 	if renderErr != nil {
-		mlog.Error("Unable to render email", mlog.Err(renderErr))
+		return errors.Wrap(renderErr, "unable to render email")
 	}
 
 	if nErr := es.SendMailWithEmbeddedFiles(user.Email, subject, renderedPage, embeddedFiles, "", "", "", "BatchedEmailNotification"); nErr != nil {
-		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(nErr))
+		return errors.Wrap(nErr, "unable to send batched email notification")
 	}
+
+	return nil
 }
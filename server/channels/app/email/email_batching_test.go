@@ -5,189 +5,207 @@ package email
 
 import (
 	"context"
-	"sync/atomic"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
 )
 
-func TestHandleNewNotifications(t *testing.T) {
-	th := SetupWithStoreMock(t)
-	defer th.TearDown()
-
-	id1 := model.NewId()
-	id2 := model.NewId()
-	id3 := model.NewId()
-
-	// test queueing of received posts by user
-	job := NewEmailBatchingJob(th.service, 128)
-
-	job.handleNewNotifications()
-
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 1, "should have received 1 post for user")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 2, "should have received posts for 2 users")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 1, "should have received 1 post for user2")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id3}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 3, "should have received posts for 3 users")
-	require.Len(t, job.pendingNotifications[id1], 3, "should have received 3 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 3, "should have received 3 posts for user2")
-	require.Len(t, job.pendingNotifications[id3], 1, "should have received 1 post for user3")
-
-	// test ordering of received posts
-	job = NewEmailBatchingJob(th.service, 128)
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test1"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test2"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test3"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test4"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test5"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	assert.Equal(t, job.pendingNotifications[id1][0].post.Message, "test1", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][1].post.Message, "test2", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][2].post.Message, "test4", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id2][0].post.Message, "test3", "incorrect order of received posts for user2")
-	assert.Equal(t, job.pendingNotifications[id2][1].post.Message, "test5", "incorrect order of received posts for user2")
-}
+func (th *TestHelper) addBatchedNotification(tb testing.TB, createAt int64, message string) *model.Post {
+	post, err := th.store.Post().Save(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		CreateAt:  createAt,
+		Message:   message,
+	})
+	require.NoError(tb, err)
 
-func TestCheckPendingNotifications(t *testing.T) {
-	th := Setup(t).InitBasic()
-	defer th.TearDown()
+	_, err = th.store.BatchedNotification().Save(&model.BatchedNotification{
+		UserId:   th.BasicUser.Id,
+		PostId:   post.Id,
+		TeamName: th.BasicTeam.Name,
+		CreateAt: post.CreateAt,
+	})
+	require.NoError(tb, err)
 
-	job := NewEmailBatchingJob(th.service, 128)
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	return post
+}
 
+func (th *TestHelper) setLastViewedAt(tb testing.TB, lastViewedAt int64) {
 	channelMember, err := th.store.Channel().GetMember(context.Background(), th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999999
+	require.NoError(tb, err)
+	channelMember.LastViewedAt = lastViewedAt
 	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	require.NoError(tb, err)
+}
 
-	nErr := th.store.Preference().Save(model.Preferences{{
+func (th *TestHelper) setEmailInterval(tb testing.TB, interval string) {
+	err := th.store.Preference().Save(model.Preferences{{
 		UserId:   th.BasicUser.Id,
 		Category: model.PreferenceCategoryNotifications,
 		Name:     model.PreferenceNameEmailInterval,
-		Value:    "60",
+		Value:    interval,
 	}})
-	require.NoError(t, nErr)
+	require.NoError(tb, err)
+}
+
+func TestAddNotificationEmailToBatch(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	user := &model.User{Id: model.NewId()}
+	post := &model.Post{Id: model.NewId(), UserId: model.NewId(), CreateAt: 10000000}
+	team := &model.Team{Name: "team"}
+
+	t.Run("batching disabled", func(t *testing.T) {
+		appErr := th.service.AddNotificationEmailToBatch(user, post, team)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.email_batching.add_notification_email_to_batch.disabled.app_error", appErr.Id)
+	})
+
+	th.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+		*cfg.EmailSettings.EnableEmailBatching = true
+	})
+
+	t.Run("saves the notification", func(t *testing.T) {
+		batchedNotificationStore := mocks.BatchedNotificationStore{}
+		batchedNotificationStore.On("Save", mock.MatchedBy(func(notification *model.BatchedNotification) bool {
+			return notification.UserId == user.Id && notification.PostId == post.Id && notification.TeamName == team.Name && notification.CreateAt == post.CreateAt
+		})).Return(&model.BatchedNotification{}, nil).Once()
+		th.service.store.(*mocks.Store).On("BatchedNotification").Return(&batchedNotificationStore).Once()
+
+		appErr := th.service.AddNotificationEmailToBatch(user, post, team)
+		require.Nil(t, appErr)
+		batchedNotificationStore.AssertExpectations(t)
+	})
+
+	t.Run("fails to save the notification", func(t *testing.T) {
+		batchedNotificationStore := mocks.BatchedNotificationStore{}
+		batchedNotificationStore.On("Save", mock.Anything).Return(nil, errors.New("error")).Once()
+		th.service.store.(*mocks.Store).On("BatchedNotification").Return(&batchedNotificationStore).Once()
+
+		appErr := th.service.AddNotificationEmailToBatch(user, post, team)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.email_batching.add_notification_email_to_batch.save.app_error", appErr.Id)
+	})
+}
+
+func TestCheckPendingNotifications(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.addBatchedNotification(t, 10000000, "post0")
+	th.setLastViewedAt(t, 9999999)
+	th.setEmailInterval(t, "60")
 
 	// test that notifications aren't sent before interval
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
+	err := th.service.processBatchedNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
+	})
+	require.NoError(t, err)
 
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	pending, err := th.store.BatchedNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "shouldn't have sent queued post")
 
 	// test that notifications are cleared if the user has acted
-	channelMember, err = th.store.Channel().GetMember(context.Background(), th.BasicChannel.Id, th.BasicUser.Id)
+	th.setLastViewedAt(t, 10001000)
+
+	// We reset the interval to something shorter
+	th.setEmailInterval(t, "10")
+
+	err = th.service.processBatchedNotifications(time.Unix(10050, 0), func(string, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
+	})
 	require.NoError(t, err)
-	channelMember.LastViewedAt = 10001000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
+
+	pending, err = th.store.BatchedNotification().GetForUser(th.BasicUser.Id)
 	require.NoError(t, err)
+	require.Empty(t, pending, "should've removed queued post since user acted")
 
-	// We reset the interval to something shorter
-	nErr = th.store.Preference().Save(model.Preferences{{
-		UserId:   th.BasicUser.Id,
-		Category: model.PreferenceCategoryNotifications,
-		Name:     model.PreferenceNameEmailInterval,
-		Value:    "10",
-	}})
-	require.NoError(t, nErr)
+	// test that notifications are sent if enough time passes since the first message
+	th.addBatchedNotification(t, 10060000, "post1")
+	th.addBatchedNotification(t, 10090000, "post2")
 
-	var wasCalled int32
-	job.checkPendingNotifications(time.Unix(10050, 0), func(string, []*batchedNotification) {
-		atomic.StoreInt32(&wasCalled, int32(1))
+	var received []*model.Post
+	err = th.service.processBatchedNotifications(time.Unix(10130, 0), func(userID string, notifications []*batchedNotification) error {
+		assert.Equal(t, th.BasicUser.Id, userID)
+		for _, notification := range notifications {
+			received = append(received, notification.post)
+		}
+		return nil
 	})
+	require.NoError(t, err)
 
-	// A hack to check whether the handler was called.
-	// It's not straightforward to just wait for it using a channel because the test should
-	// NOT call the handler, and it will be called only if the test fails.
-	time.Sleep(1 * time.Second)
-	// We do a check outside the email handler, because otherwise, failing from
-	// inside the handler doesn't let the .Go() function exit cleanly, and it gets
-	// stuck during server shutdown, trying to wait for the goroutine to exit
-	require.Equal(t, int32(0), atomic.LoadInt32(&wasCalled), "email handler should not have been called")
+	require.Len(t, received, 2)
+	assert.Equal(t, "post1", received[0].Message, "should've received post1 first")
+	assert.Equal(t, "post2", received[1].Message, "should've received post2 second")
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should've remove queued post since user acted")
+	pending, err = th.store.BatchedNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should've removed the sent posts")
+}
 
-	// test that notifications are sent if enough time passes since the first message
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10060000,
-				Message:   "post1",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10090000,
-				Message:   "post2",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
-
-	received := make(chan *model.Post, 2)
-
-	job.checkPendingNotifications(time.Unix(10130, 0), func(s string, notifications []*batchedNotification) {
+func TestCheckPendingNotificationsDeletedPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// bypasses recent user activity check
+	th.setLastViewedAt(t, 9999000)
+
+	th.addBatchedNotification(t, 10000000, "post1")
+	deleted := th.addBatchedNotification(t, 10001000, "post2")
+	err := th.store.Post().Delete(th.Context, deleted.Id, model.GetMillis(), th.BasicUser2.Id)
+	require.NoError(t, err)
+
+	var received []*model.Post
+	err = th.service.processBatchedNotifications(time.Unix(10901, 0), func(_ string, notifications []*batchedNotification) error {
 		for _, notification := range notifications {
-			received <- notification.post
+			received = append(received, notification.post)
 		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, 1, "shouldn't have sent the deleted post")
+	assert.Equal(t, "post1", received[0].Message)
+}
+
+func TestCheckPendingNotificationsSendFailure(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// bypasses recent user activity check
+	th.setLastViewedAt(t, 9999000)
+
+	th.addBatchedNotification(t, 10000000, "post")
+
+	err := th.service.processBatchedNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) error {
+		return errors.New("smtp failure")
 	})
+	require.NoError(t, err)
+
+	pending, err := th.store.BatchedNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "should've kept the notification which couldn't be sent")
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "shouldn't have sent queued post")
-
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post1", "should've received post1 first")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for first post notification")
-	}
-
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post2", "should've received post2 second")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for second post notification")
-	}
+	sent := false
+	err = th.service.processBatchedNotifications(time.Unix(10902, 0), func(string, []*batchedNotification) error { sent = true; return nil })
+	require.NoError(t, err)
+	require.True(t, sent, "should have retried sending the queued post")
+
+	pending, err = th.store.BatchedNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should've removed the sent post")
 }
 
 /**
@@ -197,40 +215,21 @@ func TestCheckPendingNotificationsDefaultInterval(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
-	job := NewEmailBatchingJob(th.service, 128)
-
 	// bypasses recent user activity check
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
+	th.setLastViewedAt(t, 9999000)
 
-	require.NotNil(t, th.BasicUser)
-	require.NotNil(t, th.BasicChannel)
-	channelMember, err := th.store.Channel().GetMember(context.Background(), th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	th.addBatchedNotification(t, 10000000, "post")
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	sent := false
+	err := th.service.processBatchedNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error { sent = true; return nil })
+	require.NoError(t, err)
+	require.False(t, sent, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	err = th.service.processBatchedNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) error { sent = true; return nil })
+	require.NoError(t, err)
+	require.True(t, sent, "should have sent queued post")
 }
 
 /**
@@ -240,46 +239,52 @@ func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
-	job := NewEmailBatchingJob(th.service, 128)
-
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
-	require.NotNil(t, th.BasicChannel)
-	require.NotNil(t, th.BasicUser)
 	// bypasses recent user activity check
-	channelMember, err := th.store.Channel().GetMember(context.Background(), th.BasicChannel.Id, th.BasicUser.Id)
+	th.setLastViewedAt(t, 9999000)
+
+	// preference value is not an integer, so we'll fall back to the default 15min value
+	th.setEmailInterval(t, "notAnIntegerValue")
+
+	th.addBatchedNotification(t, 10000000, "post")
+
+	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
+	sent := false
+	err := th.service.processBatchedNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error { sent = true; return nil })
 	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
+	require.False(t, sent, "shouldn't have sent queued post")
+
+	// notifications should be sent 901s after post was created, because default batch interval is 15mins
+	err = th.service.processBatchedNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) error { sent = true; return nil })
 	require.NoError(t, err)
+	require.True(t, sent, "should have sent queued post")
+}
 
-	// preference value is not an integer, so we'll fall back to the default 15min value
-	nErr := th.store.Preference().Save(model.Preferences{{
+func TestCheckPendingNotificationsDailyDigest(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// bypasses recent user activity check
+	th.setLastViewedAt(t, 0)
+
+	th.setEmailInterval(t, model.PreferenceEmailIntervalDayAsSeconds)
+	err := th.store.Preference().Save(model.Preferences{{
 		UserId:   th.BasicUser.Id,
 		Category: model.PreferenceCategoryNotifications,
-		Name:     model.PreferenceNameEmailInterval,
-		Value:    "notAnIntegerValue",
+		Name:     model.PreferenceNameEmailDigestTime,
+		Value:    "08:30",
 	}})
-	require.NoError(t, nErr)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	require.NoError(t, err)
 
-	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	// The basic user has no timezone set, so the digest is sent at 08:30 UTC.
+	queuedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	th.addBatchedNotification(t, queuedAt.UnixMilli(), "post")
 
-	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
+	sent := false
+	err = th.service.processBatchedNotifications(time.Date(2024, time.March, 1, 23, 0, 0, 0, time.UTC), func(string, []*batchedNotification) error { sent = true; return nil })
+	require.NoError(t, err)
+	require.False(t, sent, "shouldn't have sent queued post before the digest time")
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	err = th.service.processBatchedNotifications(time.Date(2024, time.March, 2, 8, 31, 0, 0, time.UTC), func(string, []*batchedNotification) error { sent = true; return nil })
+	require.NoError(t, err)
+	require.True(t, sent, "should have sent queued post at the digest time")
 }
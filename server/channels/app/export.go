@@ -91,51 +91,78 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	if opts.IncludeRolesAndSchemes {
-		if err := a.exportRolesAndSchemes(ctx, job, writer); err != nil {
+		if err := a.exportRolesAndSchemes(ctx, job, writer, opts.Since); err != nil {
 			return err
 		}
 	}
 
 	ctx.Logger().Info("Bulk export: exporting teams")
-	teamNames, err := a.exportAllTeams(ctx, job, writer)
+	teamNames, err := a.exportAllTeams(ctx, job, writer, opts.Since)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting channels")
-	if err = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels); err != nil {
+	if err = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels, opts.Since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting users")
-	profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures)
+	profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures, opts.Since)
 	if err != nil {
 		return err
 	}
 
+	ctx.Logger().Info("Bulk export: exporting bots")
+	if err = a.exportAllBots(ctx, job, writer, opts.Since); err != nil {
+		return err
+	}
+
+	ctx.Logger().Info("Bulk export: exporting groups")
+	if err = a.exportAllGroups(ctx, job, writer); err != nil {
+		return err
+	}
+
+	ctx.Logger().Info("Bulk export: exporting channel bookmarks")
+	if err = a.exportAllChannelBookmarks(ctx, job, writer, teamNames, opts.IncludeArchivedChannels, opts.Since); err != nil {
+		return err
+	}
+
+	ctx.Logger().Info("Bulk export: exporting integrations")
+	if err = a.exportAllIntegrations(ctx, job, writer, teamNames, opts.IncludeArchivedChannels, opts.Since); err != nil {
+		return err
+	}
+
 	ctx.Logger().Info("Bulk export: exporting posts")
-	attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting emoji")
-	emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive)
+	emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive, opts.Since)
 	if err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct channels")
-	if err = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels); err != nil {
+	if err = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels, opts.Since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since)
 	if err != nil {
 		return err
 	}
 
+	if opts.Since > 0 {
+		ctx.Logger().Info("Bulk export: exporting deletions")
+		if err = a.exportDeletions(ctx, job, writer, teamNames, opts.Since); err != nil {
+			return err
+		}
+	}
+
 	if opts.IncludeAttachments {
 		ctx.Logger().Info("Bulk export: exporting file attachments")
 		if err = a.exportAttachments(ctx, attachments, outPath, zipWr); err != nil {
@@ -222,7 +249,7 @@ func (a *App) exportVersion(writer io.Writer) *model.AppError {
 	return a.exportWriteLine(writer, versionLine)
 }
 
-func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.Writer) *model.AppError {
+func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.Writer, since int64) *model.AppError {
 	// We export schemes first since they'll already include their attached roles
 	// which we map to avoid exporting them twice later in exportRoles.
 	schemeRolesMap := make(map[string]bool)
@@ -233,28 +260,28 @@ func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.W
 	}
 
 	ctx.Logger().Info("Bulk export: exporting team schemes")
-	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeTeam, schemeRolesMap, roles); err != nil {
+	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeTeam, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting channel schemes")
-	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeChannel, schemeRolesMap, roles); err != nil {
+	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeChannel, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting roles")
-	if err := a.exportRoles(ctx, job, writer, schemeRolesMap, roles); err != nil {
+	if err := a.exportRoles(ctx, job, writer, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, schemeRoles map[string]bool, allRoles []*model.Role) *model.AppError {
+func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, schemeRoles map[string]bool, allRoles []*model.Role, since int64) *model.AppError {
 	var cnt int
 	for _, role := range allRoles {
 		// We skip any roles that will be included as part of custom schemes.
-		if !schemeRoles[role.Name] {
+		if !schemeRoles[role.Name] && role.UpdateAt >= since {
 			if err := a.exportWriteLine(writer, ImportLineFromRole(role)); err != nil {
 				return err
 			}
@@ -267,7 +294,7 @@ func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, sch
 	return nil
 }
 
-func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, scope string, schemeRolesMap map[string]bool, allRoles []*model.Role) *model.AppError {
+func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, scope string, schemeRolesMap map[string]bool, allRoles []*model.Role, since int64) *model.AppError {
 	rolesMap := make(map[string]*model.Role, len(allRoles))
 	for _, role := range allRoles {
 		rolesMap[role.Name] = role
@@ -307,6 +334,10 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 				schemeRolesMap[scheme.DefaultChannelGuestRole] = true
 			}
 
+			if !schemeUpdatedSince(scheme, rolesMap, since) {
+				continue
+			}
+
 			if err := a.exportWriteLine(writer, ImportLineFromScheme(scheme, rolesMap)); err != nil {
 				return err
 			}
@@ -322,7 +353,31 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 	}
 }
 
-func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer) (map[string]bool, *model.AppError) {
+// schemeUpdatedSince reports whether the scheme or one of its roles was updated since the given time.
+func schemeUpdatedSince(scheme *model.Scheme, rolesMap map[string]*model.Role, since int64) bool {
+	if scheme.UpdateAt >= since {
+		return true
+	}
+
+	for _, name := range []string{
+		scheme.DefaultTeamAdminRole,
+		scheme.DefaultTeamUserRole,
+		scheme.DefaultTeamGuestRole,
+		scheme.DefaultChannelAdminRole,
+		scheme.DefaultChannelUserRole,
+		scheme.DefaultChannelGuestRole,
+	} {
+		if role, ok := rolesMap[name]; ok && role.UpdateAt >= since {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exportAllTeams returns the names of all the teams that are not deleted,
+// including the ones that weren't updated since the given time.
+func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer, since int64) (map[string]bool, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	teamNames := make(map[string]bool)
 	cnt := 0
@@ -347,6 +402,10 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 			}
 			teamNames[team.Name] = true
 
+			if team.UpdateAt < since {
+				continue
+			}
+
 			teamLine := ImportLineFromTeam(team)
 			if err := a.exportWriteLine(writer, teamLine); err != nil {
 				return nil, err
@@ -357,7 +416,7 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 	return teamNames, nil
 }
 
-func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool) *model.AppError {
+func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, since int64) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -376,8 +435,11 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 		for _, channel := range channels {
 			afterId = channel.Id
 
-			// Skip deleted.
-			if channel.DeleteAt != 0 && !withArchived {
+			if channel.UpdateAt < since {
+				continue
+			}
+			// Skip deleted, unless archived since the given time so the import archives it too.
+			if channel.DeleteAt != 0 && !withArchived && (since == 0 || channel.DeleteAt < since) {
 				continue
 			}
 			// Skip channels on deleted teams.
@@ -395,10 +457,23 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 	return nil
 }
 
-func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool, since int64) ([]string, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	profilePictures := []string{}
+
+	// Users untouched since the last export are still exported when their memberships changed.
+	membershipsUpdated := map[string]bool{}
+	if since > 0 {
+		userIds, err := a.Srv().Store().User().GetIdsWithMembershipsUpdatedSince(since, includeArchivedChannels)
+		if err != nil {
+			return profilePictures, model.NewAppError("exportAllUsers", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, userId := range userIds {
+			membershipsUpdated[userId] = true
+		}
+	}
+
 	for {
 		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
 
@@ -415,6 +490,10 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 		for _, user := range users {
 			afterId = user.Id
 
+			if user.UpdateAt < since && !membershipsUpdated[user.Id] {
+				continue
+			}
+
 			// Gathering here the exportable preferences to pass them on to ImportLineFromUser
 			exportedPrefs := make(map[string]*string)
 			allPrefs, err := a.GetPreferencesForUser(ctx, user.Id)
@@ -435,6 +514,8 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 						pref.Value = model.PreferenceEmailIntervalFifteen
 					case model.PreferenceEmailIntervalHourAsSeconds:
 						pref.Value = model.PreferenceEmailIntervalHour
+					case model.PreferenceEmailIntervalDayAsSeconds:
+						pref.Value = model.PreferenceEmailIntervalDay
 					case "0":
 						pref.Value = ""
 					}
@@ -562,7 +643,368 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+// exportLookup caches the users, teams and channels referenced by ID in the
+// exported entities, as the import file references them by name.
+type exportLookup struct {
+	store     store.Store
+	usernames map[string]string
+	channels  map[string]*model.Channel
+}
+
+func newExportLookup(s store.Store) *exportLookup {
+	return &exportLookup{
+		store:     s,
+		usernames: make(map[string]string),
+		channels:  make(map[string]*model.Channel),
+	}
+}
+
+// username returns an empty string if the user doesn't exist anymore.
+func (l *exportLookup) username(userID string) (string, *model.AppError) {
+	if username, ok := l.usernames[userID]; ok {
+		return username, nil
+	}
+
+	user, err := l.store.User().Get(context.Background(), userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return "", model.NewAppError("BulkExport", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		l.usernames[userID] = ""
+		return "", nil
+	}
+
+	l.usernames[userID] = user.Username
+	return user.Username, nil
+}
+
+// channel returns nil if the channel doesn't exist anymore.
+func (l *exportLookup) channel(channelID string) (*model.Channel, *model.AppError) {
+	if channel, ok := l.channels[channelID]; ok {
+		return channel, nil
+	}
+
+	channel, err := l.store.Channel().Get(channelID, true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return nil, model.NewAppError("BulkExport", "app.channel.get.find.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		channel = nil
+	}
+
+	l.channels[channelID] = channel
+	return channel, nil
+}
+
+func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, since int64) *model.AppError {
+	lookup := newExportLookup(a.Srv().Store())
+	cnt := 0
+	for page := 0; ; page++ {
+		bots, err := a.Srv().Store().Bot().GetAll(&model.BotGetOptions{IncludeDeleted: true, Page: page, PerPage: 1000})
+		if err != nil {
+			return model.NewAppError("exportAllBots", "app.bot.getbots.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(bots) == 0 {
+			break
+		}
+		cnt += len(bots)
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "bots_exported", cnt)
+
+		for _, bot := range bots {
+			if bot.UpdateAt < since {
+				continue
+			}
+
+			// Bots owned by a plugin keep the plugin ID as their owner.
+			owner, appErr := lookup.username(bot.OwnerId)
+			if appErr != nil {
+				return appErr
+			}
+			if owner == "" {
+				owner = bot.OwnerId
+			}
+
+			if err := a.exportWriteLine(writer, ImportLineFromBot(bot, owner)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportAllGroups always exports every custom group, as adding or removing
+// members doesn't update the group itself.
+func (a *App) exportAllGroups(ctx request.CTX, job *model.Job, writer io.Writer) *model.AppError {
+	groups, err := a.Srv().Store().Group().GetAllBySource(model.GroupSourceCustom)
+	if err != nil {
+		return model.NewAppError("exportAllGroups", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, group := range groups {
+		users, err := a.Srv().Store().Group().GetMemberUsers(group.Id)
+		if err != nil {
+			return model.NewAppError("exportAllGroups", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		members := make([]string, 0, len(users))
+		for _, user := range users {
+			members = append(members, user.Username)
+		}
+
+		if err := a.exportWriteLine(writer, ImportLineFromGroup(group, members)); err != nil {
+			return err
+		}
+	}
+	updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "groups_exported", len(groups))
+
+	return nil
+}
+
+// exportAllChannelBookmarks writes the tombstones of the bookmarks deleted since
+// the given time after the bookmarks themselves.
+func (a *App) exportAllChannelBookmarks(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, since int64) *model.AppError {
+	lookup := newExportLookup(a.Srv().Store())
+	var deleteLines []*imports.LineImportData
+	afterId := strings.Repeat("0", 26)
+	cnt := 0
+	for {
+		channels, err := a.Srv().Store().Channel().GetAllChannelsForExportAfter(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportAllChannelBookmarks", "app.channel.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(channels) == 0 {
+			break
+		}
+
+		for _, channel := range channels {
+			afterId = channel.Id
+
+			// Skip the channels that were not exported.
+			if channel.DeleteAt != 0 && !withArchived {
+				continue
+			}
+			if ok := teamNames[channel.TeamName]; !ok {
+				continue
+			}
+
+			bookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, since)
+			if err != nil {
+				return model.NewAppError("exportAllChannelBookmarks", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			for _, bookmark := range bookmarks {
+				// File bookmarks aren't attached to a post, so they can't be exported as attachments.
+				if bookmark.Type != model.ChannelBookmarkLink {
+					continue
+				}
+
+				if bookmark.DeleteAt != 0 {
+					deleteLines = append(deleteLines, ImportLineForDeletedChannelBookmark(bookmark.ChannelBookmark, channel.TeamName, channel.Name))
+					continue
+				}
+
+				owner, appErr := lookup.username(bookmark.OwnerId)
+				if appErr != nil {
+					return appErr
+				}
+				if owner == "" {
+					continue
+				}
+
+				if err := a.exportWriteLine(writer, ImportLineFromChannelBookmark(bookmark.ChannelBookmark, channel.TeamName, channel.Name, owner)); err != nil {
+					return err
+				}
+				cnt++
+			}
+		}
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "channel_bookmarks_exported", cnt)
+	}
+
+	for _, line := range deleteLines {
+		if err := a.exportWriteLine(writer, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportAllIntegrations exports the incoming webhooks, outgoing webhooks and custom slash commands
+// of the exported teams. Their IDs and tokens are not exported, so new ones are generated on import.
+func (a *App) exportAllIntegrations(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, since int64) *model.AppError {
+	names := make([]string, 0, len(teamNames))
+	for name := range teamNames {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	teams, err := a.Srv().Store().Team().GetByNames(names)
+	if err != nil {
+		return model.NewAppError("exportAllIntegrations", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	lookup := newExportLookup(a.Srv().Store())
+
+	// Integrations whose channel or creator is gone can't be imported.
+	channelName := func(channelID string) (string, *model.AppError) {
+		channel, appErr := lookup.channel(channelID)
+		if appErr != nil || channel == nil {
+			return "", appErr
+		}
+		if channel.DeleteAt != 0 && !withArchived {
+			return "", nil
+		}
+		return channel.Name, nil
+	}
+
+	cnt := 0
+	for _, team := range teams {
+		for offset := 0; ; offset += 1000 {
+			hooks, err := a.Srv().Store().Webhook().GetIncomingByTeam(team.Id, offset, 1000)
+			if err != nil {
+				return model.NewAppError("exportAllIntegrations", "app.webhooks.get_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			for _, hook := range hooks {
+				if hook.UpdateAt < since {
+					continue
+				}
+
+				channel, appErr := channelName(hook.ChannelId)
+				if appErr != nil {
+					return appErr
+				}
+				creator, appErr := lookup.username(hook.UserId)
+				if appErr != nil {
+					return appErr
+				}
+				if channel == "" || creator == "" {
+					continue
+				}
+
+				if err := a.exportWriteLine(writer, ImportLineFromIncomingWebhook(hook, team.Name, channel, creator)); err != nil {
+					return err
+				}
+				cnt++
+			}
+
+			if len(hooks) < 1000 {
+				break
+			}
+		}
+	}
+
+	for _, team := range teams {
+		hooks, err := a.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+		if err != nil {
+			return model.NewAppError("exportAllIntegrations", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, hook := range hooks {
+			if hook.UpdateAt < since {
+				continue
+			}
+
+			var channel string
+			if hook.ChannelId != "" {
+				var appErr *model.AppError
+				if channel, appErr = channelName(hook.ChannelId); appErr != nil {
+					return appErr
+				}
+				if channel == "" {
+					continue
+				}
+			}
+			creator, appErr := lookup.username(hook.CreatorId)
+			if appErr != nil {
+				return appErr
+			}
+			if creator == "" {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, ImportLineFromOutgoingWebhook(hook, team.Name, channel, creator)); err != nil {
+				return err
+			}
+			cnt++
+		}
+	}
+
+	for _, team := range teams {
+		cmds, err := a.Srv().Store().Command().GetByTeam(team.Id)
+		if err != nil {
+			return model.NewAppError("exportAllIntegrations", "app.command.listteamcommands.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, cmd := range cmds {
+			// Plugin commands are registered again by their plugin.
+			if cmd.PluginId != "" || cmd.UpdateAt < since {
+				continue
+			}
+			creator, appErr := lookup.username(cmd.CreatorId)
+			if appErr != nil {
+				return appErr
+			}
+			if creator == "" {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, ImportLineFromCommand(cmd, team.Name, creator)); err != nil {
+				return err
+			}
+			cnt++
+		}
+	}
+	updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "integrations_exported", cnt)
+
+	return nil
+}
+
+// buildPostPrioritiesAndAcknowledgements returns the priority and acknowledgements of the given posts, keyed by post ID.
+func (a *App) buildPostPrioritiesAndAcknowledgements(postIDs []string) (map[string]*imports.PostPriorityImportData, map[string][]imports.PostAcknowledgementImportData, *model.AppError) {
+	priorities, err := a.Srv().Store().PostPriority().GetForPosts(postIDs)
+	if err != nil {
+		return nil, nil, model.NewAppError("buildPostPrioritiesAndAcknowledgements", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	prioritiesByPost := make(map[string]*imports.PostPriorityImportData, len(priorities))
+	for _, priority := range priorities {
+		prioritiesByPost[priority.PostId] = ImportPostPriorityFromPostPriority(priority)
+	}
+
+	acknowledgements, err := a.Srv().Store().PostAcknowledgement().GetForPosts(postIDs)
+	if err != nil {
+		return nil, nil, model.NewAppError("buildPostPrioritiesAndAcknowledgements", "app.acknowledgement.getforpost.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	lookup := newExportLookup(a.Srv().Store())
+	acknowledgementsByPost := make(map[string][]imports.PostAcknowledgementImportData)
+	for _, acknowledgement := range acknowledgements {
+		username, appErr := lookup.username(acknowledgement.UserId)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		// The user that acknowledged the post might've been deleted by now.
+		if username == "" {
+			continue
+		}
+		acknowledgementsByPost[acknowledgement.PostId] = append(acknowledgementsByPost[acknowledgement.PostId], imports.PostAcknowledgementImportData{
+			User:           model.NewString(username),
+			AcknowledgedAt: model.NewInt64(acknowledgement.AcknowledgedAt),
+		})
+	}
+
+	return prioritiesByPost, acknowledgementsByPost, nil
+}
+
+func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool, since int64) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -575,7 +1017,7 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			logCheckpoint = time.Now()
 		}
 
-		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, since, includeArchivedChannels)
 		if nErr != nil {
 			return nil, model.NewAppError("exportAllPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
@@ -586,6 +1028,15 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 		cnt += len(posts)
 		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "posts_exported", cnt)
 
+		postIDs := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.Id)
+		}
+		priorities, acknowledgements, err := a.buildPostPrioritiesAndAcknowledgements(postIDs)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, post := range posts {
 			afterId = post.Id
 			postProcessCount++
@@ -596,6 +1047,10 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			}
 
 			postLine := ImportLineForPost(post)
+			postLine.Post.Priority = priorities[post.Id]
+			if postAcknowledgements, ok := acknowledgements[post.Id]; ok {
+				postLine.Post.Acknowledgements = &postAcknowledgements
+			}
 
 			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments)
 			if err != nil {
@@ -707,7 +1162,7 @@ func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportDat
 	return attachments, nil
 }
 
-func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool) ([]string, *model.AppError) {
+func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool, since int64) ([]string, *model.AppError) {
 	var emojiPaths []string
 	pageNumber := 0
 	cnt := 0
@@ -735,6 +1190,10 @@ func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer,
 		}
 
 		for _, emoji := range customEmojiList {
+			if emoji.UpdateAt < since {
+				continue
+			}
+
 			emojiImagePath := filepath.Join(emojiPath, emoji.Id, "image")
 			filePath := filepath.Join(exportDir, emoji.Id, "image")
 			if exportFiles {
@@ -791,7 +1250,7 @@ func (a *App) copyEmojiImages(emojiId string, emojiImagePath string, pathToDir s
 	return nil
 }
 
-func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool) *model.AppError {
+func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, since int64) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -819,6 +1278,10 @@ func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io
 				continue
 			}
 
+			if channel.UpdateAt < since {
+				continue
+			}
+
 			favoritedBy, err := a.buildFavoritedByList(channel.Id)
 			if err != nil {
 				return err
@@ -857,7 +1320,7 @@ func (a *App) buildFavoritedByList(channelID string) ([]string, *model.AppError)
 	return userIDs, nil
 }
 
-func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool, since int64) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -870,7 +1333,7 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			logCheckpoint = time.Now()
 		}
 
-		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, since, includeArchivedChannels)
 		if err != nil {
 			return nil, model.NewAppError("exportAllDirectPosts", "app.post.get_direct_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -881,6 +1344,15 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 		cnt += len(posts)
 		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "direct_posts_exported", cnt)
 
+		postIDs := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.Id)
+		}
+		priorities, acknowledgements, appErr := a.buildPostPrioritiesAndAcknowledgements(postIDs)
+		if appErr != nil {
+			return nil, appErr
+		}
+
 		for _, post := range posts {
 			afterId = post.Id
 			postProcessCount++
@@ -916,6 +1388,10 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 
 			postLine := ImportLineForDirectPost(post)
 			postLine.DirectPost.Replies = &replies
+			postLine.DirectPost.Priority = priorities[post.Id]
+			if postAcknowledgements, ok := acknowledgements[post.Id]; ok {
+				postLine.DirectPost.Acknowledgements = &postAcknowledgements
+			}
 			if len(postAttachments) > 0 {
 				postLine.DirectPost.Attachments = &postAttachments
 			}
@@ -927,6 +1403,242 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 	return attachments, nil
 }
 
+// exportDeletions writes the tombstones of the teams, memberships, integrations, bots and posts
+// deleted since the given time. Users and channels carry their deletion time in their own lines instead.
+func (a *App) exportDeletions(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, since int64) *model.AppError {
+	cnt := 0
+	afterId := strings.Repeat("0", 26)
+	for {
+		teams, err := a.Srv().Store().Team().GetAllForExportAfter(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportDeletions", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(teams) == 0 {
+			break
+		}
+
+		for _, team := range teams {
+			afterId = team.Id
+			if team.DeleteAt < since {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, ImportLineForDeletedTeam(team)); err != nil {
+				return err
+			}
+			cnt++
+		}
+	}
+
+	lookup := newExportLookup(a.Srv().Store())
+	names := make([]string, 0, len(teamNames))
+	for name := range teamNames {
+		names = append(names, name)
+	}
+	if len(names) > 0 {
+		teams, err := a.Srv().Store().Team().GetByNames(names)
+		if err != nil {
+			return model.NewAppError("exportDeletions", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, team := range teams {
+			n, appErr := a.exportTeamDeletions(writer, lookup, team, since)
+			if appErr != nil {
+				return appErr
+			}
+			cnt += n
+		}
+	}
+
+	for page := 0; ; page++ {
+		bots, err := a.Srv().Store().Bot().GetAll(&model.BotGetOptions{IncludeDeleted: true, Page: page, PerPage: 1000})
+		if err != nil {
+			return model.NewAppError("exportDeletions", "app.bot.getbots.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(bots) == 0 {
+			break
+		}
+
+		for _, bot := range bots {
+			if bot.DeleteAt == 0 || bot.DeleteAt < since {
+				continue
+			}
+			if err := a.exportWriteLine(writer, ImportLineForDeletedBot(bot)); err != nil {
+				return err
+			}
+			cnt++
+		}
+	}
+	updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "deletions_exported", cnt)
+
+	channelMembers := make(map[string][]string)
+	afterId = strings.Repeat("0", 26)
+	for {
+		posts, err := a.Srv().Store().Post().GetDeletedForExportAfter(1000, afterId, since)
+		if err != nil {
+			return model.NewAppError("exportDeletions", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(posts) == 0 {
+			break
+		}
+
+		for _, post := range posts {
+			afterId = post.Id
+
+			if post.ChannelType != model.ChannelTypeDirect && post.ChannelType != model.ChannelTypeGroup {
+				// Posts of deleted teams are removed with their team.
+				if !teamNames[post.TeamName] {
+					continue
+				}
+				if err := a.exportWriteLine(writer, ImportLineForDeletedPost(post, nil)); err != nil {
+					return err
+				}
+				cnt++
+				continue
+			}
+
+			members, ok := channelMembers[post.ChannelId]
+			if !ok {
+				userIDs, err := a.Srv().Store().Channel().GetAllChannelMemberIdsByChannelId(post.ChannelId)
+				if err != nil {
+					return model.NewAppError("exportDeletions", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+				for _, userID := range userIDs {
+					username, appErr := lookup.username(userID)
+					if appErr != nil {
+						return appErr
+					}
+					if username != "" {
+						members = append(members, username)
+					}
+				}
+				channelMembers[post.ChannelId] = members
+			}
+			if len(members) == 1 {
+				members = []string{members[0], members[0]}
+			}
+			if len(members) < 2 {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, ImportLineForDeletedPost(post, members)); err != nil {
+				return err
+			}
+			cnt++
+		}
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "deletions_exported", cnt)
+	}
+
+	return nil
+}
+
+// exportTeamDeletions writes the tombstones of the memberships left and the integrations deleted
+// in the team since the given time, and returns how many were written. Removals whose user or
+// channel doesn't exist anymore are skipped, as the import couldn't find them either.
+func (a *App) exportTeamDeletions(writer io.Writer, lookup *exportLookup, team *model.Team, since int64) (int, *model.AppError) {
+	cnt := 0
+
+	// Team memberships go first, as leaving the team also removes its channel memberships.
+	teamMembers, err := a.Srv().Store().Team().GetMembersLeftSince(team.Id, since)
+	if err != nil {
+		return cnt, model.NewAppError("exportTeamDeletions", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, member := range teamMembers {
+		username, appErr := lookup.username(member.UserId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		if username == "" {
+			continue
+		}
+		if err := a.exportWriteLine(writer, ImportLineForLeftTeamMember(team.Name, username, member.DeleteAt)); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	channelMembers, err := a.Srv().Store().ChannelMemberHistory().GetMembersLeftSince(team.Id, since)
+	if err != nil {
+		return cnt, model.NewAppError("exportTeamDeletions", "app.channel_member_history.get_members_left.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, member := range channelMembers {
+		channel, appErr := lookup.channel(member.ChannelId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		username, appErr := lookup.username(member.UserId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		if channel == nil || username == "" || member.LeaveTime == nil {
+			continue
+		}
+		if err := a.exportWriteLine(writer, ImportLineForLeftChannelMember(team.Name, channel.Name, username, *member.LeaveTime)); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	incomingHooks, err := a.Srv().Store().Webhook().GetIncomingDeletedSince(team.Id, since)
+	if err != nil {
+		return cnt, model.NewAppError("exportTeamDeletions", "app.webhooks.get_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, hook := range incomingHooks {
+		channel, appErr := lookup.channel(hook.ChannelId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		creator, appErr := lookup.username(hook.UserId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		if channel == nil || creator == "" {
+			continue
+		}
+		if err := a.exportWriteLine(writer, ImportLineForDeletedIncomingWebhook(hook, team.Name, channel.Name, creator)); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	outgoingHooks, err := a.Srv().Store().Webhook().GetOutgoingDeletedSince(team.Id, since)
+	if err != nil {
+		return cnt, model.NewAppError("exportTeamDeletions", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, hook := range outgoingHooks {
+		creator, appErr := lookup.username(hook.CreatorId)
+		if appErr != nil {
+			return cnt, appErr
+		}
+		if creator == "" {
+			continue
+		}
+		if err := a.exportWriteLine(writer, ImportLineForDeletedOutgoingWebhook(hook, team.Name, creator)); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	cmds, err := a.Srv().Store().Command().GetDeletedByTeamSince(team.Id, since)
+	if err != nil {
+		return cnt, model.NewAppError("exportTeamDeletions", "app.command.listteamcommands.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, cmd := range cmds {
+		// Plugin commands are registered again by their plugin.
+		if cmd.PluginId != "" {
+			continue
+		}
+		if err := a.exportWriteLine(writer, ImportLineForDeletedCommand(cmd, team.Name)); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	return cnt, nil
+}
+
 func (a *App) exportFile(outPath, filePath string, zipWr *zip.Writer) *model.AppError {
 	var wr io.Writer
 	var err error
@@ -217,6 +217,118 @@ func ImportReactionFromPost(user *model.User, reaction *model.Reaction) *imports
 	}
 }
 
+func ImportLineFromBot(bot *model.Bot, owner string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "bot",
+		Bot: &imports.BotImportData{
+			Username:    &bot.Username,
+			Owner:       &owner,
+			Description: &bot.Description,
+			DeleteAt:    &bot.DeleteAt,
+		},
+	}
+}
+
+func ImportLineFromGroup(group *model.Group, members []string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "group",
+		Group: &imports.GroupImportData{
+			Name:           group.Name,
+			DisplayName:    &group.DisplayName,
+			Description:    &group.Description,
+			AllowReference: &group.AllowReference,
+			Members:        &members,
+		},
+	}
+}
+
+func ImportLineFromChannelBookmark(bookmark *model.ChannelBookmark, teamName, channelName, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "channel_bookmark",
+		ChannelBookmark: &imports.ChannelBookmarkImportData{
+			Team:        &teamName,
+			Channel:     &channelName,
+			User:        &username,
+			DisplayName: &bookmark.DisplayName,
+			LinkURL:     &bookmark.LinkUrl,
+			ImageURL:    &bookmark.ImageUrl,
+			Emoji:       &bookmark.Emoji,
+			SortOrder:   &bookmark.SortOrder,
+		},
+	}
+}
+
+func ImportLineFromIncomingWebhook(hook *model.IncomingWebhook, teamName, channelName, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "incoming_webhook",
+		IncomingWebhook: &imports.IncomingWebhookImportData{
+			Id:            &hook.Id,
+			Team:          &teamName,
+			Channel:       &channelName,
+			User:          &username,
+			DisplayName:   &hook.DisplayName,
+			Description:   &hook.Description,
+			Username:      &hook.Username,
+			IconURL:       &hook.IconURL,
+			ChannelLocked: &hook.ChannelLocked,
+		},
+	}
+}
+
+func ImportLineFromOutgoingWebhook(hook *model.OutgoingWebhook, teamName, channelName, username string) *imports.LineImportData {
+	triggerWords := []string(hook.TriggerWords)
+	callbackURLs := []string(hook.CallbackURLs)
+	line := &imports.LineImportData{
+		Type: "outgoing_webhook",
+		OutgoingWebhook: &imports.OutgoingWebhookImportData{
+			Team:         &teamName,
+			User:         &username,
+			DisplayName:  &hook.DisplayName,
+			Description:  &hook.Description,
+			TriggerWords: &triggerWords,
+			TriggerWhen:  &hook.TriggerWhen,
+			CallbackURLs: &callbackURLs,
+			ContentType:  &hook.ContentType,
+			Username:     &hook.Username,
+			IconURL:      &hook.IconURL,
+			Token:        &hook.Token,
+		},
+	}
+	if channelName != "" {
+		line.OutgoingWebhook.Channel = &channelName
+	}
+	return line
+}
+
+func ImportLineFromCommand(cmd *model.Command, teamName, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "command",
+		Command: &imports.CommandImportData{
+			Team:             &teamName,
+			User:             &username,
+			Trigger:          &cmd.Trigger,
+			Method:           &cmd.Method,
+			URL:              &cmd.URL,
+			DisplayName:      &cmd.DisplayName,
+			Description:      &cmd.Description,
+			Username:         &cmd.Username,
+			IconURL:          &cmd.IconURL,
+			AutoComplete:     &cmd.AutoComplete,
+			AutoCompleteDesc: &cmd.AutoCompleteDesc,
+			AutoCompleteHint: &cmd.AutoCompleteHint,
+			Token:            &cmd.Token,
+		},
+	}
+}
+
+func ImportPostPriorityFromPostPriority(priority *model.PostPriority) *imports.PostPriorityImportData {
+	return &imports.PostPriorityImportData{
+		Priority:                priority.Priority,
+		RequestedAck:            priority.RequestedAck,
+		PersistentNotifications: priority.PersistentNotifications,
+	}
+}
+
 func ImportLineFromEmoji(emoji *model.Emoji, filePath string) *imports.LineImportData {
 	return &imports.LineImportData{
 		Type: "emoji",
@@ -269,3 +381,128 @@ func ImportLineFromScheme(scheme *model.Scheme, rolesMap map[string]*model.Role)
 		Scheme: data,
 	}
 }
+
+func ImportLineForDeletedTeam(team *model.TeamForExport) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewString(imports.DeleteTypeTeam),
+			Team:     &team.Name,
+			DeleteAt: &team.DeleteAt,
+		},
+	}
+}
+
+// ImportLineForDeletedPost returns the tombstone of a channel post, or of a direct post
+// when the members of its channel are given.
+func ImportLineForDeletedPost(post *model.DeletedPostForExport, channelMembers []string) *imports.LineImportData {
+	data := &imports.DeleteImportData{
+		Type:     model.NewString(imports.DeleteTypePost),
+		User:     &post.Username,
+		CreateAt: &post.CreateAt,
+		DeleteAt: &post.DeleteAt,
+	}
+
+	if channelMembers != nil {
+		data.Type = model.NewString(imports.DeleteTypeDirectPost)
+		data.ChannelMembers = &channelMembers
+	} else {
+		data.Team = &post.TeamName
+		data.Channel = &post.ChannelName
+	}
+
+	return &imports.LineImportData{
+		Type:   "delete",
+		Delete: data,
+	}
+}
+
+func ImportLineForDeletedChannelBookmark(bookmark *model.ChannelBookmark, teamName, channelName string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:        model.NewString(imports.DeleteTypeChannelBookmark),
+			Team:        &teamName,
+			Channel:     &channelName,
+			DisplayName: &bookmark.DisplayName,
+			LinkURL:     &bookmark.LinkUrl,
+			DeleteAt:    &bookmark.DeleteAt,
+		},
+	}
+}
+
+func ImportLineForLeftTeamMember(teamName, username string, deleteAt int64) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewString(imports.DeleteTypeTeamMembership),
+			Team:     &teamName,
+			User:     &username,
+			DeleteAt: &deleteAt,
+		},
+	}
+}
+
+func ImportLineForLeftChannelMember(teamName, channelName, username string, leaveTime int64) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewString(imports.DeleteTypeChannelMembership),
+			Team:     &teamName,
+			Channel:  &channelName,
+			User:     &username,
+			DeleteAt: &leaveTime,
+		},
+	}
+}
+
+func ImportLineForDeletedIncomingWebhook(hook *model.IncomingWebhook, teamName, channelName, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:        model.NewString(imports.DeleteTypeIncomingWebhook),
+			Id:          &hook.Id,
+			Team:        &teamName,
+			Channel:     &channelName,
+			User:        &username,
+			DisplayName: &hook.DisplayName,
+			DeleteAt:    &hook.DeleteAt,
+		},
+	}
+}
+
+func ImportLineForDeletedOutgoingWebhook(hook *model.OutgoingWebhook, teamName, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:        model.NewString(imports.DeleteTypeOutgoingWebhook),
+			Team:        &teamName,
+			User:        &username,
+			DisplayName: &hook.DisplayName,
+			DeleteAt:    &hook.DeleteAt,
+		},
+	}
+}
+
+func ImportLineForDeletedCommand(cmd *model.Command, teamName string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewString(imports.DeleteTypeCommand),
+			Team:     &teamName,
+			Trigger:  &cmd.Trigger,
+			DeleteAt: &cmd.DeleteAt,
+		},
+	}
+}
+
+func ImportLineForDeletedBot(bot *model.Bot) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewString(imports.DeleteTypeBot),
+			User:     &bot.Username,
+			DeleteAt: &bot.DeleteAt,
+		},
+	}
+}
@@ -5,16 +5,20 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
@@ -175,7 +179,7 @@ func TestExportCustomEmoji(t *testing.T) {
 	outPath, err := filepath.Abs(filePath)
 	require.NoError(t, err)
 
-	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false)
+	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false, 0)
 	require.Nil(t, appErr, "should not have failed")
 }
 
@@ -465,7 +469,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	}
 	th1.App.CreatePost(th1.Context, p4, gmChannel, false, true)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 	assert.Equal(t, 4, len(posts))
 
@@ -478,7 +482,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(posts))
 
@@ -487,7 +491,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -538,7 +542,7 @@ func TestExportPostWithProps(t *testing.T) {
 	}
 	th1.App.CreatePost(th1.Context, p2, gmChannel, false, true)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 	assert.Len(t, posts, 2)
 	require.NotEmpty(t, posts[0].Props)
@@ -553,7 +557,7 @@ func TestExportPostWithProps(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 	assert.Len(t, posts, 0)
 
@@ -562,7 +566,7 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -586,7 +590,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	err := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
 	require.Nil(t, err)
 
-	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 
@@ -595,7 +599,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, nErr)
 	assert.Equal(t, 0, len(posts))
 
@@ -604,7 +608,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, i)
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", 0, false)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 	assert.Equal(t, 1, len((*posts[0].ChannelMembers)))
@@ -1213,3 +1217,346 @@ func TestExportSchemes(t *testing.T) {
 		require.Equal(t, customTeamGuestRole.BuiltIn, importedTeamGuestRole.BuiltIn)
 	})
 }
+
+func TestExportIntegrationsAndBookmarks(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	bot, appErr := th1.App.CreateBot(th1.Context, &model.Bot{
+		Username:    "exportbot",
+		Description: "a bot to export",
+		OwnerId:     th1.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	group, err := th1.App.Srv().Store().Group().Create(&model.Group{
+		Name:           model.NewString("developers"),
+		DisplayName:    "Developers",
+		Source:         model.GroupSourceCustom,
+		AllowReference: true,
+	})
+	require.NoError(t, err)
+	_, err = th1.App.Srv().Store().Group().UpsertMember(group.Id, th1.BasicUser2.Id)
+	require.NoError(t, err)
+
+	_, err = th1.App.Srv().Store().ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   th1.BasicChannel.Id,
+		OwnerId:     th1.BasicUser.Id,
+		DisplayName: "Docs",
+		LinkUrl:     "https://mattermost.com/docs",
+		Type:        model.ChannelBookmarkLink,
+	}, true)
+	require.NoError(t, err)
+
+	incomingHook, err := th1.App.Srv().Store().Webhook().SaveIncoming(&model.IncomingWebhook{
+		UserId:      th1.BasicUser.Id,
+		ChannelId:   th1.BasicChannel.Id,
+		TeamId:      th1.BasicTeam.Id,
+		DisplayName: "incoming",
+	})
+	require.NoError(t, err)
+	unnamedHook, err := th1.App.Srv().Store().Webhook().SaveIncoming(&model.IncomingWebhook{
+		UserId:    th1.BasicUser2.Id,
+		ChannelId: th1.BasicChannel.Id,
+		TeamId:    th1.BasicTeam.Id,
+	})
+	require.NoError(t, err)
+
+	outgoingHook, err := th1.App.Srv().Store().Webhook().SaveOutgoing(&model.OutgoingWebhook{
+		CreatorId:    th1.BasicUser.Id,
+		TeamId:       th1.BasicTeam.Id,
+		DisplayName:  "outgoing",
+		TriggerWords: []string{"build"},
+		CallbackURLs: []string{"https://example.com/hook"},
+	})
+	require.NoError(t, err)
+
+	command, err := th1.App.Srv().Store().Command().Save(&model.Command{
+		CreatorId: th1.BasicUser.Id,
+		TeamId:    th1.BasicTeam.Id,
+		Trigger:   "deploy",
+		Method:    model.CommandMethodPost,
+		URL:       "https://example.com/deploy",
+	})
+	require.NoError(t, err)
+
+	post, appErr := th1.App.CreatePost(th1.Context, &model.Post{
+		ChannelId: th1.BasicChannel.Id,
+		UserId:    th1.BasicUser.Id,
+		Message:   "urgent message",
+		Metadata: &model.PostMetadata{
+			Priority: &model.PostPriority{
+				Priority:     model.NewString(model.PostPriorityUrgent),
+				RequestedAck: model.NewBool(true),
+			},
+		},
+	}, th1.BasicChannel, false, true)
+	require.Nil(t, appErr)
+	_, err = th1.App.Srv().Store().PostAcknowledgement().Save(post.Id, th1.BasicUser2.Id, 0)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	exported := b.Bytes()
+	appErr, i := th2.App.BulkImport(th2.Context, bytes.NewReader(exported), nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	// Importing again updates the integrations rather than duplicating them.
+	appErr, i = th2.App.BulkImport(th2.Context, bytes.NewReader(exported), nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	user, err := th2.App.Srv().Store().User().GetByUsername(bot.Username)
+	require.NoError(t, err)
+	importedBot, err := th2.App.Srv().Store().Bot().Get(user.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, "a bot to export", importedBot.Description)
+
+	importedGroup, err := th2.App.Srv().Store().Group().GetByName("developers", model.GroupSearchOpts{})
+	require.NoError(t, err)
+	members, err := th2.App.Srv().Store().Group().GetMemberUsers(importedGroup.Id)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, th1.BasicUser2.Username, members[0].Username)
+
+	team, err := th2.App.Srv().Store().Team().GetByName(th1.BasicTeam.Name)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, th1.BasicChannel.Name, false)
+	require.NoError(t, err)
+
+	bookmarks, err := th2.App.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, "https://mattermost.com/docs", bookmarks[0].LinkUrl)
+
+	incoming, err := th2.App.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	require.NoError(t, err)
+	require.Len(t, incoming, 2)
+	importedHook, err := th2.App.Srv().Store().Webhook().GetIncoming(incomingHook.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, "incoming", importedHook.DisplayName)
+	importedHook, err = th2.App.Srv().Store().Webhook().GetIncoming(unnamedHook.Id, false)
+	require.NoError(t, err)
+	assert.Empty(t, importedHook.DisplayName)
+
+	outgoing, err := th2.App.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	require.NoError(t, err)
+	require.Len(t, outgoing, 1)
+	assert.Equal(t, []string{"build"}, []string(outgoing[0].TriggerWords))
+	assert.Equal(t, outgoingHook.Token, outgoing[0].Token)
+
+	cmd, err := th2.App.Srv().Store().Command().GetByTrigger(team.Id, "deploy")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/deploy", cmd.URL)
+	assert.Equal(t, command.Token, cmd.Token)
+
+	posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, post.CreateAt)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	priority, err := th2.App.Srv().Store().PostPriority().GetForPost(posts[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostPriorityUrgent, *priority.Priority)
+	acknowledgements, err := th2.App.Srv().Store().PostAcknowledgement().GetForPost(posts[0].Id)
+	require.NoError(t, err)
+	assert.Len(t, acknowledgements, 1)
+}
+
+func TestExportIncremental(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	kept, appErr := th1.App.CreatePost(th1.Context, &model.Post{
+		ChannelId: th1.BasicChannel.Id,
+		UserId:    th1.BasicUser.Id,
+		Message:   "kept",
+	}, th1.BasicChannel, false, true)
+	require.Nil(t, appErr)
+	deleted, appErr := th1.App.CreatePost(th1.Context, &model.Post{
+		ChannelId: th1.BasicChannel.Id,
+		UserId:    th1.BasicUser.Id,
+		Message:   "deleted",
+	}, th1.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	var full bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &full, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	time.Sleep(time.Millisecond)
+	since := model.GetMillis()
+
+	_, appErr = th1.App.DeletePost(th1.Context, deleted.Id, th1.BasicUser.Id)
+	require.Nil(t, appErr)
+	added, appErr := th1.App.CreatePost(th1.Context, &model.Post{
+		ChannelId: th1.BasicChannel.Id,
+		UserId:    th1.BasicUser.Id,
+		Message:   "added",
+	}, th1.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	var delta bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &delta, "somePath", nil, model.BulkExportOpts{Since: since})
+	require.Nil(t, appErr)
+
+	assert.NotContains(t, delta.String(), `"message":"kept"`)
+	assert.Contains(t, delta.String(), `"message":"added"`)
+	assert.Contains(t, delta.String(), `"type":"delete"`)
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, i := th2.App.BulkImport(th2.Context, &full, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	deltaData := delta.Bytes()
+	// Applying the same delta twice must give the same result.
+	for n := 0; n < 2; n++ {
+		appErr, i = th2.App.BulkImport(th2.Context, bytes.NewReader(deltaData), nil, false, 5)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, i)
+	}
+
+	team, err := th2.App.Srv().Store().Team().GetByName(th1.BasicTeam.Name)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, th1.BasicChannel.Name, false)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		post    *model.Post
+		deleted bool
+	}{
+		{kept, false},
+		{deleted, true},
+		{added, false},
+	} {
+		posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, tc.post.CreateAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1, tc.post.Message)
+		assert.Equal(t, tc.deleted, posts[0].DeleteAt != 0, tc.post.Message)
+	}
+}
+
+func TestExportIncrementalRemovals(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	leaver := th1.CreateUser()
+	th1.LinkUserToTeam(leaver, th1.BasicTeam)
+
+	bot, appErr := th1.App.CreateBot(th1.Context, &model.Bot{
+		Username: "removedbot",
+		OwnerId:  th1.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	incomingHook, err := th1.App.Srv().Store().Webhook().SaveIncoming(&model.IncomingWebhook{
+		UserId:      th1.BasicUser.Id,
+		ChannelId:   th1.BasicChannel.Id,
+		TeamId:      th1.BasicTeam.Id,
+		DisplayName: "incoming",
+	})
+	require.NoError(t, err)
+	outgoingHook, err := th1.App.Srv().Store().Webhook().SaveOutgoing(&model.OutgoingWebhook{
+		CreatorId:    th1.BasicUser.Id,
+		TeamId:       th1.BasicTeam.Id,
+		DisplayName:  "outgoing",
+		TriggerWords: []string{"build"},
+		CallbackURLs: []string{"https://example.com/hook"},
+	})
+	require.NoError(t, err)
+	command, err := th1.App.Srv().Store().Command().Save(&model.Command{
+		CreatorId: th1.BasicUser.Id,
+		TeamId:    th1.BasicTeam.Id,
+		Trigger:   "deploy",
+		Method:    model.CommandMethodPost,
+		URL:       "https://example.com/deploy",
+	})
+	require.NoError(t, err)
+
+	var full bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &full, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	time.Sleep(time.Millisecond)
+	since := model.GetMillis()
+
+	require.Nil(t, th1.App.RemoveUserFromChannel(th1.Context, th1.BasicUser2.Id, th1.BasicUser2.Id, th1.BasicChannel))
+	require.Nil(t, th1.App.RemoveUserFromTeam(th1.Context, th1.BasicTeam.Id, leaver.Id, leaver.Id))
+	require.NoError(t, th1.App.Srv().Store().Webhook().DeleteIncoming(incomingHook.Id, model.GetMillis()))
+	require.NoError(t, th1.App.Srv().Store().Webhook().DeleteOutgoing(outgoingHook.Id, model.GetMillis()))
+	require.NoError(t, th1.App.Srv().Store().Command().Delete(command.Id, model.GetMillis()))
+	_, appErr = th1.App.UpdateBotActive(th1.Context, bot.UserId, false)
+	require.Nil(t, appErr)
+
+	var delta bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &delta, "somePath", nil, model.BulkExportOpts{Since: since})
+	require.Nil(t, appErr)
+
+	for _, deleteType := range []string{
+		imports.DeleteTypeTeamMembership,
+		imports.DeleteTypeChannelMembership,
+		imports.DeleteTypeIncomingWebhook,
+		imports.DeleteTypeOutgoingWebhook,
+		imports.DeleteTypeCommand,
+		imports.DeleteTypeBot,
+	} {
+		assert.Contains(t, delta.String(), `"type":"`+deleteType+`"`)
+	}
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, i := th2.App.BulkImport(th2.Context, &full, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	deltaData := delta.Bytes()
+	// Applying the same delta twice must give the same result.
+	for n := 0; n < 2; n++ {
+		appErr, i = th2.App.BulkImport(th2.Context, bytes.NewReader(deltaData), nil, false, 5)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, i)
+	}
+
+	team, err := th2.App.Srv().Store().Team().GetByName(th1.BasicTeam.Name)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, th1.BasicChannel.Name, false)
+	require.NoError(t, err)
+
+	user2, err := th2.App.Srv().Store().User().GetByUsername(th1.BasicUser2.Username)
+	require.NoError(t, err)
+	_, err = th2.App.Srv().Store().Channel().GetMember(context.Background(), channel.Id, user2.Id)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	importedLeaver, err := th2.App.Srv().Store().User().GetByUsername(leaver.Username)
+	require.NoError(t, err)
+	member, err := th2.App.Srv().Store().Team().GetMember(th2.Context, team.Id, importedLeaver.Id)
+	require.NoError(t, err)
+	assert.NotZero(t, member.DeleteAt)
+
+	incoming, err := th2.App.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	require.NoError(t, err)
+	assert.Empty(t, incoming)
+	outgoing, err := th2.App.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	require.NoError(t, err)
+	assert.Empty(t, outgoing)
+	_, err = th2.App.Srv().Store().Command().GetByTrigger(team.Id, "deploy")
+	assert.ErrorAs(t, err, &nfErr)
+
+	botUser, err := th2.App.Srv().Store().User().GetByUsername(bot.Username)
+	require.NoError(t, err)
+	importedBot, err := th2.App.Srv().Store().Bot().Get(botUser.Id, true)
+	require.NoError(t, err)
+	assert.NotZero(t, importedBot.DeleteAt)
+}
@@ -64,6 +64,13 @@ func (a *App) CheckMandatoryS3Fields(settings *model.FileSettings) *model.AppErr
 		fileBackendSettings = filestore.NewFileBackendSettingsFromConfig(settings, false, false)
 	}
 
+	if fileBackendSettings.DriverName == model.ImageDriverAzure {
+		if err := fileBackendSettings.CheckMandatoryAzureFields(); err != nil {
+			return model.NewAppError("CheckMandatoryS3Fields", "api.admin.test_azure.missing_azure_container", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		return nil
+	}
+
 	err := fileBackendSettings.CheckMandatoryS3Fields()
 	if err != nil {
 		return model.NewAppError("CheckMandatoryS3Fields", "api.admin.test_s3.missing_s3_bucket", nil, "", http.StatusBadRequest).Wrap(err)
@@ -77,6 +84,10 @@ func connectionTestErrorToAppError(connTestErr error) *model.AppError {
 		return model.NewAppError("TestConnection", "api.file.test_connection_s3_auth.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	case *filestore.S3FileBackendNoBucketError:
 		return model.NewAppError("TestConnection", "api.file.test_connection_s3_bucket_does_not_exist.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	case *filestore.AzureFileBackendAuthError:
+		return model.NewAppError("TestConnection", "api.file.test_connection_azure_auth.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	case *filestore.AzureFileBackendNoContainerError:
+		return model.NewAppError("TestConnection", "api.file.test_connection_azure_container_does_not_exist.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	default:
 		return model.NewAppError("TestConnection", "api.file.test_connection.app_error", nil, "", http.StatusInternalServerError).Wrap(connTestErr)
 	}
@@ -1177,6 +1188,50 @@ func (a *App) generatePreviewImage(rctx request.CTX, img image.Image, imgType, p
 	}
 }
 
+// RegenerateFilePreviews generates again the thumbnail, preview and mini preview of an
+// image file, setting the preview paths of images uploaded before they could be decoded.
+func (a *App) RegenerateFilePreviews(rctx request.CTX, fileInfo *model.FileInfo) error {
+	if !fileInfo.IsImage() || fileInfo.IsSvg() || fileInfo.MimeType == "image/gif" {
+		return nil
+	}
+
+	file, appErr := a.FileReader(fileInfo.Path)
+	if appErr != nil {
+		return errors.Wrap(appErr, "failed to open image file")
+	}
+	defer file.Close()
+
+	img, imgType, release, err := prepareImage(rctx, a.ch.imgDecoder, file)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if fileInfo.PreviewPath == "" || fileInfo.ThumbnailPath == "" {
+		pathWithoutExtension := strings.TrimSuffix(fileInfo.Path, path.Ext(fileInfo.Path))
+		fileInfo.PreviewPath = pathWithoutExtension + "_preview." + getFileExtFromMimeType(fileInfo.MimeType)
+		fileInfo.ThumbnailPath = pathWithoutExtension + "_thumb." + getFileExtFromMimeType(fileInfo.MimeType)
+	}
+	fileInfo.HasPreviewImage = true
+
+	a.generateThumbnailImage(rctx, img, imgType, fileInfo.ThumbnailPath)
+	a.generatePreviewImage(rctx, img, imgType, fileInfo.PreviewPath)
+
+	miniPreview, err := imaging.GenerateMiniPreviewImage(img, miniPreviewImageWidth, miniPreviewImageHeight, jpegEncQuality)
+	if err != nil {
+		rctx.Logger().Info("Unable to generate mini preview image", mlog.Err(err), mlog.String("file_info_id", fileInfo.Id))
+	} else {
+		fileInfo.MiniPreview = &miniPreview
+	}
+
+	if _, err := a.Srv().Store().FileInfo().Upsert(rctx, fileInfo); err != nil {
+		return errors.Wrap(err, "failed to save the file info")
+	}
+	a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(fileInfo.PostId, false)
+
+	return nil
+}
+
 // generateMiniPreview updates mini preview if needed
 // will save fileinfo with the preview added
 func (a *App) generateMiniPreview(rctx request.CTX, fi *model.FileInfo) {
@@ -1457,21 +1512,21 @@ func (a *App) SearchFilesInTeamForUser(c request.CTX, terms string, userId strin
 }
 
 func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error {
-	// We don't process images.
-	if fileInfo.IsImage() {
-		return nil
-	}
+	text := a.extractContentWithPlugins(rctx, fileInfo)
 
-	file, aerr := a.FileReader(fileInfo.Path)
-	if aerr != nil {
-		return errors.Wrap(aerr, "failed to open file for extract file content")
-	}
-	defer file.Close()
-	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
-		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
-	})
-	if err != nil {
-		return errors.Wrap(err, "failed to extract file content")
+	// We don't process images, only plugins do.
+	if text == "" && !fileInfo.IsImage() {
+		file, aerr := a.FileReader(fileInfo.Path)
+		if aerr != nil {
+			return errors.Wrap(aerr, "failed to open file for extract file content")
+		}
+		defer file.Close()
+
+		var err error
+		text, err = docextractor.Extract(rctx.Logger(), fileInfo.Name, file, a.contentExtractSettings())
+		if err != nil {
+			return errors.Wrap(err, "failed to extract file content")
+		}
 	}
 	if text != "" {
 		if len(text) > maxContentExtractionSize {
@@ -1490,6 +1545,51 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 	return nil
 }
 
+// extractContentWithPlugins returns the text extracted from the file by the first plugin
+// implementing the ExtractFileContent hook to return some.
+func (a *App) extractContentWithPlugins(rctx request.CTX, fileInfo *model.FileInfo) string {
+	var text string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks) bool {
+		pluginText, err := hooks.ExtractFileContent(pluginContext, fileInfo)
+		if err != nil {
+			rctx.Logger().Warn("Plugin failed to extract file content", mlog.Err(err), mlog.String("file_info_id", fileInfo.Id))
+			return true
+		}
+		text = pluginText
+		return text == ""
+	}, plugin.ExtractFileContentID)
+
+	return text
+}
+
+// contentExtractSettings returns the settings of the content extraction, with the limits of
+// each extension completed by the default ones.
+func (a *App) contentExtractSettings() docextractor.ExtractSettings {
+	fileSettings := a.Config().FileSettings
+	settings := docextractor.ExtractSettings{
+		ArchiveRecursion: *fileSettings.ArchiveRecursion,
+		Limits:           make(map[string]docextractor.ExtractLimits, len(fileSettings.ExtractContentLimits)),
+		DefaultLimits: docextractor.ExtractLimits{
+			MaxFileSize: *fileSettings.ExtractContentMaxFileSize,
+			Timeout:     time.Duration(*fileSettings.ExtractContentTimeoutSeconds) * time.Second,
+		},
+	}
+
+	for extension, limits := range fileSettings.ExtractContentLimits {
+		extensionLimits := settings.DefaultLimits
+		if limits.MaxFileSize != nil {
+			extensionLimits.MaxFileSize = *limits.MaxFileSize
+		}
+		if limits.TimeoutSeconds != nil {
+			extensionLimits.Timeout = time.Duration(*limits.TimeoutSeconds) * time.Second
+		}
+		settings.Limits[strings.ToLower(strings.TrimPrefix(extension, "."))] = extensionLimits
+	}
+
+	return settings
+}
+
 // GetLastAccessibleFileTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
 func (a *App) GetLastAccessibleFileTime() (int64, *model.AppError) {
 	license := a.Srv().License()
@@ -5,6 +5,7 @@ package app
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"image"
@@ -24,7 +25,9 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
 	eMocks "github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/mocks"
 	filesStoreMocks "github.com/mattermost/mattermost/server/v8/platform/shared/filestore/mocks"
 )
@@ -397,6 +400,41 @@ func TestGenerateThumbnailImage(t *testing.T) {
 	})
 }
 
+func TestRegenerateFilePreviews(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	img, err := testutils.ReadTestFile("testjpg.jpg")
+	require.NoError(t, err)
+	_, appErr := th.App.WriteFile(bytes.NewReader(img), "regenerate/test.jpg")
+	require.Nil(t, appErr)
+	defer th.App.RemoveDirectory("regenerate")
+
+	info, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+		CreatorId: th.BasicUser.Id,
+		Path:      "regenerate/test.jpg",
+		Name:      "test.jpg",
+		Extension: "jpg",
+		MimeType:  "image/jpeg",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, th.App.RegenerateFilePreviews(th.Context, info))
+
+	info, err = th.App.Srv().Store().FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.True(t, info.HasPreviewImage)
+	assert.Equal(t, "regenerate/test_thumb.jpg", info.ThumbnailPath)
+	assert.Equal(t, "regenerate/test_preview.jpg", info.PreviewPath)
+	assert.NotNil(t, info.MiniPreview)
+
+	for _, path := range []string{info.ThumbnailPath, info.PreviewPath} {
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		assert.True(t, exists, path)
+	}
+}
+
 func createDummyImage() *image.RGBA {
 	width := 200
 	height := 100
@@ -599,13 +637,36 @@ func TestSearchFilesInTeamForUser(t *testing.T) {
 }
 
 func TestExtractContentFromFileInfo(t *testing.T) {
-	app := &App{}
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
 	fi := &model.FileInfo{
 		MimeType: "image/jpeg",
 	}
 
 	// Test that we don't process images.
-	require.NoError(t, app.ExtractContentFromFileInfo(request.TestContext(t), fi))
+	require.NoError(t, th.App.ExtractContentFromFileInfo(request.TestContext(t), fi))
+}
+
+func TestContentExtractSettings(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.ExtractContentMaxFileSize = 1000
+		*cfg.FileSettings.ExtractContentTimeoutSeconds = 10
+		cfg.FileSettings.ExtractContentLimits = map[string]*model.ExtractContentLimits{
+			".XLSX": {MaxFileSize: model.NewInt64(5000)},
+			"eml":   {TimeoutSeconds: model.NewInt64(0)},
+		}
+	})
+
+	settings := th.App.contentExtractSettings()
+	assert.Equal(t, docextractor.ExtractLimits{MaxFileSize: 1000, Timeout: 10 * time.Second}, settings.DefaultLimits)
+	assert.Equal(t, map[string]docextractor.ExtractLimits{
+		"xlsx": {MaxFileSize: 5000, Timeout: 10 * time.Second},
+		"eml":  {MaxFileSize: 1000},
+	}, settings.Limits)
 }
 
 func TestGetLastAccessibleFileTime(t *testing.T) {
@@ -54,6 +54,22 @@ func GenerateThumbnail(img image.Image, targetWidth, targetHeight int) image.Ima
 	return thumb
 }
 
+// FitImage scales the image down to fit in the given width and height, keeping its aspect
+// ratio. A zero width or height leaves that dimension unconstrained.
+func FitImage(img image.Image, width, height int) image.Image {
+	if width == 0 {
+		width = img.Bounds().Dx()
+	}
+	if height == 0 {
+		height = img.Bounds().Dy()
+	}
+	if img.Bounds().Dx() <= width && img.Bounds().Dy() <= height {
+		return img
+	}
+
+	return imaging.Fit(img, width, height, imaging.Lanczos)
+}
+
 // GenerateMiniPreviewImage generates the mini preview for the given image.
 func GenerateMiniPreviewImage(img image.Image, w, h, q int) ([]byte, error) {
 	var buf bytes.Buffer
@@ -339,6 +339,36 @@ func (a *App) importLine(c request.CTX, line imports.LineImportData, dryRun bool
 			return model.NewAppError("BulkImport", "app.import.import_line.null_user.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importUser(c, line.User, dryRun)
+	case line.Type == "bot":
+		if line.Bot == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_bot.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importBot(c, line.Bot, dryRun)
+	case line.Type == "group":
+		if line.Group == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_group.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importGroup(c, line.Group, dryRun)
+	case line.Type == "channel_bookmark":
+		if line.ChannelBookmark == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_channel_bookmark.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importChannelBookmark(c, line.ChannelBookmark, dryRun)
+	case line.Type == "incoming_webhook":
+		if line.IncomingWebhook == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_incoming_webhook.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importIncomingWebhook(c, line.IncomingWebhook, dryRun)
+	case line.Type == "outgoing_webhook":
+		if line.OutgoingWebhook == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_outgoing_webhook.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importOutgoingWebhook(c, line.OutgoingWebhook, dryRun)
+	case line.Type == "command":
+		if line.Command == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_command.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importCommand(c, line.Command, dryRun)
 	case line.Type == "direct_channel":
 		if line.DirectChannel == nil {
 			return model.NewAppError("BulkImport", "app.import.import_line.null_direct_channel.error", nil, "", http.StatusBadRequest)
@@ -349,6 +379,11 @@ func (a *App) importLine(c request.CTX, line imports.LineImportData, dryRun bool
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(c, line.Emoji, dryRun)
+	case line.Type == "delete":
+		if line.Delete == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_delete.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importDelete(c, line.Delete, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
 	}
@@ -769,6 +769,8 @@ func (a *App) importUser(rctx request.CTX, data *imports.UserImportData, dryRun
 				intervalSeconds = model.PreferenceEmailIntervalFifteenAsSeconds
 			case model.PreferenceEmailIntervalHour:
 				intervalSeconds = model.PreferenceEmailIntervalHourAsSeconds
+			case model.PreferenceEmailIntervalDay:
+				intervalSeconds = model.PreferenceEmailIntervalDayAsSeconds
 			}
 		}
 		if intervalSeconds != "" {
@@ -1609,6 +1611,9 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		if line.Post.IsPinned != nil {
 			post.IsPinned = *line.Post.IsPinned
 		}
+		if line.Post.Priority != nil {
+			setImportedPostPriority(post, line.Post.Priority)
+		}
 
 		fileIDs := a.uploadAttachments(rctx, line.Post.Attachments, post, team.Id, extractContent)
 		for _, fileID := range post.FileIds {
@@ -1697,6 +1702,15 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if postWithData.postData.Acknowledgements != nil {
+			for _, acknowledgement := range *postWithData.postData.Acknowledgements {
+				acknowledgement := acknowledgement
+				if err := a.importAcknowledgement(&acknowledgement, postWithData.post); err != nil {
+					return postWithData.lineNumber, err
+				}
+			}
+		}
+
 		if postWithData.postData.Replies != nil && len(*postWithData.postData.Replies) > 0 {
 			err := a.importReplies(rctx, *postWithData.postData.Replies, postWithData.post, postWithData.team.Id, extractContent)
 			if err != nil {
@@ -1922,6 +1936,9 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		if line.DirectPost.IsPinned != nil {
 			post.IsPinned = *line.DirectPost.IsPinned
 		}
+		if line.DirectPost.Priority != nil {
+			setImportedPostPriority(post, line.DirectPost.Priority)
+		}
 
 		fileIDs := a.uploadAttachments(rctx, line.DirectPost.Attachments, post, "noteam", extractContent)
 		for _, fileID := range post.FileIds {
@@ -2008,6 +2025,15 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if postWithData.directPostData.Acknowledgements != nil {
+			for _, acknowledgement := range *postWithData.directPostData.Acknowledgements {
+				acknowledgement := acknowledgement
+				if err := a.importAcknowledgement(&acknowledgement, postWithData.post); err != nil {
+					return postWithData.lineNumber, err
+				}
+			}
+		}
+
 		if postWithData.directPostData.Replies != nil {
 			if err := a.importReplies(rctx, *postWithData.directPostData.Replies, postWithData.post, "noteam", extractContent); err != nil {
 				return postWithData.lineNumber, err
@@ -2085,3 +2111,826 @@ func (a *App) importEmoji(rctx request.CTX, data *imports.EmojiImportData, dryRu
 
 	return nil
 }
+
+func (a *App) getImportTeam(teamName string) (*model.Team, *model.AppError) {
+	team, err := a.Srv().Store().Team().GetByName(teamName)
+	if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.import.get_import_team.not_found.error", map[string]any{"TeamName": teamName}, "", http.StatusBadRequest).Wrap(err)
+	}
+	return team, nil
+}
+
+func (a *App) getImportChannel(teamID, channelName string) (*model.Channel, *model.AppError) {
+	channel, err := a.Srv().Store().Channel().GetByNameIncludeDeleted(teamID, channelName, true)
+	if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.import.get_import_channel.not_found.error", map[string]any{"ChannelName": channelName}, "", http.StatusBadRequest).Wrap(err)
+	}
+	return channel, nil
+}
+
+func (a *App) getImportUser(username string) (*model.User, *model.AppError) {
+	user, err := a.Srv().Store().User().GetByUsername(username)
+	if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.import.get_import_user.not_found.error", map[string]any{"Username": username}, "", http.StatusBadRequest).Wrap(err)
+	}
+	return user, nil
+}
+
+// setImportedPostPriority sets the priority of a post, which is only saved when the post is created.
+func setImportedPostPriority(post *model.Post, data *imports.PostPriorityImportData) {
+	if post.Metadata == nil {
+		post.Metadata = &model.PostMetadata{}
+	}
+	post.Metadata.Priority = &model.PostPriority{
+		Priority:                data.Priority,
+		RequestedAck:            data.RequestedAck,
+		PersistentNotifications: data.PersistentNotifications,
+	}
+}
+
+func (a *App) importAcknowledgement(data *imports.PostAcknowledgementImportData, post *model.Post) *model.AppError {
+	if err := imports.ValidatePostAcknowledgementImportData(data); err != nil {
+		return err
+	}
+
+	user, appErr := a.getImportUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	if _, err := a.Srv().Store().PostAcknowledgement().Save(post.Id, user.Id, *data.AcknowledgedAt); err != nil {
+		return model.NewAppError("importAcknowledgement", "app.acknowledgement.save.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importBot(rctx request.CTX, data *imports.BotImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Username != nil {
+		fields = append(fields, mlog.String("bot_username", *data.Username))
+	}
+	rctx.Logger().Info("Validating bot", fields...)
+
+	if err := imports.ValidateBotImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing bot", fields...)
+
+	user, appErr := a.getImportUser(*data.Username)
+	if appErr != nil {
+		return appErr
+	}
+
+	// Bots can be owned by a plugin, in which case the owner is kept as is.
+	ownerID := *data.Owner
+	if owner, err := a.Srv().Store().User().GetByUsername(*data.Owner); err == nil {
+		ownerID = owner.Id
+	}
+
+	bot, err := a.Srv().Store().Bot().Get(user.Id, true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("importBot", "app.bot.getbot.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		bot = &model.Bot{UserId: user.Id}
+	}
+
+	bot.Username = user.Username
+	bot.DisplayName = user.FirstName
+	bot.OwnerId = ownerID
+	if data.Description != nil {
+		bot.Description = *data.Description
+	}
+	if data.DeleteAt != nil {
+		bot.DeleteAt = *data.DeleteAt
+	}
+
+	if bot.CreateAt == 0 {
+		_, err = a.Srv().Store().Bot().Save(bot)
+	} else {
+		_, err = a.Srv().Store().Bot().Update(bot)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importBot", "app.bot.createbot.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importGroup(rctx request.CTX, data *imports.GroupImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Name != nil {
+		fields = append(fields, mlog.String("group_name", *data.Name))
+	}
+	rctx.Logger().Info("Validating group", fields...)
+
+	if err := imports.ValidateGroupImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing group", fields...)
+
+	group, err := a.Srv().Store().Group().GetByName(*data.Name, model.GroupSearchOpts{})
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("importGroup", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		group = &model.Group{
+			Name:   data.Name,
+			Source: model.GroupSourceCustom,
+		}
+	}
+
+	if group.Source != model.GroupSourceCustom {
+		return model.NewAppError("BulkImport", "app.import.import_group.not_custom.error", map[string]any{"GroupName": *data.Name}, "", http.StatusBadRequest)
+	}
+
+	group.DisplayName = *data.DisplayName
+	if data.Description != nil {
+		group.Description = *data.Description
+	}
+	if data.AllowReference != nil {
+		group.AllowReference = *data.AllowReference
+	}
+
+	if group.Id == "" {
+		group, err = a.Srv().Store().Group().Create(group)
+	} else {
+		group, err = a.Srv().Store().Group().Update(group)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importGroup", "app.insert_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if data.Members == nil || len(*data.Members) == 0 {
+		return nil
+	}
+
+	members, appErr := a.getUsersByUsernames(*data.Members)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, member := range members {
+		if _, err := a.Srv().Store().Group().UpsertMember(group.Id, member.Id); err != nil {
+			return model.NewAppError("importGroup", "app.update_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (a *App) importChannelBookmark(rctx request.CTX, data *imports.ChannelBookmarkImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Channel != nil && data.DisplayName != nil {
+		fields = append(fields, mlog.String("channel_name", *data.Channel), mlog.String("bookmark_name", *data.DisplayName))
+	}
+	rctx.Logger().Info("Validating channel bookmark", fields...)
+
+	if err := imports.ValidateChannelBookmarkImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing channel bookmark", fields...)
+
+	team, appErr := a.getImportTeam(*data.Team)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.getImportChannel(team.Id, *data.Channel)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getImportUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	existing, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	if err != nil {
+		return model.NewAppError("importChannelBookmark", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var bookmark *model.ChannelBookmark
+	for _, b := range existing {
+		if b.Type == model.ChannelBookmarkLink && b.DisplayName == *data.DisplayName && b.LinkUrl == *data.LinkURL {
+			bookmark = b.ChannelBookmark
+			break
+		}
+	}
+
+	if bookmark == nil {
+		bookmark = &model.ChannelBookmark{
+			ChannelId: channel.Id,
+			Type:      model.ChannelBookmarkLink,
+		}
+	}
+
+	bookmark.OwnerId = user.Id
+	bookmark.DisplayName = *data.DisplayName
+	bookmark.LinkUrl = *data.LinkURL
+	if data.ImageURL != nil {
+		bookmark.ImageUrl = *data.ImageURL
+	}
+	if data.Emoji != nil {
+		bookmark.Emoji = *data.Emoji
+	}
+	if data.SortOrder != nil {
+		bookmark.SortOrder = *data.SortOrder
+	}
+
+	if bookmark.Id == "" {
+		_, err = a.Srv().Store().ChannelBookmark().Save(bookmark, data.SortOrder == nil)
+	} else {
+		err = a.Srv().Store().ChannelBookmark().Update(bookmark)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importChannelBookmark", "app.channel.bookmark.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// importIncomingWebhook matches existing webhooks by ID, then by channel, creator and display
+// name for the exports without IDs. New webhooks keep their exported ID unless it is taken.
+func (a *App) importIncomingWebhook(rctx request.CTX, data *imports.IncomingWebhookImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Channel != nil {
+		fields = append(fields, mlog.String("channel_name", *data.Channel))
+	}
+	rctx.Logger().Info("Validating incoming webhook", fields...)
+
+	if err := imports.ValidateIncomingWebhookImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing incoming webhook", fields...)
+
+	team, appErr := a.getImportTeam(*data.Team)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.getImportChannel(team.Id, *data.Channel)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getImportUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	existing, err := a.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	if err != nil {
+		return model.NewAppError("importIncomingWebhook", "app.webhooks.get_incoming_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var hook *model.IncomingWebhook
+	var hookID string
+	if data.Id != nil {
+		hookID = *data.Id
+		for _, h := range existing {
+			if h.Id == hookID {
+				hook = h
+				break
+			}
+		}
+	}
+	if hook == nil {
+		var displayName string
+		if data.DisplayName != nil {
+			displayName = *data.DisplayName
+		}
+		for _, h := range existing {
+			if h.UserId == user.Id && h.DisplayName == displayName {
+				hook = h
+				break
+			}
+		}
+	}
+	if hook == nil {
+		hook = &model.IncomingWebhook{}
+	}
+
+	hook.UserId = user.Id
+	hook.ChannelId = channel.Id
+	hook.TeamId = team.Id
+	if data.DisplayName != nil {
+		hook.DisplayName = *data.DisplayName
+	}
+	if data.Description != nil {
+		hook.Description = *data.Description
+	}
+	if data.Username != nil {
+		hook.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		hook.IconURL = *data.IconURL
+	}
+	if data.ChannelLocked != nil {
+		hook.ChannelLocked = *data.ChannelLocked
+	}
+
+	if hook.Id != "" {
+		_, err = a.Srv().Store().Webhook().UpdateIncoming(hook)
+	} else if hookID != "" {
+		hook.Id = hookID
+		_, err = a.Srv().Store().Webhook().ImportIncoming(hook)
+		var cErr *store.ErrConflict
+		if errors.As(err, &cErr) {
+			rctx.Logger().Warn("Incoming webhook ID already in use, a new one is generated", append(fields, mlog.String("webhook_id", hookID))...)
+			hook.Id = ""
+			_, err = a.Srv().Store().Webhook().SaveIncoming(hook)
+		}
+	} else {
+		_, err = a.Srv().Store().Webhook().SaveIncoming(hook)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importIncomingWebhook", "app.webhooks.save_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// importOutgoingWebhook matches existing webhooks by team, creator and display name, as
+// webhook IDs are not exported. The exported token is kept unless another webhook of the
+// team uses it.
+func (a *App) importOutgoingWebhook(rctx request.CTX, data *imports.OutgoingWebhookImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Team != nil {
+		fields = append(fields, mlog.String("team_name", *data.Team))
+	}
+	rctx.Logger().Info("Validating outgoing webhook", fields...)
+
+	if err := imports.ValidateOutgoingWebhookImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing outgoing webhook", fields...)
+
+	team, appErr := a.getImportTeam(*data.Team)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getImportUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	var channelID string
+	if data.Channel != nil {
+		channel, appErr := a.getImportChannel(team.Id, *data.Channel)
+		if appErr != nil {
+			return appErr
+		}
+		channelID = channel.Id
+	}
+
+	existing, err := a.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	if err != nil {
+		return model.NewAppError("importOutgoingWebhook", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	hook := &model.OutgoingWebhook{}
+	if data.DisplayName != nil {
+		for _, h := range existing {
+			if h.CreatorId == user.Id && h.DisplayName == *data.DisplayName {
+				hook = h
+				break
+			}
+		}
+	}
+
+	if data.Token != nil && *data.Token != hook.Token {
+		inUse := false
+		for _, h := range existing {
+			if h.Id != hook.Id && h.Token == *data.Token {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			rctx.Logger().Warn("Outgoing webhook token already in use, it is not imported", fields...)
+		} else {
+			hook.Token = *data.Token
+		}
+	}
+
+	hook.CreatorId = user.Id
+	hook.TeamId = team.Id
+	hook.ChannelId = channelID
+	hook.CallbackURLs = *data.CallbackURLs
+	if data.TriggerWords != nil {
+		hook.TriggerWords = *data.TriggerWords
+	}
+	if data.TriggerWhen != nil {
+		hook.TriggerWhen = *data.TriggerWhen
+	}
+	if data.DisplayName != nil {
+		hook.DisplayName = *data.DisplayName
+	}
+	if data.Description != nil {
+		hook.Description = *data.Description
+	}
+	if data.ContentType != nil {
+		hook.ContentType = *data.ContentType
+	}
+	if data.Username != nil {
+		hook.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		hook.IconURL = *data.IconURL
+	}
+
+	if hook.Id == "" {
+		_, err = a.Srv().Store().Webhook().SaveOutgoing(hook)
+	} else {
+		_, err = a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importOutgoingWebhook", "app.webhooks.save_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// importCommand matches existing commands by team and trigger. The exported token is kept
+// unless another command of the team uses it.
+func (a *App) importCommand(rctx request.CTX, data *imports.CommandImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Team != nil && data.Trigger != nil {
+		fields = append(fields, mlog.String("team_name", *data.Team), mlog.String("trigger", *data.Trigger))
+	}
+	rctx.Logger().Info("Validating command", fields...)
+
+	if err := imports.ValidateCommandImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing command", fields...)
+
+	team, appErr := a.getImportTeam(*data.Team)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getImportUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	cmd, err := a.Srv().Store().Command().GetByTrigger(team.Id, *data.Trigger)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("importCommand", "app.command.getcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		cmd = &model.Command{}
+	}
+
+	if data.Token != nil && *data.Token != cmd.Token {
+		existing, err := a.Srv().Store().Command().GetByTeam(team.Id)
+		if err != nil {
+			return model.NewAppError("importCommand", "app.command.listteamcommands.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		inUse := false
+		for _, c := range existing {
+			if c.Id != cmd.Id && c.Token == *data.Token {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			rctx.Logger().Warn("Command token already in use, it is not imported", fields...)
+		} else {
+			cmd.Token = *data.Token
+		}
+	}
+
+	cmd.CreatorId = user.Id
+	cmd.TeamId = team.Id
+	cmd.Trigger = *data.Trigger
+	cmd.Method = *data.Method
+	cmd.URL = *data.URL
+	if data.DisplayName != nil {
+		cmd.DisplayName = *data.DisplayName
+	}
+	if data.Description != nil {
+		cmd.Description = *data.Description
+	}
+	if data.Username != nil {
+		cmd.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		cmd.IconURL = *data.IconURL
+	}
+	if data.AutoComplete != nil {
+		cmd.AutoComplete = *data.AutoComplete
+	}
+	if data.AutoCompleteDesc != nil {
+		cmd.AutoCompleteDesc = *data.AutoCompleteDesc
+	}
+	if data.AutoCompleteHint != nil {
+		cmd.AutoCompleteHint = *data.AutoCompleteHint
+	}
+
+	if cmd.Id == "" {
+		_, err = a.Srv().Store().Command().Save(cmd)
+	} else {
+		_, err = a.Srv().Store().Command().Update(cmd)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importCommand", "app.command.createcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// importDelete applies a tombstone from an incremental export. Entities that
+// don't exist, or are already deleted, are skipped so deltas can be applied again.
+func (a *App) importDelete(rctx request.CTX, data *imports.DeleteImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Type != nil {
+		fields = append(fields, mlog.String("type", *data.Type))
+	}
+	rctx.Logger().Info("Validating delete", fields...)
+
+	if err := imports.ValidateDeleteImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing delete", fields...)
+
+	var nfErr *store.ErrNotFound
+	var team *model.Team
+	var channel *model.Channel
+	if data.Team != nil {
+		var err error
+		if team, err = a.Srv().Store().Team().GetByName(*data.Team); err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.team.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	if team != nil && data.Channel != nil {
+		var err error
+		if channel, err = a.Srv().Store().Channel().GetByNameIncludeDeleted(team.Id, *data.Channel, true); err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	var user *model.User
+	if data.User != nil {
+		var err error
+		if user, err = a.Srv().Store().User().GetByUsername(*data.User); err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.user.get_by_username.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	switch *data.Type {
+	case imports.DeleteTypeTeam:
+		if team.DeleteAt != 0 {
+			return nil
+		}
+		return a.SoftDeleteTeam(team.Id)
+	case imports.DeleteTypeTeamMembership:
+		member, err := a.Srv().Store().Team().GetMember(rctx, team.Id, user.Id)
+		if err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.team.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if member.DeleteAt != 0 {
+			return nil
+		}
+		return a.LeaveTeam(rctx, team, user, user.Id)
+	case imports.DeleteTypeChannelMembership:
+		if _, err := a.Srv().Store().Channel().GetMember(context.Background(), channel.Id, user.Id); err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.channel.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		// Only guests can leave the default channel without leaving the team.
+		if channel.Name == model.DefaultChannelName && !user.IsGuest() {
+			return nil
+		}
+		return a.removeUserFromChannel(rctx, user.Id, user.Id, channel)
+	case imports.DeleteTypeIncomingWebhook:
+		hooks, err := a.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+		if err != nil {
+			return model.NewAppError("importDelete", "app.webhooks.get_incoming_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Webhooks are found by their ID first, as the import keeps it when it can.
+		var hook *model.IncomingWebhook
+		for _, h := range hooks {
+			if data.Id != nil && h.Id == *data.Id {
+				hook = h
+				break
+			}
+		}
+		if hook == nil {
+			var displayName string
+			if data.DisplayName != nil {
+				displayName = *data.DisplayName
+			}
+			for _, h := range hooks {
+				if h.UserId == user.Id && h.DisplayName == displayName {
+					hook = h
+					break
+				}
+			}
+		}
+		if hook == nil {
+			return nil
+		}
+
+		if err := a.Srv().Store().Webhook().DeleteIncoming(hook.Id, *data.DeleteAt); err != nil {
+			return model.NewAppError("importDelete", "app.webhooks.delete_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		a.Srv().Platform().InvalidateCacheForWebhook(hook.Id)
+		return nil
+	case imports.DeleteTypeOutgoingWebhook:
+		hooks, err := a.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+		if err != nil {
+			return model.NewAppError("importDelete", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		var displayName string
+		if data.DisplayName != nil {
+			displayName = *data.DisplayName
+		}
+		for _, hook := range hooks {
+			if hook.CreatorId != user.Id || hook.DisplayName != displayName {
+				continue
+			}
+			if err := a.Srv().Store().Webhook().DeleteOutgoing(hook.Id, *data.DeleteAt); err != nil {
+				return model.NewAppError("importDelete", "app.webhooks.delete_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			break
+		}
+		return nil
+	case imports.DeleteTypeCommand:
+		cmd, err := a.Srv().Store().Command().GetByTrigger(team.Id, *data.Trigger)
+		if err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.command.getcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if err := a.Srv().Store().Command().Delete(cmd.Id, *data.DeleteAt); err != nil {
+			return model.NewAppError("importDelete", "app.command.deletecommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	case imports.DeleteTypeBot:
+		bot, err := a.Srv().Store().Bot().Get(user.Id, true)
+		if err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.bot.getbot.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if bot.DeleteAt != 0 {
+			return nil
+		}
+		_, appErr := a.UpdateBotActive(rctx, user.Id, false)
+		return appErr
+	case imports.DeleteTypeChannelBookmark:
+		bookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+		if err != nil {
+			return model.NewAppError("importDelete", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, bookmark := range bookmarks {
+			if bookmark.DisplayName != *data.DisplayName || bookmark.LinkUrl != *data.LinkURL {
+				continue
+			}
+			if err := a.Srv().Store().ChannelBookmark().Delete(bookmark.Id, false); err != nil {
+				return model.NewAppError("importDelete", "app.channel.bookmark.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+		return nil
+	case imports.DeleteTypeDirectPost:
+		usernames := utils.RemoveDuplicatesFromStringArray(*data.ChannelMembers)
+		members, err := a.Srv().Store().User().GetProfilesByUsernames(usernames, nil)
+		if err != nil {
+			return model.NewAppError("importDelete", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		// The channel can't exist if one of its members doesn't.
+		if len(members) != len(usernames) {
+			return nil
+		}
+
+		userIDs := make([]string, 0, len(members))
+		for _, member := range members {
+			userIDs = append(userIDs, member.Id)
+		}
+
+		var name string
+		switch len(userIDs) {
+		case 1:
+			name = model.GetDMNameFromIds(userIDs[0], userIDs[0])
+		case 2:
+			name = model.GetDMNameFromIds(userIDs[0], userIDs[1])
+		default:
+			name = model.GetGroupNameFromUserIds(userIDs)
+		}
+		if channel, err = a.Srv().Store().Channel().GetByName("", name, true); err != nil {
+			if errors.As(err, &nfErr) {
+				return nil
+			}
+			return model.NewAppError("importDelete", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Posts are identified by their channel, author and creation time.
+	posts, err := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
+	if err != nil {
+		return model.NewAppError("importDelete", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, post := range posts {
+		if post.UserId != user.Id || post.DeleteAt != 0 {
+			continue
+		}
+		if err := a.Srv().Store().Post().Delete(rctx, post.Id, *data.DeleteAt, user.Id); err != nil {
+			return model.NewAppError("importDelete", "app.post.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		break
+	}
+
+	return nil
+}
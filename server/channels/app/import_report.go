@@ -0,0 +1,956 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// BulkImportReport resolves every line of an import file against the existing data and
+// reports what importing it would change, without writing anything. Invalid lines and
+// missing references are part of the report rather than errors, so that a single run
+// finds all of them.
+func (a *App) BulkImportReport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.BulkImportReport, *model.AppError) {
+	scanner := bufio.NewScanner(jsonlReader)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxScanTokenSize)
+
+	var attachedFiles map[string]*zip.File
+	if attachmentsReader != nil {
+		attachedFiles = make(map[string]*zip.File, len(attachmentsReader.File))
+		for _, fi := range attachmentsReader.File {
+			attachedFiles[fi.Name] = fi
+		}
+	}
+
+	r := newImportReporter(a, c)
+	for scanner.Scan() {
+		r.line++
+		if r.line%statusUpdateAfterLines == 0 {
+			c.Logger().Info("Report progress", mlog.Int("processed_lines", r.line))
+		}
+
+		var line imports.LineImportData
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, model.NewAppError("BulkImportReport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+
+		if r.line == 1 {
+			importDataFileVersion, appErr := processImportDataFileVersionLine(line)
+			if appErr != nil {
+				return nil, appErr
+			}
+
+			if importDataFileVersion != 1 {
+				return nil, model.NewAppError("BulkImportReport", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest)
+			}
+			continue
+		}
+
+		if err := processAttachments(c, &line, importPath, attachedFiles); err != nil {
+			r.missing("attachment", "", err.Error())
+		}
+
+		if appErr := r.reportLine(line); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, model.NewAppError("BulkImportReport", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r.report.Lines = r.line
+	return r.report, nil
+}
+
+// importReporter keeps track of the entities an import file creates, so that later lines
+// referencing them aren't reported as missing, and caches the lookups of existing ones.
+type importReporter struct {
+	app    *App
+	rctx   request.CTX
+	report *model.BulkImportReport
+	line   int
+
+	// The entities created or updated by the previous lines of the file.
+	fileTeams    map[string]bool
+	fileChannels map[string]bool
+	fileUsers    map[string]bool
+	fileEmails   map[string]string
+
+	// The existing entities, nil when they don't exist.
+	teams    map[string]*model.Team
+	channels map[string]*model.Channel
+	users    map[string]*model.User
+	emails   map[string]*model.User
+}
+
+func newImportReporter(a *App, rctx request.CTX) *importReporter {
+	return &importReporter{
+		app:          a,
+		rctx:         rctx,
+		report:       model.NewBulkImportReport(),
+		fileTeams:    make(map[string]bool),
+		fileChannels: make(map[string]bool),
+		fileUsers:    make(map[string]bool),
+		fileEmails:   make(map[string]string),
+		teams:        make(map[string]*model.Team),
+		channels:     make(map[string]*model.Channel),
+		users:        make(map[string]*model.User),
+		emails:       make(map[string]*model.User),
+	}
+}
+
+func isStoreNotFound(err error) bool {
+	var nfErr *store.ErrNotFound
+	return errors.As(err, &nfErr)
+}
+
+func channelKey(teamName, channelName string) string {
+	return teamName + "/" + channelName
+}
+
+func (r *importReporter) count(entityType string, exists, changed bool) {
+	entity := r.report.Entity(entityType)
+	switch {
+	case !exists:
+		entity.Creates++
+	case changed:
+		entity.Updates++
+	default:
+		entity.NoOps++
+	}
+}
+
+func (r *importReporter) invalid(entityType string, appErr *model.AppError) {
+	appErr.Translate(r.rctx.GetT())
+	r.report.Errors = append(r.report.Errors, &model.BulkImportIssue{Line: r.line, Type: entityType, Message: appErr.Message})
+}
+
+func (r *importReporter) conflict(entityType, name, message string) {
+	r.report.Conflicts = append(r.report.Conflicts, &model.BulkImportIssue{Line: r.line, Type: entityType, Name: name, Message: message})
+}
+
+func (r *importReporter) missing(entityType, name, message string) {
+	r.report.MissingReferences = append(r.report.MissingReferences, &model.BulkImportIssue{Line: r.line, Type: entityType, Name: name, Message: message})
+}
+
+func (r *importReporter) getTeam(name string) (*model.Team, *model.AppError) {
+	if team, ok := r.teams[name]; ok {
+		return team, nil
+	}
+
+	team, err := r.app.Srv().Store().Team().GetByName(name)
+	if err != nil {
+		if !isStoreNotFound(err) {
+			return nil, model.NewAppError("BulkImportReport", "app.team.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		team = nil
+	}
+	r.teams[name] = team
+	return team, nil
+}
+
+func (r *importReporter) getChannel(teamName, name string) (*model.Channel, *model.AppError) {
+	key := channelKey(teamName, name)
+	if channel, ok := r.channels[key]; ok {
+		return channel, nil
+	}
+
+	team, appErr := r.getTeam(teamName)
+	if appErr != nil || team == nil {
+		return nil, appErr
+	}
+
+	channel, err := r.app.Srv().Store().Channel().GetByNameIncludeDeleted(team.Id, name, true)
+	if err != nil {
+		if !isStoreNotFound(err) {
+			return nil, model.NewAppError("BulkImportReport", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		channel = nil
+	}
+	r.channels[key] = channel
+	return channel, nil
+}
+
+func (r *importReporter) getUser(username string) (*model.User, *model.AppError) {
+	username = strings.ToLower(username)
+	if user, ok := r.users[username]; ok {
+		return user, nil
+	}
+
+	user, err := r.app.Srv().Store().User().GetByUsername(username)
+	if err != nil {
+		if !isStoreNotFound(err) {
+			return nil, model.NewAppError("BulkImportReport", "app.user.get_by_username.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		user = nil
+	}
+	r.users[username] = user
+	return user, nil
+}
+
+func (r *importReporter) getUserByEmail(email string) (*model.User, *model.AppError) {
+	email = strings.ToLower(email)
+	if user, ok := r.emails[email]; ok {
+		return user, nil
+	}
+
+	user, err := r.app.Srv().Store().User().GetByEmail(email)
+	if err != nil {
+		if !isStoreNotFound(err) {
+			return nil, model.NewAppError("BulkImportReport", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		user = nil
+	}
+	r.emails[email] = user
+	return user, nil
+}
+
+// checkTeam reports the team as missing if neither the file nor the server has it.
+func (r *importReporter) checkTeam(name string) *model.AppError {
+	if r.fileTeams[name] {
+		return nil
+	}
+	team, appErr := r.getTeam(name)
+	if appErr != nil {
+		return appErr
+	}
+	if team == nil {
+		r.missing("team", name, fmt.Sprintf("Team %q does not exist.", name))
+	}
+	return nil
+}
+
+// checkChannel reports the channel as missing if neither the file nor the server has it.
+func (r *importReporter) checkChannel(teamName, name string) *model.AppError {
+	if r.fileChannels[channelKey(teamName, name)] {
+		return nil
+	}
+	channel, appErr := r.getChannel(teamName, name)
+	if appErr != nil {
+		return appErr
+	}
+	if channel == nil {
+		r.missing("channel", channelKey(teamName, name), fmt.Sprintf("Channel %q does not exist in team %q.", name, teamName))
+	}
+	return nil
+}
+
+// checkUser reports the user as missing if neither the file nor the server has it.
+func (r *importReporter) checkUser(username string) *model.AppError {
+	if r.fileUsers[strings.ToLower(username)] {
+		return nil
+	}
+	user, appErr := r.getUser(username)
+	if appErr != nil {
+		return appErr
+	}
+	if user == nil {
+		r.missing("user", username, fmt.Sprintf("User %q does not exist.", username))
+	}
+	return nil
+}
+
+func (r *importReporter) checkUsers(usernames []string) *model.AppError {
+	for _, username := range usernames {
+		if appErr := r.checkUser(username); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
+
+func (r *importReporter) reportLine(line imports.LineImportData) *model.AppError {
+	switch line.Type {
+	case "post":
+		if line.Post == nil {
+			r.invalid(line.Type, model.NewAppError("BulkImportReport", "app.import.import_line.null_post.error", nil, "", http.StatusBadRequest))
+			return nil
+		}
+		if appErr := imports.ValidatePostImportData(line.Post, r.app.MaxPostSize()); appErr != nil {
+			r.invalid(line.Type, appErr)
+			return nil
+		}
+		return r.reportPost(line.Post)
+	case "direct_post":
+		if line.DirectPost == nil {
+			r.invalid(line.Type, model.NewAppError("BulkImportReport", "app.import.import_line.null_direct_post.error", nil, "", http.StatusBadRequest))
+			return nil
+		}
+		if appErr := imports.ValidateDirectPostImportData(line.DirectPost, r.app.MaxPostSize()); appErr != nil {
+			r.invalid(line.Type, appErr)
+			return nil
+		}
+		return r.reportDirectPost(line.DirectPost)
+	}
+
+	// A dry run of the import of any other line only validates it.
+	if appErr := r.app.importLine(r.rctx, line, true); appErr != nil {
+		r.invalid(line.Type, appErr)
+		return nil
+	}
+
+	switch line.Type {
+	case "role":
+		return r.reportRole(line.Role)
+	case "scheme":
+		return r.reportScheme(line.Scheme)
+	case "team":
+		return r.reportTeam(line.Team)
+	case "channel":
+		return r.reportChannel(line.Channel)
+	case "user":
+		return r.reportUser(line.User)
+	case "bot":
+		return r.reportBot(line.Bot)
+	case "group":
+		return r.reportGroup(line.Group)
+	case "channel_bookmark":
+		return r.reportChannelBookmark(line.ChannelBookmark)
+	case "incoming_webhook":
+		return r.reportIncomingWebhook(line.IncomingWebhook)
+	case "outgoing_webhook":
+		return r.reportOutgoingWebhook(line.OutgoingWebhook)
+	case "command":
+		return r.reportCommand(line.Command)
+	case "direct_channel":
+		return r.reportDirectChannel(line.DirectChannel)
+	case "emoji":
+		return r.reportEmoji(line.Emoji)
+	case "delete":
+		return r.reportDelete(line.Delete)
+	}
+	return nil
+}
+
+func (r *importReporter) reportRole(data *imports.RoleImportData) *model.AppError {
+	role, err := r.app.Srv().Store().Role().GetByName(context.Background(), *data.Name)
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.role.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("role", role != nil, true)
+	return nil
+}
+
+func (r *importReporter) reportScheme(data *imports.SchemeImportData) *model.AppError {
+	scheme, err := r.app.Srv().Store().Scheme().GetByName(*data.Name)
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.scheme.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("scheme", scheme != nil, true)
+	return nil
+}
+
+func (r *importReporter) reportTeam(data *imports.TeamImportData) *model.AppError {
+	team, appErr := r.getTeam(*data.Name)
+	if appErr != nil {
+		return appErr
+	}
+	r.fileTeams[*data.Name] = true
+
+	if team == nil {
+		r.count("team", false, false)
+		return nil
+	}
+
+	changed := team.DisplayName != *data.DisplayName || team.Type != *data.Type ||
+		(data.Description != nil && team.Description != *data.Description) ||
+		(data.AllowOpenInvite != nil && team.AllowOpenInvite != *data.AllowOpenInvite)
+	r.count("team", true, changed)
+	return nil
+}
+
+func (r *importReporter) reportChannel(data *imports.ChannelImportData) *model.AppError {
+	if appErr := r.checkTeam(*data.Team); appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := r.getChannel(*data.Team, *data.Name)
+	if appErr != nil {
+		return appErr
+	}
+	r.fileChannels[channelKey(*data.Team, *data.Name)] = true
+
+	if channel == nil {
+		r.count("channel", false, false)
+		return nil
+	}
+
+	changed := channel.DisplayName != *data.DisplayName || channel.Type != *data.Type ||
+		(data.Header != nil && channel.Header != *data.Header) ||
+		(data.Purpose != nil && channel.Purpose != *data.Purpose) ||
+		(data.DeletedAt != nil && *data.DeletedAt > 0 && channel.DeleteAt == 0)
+	r.count("channel", true, changed)
+	return nil
+}
+
+func (r *importReporter) reportUser(data *imports.UserImportData) *model.AppError {
+	username := strings.ToLower(*data.Username)
+	email := strings.ToLower(*data.Email)
+
+	user, appErr := r.getUser(username)
+	if appErr != nil {
+		return appErr
+	}
+
+	if other, ok := r.fileEmails[email]; ok && other != username {
+		r.conflict("user", username, fmt.Sprintf("Email %q is also used by user %q in the import file.", email, other))
+	} else if !ok {
+		emailUser, appErr := r.getUserByEmail(email)
+		if appErr != nil {
+			return appErr
+		}
+		if emailUser != nil && emailUser.Username != username {
+			r.conflict("user", username, fmt.Sprintf("Email %q already belongs to user %q.", email, emailUser.Username))
+		}
+	}
+	if user != nil && user.Email != email {
+		r.conflict("user", username, fmt.Sprintf("User %q exists with email %q, the import would change it to %q.", username, user.Email, email))
+	}
+
+	r.fileUsers[username] = true
+	r.fileEmails[email] = username
+
+	if data.Teams != nil {
+		for _, teamData := range *data.Teams {
+			if appErr := r.checkTeam(*teamData.Name); appErr != nil {
+				return appErr
+			}
+			if teamData.Channels == nil {
+				continue
+			}
+			for _, channelData := range *teamData.Channels {
+				if appErr := r.checkChannel(*teamData.Name, *channelData.Name); appErr != nil {
+					return appErr
+				}
+			}
+		}
+	}
+
+	if user == nil {
+		r.count("user", false, false)
+		return nil
+	}
+
+	changed := user.Email != email ||
+		(data.Nickname != nil && user.Nickname != *data.Nickname) ||
+		(data.FirstName != nil && user.FirstName != *data.FirstName) ||
+		(data.LastName != nil && user.LastName != *data.LastName) ||
+		(data.Position != nil && user.Position != *data.Position) ||
+		(data.Locale != nil && user.Locale != *data.Locale) ||
+		(data.Roles != nil && user.Roles != *data.Roles) ||
+		(data.DeleteAt != nil && user.DeleteAt != *data.DeleteAt)
+	r.count("user", true, changed)
+	return nil
+}
+
+func (r *importReporter) reportBot(data *imports.BotImportData) *model.AppError {
+	if appErr := r.checkUsers([]string{*data.Username, *data.Owner}); appErr != nil {
+		return appErr
+	}
+
+	user, appErr := r.getUser(*data.Username)
+	if appErr != nil || user == nil {
+		r.count("bot", false, false)
+		return appErr
+	}
+
+	bot, err := r.app.Srv().Store().Bot().Get(user.Id, true)
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.bot.getbot.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("bot", bot != nil, true)
+	return nil
+}
+
+func (r *importReporter) reportGroup(data *imports.GroupImportData) *model.AppError {
+	if data.Members != nil {
+		if appErr := r.checkUsers(*data.Members); appErr != nil {
+			return appErr
+		}
+	}
+
+	group, err := r.app.Srv().Store().Group().GetByName(*data.Name, model.GroupSearchOpts{})
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("group", group != nil, true)
+	return nil
+}
+
+func (r *importReporter) reportChannelBookmark(data *imports.ChannelBookmarkImportData) *model.AppError {
+	if appErr := r.checkChannel(*data.Team, *data.Channel); appErr != nil {
+		return appErr
+	}
+	if appErr := r.checkUser(*data.User); appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := r.getChannel(*data.Team, *data.Channel)
+	if appErr != nil || channel == nil {
+		r.count("channel_bookmark", false, false)
+		return appErr
+	}
+
+	bookmarks, err := r.app.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	if err != nil {
+		return model.NewAppError("BulkImportReport", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	exists := false
+	for _, bookmark := range bookmarks {
+		if bookmark.Type == model.ChannelBookmarkLink && bookmark.DisplayName == *data.DisplayName && bookmark.LinkUrl == *data.LinkURL {
+			exists = true
+			break
+		}
+	}
+	r.count("channel_bookmark", exists, true)
+	return nil
+}
+
+func (r *importReporter) reportIncomingWebhook(data *imports.IncomingWebhookImportData) *model.AppError {
+	if appErr := r.checkChannel(*data.Team, *data.Channel); appErr != nil {
+		return appErr
+	}
+	if appErr := r.checkUser(*data.User); appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := r.getChannel(*data.Team, *data.Channel)
+	if appErr != nil {
+		return appErr
+	}
+	user, appErr := r.getUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+	if channel == nil || user == nil {
+		r.count("incoming_webhook", false, false)
+		return nil
+	}
+
+	hooks, err := r.app.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	if err != nil {
+		return model.NewAppError("BulkImportReport", "app.webhooks.get_incoming_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// Webhooks without a display name can't be matched, and are always created.
+	exists := false
+	for _, hook := range hooks {
+		if data.DisplayName != nil && hook.UserId == user.Id && hook.DisplayName == *data.DisplayName {
+			exists = true
+			break
+		}
+	}
+	r.count("incoming_webhook", exists, true)
+	return nil
+}
+
+func (r *importReporter) reportOutgoingWebhook(data *imports.OutgoingWebhookImportData) *model.AppError {
+	if appErr := r.checkTeam(*data.Team); appErr != nil {
+		return appErr
+	}
+	if data.Channel != nil {
+		if appErr := r.checkChannel(*data.Team, *data.Channel); appErr != nil {
+			return appErr
+		}
+	}
+	if appErr := r.checkUser(*data.User); appErr != nil {
+		return appErr
+	}
+
+	team, appErr := r.getTeam(*data.Team)
+	if appErr != nil {
+		return appErr
+	}
+	user, appErr := r.getUser(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+	if team == nil || user == nil {
+		r.count("outgoing_webhook", false, false)
+		return nil
+	}
+
+	hooks, err := r.app.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	if err != nil {
+		return model.NewAppError("BulkImportReport", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	exists := false
+	for _, hook := range hooks {
+		if data.DisplayName != nil && hook.CreatorId == user.Id && hook.DisplayName == *data.DisplayName {
+			exists = true
+			break
+		}
+	}
+	r.count("outgoing_webhook", exists, true)
+	return nil
+}
+
+func (r *importReporter) reportCommand(data *imports.CommandImportData) *model.AppError {
+	if appErr := r.checkTeam(*data.Team); appErr != nil {
+		return appErr
+	}
+	if appErr := r.checkUser(*data.User); appErr != nil {
+		return appErr
+	}
+
+	team, appErr := r.getTeam(*data.Team)
+	if appErr != nil || team == nil {
+		r.count("command", false, false)
+		return appErr
+	}
+
+	command, err := r.app.Srv().Store().Command().GetByTrigger(team.Id, *data.Trigger)
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.command.getcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("command", command != nil, true)
+	return nil
+}
+
+func (r *importReporter) reportEmoji(data *imports.EmojiImportData) *model.AppError {
+	emoji, err := r.app.Srv().Store().Emoji().GetByName(r.rctx, *data.Name, true)
+	if err != nil && !isStoreNotFound(err) {
+		return model.NewAppError("BulkImportReport", "app.emoji.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r.count("emoji", emoji != nil, true)
+	return nil
+}
+
+// getDirectChannel returns the existing direct or group channel of the given members, or
+// nil if it or one of its members doesn't exist.
+func (r *importReporter) getDirectChannel(members []string) (*model.Channel, *model.AppError) {
+	userIDs := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, username := range members {
+		user, appErr := r.getUser(username)
+		if appErr != nil || user == nil {
+			return nil, appErr
+		}
+		if !seen[user.Id] {
+			seen[user.Id] = true
+			userIDs = append(userIDs, user.Id)
+		}
+	}
+
+	var name string
+	switch len(userIDs) {
+	case 1:
+		name = model.GetDMNameFromIds(userIDs[0], userIDs[0])
+	case 2:
+		name = model.GetDMNameFromIds(userIDs[0], userIDs[1])
+	default:
+		name = model.GetGroupNameFromUserIds(userIDs)
+	}
+
+	channel, err := r.app.Srv().Store().Channel().GetByName("", name, true)
+	if err != nil {
+		if isStoreNotFound(err) {
+			return nil, nil
+		}
+		return nil, model.NewAppError("BulkImportReport", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return channel, nil
+}
+
+func (r *importReporter) reportDirectChannel(data *imports.DirectChannelImportData) *model.AppError {
+	if appErr := r.checkUsers(*data.Members); appErr != nil {
+		return appErr
+	}
+	if data.FavoritedBy != nil {
+		if appErr := r.checkUsers(*data.FavoritedBy); appErr != nil {
+			return appErr
+		}
+	}
+
+	channel, appErr := r.getDirectChannel(*data.Members)
+	if appErr != nil {
+		return appErr
+	}
+	r.count("direct_channel", channel != nil, channel != nil && data.Header != nil && channel.Header != *data.Header)
+	return nil
+}
+
+// checkPostUsers reports the missing authors of a post, its replies and reactions, and
+// the missing users who flagged them.
+func (r *importReporter) checkPostUsers(user string, flaggedBy *[]string, reactions *[]imports.ReactionImportData, replies *[]imports.ReplyImportData) *model.AppError {
+	usernames := []string{user}
+	if flaggedBy != nil {
+		usernames = append(usernames, *flaggedBy...)
+	}
+	if reactions != nil {
+		for _, reaction := range *reactions {
+			usernames = append(usernames, *reaction.User)
+		}
+	}
+	if replies != nil {
+		for _, reply := range *replies {
+			if appErr := r.checkPostUsers(*reply.User, reply.FlaggedBy, reply.Reactions, nil); appErr != nil {
+				return appErr
+			}
+		}
+	}
+	return r.checkUsers(usernames)
+}
+
+// findPost returns the existing post of a channel created at the given time with the given
+// message, either a root post or a reply to rootID.
+func (r *importReporter) findPost(channelID string, createAt int64, message, rootID string) (*model.Post, *model.AppError) {
+	posts, err := r.app.Srv().Store().Post().GetPostsCreatedAt(channelID, createAt)
+	if err != nil {
+		return nil, model.NewAppError("BulkImportReport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, post := range posts {
+		if post.Message == message && (rootID == "" || post.RootId == rootID) {
+			return post, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *importReporter) reportPostAndReplies(entityType string, channel *model.Channel, createAt int64, message string, editAt *int64, isPinned *bool, replies *[]imports.ReplyImportData) *model.AppError {
+	var post *model.Post
+	if channel != nil {
+		var appErr *model.AppError
+		if post, appErr = r.findPost(channel.Id, createAt, message, ""); appErr != nil {
+			return appErr
+		}
+	}
+
+	changed := post != nil &&
+		((editAt != nil && post.EditAt != *editAt) || (isPinned != nil && post.IsPinned != *isPinned))
+	r.count(entityType, post != nil, changed)
+
+	if replies == nil {
+		return nil
+	}
+	for _, replyData := range *replies {
+		var reply *model.Post
+		if post != nil {
+			var appErr *model.AppError
+			if reply, appErr = r.findPost(channel.Id, *replyData.CreateAt, *replyData.Message, post.Id); appErr != nil {
+				return appErr
+			}
+		}
+		r.count("reply", reply != nil, reply != nil && replyData.EditAt != nil && reply.EditAt != *replyData.EditAt)
+	}
+	return nil
+}
+
+func (r *importReporter) reportPost(data *imports.PostImportData) *model.AppError {
+	if appErr := r.checkChannel(*data.Team, *data.Channel); appErr != nil {
+		return appErr
+	}
+	if appErr := r.checkPostUsers(*data.User, data.FlaggedBy, data.Reactions, data.Replies); appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := r.getChannel(*data.Team, *data.Channel)
+	if appErr != nil {
+		return appErr
+	}
+	return r.reportPostAndReplies("post", channel, *data.CreateAt, *data.Message, data.EditAt, data.IsPinned, data.Replies)
+}
+
+func (r *importReporter) reportDirectPost(data *imports.DirectPostImportData) *model.AppError {
+	if appErr := r.checkUsers(*data.ChannelMembers); appErr != nil {
+		return appErr
+	}
+	if appErr := r.checkPostUsers(*data.User, data.FlaggedBy, data.Reactions, data.Replies); appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := r.getDirectChannel(*data.ChannelMembers)
+	if appErr != nil {
+		return appErr
+	}
+	return r.reportPostAndReplies("direct_post", channel, *data.CreateAt, *data.Message, data.EditAt, data.IsPinned, data.Replies)
+}
+
+// reportDelete counts a delete line as a delete if the entity exists, and as a no-op
+// otherwise, since the import ignores tombstones of missing entities.
+func (r *importReporter) reportDelete(data *imports.DeleteImportData) *model.AppError {
+	entity := r.report.Entity(*data.Type)
+
+	exists := false
+	switch *data.Type {
+	case imports.DeleteTypeTeam:
+		team, appErr := r.getTeam(*data.Team)
+		if appErr != nil {
+			return appErr
+		}
+		exists = team != nil && team.DeleteAt == 0
+	case imports.DeleteTypeTeamMembership:
+		team, appErr := r.getTeam(*data.Team)
+		if appErr != nil {
+			return appErr
+		}
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if team == nil || user == nil {
+			break
+		}
+		member, err := r.app.Srv().Store().Team().GetMember(r.rctx, team.Id, user.Id)
+		if err != nil && !isStoreNotFound(err) {
+			return model.NewAppError("BulkImportReport", "app.team.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		exists = member != nil && member.DeleteAt == 0
+	case imports.DeleteTypeChannelMembership:
+		channel, appErr := r.getChannel(*data.Team, *data.Channel)
+		if appErr != nil {
+			return appErr
+		}
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if channel == nil || user == nil {
+			break
+		}
+		member, err := r.app.Srv().Store().Channel().GetMember(context.Background(), channel.Id, user.Id)
+		if err != nil && !isStoreNotFound(err) {
+			return model.NewAppError("BulkImportReport", "app.channel.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		exists = member != nil && (channel.Name != model.DefaultChannelName || user.IsGuest())
+	case imports.DeleteTypeIncomingWebhook:
+		channel, appErr := r.getChannel(*data.Team, *data.Channel)
+		if appErr != nil {
+			return appErr
+		}
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if channel == nil || user == nil {
+			break
+		}
+		hooks, err := r.app.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+		if err != nil {
+			return model.NewAppError("BulkImportReport", "app.webhooks.get_incoming_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, hook := range hooks {
+			if (data.Id != nil && hook.Id == *data.Id) || (hook.UserId == user.Id && data.DisplayName != nil && hook.DisplayName == *data.DisplayName) {
+				exists = true
+				break
+			}
+		}
+	case imports.DeleteTypeOutgoingWebhook:
+		team, appErr := r.getTeam(*data.Team)
+		if appErr != nil {
+			return appErr
+		}
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if team == nil || user == nil {
+			break
+		}
+		hooks, err := r.app.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+		if err != nil {
+			return model.NewAppError("BulkImportReport", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, hook := range hooks {
+			if hook.CreatorId == user.Id && data.DisplayName != nil && hook.DisplayName == *data.DisplayName {
+				exists = true
+				break
+			}
+		}
+	case imports.DeleteTypeCommand:
+		team, appErr := r.getTeam(*data.Team)
+		if appErr != nil || team == nil {
+			return appErr
+		}
+		command, err := r.app.Srv().Store().Command().GetByTrigger(team.Id, *data.Trigger)
+		if err != nil && !isStoreNotFound(err) {
+			return model.NewAppError("BulkImportReport", "app.command.getcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		exists = command != nil
+	case imports.DeleteTypeBot:
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if user == nil {
+			break
+		}
+		bot, err := r.app.Srv().Store().Bot().Get(user.Id, true)
+		if err != nil && !isStoreNotFound(err) {
+			return model.NewAppError("BulkImportReport", "app.bot.getbot.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		exists = bot != nil && bot.DeleteAt == 0
+	case imports.DeleteTypeChannelBookmark:
+		channel, appErr := r.getChannel(*data.Team, *data.Channel)
+		if appErr != nil {
+			return appErr
+		}
+		if channel == nil {
+			break
+		}
+		bookmarks, err := r.app.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+		if err != nil {
+			return model.NewAppError("BulkImportReport", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, bookmark := range bookmarks {
+			if bookmark.DisplayName == *data.DisplayName && bookmark.LinkUrl == *data.LinkURL {
+				exists = true
+				break
+			}
+		}
+	case imports.DeleteTypePost, imports.DeleteTypeDirectPost:
+		var channel *model.Channel
+		var appErr *model.AppError
+		if *data.Type == imports.DeleteTypePost {
+			channel, appErr = r.getChannel(*data.Team, *data.Channel)
+		} else {
+			channel, appErr = r.getDirectChannel(*data.ChannelMembers)
+		}
+		if appErr != nil {
+			return appErr
+		}
+		user, appErr := r.getUser(*data.User)
+		if appErr != nil {
+			return appErr
+		}
+		if channel == nil || user == nil {
+			break
+		}
+		posts, err := r.app.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
+		if err != nil {
+			return model.NewAppError("BulkImportReport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, post := range posts {
+			if post.UserId == user.Id && post.DeleteAt == 0 {
+				exists = true
+				break
+			}
+		}
+	}
+
+	if exists {
+		entity.Deletes++
+	} else {
+		entity.NoOps++
+	}
+	return nil
+}
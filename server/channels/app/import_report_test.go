@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBulkImportReport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	teamName := th.BasicTeam.Name
+	channelName := th.BasicChannel.Name
+	newTeamName := model.NewRandomTeamName()
+	newUsername := model.NewId()
+
+	_, appErr := th.App.CreatePost(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "Existing post",
+		CreateAt:  123456789012,
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	data := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "` + th.BasicTeam.Type + `", "display_name": "` + th.BasicTeam.DisplayName + `", "name": "` + teamName + `"}}
+{"type": "team", "team": {"type": "O", "display_name": "New team", "name": "` + newTeamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "Renamed channel", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "New channel", "team": "` + newTeamName + `", "name": "new-channel"}}
+{"type": "user", "user": {"username": "` + newUsername + `", "email": "` + th.BasicUser2.Email + `", "teams": [{"name": "` + newTeamName + `", "channels": [{"name": "new-channel"}, {"name": "missing-channel"}]}]}}
+{"type": "user", "user": {"username": "` + th.BasicUser.Username + `", "email": "` + newUsername + `@example.com"}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + th.BasicUser.Username + `", "message": "Existing post", "create_at": 123456789012, "replies": [{"user": "` + newUsername + `", "message": "New reply", "create_at": 123456789013}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "missing-user", "message": "New post", "create_at": 123456789014}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + th.BasicUser.Username + `", "message": "Invalid post"}}
+{"type": "delete", "delete": {"type": "team", "team": "` + newTeamName + `", "delete_at": 123456789016}}`
+
+	report, appErr := th.App.BulkImportReport(th.Context, strings.NewReader(data), nil, "")
+	require.Nil(t, appErr)
+
+	assert.Equal(t, 11, report.Lines)
+	assert.Equal(t, &model.BulkImportEntityReport{Creates: 1, NoOps: 2}, report.Entities["team"])
+	assert.Equal(t, &model.BulkImportEntityReport{Creates: 1, Updates: 1}, report.Entities["channel"])
+	assert.Equal(t, &model.BulkImportEntityReport{Creates: 1, Updates: 1}, report.Entities["user"])
+	assert.Equal(t, &model.BulkImportEntityReport{Creates: 1, NoOps: 1}, report.Entities["post"])
+	assert.Equal(t, &model.BulkImportEntityReport{Creates: 1}, report.Entities["reply"])
+
+	require.Len(t, report.Conflicts, 2)
+	assert.Equal(t, 6, report.Conflicts[0].Line)
+	assert.Equal(t, newUsername, report.Conflicts[0].Name)
+	assert.Equal(t, 7, report.Conflicts[1].Line)
+	assert.Equal(t, th.BasicUser.Username, report.Conflicts[1].Name)
+
+	require.Len(t, report.MissingReferences, 2)
+	assert.Equal(t, "channel", report.MissingReferences[0].Type)
+	assert.Equal(t, newTeamName+"/missing-channel", report.MissingReferences[0].Name)
+	assert.Equal(t, "user", report.MissingReferences[1].Type)
+	assert.Equal(t, "missing-user", report.MissingReferences[1].Name)
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, 10, report.Errors[0].Line)
+	assert.Equal(t, "post", report.Errors[0].Type)
+
+	t.Run("nothing is written", func(t *testing.T) {
+		_, err := th.App.Srv().Store().Team().GetByName(newTeamName)
+		require.Error(t, err)
+
+		channel, err := th.App.Srv().Store().Channel().Get(th.BasicChannel.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicChannel.DisplayName, channel.DisplayName)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, appErr := th.App.BulkImportReport(th.Context, strings.NewReader(`{"type": "version", "version": 2}`), nil, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.bulk_import.unsupported_version.error", appErr.Id)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// ArchiveWriter writes a bulk import archive, as processed by the import_process job,
+// for the tools converting the exports of other platforms.
+type ArchiveWriter struct {
+	zipWr *zip.Writer
+	jsonl io.Writer
+	paths map[string]bool
+}
+
+// NewArchiveWriter creates an ArchiveWriter writing the archive to w.
+func NewArchiveWriter(w io.Writer) *ArchiveWriter {
+	return &ArchiveWriter{
+		zipWr: zip.NewWriter(w),
+		paths: make(map[string]bool),
+	}
+}
+
+// AddAttachment copies a file in the data directory of the archive and returns the
+// attachment referencing it. As the import file is written last, every attachment
+// must be added before the first line.
+func (aw *ArchiveWriter) AddAttachment(filePath string, r io.Reader) (*AttachmentImportData, error) {
+	if aw.jsonl != nil {
+		return nil, errors.New("attachments must be added before the import lines")
+	}
+
+	filePath = path.Clean(strings.TrimPrefix(filePath, "/"))
+	if strings.HasPrefix(filePath, "..") {
+		return nil, fmt.Errorf("invalid attachment path %q", filePath)
+	}
+
+	if !aw.paths[filePath] {
+		wr, err := aw.zipWr.CreateHeader(&zip.FileHeader{
+			Name:   path.Join(model.ExportDataDir, filePath),
+			Method: zip.Store,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create attachment %q: %w", filePath, err)
+		}
+		if _, err := io.Copy(wr, r); err != nil {
+			return nil, fmt.Errorf("failed to write attachment %q: %w", filePath, err)
+		}
+		aw.paths[filePath] = true
+	}
+
+	return &AttachmentImportData{Path: model.NewString(filePath)}, nil
+}
+
+// WriteLine appends a line to the import file of the archive.
+func (aw *ArchiveWriter) WriteLine(line *LineImportData) error {
+	if aw.jsonl == nil {
+		var err error
+		if aw.jsonl, err = aw.zipWr.Create("import.jsonl"); err != nil {
+			return fmt.Errorf("failed to create the import file: %w", err)
+		}
+	}
+
+	b, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to encode the %s line: %w", line.Type, err)
+	}
+
+	if _, err := aw.jsonl.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write the %s line: %w", line.Type, err)
+	}
+
+	return nil
+}
+
+// Close finishes the archive, without closing the underlying writer.
+func (aw *ArchiveWriter) Close() error {
+	return aw.zipWr.Close()
+}
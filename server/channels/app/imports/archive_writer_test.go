@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestArchiveWriter(t *testing.T) {
+	var buf bytes.Buffer
+	aw := NewArchiveWriter(&buf)
+
+	attachment, err := aw.AddAttachment("files/a.txt", strings.NewReader("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "files/a.txt", *attachment.Path)
+
+	_, err = aw.AddAttachment("/files/a.txt", strings.NewReader("again"))
+	require.NoError(t, err, "an attachment added twice is only written once")
+
+	_, err = aw.AddAttachment("../a.txt", strings.NewReader("a"))
+	require.Error(t, err)
+
+	require.NoError(t, aw.WriteLine(&LineImportData{Type: "version", Version: model.NewInt(1)}))
+	_, err = aw.AddAttachment("files/b.txt", strings.NewReader("b"))
+	require.Error(t, err, "attachments can't be added after the lines")
+	require.NoError(t, aw.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, r.File, 2)
+	assert.Equal(t, "data/files/a.txt", r.File[0].Name)
+	assert.Equal(t, "import.jsonl", r.File[1].Name)
+
+	f, err := r.File[1].Open()
+	require.NoError(t, err)
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"type\":\"version\",\"version\":1}\n", string(b))
+}
@@ -13,18 +13,25 @@ import (
 // Import Data Models
 
 type LineImportData struct {
-	Type          string                   `json:"type"`
-	Role          *RoleImportData          `json:"role,omitempty"`
-	Scheme        *SchemeImportData        `json:"scheme,omitempty"`
-	Team          *TeamImportData          `json:"team,omitempty"`
-	Channel       *ChannelImportData       `json:"channel,omitempty"`
-	User          *UserImportData          `json:"user,omitempty"`
-	Post          *PostImportData          `json:"post,omitempty"`
-	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
-	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
-	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
-	Version       *int                     `json:"version,omitempty"`
-	Info          *VersionInfoImportData   `json:"info,omitempty"`
+	Type            string                     `json:"type"`
+	Role            *RoleImportData            `json:"role,omitempty"`
+	Scheme          *SchemeImportData          `json:"scheme,omitempty"`
+	Team            *TeamImportData            `json:"team,omitempty"`
+	Channel         *ChannelImportData         `json:"channel,omitempty"`
+	User            *UserImportData            `json:"user,omitempty"`
+	Bot             *BotImportData             `json:"bot,omitempty"`
+	Group           *GroupImportData           `json:"group,omitempty"`
+	ChannelBookmark *ChannelBookmarkImportData `json:"channel_bookmark,omitempty"`
+	IncomingWebhook *IncomingWebhookImportData `json:"incoming_webhook,omitempty"`
+	OutgoingWebhook *OutgoingWebhookImportData `json:"outgoing_webhook,omitempty"`
+	Command         *CommandImportData         `json:"command,omitempty"`
+	Post            *PostImportData            `json:"post,omitempty"`
+	DirectChannel   *DirectChannelImportData   `json:"direct_channel,omitempty"`
+	DirectPost      *DirectPostImportData      `json:"direct_post,omitempty"`
+	Emoji           *EmojiImportData           `json:"emoji,omitempty"`
+	Delete          *DeleteImportData          `json:"delete,omitempty"`
+	Version         *int                       `json:"version,omitempty"`
+	Info            *VersionInfoImportData     `json:"info,omitempty"`
 }
 
 type VersionInfoImportData struct {
@@ -128,6 +135,80 @@ type UserChannelNotifyPropsImportData struct {
 	MarkUnread *string `json:"mark_unread"`
 }
 
+// BotImportData turns an imported user into a bot. The user itself,
+// with its team and channel memberships, is imported by a user line.
+type BotImportData struct {
+	Username    *string `json:"username"`
+	Owner       *string `json:"owner"`
+	Description *string `json:"description,omitempty"`
+	DeleteAt    *int64  `json:"delete_at,omitempty"`
+}
+
+type GroupImportData struct {
+	Name           *string   `json:"name"`
+	DisplayName    *string   `json:"display_name"`
+	Description    *string   `json:"description,omitempty"`
+	AllowReference *bool     `json:"allow_reference,omitempty"`
+	Members        *[]string `json:"members,omitempty"`
+}
+
+type ChannelBookmarkImportData struct {
+	Team        *string `json:"team"`
+	Channel     *string `json:"channel"`
+	User        *string `json:"user"`
+	DisplayName *string `json:"display_name"`
+	LinkURL     *string `json:"link_url"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	Emoji       *string `json:"emoji,omitempty"`
+	SortOrder   *int64  `json:"sort_order,omitempty"`
+}
+
+type IncomingWebhookImportData struct {
+	// Id is kept on import, unless another webhook uses it, as it is part of the webhook URL.
+	Id            *string `json:"id,omitempty"`
+	Team          *string `json:"team"`
+	Channel       *string `json:"channel"`
+	User          *string `json:"user"`
+	DisplayName   *string `json:"display_name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Username      *string `json:"username,omitempty"`
+	IconURL       *string `json:"icon_url,omitempty"`
+	ChannelLocked *bool   `json:"channel_locked,omitempty"`
+}
+
+type OutgoingWebhookImportData struct {
+	Team         *string   `json:"team"`
+	Channel      *string   `json:"channel,omitempty"`
+	User         *string   `json:"user"`
+	DisplayName  *string   `json:"display_name,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+	TriggerWords *[]string `json:"trigger_words,omitempty"`
+	TriggerWhen  *int      `json:"trigger_when,omitempty"`
+	CallbackURLs *[]string `json:"callback_urls"`
+	ContentType  *string   `json:"content_type,omitempty"`
+	Username     *string   `json:"username,omitempty"`
+	IconURL      *string   `json:"icon_url,omitempty"`
+	// Token is kept on import, unless another webhook of the team uses it.
+	Token *string `json:"token,omitempty"`
+}
+
+type CommandImportData struct {
+	Team             *string `json:"team"`
+	User             *string `json:"user"`
+	Trigger          *string `json:"trigger"`
+	Method           *string `json:"method"`
+	URL              *string `json:"url"`
+	DisplayName      *string `json:"display_name,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	Username         *string `json:"username,omitempty"`
+	IconURL          *string `json:"icon_url,omitempty"`
+	AutoComplete     *bool   `json:"auto_complete,omitempty"`
+	AutoCompleteDesc *string `json:"auto_complete_desc,omitempty"`
+	AutoCompleteHint *string `json:"auto_complete_hint,omitempty"`
+	// Token is kept on import, unless another command of the team uses it.
+	Token *string `json:"token,omitempty"`
+}
+
 type EmojiImportData struct {
 	Name  *string   `json:"name"`
 	Image *string   `json:"image"`
@@ -140,6 +221,17 @@ type ReactionImportData struct {
 	EmojiName *string `json:"emoji_name"`
 }
 
+type PostPriorityImportData struct {
+	Priority                *string `json:"priority"`
+	RequestedAck            *bool   `json:"requested_ack,omitempty"`
+	PersistentNotifications *bool   `json:"persistent_notifications,omitempty"`
+}
+
+type PostAcknowledgementImportData struct {
+	User           *string `json:"user"`
+	AcknowledgedAt *int64  `json:"acknowledged_at"`
+}
+
 type ReplyImportData struct {
 	User *string `json:"user"`
 
@@ -169,6 +261,9 @@ type PostImportData struct {
 	Replies     *[]ReplyImportData      `json:"replies,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+
+	Priority         *PostPriorityImportData          `json:"priority,omitempty"`
+	Acknowledgements *[]PostAcknowledgementImportData `json:"acknowledgements,omitempty"`
 }
 
 type DirectChannelImportData struct {
@@ -193,6 +288,41 @@ type DirectPostImportData struct {
 	Replies     *[]ReplyImportData      `json:"replies"`
 	Attachments *[]AttachmentImportData `json:"attachments"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+
+	Priority         *PostPriorityImportData          `json:"priority,omitempty"`
+	Acknowledgements *[]PostAcknowledgementImportData `json:"acknowledgements,omitempty"`
+}
+
+// The types of entity a delete line can remove. Removed reactions and group
+// members aren't carried over by incremental exports.
+const (
+	DeleteTypeTeam              = "team"
+	DeleteTypePost              = "post"
+	DeleteTypeDirectPost        = "direct_post"
+	DeleteTypeChannelBookmark   = "channel_bookmark"
+	DeleteTypeTeamMembership    = "team_membership"
+	DeleteTypeChannelMembership = "channel_membership"
+	DeleteTypeIncomingWebhook   = "incoming_webhook"
+	DeleteTypeOutgoingWebhook   = "outgoing_webhook"
+	DeleteTypeCommand           = "command"
+	DeleteTypeBot               = "bot"
+)
+
+// DeleteImportData is the tombstone of an entity deleted since the start of an
+// incremental export. The entity is identified by the same fields the import
+// uses to find it, and a missing entity is not an error.
+type DeleteImportData struct {
+	Type           *string   `json:"type"`
+	Id             *string   `json:"id,omitempty"`
+	Team           *string   `json:"team,omitempty"`
+	Channel        *string   `json:"channel,omitempty"`
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	User           *string   `json:"user,omitempty"`
+	CreateAt       *int64    `json:"create_at,omitempty"`
+	DisplayName    *string   `json:"display_name,omitempty"`
+	LinkURL        *string   `json:"link_url,omitempty"`
+	Trigger        *string   `json:"trigger,omitempty"`
+	DeleteAt       *int64    `json:"delete_at"`
 }
 
 type SchemeImportData struct {
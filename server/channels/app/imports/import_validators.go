@@ -485,6 +485,21 @@ func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.props_too_large.error", nil, "", http.StatusBadRequest)
 	}
 
+	if data.Priority != nil {
+		if err := ValidatePostPriorityImportData(data.Priority); err != nil {
+			return err
+		}
+	}
+
+	if data.Acknowledgements != nil {
+		for _, acknowledgement := range *data.Acknowledgements {
+			acknowledgement := acknowledgement
+			if err := ValidatePostAcknowledgementImportData(&acknowledgement); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -581,6 +596,262 @@ func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.Priority != nil {
+		if err := ValidatePostPriorityImportData(data.Priority); err != nil {
+			return err
+		}
+	}
+
+	if data.Acknowledgements != nil {
+		for _, acknowledgement := range *data.Acknowledgements {
+			acknowledgement := acknowledgement
+			if err := ValidatePostAcknowledgementImportData(&acknowledgement); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidatePostPriorityImportData(data *PostPriorityImportData) *model.AppError {
+	if data.Priority == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_post_priority_import_data.priority_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidatePostAcknowledgementImportData(data *PostAcknowledgementImportData) *model.AppError {
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_post_acknowledgement_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.AcknowledgedAt == nil || *data.AcknowledgedAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_post_acknowledgement_import_data.acknowledged_at_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateBotImportData(data *BotImportData) *model.AppError {
+	if data.Username == nil || !model.IsValidUsername(*data.Username) {
+		return model.NewAppError("BulkImport", "app.import.validate_bot_import_data.username_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Owner == nil || *data.Owner == "" || utf8.RuneCountInString(*data.Owner) > model.BotCreatorIdMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_bot_import_data.owner_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Description != nil && utf8.RuneCountInString(*data.Description) > model.BotDescriptionMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_bot_import_data.description_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateGroupImportData(data *GroupImportData) *model.AppError {
+	if data.Name == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_group_import_data.name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_group_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	group := &model.Group{
+		Name:        data.Name,
+		DisplayName: *data.DisplayName,
+		Source:      model.GroupSourceCustom,
+	}
+	if data.Description != nil {
+		group.Description = *data.Description
+	}
+	if data.AllowReference != nil {
+		group.AllowReference = *data.AllowReference
+	}
+
+	return group.IsValidForCreate()
+}
+
+func ValidateChannelBookmarkImportData(data *ChannelBookmarkImportData) *model.AppError {
+	if data.Team == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.LinkURL == nil || !model.IsValidHTTPURL(*data.LinkURL) {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_url_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.ImageURL != nil && *data.ImageURL != "" && !model.IsValidHTTPURL(*data.ImageURL) {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.image_url_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateIncomingWebhookImportData(data *IncomingWebhookImportData) *model.AppError {
+	if data.Team == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Id != nil && !model.IsValidId(*data.Id) {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.id_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateOutgoingWebhookImportData(data *OutgoingWebhookImportData) *model.AppError {
+	if data.Team == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil && (data.TriggerWords == nil || len(*data.TriggerWords) == 0) {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.trigger_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.CallbackURLs == nil || len(*data.CallbackURLs) == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.callback_urls_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.TriggerWhen != nil && (*data.TriggerWhen < 0 || *data.TriggerWhen > 1) {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.trigger_when_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Token != nil && !model.IsValidId(*data.Token) {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.token_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateCommandImportData(data *CommandImportData) *model.AppError {
+	if data.Team == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Trigger == nil || *data.Trigger == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.trigger_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Method == nil || (*data.Method != model.CommandMethodPost && *data.Method != model.CommandMethodGet) {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.method_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.URL == nil || !model.IsValidHTTPURL(*data.URL) {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.url_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Token != nil && !model.IsValidId(*data.Token) {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.token_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateDeleteImportData(data *DeleteImportData) *model.AppError {
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.type_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case DeleteTypeTeam:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypePost:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Channel == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeDirectPost:
+		if data.ChannelMembers == nil || len(*data.ChannelMembers) < 2 || len(*data.ChannelMembers) > model.ChannelGroupMaxUsers {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_members_invalid.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeChannelBookmark:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Channel == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.DisplayName == nil || data.LinkURL == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.bookmark_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeTeamMembership, DeleteTypeOutgoingWebhook:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeChannelMembership, DeleteTypeIncomingWebhook:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Channel == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeCommand:
+		if data.Team == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Trigger == nil || *data.Trigger == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.trigger_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeBot:
+		// Bots are found by their username alone.
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.type_invalid.error", map[string]any{"Type": *data.Type}, "", http.StatusBadRequest)
+	}
+
+	// Only teams, bookmarks and commands are found without their user.
+	switch *data.Type {
+	case DeleteTypeTeam, DeleteTypeChannelBookmark, DeleteTypeCommand:
+	default:
+		if data.User == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *data.Type == DeleteTypePost || *data.Type == DeleteTypeDirectPost {
+		if data.CreateAt == nil || *data.CreateAt == 0 {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.create_at_missing.error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if data.DeleteAt == nil || *data.DeleteAt <= 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.delete_at_missing.error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -631,5 +902,6 @@ func isValidCommentsNotifyLevel(notifyLevel string) bool {
 func isValidEmailBatchingInterval(emailInterval string) bool {
 	return emailInterval == model.PreferenceEmailIntervalImmediately ||
 		emailInterval == model.PreferenceEmailIntervalFifteen ||
-		emailInterval == model.PreferenceEmailIntervalHour
+		emailInterval == model.PreferenceEmailIntervalHour ||
+		emailInterval == model.PreferenceEmailIntervalDay
 }
@@ -627,6 +627,9 @@ func TestImportValidateUserImportData(t *testing.T) {
 	data.EmailInterval = ptrStr("hour")
 	checkNoError(t, ValidateUserImportData(&data))
 
+	data.EmailInterval = ptrStr("day")
+	checkNoError(t, ValidateUserImportData(&data))
+
 	//Invalid values
 	data.EmailInterval = ptrStr("invalid")
 	checkError(t, ValidateUserImportData(&data))
@@ -1328,6 +1331,328 @@ func TestImportValidateDirectPostImportData(t *testing.T) {
 	require.Nil(t, err, "Validation should succeed with valid optional parameters")
 }
 
+func TestImportValidatePostPriorityAndAcknowledgementsImportData(t *testing.T) {
+	maxPostSize := 10000
+	data := PostImportData{
+		Team:     ptrStr("teamname"),
+		Channel:  ptrStr("channelname"),
+		User:     ptrStr("username"),
+		Message:  ptrStr("message"),
+		CreateAt: ptrInt64(model.GetMillis()),
+		Priority: &PostPriorityImportData{
+			Priority:     ptrStr(model.PostPriorityUrgent),
+			RequestedAck: ptrBool(true),
+		},
+		Acknowledgements: &[]PostAcknowledgementImportData{{
+			User:           ptrStr("username"),
+			AcknowledgedAt: ptrInt64(model.GetMillis()),
+		}},
+	}
+	checkNoError(t, ValidatePostImportData(&data, maxPostSize))
+
+	data.Priority.Priority = nil
+	err := ValidatePostImportData(&data, maxPostSize)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_post_priority_import_data.priority_missing.error", err.Id)
+
+	data.Priority.Priority = ptrStr("")
+	(*data.Acknowledgements)[0].User = nil
+	err = ValidatePostImportData(&data, maxPostSize)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_post_acknowledgement_import_data.user_missing.error", err.Id)
+
+	(*data.Acknowledgements)[0].User = ptrStr("username")
+	(*data.Acknowledgements)[0].AcknowledgedAt = ptrInt64(0)
+	err = ValidatePostImportData(&data, maxPostSize)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_post_acknowledgement_import_data.acknowledged_at_missing.error", err.Id)
+
+	directData := DirectPostImportData{
+		ChannelMembers:   &[]string{"username", "username2"},
+		User:             ptrStr("username"),
+		Message:          ptrStr("message"),
+		CreateAt:         ptrInt64(model.GetMillis()),
+		Acknowledgements: data.Acknowledgements,
+	}
+	err = ValidateDirectPostImportData(&directData, maxPostSize)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_post_acknowledgement_import_data.acknowledged_at_missing.error", err.Id)
+}
+
+func TestImportValidateBotImportData(t *testing.T) {
+	var testCases = []struct {
+		testName    string
+		data        BotImportData
+		expectedErr string
+	}{
+		{"success", BotImportData{Username: ptrStr("bot"), Owner: ptrStr("owner"), Description: ptrStr("description")}, ""},
+		{"plugin owner", BotImportData{Username: ptrStr("bot"), Owner: ptrStr("com.mattermost.plugin")}, ""},
+		{"missing username", BotImportData{Owner: ptrStr("owner")}, "app.import.validate_bot_import_data.username_invalid.error"},
+		{"invalid username", BotImportData{Username: ptrStr("invalid username!"), Owner: ptrStr("owner")}, "app.import.validate_bot_import_data.username_invalid.error"},
+		{"missing owner", BotImportData{Username: ptrStr("bot")}, "app.import.validate_bot_import_data.owner_invalid.error"},
+		{"empty owner", BotImportData{Username: ptrStr("bot"), Owner: ptrStr("")}, "app.import.validate_bot_import_data.owner_invalid.error"},
+		{"long description", BotImportData{Username: ptrStr("bot"), Owner: ptrStr("owner"), Description: ptrStr(strings.Repeat("a", model.BotDescriptionMaxRunes+1))}, "app.import.validate_bot_import_data.description_length.error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateBotImportData(&tc.data)
+			if tc.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr, err.Id)
+			}
+		})
+	}
+}
+
+func TestImportValidateGroupImportData(t *testing.T) {
+	var testCases = []struct {
+		testName    string
+		data        GroupImportData
+		expectedErr string
+	}{
+		{"success", GroupImportData{Name: ptrStr("developers"), DisplayName: ptrStr("Developers"), AllowReference: ptrBool(true), Members: &[]string{"user1"}}, ""},
+		{"missing name", GroupImportData{DisplayName: ptrStr("Developers")}, "app.import.validate_group_import_data.name_missing.error"},
+		{"missing display name", GroupImportData{Name: ptrStr("developers")}, "app.import.validate_group_import_data.display_name_missing.error"},
+		{"invalid name", GroupImportData{Name: ptrStr("Developers!"), DisplayName: ptrStr("Developers")}, "model.group.name.invalid_chars.app_error"},
+		{"empty display name", GroupImportData{Name: ptrStr("developers"), DisplayName: ptrStr("")}, "model.group.display_name.app_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateGroupImportData(&tc.data)
+			if tc.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr, err.Id)
+			}
+		})
+	}
+}
+
+func TestImportValidateChannelBookmarkImportData(t *testing.T) {
+	valid := func() ChannelBookmarkImportData {
+		return ChannelBookmarkImportData{
+			Team:        ptrStr("teamname"),
+			Channel:     ptrStr("channelname"),
+			User:        ptrStr("username"),
+			DisplayName: ptrStr("Docs"),
+			LinkURL:     ptrStr("https://mattermost.com/docs"),
+		}
+	}
+
+	data := valid()
+	checkNoError(t, ValidateChannelBookmarkImportData(&data))
+
+	var testCases = []struct {
+		testName    string
+		modify      func(*ChannelBookmarkImportData)
+		expectedErr string
+	}{
+		{"missing team", func(d *ChannelBookmarkImportData) { d.Team = nil }, "app.import.validate_channel_bookmark_import_data.team_missing.error"},
+		{"missing channel", func(d *ChannelBookmarkImportData) { d.Channel = nil }, "app.import.validate_channel_bookmark_import_data.channel_missing.error"},
+		{"missing user", func(d *ChannelBookmarkImportData) { d.User = nil }, "app.import.validate_channel_bookmark_import_data.user_missing.error"},
+		{"empty display name", func(d *ChannelBookmarkImportData) { d.DisplayName = ptrStr("") }, "app.import.validate_channel_bookmark_import_data.display_name_missing.error"},
+		{"invalid link", func(d *ChannelBookmarkImportData) { d.LinkURL = ptrStr("not a link") }, "app.import.validate_channel_bookmark_import_data.link_url_invalid.error"},
+		{"invalid image", func(d *ChannelBookmarkImportData) { d.ImageURL = ptrStr("not a link") }, "app.import.validate_channel_bookmark_import_data.image_url_invalid.error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := valid()
+			tc.modify(&data)
+			err := ValidateChannelBookmarkImportData(&data)
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedErr, err.Id)
+		})
+	}
+}
+
+func TestImportValidateIncomingWebhookImportData(t *testing.T) {
+	data := IncomingWebhookImportData{
+		Team:    ptrStr("teamname"),
+		Channel: ptrStr("channelname"),
+		User:    ptrStr("username"),
+	}
+	checkNoError(t, ValidateIncomingWebhookImportData(&data))
+
+	data.Channel = nil
+	err := ValidateIncomingWebhookImportData(&data)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_incoming_webhook_import_data.channel_missing.error", err.Id)
+
+	data.Channel = ptrStr("channelname")
+	data.Id = ptrStr(model.NewId())
+	checkNoError(t, ValidateIncomingWebhookImportData(&data))
+
+	data.Id = ptrStr("invalid")
+	err = ValidateIncomingWebhookImportData(&data)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.import.validate_incoming_webhook_import_data.id_invalid.error", err.Id)
+}
+
+func TestImportValidateOutgoingWebhookImportData(t *testing.T) {
+	valid := func() OutgoingWebhookImportData {
+		return OutgoingWebhookImportData{
+			Team:         ptrStr("teamname"),
+			User:         ptrStr("username"),
+			TriggerWords: &[]string{"build"},
+			CallbackURLs: &[]string{"https://example.com/hook"},
+		}
+	}
+
+	data := valid()
+	checkNoError(t, ValidateOutgoingWebhookImportData(&data))
+
+	data.TriggerWords = nil
+	data.Channel = ptrStr("channelname")
+	checkNoError(t, ValidateOutgoingWebhookImportData(&data))
+
+	var testCases = []struct {
+		testName    string
+		modify      func(*OutgoingWebhookImportData)
+		expectedErr string
+	}{
+		{"missing team", func(d *OutgoingWebhookImportData) { d.Team = nil }, "app.import.validate_outgoing_webhook_import_data.team_missing.error"},
+		{"missing user", func(d *OutgoingWebhookImportData) { d.User = nil }, "app.import.validate_outgoing_webhook_import_data.user_missing.error"},
+		{"missing channel and trigger words", func(d *OutgoingWebhookImportData) { d.TriggerWords = &[]string{} }, "app.import.validate_outgoing_webhook_import_data.trigger_missing.error"},
+		{"missing callback urls", func(d *OutgoingWebhookImportData) { d.CallbackURLs = nil }, "app.import.validate_outgoing_webhook_import_data.callback_urls_missing.error"},
+		{"invalid trigger when", func(d *OutgoingWebhookImportData) { triggerWhen := 2; d.TriggerWhen = &triggerWhen }, "app.import.validate_outgoing_webhook_import_data.trigger_when_invalid.error"},
+		{"invalid token", func(d *OutgoingWebhookImportData) { d.Token = ptrStr("invalid") }, "app.import.validate_outgoing_webhook_import_data.token_invalid.error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := valid()
+			tc.modify(&data)
+			err := ValidateOutgoingWebhookImportData(&data)
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedErr, err.Id)
+		})
+	}
+}
+
+func TestImportValidateCommandImportData(t *testing.T) {
+	valid := func() CommandImportData {
+		return CommandImportData{
+			Team:    ptrStr("teamname"),
+			User:    ptrStr("username"),
+			Trigger: ptrStr("deploy"),
+			Method:  ptrStr(model.CommandMethodPost),
+			URL:     ptrStr("https://example.com/deploy"),
+		}
+	}
+
+	data := valid()
+	checkNoError(t, ValidateCommandImportData(&data))
+
+	var testCases = []struct {
+		testName    string
+		modify      func(*CommandImportData)
+		expectedErr string
+	}{
+		{"missing team", func(d *CommandImportData) { d.Team = nil }, "app.import.validate_command_import_data.team_missing.error"},
+		{"missing user", func(d *CommandImportData) { d.User = nil }, "app.import.validate_command_import_data.user_missing.error"},
+		{"empty trigger", func(d *CommandImportData) { d.Trigger = ptrStr("") }, "app.import.validate_command_import_data.trigger_missing.error"},
+		{"invalid method", func(d *CommandImportData) { d.Method = ptrStr("X") }, "app.import.validate_command_import_data.method_invalid.error"},
+		{"invalid url", func(d *CommandImportData) { d.URL = ptrStr("not a url") }, "app.import.validate_command_import_data.url_invalid.error"},
+		{"invalid token", func(d *CommandImportData) { d.Token = ptrStr("invalid") }, "app.import.validate_command_import_data.token_invalid.error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := valid()
+			tc.modify(&data)
+			err := ValidateCommandImportData(&data)
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedErr, err.Id)
+		})
+	}
+}
+
+func TestImportValidateDeleteImportData(t *testing.T) {
+	valid := func() DeleteImportData {
+		return DeleteImportData{
+			Type:     ptrStr(DeleteTypePost),
+			Team:     ptrStr("teamname"),
+			Channel:  ptrStr("channelname"),
+			User:     ptrStr("username"),
+			CreateAt: model.NewInt64(1000),
+			DeleteAt: model.NewInt64(2000),
+		}
+	}
+
+	data := valid()
+	checkNoError(t, ValidateDeleteImportData(&data))
+
+	data = DeleteImportData{Type: ptrStr(DeleteTypeTeam), Team: ptrStr("teamname"), DeleteAt: model.NewInt64(2000)}
+	checkNoError(t, ValidateDeleteImportData(&data))
+
+	data = DeleteImportData{
+		Type:           ptrStr(DeleteTypeDirectPost),
+		ChannelMembers: &[]string{"username1", "username2"},
+		User:           ptrStr("username1"),
+		CreateAt:       model.NewInt64(1000),
+		DeleteAt:       model.NewInt64(2000),
+	}
+	checkNoError(t, ValidateDeleteImportData(&data))
+
+	for _, data := range []DeleteImportData{
+		{Type: ptrStr(DeleteTypeTeamMembership), Team: ptrStr("teamname"), User: ptrStr("username"), DeleteAt: model.NewInt64(2000)},
+		{Type: ptrStr(DeleteTypeChannelMembership), Team: ptrStr("teamname"), Channel: ptrStr("channelname"), User: ptrStr("username"), DeleteAt: model.NewInt64(2000)},
+		{Type: ptrStr(DeleteTypeIncomingWebhook), Team: ptrStr("teamname"), Channel: ptrStr("channelname"), User: ptrStr("username"), DeleteAt: model.NewInt64(2000)},
+		{Type: ptrStr(DeleteTypeOutgoingWebhook), Team: ptrStr("teamname"), User: ptrStr("username"), DisplayName: ptrStr("hook"), DeleteAt: model.NewInt64(2000)},
+		{Type: ptrStr(DeleteTypeCommand), Team: ptrStr("teamname"), Trigger: ptrStr("deploy"), DeleteAt: model.NewInt64(2000)},
+		{Type: ptrStr(DeleteTypeBot), User: ptrStr("botname"), DeleteAt: model.NewInt64(2000)},
+	} {
+		checkNoError(t, ValidateDeleteImportData(&data))
+	}
+
+	var testCases = []struct {
+		testName    string
+		modify      func(*DeleteImportData)
+		expectedErr string
+	}{
+		{"missing type", func(d *DeleteImportData) { d.Type = nil }, "app.import.validate_delete_import_data.type_missing.error"},
+		{"invalid type", func(d *DeleteImportData) { d.Type = ptrStr("user") }, "app.import.validate_delete_import_data.type_invalid.error"},
+		{"missing team", func(d *DeleteImportData) { d.Team = nil }, "app.import.validate_delete_import_data.team_missing.error"},
+		{"missing channel", func(d *DeleteImportData) { d.Channel = nil }, "app.import.validate_delete_import_data.channel_missing.error"},
+		{"missing user", func(d *DeleteImportData) { d.User = nil }, "app.import.validate_delete_import_data.user_missing.error"},
+		{"zero create at", func(d *DeleteImportData) { d.CreateAt = model.NewInt64(0) }, "app.import.validate_delete_import_data.create_at_missing.error"},
+		{"missing delete at", func(d *DeleteImportData) { d.DeleteAt = nil }, "app.import.validate_delete_import_data.delete_at_missing.error"},
+		{"direct post with one member", func(d *DeleteImportData) {
+			d.Type = ptrStr(DeleteTypeDirectPost)
+			d.ChannelMembers = &[]string{"username"}
+		}, "app.import.validate_delete_import_data.channel_members_invalid.error"},
+		{"bookmark without link", func(d *DeleteImportData) {
+			d.Type = ptrStr(DeleteTypeChannelBookmark)
+			d.DisplayName = ptrStr("Docs")
+		}, "app.import.validate_delete_import_data.bookmark_missing.error"},
+		{"command without trigger", func(d *DeleteImportData) { d.Type = ptrStr(DeleteTypeCommand) }, "app.import.validate_delete_import_data.trigger_missing.error"},
+		{"channel membership without channel", func(d *DeleteImportData) {
+			d.Type = ptrStr(DeleteTypeChannelMembership)
+			d.Channel = nil
+		}, "app.import.validate_delete_import_data.channel_missing.error"},
+		{"bot without user", func(d *DeleteImportData) {
+			d.Type = ptrStr(DeleteTypeBot)
+			d.User = nil
+		}, "app.import.validate_delete_import_data.user_missing.error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := valid()
+			tc.modify(&data)
+			err := ValidateDeleteImportData(&data)
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedErr, err.Id)
+		})
+	}
+}
+
 func TestImportValidateEmojiImportData(t *testing.T) {
 	var testCases = []struct {
 		testName          string
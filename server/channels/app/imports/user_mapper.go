@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// UserResolver looks up the users that already exist on the destination server.
+type UserResolver interface {
+	// UsernameByEmail returns the username of the user with the given email, or an empty string.
+	UsernameByEmail(email string) (string, error)
+	// UsernameExists reports whether a user already has the given username.
+	UsernameExists(username string) (bool, error)
+}
+
+// UserMapper assigns the usernames of the users converted from other platforms. Users are
+// mapped by email, so the users that already exist on the destination server keep their
+// username, and the new ones get a username that isn't taken.
+type UserMapper struct {
+	logger    mlog.LoggerIFace
+	resolver  UserResolver
+	byEmail   map[string]string
+	usernames map[string]bool
+	existing  map[string]bool
+}
+
+// NewUserMapper creates a UserMapper. The resolver is optional.
+func NewUserMapper(logger mlog.LoggerIFace, resolver UserResolver) *UserMapper {
+	return &UserMapper{
+		logger:    logger,
+		resolver:  resolver,
+		byEmail:   make(map[string]string),
+		usernames: make(map[string]bool),
+		existing:  make(map[string]bool),
+	}
+}
+
+// Username returns the username of the user with the given email, deriving it from
+// the given name when the user doesn't exist yet. Users without an email always get
+// a new username.
+func (m *UserMapper) Username(email, name string) (string, error) {
+	email = strings.ToLower(email)
+	if username, ok := m.byEmail[email]; ok && email != "" {
+		return username, nil
+	}
+
+	if m.resolver != nil && email != "" {
+		username, err := m.resolver.UsernameByEmail(email)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up the user with email %q: %w", email, err)
+		}
+		if username != "" {
+			m.byEmail[email] = username
+			m.usernames[username] = true
+			m.existing[username] = true
+			return username, nil
+		}
+	}
+
+	base := model.CleanUsername(m.logger, name)
+	username := base
+	for i := 2; ; i++ {
+		taken, err := m.isTaken(username)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			break
+		}
+
+		suffix := fmt.Sprintf("-%d", i)
+		if len(base)+len(suffix) > model.UserNameMaxLength {
+			base = base[:model.UserNameMaxLength-len(suffix)]
+		}
+		username = base + suffix
+	}
+
+	if email != "" {
+		m.byEmail[email] = username
+	}
+	m.usernames[username] = true
+	return username, nil
+}
+
+// Existing reports whether the username belongs to a user of the destination server.
+// Those users must not be imported again, as importing a user resets its password.
+func (m *UserMapper) Existing(username string) bool {
+	return m.existing[username]
+}
+
+func (m *UserMapper) isTaken(username string) (bool, error) {
+	if m.usernames[username] {
+		return true, nil
+	}
+
+	if m.resolver == nil {
+		return false, nil
+	}
+
+	exists, err := m.resolver.UsernameExists(username)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up the user %q: %w", username, err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testUserResolver map[string]string
+
+func (r testUserResolver) UsernameByEmail(email string) (string, error) {
+	return r[email], nil
+}
+
+func (r testUserResolver) UsernameExists(username string) (bool, error) {
+	for _, existing := range r {
+		if existing == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestUserMapper(t *testing.T) {
+	mapper := NewUserMapper(mlog.CreateConsoleTestLogger(t), testUserResolver{"alice@example.com": "alice", "other@example.com": "bob"})
+
+	username, err := mapper.Username("Alice@Example.com", "Alice Smith")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", username)
+	assert.True(t, mapper.Existing(username))
+
+	username, err = mapper.Username("bob@example.org", "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, "bob-2", username, "the username of an existing user is taken")
+	assert.False(t, mapper.Existing(username))
+
+	username, err = mapper.Username("bob@example.org", "Someone Else")
+	require.NoError(t, err)
+	assert.Equal(t, "bob-2", username, "users are mapped by email")
+
+	username, err = mapper.Username("", "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, "bob-3", username)
+	username, err = mapper.Username("", "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, "bob-4", username, "users without an email are never merged")
+}
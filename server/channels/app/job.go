@@ -60,6 +60,10 @@ func (a *App) GetJobsByTypeAndStatus(c request.CTX, jobTypes []string, status st
 	return jobs, nil
 }
 
+func (a *App) GetJobLineage(c request.CTX, job *model.Job) ([]*model.Job, *model.AppError) {
+	return a.Srv().Jobs.GetJobLineage(c, job)
+}
+
 func (a *App) CreateJob(c request.CTX, job *model.Job) (*model.Job, *model.AppError) {
 	return a.Srv().Jobs.CreateJob(c, job.Type, job.Data)
 }
@@ -106,7 +110,10 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeRegeneratePreviews,
+		model.JobTypeFileEncryption,
+		model.JobTypeFileTierMigration:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
 	}
 
@@ -140,7 +147,10 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeRegeneratePreviews,
+		model.JobTypeFileEncryption,
+		model.JobTypeFileTierMigration:
 		permission = model.PermissionManageJobs
 	}
 
@@ -175,9 +185,28 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeRegeneratePreviews,
+		model.JobTypeFileEncryption,
+		model.JobTypeFileTierMigration:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	}
 
 	return false, nil
 }
+
+func (a *App) GetJobSchedules() ([]*model.JobSchedule, *model.AppError) {
+	return a.Srv().Jobs.GetJobSchedules()
+}
+
+// SetJobTypePaused pauses or resumes the given job type on the whole cluster.
+func (a *App) SetJobTypePaused(jobType string, paused bool) *model.AppError {
+	if !a.Srv().Jobs.IsRegisteredJobType(jobType) {
+		return model.NewAppError("SetJobTypePaused", "app.job.set_job_type_paused.invalid_job_type.app_error", map[string]any{"JobType": jobType}, "", http.StatusBadRequest)
+	}
+
+	if paused {
+		return a.Srv().Jobs.PauseJobType(jobType)
+	}
+	return a.Srv().Jobs.ResumeJobType(jobType)
+}
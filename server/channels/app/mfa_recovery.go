@@ -0,0 +1,325 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones, returned
+// in clear for the user to write down: only their hashes are kept.
+func (a *App) GenerateMfaRecoveryCodes(rctx request.CTX, userID string) ([]string, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	codes := make([]string, 0, model.MfaRecoveryCodeCount)
+	recoveryCodes := make([]*model.MfaRecoveryCode, 0, model.MfaRecoveryCodeCount)
+	for i := 0; i < model.MfaRecoveryCodeCount; i++ {
+		code := model.NewMfaRecoveryCode()
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, &model.MfaRecoveryCode{CodeHash: model.HashMfaRecoveryCode(a.mfaRecoveryCodeKey(), userID, code)})
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().SaveForUser(userID, recoveryCodes); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.logMfaRecoveryAudit(rctx, userID, "generated mfa recovery codes")
+
+	return codes, nil
+}
+
+// mfaRecoveryCodeKey returns the key the recovery codes are hashed with, so that their
+// hashes can't be brute forced without the secret of the server.
+func (a *App) mfaRecoveryCodeKey() []byte {
+	hash := hmac.New(sha256.New, a.PostActionCookieSecret())
+	hash.Write([]byte("mfa_recovery_code"))
+	return hash.Sum(nil)
+}
+
+// GetMfaRecoveryCodesStatus returns how many unused recovery codes a user has left.
+func (a *App) GetMfaRecoveryCodesStatus(userID string) (*model.MfaRecoveryCodesStatus, *model.AppError) {
+	codes, err := a.Srv().Store().MfaRecoveryCode().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetMfaRecoveryCodesStatus", "app.mfa_recovery_code.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	status := &model.MfaRecoveryCodesStatus{}
+	for _, code := range codes {
+		if code.UsedAt == 0 {
+			status.Remaining++
+		}
+		status.CreateAt = code.CreateAt
+	}
+
+	return status, nil
+}
+
+// checkMfaRecoveryCode checks a recovery code passed as the MFA token of a user, using it
+// up if it's valid.
+func (a *App) checkMfaRecoveryCode(rctx request.CTX, user *model.User, code string) *model.AppError {
+	codes, err := a.Srv().Store().MfaRecoveryCode().GetForUser(user.Id)
+	if err != nil {
+		return model.NewAppError("checkMfaRecoveryCode", "app.mfa_recovery_code.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var match *model.MfaRecoveryCode
+	for _, recoveryCode := range codes {
+		if recoveryCode.UsedAt == 0 && model.CompareMfaRecoveryCode(a.mfaRecoveryCodeKey(), recoveryCode.CodeHash, user.Id, code) {
+			match = recoveryCode
+			break
+		}
+	}
+
+	if match == nil {
+		return model.NewAppError("checkMfaRecoveryCode", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().MarkUsed(match.Id, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			// The code was used concurrently
+			return model.NewAppError("checkMfaRecoveryCode", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+		default:
+			return model.NewAppError("checkMfaRecoveryCode", "app.mfa_recovery_code.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.logMfaRecoveryAudit(rctx, user.Id, "used mfa recovery code id="+match.Id)
+
+	return nil
+}
+
+// logMfaRecoveryAudit records an MFA recovery event in the audits of the user.
+func (a *App) logMfaRecoveryAudit(rctx request.CTX, userID, extraInfo string) {
+	rctx.Logger().Info("MFA recovery", mlog.String("user_id", userID), mlog.String("event", extraInfo))
+
+	audit := &model.Audit{
+		UserId:    userID,
+		IpAddress: rctx.IPAddress(),
+		Action:    rctx.Path(),
+		ExtraInfo: extraInfo,
+		SessionId: rctx.Session().Id,
+	}
+	if err := a.Srv().Store().Audit().Save(audit); err != nil {
+		rctx.Logger().Warn("Failed to save the MFA recovery audit", mlog.String("user_id", userID), mlog.Err(err))
+	}
+}
+
+// RequestMfaReset emails a user who lost their second factor a link to confirm they want
+// an admin to reset MFA on their account. Nothing is disclosed about whether the email
+// matches a user with MFA.
+func (a *App) RequestMfaReset(rctx request.CTX, email string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return model.NewAppError("RequestMfaReset", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUserByEmail(email)
+	if appErr != nil {
+		return nil
+	}
+
+	if user.DeleteAt != 0 || user.IsRemote() {
+		return nil
+	}
+
+	if !user.MfaActive {
+		hasCredentials, appErr := a.HasWebAuthnCredentials(user.Id)
+		if appErr != nil {
+			return appErr
+		}
+		if !hasCredentials {
+			return nil
+		}
+	}
+
+	if _, err := a.Srv().Store().MfaResetRequest().GetPendingForUser(user.Id); err == nil {
+		return nil
+	}
+
+	tokenExtra, err := json.Marshal(struct {
+		UserId string
+		Email  string
+	}{
+		user.Id,
+		user.Email,
+	})
+	if err != nil {
+		return model.NewAppError("RequestMfaReset", "app.mfa_reset_request.create_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	token := model.NewToken(model.TokenTypeMfaReset, string(tokenExtra))
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return model.NewAppError("RequestMfaReset", "app.mfa_reset_request.create_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().EmailService.SendMfaResetRequestEmail(user.Email, token, user.Locale, a.GetSiteURL()); err != nil {
+		return model.NewAppError("RequestMfaReset", "app.mfa_reset_request.send_email.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// ConfirmMfaResetRequest creates the MFA reset request of the user the token was emailed
+// to, pending until an admin approves or denies it.
+func (a *App) ConfirmMfaResetRequest(rctx request.CTX, tokenString string) (*model.MfaResetRequest, *model.AppError) {
+	token, err := a.Srv().Store().Token().GetByToken(tokenString)
+	if err != nil || token.Type != model.TokenTypeMfaReset {
+		return nil, model.NewAppError("ConfirmMfaResetRequest", "app.mfa_reset_request.invalid_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store().Token().Delete(token.Token); err != nil {
+		rctx.Logger().Warn("Failed to delete the MFA reset token", mlog.Err(err))
+	}
+
+	if model.GetMillis()-token.CreateAt >= model.MfaResetTokenExpiry {
+		return nil, model.NewAppError("ConfirmMfaResetRequest", "app.mfa_reset_request.invalid_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	tokenData := struct {
+		UserId string
+		Email  string
+	}{}
+	if err := json.Unmarshal([]byte(token.Extra), &tokenData); err != nil {
+		return nil, model.NewAppError("ConfirmMfaResetRequest", "app.mfa_reset_request.invalid_token.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	user, appErr := a.GetUser(tokenData.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.Email != tokenData.Email {
+		return nil, model.NewAppError("ConfirmMfaResetRequest", "app.mfa_reset_request.invalid_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if pending, err := a.Srv().Store().MfaResetRequest().GetPendingForUser(user.Id); err == nil {
+		return pending, nil
+	}
+
+	request, err := a.Srv().Store().MfaResetRequest().Save(&model.MfaResetRequest{UserId: user.Id})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("ConfirmMfaResetRequest", "app.mfa_reset_request.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.logMfaRecoveryAudit(rctx, user.Id, "requested mfa reset request_id="+request.Id)
+
+	return request, nil
+}
+
+// GetMfaResetRequests returns the MFA reset requests with the given status, or all of them
+// if it's empty, most recent first.
+func (a *App) GetMfaResetRequests(status string, page, perPage int) ([]*model.MfaResetRequest, *model.AppError) {
+	requests, err := a.Srv().Store().MfaResetRequest().GetAll(status, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetMfaResetRequests", "app.mfa_reset_request.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return requests, nil
+}
+
+// GetMfaResetRequest returns an MFA reset request.
+func (a *App) GetMfaResetRequest(requestID string) (*model.MfaResetRequest, *model.AppError) {
+	request, err := a.Srv().Store().MfaResetRequest().Get(requestID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetMfaResetRequest", "app.mfa_reset_request.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetMfaResetRequest", "app.mfa_reset_request.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return request, nil
+}
+
+// ResolveMfaResetRequest approves or denies a pending MFA reset request. Approving it
+// removes every second factor of the user: their TOTP secret, recovery codes and security
+// keys.
+func (a *App) ResolveMfaResetRequest(rctx request.CTX, requestID string, approve bool, resolvedBy string) (*model.MfaResetRequest, *model.AppError) {
+	request, appErr := a.GetMfaResetRequest(requestID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	status := model.MfaResetRequestStatusDenied
+	if approve {
+		status = model.MfaResetRequestStatusApproved
+	}
+
+	updateAt := model.GetMillis()
+	if err := a.Srv().Store().MfaResetRequest().Resolve(request.Id, status, resolvedBy, updateAt); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("ResolveMfaResetRequest", "app.mfa_reset_request.resolved.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("ResolveMfaResetRequest", "app.mfa_reset_request.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	request.Status = status
+	request.ResolvedBy = resolvedBy
+	request.UpdateAt = updateAt
+
+	if !approve {
+		return request, nil
+	}
+
+	if appErr := a.DeactivateMfa(request.UserId); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().PermanentDeleteByUser(request.UserId); err != nil {
+		return nil, model.NewAppError("ResolveMfaResetRequest", "app.webauthn.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	a.InvalidateCacheForUser(request.UserId)
+
+	a.Srv().Go(func() {
+		user, appErr := a.GetUser(request.UserId)
+		if appErr != nil {
+			rctx.Logger().Error("Failed to get user", mlog.Err(appErr))
+			return
+		}
+
+		if err := a.Srv().EmailService.SendMfaChangeEmail(user.Email, false, user.Locale, a.GetSiteURL()); err != nil {
+			rctx.Logger().Error("Failed to send mfa change email", mlog.Err(err))
+		}
+	})
+
+	return request, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	emailmocks "github.com/mattermost/mattermost/server/v8/channels/app/email/mocks"
+)
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	t.Run("requires mfa to be active", func(t *testing.T) {
+		_, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.mfa_recovery_code.mfa_inactive.app_error", appErr.Id)
+	})
+
+	require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+	user, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	codes, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+	require.Nil(t, appErr)
+	require.Len(t, codes, model.MfaRecoveryCodeCount)
+
+	status, appErr := th.App.GetMfaRecoveryCodesStatus(user.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.MfaRecoveryCodeCount, status.Remaining)
+
+	t.Run("a recovery code may be used once", func(t *testing.T) {
+		require.Nil(t, th.App.CheckUserMfa(th.Context, user, codes[0]))
+
+		appErr := th.App.CheckUserMfa(th.Context, user, codes[0])
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+
+		status, appErr := th.App.GetMfaRecoveryCodesStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MfaRecoveryCodeCount-1, status.Remaining)
+	})
+
+	t.Run("the formatting of recovery codes is ignored", func(t *testing.T) {
+		require.Nil(t, th.App.CheckUserMfa(th.Context, user, " "+strings.ToUpper(strings.ReplaceAll(codes[1], "-", ""))+" "))
+	})
+
+	t.Run("unknown codes are rejected", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, model.NewMfaRecoveryCode())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("regenerating replaces the codes", func(t *testing.T) {
+		newCodes, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+		require.Nil(t, appErr)
+
+		require.NotNil(t, th.App.CheckUserMfa(th.Context, user, codes[2]))
+		require.Nil(t, th.App.CheckUserMfa(th.Context, user, newCodes[2]))
+	})
+
+	t.Run("deactivating mfa removes the codes", func(t *testing.T) {
+		require.Nil(t, th.App.DeactivateMfa(user.Id))
+
+		status, appErr := th.App.GetMfaRecoveryCodesStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, status.Remaining)
+	})
+}
+
+func TestMfaResetRequests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+	_, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	var token *model.Token
+	emailServiceMock := emailmocks.ServiceInterface{}
+	emailServiceMock.On("SendMfaResetRequestEmail", th.BasicUser.Email, mock.AnythingOfType("*model.Token"), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		token = args.Get(1).(*model.Token)
+	}).Return(nil)
+	emailServiceMock.On("SendMfaChangeEmail", mock.Anything, false, mock.Anything, mock.Anything).Return(nil)
+	emailServiceMock.On("Stop").Return()
+	th.App.Srv().EmailService = &emailServiceMock
+
+	t.Run("nothing is sent for users without mfa", func(t *testing.T) {
+		require.Nil(t, th.App.RequestMfaReset(th.Context, th.BasicUser2.Email))
+		require.Nil(t, th.App.RequestMfaReset(th.Context, "unknown@example.com"))
+		assert.Nil(t, token)
+	})
+
+	require.Nil(t, th.App.RequestMfaReset(th.Context, th.BasicUser.Email))
+	require.NotNil(t, token)
+
+	t.Run("the token must be valid", func(t *testing.T) {
+		_, appErr := th.App.ConfirmMfaResetRequest(th.Context, model.NewRandomString(model.TokenSize))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.mfa_reset_request.invalid_token.app_error", appErr.Id)
+	})
+
+	request, appErr := th.App.ConfirmMfaResetRequest(th.Context, token.Token)
+	require.Nil(t, appErr)
+	assert.Equal(t, th.BasicUser.Id, request.UserId)
+	assert.Equal(t, model.MfaResetRequestStatusPending, request.Status)
+
+	t.Run("the token is only used once", func(t *testing.T) {
+		_, appErr := th.App.ConfirmMfaResetRequest(th.Context, token.Token)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("pending requests are listed", func(t *testing.T) {
+		requests, appErr := th.App.GetMfaResetRequests(model.MfaResetRequestStatusPending, 0, 100)
+		require.Nil(t, appErr)
+		require.Len(t, requests, 1)
+		assert.Equal(t, request.Id, requests[0].Id)
+	})
+
+	t.Run("approving removes the second factors", func(t *testing.T) {
+		resolved, appErr := th.App.ResolveMfaResetRequest(th.Context, request.Id, true, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MfaResetRequestStatusApproved, resolved.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, resolved.ResolvedBy)
+
+		user, appErr := th.App.GetUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.False(t, user.MfaActive)
+
+		status, appErr := th.App.GetMfaRecoveryCodesStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, status.Remaining)
+	})
+
+	t.Run("resolved requests can't be resolved again", func(t *testing.T) {
+		_, appErr := th.App.ResolveMfaResetRequest(th.Context, request.Id, false, th.SystemAdminUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.mfa_reset_request.resolved.app_error", appErr.Id)
+
+		_, appErr = th.App.ResolveMfaResetRequest(th.Context, model.NewId(), false, th.SystemAdminUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
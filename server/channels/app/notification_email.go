@@ -128,8 +128,17 @@ func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotificatio
 		references = referencesVal
 	}
 
+	// Replies to the email are posted to the thread of the post, if replying by email is enabled.
+	replyToAddress := a.replyByEmailAddress(post.Id, user.Id)
+
 	a.Srv().Go(func() {
-		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, html.UnescapeString(subjectText), bodyText, embeddedFiles, messageID, inReplyTo, references, "Notification"); nErr != nil {
+		var nErr error
+		if replyToAddress != "" {
+			nErr = a.Srv().EmailService.SendMailWithEmbeddedFilesAndCustomReplyTo(user.Email, html.UnescapeString(subjectText), bodyText, replyToAddress, embeddedFiles, messageID, inReplyTo, references, "Notification")
+		} else {
+			nErr = a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, html.UnescapeString(subjectText), bodyText, embeddedFiles, messageID, inReplyTo, references, "Notification")
+		}
+		if nErr != nil {
 			c.Logger().Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(nErr))
 		}
 	})
@@ -12,12 +12,15 @@ import (
 	"github.com/dyatlov/go-opengraph/opengraph"
 	"golang.org/x/net/html/charset"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
 )
 
 const (
 	MaxOpenGraphResponseSize   = 1024 * 1024 * 50
 	openGraphMetadataCacheSize = 10000
+	openGraphImageMaxWidth     = 1024
 )
 
 func (a *App) GetOpenGraphMetadata(requestURL string) ([]byte, error) {
@@ -61,6 +64,12 @@ func (a *App) parseOpenGraphMetadata(requestURL string, body io.Reader, contentT
 
 	// If image proxy enabled modify open graph data to feed though proxy
 	if toProxyURL := a.ImageProxyAdder(); toProxyURL != nil {
+		// The local image proxy scales the images down, sparing clients from downloading full size images.
+		if *a.Config().ImageProxySettings.ImageProxyType == model.ImageProxyTypeLocal {
+			toProxyURL = func(url string) string {
+				return a.ImageProxy().GetProxiedImageURLWithOptions(url, imageproxy.Options{Width: openGraphImageMaxWidth})
+			}
+		}
 		og = openGraphDataWithProxyAddedToImageURLs(og, toProxyURL)
 	}
 
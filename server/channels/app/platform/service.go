@@ -136,14 +136,6 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	// Assume the first user account has not been created yet. A call to the DB will later check if this is really the case.
 	ps.isFirstUserAccount.Store(true)
 
-	// Step 1: Cache provider.
-	// At the moment we only have this implementation
-	// in the future the cache provider will be built based on the loaded config
-	ps.cacheProvider = cache.NewProvider()
-	if err2 := ps.cacheProvider.Connect(); err2 != nil {
-		return nil, fmt.Errorf("unable to connect to cache provider: %w", err2)
-	}
-
 	// Apply options, some of the options overrides the default config actually.
 	for _, option := range options {
 		if err := option(ps); err != nil {
@@ -166,6 +158,21 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		ps.configStore = configStore
 	}
 
+	// Step 1: Cache provider.
+	// Depends on the config store being loaded.
+	if cacheSettings := ps.Config().CacheSettings; *cacheSettings.CacheType == model.CacheTypeRedis {
+		ps.cacheProvider = cache.NewRedisProvider(cache.RedisOptions{
+			Address:  *cacheSettings.RedisAddress,
+			Password: *cacheSettings.RedisPassword,
+			DB:       *cacheSettings.RedisDB,
+		})
+	} else {
+		ps.cacheProvider = cache.NewProvider()
+	}
+	if err2 := ps.cacheProvider.Connect(); err2 != nil {
+		return nil, fmt.Errorf("unable to connect to cache provider: %w", err2)
+	}
+
 	// Step 2: Start logging.
 	if err := ps.initLogging(); err != nil {
 		return nil, fmt.Errorf("failed to initialize logging: %w", err)
@@ -251,6 +258,16 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 			return nil, fmt.Errorf("failed to initialize filebackend: %w", err2)
 		}
 
+		if *ps.Config().FileSettings.EnableArchiveStore {
+			mlog.Info("Setting up archive filestore", mlog.String("driver_name", *ps.Config().FileSettings.ArchiveDriverName))
+			archiveBackend, errArchive := filestore.NewFileBackend(filestore.NewArchiveFileBackendSettingsFromConfig(&ps.Config().FileSettings, license != nil && *license.Features.Compliance, insecure != nil && *insecure))
+			if errArchive != nil {
+				return nil, fmt.Errorf("failed to initialize archive filebackend: %w", errArchive)
+			}
+
+			backend = filestore.NewTieredFileBackend(backend, archiveBackend)
+		}
+
 		ps.filestore = backend
 	}
 
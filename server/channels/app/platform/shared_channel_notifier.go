@@ -21,6 +21,15 @@ var sharedChannelEventsForSync = []model.WebsocketEventType{
 	model.WebsocketEventPostDeleted,
 	model.WebsocketEventReactionAdded,
 	model.WebsocketEventReactionRemoved,
+	model.WebsocketEventAcknowledgementAdded,
+	model.WebsocketEventAcknowledgementRemoved,
+	model.WebsocketEventChannelUpdated,
+	model.WebsocketEventUserAdded,
+	model.WebsocketEventUserRemoved,
+	model.WebsocketEventChannelBookmarkCreated,
+	model.WebsocketEventChannelBookmarkUpdated,
+	model.WebsocketEventChannelBookmarkDeleted,
+	model.WebsocketEventChannelBookmarkSorted,
 }
 
 var sharedChannelEventsForInvitation = []model.WebsocketEventType{
@@ -72,6 +72,43 @@ func TestServerSyncSharedChannelHandler(t *testing.T) {
 		assert.Equal(t, channel.Id, mockService.channelNotifications[0])
 	})
 
+	t.Run("sync service active when received channel, membership, acknowledgement or bookmark event, it triggers a shared channel content sync", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		defer th.TearDown()
+
+		mockStore := th.Service.Store.(*mocks.Store)
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("Get", "channelID", true).Return(&model.Channel{
+			Id:     "channelID",
+			Shared: model.NewBool(true),
+		}, nil)
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		mockService := NewMockSharedChannelService(nil)
+		mockService.active = true
+		th.Service.SetSharedChannelService(mockService)
+
+		events := []model.WebsocketEventType{
+			model.WebsocketEventChannelUpdated,
+			model.WebsocketEventUserAdded,
+			model.WebsocketEventUserRemoved,
+			model.WebsocketEventAcknowledgementAdded,
+			model.WebsocketEventAcknowledgementRemoved,
+			model.WebsocketEventChannelBookmarkCreated,
+			model.WebsocketEventChannelBookmarkUpdated,
+			model.WebsocketEventChannelBookmarkDeleted,
+			model.WebsocketEventChannelBookmarkSorted,
+		}
+		for _, event := range events {
+			th.Service.SharedChannelSyncHandler(model.NewWebSocketEvent(event, "", "channelID", "", nil, ""))
+		}
+
+		require.Len(t, mockService.channelNotifications, len(events))
+		for _, channelID := range mockService.channelNotifications {
+			assert.Equal(t, "channelID", channelID)
+		}
+	})
+
 	t.Run("sync service doesn't panic when no RemoteId", func(t *testing.T) {
 		th := SetupWithStoreMock(t)
 		defer th.TearDown()
@@ -568,7 +568,12 @@ func (api *PluginAPI) SearchPostsInTeamForUser(teamID string, userID string, sea
 		includeDeletedChannels = *searchParams.IncludeDeletedChannels
 	}
 
-	results, appErr := api.app.SearchPostsForUser(api.ctx, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	orderBy := ""
+	if searchParams.OrderBy != nil {
+		orderBy = *searchParams.OrderBy
+	}
+
+	results, appErr := api.app.SearchPostsForUser(api.ctx, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, orderBy, page, perPage)
 	if results != nil {
 		results = results.ForPlugin()
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
 	"github.com/mattermost/mattermost/server/public/plugin/utils"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
 	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 )
 
@@ -2137,3 +2138,64 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestHookExtractFileContent(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, activationErrors := SetAppEnvironmentWithPlugins(t, []string{
+		`
+		package main
+
+		import (
+			"fmt"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ExtractFileContent(c *plugin.Context, info *model.FileInfo) (string, error) {
+			if !info.IsImage() {
+				return "", nil
+			}
+
+			data, appErr := p.API.GetFile(info.Id)
+			if appErr != nil {
+				return "", appErr
+			}
+			return fmt.Sprintf("image of %d bytes", len(data)), nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+		`,
+	}, th.App, th.NewPluginAPI)
+	defer tearDown()
+	require.Nil(t, activationErrors[0])
+
+	extractedContent := func(t *testing.T, data []byte, filename string) string {
+		t.Helper()
+		info, appErr := th.App.UploadFile(th.Context, data, th.BasicChannel.Id, filename)
+		require.Nil(t, appErr)
+		require.NoError(t, th.App.ExtractContentFromFileInfo(th.Context, info))
+
+		info, err := th.App.Srv().Store().FileInfo().Get(info.Id)
+		require.NoError(t, err)
+		return info.Content
+	}
+
+	t.Run("image extracted by the plugin", func(t *testing.T) {
+		data, err := testutils.ReadTestFile("test.png")
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("image of %d bytes", len(data)), extractedContent(t, data, "test.png"))
+	})
+
+	t.Run("file left to the server", func(t *testing.T) {
+		assert.Equal(t, "extracted by the server", extractedContent(t, []byte("extracted by the server"), "test.txt"))
+	})
+}
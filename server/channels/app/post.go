@@ -1673,7 +1673,7 @@ func (a *App) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams)
 	})
 }
 
-func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, orderBy string, page, perPage int) (*model.PostSearchResults, *model.AppError) {
 	var postSearchResults *model.PostSearchResults
 	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
 	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
@@ -1687,6 +1687,7 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 	for _, params := range paramsList {
 		params.OrTerms = isOrSearch
 		params.IncludeDeletedChannels = includeDeleted
+		params.OrderBy = orderBy
 		// Don't allow users to search for "*"
 		if params.Terms != "*" {
 			// TODO: we have to send channel ids
@@ -1720,10 +1721,22 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 		}
 	}
 
-	if appErr := a.filterInaccessiblePosts(postSearchResults.PostList, filterPostOptions{assumeSortedCreatedAt: true}); appErr != nil {
+	if appErr := a.filterInaccessiblePosts(postSearchResults.PostList, filterPostOptions{assumeSortedCreatedAt: orderBy != model.SearchOrderByRelevance}); appErr != nil {
 		return nil, appErr
 	}
 
+	// The matches and highlights of the inaccessible posts go with them.
+	for postID := range postSearchResults.Matches {
+		if _, ok := postSearchResults.Posts[postID]; !ok {
+			delete(postSearchResults.Matches, postID)
+		}
+	}
+	for postID := range postSearchResults.Highlights {
+		if _, ok := postSearchResults.Posts[postID]; !ok {
+			delete(postSearchResults.Highlights, postID)
+		}
+	}
+
 	return postSearchResults, nil
 }
 
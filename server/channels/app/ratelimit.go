@@ -4,6 +4,7 @@
 package app
 
 import (
+	"io"
 	"math"
 	"net/http"
 	"strconv"
@@ -21,10 +22,21 @@ import (
 
 type RateLimiter struct {
 	throttledRateLimiter *throttled.GCRARateLimiter
+	policies             []*rateLimitPolicy
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
+
+	// keyPrefix isolates the keys of rate limiters sharing a store.
+	keyPrefix string
+}
+
+// rateLimitPolicy is a rate limiter dedicated to the requests matching a
+// model.RateLimitPolicy.
+type rateLimitPolicy struct {
+	*model.RateLimitPolicy
+	throttledRateLimiter *throttled.GCRARateLimiter
 }
 
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
@@ -33,6 +45,12 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
+	return NewRateLimiterWithStore(settings, store, trustedProxyIPHeader)
+}
+
+// NewRateLimiterWithStore creates a rate limiter keeping its state in the given
+// store, which can be shared by the nodes of a cluster.
+func NewRateLimiterWithStore(settings *model.RateLimitSettings, store throttled.GCRAStore, trustedProxyIPHeader []string) (*RateLimiter, error) {
 	quota := throttled.RateQuota{
 		MaxRate:  throttled.PerSec(*settings.PerSec),
 		MaxBurst: *settings.MaxBurst,
@@ -43,8 +61,33 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
 	}
 
+	policies := make([]*rateLimitPolicy, 0, len(settings.Policies))
+	for _, policy := range settings.Policies {
+		var quota throttled.RateQuota
+		if policy.PerSec != nil && *policy.PerSec > 0 {
+			quota.MaxRate = throttled.PerSec(*policy.PerSec)
+		} else if policy.PerMin != nil {
+			quota.MaxRate = throttled.PerMin(*policy.PerMin)
+		}
+		if policy.MaxBurst != nil {
+			quota.MaxBurst = *policy.MaxBurst
+		}
+
+		// The keys of the policies are prefixed with their name, so they can share the store.
+		policyRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		}
+
+		policies = append(policies, &rateLimitPolicy{
+			RateLimitPolicy:      policy,
+			throttledRateLimiter: policyRateLimiter,
+		})
+	}
+
 	return &RateLimiter{
 		throttledRateLimiter: throttledRateLimiter,
+		policies:             policies,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -52,6 +95,63 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 	}, nil
 }
 
+// NewRouteRateLimiter creates a rate limiter dedicated to a single route,
+// whose keys are prefixed with name so that it can share the store of the
+// global rate limiter.
+func NewRouteRateLimiter(name string, settings *model.RateLimitSettings, store throttled.GCRAStore, trustedProxyIPHeader []string) (*RateLimiter, error) {
+	rl, err := NewRateLimiterWithStore(settings, store, trustedProxyIPHeader)
+	if err != nil {
+		return nil, err
+	}
+	rl.keyPrefix = "route:" + name + ":"
+	return rl, nil
+}
+
+// NewRateLimitStore creates the store configured in the rate limit settings.
+func NewRateLimitStore(cfg *model.Config) (throttled.GCRAStore, error) {
+	if *cfg.RateLimitSettings.StoreType == model.RateLimitStoreTypeRedis {
+		store, err := newRedisRateLimitStore(*cfg.CacheSettings.RedisAddress, *cfg.CacheSettings.RedisPassword, *cfg.CacheSettings.RedisDB)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_redis_store"))
+		}
+		return store, nil
+	}
+
+	store, err := memstore.New(*cfg.RateLimitSettings.MemoryStoreSize)
+	if err != nil {
+		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
+	}
+	return store, nil
+}
+
+// RateLimitStore returns the store shared by the rate limiters of the
+// server, creating it on first use.
+func (s *Server) RateLimitStore() (throttled.GCRAStore, error) {
+	s.rateLimitStoreMut.Lock()
+	defer s.rateLimitStoreMut.Unlock()
+
+	if s.rateLimitStore == nil {
+		store, err := NewRateLimitStore(s.platform.Config())
+		if err != nil {
+			return nil, err
+		}
+		s.rateLimitStore = store
+	}
+	return s.rateLimitStore, nil
+}
+
+func (s *Server) closeRateLimitStore() {
+	s.rateLimitStoreMut.Lock()
+	defer s.rateLimitStoreMut.Unlock()
+
+	if closer, ok := s.rateLimitStore.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			mlog.Warn("Unable to close the rate limit store", mlog.Err(err))
+		}
+	}
+	s.rateLimitStore = nil
+}
+
 func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
@@ -76,7 +176,11 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 }
 
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimitWriter(rl.throttledRateLimiter, key, w)
+}
+
+func (rl *RateLimiter) rateLimitWriter(throttledRateLimiter *throttled.GCRARateLimiter, key string, w http.ResponseWriter) bool {
+	limited, context, err := throttledRateLimiter.RateLimit(rl.keyPrefix+key, 1)
 	if err != nil {
 		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
 		return false
@@ -92,36 +196,66 @@ func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
 	return limited
 }
 
-func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) bool {
-	if rl.useAuth {
-		return rl.RateLimitWriter(userID, w)
+// RateLimitRequest rate limits the request against the first policy matching it,
+// or against the global quota if there is none.
+func (rl *RateLimiter) RateLimitRequest(r *http.Request, w http.ResponseWriter) bool {
+	key := rl.GenerateKey(r)
+
+	if policy := rl.matchingPolicy(r); policy != nil {
+		return rl.rateLimitWriter(policy.throttledRateLimiter, *policy.Name+":"+key, w)
 	}
-	return false
+
+	return rl.RateLimitWriter(key, w)
+}
+
+// UserIdRateLimit rate limits the request by the ID of the user making it,
+// against the first policy matching the request or the global quota.
+func (rl *RateLimiter) UserIdRateLimit(userID string, r *http.Request, w http.ResponseWriter) bool {
+	if !rl.useAuth {
+		return false
+	}
+
+	if policy := rl.matchingPolicy(r); policy != nil {
+		return rl.rateLimitWriter(policy.throttledRateLimiter, *policy.Name+":"+userID, w)
+	}
+
+	return rl.RateLimitWriter(userID, w)
+}
+
+func (rl *RateLimiter) matchingPolicy(r *http.Request) *rateLimitPolicy {
+	for _, policy := range rl.policies {
+		if policy.Matches(r.Method, r.URL.Path) {
+			return policy
+		}
+	}
+	return nil
 }
 
 func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		key := rl.GenerateKey(r)
-
-		if !rl.RateLimitWriter(key, w) {
+		if !rl.RateLimitRequest(r, w) {
 			wrappedHandler.ServeHTTP(w, r)
 		}
 	})
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// Copied from https://github.com/throttled/throttled http.go, along with the
+// RateLimit-* headers of the IETF draft on rate limit headers.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
 	if v := context.Limit; v >= 0 {
 		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
 		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
 		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
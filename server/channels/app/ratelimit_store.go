@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/throttled/throttled"
+)
+
+const (
+	redisRateLimitKeyPrefix = "ratelimit:"
+
+	redisCASMissingKey = "key does not exist"
+	redisCASScript     = `
+local v = redis.call('get', KEYS[1])
+if v == false then
+  return redis.error_reply("key does not exist")
+end
+if v ~= ARGV[1] then
+  return 0
+end
+redis.call('set', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`
+)
+
+var _ throttled.GCRAStore = (*redisRateLimitStore)(nil)
+
+// redisRateLimitStore is a throttled.GCRAStore keeping the state of the rate
+// limiter in a Redis server, so that the limits are shared by all the nodes of
+// a cluster. The time of the Redis server is used, so that the nodes agree on
+// the current time.
+type redisRateLimitStore struct {
+	client *redis.Client
+}
+
+func newRedisRateLimitStore(address, password string, db int) (*redisRateLimitStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: password,
+		DB:       db,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &redisRateLimitStore{client: client}, nil
+}
+
+// GetWithTime returns the value of the key, or -1 if it doesn't exist, along
+// with the current time of the Redis server.
+func (s *redisRateLimitStore) GetWithTime(key string) (int64, time.Time, error) {
+	ctx := context.Background()
+
+	pipe := s.client.Pipeline()
+	timeCmd := pipe.Time(ctx)
+	getCmd := pipe.Get(ctx, redisRateLimitKey(key))
+	// The errors are read from the commands themselves, since a missing
+	// key fails the pipeline.
+	pipe.Exec(ctx)
+
+	now, err := timeCmd.Result()
+	if err != nil {
+		return 0, now, err
+	}
+
+	v, err := getCmd.Int64()
+	if errors.Is(err, redis.Nil) {
+		return -1, now, nil
+	} else if err != nil {
+		return 0, now, err
+	}
+	return v, now, nil
+}
+
+// SetIfNotExistsWithTTL sets the value of key only if it isn't already set,
+// and returns whether it was.
+func (s *redisRateLimitStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(context.Background(), redisRateLimitKey(key), value, minRateLimitTTL(ttl)).Result()
+}
+
+// CompareAndSwapWithTTL atomically sets the value of key to new if its
+// current value is old. It returns false if the key doesn't exist.
+func (s *redisRateLimitStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	result, err := s.client.Eval(context.Background(), redisCASScript, []string{redisRateLimitKey(key)}, old, new, minRateLimitTTL(ttl).Milliseconds()).Result()
+	if err != nil {
+		if strings.Contains(err.Error(), redisCASMissingKey) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	swapped, _ := result.(int64)
+	return swapped == 1, nil
+}
+
+func (s *redisRateLimitStore) Close() error {
+	return s.client.Close()
+}
+
+// redisRateLimitKey returns the Redis key holding the state of key. The keys
+// can be session tokens, so only their hash is stored.
+func redisRateLimitKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return redisRateLimitKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// minRateLimitTTL makes sure the keys don't expire before they can be read
+// again, since a zero TTL would remove them right away.
+func minRateLimitTTL(ttl time.Duration) time.Duration {
+	if ttl < time.Millisecond {
+		return time.Millisecond
+	}
+	return ttl
+}
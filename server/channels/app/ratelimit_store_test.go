@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestRedisRateLimitStore(t *testing.T) *redisRateLimitStore {
+	redisHost := os.Getenv("CI_REDIS_HOST")
+	if redisHost == "" {
+		t.Skip("CI_REDIS_HOST is not set, skipping the Redis tests")
+	}
+
+	redisPort := os.Getenv("CI_REDIS_PORT")
+	if redisPort == "" {
+		redisPort = "6379"
+	}
+
+	store, err := newRedisRateLimitStore(net.JoinHostPort(redisHost, redisPort), "", 0)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		store.Close()
+	})
+	return store
+}
+
+func TestRedisRateLimitStore(t *testing.T) {
+	store := newTestRedisRateLimitStore(t)
+	key := model.NewId()
+	t.Cleanup(func() {
+		store.client.Del(context.Background(), redisRateLimitKey(key))
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		v, now, err := store.GetWithTime(key)
+		require.NoError(t, err)
+		assert.EqualValues(t, -1, v)
+		assert.WithinDuration(t, time.Now(), now, time.Minute)
+
+		swapped, err := store.CompareAndSwapWithTTL(key, 1, 2, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+	})
+
+	t.Run("set if not exists", func(t *testing.T) {
+		set, err := store.SetIfNotExistsWithTTL(key, 10, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		set, err = store.SetIfNotExistsWithTTL(key, 20, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, set)
+
+		v, _, err := store.GetWithTime(key)
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, v)
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		swapped, err := store.CompareAndSwapWithTTL(key, 11, 30, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		swapped, err = store.CompareAndSwapWithTTL(key, 10, 30, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		v, _, err := store.GetWithTime(key)
+		require.NoError(t, err)
+		assert.EqualValues(t, 30, v)
+	})
+
+	t.Run("key is hashed", func(t *testing.T) {
+		n, err := store.client.Exists(context.Background(), redisRateLimitKeyPrefix+key).Result()
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		n, err = store.client.Exists(context.Background(), redisRateLimitKey(key)).Result()
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, n)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		expiring := model.NewId()
+		set, err := store.SetIfNotExistsWithTTL(expiring, 1, 0)
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		require.Eventually(t, func() bool {
+			v, _, err := store.GetWithTime(expiring)
+			return err == nil && v == -1
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestRedisRateLimitStoreSharedByNodes(t *testing.T) {
+	quota := throttled.RateQuota{MaxRate: throttled.PerMin(1), MaxBurst: 1}
+	node1, err := throttled.NewGCRARateLimiter(newTestRedisRateLimitStore(t), quota)
+	require.NoError(t, err)
+	node2, err := throttled.NewGCRARateLimiter(newTestRedisRateLimitStore(t), quota)
+	require.NoError(t, err)
+
+	key := model.NewId()
+	for i, limiter := range []*throttled.GCRARateLimiter{node1, node2} {
+		limited, _, err := limiter.RateLimit(key, 1)
+		require.NoError(t, err)
+		require.False(t, limited, "request %d", i)
+	}
+
+	limited, result, err := node1.RateLimit(key, 1)
+	require.NoError(t, err)
+	assert.True(t, limited)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/store/memstore"
 
 	"github.com/mattermost/mattermost/server/public/model"
 )
@@ -103,3 +104,118 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+func TestRateLimitPolicies(t *testing.T) {
+	settings := genRateLimitSettings(false, true, "")
+	settings.Policies = []*model.RateLimitPolicy{{
+		Name:     model.NewString("login"),
+		Routes:   []string{"/api/v4/users/login"},
+		Methods:  []string{"POST"},
+		PerMin:   model.NewInt(1),
+		MaxBurst: model.NewInt(0),
+	}}
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	var handled int
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled++
+	}))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "10.10.10.5:80"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("POST", "/api/v4/users/login")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	require.NotEmpty(t, rec.Header().Get("RateLimit-Reset"))
+
+	rec = serve("POST", "/api/v4/users/login")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// Other routes and methods use the global quota.
+	for i := 0; i < 10; i++ {
+		rec = serve("GET", "/api/v4/users/login")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "101", rec.Header().Get("RateLimit-Limit"))
+
+		rec = serve("POST", "/api/v4/posts")
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	require.Equal(t, 21, handled)
+}
+
+func TestUserIdRateLimitPolicies(t *testing.T) {
+	settings := genRateLimitSettings(true, false, "")
+	settings.Policies = []*model.RateLimitPolicy{{
+		Name:     model.NewString("posts"),
+		Routes:   []string{"/api/v4/posts"},
+		PerMin:   model.NewInt(1),
+		MaxBurst: model.NewInt(0),
+	}}
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	userID := model.NewId()
+	limit := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		rateLimiter.UserIdRateLimit(userID, httptest.NewRequest(method, path, nil), rec)
+		return rec
+	}
+
+	rec := limit("POST", "/api/v4/posts")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+
+	rec = limit("POST", "/api/v4/posts")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// The global quota of the user is not charged by the requests matching a policy.
+	rec = limit("GET", "/api/v4/users/me")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "101", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "100", rec.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRouteRateLimiter(t *testing.T) {
+	store, err := memstore.New(100)
+	require.NoError(t, err)
+
+	global, err := NewRateLimiterWithStore(genRateLimitSettings(false, true, ""), store, nil)
+	require.NoError(t, err)
+
+	routeSettings := model.RateLimitSettings{PerSec: model.NewInt(1), MaxBurst: model.NewInt(0)}
+	routeSettings.SetDefaults()
+	newRouteHandler := func() http.Handler {
+		route, err := NewRouteRateLimiter("login", &routeSettings, store, nil)
+		require.NoError(t, err)
+		return route.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	}
+	// Two nodes of a cluster sharing the store.
+	node1, node2 := newRouteHandler(), newRouteHandler()
+
+	serve := func(handler http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v4/users/login/desktop_token", nil)
+		req.RemoteAddr = "10.10.10.5:80"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, serve(node1).Code)
+	require.Equal(t, http.StatusTooManyRequests, serve(node2).Code)
+
+	// The keys of the route don't collide with the ones of the global limiter.
+	rec := httptest.NewRecorder()
+	require.False(t, global.RateLimitWriter("10.10.10.5", rec))
+	require.Equal(t, "100", rec.Header().Get("RateLimit-Remaining"))
+}
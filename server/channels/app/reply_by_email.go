@@ -0,0 +1,249 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
+)
+
+const (
+	// The signature of a reply address is truncated, keeping the address short enough
+	// for its local part to stay within the 64 characters allowed.
+	replyByEmailSignatureLength = 10
+
+	// Messages are read in memory, so they are limited to a size fitting a reply with a
+	// few attachments rather than to the size of the largest file.
+	replyByEmailMaxMessageSize = 10 * 1024 * 1024
+
+	// A post can have up to 10 files.
+	replyByEmailMaxAttachments = 10
+)
+
+var replyByEmailSignatureEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// replyByEmailSignature signs a post id for the user a notification of the post is sent
+// to, so that a reply address can't be forged, nor used by anyone else.
+func (a *App) replyByEmailSignature(postID, userID string) []byte {
+	keyHash := hmac.New(sha256.New, a.PostActionCookieSecret())
+	keyHash.Write([]byte("reply_by_email"))
+
+	hash := hmac.New(sha256.New, keyHash.Sum(nil))
+	hash.Write([]byte(postID + ":" + userID))
+	return hash.Sum(nil)[:replyByEmailSignatureLength]
+}
+
+// replyByEmailAddress returns the address the notification of a post sent to a user can
+// be replied to, or an empty string if replying by email is disabled.
+func (a *App) replyByEmailAddress(postID, userID string) string {
+	emailSettings := a.Config().EmailSettings
+	if !*emailSettings.EnableReplyByEmail || postID == "" {
+		return ""
+	}
+
+	local, domain, ok := strings.Cut(*emailSettings.ReplyByEmailAddress, "@")
+	if !ok {
+		return ""
+	}
+
+	signature := replyByEmailSignatureEncoding.EncodeToString(a.replyByEmailSignature(postID, userID))
+	return local + "+" + postID + "-" + signature + "@" + domain
+}
+
+// parseReplyByEmailAddress returns the post id and the signature of a reply address, and
+// whether the address is one.
+func (a *App) parseReplyByEmailAddress(address string) (string, []byte, bool) {
+	replyLocal, replyDomain, ok := strings.Cut(*a.Config().EmailSettings.ReplyByEmailAddress, "@")
+	if !ok {
+		return "", nil, false
+	}
+
+	local, domain, ok := strings.Cut(strings.ToLower(address), "@")
+	if !ok || domain != strings.ToLower(replyDomain) {
+		return "", nil, false
+	}
+
+	tag, ok := strings.CutPrefix(local, strings.ToLower(replyLocal)+"+")
+	if !ok {
+		return "", nil, false
+	}
+
+	postID, encodedSignature, ok := strings.Cut(tag, "-")
+	if !ok || !model.IsValidId(postID) {
+		return "", nil, false
+	}
+
+	signature, err := replyByEmailSignatureEncoding.DecodeString(encodedSignature)
+	if err != nil || len(signature) != replyByEmailSignatureLength {
+		return "", nil, false
+	}
+
+	return postID, signature, true
+}
+
+// HandleInboundEmail posts a reply to a notification email as a reply to the thread of
+// the post it notified of. The reply is posted by the user the notification was sent to,
+// who must also be the author of the reply.
+func (a *App) HandleInboundEmail(c request.CTX, recipients []string, data []byte) *model.AppError {
+	if !*a.Config().EmailSettings.EnableReplyByEmail {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	message, err := mail.ParseInboundMessage(data, *a.Config().EmailSettings.ReplyByEmailAuthservID)
+	if err != nil {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.parse.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	if *a.Config().EmailSettings.ReplyByEmailRequireAuthentication && !message.Authenticated {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.unauthenticated_sender.app_error", nil, "", http.StatusForbidden)
+	}
+
+	user, err := a.Srv().Store().User().GetByEmail(message.From)
+	if err != nil {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.unknown_sender.app_error", nil, "", http.StatusForbidden).Wrap(err)
+	}
+	if user.DeleteAt != 0 {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.unknown_sender.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+	}
+
+	var postID string
+	for _, recipient := range recipients {
+		if id, signature, ok := a.parseReplyByEmailAddress(recipient); ok && hmac.Equal(signature, a.replyByEmailSignature(id, user.Id)) {
+			postID = id
+			break
+		}
+	}
+	if postID == "" {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.invalid_address.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+	}
+
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !a.HasPermissionToChannel(c, user.Id, post.ChannelId, model.PermissionCreatePost) {
+		return model.NewAppError("HandleInboundEmail", "api.context.permissions.app_error", nil, "user_id="+user.Id+", channel_id="+post.ChannelId, http.StatusForbidden)
+	}
+
+	channel, appErr := a.GetChannel(c, post.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+
+	reply := &model.Post{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		RootId:    post.Id,
+		Message:   message.Text,
+	}
+	if post.RootId != "" {
+		reply.RootId = post.RootId
+	}
+
+	if len(message.Attachments) > 0 {
+		if *a.Config().FileSettings.EnableFileAttachments {
+			fileIDs, appErr := a.uploadInboundEmailAttachments(c, message.Attachments, channel, user.Id)
+			if appErr != nil {
+				return appErr
+			}
+			reply.FileIds = fileIDs
+		} else {
+			c.Logger().Info("Ignoring the attachments of an email reply since file attachments are disabled", mlog.String("user_id", user.Id), mlog.String("post_id", post.Id))
+		}
+	}
+
+	if reply.Message == "" && len(reply.FileIds) == 0 {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.empty.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if _, appErr := a.CreatePostAsUser(c, reply, "", true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (a *App) uploadInboundEmailAttachments(c request.CTX, attachments []*mail.InboundAttachment, channel *model.Channel, userID string) ([]string, *model.AppError) {
+	if len(attachments) > replyByEmailMaxAttachments {
+		c.Logger().Info("Ignoring the attachments of an email reply beyond the most a post can have", mlog.String("user_id", userID), mlog.Int("attachments", len(attachments)))
+		attachments = attachments[:replyByEmailMaxAttachments]
+	}
+
+	maxFileSize := *a.Config().FileSettings.MaxFileSize
+	fileIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if int64(len(attachment.Data)) > maxFileSize {
+			return nil, model.NewAppError("HandleInboundEmail", "api.file.upload_file.too_large_detailed.app_error", map[string]any{
+				"Length":   len(attachment.Data),
+				"Limit":    maxFileSize,
+				"Filename": attachment.Name,
+			}, "", http.StatusRequestEntityTooLarge)
+		}
+
+		name := attachment.Name
+		if name == "" {
+			name = "attachment"
+		}
+
+		info, appErr := a.UploadFileForUserAndTeam(c, attachment.Data, channel.Id, name, userID, channel.TeamId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		fileIDs = append(fileIDs, info.Id)
+	}
+
+	return fileIDs, nil
+}
+
+// startInboundMailServer starts receiving the replies to notification emails, if replying
+// by email is enabled.
+func (s *Server) startInboundMailServer() {
+	s.inboundMailServerMut.Lock()
+	defer s.inboundMailServerMut.Unlock()
+
+	emailSettings := s.platform.Config().EmailSettings
+	if !*emailSettings.EnableReplyByEmail {
+		return
+	}
+
+	appInstance := New(ServerConnector(s.Channels()))
+	server, err := mail.NewInboundServer(*emailSettings.ReplyByEmailListenAddress, replyByEmailMaxMessageSize, func(recipient string) bool {
+		_, _, ok := appInstance.parseReplyByEmailAddress(recipient)
+		return ok
+	}, func(_ string, recipients []string, data []byte) error {
+		if appErr := appInstance.HandleInboundEmail(request.EmptyContext(s.Log()), recipients, data); appErr != nil {
+			return appErr
+		}
+		return nil
+	}, s.Log())
+	if err != nil {
+		s.Log().Error("Failed to start the inbound mail server", mlog.String("address", *emailSettings.ReplyByEmailListenAddress), mlog.Err(err))
+		return
+	}
+
+	s.Log().Info("Inbound mail server is listening", mlog.String("address", server.Addr().String()))
+	s.inboundMailServer = server
+}
+
+func (s *Server) stopInboundMailServer() {
+	s.inboundMailServerMut.Lock()
+	defer s.inboundMailServerMut.Unlock()
+
+	if s.inboundMailServer == nil {
+		return
+	}
+
+	if err := s.inboundMailServer.Close(); err != nil {
+		s.Log().Warn("Error while stopping the inbound mail server", mlog.Err(err))
+	}
+	s.inboundMailServer = nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestReplyByEmailAddress(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	postID := model.NewId()
+	userID := model.NewId()
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Empty(t, th.App.replyByEmailAddress(postID, userID))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableReplyByEmail = true
+		*cfg.EmailSettings.ReplyByEmailAddress = "Reply@example.com"
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(postID, userID)
+		require.True(t, strings.HasPrefix(address, "Reply+"+postID+"-"))
+
+		local, _, _ := strings.Cut(address, "@")
+		assert.LessOrEqual(t, len(local), 64)
+
+		parsedPostID, signature, ok := th.App.parseReplyByEmailAddress(strings.ToLower(address))
+		require.True(t, ok)
+		assert.Equal(t, postID, parsedPostID)
+		assert.Equal(t, th.App.replyByEmailSignature(postID, userID), signature)
+		assert.NotEqual(t, th.App.replyByEmailSignature(postID, model.NewId()), signature)
+	})
+
+	t.Run("invalid addresses", func(t *testing.T) {
+		for _, address := range []string{
+			"reply@example.com",
+			"reply+" + postID + "@example.com",
+			"reply+" + postID + "-notbase32!@example.com",
+			"other+" + postID + "-aaaaaaaaaaaaaaaa@example.com",
+			"reply+" + postID + "-aaaaaaaaaaaaaaaa@other.example.com",
+			"reply+notanid-aaaaaaaaaaaaaaaa@example.com",
+		} {
+			_, _, ok := th.App.parseReplyByEmailAddress(address)
+			assert.False(t, ok, address)
+		}
+	})
+}
+
+func TestHandleInboundEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableReplyByEmail = true
+		*cfg.EmailSettings.ReplyByEmailAddress = "reply@example.com"
+		*cfg.EmailSettings.ReplyByEmailAuthservID = "mx.example.com"
+	})
+
+	authenticationResults := func(from string) string {
+		_, domain, _ := strings.Cut(from, "@")
+		return "Authentication-Results: mx.example.com; dkim=pass header.d=" + domain
+	}
+	makeMessage := func(from, body string) []byte {
+		return []byte(strings.ReplaceAll(fmt.Sprintf("%s\nFrom: %s\nSubject: Re: New message\n\n%s\n", authenticationResults(from), from, body), "\n", "\r\n"))
+	}
+
+	t.Run("posts the reply to the thread", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, makeMessage(th.BasicUser.Email, "Sounds good.\n\nOn Mon, Mar 4, 2024 at 10:00 AM Mattermost <noreply@example.com> wrote:\n> Shall we meet?"))
+		require.Nil(t, appErr)
+
+		thread, appErr := th.App.GetPostThread(th.BasicPost.Id, model.GetPostsOptions{}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		var reply *model.Post
+		for _, post := range thread.Posts {
+			if post.RootId == th.BasicPost.Id {
+				reply = post
+			}
+		}
+		require.NotNil(t, reply)
+		assert.Equal(t, th.BasicUser.Id, reply.UserId)
+		assert.Equal(t, "Sounds good.", reply.Message)
+	})
+
+	t.Run("rejects a reply from another user", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, makeMessage(th.BasicUser2.Email, "Sounds good."))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reply_by_email.invalid_address.app_error", appErr.Id)
+	})
+
+	t.Run("rejects an unknown sender", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, makeMessage("unknown@example.com", "Sounds good."))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reply_by_email.unknown_sender.app_error", appErr.Id)
+	})
+
+	t.Run("rejects an unauthenticated sender", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+		message := []byte("From: " + th.BasicUser.Email + "\r\nSubject: Re: New message\r\n\r\nSounds good.\r\n")
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, message)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reply_by_email.unauthenticated_sender.app_error", appErr.Id)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.ReplyByEmailRequireAuthentication = false
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.ReplyByEmailRequireAuthentication = true
+		})
+
+		appErr = th.App.HandleInboundEmail(th.Context, []string{address}, message)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("rejects an empty reply", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, makeMessage(th.BasicUser.Email, "> Shall we meet?"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reply_by_email.empty.app_error", appErr.Id)
+	})
+
+	t.Run("rejects a reply to a channel the user left", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		post := th.CreatePost(channel)
+		address := th.App.replyByEmailAddress(post.Id, th.BasicUser.Id)
+		require.Nil(t, th.App.RemoveUserFromChannel(th.Context, th.BasicUser.Id, th.BasicUser.Id, channel))
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, makeMessage(th.BasicUser.Email, "Sounds good."))
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("uploads the attachments", func(t *testing.T) {
+		address := th.App.replyByEmailAddress(th.BasicPost.Id, th.BasicUser.Id)
+		message := strings.Join([]string{
+			authenticationResults(th.BasicUser.Email),
+			"From: " + th.BasicUser.Email,
+			"Subject: Re: New message",
+			"MIME-Version: 1.0",
+			`Content-Type: multipart/mixed; boundary="boundary"`,
+			"",
+			"--boundary",
+			"Content-Type: text/plain",
+			"",
+			"Here are the notes.",
+			"--boundary",
+			`Content-Type: text/plain; name="notes.txt"`,
+			`Content-Disposition: attachment; filename="notes.txt"`,
+			"",
+			"The notes.",
+			"--boundary--",
+			"",
+		}, "\r\n")
+
+		appErr := th.App.HandleInboundEmail(th.Context, []string{address}, []byte(message))
+		require.Nil(t, appErr)
+
+		thread, appErr := th.App.GetPostThread(th.BasicPost.Id, model.GetPostsOptions{}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		var reply *model.Post
+		for _, post := range thread.Posts {
+			if post.Message == "Here are the notes." {
+				reply = post
+			}
+		}
+		require.NotNil(t, reply)
+		require.Len(t, reply.FileIds, 1)
+
+		info, appErr := th.App.GetFileInfo(th.Context, reply.FileIds[0])
+		require.Nil(t, appErr)
+		assert.Equal(t, "notes.txt", info.Name)
+	})
+}
@@ -5,10 +5,10 @@ package app
 
 import (
 	"bytes"
-	"encoding/csv"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
@@ -17,67 +17,41 @@ import (
 )
 
 func (a *App) SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError {
-	switch format {
-	case "csv":
-		return a.saveCSVChunk(prefix, count, reportData)
+	formatter, ok := reportFormatters[format]
+	if !ok {
+		return model.NewAppError("SaveReportChunk", "app.save_report_chunk.unsupported_format", nil, "unsupported report format", http.StatusBadRequest)
 	}
-	return model.NewAppError("SaveReportChunk", "app.save_report_chunk.unsupported_format", nil, "unsupported report format", http.StatusBadRequest)
-}
 
-func (a *App) saveCSVChunk(prefix string, count int, reportData []model.ReportableObject) *model.AppError {
 	var buf bytes.Buffer
-	w := csv.NewWriter(&buf)
-
-	for _, report := range reportData {
-		err := w.Write(report.ToReport())
-		if err != nil {
-			return model.NewAppError("saveCSVChunk", "app.save_csv_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
-		}
+	if err := formatter.writeChunk(&buf, reportData); err != nil {
+		return model.NewAppError("SaveReportChunk", "app.save_report_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
-	w.Flush()
-	if err := w.Error(); err != nil {
-		return model.NewAppError("saveCSVChunk", "app.save_csv_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
-	}
-	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, "csv"))
+	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, formatter.chunkExtension()))
 	return appErr
 }
 
 func (a *App) CompileReportChunks(format string, prefix string, numberOfChunks int, headers []string) *model.AppError {
-	switch format {
-	case "csv":
-		return a.compileCSVChunks(prefix, numberOfChunks, headers)
-	}
-	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
-}
-
-func (a *App) compileCSVChunks(prefix string, numberOfChunks int, headers []string) *model.AppError {
-	filePath := makeCompiledFilePath(prefix, "csv")
-
-	var compiledBuf bytes.Buffer
-	w := csv.NewWriter(&compiledBuf)
-	err := w.Write(headers)
-	if err != nil {
-		return model.NewAppError("compileCSVChunks", "app.compile_csv_chunks.header_error", nil, "", http.StatusInternalServerError).Wrap(err)
-	}
-	w.Flush()
-	if err = w.Error(); err != nil {
-		return model.NewAppError("saveCSVChunk", "app.save_csv_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	formatter, ok := reportFormatters[format]
+	if !ok {
+		return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 	}
 
+	chunks := make([][]byte, 0, numberOfChunks)
 	for i := 0; i < numberOfChunks; i++ {
-		chunkFilePath := makeFilePath(prefix, i, "csv")
-		chunk, err := a.ReadFile(chunkFilePath)
+		chunk, err := a.ReadFile(makeFilePath(prefix, i, formatter.chunkExtension()))
 		if err != nil {
 			return err
 		}
-		_, writeErr := compiledBuf.Write(chunk)
-		if writeErr != nil {
-			return err
-		}
+		chunks = append(chunks, chunk)
 	}
 
-	_, appErr := a.WriteFile(&compiledBuf, filePath)
+	var compiledBuf bytes.Buffer
+	if err := formatter.compile(&compiledBuf, headers, chunks); err != nil {
+		return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	_, appErr := a.WriteFile(&compiledBuf, makeCompiledFilePath(prefix, format))
 	if appErr != nil {
 		return appErr
 	}
@@ -91,7 +65,7 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 		return model.NewAppError("SendReportToUser", "app.report.send_report_to_user.missing_user_id", nil, "", http.StatusInternalServerError)
 	}
 	dateRange := job.Data["date_range"]
-	if dateRange == "" {
+	if dateRange == "" && job.Type != model.JobTypeChannelMembershipReport {
 		return model.NewAppError("SendReportToUser", "app.report.send_report_to_user.missing_date_range", nil, "", http.StatusInternalServerError)
 	}
 
@@ -125,11 +99,20 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 	if err != nil {
 		return err
 	}
+	messageID := "app.report.send_report_to_user.export_finished"
+	switch job.Type {
+	case model.JobTypeChannelMembershipReport:
+		messageID = "app.report.send_report_to_user.channel_membership_finished"
+	case model.JobTypeTeamActivityReport:
+		messageID = "app.report.send_report_to_user.team_activity_finished"
+	}
+
 	T := i18n.GetUserTranslations(user.Locale)
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message: T("app.report.send_report_to_user.export_finished", map[string]string{
+		Message: T(messageID, map[string]string{
 			"DateRange": getTranslatedDateRange(dateRange),
+			"Format":    strings.ToUpper(format),
 		}),
 		Type:    model.PostTypeDefault,
 		UserId:  systemBot.UserId,
@@ -141,16 +124,13 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 }
 
 func (a *App) CleanupReportChunks(format string, prefix string, numberOfChunks int) *model.AppError {
-	switch format {
-	case "csv":
-		return a.cleanupCSVChunks(prefix, numberOfChunks)
+	formatter, ok := reportFormatters[format]
+	if !ok {
+		return model.NewAppError("CleanupReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 	}
-	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
-}
 
-func (a *App) cleanupCSVChunks(prefix string, numberOfChunks int) *model.AppError {
 	for i := 0; i < numberOfChunks; i++ {
-		chunkFilePath := makeFilePath(prefix, i, "csv")
+		chunkFilePath := makeFilePath(prefix, i, formatter.chunkExtension())
 		if err := a.RemoveFile(chunkFilePath); err != nil {
 			return err
 		}
@@ -198,41 +178,92 @@ func (a *App) GetUserCountForReport(filter *model.UserReportOptions) (*int64, *m
 	return &count, nil
 }
 
-func (a *App) StartUsersBatchExport(rctx request.CTX, dateRange string, startAt int64, endAt int64) *model.AppError {
-	if license := a.Srv().License(); license == nil || (license.SkuShortName != model.LicenseShortSkuProfessional && license.SkuShortName != model.LicenseShortSkuEnterprise) {
-		return model.NewAppError("StartUsersBatchExport", "app.report.start_users_batch_export.license_error", nil, "", http.StatusBadRequest)
+func (a *App) GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, *model.AppError) {
+	if appErr := filter.IsValid(); appErr != nil {
+		return nil, appErr
 	}
 
-	options := map[string]string{
-		"requesting_user_id": rctx.Session().UserId,
-		"date_range":         dateRange,
-		"start_at":           strconv.FormatInt(startAt, 10),
-		"end_at":             strconv.FormatInt(endAt, 10),
+	rows, err := a.Srv().Store().Channel().GetChannelMembershipReport(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembershipReport", "app.report.get_channel_membership_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
-	// Check for existing job
-	// TODO: Maybe make this a reusable function?
-	pendingJobs, err := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, model.JobTypeExportUsersToCSV, model.JobStatusPending)
+	return rows, nil
+}
+
+func (a *App) GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, *model.AppError) {
+	if appErr := filter.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	rows, err := a.Srv().Store().Team().GetTeamActivityReport(filter)
 	if err != nil {
-		return err
+		return nil, model.NewAppError("GetTeamActivityReport", "app.report.get_team_activity_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
-	for _, job := range pendingJobs {
-		if job.Data["date_range"] == options["date_range"] && job.Data["requesting_user_id"] == rctx.Session().UserId {
-			return model.NewAppError("StartUsersBatchExport", "app.report.start_users_batch_export.job_exists", nil, "", http.StatusBadRequest)
-		}
+
+	return rows, nil
+}
+
+func (a *App) StartUsersBatchExport(rctx request.CTX, format string, dateRange string, startAt int64, endAt int64) *model.AppError {
+	options := map[string]string{
+		"date_range": dateRange,
+		"start_at":   strconv.FormatInt(startAt, 10),
+		"end_at":     strconv.FormatInt(endAt, 10),
 	}
 
-	inProgressJobs, err := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, model.JobTypeExportUsersToCSV, model.JobStatusInProgress)
-	if err != nil {
-		return err
+	return a.startBatchReport(rctx, model.JobTypeExportUsersToCSV, format, options, "app.report.start_users_batch_export.started_export")
+}
+
+func (a *App) StartChannelMembershipReport(rctx request.CTX, format string, teamID string) *model.AppError {
+	options := map[string]string{
+		"team_id": teamID,
+	}
+
+	return a.startBatchReport(rctx, model.JobTypeChannelMembershipReport, format, options, "app.report.start_channel_membership_report.started_export")
+}
+
+func (a *App) StartTeamActivityReport(rctx request.CTX, format string, dateRange string, startAt int64, endAt int64) *model.AppError {
+	options := map[string]string{
+		"date_range": dateRange,
+		"start_at":   strconv.FormatInt(startAt, 10),
+		"end_at":     strconv.FormatInt(endAt, 10),
+	}
+
+	return a.startBatchReport(rctx, model.JobTypeTeamActivityReport, format, options, "app.report.start_team_activity_report.started_export")
+}
+
+// startBatchReport creates a job generating a report in the given format, unless the
+// same report was already requested by the user, and lets the user know that the report
+// will be sent once ready.
+func (a *App) startBatchReport(rctx request.CTX, jobType string, format string, options map[string]string, startedMessageID string) *model.AppError {
+	if license := a.Srv().License(); license == nil || (license.SkuShortName != model.LicenseShortSkuProfessional && license.SkuShortName != model.LicenseShortSkuEnterprise) {
+		return model.NewAppError("startBatchReport", "app.report.start_users_batch_export.license_error", nil, "", http.StatusBadRequest)
 	}
-	for _, job := range inProgressJobs {
-		if job.Data["date_range"] == options["date_range"] && job.Data["requesting_user_id"] == rctx.Session().UserId {
-			return model.NewAppError("StartUsersBatchExport", "app.report.start_users_batch_export.job_exists", nil, "", http.StatusBadRequest)
+
+	if format == "" {
+		format = model.ReportFormatCSV
+	}
+	if !model.IsValidReportExportFormat(format) {
+		return model.NewAppError("startBatchReport", "app.report.start_batch_report.invalid_format", map[string]any{"Format": format}, "", http.StatusBadRequest)
+	}
+
+	options["requesting_user_id"] = rctx.Session().UserId
+	options["report_format"] = format
+
+	// Check for existing job
+	for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress} {
+		jobs, err := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, jobType, status)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if isSameBatchReport(job, options) {
+				return model.NewAppError("startBatchReport", "app.report.start_users_batch_export.job_exists", nil, "", http.StatusBadRequest)
+			}
 		}
 	}
 
-	_, err = a.Srv().Jobs.CreateJob(rctx, model.JobTypeExportUsersToCSV, options)
+	_, err := a.Srv().Jobs.CreateJob(rctx, jobType, options)
 	if err != nil {
 		return err
 	}
@@ -258,9 +289,12 @@ func (a *App) StartUsersBatchExport(rctx request.CTX, dateRange string, startAt
 		T := i18n.GetUserTranslations(user.Locale)
 		post := &model.Post{
 			ChannelId: channel.Id,
-			Message:   T("app.report.start_users_batch_export.started_export", map[string]string{"DateRange": getTranslatedDateRange(dateRange)}),
-			Type:      model.PostTypeDefault,
-			UserId:    systemBot.UserId,
+			Message: T(startedMessageID, map[string]string{
+				"DateRange": getTranslatedDateRange(options["date_range"]),
+				"Format":    strings.ToUpper(format),
+			}),
+			Type:   model.PostTypeDefault,
+			UserId: systemBot.UserId,
 		}
 
 		if _, err := a.CreatePost(rctx, post, channel, false, true); err != nil {
@@ -271,6 +305,28 @@ func (a *App) StartUsersBatchExport(rctx request.CTX, dateRange string, startAt
 	return nil
 }
 
+// isSameBatchReport returns whether the job generates the report requested with the
+// given options. The options of the reports requested before the report formats were
+// added have no format, and are CSV reports.
+func isSameBatchReport(job *model.Job, options map[string]string) bool {
+	for key, value := range options {
+		if key == "start_at" || key == "end_at" {
+			// The date range is compared instead, since the bounds depend on when the report was requested.
+			continue
+		}
+
+		jobValue := job.Data[key]
+		if key == "report_format" && jobValue == "" {
+			jobValue = model.ReportFormatCSV
+		}
+		if jobValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 func getTranslatedDateRange(dateRange string) string {
 	switch dateRange {
 	case model.ReportDurationLast30Days:
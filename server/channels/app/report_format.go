@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const xlsxReportSheetName = "Report"
+
+// reportFormatter writes batch reports in a given format. The rows of each batch are
+// saved as a chunk, and the chunks are compiled into the report once all of them are
+// saved.
+type reportFormatter interface {
+	// chunkExtension returns the extension of the files the chunks are saved to.
+	chunkExtension() string
+	writeChunk(w io.Writer, reportData []model.ReportableObject) error
+	compile(w io.Writer, headers []string, chunks [][]byte) error
+}
+
+// reportFormatters are the supported report formats, keyed by the extension of the
+// compiled reports.
+var reportFormatters = map[string]reportFormatter{
+	model.ReportFormatCSV:   csvReportFormatter{},
+	model.ReportFormatJSONL: jsonlReportFormatter{},
+	model.ReportFormatXLSX:  xlsxReportFormatter{},
+}
+
+type csvReportFormatter struct{}
+
+func (csvReportFormatter) chunkExtension() string {
+	return model.ReportFormatCSV
+}
+
+func (csvReportFormatter) writeChunk(w io.Writer, reportData []model.ReportableObject) error {
+	cw := csv.NewWriter(w)
+	for _, report := range reportData {
+		if err := cw.Write(report.ToReport()); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (csvReportFormatter) compile(w io.Writer, headers []string, chunks [][]byte) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonlReportFormatter writes newline-delimited JSON reports, with one object per
+// row keyed by the headers of the report. The chunks hold the rows as arrays, since
+// the headers aren't known until the report is compiled.
+type jsonlReportFormatter struct{}
+
+func (jsonlReportFormatter) chunkExtension() string {
+	return model.ReportFormatJSONL
+}
+
+func (jsonlReportFormatter) writeChunk(w io.Writer, reportData []model.ReportableObject) error {
+	enc := json.NewEncoder(w)
+	for _, report := range reportData {
+		if err := enc.Encode(report.ToReport()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (jsonlReportFormatter) compile(w io.Writer, headers []string, chunks [][]byte) error {
+	bw := bufio.NewWriter(w)
+	for _, chunk := range chunks {
+		dec := json.NewDecoder(bytes.NewReader(chunk))
+		for {
+			var row []string
+			if err := dec.Decode(&row); err == io.EOF {
+				break
+			} else if err != nil {
+				return errors.Wrap(err, "failed to decode report row")
+			}
+
+			if err := writeJSONLReportRow(bw, headers, row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeJSONLReportRow writes the row as an object keeping the order of the headers,
+// which a map wouldn't.
+func writeJSONLReportRow(w *bufio.Writer, headers []string, row []string) error {
+	w.WriteByte('{')
+	for i, value := range row {
+		if i >= len(headers) {
+			break
+		}
+		if i > 0 {
+			w.WriteByte(',')
+		}
+
+		key, err := json.Marshal(headers[i])
+		if err != nil {
+			return err
+		}
+		val, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		w.Write(key)
+		w.WriteByte(':')
+		w.Write(val)
+	}
+	w.WriteByte('}')
+	_, err := w.WriteString("\n")
+	return err
+}
+
+// xlsxReportFormatter writes reports as an Excel workbook with a single sheet. The
+// chunks are saved as CSV, and streamed into the sheet when the report is compiled.
+type xlsxReportFormatter struct{}
+
+func (xlsxReportFormatter) chunkExtension() string {
+	return model.ReportFormatCSV
+}
+
+func (xlsxReportFormatter) writeChunk(w io.Writer, reportData []model.ReportableObject) error {
+	return csvReportFormatter{}.writeChunk(w, reportData)
+}
+
+func (xlsxReportFormatter) compile(w io.Writer, headers []string, chunks [][]byte) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), xlsxReportSheetName); err != nil {
+		return err
+	}
+
+	sw, err := f.NewStreamWriter(xlsxReportSheetName)
+	if err != nil {
+		return err
+	}
+
+	rowNumber := 1
+	writeRow := func(values []string) error {
+		cells := make([]any, len(values))
+		for i, value := range values {
+			cells[i] = value
+		}
+
+		cell, err := excelize.CoordinatesToCellName(1, rowNumber)
+		if err != nil {
+			return err
+		}
+		rowNumber++
+		return sw.SetRow(cell, cells)
+	}
+
+	if err := writeRow(headers); err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		cr := csv.NewReader(bytes.NewReader(chunk))
+		cr.FieldsPerRecord = -1
+		for {
+			row, err := cr.Read()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return errors.Wrap(err, "failed to read report row")
+			}
+
+			if err := writeRow(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+
+	return f.Write(w)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func compileTestReport(t *testing.T, formatter reportFormatter) []byte {
+	t.Helper()
+
+	var chunks [][]byte
+	for _, row := range testData {
+		var chunk bytes.Buffer
+		require.NoError(t, formatter.writeChunk(&chunk, []model.ReportableObject{row}))
+		chunks = append(chunks, chunk.Bytes())
+	}
+
+	var report bytes.Buffer
+	require.NoError(t, formatter.compile(&report, []string{"Name", "NumPosts", "StartDate"}, chunks))
+	return report.Bytes()
+}
+
+func TestReportFormatters(t *testing.T) {
+	t.Run("jsonl", func(t *testing.T) {
+		report := compileTestReport(t, reportFormatters[model.ReportFormatJSONL])
+
+		expected :=
+			`{"Name":"some-name","NumPosts":"400","StartDate":"2024-01-01"}
+{"Name":"some-other-name","NumPosts":"500","StartDate":"2023-01-01"}
+{"Name":"some-other-other-name","NumPosts":"600","StartDate":"2022-01-01"}
+`
+		require.Equal(t, expected, string(report))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		report := compileTestReport(t, reportFormatters[model.ReportFormatXLSX])
+
+		f, err := excelize.OpenReader(bytes.NewReader(report))
+		require.NoError(t, err)
+		defer f.Close()
+
+		rows, err := f.GetRows(xlsxReportSheetName)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"Name", "NumPosts", "StartDate"},
+			{"some-name", "400", "2024-01-01"},
+			{"some-other-name", "500", "2023-01-01"},
+			{"some-other-other-name", "600", "2022-01-01"},
+		}, rows)
+	})
+}
+
+func TestIsSameBatchReport(t *testing.T) {
+	options := map[string]string{
+		"requesting_user_id": "user",
+		"date_range":         model.ReportDurationLast30Days,
+		"start_at":           "1",
+		"end_at":             "2",
+		"report_format":      model.ReportFormatCSV,
+	}
+
+	job := &model.Job{Data: model.StringMap{
+		"requesting_user_id": "user",
+		"date_range":         model.ReportDurationLast30Days,
+		"start_at":           "0",
+		"end_at":             "1",
+	}}
+	require.True(t, isSameBatchReport(job, options))
+
+	job.Data["report_format"] = model.ReportFormatXLSX
+	require.False(t, isSameBatchReport(job, options))
+
+	job.Data["report_format"] = model.ReportFormatCSV
+	job.Data["date_range"] = model.ReportDurationAllTime
+	require.False(t, isSameBatchReport(job, options))
+}
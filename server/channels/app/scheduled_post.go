@@ -0,0 +1,322 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// The scheduled posts sent at once by each run of the scheduled posts job.
+const scheduledPostsBatchSize = 100
+
+func (a *App) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	if !*a.Config().ServiceSettings.ScheduledPosts {
+		return nil, model.NewAppError("SaveScheduledPost", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	if appErr := scheduledPost.IsValidSchedule(model.GetMillis()); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.checkScheduledPostChannel(scheduledPost); appErr != nil {
+		return nil, appErr
+	}
+
+	// The id is set by the server, so that a scheduled post can't overwrite another one.
+	scheduledPost.Id = ""
+	saved, err := a.Srv().Store().ScheduledPost().Save(scheduledPost)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SaveScheduledPost", "app.scheduled_post.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostCreated, saved, connectionID)
+
+	return saved, nil
+}
+
+func (a *App) GetScheduledPost(scheduledPostID string) (*model.ScheduledPost, *model.AppError) {
+	if !*a.Config().ServiceSettings.ScheduledPosts {
+		return nil, model.NewAppError("GetScheduledPost", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	scheduledPost, err := a.Srv().Store().ScheduledPost().Get(scheduledPostID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return scheduledPost, nil
+}
+
+func (a *App) GetScheduledPostsForUser(rctx request.CTX, userID, teamID string) ([]*model.ScheduledPost, *model.AppError) {
+	if !*a.Config().ServiceSettings.ScheduledPosts {
+		return nil, model.NewAppError("GetScheduledPostsForUser", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	scheduledPosts, err := a.Srv().Store().ScheduledPost().GetForUser(userID, teamID)
+	if err != nil {
+		return nil, model.NewAppError("GetScheduledPostsForUser", "app.scheduled_post.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return scheduledPosts, nil
+}
+
+// UpdateScheduledPost edits the message, files and schedule of a scheduled post. A post
+// which could not be sent is scheduled again.
+func (a *App) UpdateScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	existing, appErr := a.GetScheduledPost(scheduledPost.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if existing.UserId != scheduledPost.UserId {
+		return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if appErr = scheduledPost.IsValidSchedule(model.GetMillis()); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr = a.checkScheduledPostChannel(existing); appErr != nil {
+		return nil, appErr
+	}
+
+	// The channel and thread of a scheduled post can't be changed.
+	existing.Message = scheduledPost.Message
+	existing.SetProps(scheduledPost.GetProps())
+	existing.FileIds = scheduledPost.FileIds
+	existing.Priority = scheduledPost.Priority
+	existing.ScheduledAt = scheduledPost.ScheduledAt
+	existing.ProcessedAt = 0
+	existing.ErrorCode = ""
+
+	updated, err := a.Srv().Store().ScheduledPost().Update(existing)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostUpdated, updated, connectionID)
+
+	return updated, nil
+}
+
+func (a *App) DeleteScheduledPost(rctx request.CTX, userID, scheduledPostID, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.GetScheduledPost(scheduledPostID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scheduledPost.UserId != userID {
+		return nil, model.NewAppError("DeleteScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if err := a.Srv().Store().ScheduledPost().PermanentDelete(scheduledPost.Id); err != nil {
+		return nil, model.NewAppError("DeleteScheduledPost", "app.scheduled_post.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostDeleted, scheduledPost, connectionID)
+
+	return scheduledPost, nil
+}
+
+// checkScheduledPostChannel checks that the channel of the scheduled post can still be
+// posted to.
+func (a *App) checkScheduledPostChannel(scheduledPost *model.ScheduledPost) *model.AppError {
+	channel, err := a.Srv().Store().Channel().Get(scheduledPost.ChannelId, true)
+	if err != nil {
+		return model.NewAppError("checkScheduledPostChannel", "api.context.invalid_param.app_error", map[string]any{"Name": "scheduled_post.channel_id"}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if channel.DeleteAt != 0 {
+		return model.NewAppError("checkScheduledPostChannel", "app.scheduled_post.channel_archived.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) publishScheduledPostEvent(rctx request.CTX, event model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionID string) {
+	scheduledPostJSON, err := json.Marshal(scheduledPost)
+	if err != nil {
+		rctx.Logger().Warn("Failed to encode scheduled post to JSON", mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(event, "", "", scheduledPost.UserId, nil, connectionID)
+	message.Add("scheduled_post", string(scheduledPostJSON))
+	a.Publish(message)
+}
+
+// ProcessScheduledPosts sends the scheduled posts that are due.
+func (a *App) ProcessScheduledPosts(rctx request.CTX) *model.AppError {
+	now := model.GetMillis()
+
+	var afterTime int64
+	var afterID string
+	for {
+		scheduledPosts, err := a.Srv().Store().ScheduledPost().GetPendingScheduledPosts(now, afterTime, afterID, scheduledPostsBatchSize)
+		if err != nil {
+			return model.NewAppError("ProcessScheduledPosts", "app.scheduled_post.get_pending.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, scheduledPost := range scheduledPosts {
+			a.sendScheduledPost(rctx, scheduledPost)
+		}
+
+		if len(scheduledPosts) < scheduledPostsBatchSize {
+			return nil
+		}
+		last := scheduledPosts[len(scheduledPosts)-1]
+		afterTime, afterID = last.ScheduledAt, last.Id
+	}
+}
+
+// sendScheduledPost creates the post of a scheduled post, checking that its author is
+// still allowed to post in the channel. The scheduled post is deleted once sent, or is
+// kept with the reason it could not be sent, which the author is told about.
+func (a *App) sendScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost) {
+	logger := rctx.Logger().With(mlog.String("scheduled_post_id", scheduledPost.Id))
+
+	channel, errorCode := a.checkScheduledPostCanBeSent(rctx, scheduledPost)
+	if errorCode == "" {
+		post := scheduledPost.ToPost()
+		post.SanitizeInput()
+		if post.GetPriority() != nil && (!a.IsPostPriorityEnabled() || post.RootId != "") {
+			post.Metadata = nil
+		}
+
+		if _, appErr := a.CreatePost(rctx, post, channel, true, false); appErr != nil {
+			logger.Warn("Failed to send the scheduled post", mlog.Err(appErr))
+			errorCode = model.ScheduledPostErrorCodeUnknown
+			if appErr.StatusCode == http.StatusBadRequest {
+				errorCode = model.ScheduledPostErrorCodeInvalidPost
+			}
+		}
+	}
+
+	if errorCode == "" {
+		if err := a.Srv().Store().ScheduledPost().PermanentDelete(scheduledPost.Id); err != nil {
+			logger.Warn("Failed to delete the sent scheduled post", mlog.Err(err))
+			return
+		}
+		a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostDeleted, scheduledPost, "")
+		return
+	}
+
+	scheduledPost.ProcessedAt = model.GetMillis()
+	scheduledPost.ErrorCode = errorCode
+	if _, err := a.Srv().Store().ScheduledPost().Update(scheduledPost); err != nil {
+		logger.Warn("Failed to update the scheduled post", mlog.Err(err))
+		return
+	}
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostUpdated, scheduledPost, "")
+
+	if errorCode != model.ScheduledPostErrorCodeUserDeleted {
+		a.notifyScheduledPostFailure(rctx, scheduledPost, channel)
+	}
+}
+
+// checkScheduledPostCanBeSent returns the channel of the scheduled post, or the reason
+// it can't be sent.
+func (a *App) checkScheduledPostCanBeSent(rctx request.CTX, scheduledPost *model.ScheduledPost) (*model.Channel, string) {
+	user, err := a.Srv().Store().User().Get(context.Background(), scheduledPost.UserId)
+	if err != nil || user.DeleteAt != 0 {
+		return nil, model.ScheduledPostErrorCodeUserDeleted
+	}
+
+	channel, err := a.Srv().Store().Channel().Get(scheduledPost.ChannelId, true)
+	if err != nil {
+		return nil, model.ScheduledPostErrorCodeChannelNotFound
+	}
+	if channel.DeleteAt != 0 {
+		return channel, model.ScheduledPostErrorCodeChannelArchived
+	}
+
+	if !a.HasPermissionToChannel(rctx, user.Id, channel.Id, model.PermissionCreatePost) &&
+		!(channel.Type == model.ChannelTypeOpen && a.HasPermissionToTeam(rctx, user.Id, channel.TeamId, model.PermissionCreatePostPublic)) {
+		return channel, model.ScheduledPostErrorCodeNoPermission
+	}
+
+	if scheduledPost.RootId != "" {
+		root, err := a.Srv().Store().Post().GetSingle(rctx, scheduledPost.RootId, true)
+		if err != nil || root.DeleteAt != 0 {
+			return channel, model.ScheduledPostErrorCodeThreadDeleted
+		}
+	}
+
+	return channel, ""
+}
+
+// notifyScheduledPostFailure lets the author of a scheduled post know that it could not
+// be sent, with a direct message from the system bot.
+func (a *App) notifyScheduledPostFailure(rctx request.CTX, scheduledPost *model.ScheduledPost, channel *model.Channel) {
+	user, appErr := a.GetUser(scheduledPost.UserId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the author of the scheduled post", mlog.Err(appErr))
+		return
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the system bot", mlog.Err(appErr))
+		return
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(rctx, user.Id, systemBot.UserId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get or create the DM", mlog.Err(appErr))
+		return
+	}
+
+	channelName := ""
+	if channel != nil {
+		channelName = channel.DisplayName
+		if channelName == "" {
+			channelName = channel.Name
+		}
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	post := &model.Post{
+		ChannelId: dm.Id,
+		UserId:    systemBot.UserId,
+		Type:      model.PostTypeDefault,
+		Message: T("app.scheduled_post.failed_notification", map[string]any{
+			"ChannelName": channelName,
+			"Reason":      T("app.scheduled_post.error_code." + scheduledPost.ErrorCode),
+		}),
+	}
+
+	if _, appErr := a.CreatePost(rctx, post, dm, false, false); appErr != nil {
+		rctx.Logger().Warn("Failed to notify the author of the scheduled post", mlog.Err(appErr))
+	}
+}
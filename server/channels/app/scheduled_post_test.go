@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestScheduledPost(userID, channelID string, scheduledAt int64) *model.ScheduledPost {
+	return &model.ScheduledPost{
+		Draft: model.Draft{
+			UserId:    userID,
+			ChannelId: channelID,
+			Message:   "scheduled message",
+		},
+		ScheduledAt: scheduledAt,
+	}
+}
+
+func TestSaveScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("save and get scheduled post", func(t *testing.T) {
+		scheduledPost := newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()+60*60*1000)
+		saved, appErr := th.App.SaveScheduledPost(th.Context, scheduledPost, "")
+		require.Nil(t, appErr)
+		require.NotEmpty(t, saved.Id)
+
+		got, appErr := th.App.GetScheduledPost(saved.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "scheduled message", got.Message)
+		assert.Equal(t, saved.ScheduledAt, got.ScheduledAt)
+
+		list, appErr := th.App.GetScheduledPostsForUser(th.Context, th.BasicUser.Id, th.BasicTeam.Id)
+		require.Nil(t, appErr)
+		require.Len(t, list, 1)
+		assert.Equal(t, saved.Id, list[0].Id)
+
+		_, appErr = th.App.DeleteScheduledPost(th.Context, th.BasicUser.Id, saved.Id, "")
+		require.Nil(t, appErr)
+	})
+
+	t.Run("schedule in the past", func(t *testing.T) {
+		scheduledPost := newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()-60*60*1000)
+		_, appErr := th.App.SaveScheduledPost(th.Context, scheduledPost, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		scheduledPost := newTestScheduledPost(th.BasicUser.Id, channel.Id, model.GetMillis()+60*60*1000)
+		_, appErr = th.App.SaveScheduledPost(th.Context, scheduledPost, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scheduled_post.channel_archived.app_error", appErr.Id)
+	})
+
+	t.Run("feature disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = true })
+
+		scheduledPost := newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()+60*60*1000)
+		_, appErr := th.App.SaveScheduledPost(th.Context, scheduledPost, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+}
+
+func TestUpdateScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	saved, appErr := th.App.SaveScheduledPost(th.Context, newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()+60*60*1000), "")
+	require.Nil(t, appErr)
+
+	t.Run("update message and schedule", func(t *testing.T) {
+		otherChannel := th.CreateChannel(th.Context, th.BasicTeam)
+		update := newTestScheduledPost(th.BasicUser.Id, otherChannel.Id, model.GetMillis()+2*60*60*1000)
+		update.Id = saved.Id
+		update.Message = "updated message"
+
+		updated, appErr := th.App.UpdateScheduledPost(th.Context, update, "")
+		require.Nil(t, appErr)
+		assert.Equal(t, "updated message", updated.Message)
+		assert.Equal(t, update.ScheduledAt, updated.ScheduledAt)
+		// The channel can't be changed.
+		assert.Equal(t, th.BasicChannel.Id, updated.ChannelId)
+	})
+
+	t.Run("another user's scheduled post", func(t *testing.T) {
+		update := newTestScheduledPost(th.BasicUser2.Id, th.BasicChannel.Id, model.GetMillis()+60*60*1000)
+		update.Id = saved.Id
+
+		_, appErr := th.App.UpdateScheduledPost(th.Context, update, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		_, appErr = th.App.DeleteScheduledPost(th.Context, th.BasicUser2.Id, saved.Id, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestProcessScheduledPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("sends due scheduled posts", func(t *testing.T) {
+		due, err := th.App.Srv().Store().ScheduledPost().Save(newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()-1000))
+		require.NoError(t, err)
+		later, err := th.App.Srv().Store().ScheduledPost().Save(newTestScheduledPost(th.BasicUser.Id, th.BasicChannel.Id, model.GetMillis()+60*60*1000))
+		require.NoError(t, err)
+
+		appErr := th.App.ProcessScheduledPosts(th.Context)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetScheduledPost(due.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		_, appErr = th.App.GetScheduledPost(later.Id)
+		require.Nil(t, appErr)
+
+		posts, appErr := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, "scheduled message", post.Message)
+		assert.Equal(t, th.BasicUser.Id, post.UserId)
+	})
+
+	t.Run("keeps the scheduled posts which can't be sent", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		scheduledPost, err := th.App.Srv().Store().ScheduledPost().Save(newTestScheduledPost(th.BasicUser.Id, channel.Id, model.GetMillis()-1000))
+		require.NoError(t, err)
+
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.ProcessScheduledPosts(th.Context)
+		require.Nil(t, appErr)
+
+		got, appErr := th.App.GetScheduledPost(scheduledPost.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, got.ProcessedAt)
+		assert.Equal(t, model.ScheduledPostErrorCodeChannelArchived, got.ErrorCode)
+
+		// It isn't attempted again until it is rescheduled.
+		appErr = th.App.ProcessScheduledPosts(th.Context)
+		require.Nil(t, appErr)
+		again, appErr := th.App.GetScheduledPost(scheduledPost.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, got.ProcessedAt, again.ProcessedAt)
+	})
+}
@@ -5,6 +5,7 @@ package app
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -53,16 +54,76 @@ func (a *App) PurgeElasticsearchIndexes(c request.CTX, indexes []string) *model.
 	return appErr
 }
 
-func (a *App) PurgeBleveIndexes(c request.CTX) *model.AppError {
+func (a *App) PurgeBleveIndexes(c request.CTX, indexes []string) *model.AppError {
 	engine := a.SearchEngine().BleveEngine
 	if engine == nil {
 		err := model.NewAppError("PurgeBleveIndexes", "searchengine.bleve.disabled.error", nil, "", http.StatusNotImplemented)
 		return err
 	}
-	if err := engine.PurgeIndexes(c); err != nil {
-		return err
+
+	var appErr *model.AppError
+	if len(indexes) > 0 {
+		appErr = engine.PurgeIndexList(c, indexes)
+	} else {
+		appErr = engine.PurgeIndexes(c)
 	}
-	return nil
+
+	return appErr
+}
+
+func (a *App) bleveEngine(where string) (searchengine.IndexStatsReporter, *model.AppError) {
+	engine, ok := a.SearchEngine().BleveEngine.(searchengine.IndexStatsReporter)
+	if !ok {
+		return nil, model.NewAppError(where, "searchengine.bleve.disabled.error", nil, "", http.StatusNotImplemented)
+	}
+	return engine, nil
+}
+
+func (a *App) GetBleveIndexStats() ([]*model.BleveIndexStats, *model.AppError) {
+	engine, appErr := a.bleveEngine("GetBleveIndexStats")
+	if appErr != nil {
+		return nil, appErr
+	}
+	return engine.GetIndexStats()
+}
+
+func (a *App) VerifyBleveIndex(indexName string) (*model.BleveIndexStats, *model.AppError) {
+	engine, appErr := a.bleveEngine("VerifyBleveIndex")
+	if appErr != nil {
+		return nil, appErr
+	}
+	return engine.VerifyIndex(indexName)
+}
+
+// CreateBleveReindexJob creates a Bleve indexing job restricted to the time range and, when
+// set, to the channel of the request.
+func (a *App) CreateBleveReindexJob(c request.CTX, req *model.BleveReindexRequest) (*model.Job, *model.AppError) {
+	if _, appErr := a.bleveEngine("CreateBleveReindexJob"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := req.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	data := map[string]string{}
+	if req.ChannelId != "" {
+		if _, appErr := a.GetChannel(c, req.ChannelId); appErr != nil {
+			return nil, appErr
+		}
+		data["channel_id"] = req.ChannelId
+	}
+	if req.StartTime > 0 {
+		data["start_time"] = strconv.FormatInt(req.StartTime, 10)
+	}
+	if req.EndTime > 0 {
+		data["end_time"] = strconv.FormatInt(req.EndTime, 10)
+	}
+
+	return a.CreateJob(c, &model.Job{
+		Type: model.JobTypeBlevePostIndexing,
+		Data: data,
+	})
 }
 
 func (a *App) ActiveSearchBackend() string {
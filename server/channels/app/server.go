@@ -25,6 +25,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
+	"github.com/throttled/throttled"
 	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -40,6 +41,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/channel_membership_report"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
@@ -48,6 +50,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_encryption"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_tier_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/hosted_purchase_screening"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_process"
@@ -55,12 +59,16 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/last_accessible_post"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_webhook_delivery"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_post_stats"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/regenerate_previews"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/scheduled_posts"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/team_activity_report"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
@@ -102,8 +110,14 @@ type Server struct {
 	ListenAddr  *net.TCPAddr
 	RateLimiter *RateLimiter
 
+	rateLimitStore    throttled.GCRAStore
+	rateLimitStoreMut sync.Mutex
+
 	localModeServer *http.Server
 
+	inboundMailServer    *mail.InboundServer
+	inboundMailServerMut sync.Mutex
+
 	didFinishListen chan struct{}
 
 	EmailService email.ServiceInterface
@@ -386,11 +400,6 @@ func NewServer(options ...Option) (*Server, error) {
 		mlog.Error("SiteURL must be set. Some features will operate incorrectly if the SiteURL is not set. See documentation for details: https://mattermost.com/pl/configure-site-url")
 	}
 
-	// Start email batching because it's not like the other jobs
-	s.platform.AddConfigListener(func(_, _ *model.Config) {
-		s.EmailService.InitEmailBatching()
-	})
-
 	isTrial := false
 	if licence := s.License(); licence != nil {
 		isTrial = licence.IsTrial
@@ -463,6 +472,23 @@ func NewServer(options ...Option) (*Server, error) {
 		}
 	})
 
+	s.platform.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if *oldCfg.EmailSettings.EnableReplyByEmail != *newCfg.EmailSettings.EnableReplyByEmail ||
+			*oldCfg.EmailSettings.ReplyByEmailListenAddress != *newCfg.EmailSettings.ReplyByEmailListenAddress {
+			s.stopInboundMailServer()
+			s.startInboundMailServer()
+		}
+	})
+
+	s.platform.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if *oldCfg.ExperimentalAuditSettings.HashChainEnabled != *newCfg.ExperimentalAuditSettings.HashChainEnabled ||
+			*oldCfg.ExperimentalAuditSettings.DatabaseEnabled != *newCfg.ExperimentalAuditSettings.DatabaseEnabled ||
+			*oldCfg.ExperimentalAuditSettings.CheckpointInterval != *newCfg.ExperimentalAuditSettings.CheckpointInterval ||
+			*oldCfg.ExperimentalAuditSettings.CheckpointSigningKeyFile != *newCfg.ExperimentalAuditSettings.CheckpointSigningKeyFile {
+			s.configureAuditSinks(s.Audit)
+		}
+	})
+
 	// Disable active guest accounts on first run if guest accounts are disabled
 	if !*s.platform.Config().GuestAccountsSettings.Enable {
 		appInstance := New(ServerConnector(s.Channels()))
@@ -501,6 +527,7 @@ func (s *Server) runJobs() {
 		appInstance := New(ServerConnector(s.Channels()))
 		runDNDStatusExpireJob(appInstance)
 		runPostReminderJob(appInstance)
+		runEmailBatchingJob(appInstance)
 	})
 	s.Go(func() {
 		runSecurityJob(s)
@@ -655,6 +682,8 @@ func (s *Server) StopHTTPServer() {
 		s.Server.Close()
 		s.Server = nil
 	}
+
+	s.RateLimiter = nil
 }
 
 func (s *Server) Shutdown() {
@@ -690,7 +719,9 @@ func (s *Server) Shutdown() {
 	s.serviceMux.RUnlock()
 
 	s.StopHTTPServer()
+	s.closeRateLimitStore()
 	s.stopLocalModeServer()
+	s.stopInboundMailServer()
 	// Push notification hub needs to be shutdown after HTTP server
 	// to prevent stray requests from generating a push notification after it's shut down.
 	s.StopPushNotificationsHubWorkers()
@@ -714,10 +745,6 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Failed to stop metrics server", mlog.Err(err))
 	}
 
-	// Stopping email service after HTTP server has stopped to prevent
-	// any stray notifications from being queued.
-	s.EmailService.Stop()
-
 	// This must be done after the cluster is stopped.
 	if s.Jobs != nil {
 		// For simplicity we don't check if workers and schedulers are active
@@ -865,7 +892,9 @@ func (s *Server) Start() error {
 	err := s.FileBackend().TestConnection()
 	if err != nil {
 		if _, ok := err.(*filestore.S3FileBackendNoBucketError); ok {
-			err = s.FileBackend().(*filestore.S3FileBackend).MakeBucket()
+			err = filestore.UnwrapFileBackend(s.FileBackend()).(*filestore.S3FileBackend).MakeBucket()
+		} else if _, ok := err.(*filestore.AzureFileBackendNoContainerError); ok {
+			err = filestore.UnwrapFileBackend(s.FileBackend()).(*filestore.AzureFileBackend).MakeContainer()
 		}
 		if err != nil {
 			mlog.Error("Problem with file storage settings", mlog.Err(err))
@@ -916,7 +945,12 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		rateLimitStore, err2 := s.RateLimitStore()
+		if err2 != nil {
+			return err2
+		}
+
+		rateLimiter, err2 := NewRateLimiterWithStore(&s.platform.Config().RateLimitSettings, rateLimitStore, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
 		if err2 != nil {
 			return err2
 		}
@@ -1080,6 +1114,8 @@ func (s *Server) Start() error {
 		mlog.Error("Error starting inter-cluster services", mlog.Err(err))
 	}
 
+	s.startInboundMailServer()
+
 	return nil
 }
 
@@ -1543,6 +1579,12 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeRegeneratePreviews,
+		regenerate_previews.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store()),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeLastAccessiblePost,
 		last_accessible_post.MakeWorker(s.Jobs, s.License(), New(ServerConnector(s.Channels()))),
@@ -1603,6 +1645,42 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeChannelMembershipReport,
+		channel_membership_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeTeamActivityReport,
+		team_activity_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileEncryption,
+		file_encryption.MakeWorker(s.Jobs, s.FileBackend()),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileTierMigration,
+		file_tier_migration.MakeWorker(s.Jobs, s.Store(), s.FileBackend()),
+		file_tier_migration.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingWebhookDelivery,
+		outgoing_webhook_delivery.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		outgoing_webhook_delivery.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeScheduledPosts,
+		scheduled_posts.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		scheduled_posts.MakeScheduler(s.Jobs),
+	)
+
 	s.platform.Jobs = s.Jobs
 }
 
@@ -1773,6 +1851,40 @@ func runPostReminderJob(a *App) {
 	})
 }
 
+// runEmailBatchingJob sends the due digest emails from the cluster leader, on a task
+// recreated whenever the leader or the email batching settings change.
+func runEmailBatchingJob(a *App) {
+	startTask := func() {
+		cancelTask(&a.ch.emailBatchingMut, &a.ch.emailBatchingTask)
+
+		cfg := a.Config()
+		if !a.IsLeader() || !*cfg.EmailSettings.EnableEmailBatching {
+			return
+		}
+		withMut(&a.ch.emailBatchingMut, func() {
+			fn := func() {
+				if err := a.Srv().EmailService.ProcessBatchedNotifications(); err != nil {
+					mlog.Error("Failed to process batched email notifications", mlog.Err(err))
+				}
+			}
+			interval := time.Duration(*cfg.EmailSettings.EmailBatchingInterval) * time.Second
+			a.ch.emailBatchingTask = model.CreateRecurringTaskFromNextIntervalTime("Send batched email notifications", fn, interval)
+		})
+	}
+
+	startTask()
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if email batching task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		startTask()
+	})
+	a.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if *oldCfg.EmailSettings.EnableEmailBatching != *newCfg.EmailSettings.EnableEmailBatching ||
+			*oldCfg.EmailSettings.EmailBatchingInterval != *newCfg.EmailSettings.EmailBatchingInterval {
+			startTask()
+		}
+	})
+}
+
 func (a *App) GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError) {
 	table, err := a.Srv().Store().GetAppliedMigrations()
 	if err != nil {
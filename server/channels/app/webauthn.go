@@ -0,0 +1,360 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
+)
+
+func (a *App) webAuthnRelyingParty(where string) (*mfa.WebAuthnRelyingParty, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError(where, "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	rp, err := mfa.NewWebAuthnRelyingParty(a.GetSiteURL())
+	if err != nil {
+		return nil, model.NewAppError(where, "app.webauthn.site_url.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	}
+
+	return rp, nil
+}
+
+func (a *App) createWebAuthnChallenge(tokenType, userID string) (*model.Token, *model.AppError) {
+	token := model.NewToken(tokenType, userID)
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return nil, model.NewAppError("createWebAuthnChallenge", "app.recover.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return token, nil
+}
+
+// consumeWebAuthnChallenge looks up the challenge a response was signed for and deletes
+// it, so that each challenge is only answered once.
+func (a *App) consumeWebAuthnChallenge(tokenType, userID string, clientDataJSON []byte) ([]byte, *model.AppError) {
+	challenge, err := mfa.WebAuthnChallenge(clientDataJSON)
+	if err != nil {
+		return nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	token, err := a.Srv().Store().Token().GetByToken(string(challenge))
+	if err != nil {
+		return nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if err := a.Srv().Store().Token().Delete(token.Token); err != nil {
+		return nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if token.Type != tokenType || token.Extra != userID || model.GetMillis()-token.CreateAt > model.WebAuthnTimeout {
+		return nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return challenge, nil
+}
+
+func webAuthnCredentialDescriptors(credentials []*model.WebAuthnCredential) []model.WebAuthnCredentialDescriptor {
+	descriptors := make([]model.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, model.WebAuthnCredentialDescriptor{
+			Type: model.WebAuthnPublicKeyCredentialType,
+			Id:   credential.CredentialId,
+		})
+	}
+	return descriptors
+}
+
+// BeginWebAuthnRegistration returns the options for the user to register a new security
+// key with.
+func (a *App) BeginWebAuthnRegistration(userID string) (*model.WebAuthnCreationOptions, *model.AppError) {
+	rp, appErr := a.webAuthnRelyingParty("BeginWebAuthnRegistration")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnCredentialMaxPerUser {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "app.webauthn.too_many_credentials.app_error", map[string]any{"Max": model.WebAuthnCredentialMaxPerUser}, "", http.StatusBadRequest)
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	params := make([]model.WebAuthnCredentialParameters, 0, len(mfa.WebAuthnAlgorithms))
+	for _, alg := range mfa.WebAuthnAlgorithms {
+		params = append(params, model.WebAuthnCredentialParameters{
+			Type: model.WebAuthnPublicKeyCredentialType,
+			Alg:  alg,
+		})
+	}
+
+	return &model.WebAuthnCreationOptions{
+		Rp: model.WebAuthnRelyingPartyEntity{
+			Id:   rp.ID,
+			Name: *a.Config().TeamSettings.SiteName,
+		},
+		User: model.WebAuthnUserEntity{
+			Id:          model.EncodeWebAuthnBase64([]byte(user.Id)),
+			Name:        user.Username,
+			DisplayName: user.GetDisplayName(model.ShowFullName),
+		},
+		Challenge:          model.EncodeWebAuthnBase64([]byte(token.Token)),
+		PubKeyCredParams:   params,
+		Timeout:            model.WebAuthnTimeout,
+		ExcludeCredentials: webAuthnCredentialDescriptors(credentials),
+		AuthenticatorSelection: model.WebAuthnAuthenticatorSelection{
+			UserVerification: "discouraged",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// FinishWebAuthnRegistration verifies the credential created by a security key in
+// response to BeginWebAuthnRegistration and saves it.
+func (a *App) FinishWebAuthnRegistration(rctx request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError) {
+	rp, appErr := a.webAuthnRelyingParty("FinishWebAuthnRegistration")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	clientDataJSON, err := model.DecodeWebAuthnBase64(registration.Response.ClientDataJSON)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	attestationObject, err := model.DecodeWebAuthnBase64(registration.Response.AttestationObject)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	challenge, appErr := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID, clientDataJSON)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	verified, err := rp.VerifyWebAuthnRegistration(challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnCredentialMaxPerUser {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.too_many_credentials.app_error", map[string]any{"Max": model.WebAuthnCredentialMaxPerUser}, "", http.StatusBadRequest)
+	}
+
+	credentialID := model.EncodeWebAuthnBase64(verified.ID)
+	for _, credential := range credentials {
+		if credential.CredentialId == credentialID {
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.already_registered.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         registration.Name,
+		CredentialId: credentialID,
+		PublicKey:    verified.PublicKey,
+		SignCount:    int64(verified.SignCount),
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Make sure the new second factor is taken into account by the cached user.
+	a.InvalidateCacheForUser(userID)
+
+	return credential, nil
+}
+
+// GetWebAuthnCredentials returns the security keys registered by a user.
+func (a *App) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, err := a.Srv().Store().WebAuthnCredential().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetWebAuthnCredentials", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credentials, nil
+}
+
+// HasWebAuthnCredentials returns whether a user has registered a security key.
+func (a *App) HasWebAuthnCredentials(userID string) (bool, *model.AppError) {
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return false, appErr
+	}
+
+	return len(credentials) > 0, nil
+}
+
+// DeleteWebAuthnCredential removes one of the security keys of a user.
+func (a *App) DeleteWebAuthnCredential(userID, credentialID string) (*model.WebAuthnCredential, *model.AppError) {
+	credential, err := a.Srv().Store().WebAuthnCredential().Get(credentialID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if credential.UserId != userID {
+		return nil, model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().Delete(credentialID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.InvalidateCacheForUser(userID)
+
+	return credential, nil
+}
+
+// BeginWebAuthnLogin returns the options for a user to sign in with one of their security
+// keys. The same options are returned for users without security keys, and for unknown
+// users, so as not to disclose which of them exist.
+func (a *App) BeginWebAuthnLogin(rctx request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError) {
+	rp, appErr := a.webAuthnRelyingParty("BeginWebAuthnLogin")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	options := &model.WebAuthnRequestOptions{
+		Challenge:        model.EncodeWebAuthnBase64([]byte(model.NewRandomString(model.TokenSize))),
+		Timeout:          model.WebAuthnTimeout,
+		RpId:             rp.ID,
+		AllowCredentials: []model.WebAuthnCredentialDescriptor{},
+		UserVerification: "discouraged",
+	}
+
+	user, appErr := a.GetUserForLogin(rctx, "", loginID)
+	if appErr != nil {
+		return options, nil
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(credentials) == 0 {
+		return options, nil
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnLogin, user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	options.Challenge = model.EncodeWebAuthnBase64([]byte(token.Token))
+	options.AllowCredentials = webAuthnCredentialDescriptors(credentials)
+
+	return options, nil
+}
+
+// checkWebAuthnAssertion verifies an assertion of one of the security keys of a user,
+// passed as their MFA token.
+func (a *App) checkWebAuthnAssertion(rctx request.CTX, user *model.User, token string) *model.AppError {
+	rp, appErr := a.webAuthnRelyingParty("checkWebAuthnAssertion")
+	if appErr != nil {
+		return appErr
+	}
+
+	var assertion model.WebAuthnAssertion
+	if err := json.Unmarshal([]byte(token), &assertion); err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	clientDataJSON, err := model.DecodeWebAuthnBase64(assertion.Response.ClientDataJSON)
+	if err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	authenticatorData, err := model.DecodeWebAuthnBase64(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	signature, err := model.DecodeWebAuthnBase64(assertion.Response.Signature)
+	if err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	rawID, err := model.DecodeWebAuthnBase64(assertion.Id)
+	if err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	challenge, appErr := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnLogin, user.Id, clientDataJSON)
+	if appErr != nil {
+		return appErr
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	var credential *model.WebAuthnCredential
+	credentialID := model.EncodeWebAuthnBase64(rawID)
+	for _, c := range credentials {
+		if c.CredentialId == credentialID {
+			credential = c
+			break
+		}
+	}
+	if credential == nil {
+		return model.NewAppError("checkWebAuthnAssertion", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	signCount, err := rp.VerifyWebAuthnAssertion(challenge, &mfa.WebAuthnCredential{
+		ID:        rawID,
+		PublicKey: credential.PublicKey,
+		SignCount: uint32(credential.SignCount),
+	}, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		return model.NewAppError("checkWebAuthnAssertion", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().UpdateLastUsed(credential.Id, int64(signCount), model.GetMillis()); err != nil {
+		rctx.Logger().Warn("Failed to update the security key after its use", mlog.String("credential_id", credential.Id), mlog.Err(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const testWebAuthnOrigin = "http://localhost:8065"
+
+// testSecurityKey is an ES256 security key, answering the requests of the server the
+// way a browser would.
+type testSecurityKey struct {
+	id        []byte
+	key       *ecdsa.PrivateKey
+	signCount uint32
+	rpIDHash  [32]byte
+	origin    string
+}
+
+func newTestSecurityKey(t *testing.T) *testSecurityKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &testSecurityKey{
+		id:       []byte(model.NewId()),
+		key:      key,
+		rpIDHash: sha256.Sum256([]byte("localhost")),
+	}
+}
+
+func (k *testSecurityKey) clientData(t *testing.T, typ, challenge string) []byte {
+	origin := testWebAuthnOrigin
+	if k.origin != "" {
+		origin = k.origin
+	}
+
+	clientDataJSON, err := json.Marshal(map[string]string{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	require.NoError(t, err)
+	return clientDataJSON
+}
+
+func (k *testSecurityKey) authData(attested []byte) []byte {
+	flags := byte(0x01)
+	if attested != nil {
+		flags |= 0x40
+	}
+
+	data := append(k.rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, k.signCount)
+	return append(data, attested...)
+}
+
+func (k *testSecurityKey) register(t *testing.T, name string, options *model.WebAuthnCreationOptions) *model.WebAuthnRegistration {
+	raw, err := k.key.PublicKey.ECDH()
+	require.NoError(t, err)
+	point := raw.Bytes()
+
+	// The COSE key {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	coseKey := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	coseKey = append(coseKey, point[1:33]...)
+	coseKey = append(coseKey, 0x22, 0x58, 0x20)
+	coseKey = append(coseKey, point[33:]...)
+
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(k.id)))
+	attested = append(attested, k.id...)
+	attested = append(attested, coseKey...)
+	authData := k.authData(attested)
+
+	// The attestation object {"fmt": "none", "attStmt": {}, "authData": authData}
+	attestationObject := []byte{0xa3, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e', 0x67, 'a', 't', 't', 'S', 't', 'm', 't', 0xa0, 0x68, 'a', 'u', 't', 'h', 'D', 'a', 't', 'a', 0x59}
+	attestationObject = binary.BigEndian.AppendUint16(attestationObject, uint16(len(authData)))
+	attestationObject = append(attestationObject, authData...)
+
+	return &model.WebAuthnRegistration{
+		Name: name,
+		Id:   model.EncodeWebAuthnBase64(k.id),
+		Type: model.WebAuthnPublicKeyCredentialType,
+		Response: model.WebAuthnAttestationResponse{
+			ClientDataJSON:    model.EncodeWebAuthnBase64(k.clientData(t, "webauthn.create", options.Challenge)),
+			AttestationObject: model.EncodeWebAuthnBase64(attestationObject),
+		},
+	}
+}
+
+func (k *testSecurityKey) assert(t *testing.T, options *model.WebAuthnRequestOptions) string {
+	k.signCount++
+	authData := k.authData(nil)
+	clientDataJSON := k.clientData(t, "webauthn.get", options.Challenge)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, k.key, digest[:])
+	require.NoError(t, err)
+
+	assertion, err := json.Marshal(&model.WebAuthnAssertion{
+		Id:   model.EncodeWebAuthnBase64(k.id),
+		Type: model.WebAuthnPublicKeyCredentialType,
+		Response: model.WebAuthnAssertionResponse{
+			ClientDataJSON:    model.EncodeWebAuthnBase64(clientDataJSON),
+			AuthenticatorData: model.EncodeWebAuthnBase64(authData),
+			Signature:         model.EncodeWebAuthnBase64(signature),
+		},
+	})
+	require.NoError(t, err)
+	return string(assertion)
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("mfa disabled", func(t *testing.T) {
+		_, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = testWebAuthnOrigin
+	})
+
+	securityKey := newTestSecurityKey(t)
+
+	t.Run("registers a security key", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "localhost", options.Rp.Id)
+		assert.Equal(t, th.BasicUser.Username, options.User.Name)
+		assert.Empty(t, options.ExcludeCredentials)
+
+		credential, appErr := th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, securityKey.register(t, " My key ", options))
+		require.Nil(t, appErr)
+		assert.Equal(t, "My key", credential.Name)
+		assert.Equal(t, model.EncodeWebAuthnBase64(securityKey.id), credential.CredentialId)
+
+		hasCredentials, appErr := th.App.HasWebAuthnCredentials(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.True(t, hasCredentials)
+	})
+
+	t.Run("excludes registered security keys", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, options.ExcludeCredentials, 1)
+		assert.Equal(t, model.EncodeWebAuthnBase64(securityKey.id), options.ExcludeCredentials[0].Id)
+
+		_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, securityKey.register(t, "Again", options))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webauthn.already_registered.app_error", appErr.Id)
+	})
+
+	t.Run("rejects a challenge of another user", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, newTestSecurityKey(t).register(t, "Key", options))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webauthn.invalid_challenge.app_error", appErr.Id)
+	})
+
+	t.Run("rejects a response for another origin", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		otherKey := newTestSecurityKey(t)
+		otherKey.origin = "https://example.com"
+		_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, otherKey.register(t, "Key", options))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webauthn.invalid_response.app_error", appErr.Id)
+	})
+
+	t.Run("deletes a security key", func(t *testing.T) {
+		credentials, appErr := th.App.GetWebAuthnCredentials(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, credentials, 1)
+
+		_, appErr = th.App.DeleteWebAuthnCredential(th.BasicUser2.Id, credentials[0].Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		_, appErr = th.App.DeleteWebAuthnCredential(th.BasicUser.Id, credentials[0].Id)
+		require.Nil(t, appErr)
+
+		hasCredentials, appErr := th.App.HasWebAuthnCredentials(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.False(t, hasCredentials)
+	})
+}
+
+func TestCheckUserMfaWithWebAuthn(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = testWebAuthnOrigin
+	})
+
+	securityKey := newTestSecurityKey(t)
+	registrationOptions, appErr := th.App.BeginWebAuthnRegistration(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, securityKey.register(t, "Key", registrationOptions))
+	require.Nil(t, appErr)
+
+	t.Run("requires a second factor", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, th.BasicUser, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+
+		appErr = th.App.CheckUserMfa(th.Context, th.BasicUser2, "")
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("accepts an assertion of the security key", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, th.BasicUser.Username)
+		require.Nil(t, appErr)
+		require.Len(t, options.AllowCredentials, 1)
+
+		assertion := securityKey.assert(t, options)
+		require.Nil(t, th.App.CheckUserMfa(th.Context, th.BasicUser, assertion))
+
+		credentials, appErr := th.App.GetWebAuthnCredentials(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, credentials, 1)
+		assert.Equal(t, int64(securityKey.signCount), credentials[0].SignCount)
+		assert.NotZero(t, credentials[0].LastUsedAt)
+
+		// Each challenge is only answered once
+		appErr = th.App.CheckUserMfa(th.Context, th.BasicUser, assertion)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webauthn.invalid_challenge.app_error", appErr.Id)
+	})
+
+	t.Run("rejects an assertion of another security key", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, th.BasicUser.Email)
+		require.Nil(t, appErr)
+
+		appErr = th.App.CheckUserMfa(th.Context, th.BasicUser, newTestSecurityKey(t).assert(t, options))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("rejects a challenge for another user", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, th.BasicUser.Username)
+		require.Nil(t, appErr)
+
+		appErr = th.App.CheckUserMfa(th.Context, th.BasicUser2, securityKey.assert(t, options))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.webauthn.invalid_challenge.app_error", appErr.Id)
+	})
+
+	t.Run("returns options for unknown users", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, "unknown")
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, options.Challenge)
+		assert.Empty(t, options.AllowCredentials)
+	})
+}
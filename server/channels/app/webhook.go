@@ -4,7 +4,6 @@
 package app
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -95,119 +94,143 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 }
 
 func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
-	var jsonBytes []byte
-	var err error
-
+	body := payload.ToFormValues()
 	contentType := "application/x-www-form-urlencoded"
 	if hook.ContentType == "application/json" {
 		contentType = "application/json"
-		jsonBytes, err = json.Marshal(payload)
+		jsonBytes, err := json.Marshal(payload)
 		if err != nil {
 			c.Logger().Warn("Failed to encode to JSON", mlog.Err(err))
 			return
 		}
+		body = string(jsonBytes)
 	}
 
 	var wg sync.WaitGroup
 
-	for i := range hook.CallbackURLs {
-		var body io.Reader
-		if hook.ContentType == "application/json" {
-			body = bytes.NewReader(jsonBytes)
-		} else {
-			body = strings.NewReader(payload.ToFormValues())
+	for _, url := range hook.CallbackURLs {
+		delivery := &model.OutgoingWebhookDelivery{
+			HookId:      hook.Id,
+			PostId:      post.Id,
+			ChannelId:   channel.Id,
+			CallbackURL: url,
+			ContentType: contentType,
+			Payload:     body,
+			// Keep the delivery from being retried while the first attempt is in progress.
+			NextAttemptAt: a.outgoingWebhookDeliveryLeaseEnd(),
 		}
-		wg.Add(1)
 
-		// Get the callback URL by index to properly capture it for the go func
-		url := hook.CallbackURLs[i]
+		persisted := true
+		if _, err := a.Srv().Store().Webhook().SaveOutgoingDelivery(delivery); err != nil {
+			// The delivery is still attempted, it just won't be retried.
+			c.Logger().Warn("Failed to save the outgoing webhook delivery", mlog.String("hook_id", hook.Id), mlog.Err(err))
+			persisted = false
+		}
 
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			a.attemptOutgoingWebhookDelivery(c, hook, delivery, post, channel, persisted)
+		}()
+	}
+	wg.Wait()
+}
 
-			var accessToken *model.OutgoingOAuthConnectionToken
+// handleOutgoingWebhookResponse creates the post sent back by the receiver of an
+// outgoing webhook, if any.
+func (a *App) handleOutgoingWebhookResponse(c request.CTX, hook *model.OutgoingWebhook, webhookResp *model.OutgoingWebhookResponse, post *model.Post, channel *model.Channel) {
+	if webhookResp == nil || (webhookResp.Text == nil && len(webhookResp.Attachments) == 0) {
+		return
+	}
 
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
-				if err != nil {
-					c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
+	postRootId := ""
+	if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment && post != nil {
+		postRootId = post.Id
+	}
+	if len(webhookResp.Props) == 0 {
+		webhookResp.Props = make(model.StringInterface)
+	}
+	webhookResp.Props["webhook_display_name"] = hook.DisplayName
 
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
-					if err != nil {
-						c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
-				}
-			}
+	text := ""
+	if webhookResp.Text != nil {
+		text = a.ProcessSlackText(*webhookResp.Text)
+	}
+	webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
+	// attachments is in here for slack compatibility
+	if len(webhookResp.Attachments) > 0 {
+		webhookResp.Props["attachments"] = webhookResp.Attachments
+	}
+	if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
+		webhookResp.Username = hook.Username
+	}
 
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					c.Logger().Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
-				return
-			}
+	if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
+		webhookResp.IconURL = hook.IconURL
+	}
+	if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
+		c.Logger().Error("Failed to create response post.", mlog.Err(err))
+	}
+}
 
-			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
-				postRootId := ""
-				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
-					postRootId = post.Id
-				}
-				if len(webhookResp.Props) == 0 {
-					webhookResp.Props = make(model.StringInterface)
-				}
-				webhookResp.Props["webhook_display_name"] = hook.DisplayName
+// getOutgoingWebhookAccessToken retrieves an access token from the outgoing OAuth
+// connection matching the URL, if one exists, to use for the webhook request.
+func (a *App) getOutgoingWebhookAccessToken(c request.CTX, url string) (*model.OutgoingOAuthConnectionToken, error) {
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections == nil || !*a.Config().ServiceSettings.EnableOutgoingOAuthConnections || a.OutgoingOAuthConnections() == nil {
+		return nil, nil
+	}
 
-				text := ""
-				if webhookResp.Text != nil {
-					text = a.ProcessSlackText(*webhookResp.Text)
-				}
-				webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
-				// attachments is in here for slack compatibility
-				if len(webhookResp.Attachments) > 0 {
-					webhookResp.Props["attachments"] = webhookResp.Attachments
-				}
-				if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
-					webhookResp.Username = hook.Username
-				}
+	connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
+	if err != nil {
+		c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
+		return nil, err
+	}
 
-				if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
-					webhookResp.IconURL = hook.IconURL
-				}
-				if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
-					c.Logger().Error("Failed to create response post.", mlog.Err(err))
-				}
-			}
-		}()
+	if connection == nil {
+		return nil, nil
 	}
-	wg.Wait()
+
+	accessToken, err := a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
+	if err != nil {
+		c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
+		return nil, err
+	}
+
+	return accessToken, nil
 }
 
 func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+	hookResp, _, err := a.doOutgoingWebhookRequestWithHeaders(url, body, contentType, accessToken, nil)
+	return hookResp, err
+}
+
+// doOutgoingWebhookRequestWithHeaders makes an outgoing webhook request with the given
+// extra headers, and returns the status code of the response along with its content.
+// As for doOutgoingWebhookRequest, the content of the response is returned whatever
+// its status code is.
+func (a *App) doOutgoingWebhookRequestWithHeaders(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken, headers map[string]string) (*model.OutgoingWebhookResponse, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
 	if accessToken != nil {
 		req.Header.Add("Authorization", accessToken.AsHeaderValue())
 	}
 
 	resp, err := a.Srv().outgoingWebhookClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	defer resp.Body.Close()
@@ -215,12 +238,12 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 	var hookResp model.OutgoingWebhookResponse
 	if jsonErr := json.NewDecoder(io.LimitReader(resp.Body, MaxIntegrationResponseSize)).Decode(&hookResp); jsonErr != nil {
 		if jsonErr == io.EOF {
-			return nil, nil
+			return nil, resp.StatusCode, nil
 		}
-		return nil, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
+		return nil, resp.StatusCode, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 
-	return &hookResp, nil
+	return &hookResp, resp.StatusCode, nil
 }
 
 func SplitWebhookPost(post *model.Post, maxPostSize int) ([]*model.Post, *model.AppError) {
@@ -0,0 +1,258 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// The deliveries retried at once by each run of the delivery job.
+	outgoingWebhookDeliveryBatchSize   = 100
+	outgoingWebhookDeliveryConcurrency = 10
+
+	// How long past the request timeout a delivery is kept from being attempted by
+	// another node once claimed.
+	outgoingWebhookDeliveryLeaseMargin = time.Minute
+
+	// The delivery history older than this is deleted by the delivery job.
+	outgoingWebhookDeliveryRetention = 30 * 24 * time.Hour
+)
+
+// outgoingWebhookDeliveryLeaseEnd returns the time until which a delivery claimed
+// now is reserved for the node that claimed it.
+func (a *App) outgoingWebhookDeliveryLeaseEnd() int64 {
+	timeout := time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout) * time.Second
+	return model.GetMillis() + (timeout + outgoingWebhookDeliveryLeaseMargin).Milliseconds()
+}
+
+// attemptOutgoingWebhookDelivery makes one attempt of the delivery, records its outcome
+// and, once delivered, creates the response post. Any reply of the 2xx range is a delivery,
+// even when its content isn't a valid response. Failed attempts, including the ones answered
+// with a status code outside of the 2xx range, are retried by the delivery job until the
+// maximum number of attempts is reached, and their response is never posted.
+func (a *App) attemptOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery, post *model.Post, channel *model.Channel, persisted bool) {
+	var webhookResp *model.OutgoingWebhookResponse
+	var statusCode int
+
+	start := time.Now()
+	accessToken, err := a.getOutgoingWebhookAccessToken(c, delivery.CallbackURL)
+	if err == nil {
+		start = time.Now()
+
+		timestamp := model.GetMillis()
+		headers := map[string]string{
+			model.OutgoingWebhookHeaderDeliveryId: delivery.Id,
+			model.OutgoingWebhookHeaderTimestamp:  strconv.FormatInt(timestamp, 10),
+			model.OutgoingWebhookHeaderSignature:  model.OutgoingWebhookSignature(hook.Token, timestamp, []byte(delivery.Payload)),
+		}
+
+		webhookResp, statusCode, err = a.doOutgoingWebhookRequestWithHeaders(delivery.CallbackURL, strings.NewReader(delivery.Payload), delivery.ContentType, accessToken, headers)
+		switch {
+		case err == nil:
+		case statusCode != 0:
+			c.Logger().Warn("Outgoing Webhook response is invalid", mlog.Int("status_code", statusCode), mlog.Err(err))
+		case errors.Is(err, context.DeadlineExceeded):
+			c.Logger().Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+		default:
+			c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
+		}
+	}
+
+	deliveryErr := err
+	if statusCode != 0 {
+		if statusCode < 200 || statusCode >= 300 {
+			deliveryErr = fmt.Errorf("outgoing webhook request returned status code %d", statusCode)
+		} else {
+			deliveryErr = nil
+		}
+	}
+
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.Latency = time.Since(start).Milliseconds()
+	if deliveryErr != nil {
+		delivery.LastError = deliveryErr.Error()
+		if delivery.Attempts >= *a.Config().ServiceSettings.OutgoingWebhookMaxDeliveryAttempts {
+			delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+			delivery.NextAttemptAt = 0
+		} else {
+			delivery.Status = model.OutgoingWebhookDeliveryStatusPending
+			delivery.NextAttemptAt = model.GetMillis() + model.OutgoingWebhookRetryDelay(delivery.Attempts).Milliseconds()
+		}
+	} else {
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+		delivery.LastError = ""
+		delivery.NextAttemptAt = 0
+	}
+
+	if persisted {
+		if _, updateErr := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); updateErr != nil {
+			c.Logger().Warn("Failed to update the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(updateErr))
+		}
+	}
+
+	if deliveryErr == nil && channel != nil {
+		a.handleOutgoingWebhookResponse(c, hook, webhookResp, post, channel)
+	}
+}
+
+// retryOutgoingWebhookDelivery attempts a delivery already claimed by this node,
+// loading the webhook, post and channel it was made for.
+func (a *App) retryOutgoingWebhookDelivery(c request.CTX, delivery *model.OutgoingWebhookDelivery) {
+	hook, err := a.Srv().Store().Webhook().GetOutgoing(delivery.HookId)
+	if err != nil {
+		// The webhook was deleted since, so there is nothing left to deliver.
+		delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = 0
+		delivery.LastError = err.Error()
+		if _, updateErr := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); updateErr != nil {
+			c.Logger().Warn("Failed to update the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(updateErr))
+		}
+		return
+	}
+
+	var post *model.Post
+	if delivery.PostId != "" {
+		if post, err = a.Srv().Store().Post().GetSingle(c, delivery.PostId, true); err != nil {
+			c.Logger().Debug("Failed to get the post of the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+		}
+	}
+
+	// Without the channel, the delivery is still made but the response can't be posted.
+	var channel *model.Channel
+	if delivery.ChannelId != "" {
+		if channel, err = a.Srv().Store().Channel().Get(delivery.ChannelId, true); err != nil {
+			c.Logger().Debug("Failed to get the channel of the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+		}
+	}
+
+	a.attemptOutgoingWebhookDelivery(c, hook, delivery, post, channel, true)
+}
+
+// ProcessPendingOutgoingWebhookDeliveries retries the outgoing webhook deliveries that
+// are due, and deletes the delivery history past its retention period.
+func (a *App) ProcessPendingOutgoingWebhookDeliveries(c request.CTX) *model.AppError {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, outgoingWebhookDeliveryConcurrency)
+
+	deliveries, err := a.Srv().Store().Webhook().GetPendingOutgoingDeliveries(model.GetMillis(), outgoingWebhookDeliveryBatchSize)
+	if err != nil {
+		return model.NewAppError("ProcessPendingOutgoingWebhookDeliveries", "app.webhooks.get_pending_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, delivery := range deliveries {
+		leaseEnd := a.outgoingWebhookDeliveryLeaseEnd()
+		claimed, err := a.Srv().Store().Webhook().ClaimOutgoingDelivery(delivery.Id, delivery.NextAttemptAt, leaseEnd)
+		if err != nil {
+			c.Logger().Warn("Failed to claim the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+			continue
+		}
+		if !claimed {
+			// Another node got to it first.
+			continue
+		}
+		delivery.NextAttemptAt = leaseEnd
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(delivery *model.OutgoingWebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			a.retryOutgoingWebhookDelivery(c, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	before := model.GetMillis() - outgoingWebhookDeliveryRetention.Milliseconds()
+	for {
+		deleted, err := a.Srv().Store().Webhook().PermanentDeleteOutgoingDeliveriesBefore(before, outgoingWebhookDeliveryBatchSize)
+		if err != nil {
+			return model.NewAppError("ProcessPendingOutgoingWebhookDeliveries", "app.webhooks.delete_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if deleted < outgoingWebhookDeliveryBatchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (a *App) GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	delivery, err := a.Srv().Store().Webhook().GetOutgoingDelivery(deliveryID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return delivery, nil
+}
+
+func (a *App) GetOutgoingWebhookDeliveries(hookID string, page, perPage int) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	deliveries, err := a.Srv().Store().Webhook().GetOutgoingDeliveriesByHook(hookID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.webhooks.get_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayOutgoingWebhookDelivery sends the payload of a past delivery again, as a new
+// delivery retried like any other, so that the history of the original one is kept.
+func (a *App) ReplayOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if delivery.HookId != hook.Id {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if delivery.Status == model.OutgoingWebhookDeliveryStatusPending {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.replay_outgoing_delivery.pending.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	replay := &model.OutgoingWebhookDelivery{
+		HookId:        delivery.HookId,
+		PostId:        delivery.PostId,
+		ChannelId:     delivery.ChannelId,
+		CallbackURL:   delivery.CallbackURL,
+		ContentType:   delivery.ContentType,
+		Payload:       delivery.Payload,
+		NextAttemptAt: a.outgoingWebhookDeliveryLeaseEnd(),
+	}
+
+	if _, err := a.Srv().Store().Webhook().SaveOutgoingDelivery(replay); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.webhooks.save_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// The attempt is made in the background, like those triggered by posts.
+	attempt := *replay
+	a.Srv().Go(func() {
+		a.retryOutgoingWebhookDelivery(c, &attempt)
+	})
+
+	return replay, nil
+}
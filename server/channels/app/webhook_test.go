@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -935,6 +937,20 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		assert.Equal(t, "Hello, World!", *resp.Text)
 	})
 
+	t.Run("with a valid response and an error status code", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.Copy(w, strings.NewReader(`{"text": "Invalid command"}`))
+		}))
+		defer server.Close()
+
+		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, resp)
+		assert.Equal(t, "Invalid command", *resp.Text)
+	})
+
 	t.Run("with an invalid response", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			io.Copy(w, strings.NewReader("aaaaaaaa"))
@@ -1030,3 +1046,177 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		require.Equal(t, `Bearer test`, *resp.Text)
 	})
 }
+
+func TestOutgoingWebhookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.OutgoingWebhookMaxDeliveryAttempts = 2
+	})
+
+	var statusCode atomic.Int32
+	var responseBody atomic.Value
+	responseBody.Store("")
+	requests := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- r
+		bodies <- body
+		w.WriteHeader(int(statusCode.Load()))
+		io.WriteString(w, responseBody.Load().(string))
+	}))
+	defer ts.Close()
+
+	hasResponsePost := func(message string, since int64) bool {
+		posts, appErr := th.App.GetPostsSince(model.GetPostsSinceOptions{ChannelId: th.BasicChannel.Id, Time: since})
+		require.Nil(t, appErr)
+		for _, post := range posts.Posts {
+			if post.Message == message {
+				return true
+			}
+		}
+		return false
+	}
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicTeam.Id,
+		CallbackURLs: []string{ts.URL},
+		CreatorId:    th.BasicUser.Id,
+		TriggerWords: []string{"delivery"},
+		ContentType:  "application/json",
+	})
+	require.Nil(t, appErr)
+
+	payload := &model.OutgoingWebhookPayload{
+		Token:     hook.Token,
+		TeamId:    hook.TeamId,
+		ChannelId: th.BasicChannel.Id,
+		PostId:    th.BasicPost.Id,
+		Text:      "delivery",
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		statusCode.Store(http.StatusOK)
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		r := <-requests
+		body := <-bodies
+		deliveryID := r.Header.Get(model.OutgoingWebhookHeaderDeliveryId)
+		assert.True(t, model.IsValidId(deliveryID))
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(model.OutgoingWebhookHeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, model.OutgoingWebhookSignature(hook.Token, timestamp, body), r.Header.Get(model.OutgoingWebhookHeaderSignature))
+
+		delivery, appErr := th.App.GetOutgoingWebhookDelivery(deliveryID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+		assert.Equal(t, string(body), delivery.Payload)
+	})
+
+	t.Run("failed deliveries are retried", func(t *testing.T) {
+		statusCode.Store(http.StatusInternalServerError)
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		r := <-requests
+		<-bodies
+		delivery, appErr := th.App.GetOutgoingWebhookDelivery(r.Header.Get(model.OutgoingWebhookHeaderDeliveryId))
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+		assert.NotEmpty(t, delivery.LastError)
+		assert.Greater(t, delivery.NextAttemptAt, model.GetMillis())
+
+		// Make the delivery due.
+		delivery.NextAttemptAt = model.GetMillis() - 1
+		_, err := th.App.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+
+		require.Nil(t, th.App.ProcessPendingOutgoingWebhookDeliveries(th.Context))
+
+		r = <-requests
+		<-bodies
+		assert.Equal(t, delivery.Id, r.Header.Get(model.OutgoingWebhookHeaderDeliveryId))
+
+		// The maximum number of attempts was reached.
+		delivery, appErr = th.App.GetOutgoingWebhookDelivery(delivery.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Zero(t, delivery.NextAttemptAt)
+
+		t.Run("replay", func(t *testing.T) {
+			statusCode.Store(http.StatusOK)
+			replay, appErr := th.App.ReplayOutgoingWebhookDelivery(th.Context, hook, delivery)
+			require.Nil(t, appErr)
+			assert.NotEqual(t, delivery.Id, replay.Id)
+			assert.Equal(t, delivery.Payload, replay.Payload)
+
+			r := <-requests
+			<-bodies
+			assert.Equal(t, replay.Id, r.Header.Get(model.OutgoingWebhookHeaderDeliveryId))
+		})
+	})
+
+	t.Run("non-2xx reply with a JSON body is retried without posting the response", func(t *testing.T) {
+		since := model.GetMillis()
+		statusCode.Store(http.StatusServiceUnavailable)
+		responseBody.Store(`{"text": "not delivered"}`)
+		defer responseBody.Store("")
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		r := <-requests
+		<-bodies
+		var delivery *model.OutgoingWebhookDelivery
+		require.Eventually(t, func() bool {
+			var appErr *model.AppError
+			delivery, appErr = th.App.GetOutgoingWebhookDelivery(r.Header.Get(model.OutgoingWebhookHeaderDeliveryId))
+			require.Nil(t, appErr)
+			return delivery.Attempts == 1
+		}, 5*time.Second, 100*time.Millisecond)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusPending, delivery.Status)
+
+		delivery.NextAttemptAt = model.GetMillis() - 1
+		_, err := th.App.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+		require.Nil(t, th.App.ProcessPendingOutgoingWebhookDeliveries(th.Context))
+		<-requests
+		<-bodies
+
+		require.Never(t, func() bool {
+			return hasResponsePost("not delivered", since)
+		}, time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("2xx reply with a non-JSON body is delivered", func(t *testing.T) {
+		statusCode.Store(http.StatusOK)
+		responseBody.Store("ok")
+		defer responseBody.Store("")
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		r := <-requests
+		<-bodies
+		require.Eventually(t, func() bool {
+			delivery, appErr := th.App.GetOutgoingWebhookDelivery(r.Header.Get(model.OutgoingWebhookHeaderDeliveryId))
+			require.Nil(t, appErr)
+			return delivery.Status == model.OutgoingWebhookDeliveryStatusSuccess && delivery.Attempts == 1 && delivery.LastError == ""
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("delivery history", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+			require.Nil(t, appErr)
+			return len(deliveries) == 5
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
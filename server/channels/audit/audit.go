@@ -11,6 +11,7 @@ import (
 
 type Audit struct {
 	logger *mlog.Logger
+	chain  *chain
 
 	// OnQueueFull is called on an attempt to add an audit record to a full queue.
 	// Return true to drop record, or false to block until there is room in queue.
@@ -27,6 +28,7 @@ func (a *Audit) Init(maxQueueSize int) {
 		mlog.OnQueueFull(a.onQueueFull),
 		mlog.OnTargetQueueFull(a.onTargetQueueFull),
 	)
+	a.chain = newChain(maxQueueSize)
 }
 
 // LogRecord emits an audit record with complete info.
@@ -40,7 +42,38 @@ func (a *Audit) LogRecord(level mlog.Level, rec Record) {
 		mlog.Any(KeyError, rec.Error),
 	}
 
+	if a.chain == nil {
+		a.logger.Log(level, "", flds...)
+		return
+	}
+
+	a.chain.mut.Lock()
+	defer a.chain.mut.Unlock()
+
+	if a.chain.id == "" {
+		a.logger.Log(level, "", flds...)
+		return
+	}
+
+	chained, err := a.chain.append(level, rec)
+	if err != nil {
+		a.onLoggerError(fmt.Errorf("cannot chain audit record: %w", err))
+		a.logger.Log(level, "", flds...)
+		return
+	}
+
+	flds = append(flds, mlog.Any(KeyChain, map[string]any{
+		"chain_id":  chained.ChainId,
+		"sequence":  chained.Sequence,
+		"prev_hash": chained.PrevHash,
+		"hash":      chained.Hash,
+	}))
 	a.logger.Log(level, "", flds...)
+	a.chain.enqueue(chained, a.onSinkQueueFull)
+
+	if chained.Sequence%a.chain.checkpointInterval == 0 {
+		a.checkpoint(level)
+	}
 }
 
 // Configure sets zero or more target to output audit logs to.
@@ -48,8 +81,12 @@ func (a *Audit) Configure(cfg mlog.LoggerConfiguration) error {
 	return a.logger.ConfigureTargets(cfg, nil)
 }
 
-// Flush attempts to write all queued audit records to all targets.
+// Flush attempts to write all queued audit records to all targets and sinks.
 func (a *Audit) Flush() error {
+	if a.chain != nil {
+		a.chain.flush()
+	}
+
 	err := a.logger.Flush()
 	if err != nil {
 		a.onLoggerError(err)
@@ -59,6 +96,11 @@ func (a *Audit) Flush() error {
 
 // Shutdown cleanly stops the audit engine after making best efforts to flush all targets.
 func (a *Audit) Shutdown() error {
+	if a.chain != nil {
+		a.DisableHashChain()
+		a.chain.removeSinks()
+	}
+
 	err := a.logger.Shutdown()
 	if err != nil {
 		a.onLoggerError(err)
@@ -82,6 +124,11 @@ func (a *Audit) onTargetQueueFull(target mlog.Target, rec *mlog.LogRec, maxQueue
 	return true
 }
 
+// onSinkQueueFull only reports a full sink queue, as chained records are never dropped.
+func (a *Audit) onSinkQueueFull(name string, maxQueueSize int) {
+	mlog.Warn("Audit sink queue full, waiting for room.", mlog.String("sink", name), mlog.Int("queueSize", maxQueueSize))
+}
+
 func (a *Audit) onLoggerError(err error) {
 	if a.OnError != nil {
 		a.OnError(err)
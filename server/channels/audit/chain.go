@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// chain is the hash chain of the audit records logged by this server, and the sinks the
+// chained records are written to.
+//
+// Each server starts a new chain, of a new id, whenever the hash chain is enabled, so
+// that servers of a cluster don't need to agree on the order of their records.
+type chain struct {
+	mut sync.Mutex
+
+	id       string // empty while the hash chain is disabled
+	sequence int64
+	head     string
+
+	checkpointInterval int64
+	sign               func(checkpoint *model.AuditCheckpoint) error
+
+	sinks        map[string]*sinkQueue
+	maxQueueSize int
+}
+
+func newChain(maxQueueSize int) *chain {
+	return &chain{
+		sinks:        map[string]*sinkQueue{},
+		maxQueueSize: maxQueueSize,
+	}
+}
+
+// append adds a record at the head of the chain.
+func (c *chain) append(level mlog.Level, rec Record) (*model.AuditRecord, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	chained := &model.AuditRecord{
+		Id:        model.NewId(),
+		ChainId:   c.id,
+		Sequence:  c.sequence + 1,
+		CreateAt:  model.GetMillis(),
+		Level:     level.Name,
+		EventName: rec.EventName,
+		Status:    rec.Status,
+		UserId:    rec.Actor.UserId,
+		Data:      string(data),
+		PrevHash:  c.head,
+	}
+	chained.Hash = chained.ComputeHash()
+
+	c.sequence = chained.Sequence
+	c.head = chained.Hash
+	return chained, nil
+}
+
+func (c *chain) enqueue(item any, onFull func(name string, maxQueueSize int)) {
+	for _, q := range c.sinks {
+		q.enqueue(item, onFull)
+	}
+}
+
+func (c *chain) flush() {
+	c.mut.Lock()
+	queues := make([]*sinkQueue, 0, len(c.sinks))
+	for _, q := range c.sinks {
+		queues = append(queues, q)
+	}
+	c.mut.Unlock()
+
+	for _, q := range queues {
+		q.pending.Wait()
+	}
+}
+
+func (c *chain) removeSinks() {
+	c.mut.Lock()
+	sinks := c.sinks
+	c.sinks = map[string]*sinkQueue{}
+	c.mut.Unlock()
+
+	for _, q := range sinks {
+		q.stop()
+	}
+}
+
+// EnableHashChain chains the records logged from now on, starting a new chain unless
+// already enabled. A signed checkpoint of the chain is taken every checkpointInterval
+// records, and when the hash chain is disabled.
+func (a *Audit) EnableHashChain(checkpointInterval int, sign func(checkpoint *model.AuditCheckpoint) error) {
+	if a.chain == nil {
+		return
+	}
+
+	a.chain.mut.Lock()
+	defer a.chain.mut.Unlock()
+
+	if checkpointInterval <= 0 {
+		checkpointInterval = 1
+	}
+	a.chain.checkpointInterval = int64(checkpointInterval)
+	a.chain.sign = sign
+
+	if a.chain.id == "" {
+		a.chain.id = model.NewId()
+		a.chain.sequence = 0
+		a.chain.head = ""
+	}
+}
+
+// DisableHashChain stops chaining records, after taking a checkpoint of the chain.
+func (a *Audit) DisableHashChain() {
+	if a.chain == nil {
+		return
+	}
+
+	a.chain.mut.Lock()
+	defer a.chain.mut.Unlock()
+
+	if a.chain.id == "" {
+		return
+	}
+
+	if a.chain.sequence > 0 {
+		a.checkpoint(mlog.LvlAuditCLI)
+	}
+	a.chain.id = ""
+}
+
+// ChainID returns the id of the current hash chain, or an empty string while the hash
+// chain is disabled.
+func (a *Audit) ChainID() string {
+	if a.chain == nil {
+		return ""
+	}
+
+	a.chain.mut.Lock()
+	defer a.chain.mut.Unlock()
+	return a.chain.id
+}
+
+// checkpoint signs the head of the chain, logging the checkpoint and writing it to the
+// sinks. The chain must be locked.
+func (a *Audit) checkpoint(level mlog.Level) {
+	checkpoint := &model.AuditCheckpoint{
+		Id:       model.NewId(),
+		ChainId:  a.chain.id,
+		Sequence: a.chain.sequence,
+		Hash:     a.chain.head,
+		CreateAt: model.GetMillis(),
+	}
+
+	if a.chain.sign != nil {
+		if err := a.chain.sign(checkpoint); err != nil {
+			a.onLoggerError(fmt.Errorf("cannot sign audit checkpoint: %w", err))
+		}
+	}
+
+	a.logger.Log(level, "",
+		mlog.String(KeyEventName, model.AuditCheckpointEventName),
+		mlog.String(KeyStatus, Success),
+		mlog.Any(KeyCheckpoint, checkpoint),
+	)
+	a.chain.enqueue(checkpoint, a.onSinkQueueFull)
+}
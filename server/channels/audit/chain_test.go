@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type memorySink struct {
+	mut         sync.Mutex
+	records     []*model.AuditRecord
+	checkpoints []*model.AuditCheckpoint
+}
+
+func (s *memorySink) WriteRecord(rec *model.AuditRecord) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *memorySink) WriteCheckpoint(checkpoint *model.AuditCheckpoint) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.checkpoints = append(s.checkpoints, checkpoint)
+	return nil
+}
+
+func TestAudit_HashChain(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	audit := &Audit{}
+	audit.Init(DefMaxQueueSize)
+	defer audit.Shutdown()
+
+	sink := &memorySink{}
+	audit.AddSink("memory", sink)
+	require.True(t, audit.HasSink("memory"))
+
+	logRecords := func(n int) {
+		for i := 0; i < n; i++ {
+			audit.LogRecord(mlog.LvlAuditAPI, Record{EventName: "test", Status: Success, Actor: EventActor{UserId: model.NewId()}})
+		}
+	}
+
+	t.Run("records aren't chained by default", func(t *testing.T) {
+		logRecords(2)
+		require.NoError(t, audit.Flush())
+		assert.Empty(t, audit.ChainID())
+		assert.Empty(t, sink.records)
+	})
+
+	audit.EnableHashChain(3, func(checkpoint *model.AuditCheckpoint) error {
+		return checkpoint.Sign(key)
+	})
+	chainID := audit.ChainID()
+	require.NotEmpty(t, chainID)
+
+	logRecords(7)
+	require.NoError(t, audit.Flush())
+
+	t.Run("chains the records", func(t *testing.T) {
+		require.Len(t, sink.records, 7)
+		assert.Empty(t, sink.records[0].PrevHash)
+		for i, rec := range sink.records {
+			assert.Equal(t, chainID, rec.ChainId)
+			assert.Equal(t, int64(i+1), rec.Sequence)
+			assert.Equal(t, "test", rec.EventName)
+			assert.Equal(t, rec.ComputeHash(), rec.Hash)
+			if i > 0 {
+				assert.Equal(t, sink.records[i-1].Hash, rec.PrevHash)
+			}
+		}
+	})
+
+	t.Run("takes signed checkpoints", func(t *testing.T) {
+		require.Len(t, sink.checkpoints, 2)
+		assert.Equal(t, int64(3), sink.checkpoints[0].Sequence)
+		assert.Equal(t, sink.records[2].Hash, sink.checkpoints[0].Hash)
+		assert.Equal(t, int64(6), sink.checkpoints[1].Sequence)
+		assert.True(t, sink.checkpoints[1].VerifySignature(&key.PublicKey))
+	})
+
+	t.Run("takes a checkpoint when disabled", func(t *testing.T) {
+		audit.DisableHashChain()
+		require.NoError(t, audit.Flush())
+		require.Len(t, sink.checkpoints, 3)
+		assert.Equal(t, int64(7), sink.checkpoints[2].Sequence)
+
+		reports := model.VerifyAuditChains(sink.records, sink.checkpoints, &key.PublicKey)
+		require.Len(t, reports, 1)
+		assert.True(t, reports[0].IsValid(), reports[0].Errors)
+		assert.True(t, reports[0].Complete)
+	})
+
+	t.Run("starts a new chain when enabled again", func(t *testing.T) {
+		audit.EnableHashChain(10, nil)
+		assert.NotEqual(t, chainID, audit.ChainID())
+
+		logRecords(1)
+		require.NoError(t, audit.Flush())
+		require.Len(t, sink.records, 8)
+		assert.Equal(t, int64(1), sink.records[7].Sequence)
+		assert.Empty(t, sink.records[7].PrevHash)
+	})
+
+	t.Run("removed sinks don't receive records", func(t *testing.T) {
+		audit.RemoveSink("memory")
+		assert.False(t, audit.HasSink("memory"))
+
+		logRecords(1)
+		require.NoError(t, audit.Flush())
+		assert.Len(t, sink.records, 8)
+	})
+}
+
+// failingSink fails to write the first records it is given.
+type failingSink struct {
+	memorySink
+	failures int
+}
+
+func (s *failingSink) WriteRecord(rec *model.AuditRecord) error {
+	s.mut.Lock()
+	if s.failures > 0 {
+		s.failures--
+		s.mut.Unlock()
+		return errors.New("database unavailable")
+	}
+	s.mut.Unlock()
+	return s.memorySink.WriteRecord(rec)
+}
+
+func TestAudit_SinkDoesNotDropRecords(t *testing.T) {
+	retryWait := sinkRetryWait
+	sinkRetryWait = time.Millisecond
+	defer func() { sinkRetryWait = retryWait }()
+
+	t.Run("failed writes are retried", func(t *testing.T) {
+		audit := &Audit{OnError: func(err error) {}}
+		audit.Init(DefMaxQueueSize)
+		defer audit.Shutdown()
+
+		sink := &failingSink{failures: 3}
+		audit.AddSink("failing", sink)
+		audit.EnableHashChain(10, nil)
+
+		for i := 0; i < 5; i++ {
+			audit.LogRecord(mlog.LvlAuditAPI, Record{EventName: "test", Status: Success})
+		}
+		require.NoError(t, audit.Flush())
+
+		require.Len(t, sink.records, 5)
+		for i, rec := range sink.records {
+			assert.Equal(t, int64(i+1), rec.Sequence)
+		}
+	})
+
+	t.Run("a full queue waits for room", func(t *testing.T) {
+		audit := &Audit{}
+		audit.Init(1)
+		defer audit.Shutdown()
+
+		sink := &failingSink{failures: 2}
+		audit.AddSink("failing", sink)
+		audit.EnableHashChain(100, nil)
+
+		for i := 0; i < 10; i++ {
+			audit.LogRecord(mlog.LvlAuditAPI, Record{EventName: "test", Status: Success})
+		}
+		require.NoError(t, audit.Flush())
+
+		require.Len(t, sink.records, 10)
+		reports := model.VerifyAuditChains(sink.records, nil, nil)
+		require.Len(t, reports, 1)
+		assert.True(t, reports[0].IsValid(), reports[0].Errors)
+	})
+}
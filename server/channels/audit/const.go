@@ -6,19 +6,21 @@ package audit
 const (
 	DefMaxQueueSize = 1000
 
-	KeyActor     = "actor"
-	KeyAPIPath   = "api_path"
-	KeyEvent     = "event"
-	KeyEventData = "event_data"
-	KeyEventName = "event_name"
-	KeyMeta      = "meta"
-	KeyError     = "error"
-	KeyStatus    = "status"
-	KeyUserID    = "user_id"
-	KeySessionID = "session_id"
-	KeyClient    = "client"
-	KeyIPAddress = "ip_address"
-	KeyClusterID = "cluster_id"
+	KeyActor      = "actor"
+	KeyAPIPath    = "api_path"
+	KeyEvent      = "event"
+	KeyEventData  = "event_data"
+	KeyEventName  = "event_name"
+	KeyMeta       = "meta"
+	KeyError      = "error"
+	KeyStatus     = "status"
+	KeyUserID     = "user_id"
+	KeySessionID  = "session_id"
+	KeyClient     = "client"
+	KeyIPAddress  = "ip_address"
+	KeyClusterID  = "cluster_id"
+	KeyChain      = "chain"
+	KeyCheckpoint = "checkpoint"
 
 	Success = "success"
 	Attempt = "attempt"
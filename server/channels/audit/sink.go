@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// Sink is a destination for audit records besides the mlog targets, e.g. the database.
+// Sinks only receive records while the hash chain is enabled, in the order of the chain.
+type Sink interface {
+	WriteRecord(rec *model.AuditRecord) error
+	WriteCheckpoint(checkpoint *model.AuditCheckpoint) error
+}
+
+// The wait before writing again a record a sink failed to write, doubled on each failure.
+var (
+	sinkRetryWait    = 100 * time.Millisecond
+	sinkMaxRetryWait = time.Minute
+)
+
+// sinkQueue writes the records of a sink from its own goroutine, so that a slow sink
+// doesn't hold up the callers logging audit records.
+//
+// Chained records are never dropped: a record missing from a sink can't be told apart from
+// one removed to tamper with the chain. A full queue blocks the callers logging audit
+// records, and a failed write is retried until it succeeds or the queue is stopped.
+type sinkQueue struct {
+	name     string
+	sink     Sink
+	queue    chan any
+	pending  sync.WaitGroup
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+func newSinkQueue(name string, sink Sink, maxQueueSize int, onError func(err error)) *sinkQueue {
+	q := &sinkQueue{
+		name:     name,
+		sink:     sink,
+		queue:    make(chan any, maxQueueSize),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go q.run(onError)
+	return q
+}
+
+func (q *sinkQueue) run(onError func(err error)) {
+	defer close(q.done)
+
+	for item := range q.queue {
+		q.write(item, onError)
+		q.pending.Done()
+	}
+}
+
+func (q *sinkQueue) write(item any, onError func(err error)) {
+	wait := sinkRetryWait
+	for {
+		var err error
+		switch item := item.(type) {
+		case *model.AuditRecord:
+			err = q.sink.WriteRecord(item)
+		case *model.AuditCheckpoint:
+			err = q.sink.WriteCheckpoint(item)
+		}
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-q.stopping:
+			onError(fmt.Errorf("audit sink %s: giving up on writing, the sink is removed: %w", q.name, err))
+			return
+		default:
+		}
+
+		onError(fmt.Errorf("audit sink %s: retrying in %s: %w", q.name, wait, err))
+		select {
+		case <-q.stopping:
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, sinkMaxRetryWait)
+	}
+}
+
+// enqueue adds a record or checkpoint to the queue, calling onFull when the queue is full
+// before waiting for room in the queue.
+func (q *sinkQueue) enqueue(item any, onFull func(name string, maxQueueSize int)) {
+	q.pending.Add(1)
+
+	select {
+	case q.queue <- item:
+	default:
+		onFull(q.name, cap(q.queue))
+		q.queue <- item
+	}
+}
+
+// stop writes the remaining items of the queue and stops its goroutine. Nothing may be
+// enqueued once stopped.
+func (q *sinkQueue) stop() {
+	close(q.stopping)
+	close(q.queue)
+	<-q.done
+}
+
+// AddSink adds a sink to write audit records to, replacing the sink of the same name.
+func (a *Audit) AddSink(name string, sink Sink) {
+	if a.chain == nil {
+		return
+	}
+
+	q := newSinkQueue(name, sink, a.chain.maxQueueSize, a.onLoggerError)
+
+	a.chain.mut.Lock()
+	old := a.chain.sinks[name]
+	a.chain.sinks[name] = q
+	a.chain.mut.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+}
+
+// RemoveSink removes a sink, once the records queued for it are written.
+func (a *Audit) RemoveSink(name string) {
+	if a.chain == nil {
+		return
+	}
+
+	a.chain.mut.Lock()
+	q := a.chain.sinks[name]
+	delete(a.chain.sinks, name)
+	a.chain.mut.Unlock()
+
+	if q != nil {
+		q.stop()
+	}
+}
+
+// HasSink returns whether a sink of the given name was added.
+func (a *Audit) HasSink(name string) bool {
+	if a.chain == nil {
+		return false
+	}
+
+	a.chain.mut.Lock()
+	defer a.chain.mut.Unlock()
+	_, ok := a.chain.sinks[name]
+	return ok
+}
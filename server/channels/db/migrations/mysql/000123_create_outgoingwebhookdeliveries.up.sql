@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS OutgoingWebhookDeliveries (
+    Id varchar(26) NOT NULL,
+    HookId varchar(26) NOT NULL,
+    PostId varchar(26) DEFAULT '',
+    ChannelId varchar(26) DEFAULT '',
+    CallbackURL text NOT NULL,
+    ContentType varchar(128) DEFAULT '',
+    Payload mediumtext,
+    Status varchar(32) NOT NULL,
+    Attempts int DEFAULT 0,
+    StatusCode int DEFAULT 0,
+    Latency bigint(20) DEFAULT 0,
+    LastError text,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    NextAttemptAt bigint(20) DEFAULT 0,
+    PRIMARY KEY (Id),
+    KEY idx_outgoingwebhookdeliveries_hookid_createat (HookId, CreateAt),
+    KEY idx_outgoingwebhookdeliveries_status_nextattemptat (Status, NextAttemptAt),
+    KEY idx_outgoingwebhookdeliveries_createat (CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,74 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND index_name = 'idx_jobs_rootid'
+    ) > 0,
+    'DROP INDEX idx_jobs_rootid ON Jobs;',
+    'SELECT 1;'
+));
+
+PREPARE removeIndexIfExists FROM @preparedStatement;
+EXECUTE removeIndexIfExists;
+DEALLOCATE PREPARE removeIndexIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RunAfter'
+    ),
+    'ALTER TABLE Jobs DROP COLUMN RunAfter;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RetryCount'
+    ),
+    'ALTER TABLE Jobs DROP COLUMN RetryCount;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RootId'
+    ),
+    'ALTER TABLE Jobs DROP COLUMN RootId;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'ParentId'
+    ),
+    'ALTER TABLE Jobs DROP COLUMN ParentId;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
@@ -0,0 +1,74 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'ParentId'
+    ),
+    'ALTER TABLE Jobs ADD COLUMN ParentId varchar(26) NOT NULL DEFAULT '''';',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RootId'
+    ),
+    'ALTER TABLE Jobs ADD COLUMN RootId varchar(26) NOT NULL DEFAULT '''';',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RetryCount'
+    ),
+    'ALTER TABLE Jobs ADD COLUMN RetryCount int NOT NULL DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'RunAfter'
+    ),
+    'ALTER TABLE Jobs ADD COLUMN RunAfter bigint NOT NULL DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND index_name = 'idx_jobs_rootid'
+    ) > 0,
+    'SELECT 1;',
+    'CREATE INDEX idx_jobs_rootid ON Jobs(RootId);'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
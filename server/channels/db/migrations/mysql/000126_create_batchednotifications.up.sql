@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS BatchedNotifications (
+    Id varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    PostId varchar(26) NOT NULL,
+    TeamName varchar(64) DEFAULT '',
+    CreateAt bigint(20) NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_batchednotifications_userid_createat (UserId, CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS MfaResetRequests (
+    Id varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    Status varchar(32) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    ResolvedBy varchar(26) NOT NULL DEFAULT '',
+    PRIMARY KEY (Id),
+    KEY idx_mfaresetrequests_userid (UserId),
+    KEY idx_mfaresetrequests_status_createat (Status, CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
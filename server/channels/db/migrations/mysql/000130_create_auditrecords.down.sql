@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS AuditCheckpoints;
+DROP TABLE IF EXISTS AuditRecords;
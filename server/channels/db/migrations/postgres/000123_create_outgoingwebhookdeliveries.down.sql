@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outgoingwebhookdeliveries_hookid_createat;
+DROP INDEX IF EXISTS idx_outgoingwebhookdeliveries_status_nextattemptat;
+DROP INDEX IF EXISTS idx_outgoingwebhookdeliveries_createat;
+
+DROP TABLE IF EXISTS outgoingwebhookdeliveries;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS outgoingwebhookdeliveries (
+    id varchar(26) PRIMARY KEY,
+    hookid varchar(26) NOT NULL,
+    postid varchar(26) DEFAULT '',
+    channelid varchar(26) DEFAULT '',
+    callbackurl text NOT NULL,
+    contenttype varchar(128) DEFAULT '',
+    payload text,
+    status varchar(32) NOT NULL,
+    attempts integer DEFAULT 0,
+    statuscode integer DEFAULT 0,
+    latency bigint DEFAULT 0,
+    lasterror text,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    nextattemptat bigint DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_hookid_createat ON outgoingwebhookdeliveries (hookid, createat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_status_nextattemptat ON outgoingwebhookdeliveries (status, nextattemptat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_createat ON outgoingwebhookdeliveries (createat);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_scheduledposts_userid_channelid_scheduledat;
+DROP INDEX IF EXISTS idx_scheduledposts_scheduledat_id;
+
+DROP TABLE IF EXISTS scheduledposts;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS scheduledposts (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    userid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    rootid varchar(26) DEFAULT '',
+    message varchar(65535),
+    props varchar(8000),
+    fileids varchar(300),
+    priority text,
+    scheduledat bigint NOT NULL,
+    processedat bigint DEFAULT 0,
+    errorcode varchar(64) DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_userid_channelid_scheduledat ON scheduledposts (userid, channelid, scheduledat);
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_scheduledat_id ON scheduledposts (scheduledat, id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_jobs_rootid;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS runafter;
+ALTER TABLE jobs DROP COLUMN IF EXISTS retrycount;
+ALTER TABLE jobs DROP COLUMN IF EXISTS rootid;
+ALTER TABLE jobs DROP COLUMN IF EXISTS parentid;
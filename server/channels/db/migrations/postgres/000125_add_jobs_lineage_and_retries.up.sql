@@ -0,0 +1,6 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS parentid VARCHAR(26) NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS rootid VARCHAR(26) NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS retrycount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS runafter BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_rootid ON jobs(rootid);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_batchednotifications_userid_createat;
+
+DROP TABLE IF EXISTS batchednotifications;
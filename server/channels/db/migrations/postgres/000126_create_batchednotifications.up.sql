@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS batchednotifications (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    postid varchar(26) NOT NULL,
+    teamname varchar(64) DEFAULT '',
+    createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_batchednotifications_userid_createat ON batchednotifications (userid, createat);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS webauthncredentials (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    name varchar(64) NOT NULL,
+    credentialid varchar(1024) NOT NULL,
+    publickey bytea NOT NULL,
+    signcount bigint NOT NULL DEFAULT 0,
+    createat bigint NOT NULL,
+    lastusedat bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthncredentials_userid ON webauthncredentials (userid);
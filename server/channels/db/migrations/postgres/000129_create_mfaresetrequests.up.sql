@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS mfaresetrequests (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    status varchar(32) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    resolvedby varchar(26) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfaresetrequests_userid ON mfaresetrequests (userid);
+CREATE INDEX IF NOT EXISTS idx_mfaresetrequests_status_createat ON mfaresetrequests (status, createat);
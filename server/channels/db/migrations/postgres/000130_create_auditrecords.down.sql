@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS auditcheckpoints;
+DROP TABLE IF EXISTS auditrecords;
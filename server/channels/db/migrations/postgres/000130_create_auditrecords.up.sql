@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS auditrecords (
+    id varchar(26) PRIMARY KEY,
+    chainid varchar(26) NOT NULL,
+    sequence bigint NOT NULL,
+    createat bigint NOT NULL,
+    level varchar(64) NOT NULL,
+    eventname varchar(256) NOT NULL,
+    status varchar(32) NOT NULL,
+    userid varchar(256) NOT NULL,
+    data text NOT NULL,
+    prevhash varchar(64) NOT NULL,
+    hash varchar(64) NOT NULL,
+    UNIQUE (chainid, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditrecords_createat ON auditrecords (createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_userid_createat ON auditrecords (userid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_eventname_createat ON auditrecords (eventname, createat);
+
+CREATE TABLE IF NOT EXISTS auditcheckpoints (
+    id varchar(26) PRIMARY KEY,
+    chainid varchar(26) NOT NULL,
+    sequence bigint NOT NULL,
+    hash varchar(64) NOT NULL,
+    createat bigint NOT NULL,
+    signature text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditcheckpoints_chainid_sequence ON auditcheckpoints (chainid, sequence);
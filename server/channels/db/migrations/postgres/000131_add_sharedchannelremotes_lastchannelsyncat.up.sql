@@ -0,0 +1 @@
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastchannelsyncat BIGINT NOT NULL DEFAULT 0;
@@ -8,6 +8,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/robfig/cron/v3"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
@@ -78,6 +80,13 @@ func (scheduler *DailyScheduler) ScheduleJob(c request.CTX, _ *model.Config /* p
 	return scheduler.jobs.CreateJob(c, scheduler.jobType, nil)
 }
 
+// ParseCronSchedule parses a cron expression as set in JobSettings.CronSchedules. Both
+// the standard five fields expressions, evaluated in the local time of the server, and
+// descriptors such as @daily or @every 6h are supported.
+func ParseCronSchedule(spec string) (cron.Schedule, error) {
+	return cron.ParseStandard(spec)
+}
+
 const jitterRange = 2000 // milliseconds
 
 func getRandomDelay(limit int64) time.Duration {
@@ -95,13 +95,22 @@ func getFileCount(jobData model.StringMap) (int, error) {
 	return 0, nil
 }
 
+// getReportFormat returns the format the report was requested in, falling back to
+// the default format of the worker.
+func (worker *BatchReportWorker) getReportFormat(jobData model.StringMap) string {
+	if format := jobData["report_format"]; format != "" {
+		return format
+	}
+	return worker.reportFormat
+}
+
 func (worker *BatchReportWorker) processChunk(job *model.Job, reportData []model.ReportableObject) error {
 	fileCount, err := getFileCount(job.Data)
 	if err != nil {
 		return err
 	}
 
-	appErr := worker.app.SaveReportChunk(worker.reportFormat, job.Id, fileCount, reportData)
+	appErr := worker.app.SaveReportChunk(worker.getReportFormat(job.Data), job.Id, fileCount, reportData)
 	if appErr != nil {
 		return err
 	}
@@ -118,16 +127,17 @@ func (worker *BatchReportWorker) complete(rctx request.CTX, job *model.Job) erro
 		return err
 	}
 
-	appErr := worker.app.CompileReportChunks(worker.reportFormat, job.Id, fileCount, worker.headers)
+	format := worker.getReportFormat(job.Data)
+	appErr := worker.app.CompileReportChunks(format, job.Id, fileCount, worker.headers)
 	if appErr != nil {
 		return appErr
 	}
 
 	defer func() {
-		worker.app.CleanupReportChunks(worker.reportFormat, job.Id, fileCount)
+		worker.app.CleanupReportChunks(format, job.Id, fileCount)
 	}()
 
-	if appErr = worker.app.SendReportToUser(rctx, job, worker.reportFormat); appErr != nil {
+	if appErr = worker.app.SendReportToUser(rctx, job, format); appErr != nil {
 		return appErr
 	}
 
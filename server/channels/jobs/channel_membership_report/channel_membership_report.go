@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channel_membership_report
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+)
+
+type ChannelMembershipReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate channel membership reports.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ChannelMembershipReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		model.ReportFormatCSV,
+		[]string{
+			"ChannelId",
+			"ChannelName",
+			"ChannelDisplayName",
+			"ChannelType",
+			"TeamId",
+			"TeamName",
+			"UserId",
+			"Username",
+			"Email",
+			"Role",
+			"LastViewedAt",
+		},
+		getData(app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next.
+func parseJobMetadata(data model.StringMap) *model.ChannelMembershipReportOptions {
+	return &model.ChannelMembershipReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			PageSize:        100,
+			FromColumnValue: data["last_channel_id"],
+			FromId:          data["last_user_id"],
+		},
+		TeamId: data["team_id"],
+	}
+}
+
+// makeJobMetadata encodes the information needed to decide which batch to process next back into
+// the opaque job metadata.
+func makeJobMetadata(jobData model.StringMap, channelID string, userID string) model.StringMap {
+	jobData["last_channel_id"] = channelID
+	jobData["last_user_id"] = userID
+	return jobData
+}
+
+func getData(app ChannelMembershipReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		filter := parseJobMetadata(jobData)
+
+		rows, appErr := app.GetChannelMembershipReport(filter)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (channel_id=%v, user_id=%v)", filter.FromColumnValue, filter.FromId)
+		}
+
+		if len(rows) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, 0, len(rows))
+		for _, row := range rows {
+			reportableObjects = append(reportableObjects, row)
+		}
+
+		last := rows[len(rows)-1]
+		return reportableObjects, makeJobMetadata(jobData, last.ChannelId, last.UserId), false, nil
+	}
+}
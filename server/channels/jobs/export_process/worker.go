@@ -6,7 +6,9 @@ package export_process
 import (
 	"context"
 	"io"
+	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -53,6 +55,13 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		if since, ok := job.Data["since"]; ok && since != "" {
+			var err error
+			if opts.Since, err = strconv.ParseInt(since, 10, 64); err != nil || opts.Since < 0 {
+				return model.NewAppError("ExportProcessWorker", "app.export.parse_since.error", map[string]any{"Since": since}, "", http.StatusBadRequest).Wrap(err)
+			}
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_encryption
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// Number of files processed between two updates of the job data.
+const progressInterval = 100
+
+// MakeWorker creates a worker that encrypts the files of the store which are
+// not encrypted yet, or were encrypted with a retired key, using the active
+// encryption key. This covers every file of the store, including thumbnails
+// and previews.
+func MakeWorker(jobServer *jobs.JobServer, fileBackend filestore.FileBackend) *jobs.SimpleWorker {
+	const workerName = "FileEncryption"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.FileSettings.EnableEncryptionAtRest
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		backends := encryptedBackends(fileBackend)
+		if len(backends) == 0 {
+			return errors.New("encryption at rest is not enabled for the file store")
+		}
+
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		// Resume after the last file processed if the job was interrupted.
+		tier, _ := strconv.Atoi(job.Data["tier"])
+		encrypted, _ := strconv.Atoi(job.Data["encrypted"])
+		failed, _ := strconv.Atoi(job.Data["errors"])
+
+		for ; tier < len(backends); tier++ {
+			backend := backends[tier]
+			paths, err := backend.ListDirectoryRecursively("")
+			if err != nil {
+				return errors.Wrap(err, "failed to list the files")
+			}
+			sort.Strings(paths)
+
+			lastPath := job.Data["last_path"]
+			start := sort.SearchStrings(paths, lastPath)
+			if start < len(paths) && paths[start] == lastPath {
+				start++
+			}
+
+			for i := start; i < len(paths); i++ {
+				path := paths[i]
+				// Leftovers of an interrupted rewrite.
+				if strings.HasSuffix(path, ".reencrypt") {
+					continue
+				}
+
+				rewritten, err := backend.ReEncryptFile(path)
+				if err != nil {
+					logger.Warn("Failed to encrypt file", mlog.String("path", path), mlog.Err(err))
+					failed++
+				} else if rewritten {
+					encrypted++
+				}
+
+				if (i+1)%progressInterval == 0 || i == len(paths)-1 {
+					job.Data["tier"] = strconv.Itoa(tier)
+					job.Data["last_path"] = path
+					job.Data["encrypted"] = strconv.Itoa(encrypted)
+					job.Data["errors"] = strconv.Itoa(failed)
+					job.Progress = int64((tier*len(paths) + i + 1) * 100 / (len(backends) * len(paths)))
+					if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+						logger.Error("Worker: Failed to update job data", mlog.Err(appErr))
+					}
+				}
+			}
+
+			delete(job.Data, "last_path")
+		}
+
+		if failed > 0 {
+			return errors.Errorf("failed to encrypt %d files", failed)
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
+
+// encryptedBackends returns the encrypted backends fileBackend is made of,
+// which is more than one when the store is tiered.
+func encryptedBackends(fileBackend filestore.FileBackend) []*filestore.EncryptedFileBackend {
+	switch backend := fileBackend.(type) {
+	case *filestore.EncryptedFileBackend:
+		return []*filestore.EncryptedFileBackend{backend}
+	case *filestore.TieredFileBackend:
+		return append(encryptedBackends(backend.Primary()), encryptedBackends(backend.Secondary())...)
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_tier_migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+	pageSize           = 100
+
+	// DirectionToSecondary moves the files older than the configured number
+	// of days from the primary to the secondary backend. This is the default.
+	DirectionToSecondary = "to_secondary"
+	// DirectionToPrimary moves all the files back to the primary backend,
+	// which is needed before disabling the archive store.
+	DirectionToPrimary = "to_primary"
+)
+
+type FileTierMigrationWorker struct {
+	name        string
+	jobServer   *jobs.JobServer
+	logger      mlog.LoggerIFace
+	store       store.Store
+	fileBackend *filestore.TieredFileBackend
+
+	stop    chan struct{}
+	stopped chan bool
+	jobs    chan model.Job
+}
+
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, fileBackend filestore.FileBackend) *FileTierMigrationWorker {
+	// If the type cast fails, it will be nil
+	// which is checked later.
+	tieredBackend, _ := fileBackend.(*filestore.TieredFileBackend)
+	const workerName = "FileTierMigration"
+	worker := &FileTierMigrationWorker{
+		name:        workerName,
+		jobServer:   jobServer,
+		logger:      jobServer.Logger().With(mlog.String("worker_name", workerName)),
+		store:       store,
+		fileBackend: tieredBackend,
+		stop:        make(chan struct{}),
+		stopped:     make(chan bool, 1),
+		jobs:        make(chan model.Job),
+	}
+	return worker
+}
+
+func (worker *FileTierMigrationWorker) Run() {
+	worker.logger.Debug("Worker started")
+	// We have to re-assign the stop channel again, because
+	// it might happen that the job was restarted due to a config change.
+	worker.stop = make(chan struct{}, 1)
+
+	defer func() {
+		worker.logger.Debug("Worker finished")
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			worker.logger.Debug("Worker received stop signal")
+			return
+		case job := <-worker.jobs:
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *FileTierMigrationWorker) Stop() {
+	worker.logger.Debug("Worker stopping")
+	close(worker.stop)
+	<-worker.stopped
+}
+
+func (worker *FileTierMigrationWorker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *FileTierMigrationWorker) IsEnabled(cfg *model.Config) bool {
+	return *cfg.FileSettings.EnableArchiveStore
+}
+
+func (worker *FileTierMigrationWorker) getJobMetadata(job *model.Job, key string) (int64, *model.AppError) {
+	countStr := job.Data[key]
+	var count int64
+	var err error
+	if countStr != "" {
+		count, err = strconv.ParseInt(countStr, 10, 64)
+		if err != nil {
+			return 0, model.NewAppError("getJobMetadata", model.NoTranslation, nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	return count, nil
+}
+
+func (worker *FileTierMigrationWorker) DoJob(job *model.Job) {
+	logger := worker.logger.With(jobs.JobLoggerFields(job)...)
+	logger.Debug("Worker: Received a new candidate job.")
+	defer worker.jobServer.HandleJobPanic(logger, job)
+
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		logger.Warn("FileTierMigrationWorker experienced an error while trying to claim job", mlog.Err(err))
+		return
+	} else if !claimed {
+		return
+	}
+
+	if worker.fileBackend == nil {
+		err := errors.New("no tiered file backend found")
+		logger.Error("FileTierMigrationWorker: ", mlog.Err(err))
+		worker.setJobError(logger, job, model.NewAppError("DoJob", model.NoTranslation, nil, "", http.StatusInternalServerError).Wrap(err))
+		return
+	}
+
+	c := request.EmptyContext(worker.logger)
+
+	var appErr *model.AppError
+	// We get the job again because ClaimJob changes the job status.
+	job, appErr = worker.jobServer.GetJob(c, job.Id)
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: job execution error", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+
+	if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+
+	src, dst := worker.fileBackend.Primary(), worker.fileBackend.Secondary()
+	if job.Data["direction"] == DirectionToPrimary {
+		src, dst = dst, src
+	} else {
+		job.Data["direction"] = DirectionToSecondary
+	}
+
+	// The cutoff is computed once so that resuming the job doesn't move the goalposts.
+	cutoff, appErr := worker.getJobMetadata(job, "cutoff")
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: failed to get the cutoff", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+	if cutoff == 0 {
+		cutoff = model.GetMillis()
+		if job.Data["direction"] == DirectionToSecondary {
+			days := *worker.jobServer.Config().FileSettings.ArchiveAfterDays
+			if daysStr := job.Data["older_than_days"]; daysStr != "" {
+				var err error
+				if days, err = strconv.Atoi(daysStr); err != nil || days <= 0 {
+					worker.setJobError(logger, job, model.NewAppError("DoJob", "app.job.file_tier_migration.older_than_days.app_error", map[string]any{"Value": daysStr}, "", http.StatusBadRequest))
+					return
+				}
+			}
+			cutoff -= int64(days) * 24 * time.Hour.Milliseconds()
+		}
+		job.Data["cutoff"] = strconv.FormatInt(cutoff, 10)
+	}
+
+	// Check if there is metadata for that job.
+	// If there isn't, it will be empty by default, which is the right value.
+	startFileID := job.Data["start_file_id"]
+	startTime, appErr := worker.getJobMetadata(job, "start_create_at")
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: failed to get start create_at", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+	doneCount, appErr := worker.getJobMetadata(job, "done_file_count")
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: failed to get done file count", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+	movedCount, appErr := worker.getJobMetadata(job, "moved_file_count")
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: failed to get moved file count", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+	errorCount, appErr := worker.getJobMetadata(job, "error_count")
+	if appErr != nil {
+		logger.Error("FileTierMigrationWorker: failed to get error count", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+
+	for {
+		select {
+		case <-worker.stop:
+			logger.Info("Worker: File tier migration has been canceled via Worker Stop. Setting the job back to pending.")
+			if err := worker.jobServer.SetJobPending(job); err != nil {
+				worker.logger.Error("Worker: Failed to mark job as pending", mlog.Err(err))
+			}
+			return
+		case <-time.After(timeBetweenBatches):
+			var files []*model.FileForIndexing
+			tries := 0
+			for files == nil {
+				var err error
+				files, err = worker.store.FileInfo().GetFilesBatchForIndexing(startTime, startFileID, true, pageSize)
+				if err != nil {
+					if tries > 3 {
+						logger.Error("Worker: Failed to get files after multiple retries. Exiting")
+						worker.setJobError(logger, job, model.NewAppError("DoJob", model.NoTranslation, nil, "", http.StatusInternalServerError).Wrap(err))
+						return
+					}
+					logger.Warn("Failed to get file info for file tier migration. Retrying .. ", mlog.Err(err))
+
+					// Wait a bit before trying again.
+					time.Sleep(15 * time.Second)
+				}
+
+				tries++
+			}
+
+			done := len(files) == 0
+			for _, f := range files {
+				if f.CreateAt > cutoff {
+					done = true
+					break
+				}
+
+				// We do not fail the job if a single file failed to move,
+				// it stays available where it was.
+				moved := false
+				for _, path := range []string{f.Path, f.PreviewPath, f.ThumbnailPath} {
+					if path == "" {
+						continue
+					}
+					ok, err := MigrateFile(src, dst, path)
+					if err != nil {
+						logger.Warn("Failed to move file", mlog.String("path", path), mlog.String("id", f.Id), mlog.Err(err))
+						errorCount++
+						continue
+					}
+					moved = moved || ok
+				}
+				if moved {
+					movedCount++
+				}
+
+				startFileID = f.Id
+				startTime = f.CreateAt
+				doneCount++
+			}
+
+			job.Data["start_file_id"] = startFileID
+			job.Data["start_create_at"] = strconv.FormatInt(startTime, 10)
+			job.Data["done_file_count"] = strconv.FormatInt(doneCount, 10)
+			job.Data["moved_file_count"] = strconv.FormatInt(movedCount, 10)
+			job.Data["error_count"] = strconv.FormatInt(errorCount, 10)
+
+			if done {
+				logger.Info("FileTierMigrationWorker: Job is complete", mlog.Int("moved_file_count", movedCount), mlog.Int("error_count", errorCount))
+				worker.setJobSuccess(logger, job)
+				return
+			}
+
+			if err := worker.jobServer.UpdateInProgressJobData(job); err != nil {
+				logger.Error("Worker: Failed to update job data", mlog.Err(err))
+			}
+		}
+	}
+}
+
+// MigrateFile moves the file at path from src to dst, removing it from src
+// only once its content in dst has been verified. It returns false if there
+// was nothing to move.
+func MigrateFile(src, dst filestore.FileBackend, path string) (bool, error) {
+	exists, err := src.FileExists(path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check the source file")
+	}
+	if !exists {
+		// Already migrated, or the file is missing altogether.
+		return false, nil
+	}
+
+	r, err := src.Reader(path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open the source file")
+	}
+	defer r.Close()
+
+	srcHash := sha256.New()
+	written, err := filestore.TryWriteFileContext(context.Background(), dst, io.TeeReader(r, srcHash), path)
+	if err != nil {
+		// Don't leave a partial copy that could shadow the source file.
+		dst.RemoveFile(path)
+		return false, errors.Wrap(err, "failed to write the destination file")
+	}
+
+	// Verify what has been written before removing the source.
+	dstReader, err := dst.Reader(path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open the destination file")
+	}
+	defer dstReader.Close()
+
+	dstHash := sha256.New()
+	read, err := io.Copy(dstHash, dstReader)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read the destination file")
+	}
+	if read != written || !bytes.Equal(srcHash.Sum(nil), dstHash.Sum(nil)) {
+		dst.RemoveFile(path)
+		return false, errors.New("the content of the destination file doesn't match the source file")
+	}
+
+	if err := src.RemoveFile(path); err != nil {
+		return false, errors.Wrap(err, "failed to remove the source file")
+	}
+
+	return true, nil
+}
+
+func (worker *FileTierMigrationWorker) setJobSuccess(logger mlog.LoggerIFace, job *model.Job) {
+	if err := worker.jobServer.SetJobProgress(job, 100); err != nil {
+		logger.Error("Worker: Failed to update progress for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+	}
+
+	if err := worker.jobServer.SetJobSuccess(job); err != nil {
+		logger.Error("FileTierMigrationWorker: Failed to set success for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+	}
+}
+
+func (worker *FileTierMigrationWorker) setJobError(logger mlog.LoggerIFace, job *model.Job, appError *model.AppError) {
+	if err := worker.jobServer.SetJobError(job, appError); err != nil {
+		logger.Error("FileTierMigrationWorker: Failed to set job error", mlog.Err(err))
+	}
+}
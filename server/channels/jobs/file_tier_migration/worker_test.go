@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_tier_migration
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func TestMigrateFile(t *testing.T) {
+	src, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: "local", Directory: t.TempDir()})
+	require.NoError(t, err)
+	dst, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: "local", Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	data := []byte("some file content")
+	_, err = src.WriteFile(bytes.NewReader(data), "data/file.txt")
+	require.NoError(t, err)
+
+	t.Run("moves the file", func(t *testing.T) {
+		moved, err := MigrateFile(src, dst, "data/file.txt")
+		require.NoError(t, err)
+		assert.True(t, moved)
+
+		exists, err := src.FileExists("data/file.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		read, err := dst.ReadFile("data/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+	})
+
+	t.Run("already moved", func(t *testing.T) {
+		moved, err := MigrateFile(src, dst, "data/file.txt")
+		require.NoError(t, err)
+		assert.False(t, moved)
+	})
+
+	t.Run("tiered backend reads the moved file", func(t *testing.T) {
+		read, err := filestore.NewTieredFileBackend(src, dst).ReadFile("data/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+	})
+}
@@ -5,6 +5,8 @@ package import_process
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,7 +31,9 @@ type AppIface interface {
 	FileExists(path string) (bool, *model.AppError)
 	FileSize(path string) (int64, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
 	BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int)
+	BulkImportReport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.BulkImportReport, *model.AppError)
 	Log() *mlog.Logger
 }
 
@@ -123,6 +127,29 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.missing_jsonl", nil, "jsonFile was nil", http.StatusBadRequest)
 		}
 
+		// A dry run writes a report of what the import would change, and keeps the import file
+		// so that it can be imported afterwards.
+		if job.Data["dry_run"] == "true" {
+			report, appErr := app.BulkImportReport(appContext, jsonFile, importZipReader, model.ExportDataDir)
+			if appErr != nil {
+				return appErr
+			}
+
+			reportJSON, err := json.Marshal(report)
+			if err != nil {
+				return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.write_report", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			reportPath := filepath.Join(*app.Config().ImportSettings.Directory, job.Id+"_report.json")
+			if _, appErr := app.WriteFile(bytes.NewReader(reportJSON), reportPath); appErr != nil {
+				return appErr
+			}
+
+			job.Data["report_file"] = reportPath
+			job.Data["is_downloadable"] = "true"
+			return jobServer.UpdateInProgressJobData(job)
+		}
+
 		extractContent := job.Data["extract_content"] == "true"
 		// do the actual import.
 		appErr, lineNumber := app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
@@ -19,6 +19,9 @@ import (
 
 const (
 	CancelWatcherPollingInterval = 5000
+
+	// The longest a retry is delayed by, however many times the job was retried.
+	maxJobRetryBackoff = 24 * time.Hour
 )
 
 // JobLoggerFields returns the logger annotations reflecting the given job metadata.
@@ -122,6 +125,9 @@ func (srv *JobServer) SetJobWarning(job *model.Job) *model.AppError {
 	if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JobStatusWarning); err != nil {
 		return model.NewAppError("SetJobWarning", "app.job.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
+
+	srv.createFollowUpJobs(job)
+
 	return nil
 }
 
@@ -134,6 +140,8 @@ func (srv *JobServer) SetJobSuccess(job *model.Job) *model.AppError {
 		srv.metrics.DecrementJobActive(job.Type)
 	}
 
+	srv.createFollowUpJobs(job)
+
 	return nil
 }
 
@@ -148,6 +156,8 @@ func (srv *JobServer) SetJobError(job *model.Job, jobError *model.AppError) *mod
 			srv.metrics.DecrementJobActive(job.Type)
 		}
 
+		srv.retryJob(job)
+
 		return nil
 	}
 
@@ -179,11 +189,100 @@ func (srv *JobServer) SetJobError(job *model.Job, jobError *model.AppError) *mod
 		if !updated {
 			return model.NewAppError("SetJobError", "jobs.set_job_error.update.error", nil, "id="+job.Id, http.StatusInternalServerError)
 		}
+
+		// The job was canceled, so it isn't retried.
+		return nil
 	}
 
+	srv.retryJob(job)
+
 	return nil
 }
 
+// retryJob creates a job retrying the failed one, if the retry policy of its type allows
+// for another attempt. The retry is delayed by the backoff of the policy, doubled for
+// each of the previous retries.
+func (srv *JobServer) retryJob(job *model.Job) {
+	policy := srv.Config().JobSettings.RetryPolicies[job.Type]
+	if policy == nil || job.RetryCount+1 >= *policy.MaxAttempts {
+		return
+	}
+
+	backoff := time.Duration(*policy.BackoffSeconds) * time.Second
+	for i := 0; i < job.RetryCount && backoff < maxJobRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxJobRetryBackoff {
+		backoff = maxJobRetryBackoff
+	}
+
+	retry := srv.newChildJob(job, job.Type)
+	retry.RetryCount = job.RetryCount + 1
+	retry.RunAfter = retry.CreateAt + backoff.Milliseconds()
+
+	if _, err := srv.Store.Job().Save(retry); err != nil {
+		srv.Logger().Error("Failed to create the retry of the job", append(JobLoggerFields(job), mlog.Err(err))...)
+		return
+	}
+	srv.Logger().Info("Job failed and will be retried", append(JobLoggerFields(job), mlog.String("retry_job_id", retry.Id), mlog.Int("retry_count", retry.RetryCount), mlog.Millis("run_after", retry.RunAfter))...)
+}
+
+// createFollowUpJobs creates the jobs set in JobSettings.FollowUpJobs to follow up on the
+// completed job. They are given the data of the job, so that they can use its results.
+func (srv *JobServer) createFollowUpJobs(job *model.Job) {
+	for _, jobType := range srv.Config().JobSettings.FollowUpJobs[job.Type] {
+		if srv.workers.Get(jobType) == nil {
+			srv.Logger().Warn("Skipping a follow-up job of an unknown type", append(JobLoggerFields(job), mlog.String("follow_up_job_type", jobType))...)
+			continue
+		}
+
+		followUp := srv.newChildJob(job, jobType)
+		if _, err := srv.Store.Job().Save(followUp); err != nil {
+			srv.Logger().Error("Failed to create the follow-up job", append(JobLoggerFields(job), mlog.String("follow_up_job_type", jobType), mlog.Err(err))...)
+		}
+	}
+}
+
+// newChildJob returns a pending job of the given type, part of the lineage of the parent.
+func (srv *JobServer) newChildJob(parent *model.Job, jobType string) *model.Job {
+	data := make(model.StringMap, len(parent.Data))
+	for k, v := range parent.Data {
+		data[k] = v
+	}
+	delete(data, "error")
+
+	rootID := parent.RootId
+	if rootID == "" {
+		rootID = parent.Id
+	}
+
+	return &model.Job{
+		Id:       model.NewId(),
+		Type:     jobType,
+		CreateAt: model.GetMillis(),
+		Status:   model.JobStatusPending,
+		Data:     data,
+		ParentId: parent.Id,
+		RootId:   rootID,
+	}
+}
+
+// GetJobLineage returns the jobs of the lineage the given job is part of, from the first
+// one to the newest, such as its retries and follow-up jobs.
+func (srv *JobServer) GetJobLineage(c request.CTX, job *model.Job) ([]*model.Job, *model.AppError) {
+	rootID := job.RootId
+	if rootID == "" {
+		rootID = job.Id
+	}
+
+	jobs, err := srv.Store.Job().GetAllByRootId(c, rootID)
+	if err != nil {
+		return nil, model.NewAppError("GetJobLineage", "app.job.get_lineage.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return jobs, nil
+}
+
 func (srv *JobServer) SetJobCanceled(job *model.Job) *model.AppError {
 	if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JobStatusCanceled); err != nil {
 		return model.NewAppError("SetJobCanceled", "app.job.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -332,3 +431,61 @@ func (srv *JobServer) GetLastSuccessfulJobByType(jobType string) (*model.Job, *m
 	}
 	return job, nil
 }
+
+// PauseJobType pauses the given job type on the whole cluster: its scheduler doesn't
+// create new jobs, and its pending jobs aren't run until the job type is resumed. The
+// jobs already in progress aren't affected.
+func (srv *JobServer) PauseJobType(jobType string) *model.AppError {
+	if err := srv.Store.System().SaveOrUpdate(&model.System{Name: model.SystemJobTypePausedPrefix + jobType, Value: "true"}); err != nil {
+		return model.NewAppError("PauseJobType", "app.job.pause_job_type.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (srv *JobServer) ResumeJobType(jobType string) *model.AppError {
+	if _, err := srv.Store.System().PermanentDeleteByName(model.SystemJobTypePausedPrefix + jobType); err != nil {
+		return model.NewAppError("ResumeJobType", "app.job.resume_job_type.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (srv *JobServer) IsJobTypePaused(jobType string) (bool, *model.AppError) {
+	_, err := srv.Store.System().GetByName(model.SystemJobTypePausedPrefix + jobType)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return false, nil
+		}
+		return false, model.NewAppError("IsJobTypePaused", "app.job.get_paused_job_types.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return true, nil
+}
+
+func (srv *JobServer) GetPausedJobTypes() (map[string]bool, *model.AppError) {
+	props, err := srv.Store.System().Get()
+	if err != nil {
+		return nil, model.NewAppError("GetPausedJobTypes", "app.job.get_paused_job_types.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	paused := make(map[string]bool)
+	for name := range props {
+		if jobType, ok := strings.CutPrefix(name, model.SystemJobTypePausedPrefix); ok {
+			paused[jobType] = true
+		}
+	}
+	return paused, nil
+}
+
+// IsRegisteredJobType returns whether the job type has a worker or a scheduler on
+// this server.
+func (srv *JobServer) IsRegisteredJobType(jobType string) bool {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+	if srv.workers != nil && srv.workers.Get(jobType) != nil {
+		return true
+	}
+	if srv.schedulers != nil && srv.schedulers.schedulers[jobType] != nil {
+		return true
+	}
+	return false
+}
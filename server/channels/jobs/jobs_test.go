@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -22,7 +23,9 @@ import (
 )
 
 func makeJobServer(t *testing.T) (*JobServer, *storetest.Store, *mocks.MetricsInterface) {
-	configService := &testutils.StaticConfigService{}
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	configService := &testutils.StaticConfigService{Cfg: cfg}
 
 	mockStore := &storetest.Store{}
 	t.Cleanup(func() {
@@ -51,7 +54,9 @@ func expectErrorId(t *testing.T, errId string, appErr *model.AppError) {
 }
 
 func makeTeamEditionJobServer(t *testing.T) (*JobServer, *storetest.Store) {
-	configService := &testutils.StaticConfigService{}
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	configService := &testutils.StaticConfigService{Cfg: cfg}
 
 	mockStore := &storetest.Store{}
 	t.Cleanup(func() {
@@ -420,6 +425,116 @@ func TestSetJobError(t *testing.T) {
 	})
 }
 
+func TestRetryJob(t *testing.T) {
+	makeRetryingJobServer := func(t *testing.T) (*JobServer, *storetest.Store, *mocks.MetricsInterface) {
+		jobServer, mockStore, mockMetrics := makeJobServer(t)
+		jobServer.Config().JobSettings.RetryPolicies[model.JobTypeImportProcess] = &model.JobRetryPolicy{}
+		jobServer.Config().JobSettings.SetDefaults()
+		return jobServer, mockStore, mockMetrics
+	}
+
+	t.Run("failed job retried with backoff", func(t *testing.T) {
+		jobServer, mockStore, mockMetrics := makeRetryingJobServer(t)
+
+		job := &model.Job{
+			Id:         model.NewId(),
+			Type:       model.JobTypeImportProcess,
+			RootId:     model.NewId(),
+			RetryCount: 1,
+			Data:       map[string]string{"import_file": "file.zip"},
+		}
+
+		var retry *model.Job
+		mockStore.JobStore.On("UpdateOptimistically", job, model.JobStatusInProgress).Return(true, nil)
+		mockStore.JobStore.On("Save", mock.AnythingOfType("*model.Job")).Run(func(args mock.Arguments) {
+			retry = args.Get(0).(*model.Job)
+		}).Return(nil, nil).Once()
+		mockMetrics.On("DecrementJobActive", model.JobTypeImportProcess)
+
+		err := jobServer.SetJobError(job, &model.AppError{Message: "message"})
+		require.Nil(t, err)
+
+		require.NotNil(t, retry)
+		assert.Equal(t, model.JobTypeImportProcess, retry.Type)
+		assert.Equal(t, model.JobStatusPending, retry.Status)
+		assert.Equal(t, job.Id, retry.ParentId)
+		assert.Equal(t, job.RootId, retry.RootId)
+		assert.Equal(t, 2, retry.RetryCount)
+		assert.Equal(t, "file.zip", retry.Data["import_file"])
+		assert.NotContains(t, retry.Data, "error")
+		// The backoff is doubled for the second retry.
+		assert.Equal(t, int64(2*model.JobRetryPolicyDefaultBackoffSeconds*1000), retry.RunAfter-retry.CreateAt)
+	})
+
+	t.Run("no retry past the max attempts", func(t *testing.T) {
+		jobServer, mockStore, mockMetrics := makeRetryingJobServer(t)
+
+		job := &model.Job{
+			Id:         model.NewId(),
+			Type:       model.JobTypeImportProcess,
+			RetryCount: model.JobRetryPolicyDefaultMaxAttempts - 1,
+		}
+
+		mockStore.JobStore.On("UpdateStatus", job.Id, model.JobStatusError).Return(job, nil)
+		mockMetrics.On("DecrementJobActive", model.JobTypeImportProcess)
+
+		err := jobServer.SetJobError(job, nil)
+		require.Nil(t, err)
+		mockStore.JobStore.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("no retry without a retry policy", func(t *testing.T) {
+		jobServer, mockStore, mockMetrics := makeJobServer(t)
+
+		// Imports are not retried unless a policy is configured.
+		job := &model.Job{
+			Id:   model.NewId(),
+			Type: model.JobTypeImportProcess,
+		}
+
+		mockStore.JobStore.On("UpdateStatus", job.Id, model.JobStatusError).Return(job, nil)
+		mockMetrics.On("DecrementJobActive", model.JobTypeImportProcess)
+
+		err := jobServer.SetJobError(job, nil)
+		require.Nil(t, err)
+		mockStore.JobStore.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestCreateFollowUpJobs(t *testing.T) {
+	jobServer, mockStore, mockMetrics := makeJobServer(t)
+	jobServer.initWorkers()
+	worker := NewSimpleWorker(model.JobTypeExportDelete, jobServer, func(_ mlog.LoggerIFace, _ *model.Job) error { return nil }, func(_ *model.Config) bool { return true })
+	jobServer.RegisterJobType(model.JobTypeExportDelete, worker, nil)
+	jobServer.Config().JobSettings.FollowUpJobs = map[string][]string{
+		model.JobTypeExportProcess: {model.JobTypeExportDelete, "unknown_job_type"},
+	}
+
+	job := &model.Job{
+		Id:   model.NewId(),
+		Type: model.JobTypeExportProcess,
+		Data: map[string]string{"export_file": "export.zip"},
+	}
+
+	var followUp *model.Job
+	mockStore.JobStore.On("UpdateStatus", job.Id, model.JobStatusSuccess).Return(job, nil)
+	mockStore.JobStore.On("Save", mock.AnythingOfType("*model.Job")).Run(func(args mock.Arguments) {
+		followUp = args.Get(0).(*model.Job)
+	}).Return(nil, nil).Once()
+	mockMetrics.On("DecrementJobActive", model.JobTypeExportProcess)
+
+	err := jobServer.SetJobSuccess(job)
+	require.Nil(t, err)
+
+	require.NotNil(t, followUp)
+	assert.Equal(t, model.JobTypeExportDelete, followUp.Type)
+	assert.Equal(t, job.Id, followUp.ParentId)
+	assert.Equal(t, job.Id, followUp.RootId)
+	assert.Zero(t, followUp.RetryCount)
+	assert.Zero(t, followUp.RunAfter)
+	assert.Equal(t, "export.zip", followUp.Data["export_file"])
+}
+
 func TestSetJobCanceled(t *testing.T) {
 	t.Run("error setting status", func(t *testing.T) {
 		jobServer, mockStore, _ := makeJobServer(t)
@@ -74,7 +74,22 @@ func (watcher *Watcher) PollAndNotify() {
 		return
 	}
 
+	if len(jobs) == 0 {
+		return
+	}
+
+	paused, appErr := watcher.srv.GetPausedJobTypes()
+	if appErr != nil {
+		mlog.Error("Error occurred getting the paused job types.", mlog.Err(appErr))
+		return
+	}
+
+	now := model.GetMillis()
 	for _, job := range jobs {
+		if paused[job.Type] || job.RunAfter > now {
+			continue
+		}
+
 		worker := watcher.workers.Get(job.Type)
 		if worker != nil {
 			select {
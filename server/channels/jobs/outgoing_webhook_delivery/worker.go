@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package outgoing_webhook_delivery
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	ProcessPendingOutgoingWebhookDeliveries(c request.CTX) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "OutgoingWebhookDelivery"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ServiceSettings.EnableOutgoingWebhooks
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		if appErr := app.ProcessPendingOutgoingWebhookDeliveries(request.EmptyContext(logger)); appErr != nil {
+			return appErr
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
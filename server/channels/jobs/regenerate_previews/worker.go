@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package regenerate_previews
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const pageSize = 1000
+
+type AppIface interface {
+	RegenerateFilePreviews(rctx request.CTX, fileInfo *model.FileInfo) error
+}
+
+// MakeWorker creates a worker regenerating the thumbnails and previews of the images
+// uploaded between the optional "from" and "to" job data, in seconds.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *jobs.SimpleWorker {
+	const workerName = "RegeneratePreviews"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		var err error
+		// Files created after the job are left out, so that resuming it doesn't move the goalposts.
+		toTS := job.CreateAt
+		if toStr, ok := job.Data["to"]; ok {
+			if toTS, err = strconv.ParseInt(toStr, 10, 64); err != nil {
+				return err
+			}
+			toTS *= 1000
+		}
+
+		// The job resumes after the last file it went through, if any.
+		startFileID := job.Data["start_file_id"]
+		var startTime int64
+		if startStr, ok := job.Data["start_create_at"]; ok {
+			if startTime, err = strconv.ParseInt(startStr, 10, 64); err != nil {
+				return err
+			}
+		} else if fromStr, ok := job.Data["from"]; ok {
+			if startTime, err = strconv.ParseInt(fromStr, 10, 64); err != nil {
+				return err
+			}
+			startTime *= 1000
+		}
+
+		// The counts are missing when the job starts.
+		nFiles, _ := strconv.Atoi(job.Data["processed"])
+		nErrs, _ := strconv.Atoi(job.Data["errors"])
+
+		for done := false; !done; {
+			files, err := store.FileInfo().GetFilesBatchForIndexing(startTime, startFileID, false, pageSize)
+			if err != nil {
+				return err
+			}
+			done = len(files) < pageSize
+
+			for _, file := range files {
+				if file.CreateAt > toTS {
+					done = true
+					break
+				}
+				startTime, startFileID = file.CreateAt, file.Id
+				if !file.IsImage() || file.IsSvg() {
+					continue
+				}
+
+				fileInfo := file.FileInfo
+				logger.Debug("Regenerating file previews", mlog.String("filename", fileInfo.Name), mlog.String("filepath", fileInfo.Path))
+				if err = app.RegenerateFilePreviews(request.EmptyContext(logger), &fileInfo); err != nil {
+					logger.Warn("Failed to regenerate file previews", mlog.Err(err), mlog.String("file_info_id", fileInfo.Id))
+					nErrs++
+				}
+				nFiles++
+			}
+
+			job.Data["start_create_at"] = strconv.FormatInt(startTime, 10)
+			job.Data["start_file_id"] = startFileID
+			job.Data["errors"] = strconv.Itoa(nErrs)
+			job.Data["processed"] = strconv.Itoa(nFiles)
+			if err := jobServer.UpdateInProgressJobData(job); err != nil {
+				logger.Error("Worker: Failed to update job data", mlog.Err(err))
+			}
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
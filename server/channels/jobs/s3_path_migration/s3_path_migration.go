@@ -37,7 +37,7 @@ type S3PathMigrationWorker struct {
 func MakeWorker(jobServer *jobs.JobServer, store store.Store, fileBackend filestore.FileBackend) *S3PathMigrationWorker {
 	// If the type cast fails, it will be nil
 	// which is checked later.
-	s3Backend, _ := fileBackend.(*filestore.S3FileBackend)
+	s3Backend, _ := filestore.UnwrapFileBackend(fileBackend).(*filestore.S3FileBackend)
 	const workerName = "S3PathMigration"
 	worker := &S3PathMigrationWorker{
 		name:        workerName,
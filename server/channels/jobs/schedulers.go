@@ -6,6 +6,7 @@ package jobs
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -29,8 +30,12 @@ type Schedulers struct {
 	isLeader             bool
 	running              bool
 
-	schedulers   map[string]Scheduler
-	nextRunTimes map[string]*time.Time
+	schedulers map[string]Scheduler
+
+	// nextRunTimesMut is used to protect nextRunTimes from being read by
+	// GetJobSchedules while the schedulers update it.
+	nextRunTimesMut sync.RWMutex
+	nextRunTimes    map[string]*time.Time
 }
 
 var (
@@ -61,7 +66,7 @@ func (schedulers *Schedulers) Start() {
 		now := time.Now()
 		for name, scheduler := range schedulers.schedulers {
 			if !scheduler.Enabled(schedulers.jobs.Config()) {
-				schedulers.nextRunTimes[name] = nil
+				schedulers.storeNextRunTime(name, nil)
 			} else {
 				schedulers.setNextRunTime(schedulers.jobs.Config(), name, now, false)
 			}
@@ -87,6 +92,18 @@ func (schedulers *Schedulers) Start() {
 						if scheduler == nil || !schedulers.isLeader || !scheduler.Enabled(cfg) {
 							continue
 						}
+						paused, err := schedulers.jobs.IsJobTypePaused(name)
+						if err != nil {
+							mlog.Error("Failed to check if the job type is paused", mlog.String("scheduler", name), mlog.Err(err))
+							continue
+						}
+						if paused {
+							// The run is skipped rather than delayed, so that resuming the job
+							// type doesn't run it outside of its schedule.
+							mlog.Debug("Skipping the run of a paused job type", mlog.String("scheduler", name))
+							schedulers.setNextRunTime(cfg, name, now, false)
+							continue
+						}
 						c := request.EmptyContext(schedulers.jobs.Logger())
 						if _, err := schedulers.scheduleJob(c, cfg, name, scheduler); err != nil {
 							mlog.Error("Failed to schedule job", mlog.String("scheduler", name), mlog.Err(err))
@@ -98,7 +115,7 @@ func (schedulers *Schedulers) Start() {
 			case newCfg := <-schedulers.configChanged:
 				for name, scheduler := range schedulers.schedulers {
 					if !schedulers.isLeader || !scheduler.Enabled(newCfg) {
-						schedulers.nextRunTimes[name] = nil
+						schedulers.storeNextRunTime(name, nil)
 					} else {
 						schedulers.setNextRunTime(newCfg, name, now, false)
 					}
@@ -107,7 +124,7 @@ func (schedulers *Schedulers) Start() {
 				for name := range schedulers.schedulers {
 					schedulers.isLeader = isLeader
 					if !isLeader {
-						schedulers.nextRunTimes[name] = nil
+						schedulers.storeNextRunTime(name, nil)
 					} else {
 						schedulers.setNextRunTime(schedulers.jobs.Config(), name, now, false)
 					}
@@ -132,27 +149,74 @@ func (schedulers *Schedulers) Stop() {
 }
 
 func (schedulers *Schedulers) setNextRunTime(cfg *model.Config, name string, now time.Time, pendingJobs bool) {
-	scheduler := schedulers.schedulers[name]
+	nextTime, err := schedulers.nextRunTime(cfg, name, now, pendingJobs)
+	if err != nil {
+		mlog.Error("Failed to set next job run time", mlog.Err(err))
+		schedulers.storeNextRunTime(name, nil)
+		return
+	}
+
+	schedulers.storeNextRunTime(name, nextTime)
+	mlog.Debug("Next run time for scheduler", mlog.String("scheduler_name", name), mlog.String("next_runtime", fmt.Sprintf("%v", nextTime)))
+}
+
+// nextRunTime returns the time at which the scheduler should next create a job. The cron
+// schedule set for the job type in JobSettings.CronSchedules takes precedence over the
+// default schedule of the scheduler.
+func (schedulers *Schedulers) nextRunTime(cfg *model.Config, name string, now time.Time, pendingJobs bool) (*time.Time, *model.AppError) {
+	if spec := cronScheduleFor(cfg, name); spec != "" {
+		schedule, err := ParseCronSchedule(spec)
+		if err == nil {
+			nextTime := schedule.Next(now)
+			return &nextTime, nil
+		}
+		mlog.Error("Invalid cron schedule, falling back to the default schedule", mlog.String("scheduler_name", name), mlog.String("cron_schedule", spec), mlog.Err(err))
+	}
 
 	if !pendingJobs {
 		pj, err := schedulers.jobs.CheckForPendingJobsByType(name)
 		if err != nil {
-			mlog.Error("Failed to set next job run time", mlog.Err(err))
-			schedulers.nextRunTimes[name] = nil
-			return
+			return nil, err
 		}
 		pendingJobs = pj
 	}
 
 	lastSuccessfulJob, err := schedulers.jobs.GetLastSuccessfulJobByType(name)
 	if err != nil {
-		mlog.Error("Failed to set next job run time", mlog.Err(err))
-		schedulers.nextRunTimes[name] = nil
-		return
+		return nil, err
+	}
+
+	return schedulers.schedulers[name].NextScheduleTime(cfg, now, pendingJobs, lastSuccessfulJob), nil
+}
+
+func cronScheduleFor(cfg *model.Config, name string) string {
+	if cfg == nil {
+		return ""
+	}
+	return cfg.JobSettings.CronSchedules[name]
+}
+
+// hasCronSchedule reports whether the job type runs on a valid cron schedule rather
+// than on the default schedule of its scheduler.
+func hasCronSchedule(cfg *model.Config, name string) bool {
+	spec := cronScheduleFor(cfg, name)
+	if spec == "" {
+		return false
 	}
+	_, err := ParseCronSchedule(spec)
+	return err == nil
+}
 
-	schedulers.nextRunTimes[name] = scheduler.NextScheduleTime(cfg, now, pendingJobs, lastSuccessfulJob)
-	mlog.Debug("Next run time for scheduler", mlog.String("scheduler_name", name), mlog.String("next_runtime", fmt.Sprintf("%v", schedulers.nextRunTimes[name])))
+func (schedulers *Schedulers) storeNextRunTime(name string, nextTime *time.Time) {
+	schedulers.nextRunTimesMut.Lock()
+	defer schedulers.nextRunTimesMut.Unlock()
+	schedulers.nextRunTimes[name] = nextTime
+}
+
+func (schedulers *Schedulers) getNextRunTime(name string) *time.Time {
+	schedulers.nextRunTimesMut.RLock()
+	defer schedulers.nextRunTimesMut.RUnlock()
+	return schedulers.nextRunTimes[name]
 }
 
 func (schedulers *Schedulers) scheduleJob(c request.CTX, cfg *model.Config, name string, scheduler Scheduler) (*model.Job, *model.AppError) {
@@ -161,6 +225,13 @@ func (schedulers *Schedulers) scheduleJob(c request.CTX, cfg *model.Config, name
 		return nil, err
 	}
 
+	// The scheduler isn't asked for the run times of a cron schedule, so the run is
+	// skipped here while the previous jobs of the type are still pending.
+	if pendingJobs && hasCronSchedule(cfg, name) {
+		mlog.Debug("Skipping the cron run of a job type with pending jobs", mlog.String("scheduler", name))
+		return nil, nil
+	}
+
 	lastSuccessfulJob, err2 := schedulers.jobs.GetLastSuccessfulJobByType(name)
 	if err2 != nil {
 		return nil, err
@@ -34,6 +34,16 @@ func (scheduler *MockScheduler) ScheduleJob(c request.CTX, cfg *model.Config, pe
 	return nil, nil
 }
 
+type countingScheduler struct {
+	MockScheduler
+	scheduled int
+}
+
+func (scheduler *countingScheduler) ScheduleJob(c request.CTX, cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	scheduler.scheduled++
+	return nil, nil
+}
+
 func TestScheduler(t *testing.T) {
 	mockStore := &storetest.Store{}
 	defer mockStore.AssertExpectations(t)
@@ -153,3 +163,86 @@ func TestRandomDelay(t *testing.T) {
 		require.Less(t, out.Milliseconds(), c)
 	}
 }
+
+func TestNextRunTimeWithCronSchedule(t *testing.T) {
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.JobSettings.CronSchedules = map[string]string{
+		model.JobTypeMessageExport: "30 2 * * *",
+		model.JobTypeDataRetention: "not a cron expression",
+	}
+
+	jobServer := &JobServer{
+		Store:         mockStore,
+		ConfigService: &testutils.StaticConfigService{Cfg: cfg},
+	}
+	jobServer.initSchedulers()
+	jobServer.RegisterJobType(model.JobTypeDataRetention, nil, new(MockScheduler))
+	jobServer.RegisterJobType(model.JobTypeMessageExport, nil, new(MockScheduler))
+	jobServer.RegisterJobType(model.JobTypeLdapSync, nil, new(MockScheduler))
+
+	t.Run("cron schedule", func(t *testing.T) {
+		now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.Local)
+		nextTime, appErr := jobServer.schedulers.nextRunTime(cfg, model.JobTypeMessageExport, now, false)
+		require.Nil(t, appErr)
+		require.NotNil(t, nextTime)
+		assert.Equal(t, time.Date(2024, time.March, 11, 2, 30, 0, 0, time.Local), *nextTime)
+	})
+
+	t.Run("invalid cron schedule falls back to the default schedule", func(t *testing.T) {
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, model.JobTypeDataRetention).Return(int64(0), nil).Once()
+		mockStore.JobStore.On("GetNewestJobByStatusesAndType", []string{model.JobStatusSuccess}, model.JobTypeDataRetention).Return(nil, nil).Once()
+
+		nextTime, appErr := jobServer.schedulers.nextRunTime(cfg, model.JobTypeDataRetention, time.Now(), false)
+		require.Nil(t, appErr)
+		require.NotNil(t, nextTime)
+		assert.WithinDuration(t, time.Now().Add(60*time.Second), *nextTime, 5*time.Second)
+	})
+
+	t.Run("cron run is skipped while jobs are pending", func(t *testing.T) {
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, model.JobTypeMessageExport).Return(int64(1), nil).Once()
+
+		scheduler := &countingScheduler{}
+		_, appErr := jobServer.schedulers.scheduleJob(request.TestContext(t), cfg, model.JobTypeMessageExport, scheduler)
+		require.Nil(t, appErr)
+		assert.Zero(t, scheduler.scheduled)
+	})
+
+	t.Run("default schedule leaves pending jobs to the scheduler", func(t *testing.T) {
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, model.JobTypeLdapSync).Return(int64(1), nil).Once()
+		mockStore.JobStore.On("GetNewestJobByStatusesAndType", []string{model.JobStatusSuccess}, model.JobTypeLdapSync).Return(nil, nil).Once()
+
+		scheduler := &countingScheduler{}
+		_, appErr := jobServer.schedulers.scheduleJob(request.TestContext(t), cfg, model.JobTypeLdapSync, scheduler)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, scheduler.scheduled)
+	})
+
+	t.Run("job schedules", func(t *testing.T) {
+		mockStore.SystemStore.On("Get").Return(model.StringMap{
+			model.SystemJobTypePausedPrefix + model.JobTypeLdapSync: "true",
+			model.SystemInstallationDateKey:                         "1",
+		}, nil).Once()
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, mock.AnythingOfType("string")).Return(int64(0), nil)
+		mockStore.JobStore.On("GetNewestJobByStatusesAndType", []string{model.JobStatusSuccess}, mock.AnythingOfType("string")).Return(nil, nil)
+
+		schedules, appErr := jobServer.GetJobSchedules()
+		require.Nil(t, appErr)
+		require.Len(t, schedules, 3)
+
+		assert.Equal(t, model.JobTypeDataRetention, schedules[0].JobType)
+		assert.False(t, schedules[0].Paused)
+		assert.Equal(t, model.JobTypeLdapSync, schedules[1].JobType)
+		assert.True(t, schedules[1].Paused)
+		assert.Empty(t, schedules[1].CronSchedule)
+		assert.Equal(t, model.JobTypeMessageExport, schedules[2].JobType)
+		assert.Equal(t, "30 2 * * *", schedules[2].CronSchedule)
+		for _, schedule := range schedules {
+			assert.True(t, schedule.Enabled)
+			assert.NotZero(t, schedule.NextRunTime)
+		}
+	})
+}
@@ -4,6 +4,7 @@
 package jobs
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -131,3 +132,51 @@ func (srv *JobServer) HandleClusterLeaderChange(isLeader bool) {
 		srv.schedulers.handleClusterLeaderChange(isLeader)
 	}
 }
+
+// GetJobSchedules returns the schedule of each job type with a scheduler, sorted by job
+// type. The next run times are kept by the node running the schedulers, and computed
+// when missing, such as on the other nodes of a cluster.
+func (srv *JobServer) GetJobSchedules() ([]*model.JobSchedule, *model.AppError) {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+	if srv.schedulers == nil {
+		return nil, nil
+	}
+
+	paused, appErr := srv.GetPausedJobTypes()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	cfg := srv.Config()
+	now := time.Now()
+	schedules := make([]*model.JobSchedule, 0, len(srv.schedulers.schedulers))
+	for name, scheduler := range srv.schedulers.schedulers {
+		schedule := &model.JobSchedule{
+			JobType:      name,
+			Enabled:      scheduler.Enabled(cfg),
+			Paused:       paused[name],
+			CronSchedule: cronScheduleFor(cfg, name),
+		}
+
+		if schedule.Enabled {
+			nextTime := srv.schedulers.getNextRunTime(name)
+			if nextTime == nil {
+				if nextTime, appErr = srv.schedulers.nextRunTime(cfg, name, now, false); appErr != nil {
+					return nil, appErr
+				}
+			}
+			if nextTime != nil {
+				schedule.NextRunTime = nextTime.UnixMilli()
+			}
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].JobType < schedules[j].JobType
+	})
+
+	return schedules, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package team_activity_report
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+)
+
+type TeamActivityReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate team activity reports.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app TeamActivityReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		model.ReportFormatCSV,
+		[]string{
+			"Id",
+			"Name",
+			"DisplayName",
+			"Type",
+			"Members",
+			"Channels",
+			"Posts",
+			"ActiveUsers",
+			"LastPostAt",
+		},
+		getData(app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next.
+func parseJobMetadata(data model.StringMap) (*model.TeamActivityReportOptions, error) {
+	startAt, err := strconv.ParseInt(data["start_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	endAt, err := strconv.ParseInt(data["end_at"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TeamActivityReportOptions{
+		ReportingBaseOptions: model.ReportingBaseOptions{
+			PageSize: 100,
+			FromId:   data["last_team_id"],
+			StartAt:  startAt,
+			EndAt:    endAt,
+		},
+	}, nil
+}
+
+// makeJobMetadata encodes the information needed to decide which batch to process next back into
+// the opaque job metadata.
+func makeJobMetadata(jobData model.StringMap, teamID string) model.StringMap {
+	jobData["last_team_id"] = teamID
+	return jobData
+}
+
+func getData(app TeamActivityReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		filter, err := parseJobMetadata(jobData)
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "failed to parse job metadata")
+		}
+
+		teams, appErr := app.GetTeamActivityReport(filter)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (team_id=%v)", filter.FromId)
+		}
+
+		if len(teams) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, 0, len(teams))
+		for _, team := range teams {
+			reportableObjects = append(reportableObjects, team)
+		}
+
+		return reportableObjects, makeJobMetadata(jobData, teams[len(teams)-1].TeamId), false, nil
+	}
+}
@@ -24,6 +24,8 @@ const mySQLDeadlockCode = uint16(1213)
 type RetryLayer struct {
 	store.Store
 	AuditStore                      store.AuditStore
+	AuditRecordStore                store.AuditRecordStore
+	BatchedNotificationStore        store.BatchedNotificationStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
@@ -40,6 +42,8 @@ type RetryLayer struct {
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
+	MfaResetRequestStore            store.MfaResetRequestStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
@@ -54,6 +58,7 @@ type RetryLayer struct {
 	RemoteClusterStore              store.RemoteClusterStore
 	RetentionPolicyStore            store.RetentionPolicyStore
 	RoleStore                       store.RoleStore
+	ScheduledPostStore              store.ScheduledPostStore
 	SchemeStore                     store.SchemeStore
 	SessionStore                    store.SessionStore
 	SharedChannelStore              store.SharedChannelStore
@@ -67,6 +72,7 @@ type RetryLayer struct {
 	UserStore                       store.UserStore
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -74,6 +80,14 @@ func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *RetryLayer) AuditRecord() store.AuditRecordStore {
+	return s.AuditRecordStore
+}
+
+func (s *RetryLayer) BatchedNotification() store.BatchedNotificationStore {
+	return s.BatchedNotificationStore
+}
+
 func (s *RetryLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -138,6 +152,14 @@ func (s *RetryLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *RetryLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
+func (s *RetryLayer) MfaResetRequest() store.MfaResetRequestStore {
+	return s.MfaResetRequestStore
+}
+
 func (s *RetryLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -194,6 +216,10 @@ func (s *RetryLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *RetryLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
 func (s *RetryLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -246,6 +272,10 @@ func (s *RetryLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
 
+func (s *RetryLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *RetryLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -255,6 +285,16 @@ type RetryLayerAuditStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerAuditRecordStore struct {
+	store.AuditRecordStore
+	Root *RetryLayer
+}
+
+type RetryLayerBatchedNotificationStore struct {
+	store.BatchedNotificationStore
+	Root *RetryLayer
+}
+
 type RetryLayerBotStore struct {
 	store.BotStore
 	Root *RetryLayer
@@ -335,6 +375,16 @@ type RetryLayerLinkMetadataStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *RetryLayer
+}
+
+type RetryLayerMfaResetRequestStore struct {
+	store.MfaResetRequestStore
+	Root *RetryLayer
+}
+
 type RetryLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *RetryLayer
@@ -405,6 +455,11 @@ type RetryLayerRoleStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *RetryLayer
+}
+
 type RetryLayerSchemeStore struct {
 	store.SchemeStore
 	Root *RetryLayer
@@ -470,6 +525,11 @@ type RetryLayerUserTermsOfServiceStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *RetryLayer
+}
+
 type RetryLayerWebhookStore struct {
 	store.WebhookStore
 	Root *RetryLayer
@@ -554,6 +614,195 @@ func (s *RetryLayerAuditStore) Save(audit *model.Audit) error {
 
 }
 
+func (s *RetryLayerAuditRecordStore) GetAll(filter *model.AuditRecordFilter) ([]*model.AuditRecord, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.GetAll(filter)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) GetCheckpoints(chainID string, offset int, limit int) ([]*model.AuditCheckpoint, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.GetCheckpoints(chainID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) Save(record *model.AuditRecord) (*model.AuditRecord, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.Save(record)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) SaveCheckpoint(checkpoint *model.AuditCheckpoint) (*model.AuditCheckpoint, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.SaveCheckpoint(checkpoint)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerBatchedNotificationStore) Delete(ids []string) error {
+
+	tries := 0
+	for {
+		err := s.BatchedNotificationStore.Delete(ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerBatchedNotificationStore) GetForUser(userID string) ([]*model.BatchedNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.BatchedNotificationStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerBatchedNotificationStore) GetUsersWithPending(afterUserID string, limit int) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.BatchedNotificationStore.GetUsersWithPending(afterUserID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerBatchedNotificationStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.BatchedNotificationStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerBatchedNotificationStore) Save(notification *model.BatchedNotification) (*model.BatchedNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.BatchedNotificationStore.Save(notification)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
 
 	tries := 0
@@ -1343,6 +1592,27 @@ func (s *RetryLayerChannelStore) GetChannelMembersTimezones(channelID string) ([
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelMembershipReport(filter)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 
 	tries := 0
@@ -3176,11 +3446,11 @@ func (s *RetryLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
+func (s *RetryLayerChannelMemberHistoryStore) GetMembersLeftSince(teamID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
+		result, err := s.ChannelMemberHistoryStore.GetMembersLeftSince(teamID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -3197,76 +3467,118 @@ func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
+func (s *RetryLayerChannelMemberHistoryStore) GetMembershipChangesSince(channelID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
 
 	tries := 0
 	for {
-		err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
+		result, err := s.ChannelMemberHistoryStore.GetMembershipChangesSince(channelID, since)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
+func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
 
 	tries := 0
 	for {
-		err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
+		result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+		err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		if err == nil {
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
 			return result, resultVar1, err
@@ -3512,6 +3824,27 @@ func (s *RetryLayerCommandStore) GetByTrigger(teamID string, trigger string) (*m
 
 }
 
+func (s *RetryLayerCommandStore) GetDeletedByTeamSince(teamID string, since int64) ([]*model.Command, error) {
+
+	tries := 0
+	for {
+		result, err := s.CommandStore.GetDeletedByTeamSince(teamID, since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
 
 	tries := 0
@@ -4343,6 +4676,27 @@ func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 
 }
 
+func (s *RetryLayerFileInfoStore) GetChannelFilesBatchForIndexing(channelID string, startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.GetChannelFilesBatchForIndexing(channelID, startTime, startFileID, includeDeleted, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 
 	tries := 0
@@ -5840,6 +6194,27 @@ func (s *RetryLayerJobStore) Get(c request.CTX, id string) (*model.Job, error) {
 
 }
 
+func (s *RetryLayerJobStore) GetAllByRootId(c request.CTX, rootID string) ([]*model.Job, error) {
+
+	tries := 0
+	for {
+		result, err := s.JobStore.GetAllByRootId(c, rootID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerJobStore) GetAllByStatus(c request.CTX, status string) ([]*model.Job, error) {
 
 	tries := 0
@@ -6239,32 +6614,11 @@ func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 
 }
 
-func (s *RetryLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
-
-	tries := 0
-	for {
-		err := s.NotifyAdminStore.DeleteBefore(trial, now)
-		if err == nil {
-			return nil
-		}
-		if !isRepeatableError(err) {
-			return err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
-
-}
-
-func (s *RetryLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
+func (s *RetryLayerMfaRecoveryCodeStore) GetForUser(userID string) ([]*model.MfaRecoveryCode, error) {
 
 	tries := 0
 	for {
-		result, err := s.NotifyAdminStore.Get(trial)
+		result, err := s.MfaRecoveryCodeStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -6281,53 +6635,53 @@ func (s *RetryLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData,
 
 }
 
-func (s *RetryLayerNotifyAdminStore) GetDataByUserIdAndFeature(userId string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
+func (s *RetryLayerMfaRecoveryCodeStore) MarkUsed(id string, usedAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userId, feature)
+		err := s.MfaRecoveryCodeStore.MarkUsed(id, usedAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
+func (s *RetryLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.NotifyAdminStore.Save(data)
+		err := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerNotifyAdminStore) Update(userId string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
+func (s *RetryLayerMfaRecoveryCodeStore) SaveForUser(userID string, codes []*model.MfaRecoveryCode) error {
 
 	tries := 0
 	for {
-		err := s.NotifyAdminStore.Update(userId, requiredPlan, requiredFeature, now)
+		err := s.MfaRecoveryCodeStore.SaveForUser(userID, codes)
 		if err == nil {
 			return nil
 		}
@@ -6344,32 +6698,32 @@ func (s *RetryLayerNotifyAdminStore) Update(userId string, requiredPlan string,
 
 }
 
-func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
+func (s *RetryLayerMfaResetRequestStore) Get(id string) (*model.MfaResetRequest, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.DeleteApp(id)
+		result, err := s.MfaResetRequestStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+func (s *RetryLayerMfaResetRequestStore) GetAll(status string, offset int, limit int) ([]*model.MfaResetRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessData(token)
+		result, err := s.MfaResetRequestStore.GetAll(status, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6386,11 +6740,11 @@ func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, e
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+func (s *RetryLayerMfaResetRequestStore) GetPendingForUser(userID string) (*model.MfaResetRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+		result, err := s.MfaResetRequestStore.GetPendingForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -6407,53 +6761,53 @@ func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
+func (s *RetryLayerMfaResetRequestStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
+		err := s.MfaResetRequestStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+func (s *RetryLayerMfaResetRequestStore) Resolve(id string, status string, resolvedBy string, updateAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApp(id)
+		err := s.MfaResetRequestStore.Resolve(id, status, resolvedBy, updateAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerMfaResetRequestStore) Save(request *model.MfaResetRequest) (*model.MfaResetRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
+		result, err := s.MfaResetRequestStore.Save(request)
 		if err == nil {
 			return result, nil
 		}
@@ -6470,32 +6824,32 @@ func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int
 
 }
 
-func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApps(offset, limit)
+		err := s.NotifyAdminStore.DeleteBefore(trial, now)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+func (s *RetryLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthData(code)
+		result, err := s.NotifyAdminStore.Get(trial)
 		if err == nil {
 			return result, nil
 		}
@@ -6512,11 +6866,11 @@ func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error)
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerNotifyAdminStore) GetDataByUserIdAndFeature(userId string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
+		result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userId, feature)
 		if err == nil {
 			return result, nil
 		}
@@ -6533,11 +6887,11 @@ func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limi
 
 }
 
-func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
+func (s *RetryLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
+		result, err := s.NotifyAdminStore.Save(data)
 		if err == nil {
 			return result, nil
 		}
@@ -6554,11 +6908,11 @@ func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId str
 
 }
 
-func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
+func (s *RetryLayerNotifyAdminStore) Update(userId string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
+		err := s.NotifyAdminStore.Update(userId, requiredPlan, requiredFeature, now)
 		if err == nil {
 			return nil
 		}
@@ -6575,11 +6929,11 @@ func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) erro
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
+func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAccessData(token)
+		err := s.OAuthStore.DeleteApp(id)
 		if err == nil {
 			return nil
 		}
@@ -6596,95 +6950,95 @@ func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
+func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAllAccessData()
+		result, err := s.OAuthStore.GetAccessData(token)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
+func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAuthData(code)
+		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAuthDataByClientId(clientId string, userId string) error {
+func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAuthDataByClientId(clientId, userId)
+		result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAuthDataByUserId(userId string) error {
+func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAuthDataByUserId(userId)
+		result, err := s.OAuthStore.GetApp(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAccessData(accessData)
+		result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6701,11 +7055,11 @@ func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 
 }
 
-func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveApp(app)
+		result, err := s.OAuthStore.GetApps(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6722,11 +7076,11 @@ func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAuthData(authData)
+		result, err := s.OAuthStore.GetAuthData(code)
 		if err == nil {
 			return result, nil
 		}
@@ -6743,11 +7097,11 @@ func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateAccessData(accessData)
+		result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6764,11 +7118,11 @@ func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateApp(app)
+		result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
 		if err == nil {
 			return result, nil
 		}
@@ -6785,11 +7139,11 @@ func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
+func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
+		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
 		if err == nil {
 			return nil
 		}
@@ -6806,116 +7160,116 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX,
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
+		err := s.OAuthStore.RemoveAccessData(token)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
+		err := s.OAuthStore.RemoveAllAccessData()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
+		err := s.OAuthStore.RemoveAuthData(code)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOAuthStore) RemoveAuthDataByClientId(clientId string, userId string) error {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
+		err := s.OAuthStore.RemoveAuthDataByClientId(clientId, userId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerOAuthStore) RemoveAuthDataByUserId(userId string) error {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		err := s.OAuthStore.RemoveAuthDataByUserId(userId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		result, err := s.OAuthStore.SaveAccessData(accessData)
 		if err == nil {
 			return result, nil
 		}
@@ -6932,74 +7286,74 @@ func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 
 }
 
-func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.Delete(pluginID, key)
+		result, err := s.OAuthStore.SaveApp(app)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllExpired() error {
+func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllExpired()
+		result, err := s.OAuthStore.SaveAuthData(authData)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllForPlugin(PluginID)
+		result, err := s.OAuthStore.UpdateAccessData(accessData)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.Get(pluginID, key)
+		result, err := s.OAuthStore.UpdateApp(app)
 		if err == nil {
 			return result, nil
 		}
@@ -7016,32 +7370,32 @@ func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 
 }
 
-func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.List(pluginID, page, perPage)
+		err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SaveOrUpdate(keyVal)
+		result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
 		if err == nil {
 			return result, nil
 		}
@@ -7058,11 +7412,11 @@ func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 
 }
 
-func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+		result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
 		if err == nil {
 			return result, nil
 		}
@@ -7079,11 +7433,11 @@ func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCount(options)
+		result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
 		if err == nil {
 			return result, nil
 		}
@@ -7100,11 +7454,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
 		if err == nil {
 			return result, nil
 		}
@@ -7121,11 +7475,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 		if err == nil {
 			return result, nil
 		}
@@ -7142,107 +7496,95 @@ func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (
 
 }
 
-func (s *RetryLayerPostStore) ClearCaches() {
-
-	s.PostStore.ClearCaches()
-
-}
-
-func (s *RetryLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
+		err := s.PluginStore.Delete(pluginID, key)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerPluginStore) DeleteAllExpired() error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		err := s.PluginStore.DeleteAllExpired()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetEditHistoryForPost(postId string) ([]*model.Post, error) {
+func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetEditHistoryForPost(postId)
+		err := s.PluginStore.DeleteAllForPlugin(PluginID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
-
-	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
-
-}
-
-func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+		result, err := s.PluginStore.Get(pluginID, key)
 		if err == nil {
 			return result, nil
 		}
@@ -7259,11 +7601,11 @@ func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit i
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+		result, err := s.PluginStore.List(pluginID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -7280,11 +7622,11 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+		result, err := s.PluginStore.SaveOrUpdate(keyVal)
 		if err == nil {
 			return result, nil
 		}
@@ -7301,17 +7643,11 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 
 }
 
-func (s *RetryLayerPostStore) GetMaxPostSize() int {
-
-	return s.PostStore.GetMaxPostSize()
-
-}
-
-func (s *RetryLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
+func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetNthRecentPostTime(n)
+		result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
 		if err == nil {
 			return result, nil
 		}
@@ -7328,11 +7664,11 @@ func (s *RetryLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldest()
+		result, err := s.PostStore.AnalyticsPostCount(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7349,11 +7685,11 @@ func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldestEntityCreationTime()
+		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7370,11 +7706,11 @@ func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -7391,32 +7727,38 @@ func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string
 
 }
 
-func (s *RetryLayerPostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
+func (s *RetryLayerPostStore) ClearCaches() {
+
+	s.PostStore.ClearCaches()
+
+}
+
+func (s *RetryLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostAfterTime(channelID, timestamp, collapsedThreads)
+		err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdAfterTime(channelID, timestamp, collapsedThreads)
+		result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -7433,11 +7775,11 @@ func (s *RetryLayerPostStore) GetPostIdAfterTime(channelID string, timestamp int
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+func (s *RetryLayerPostStore) GetChannelPostsBatchForIndexing(channelID string, startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdBeforeTime(channelID, timestamp, collapsedThreads)
+		result, err := s.PostStore.GetChannelPostsBatchForIndexing(channelID, startTime, startPostID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7454,11 +7796,11 @@ func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelID string, timestamp in
 
 }
 
-func (s *RetryLayerPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
+func (s *RetryLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.DeletedPostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostReminderMetadata(postID)
+		result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -7475,11 +7817,11 @@ func (s *RetryLayerPostStore) GetPostReminderMetadata(postID string) (*store.Pos
 
 }
 
-func (s *RetryLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostReminders(now)
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, since, includeArchivedChannels)
 		if err == nil {
 			return result, nil
 		}
@@ -7496,11 +7838,11 @@ func (s *RetryLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 
 }
 
-func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetEditHistoryForPost(postId string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPosts(options, allowFromCache, sanitizeOptions)
+		result, err := s.PostStore.GetEditHistoryForPost(postId)
 		if err == nil {
 			return result, nil
 		}
@@ -7517,11 +7859,17 @@ func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 
 }
 
-func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+
+	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsAfter(options, sanitizeOptions)
+		result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7538,11 +7886,11 @@ func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions, sanit
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+		result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7559,11 +7907,11 @@ func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPos
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBefore(options, sanitizeOptions)
+		result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7580,11 +7928,17 @@ func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sani
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetMaxPostSize() int {
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *RetryLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByIds(postIds)
+		result, err := s.PostStore.GetNthRecentPostTime(n)
 		if err == nil {
 			return result, nil
 		}
@@ -7601,11 +7955,11 @@ func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, er
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByThread(threadID string, since int64) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByThread(threadID, since)
+		result, err := s.PostStore.GetOldest()
 		if err == nil {
 			return result, nil
 		}
@@ -7622,11 +7976,11 @@ func (s *RetryLayerPostStore) GetPostsByThread(threadID string, since int64) ([]
 
 }
 
-func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
+		result, err := s.PostStore.GetOldestEntityCreationTime()
 		if err == nil {
 			return result, nil
 		}
@@ -7643,11 +7997,11 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.PostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, since, includeArchivedChannels)
 		if err == nil {
 			return result, nil
 		}
@@ -7664,32 +8018,32 @@ func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+func (s *RetryLayerPostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+		result, err := s.PostStore.GetPostAfterTime(channelID, timestamp, collapsedThreads)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+func (s *RetryLayerPostStore) GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRepliesForExport(parentID)
+		result, err := s.PostStore.GetPostIdAfterTime(channelID, timestamp, collapsedThreads)
 		if err == nil {
 			return result, nil
 		}
@@ -7706,11 +8060,11 @@ func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.Rep
 
 }
 
-func (s *RetryLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
+		result, err := s.PostStore.GetPostIdBeforeTime(channelID, timestamp, collapsedThreads)
 		if err == nil {
 			return result, nil
 		}
@@ -7727,11 +8081,11 @@ func (s *RetryLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted
 
 }
 
-func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
+func (s *RetryLayerPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
+		result, err := s.PostStore.GetPostReminderMetadata(postID)
 		if err == nil {
 			return result, nil
 		}
@@ -7748,17 +8102,11 @@ func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPo
 
 }
 
-func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
-
-	s.PostStore.InvalidateLastPostTimeCache(channelID)
-
-}
-
-func (s *RetryLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Overwrite(rctx, post)
+		result, err := s.PostStore.GetPostReminders(now)
 		if err == nil {
 			return result, nil
 		}
@@ -7775,32 +8123,32 @@ func (s *RetryLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*mo
 
 }
 
-func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		result, err := s.PostStore.GetPosts(options, allowFromCache, sanitizeOptions)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.PostStore.GetPostsAfter(options, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -7817,74 +8165,74 @@ func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
+		result, err := s.PostStore.GetPostsBefore(options, sanitizeOptions)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByUser(rctx, userID)
+		result, err := s.PostStore.GetPostsByIds(postIds)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsByThread(threadID string, since int64) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Save(rctx, post)
+		result, err := s.PostStore.GetPostsByThread(threadID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -7901,32 +8249,32 @@ func (s *RetryLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.P
 
 }
 
-func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Search(teamID, userID, params)
+		result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -7943,53 +8291,53 @@ func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model
 
 }
 
-func (s *RetryLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
+		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) SetPostReminder(reminder *model.PostReminder) error {
+func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.SetPostReminder(reminder)
+		result, err := s.PostStore.GetRepliesForExport(parentID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Update(rctx, newPost, oldPost)
+		result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -8006,32 +8354,38 @@ func (s *RetryLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldP
 
 }
 
-func (s *RetryLayerPostAcknowledgementStore) Delete(acknowledgement *model.PostAcknowledgement) error {
+func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.PostAcknowledgementStore.Delete(acknowledgement)
+		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostAcknowledgementStore) Get(postID string, userID string) (*model.PostAcknowledgement, error) {
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
+
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
+
+}
+
+func (s *RetryLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostAcknowledgementStore.Get(postID, userID)
+		result, err := s.PostStore.Overwrite(rctx, post)
 		if err == nil {
 			return result, nil
 		}
@@ -8048,32 +8402,32 @@ func (s *RetryLayerPostAcknowledgementStore) Get(postID string, userID string) (
 
 }
 
-func (s *RetryLayerPostAcknowledgementStore) GetForPost(postID string) ([]*model.PostAcknowledgement, error) {
+func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostAcknowledgementStore.GetForPost(postID)
+		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, error) {
+func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostAcknowledgementStore.GetForPosts(postIds)
+		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8090,32 +8444,32 @@ func (s *RetryLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*m
 
 }
 
-func (s *RetryLayerPostAcknowledgementStore) Save(postID string, userID string, acknowledgedAt int64) (*model.PostAcknowledgement, error) {
+func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostAcknowledgementStore.Save(postID, userID, acknowledgedAt)
+		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) Delete(postIds []string) error {
+func (s *RetryLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.Delete(postIds)
+		err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
 		if err == nil {
 			return nil
 		}
@@ -8132,11 +8486,11 @@ func (s *RetryLayerPostPersistentNotificationStore) Delete(postIds []string) err
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) DeleteByChannel(channelIds []string) error {
+func (s *RetryLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.DeleteByChannel(channelIds)
+		err := s.PostStore.PermanentDeleteByUser(rctx, userID)
 		if err == nil {
 			return nil
 		}
@@ -8153,53 +8507,53 @@ func (s *RetryLayerPostPersistentNotificationStore) DeleteByChannel(channelIds [
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []string) error {
+func (s *RetryLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.DeleteByTeam(teamIds)
+		result, err := s.PostStore.Save(rctx, post)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
+		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
 		if err == nil {
-			return nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error) {
+func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostPersistentNotificationStore.Get(params)
+		result, err := s.PostStore.Search(teamID, userID, params)
 		if err == nil {
 			return result, nil
 		}
@@ -8216,11 +8570,11 @@ func (s *RetryLayerPostPersistentNotificationStore) Get(params model.GetPersiste
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
+func (s *RetryLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostPersistentNotificationStore.GetSingle(postID)
+		result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -8237,11 +8591,11 @@ func (s *RetryLayerPostPersistentNotificationStore) GetSingle(postID string) (*m
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
+func (s *RetryLayerPostStore) SetPostReminder(reminder *model.PostReminder) error {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.UpdateLastActivity(postIds)
+		err := s.PostStore.SetPostReminder(reminder)
 		if err == nil {
 			return nil
 		}
@@ -8258,11 +8612,11 @@ func (s *RetryLayerPostPersistentNotificationStore) UpdateLastActivity(postIds [
 
 }
 
-func (s *RetryLayerPostPriorityStore) GetForPost(postId string) (*model.PostPriority, error) {
+func (s *RetryLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostPriorityStore.GetForPost(postId)
+		result, err := s.PostStore.Update(rctx, newPost, oldPost)
 		if err == nil {
 			return result, nil
 		}
@@ -8279,32 +8633,32 @@ func (s *RetryLayerPostPriorityStore) GetForPost(postId string) (*model.PostPrio
 
 }
 
-func (s *RetryLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.PostPriority, error) {
+func (s *RetryLayerPostAcknowledgementStore) Delete(acknowledgement *model.PostAcknowledgement) error {
 
 	tries := 0
 	for {
-		result, err := s.PostPriorityStore.GetForPosts(ids)
+		err := s.PostAcknowledgementStore.Delete(acknowledgement)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *RetryLayerPostAcknowledgementStore) Get(postID string, userID string) (*model.PostAcknowledgement, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		result, err := s.PostAcknowledgementStore.Get(postID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -8321,76 +8675,34 @@ func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 
 }
 
-func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
+func (s *RetryLayerPostAcknowledgementStore) GetForPost(postID string) ([]*model.PostAcknowledgement, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Delete(userID, category, name)
+		result, err := s.PostAcknowledgementStore.GetForPost(postID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+func (s *RetryLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategory(userID, category)
+		result, err := s.PostAcknowledgementStore.GetForPosts(postIds)
 		if err == nil {
-			return nil
-		}
-		if !isRepeatableError(err) {
-			return err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
-
-}
-
-func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
-
-	tries := 0
-	for {
-		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
-		if err == nil {
-			return nil
-		}
-		if !isRepeatableError(err) {
-			return err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
-
-}
-
-func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
-
-	tries := 0
-	for {
-		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
-		if err == nil {
-			return result, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
 			return result, err
@@ -8405,11 +8717,11 @@ func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error)
 
 }
 
-func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+func (s *RetryLayerPostAcknowledgementStore) Save(postID string, userID string, acknowledgedAt int64) (*model.PostAcknowledgement, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.Get(userID, category, name)
+		result, err := s.PostAcknowledgementStore.Save(postID, userID, acknowledgedAt)
 		if err == nil {
 			return result, nil
 		}
@@ -8426,74 +8738,74 @@ func (s *RetryLayerPreferenceStore) Get(userID string, category string, name str
 
 }
 
-func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *RetryLayerPostPersistentNotificationStore) Delete(postIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetAll(userID)
+		err := s.PostPersistentNotificationStore.Delete(postIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *RetryLayerPostPersistentNotificationStore) DeleteByChannel(channelIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategory(userID, category)
+		err := s.PostPersistentNotificationStore.DeleteByChannel(channelIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, nane string) (model.Preferences, error) {
+func (s *RetryLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategoryAndName(category, nane)
+		err := s.PostPersistentNotificationStore.DeleteByTeam(teamIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.PermanentDeleteByUser(userID)
+		err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
 		if err == nil {
 			return nil
 		}
@@ -8510,53 +8822,53 @@ func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 
 }
 
-func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *RetryLayerPostPersistentNotificationStore) Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Save(preferences)
+		result, err := s.PostPersistentNotificationStore.Get(params)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+func (s *RetryLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.Clear(notices)
+		result, err := s.PostPersistentNotificationStore.GetSingle(postID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+func (s *RetryLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		err := s.PostPersistentNotificationStore.UpdateLastActivity(postIds)
 		if err == nil {
 			return nil
 		}
@@ -8573,11 +8885,11 @@ func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.Pro
 
 }
 
-func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+func (s *RetryLayerPostPriorityStore) GetForPost(postId string) (*model.PostPriority, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductNoticesStore.GetViews(userID)
+		result, err := s.PostPriorityStore.GetForPost(postId)
 		if err == nil {
 			return result, nil
 		}
@@ -8594,32 +8906,32 @@ func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.Product
 
 }
 
-func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
+func (s *RetryLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.PostPriority, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.View(userID, notices)
+		result, err := s.PostPriorityStore.GetForPosts(ids)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.BulkGetForPosts(postIds)
+		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8636,32 +8948,32 @@ func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 
 }
 
-func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Delete(reaction)
+		err := s.PreferenceStore.Delete(userID, category, name)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		err := s.PreferenceStore.DeleteCategory(userID, category)
 		if err == nil {
 			return nil
 		}
@@ -8678,11 +8990,11 @@ func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error
 
 }
 
-func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) error {
+func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
 		if err == nil {
 			return nil
 		}
@@ -8699,11 +9011,11 @@ func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsF
 
 }
 
-func (s *RetryLayerReactionStore) ExistsOnPost(postId string, emojiName string) (bool, error) {
+func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.ExistsOnPost(postId, emojiName)
+		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8720,11 +9032,11 @@ func (s *RetryLayerReactionStore) ExistsOnPost(postId string, emojiName string)
 
 }
 
-func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+		result, err := s.PreferenceStore.Get(userID, category, name)
 		if err == nil {
 			return result, nil
 		}
@@ -8741,11 +9053,11 @@ func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool)
 
 }
 
-func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
+		result, err := s.PreferenceStore.GetAll(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -8762,11 +9074,11 @@ func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, ex
 
 }
 
-func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+		result, err := s.PreferenceStore.GetCategory(userID, category)
 		if err == nil {
 			return result, nil
 		}
@@ -8783,11 +9095,11 @@ func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remote
 
 }
 
-func (s *RetryLayerReactionStore) GetUniqueCountForPost(postId string) (int, error) {
+func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, nane string) (model.Preferences, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetUniqueCountForPost(postId)
+		result, err := s.PreferenceStore.GetCategoryAndName(category, nane)
 		if err == nil {
 			return result, nil
 		}
@@ -8804,32 +9116,32 @@ func (s *RetryLayerReactionStore) GetUniqueCountForPost(postId string) (int, err
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		err := s.PreferenceStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.PermanentDeleteByUser(userID)
+		err := s.PreferenceStore.Save(preferences)
 		if err == nil {
 			return nil
 		}
@@ -8846,53 +9158,53 @@ func (s *RetryLayerReactionStore) PermanentDeleteByUser(userID string) error {
 
 }
 
-func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Save(reaction)
+		err := s.ProductNoticesStore.Clear(notices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
+func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Delete(remoteClusterId)
+		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
+func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Get(remoteClusterId)
+		result, err := s.ProductNoticesStore.GetViews(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -8909,32 +9221,32 @@ func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.Remot
 
 }
 
-func (s *RetryLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.GetAll(filter)
+		err := s.ProductNoticesStore.View(userID, notices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRemoteClusterStore) GetByPluginID(pluginID string) (*model.RemoteCluster, error) {
+func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.GetByPluginID(pluginID)
+		result, err := s.ReactionStore.BulkGetForPosts(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -8951,11 +9263,11 @@ func (s *RetryLayerRemoteClusterStore) GetByPluginID(pluginID string) (*model.Re
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Save(rc)
+		result, err := s.ReactionStore.Delete(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -8972,11 +9284,11 @@ func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.Rem
 
 }
 
-func (s *RetryLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
+func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 
 	tries := 0
 	for {
-		err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
+		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 		if err == nil {
 			return nil
 		}
@@ -8993,32 +9305,32 @@ func (s *RetryLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) err
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) error {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Update(rc)
+		err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
+func (s *RetryLayerReactionStore) ExistsOnPost(postId string, emojiName string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
+		result, err := s.ReactionStore.ExistsOnPost(postId, emojiName)
 		if err == nil {
 			return result, nil
 		}
@@ -9035,74 +9347,74 @@ func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topi
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
+func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
+		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
+func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
+		result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Delete(id string) error {
+func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.Delete(id)
+		result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerReactionStore) GetUniqueCountForPost(postId string) (int, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+		result, err := s.ReactionStore.GetUniqueCountForPost(postId)
 		if err == nil {
 			return result, nil
 		}
@@ -9119,11 +9431,11 @@ func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, e
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Get(id)
+		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9140,32 +9452,32 @@ func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyW
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerReactionStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+		err := s.ReactionStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+		result, err := s.ReactionStore.Save(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -9182,11 +9494,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID s
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+		result, err := s.RemoteClusterStore.Delete(remoteClusterId)
 		if err == nil {
 			return result, nil
 		}
@@ -9203,11 +9515,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
+		result, err := s.RemoteClusterStore.Get(remoteClusterId)
 		if err == nil {
 			return result, nil
 		}
@@ -9224,11 +9536,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
+func (s *RetryLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
+		result, err := s.RemoteClusterStore.GetAll(filter)
 		if err == nil {
 			return result, nil
 		}
@@ -9245,11 +9557,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int6
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
+func (s *RetryLayerRemoteClusterStore) GetByPluginID(pluginID string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetCount()
+		result, err := s.RemoteClusterStore.GetByPluginID(pluginID)
 		if err == nil {
 			return result, nil
 		}
@@ -9266,11 +9578,11 @@ func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
+func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
+		result, err := s.RemoteClusterStore.Save(rc)
 		if err == nil {
 			return result, nil
 		}
@@ -9287,32 +9599,32 @@ func (s *RetryLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+func (s *RetryLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+		err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+func (s *RetryLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+		result, err := s.RemoteClusterStore.Update(rc)
 		if err == nil {
 			return result, nil
 		}
@@ -9329,11 +9641,11 @@ func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, o
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
+func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
+		result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
 		if err == nil {
 			return result, nil
 		}
@@ -9350,53 +9662,53 @@ func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, l
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
+func (s *RetryLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
+		err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Patch(patch)
+		err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
+func (s *RetryLayerRetentionPolicyStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
+		err := s.RetentionPolicyStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -9413,32 +9725,32 @@ func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channel
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+		result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Save(policy)
+		result, err := s.RetentionPolicyStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -9455,11 +9767,11 @@ func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithT
 
 }
 
-func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.AllChannelSchemeRoles()
+		result, err := s.RetentionPolicyStore.GetAll(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9476,11 +9788,11 @@ func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+		result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9497,11 +9809,11 @@ func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string)
 
 }
 
-func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+		result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9518,11 +9830,11 @@ func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*mod
 
 }
 
-func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Delete(roleID)
+		result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9539,11 +9851,11 @@ func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Get(roleID)
+		result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
 		if err == nil {
 			return result, nil
 		}
@@ -9560,11 +9872,11 @@ func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetAll()
+		result, err := s.RetentionPolicyStore.GetCount()
 		if err == nil {
 			return result, nil
 		}
@@ -9581,11 +9893,11 @@ func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByName(ctx, name)
+		result, err := s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9602,11 +9914,11 @@ func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*mode
 
 }
 
-func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByNames(names)
+		result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9623,32 +9935,32 @@ func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error)
 
 }
 
-func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
+func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
 
 	tries := 0
 	for {
-		err := s.RoleStore.PermanentDeleteAll()
+		result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Save(role)
+		result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9665,11 +9977,11 @@ func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerSchemeStore) CountByScope(scope string) (int64, error) {
+func (s *RetryLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.CountByScope(scope)
+		result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
 		if err == nil {
 			return result, nil
 		}
@@ -9686,11 +9998,11 @@ func (s *RetryLayerSchemeStore) CountByScope(scope string) (int64, error) {
 
 }
 
-func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+func (s *RetryLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+		result, err := s.RetentionPolicyStore.Patch(patch)
 		if err == nil {
 			return result, nil
 		}
@@ -9707,53 +10019,53 @@ func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionI
 
 }
 
-func (s *RetryLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Delete(schemeID)
+		err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Get(schemeID)
+		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+		result, err := s.RetentionPolicyStore.Save(policy)
 		if err == nil {
 			return result, nil
 		}
@@ -9770,11 +10082,11 @@ func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int)
 
 }
 
-func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.GetByName(schemeName)
+		result, err := s.RoleStore.AllChannelSchemeRoles()
 		if err == nil {
 			return result, nil
 		}
@@ -9791,32 +10103,32 @@ func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, err
 
 }
 
-func (s *RetryLayerSchemeStore) PermanentDeleteAll() error {
+func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
 
 	tries := 0
 	for {
-		err := s.SchemeStore.PermanentDeleteAll()
+		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Save(scheme)
+		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
 		if err == nil {
 			return result, nil
 		}
@@ -9833,11 +10145,11 @@ func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error
 
 }
 
-func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.AnalyticsSessionCount()
+		result, err := s.RoleStore.Delete(roleID)
 		if err == nil {
 			return result, nil
 		}
@@ -9854,32 +10166,32 @@ func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 
 }
 
-func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.Cleanup(expiryTime, batchSize)
+		result, err := s.RoleStore.Get(roleID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*model.Session, error) {
+func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.Get(c, sessionIDOrToken)
+		result, err := s.RoleStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -9896,11 +10208,11 @@ func (s *RetryLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*m
 
 }
 
-func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
+		result, err := s.RoleStore.GetByName(ctx, name)
 		if err == nil {
 			return result, nil
 		}
@@ -9917,11 +10229,11 @@ func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, li
 
 }
 
-func (s *RetryLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*model.Session, error) {
+func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessions(c, userID)
+		result, err := s.RoleStore.GetByNames(names)
 		if err == nil {
 			return result, nil
 		}
@@ -9938,32 +10250,32 @@ func (s *RetryLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*m
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+		err := s.RoleStore.PermanentDeleteAll()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+		result, err := s.RoleStore.Save(role)
 		if err == nil {
 			return result, nil
 		}
@@ -9980,74 +10292,53 @@ func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 
 }
 
-func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+func (s *RetryLayerScheduledPostStore) Get(id string) (*model.ScheduledPost, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+		result, err := s.ScheduledPostStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
+func (s *RetryLayerScheduledPostStore) GetForUser(userID string, teamID string) ([]*model.ScheduledPost, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.Remove(sessionIDOrToken)
+		result, err := s.ScheduledPostStore.GetForUser(userID, teamID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
-
-}
-
-func (s *RetryLayerSessionStore) RemoveAllSessions() error {
-
-	tries := 0
-	for {
-		err := s.SessionStore.RemoveAllSessions()
-		if err == nil {
-			return nil
-		}
-		if !isRepeatableError(err) {
-			return err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*model.Session, error) {
+func (s *RetryLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64, afterTime int64, afterID string, limit int) ([]*model.ScheduledPost, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.Save(c, session)
+		result, err := s.ScheduledPostStore.GetPendingScheduledPosts(beforeTime, afterTime, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -10064,32 +10355,32 @@ func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*m
 
 }
 
-func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+func (s *RetryLayerScheduledPostStore) PermanentDelete(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+		err := s.ScheduledPostStore.PermanentDelete(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+func (s *RetryLayerScheduledPostStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+		err := s.ScheduledPostStore.PermanentDeleteByUser(userID)
 		if err == nil {
 			return nil
 		}
@@ -10106,74 +10397,74 @@ func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
+func (s *RetryLayerScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
+		result, err := s.ScheduledPostStore.Save(scheduledPost)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
+func (s *RetryLayerScheduledPostStore) Update(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
+		result, err := s.ScheduledPostStore.Update(scheduledPost)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
+func (s *RetryLayerSchemeStore) CountByScope(scope string) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateProps(session)
+		result, err := s.SchemeStore.CountByScope(scope)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateRoles(userID, roles)
+		result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
 		if err == nil {
 			return result, nil
 		}
@@ -10190,11 +10481,11 @@ func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (strin
 
 }
 
-func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
+func (s *RetryLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Delete(channelId)
+		result, err := s.SchemeStore.Delete(schemeID)
 		if err == nil {
 			return result, nil
 		}
@@ -10211,11 +10502,11 @@ func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
 
 }
 
-func (s *RetryLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
+func (s *RetryLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.DeleteRemote(remoteId)
+		result, err := s.SchemeStore.Get(schemeID)
 		if err == nil {
 			return result, nil
 		}
@@ -10232,11 +10523,11 @@ func (s *RetryLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, erro
 
 }
 
-func (s *RetryLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
+func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Get(channelId)
+		result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -10253,11 +10544,11 @@ func (s *RetryLayerSharedChannelStore) Get(channelId string) (*model.SharedChann
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+		result, err := s.SchemeStore.GetByName(schemeName)
 		if err == nil {
 			return result, nil
 		}
@@ -10274,32 +10565,32 @@ func (s *RetryLayerSharedChannelStore) GetAll(offset int, limit int, opts model.
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+func (s *RetryLayerSchemeStore) PermanentDeleteAll() error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAllCount(opts)
+		err := s.SchemeStore.PermanentDeleteAll()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
+func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
+		result, err := s.SchemeStore.Save(scheme)
 		if err == nil {
 			return result, nil
 		}
@@ -10316,11 +10607,11 @@ func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId str
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemote(id)
+		result, err := s.SessionStore.AnalyticsSessionCount()
 		if err == nil {
 			return result, nil
 		}
@@ -10337,32 +10628,32 @@ func (s *RetryLayerSharedChannelStore) GetRemote(id string) (*model.SharedChanne
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
+		err := s.SessionStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
+func (s *RetryLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
+		result, err := s.SessionStore.Get(c, sessionIDOrToken)
 		if err == nil {
 			return result, nil
 		}
@@ -10379,11 +10670,11 @@ func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemotes(opts)
+		result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
 		if err == nil {
 			return result, nil
 		}
@@ -10400,11 +10691,11 @@ func (s *RetryLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemote
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
+func (s *RetryLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
+		result, err := s.SessionStore.GetSessions(c, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -10421,11 +10712,11 @@ func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*mo
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
 		if err == nil {
 			return result, nil
 		}
@@ -10442,11 +10733,11 @@ func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID st
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetUsersForSync(filter)
+		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -10463,74 +10754,74 @@ func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForS
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetUsersForUser(userID)
+		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.HasChannel(channelID)
+		err := s.SessionStore.Remove(sessionIDOrToken)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
+func (s *RetryLayerSessionStore) RemoveAllSessions() error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
+		err := s.SessionStore.RemoveAllSessions()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Save(sc)
+		result, err := s.SessionStore.Save(c, session)
 		if err == nil {
 			return result, nil
 		}
@@ -10547,11 +10838,11 @@ func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.Sha
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveAttachment(remote)
+		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
 		if err == nil {
 			return result, nil
 		}
@@ -10568,74 +10859,74 @@ func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChanne
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveRemote(remote)
+		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveUser(remote)
+		err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Update(sc)
+		err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
 
 	tries := 0
 	for {
-		err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+		err := s.SessionStore.UpdateProps(session)
 		if err == nil {
 			return nil
 		}
@@ -10652,11 +10943,11 @@ func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syn
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.UpdateRemote(remote)
+		result, err := s.SessionStore.UpdateRoles(userID, roles)
 		if err == nil {
 			return result, nil
 		}
@@ -10673,53 +10964,53 @@ func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+		result, err := s.SharedChannelStore.Delete(channelId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+func (s *RetryLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+		result, err := s.SharedChannelStore.DeleteRemote(remoteId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+func (s *RetryLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.UpsertAttachment(remote)
+		result, err := s.SharedChannelStore.Get(channelId)
 		if err == nil {
 			return result, nil
 		}
@@ -10736,11 +11027,11 @@ func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChan
 
 }
 
-func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
+func (s *RetryLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.Get(userID)
+		result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10757,11 +11048,11 @@ func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
 
 }
 
-func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+func (s *RetryLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.GetByIds(userIds)
+		result, err := s.SharedChannelStore.GetAllCount(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10778,11 +11069,11 @@ func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 
 }
 
-func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.GetTotalActiveUsersCount()
+		result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
 		if err == nil {
 			return result, nil
 		}
@@ -10799,53 +11090,53 @@ func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 
 }
 
-func (s *RetryLayerStatusStore) ResetAll() error {
+func (s *RetryLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
 
 	tries := 0
 	for {
-		err := s.StatusStore.ResetAll()
+		result, err := s.SharedChannelStore.GetRemote(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+func (s *RetryLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
 
 	tries := 0
 	for {
-		err := s.StatusStore.SaveOrUpdate(status)
+		result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+		result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
 		if err == nil {
 			return result, nil
 		}
@@ -10862,32 +11153,32 @@ func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, err
 
 }
 
-func (s *RetryLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+func (s *RetryLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
 
 	tries := 0
 	for {
-		err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+		result, err := s.SharedChannelStore.GetRemotes(opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
+func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.Get()
+		result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
 		if err == nil {
 			return result, nil
 		}
@@ -10904,11 +11195,11 @@ func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
 
 }
 
-func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
+func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.GetByName(name)
+		result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
 		if err == nil {
 			return result, nil
 		}
@@ -10925,11 +11216,11 @@ func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
 
 }
 
-func (s *RetryLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.InsertIfExists(system)
+		result, err := s.SharedChannelStore.GetUsersForSync(filter)
 		if err == nil {
 			return result, nil
 		}
@@ -10946,11 +11237,11 @@ func (s *RetryLayerSystemStore) InsertIfExists(system *model.System) (*model.Sys
 
 }
 
-func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+func (s *RetryLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.PermanentDeleteByName(name)
+		result, err := s.SharedChannelStore.GetUsersForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -10967,74 +11258,74 @@ func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.Syste
 
 }
 
-func (s *RetryLayerSystemStore) Save(system *model.System) error {
+func (s *RetryLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.Save(system)
+		result, err := s.SharedChannelStore.HasChannel(channelID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) SaveOrUpdate(system *model.System) error {
+func (s *RetryLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.SaveOrUpdate(system)
+		result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) Update(system *model.System) error {
+func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.Update(system)
+		result, err := s.SharedChannelStore.Save(sc)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+		result, err := s.SharedChannelStore.SaveAttachment(remote)
 		if err == nil {
 			return result, nil
 		}
@@ -11051,11 +11342,11 @@ func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (i
 
 }
 
-func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.AnalyticsTeamCount(opts)
+		result, err := s.SharedChannelStore.SaveRemote(remote)
 		if err == nil {
 			return result, nil
 		}
@@ -11072,38 +11363,32 @@ func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64,
 
 }
 
-func (s *RetryLayerTeamStore) ClearAllCustomRoleAssignments() error {
+func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.ClearAllCustomRoleAssignments()
+		result, err := s.SharedChannelStore.SaveUser(remote)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) ClearCaches() {
-
-	s.TeamStore.ClearCaches()
-
-}
-
-func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
+func (s *RetryLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Get(id)
+		result, err := s.SharedChannelStore.Update(sc)
 		if err == nil {
 			return result, nil
 		}
@@ -11120,32 +11405,32 @@ func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+		err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
+func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAll()
+		result, err := s.SharedChannelStore.UpdateRemote(remote)
 		if err == nil {
 			return result, nil
 		}
@@ -11162,74 +11447,74 @@ func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
+		err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerSharedChannelStore) UpdateRemoteLastChannelSyncAt(id string, syncTime int64) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllPage(offset, limit, opts)
+		err := s.SharedChannelStore.UpdateRemoteLastChannelSyncAt(id, syncTime)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+func (s *RetryLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllPrivateTeamListing()
+		err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllTeamListing()
+		result, err := s.SharedChannelStore.UpsertAttachment(remote)
 		if err == nil {
 			return result, nil
 		}
@@ -11246,11 +11531,11 @@ func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error) {
+func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByEmptyInviteID()
+		result, err := s.StatusStore.Get(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -11267,11 +11552,11 @@ func (s *RetryLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
+func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByInviteId(inviteID)
+		result, err := s.StatusStore.GetByIds(userIds)
 		if err == nil {
 			return result, nil
 		}
@@ -11288,11 +11573,11 @@ func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error
 
 }
 
-func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
+func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByName(name)
+		result, err := s.StatusStore.GetTotalActiveUsersCount()
 		if err == nil {
 			return result, nil
 		}
@@ -11309,53 +11594,53 @@ func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+func (s *RetryLayerStatusStore) ResetAll() error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByNames(name)
+		err := s.StatusStore.ResetAll()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *RetryLayerStatusStore) SaveOrUpdate(status *model.Status) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
+		err := s.StatusStore.SaveOrUpdate(status)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
+		result, err := s.StatusStore.UpdateExpiredDNDStatuses()
 		if err == nil {
 			return result, nil
 		}
@@ -11372,32 +11657,32 @@ func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID str
 
 }
 
-func (s *RetryLayerTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]string, error) {
+func (s *RetryLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetCommonTeamIDsForMultipleUsers(userIDs)
+		err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
+func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+		result, err := s.SystemStore.Get()
 		if err == nil {
 			return result, nil
 		}
@@ -11414,11 +11699,11 @@ func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUs
 
 }
 
-func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
+func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMany(ids)
+		result, err := s.SystemStore.GetByName(name)
 		if err == nil {
 			return result, nil
 		}
@@ -11435,11 +11720,11 @@ func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetMember(c request.CTX, teamID string, userID string) (*model.TeamMember, error) {
+func (s *RetryLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMember(c, teamID, userID)
+		result, err := s.SystemStore.InsertIfExists(system)
 		if err == nil {
 			return result, nil
 		}
@@ -11456,11 +11741,11 @@ func (s *RetryLayerTeamStore) GetMember(c request.CTX, teamID string, userID str
 
 }
 
-func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
+		result, err := s.SystemStore.PermanentDeleteByName(name)
 		if err == nil {
 			return result, nil
 		}
@@ -11477,74 +11762,74 @@ func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, t
 
 }
 
-func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+func (s *RetryLayerSystemStore) Save(system *model.System) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
+		err := s.SystemStore.Save(system)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
+func (s *RetryLayerSystemStore) SaveOrUpdate(system *model.System) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamMembersForExport(userID)
+		err := s.SystemStore.SaveOrUpdate(system)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
+func (s *RetryLayerSystemStore) Update(system *model.System) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
+		err := s.SystemStore.Update(system)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsByUserId(userID)
+		result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
 		if err == nil {
 			return result, nil
 		}
@@ -11561,11 +11846,11 @@ func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, er
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsForUser(c request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsForUser(c, userID, excludeTeamID, includeDeleted)
+		result, err := s.TeamStore.AnalyticsTeamCount(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -11582,53 +11867,38 @@ func (s *RetryLayerTeamStore) GetTeamsForUser(c request.CTX, userID string, excl
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) ClearAllCustomRoleAssignments() error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
+		err := s.TeamStore.ClearAllCustomRoleAssignments()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *RetryLayerTeamStore) ClearCaches() {
 
-	tries := 0
-	for {
-		result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
-		if err == nil {
-			return result, nil
-		}
-		if !isRepeatableError(err) {
-			return result, err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
+	s.TeamStore.ClearCaches()
 
 }
 
-func (s *RetryLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
+func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
+		result, err := s.TeamStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -11645,11 +11915,11 @@ func (s *RetryLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool)
 
 }
 
-func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+func (s *RetryLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GroupSyncedTeamCount()
+		result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -11666,17 +11936,11 @@ func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 }
 
-func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
-
-	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
-
-}
-
-func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
+func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
+		result, err := s.TeamStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -11693,137 +11957,137 @@ func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID s
 
 }
 
-func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
+func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.PermanentDelete(teamID)
+		result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveAllMembersByTeam(teamID)
+		result, err := s.TeamStore.GetAllPage(offset, limit, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveAllMembersByUser(ctx request.CTX, userID string) error {
+func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveAllMembersByUser(ctx, userID)
+		result, err := s.TeamStore.GetAllPrivateTeamListing()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
+func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveMember(rctx, teamID, userID)
+		result, err := s.TeamStore.GetAllTeamListing()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
+func (s *RetryLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
+		result, err := s.TeamStore.GetByEmptyInviteID()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) ResetAllTeamSchemes() error {
+func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.ResetAllTeamSchemes()
+		result, err := s.TeamStore.GetByInviteId(inviteID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Save(team)
+		result, err := s.TeamStore.GetByName(name)
 		if err == nil {
 			return result, nil
 		}
@@ -11840,11 +12104,11 @@ func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
+		result, err := s.TeamStore.GetByNames(name)
 		if err == nil {
 			return result, nil
 		}
@@ -11861,11 +12125,11 @@ func (s *RetryLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMem
 
 }
 
-func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+		result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -11882,11 +12146,11 @@ func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, m
 
 }
 
-func (s *RetryLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchAll(opts)
+		result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -11903,32 +12167,32 @@ func (s *RetryLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team,
 
 }
 
-func (s *RetryLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
+func (s *RetryLayerTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
+		result, err := s.TeamStore.GetCommonTeamIDsForMultipleUsers(userIDs)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchOpen(opts)
+		result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
 		if err == nil {
 			return result, nil
 		}
@@ -11945,11 +12209,11 @@ func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team,
 
 }
 
-func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchPrivate(opts)
+		result, err := s.TeamStore.GetMany(ids)
 		if err == nil {
 			return result, nil
 		}
@@ -11966,11 +12230,11 @@ func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Te
 
 }
 
-func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMember(c request.CTX, teamID string, userID string) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Update(team)
+		result, err := s.TeamStore.GetMember(c, teamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -11987,32 +12251,32 @@ func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
+func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
+		result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UpdateMember(rctx, member)
+		result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -12029,32 +12293,32 @@ func (s *RetryLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamM
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+func (s *RetryLayerTeamStore) GetMembersLeftSince(teamID string, since int64) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+		result, err := s.TeamStore.GetMembersLeftSince(teamID, since)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UpdateMultipleMembers(members)
+		result, err := s.TeamStore.GetTeamActivityReport(filter)
 		if err == nil {
 			return result, nil
 		}
@@ -12071,11 +12335,11 @@ func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember)
 
 }
 
-func (s *RetryLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
+func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
+		result, err := s.TeamStore.GetTeamMembersForExport(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -12092,11 +12356,11 @@ func (s *RetryLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string
 
 }
 
-func (s *RetryLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
+		result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -12113,11 +12377,11 @@ func (s *RetryLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*mo
 
 }
 
-func (s *RetryLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
+		result, err := s.TeamStore.GetTeamsByUserId(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -12134,11 +12398,11 @@ func (s *RetryLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.T
 
 }
 
-func (s *RetryLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+func (s *RetryLayerTeamStore) GetTeamsForUser(c request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TermsOfServiceStore.Save(termsOfService)
+		result, err := s.TeamStore.GetTeamsForUser(c, userID, excludeTeamID, includeDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -12155,53 +12419,53 @@ func (s *RetryLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfServic
 
 }
 
-func (s *RetryLayerThreadStore) DeleteMembershipForUser(userId string, postID string) error {
+func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.DeleteMembershipForUser(userId, postID)
+		result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) DeleteMembershipsForChannel(userID string, channelID string) error {
+func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
+		result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.DeleteOrphanedRows(limit)
+		result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -12218,11 +12482,11 @@ func (s *RetryLayerThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 }
 
-func (s *RetryLayerThreadStore) Get(id string) (*model.Thread, error) {
+func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.Get(id)
+		result, err := s.TeamStore.GroupSyncedTeamCount()
 		if err == nil {
 			return result, nil
 		}
@@ -12239,11 +12503,17 @@ func (s *RetryLayerThreadStore) Get(id string) (*model.Thread, error) {
 
 }
 
-func (s *RetryLayerThreadStore) GetMembershipForUser(userId string, postID string) (*model.ThreadMembership, error) {
+func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
+
+	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
+
+}
+
+func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetMembershipForUser(userId, postID)
+		result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
 		if err == nil {
 			return result, nil
 		}
@@ -12260,137 +12530,137 @@ func (s *RetryLayerThreadStore) GetMembershipForUser(userId string, postID strin
 
 }
 
-func (s *RetryLayerThreadStore) GetMembershipsForUser(userId string, teamID string) ([]*model.ThreadMembership, error) {
+func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetMembershipsForUser(userId, teamID)
+		err := s.TeamStore.PermanentDelete(teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetTeamsUnreadForUser(userID string, teamIDs []string, includeUrgentMentionCount bool) (map[string]*model.TeamUnread, error) {
+func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTeamsUnreadForUser(userID, teamIDs, includeUrgentMentionCount)
+		err := s.TeamStore.RemoveAllMembersByTeam(teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error) {
+func (s *RetryLayerTeamStore) RemoveAllMembersByUser(ctx request.CTX, userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadFollowers(threadID, fetchOnlyActive)
+		err := s.TeamStore.RemoveAllMembersByUser(ctx, userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+func (s *RetryLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadForUser(threadMembership, extended, postPriorityIsEnabled)
+		err := s.TeamStore.RemoveMember(rctx, teamID, userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *model.ThreadMembership) (int64, error) {
+func (s *RetryLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadUnreadReplyCount(threadMembership)
+		err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadsForUser(userId string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+func (s *RetryLayerTeamStore) ResetAllTeamSchemes() error {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadsForUser(userId, teamID, opts)
+		err := s.TeamStore.ResetAllTeamSchemes()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalThreads(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalThreads(userId, teamID, opts)
+		result, err := s.TeamStore.Save(team)
 		if err == nil {
 			return result, nil
 		}
@@ -12407,11 +12677,11 @@ func (s *RetryLayerThreadStore) GetTotalThreads(userId string, teamID string, op
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadMentions(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadMentions(userId, teamID, opts)
+		result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -12428,11 +12698,11 @@ func (s *RetryLayerThreadStore) GetTotalUnreadMentions(userId string, teamID str
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadThreads(userId, teamID, opts)
+		result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -12449,11 +12719,11 @@ func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userId string, teamID stri
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadUrgentMentions(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userId, teamID, opts)
+		result, err := s.TeamStore.SearchAll(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -12470,95 +12740,95 @@ func (s *RetryLayerThreadStore) GetTotalUnreadUrgentMentions(userId string, team
 
 }
 
-func (s *RetryLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
+func (s *RetryLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.MaintainMembership(userID, postID, opts)
+		result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) MarkAllAsRead(userID string, threadIds []string) error {
+func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.MarkAllAsRead(userID, threadIds)
+		result, err := s.TeamStore.SearchOpen(opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []string) error {
+func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
+		result, err := s.TeamStore.SearchPrivate(opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) MarkAllAsReadByTeam(userID string, teamID string) error {
+func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
+		result, err := s.TeamStore.Update(team)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) MarkAsRead(userID string, threadID string, timestamp int64) error {
+func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
 
 	tries := 0
 	for {
-		err := s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
+		err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
 		if err == nil {
 			return nil
 		}
@@ -12575,53 +12845,53 @@ func (s *RetryLayerThreadStore) MarkAsRead(userID string, threadID string, times
 
 }
 
-func (s *RetryLayerThreadStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		result, err := s.TeamStore.UpdateMember(rctx, member)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.UpdateMembership(membership)
+		result, err := s.TeamStore.UpdateMultipleMembers(members)
 		if err == nil {
 			return result, nil
 		}
@@ -12638,38 +12908,32 @@ func (s *RetryLayerThreadStore) UpdateMembership(membership *model.ThreadMembers
 
 }
 
-func (s *RetryLayerTokenStore) Cleanup(expiryTime int64) {
-
-	s.TokenStore.Cleanup(expiryTime)
-
-}
-
-func (s *RetryLayerTokenStore) Delete(token string) error {
+func (s *RetryLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.TokenStore.Delete(token)
+		result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, error) {
+func (s *RetryLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.TokenStore.GetAllTokensByType(tokenType)
+		result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -12686,11 +12950,11 @@ func (s *RetryLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.To
 
 }
 
-func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, error) {
+func (s *RetryLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.TokenStore.GetByToken(token)
+		result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -12707,32 +12971,32 @@ func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 
 }
 
-func (s *RetryLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+func (s *RetryLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
 
 	tries := 0
 	for {
-		err := s.TokenStore.RemoveAllTokensByType(tokenType)
+		result, err := s.TermsOfServiceStore.Save(termsOfService)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTokenStore) Save(recovery *model.Token) error {
+func (s *RetryLayerThreadStore) DeleteMembershipForUser(userId string, postID string) error {
 
 	tries := 0
 	for {
-		err := s.TokenStore.Save(recovery)
+		err := s.ThreadStore.DeleteMembershipForUser(userId, postID)
 		if err == nil {
 			return nil
 		}
@@ -12749,11 +13013,11 @@ func (s *RetryLayerTokenStore) Save(recovery *model.Token) error {
 
 }
 
-func (s *RetryLayerUploadSessionStore) Delete(id string) error {
+func (s *RetryLayerThreadStore) DeleteMembershipsForChannel(userID string, channelID string) error {
 
 	tries := 0
 	for {
-		err := s.UploadSessionStore.Delete(id)
+		err := s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
 		if err == nil {
 			return nil
 		}
@@ -12770,11 +13034,11 @@ func (s *RetryLayerUploadSessionStore) Delete(id string) error {
 
 }
 
-func (s *RetryLayerUploadSessionStore) Get(c request.CTX, id string) (*model.UploadSession, error) {
+func (s *RetryLayerThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UploadSessionStore.Get(c, id)
+		result, err := s.ThreadStore.DeleteOrphanedRows(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -12791,11 +13055,11 @@ func (s *RetryLayerUploadSessionStore) Get(c request.CTX, id string) (*model.Upl
 
 }
 
-func (s *RetryLayerUploadSessionStore) GetForUser(userID string) ([]*model.UploadSession, error) {
+func (s *RetryLayerThreadStore) Get(id string) (*model.Thread, error) {
 
 	tries := 0
 	for {
-		result, err := s.UploadSessionStore.GetForUser(userID)
+		result, err := s.ThreadStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -12812,11 +13076,11 @@ func (s *RetryLayerUploadSessionStore) GetForUser(userID string) ([]*model.Uploa
 
 }
 
-func (s *RetryLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+func (s *RetryLayerThreadStore) GetMembershipForUser(userId string, postID string) (*model.ThreadMembership, error) {
 
 	tries := 0
 	for {
-		result, err := s.UploadSessionStore.Save(session)
+		result, err := s.ThreadStore.GetMembershipForUser(userId, postID)
 		if err == nil {
 			return result, nil
 		}
@@ -12833,32 +13097,32 @@ func (s *RetryLayerUploadSessionStore) Save(session *model.UploadSession) (*mode
 
 }
 
-func (s *RetryLayerUploadSessionStore) Update(session *model.UploadSession) error {
+func (s *RetryLayerThreadStore) GetMembershipsForUser(userId string, teamID string) ([]*model.ThreadMembership, error) {
 
 	tries := 0
 	for {
-		err := s.UploadSessionStore.Update(session)
+		result, err := s.ThreadStore.GetMembershipsForUser(userId, teamID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error) {
+func (s *RetryLayerThreadStore) GetTeamsUnreadForUser(userID string, teamIDs []string, includeUrgentMentionCount bool) (map[string]*model.TeamUnread, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsActiveCount(timestamp, options)
+		result, err := s.ThreadStore.GetTeamsUnreadForUser(userID, teamIDs, includeUrgentMentionCount)
 		if err == nil {
 			return result, nil
 		}
@@ -12875,11 +13139,11 @@ func (s *RetryLayerUserStore) AnalyticsActiveCount(timestamp int64, options mode
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+func (s *RetryLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+		result, err := s.ThreadStore.GetThreadFollowers(threadID, fetchOnlyActive)
 		if err == nil {
 			return result, nil
 		}
@@ -12896,11 +13160,11 @@ func (s *RetryLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, end
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+func (s *RetryLayerThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+		result, err := s.ThreadStore.GetThreadForUser(threadMembership, extended, postPriorityIsEnabled)
 		if err == nil {
 			return result, nil
 		}
@@ -12917,11 +13181,11 @@ func (s *RetryLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
+func (s *RetryLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *model.ThreadMembership) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsGetGuestCount()
+		result, err := s.ThreadStore.GetThreadUnreadReplyCount(threadMembership)
 		if err == nil {
 			return result, nil
 		}
@@ -12938,11 +13202,11 @@ func (s *RetryLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+func (s *RetryLayerThreadStore) GetThreadsForUser(userId string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
+		result, err := s.ThreadStore.GetThreadsForUser(userId, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -12959,11 +13223,11 @@ func (s *RetryLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalThreads(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AnalyticsGetSystemAdminCount()
+		result, err := s.ThreadStore.GetTotalThreads(userId, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -12980,11 +13244,11 @@ func (s *RetryLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+func (s *RetryLayerThreadStore) GetTotalUnreadMentions(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
+		result, err := s.ThreadStore.GetTotalUnreadMentions(userId, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13001,38 +13265,32 @@ func (s *RetryLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamI
 
 }
 
-func (s *RetryLayerUserStore) ClearAllCustomRoleAssignments() error {
+func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.ClearAllCustomRoleAssignments()
+		result, err := s.ThreadStore.GetTotalUnreadThreads(userId, teamID, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) ClearCaches() {
-
-	s.UserStore.ClearCaches()
-
-}
-
-func (s *RetryLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalUnreadUrgentMentions(userId string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.Count(options)
+		result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userId, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13049,11 +13307,11 @@ func (s *RetryLayerUserStore) Count(options model.UserCountOptions) (int64, erro
 
 }
 
-func (s *RetryLayerUserStore) DeactivateGuests() ([]string, error) {
+func (s *RetryLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.DeactivateGuests()
+		result, err := s.ThreadStore.MaintainMembership(userID, postID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13070,137 +13328,137 @@ func (s *RetryLayerUserStore) DeactivateGuests() ([]string, error) {
 
 }
 
-func (s *RetryLayerUserStore) DemoteUserToGuest(userID string) (*model.User, error) {
+func (s *RetryLayerThreadStore) MarkAllAsRead(userID string, threadIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.DemoteUserToGuest(userID)
+		err := s.ThreadStore.MarkAllAsRead(userID, threadIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
+func (s *RetryLayerThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.Get(ctx, id)
+		err := s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAll() ([]*model.User, error) {
+func (s *RetryLayerThreadStore) MarkAllAsReadByTeam(userID string, teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAll()
+		err := s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.User, error) {
+func (s *RetryLayerThreadStore) MarkAsRead(userID string, threadID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAllAfter(limit, afterID)
+		err := s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+func (s *RetryLayerThreadStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAllNotInAuthService(authServices)
+		result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAllProfiles(options)
+		result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAllProfilesInChannel(rctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
+func (s *RetryLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAllProfilesInChannel(rctx, channelID, allowFromCache)
+		result, err := s.ThreadStore.UpdateMembership(membership)
 		if err == nil {
 			return result, nil
 		}
@@ -13217,32 +13475,38 @@ func (s *RetryLayerUserStore) GetAllProfilesInChannel(rctx context.Context, chan
 
 }
 
-func (s *RetryLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+func (s *RetryLayerTokenStore) Cleanup(expiryTime int64) {
+
+	s.TokenStore.Cleanup(expiryTime)
+
+}
+
+func (s *RetryLayerTokenStore) Delete(token string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAllUsingAuthService(authService)
+		err := s.TokenStore.Delete(token)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, channelID string) (int64, error) {
+func (s *RetryLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userID, channelID)
+		result, err := s.TokenStore.GetAllTokensByType(tokenType)
 		if err == nil {
 			return result, nil
 		}
@@ -13259,11 +13523,11 @@ func (s *RetryLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, cha
 
 }
 
-func (s *RetryLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetByAuth(authData, authService)
+		result, err := s.TokenStore.GetByToken(token)
 		if err == nil {
 			return result, nil
 		}
@@ -13280,74 +13544,74 @@ func (s *RetryLayerUserStore) GetByAuth(authData *string, authService string) (*
 
 }
 
-func (s *RetryLayerUserStore) GetByEmail(email string) (*model.User, error) {
+func (s *RetryLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetByEmail(email)
+		err := s.TokenStore.RemoveAllTokensByType(tokenType)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetByRemoteID(remoteID string) (*model.User, error) {
+func (s *RetryLayerTokenStore) Save(recovery *model.Token) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetByRemoteID(remoteID)
+		err := s.TokenStore.Save(recovery)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetByUsername(username string) (*model.User, error) {
+func (s *RetryLayerUploadSessionStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetByUsername(username)
+		err := s.UploadSessionStore.Delete(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+func (s *RetryLayerUploadSessionStore) Get(c request.CTX, id string) (*model.UploadSession, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetChannelGroupUsers(channelID)
+		result, err := s.UploadSessionStore.Get(c, id)
 		if err == nil {
 			return result, nil
 		}
@@ -13364,29 +13628,11 @@ func (s *RetryLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.U
 
 }
 
-func (s *RetryLayerUserStore) GetEtagForAllProfiles() string {
-
-	return s.UserStore.GetEtagForAllProfiles()
-
-}
-
-func (s *RetryLayerUserStore) GetEtagForProfiles(teamID string) string {
-
-	return s.UserStore.GetEtagForProfiles(teamID)
-
-}
-
-func (s *RetryLayerUserStore) GetEtagForProfilesNotInTeam(teamID string) string {
-
-	return s.UserStore.GetEtagForProfilesNotInTeam(teamID)
-
-}
-
-func (s *RetryLayerUserStore) GetForLogin(loginID string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+func (s *RetryLayerUploadSessionStore) GetForUser(userID string) ([]*model.UploadSession, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetForLogin(loginID, allowSignInWithUsername, allowSignInWithEmail)
+		result, err := s.UploadSessionStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -13403,11 +13649,11 @@ func (s *RetryLayerUserStore) GetForLogin(loginID string, allowSignInWithUsernam
 
 }
 
-func (s *RetryLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
+func (s *RetryLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetKnownUsers(userID)
+		result, err := s.UploadSessionStore.Save(session)
 		if err == nil {
 			return result, nil
 		}
@@ -13424,32 +13670,32 @@ func (s *RetryLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 
 }
 
-func (s *RetryLayerUserStore) GetMany(ctx context.Context, ids []string) ([]*model.User, error) {
+func (s *RetryLayerUploadSessionStore) Update(session *model.UploadSession) error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetMany(ctx, ids)
+		err := s.UploadSessionStore.Update(session)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetNewUsersForTeam(teamID, offset, limit, viewRestrictions)
+		result, err := s.UserStore.AnalyticsActiveCount(timestamp, options)
 		if err == nil {
 			return result, nil
 		}
@@ -13466,11 +13712,11 @@ func (s *RetryLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limi
 
 }
 
-func (s *RetryLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userID, channelIds)
+		result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
 		if err == nil {
 			return result, nil
 		}
@@ -13487,11 +13733,11 @@ func (s *RetryLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string,
 
 }
 
-func (s *RetryLayerUserStore) GetProfileByIds(ctx context.Context, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfileByIds(ctx, userIds, options, allowFromCache)
+		result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
 		if err == nil {
 			return result, nil
 		}
@@ -13508,11 +13754,11 @@ func (s *RetryLayerUserStore) GetProfileByIds(ctx context.Context, userIds []str
 
 }
 
-func (s *RetryLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfiles(options)
+		result, err := s.UserStore.AnalyticsGetGuestCount()
 		if err == nil {
 			return result, nil
 		}
@@ -13529,11 +13775,11 @@ func (s *RetryLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*mod
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+		result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
 		if err == nil {
 			return result, nil
 		}
@@ -13550,11 +13796,11 @@ func (s *RetryLayerUserStore) GetProfilesByUsernames(usernames []string, viewRes
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesInChannel(options)
+		result, err := s.UserStore.AnalyticsGetSystemAdminCount()
 		if err == nil {
 			return result, nil
 		}
@@ -13571,11 +13817,11 @@ func (s *RetryLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesInChannelByAdmin(options)
+		result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
 		if err == nil {
 			return result, nil
 		}
@@ -13592,32 +13838,38 @@ func (s *RetryLayerUserStore) GetProfilesInChannelByAdmin(options *model.UserGet
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) ClearAllCustomRoleAssignments() error {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesInChannelByStatus(options)
+		err := s.UserStore.ClearAllCustomRoleAssignments()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesNotInChannel(teamID string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) ClearCaches() {
+
+	s.UserStore.ClearCaches()
+
+}
+
+func (s *RetryLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesNotInChannel(teamID, channelId, groupConstrained, offset, limit, viewRestrictions)
+		result, err := s.UserStore.Count(options)
 		if err == nil {
 			return result, nil
 		}
@@ -13634,11 +13886,11 @@ func (s *RetryLayerUserStore) GetProfilesNotInChannel(teamID string, channelId s
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) DeactivateGuests() ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
+		result, err := s.UserStore.DeactivateGuests()
 		if err == nil {
 			return result, nil
 		}
@@ -13655,11 +13907,11 @@ func (s *RetryLayerUserStore) GetProfilesNotInTeam(teamID string, groupConstrain
 
 }
 
-func (s *RetryLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) DemoteUserToGuest(userID string) (*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetProfilesWithoutTeam(options)
+		result, err := s.UserStore.DemoteUserToGuest(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -13676,11 +13928,11 @@ func (s *RetryLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptio
 
 }
 
-func (s *RetryLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamID, offset, limit, viewRestrictions)
+		result, err := s.UserStore.Get(ctx, id)
 		if err == nil {
 			return result, nil
 		}
@@ -13697,11 +13949,11 @@ func (s *RetryLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string, offse
 
 }
 
-func (s *RetryLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+func (s *RetryLayerUserStore) GetAll() ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetSystemAdminProfiles()
+		result, err := s.UserStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -13718,11 +13970,11 @@ func (s *RetryLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User,
 
 }
 
-func (s *RetryLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetTeamGroupUsers(teamID)
+		result, err := s.UserStore.GetAllAfter(limit, afterID)
 		if err == nil {
 			return result, nil
 		}
@@ -13739,11 +13991,11 @@ func (s *RetryLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, e
 
 }
 
-func (s *RetryLayerUserStore) GetUnreadCount(userID string, isCRTEnabled bool) (int64, error) {
+func (s *RetryLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUnreadCount(userID, isCRTEnabled)
+		result, err := s.UserStore.GetAllNotInAuthService(authServices)
 		if err == nil {
 			return result, nil
 		}
@@ -13760,11 +14012,11 @@ func (s *RetryLayerUserStore) GetUnreadCount(userID string, isCRTEnabled bool) (
 
 }
 
-func (s *RetryLayerUserStore) GetUnreadCountForChannel(userID string, channelID string) (int64, error) {
+func (s *RetryLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUnreadCountForChannel(userID, channelID)
+		result, err := s.UserStore.GetAllProfiles(options)
 		if err == nil {
 			return result, nil
 		}
@@ -13781,11 +14033,11 @@ func (s *RetryLayerUserStore) GetUnreadCountForChannel(userID string, channelID
 
 }
 
-func (s *RetryLayerUserStore) GetUserCountForReport(filter *model.UserReportOptions) (int64, error) {
+func (s *RetryLayerUserStore) GetAllProfilesInChannel(rctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUserCountForReport(filter)
+		result, err := s.UserStore.GetAllProfilesInChannel(rctx, channelID, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -13802,11 +14054,11 @@ func (s *RetryLayerUserStore) GetUserCountForReport(filter *model.UserReportOpti
 
 }
 
-func (s *RetryLayerUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
+func (s *RetryLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUserReport(filter)
+		result, err := s.UserStore.GetAllUsingAuthService(authService)
 		if err == nil {
 			return result, nil
 		}
@@ -13823,11 +14075,11 @@ func (s *RetryLayerUserStore) GetUserReport(filter *model.UserReportOptions) ([]
 
 }
 
-func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error) {
+func (s *RetryLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, channelID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUsersBatchForIndexing(startTime, startFileID, limit)
+		result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userID, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -13844,11 +14096,11 @@ func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFil
 
 }
 
-func (s *RetryLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.GetUsersWithInvalidEmails(page, perPage, restrictedDomains)
+		result, err := s.UserStore.GetByAuth(authData, authService)
 		if err == nil {
 			return result, nil
 		}
@@ -13865,11 +14117,11 @@ func (s *RetryLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, r
 
 }
 
-func (s *RetryLayerUserStore) InferSystemInstallDate() (int64, error) {
+func (s *RetryLayerUserStore) GetByEmail(email string) (*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.InferSystemInstallDate()
+		result, err := s.UserStore.GetByEmail(email)
 		if err == nil {
 			return result, nil
 		}
@@ -13886,50 +14138,32 @@ func (s *RetryLayerUserStore) InferSystemInstallDate() (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) InsertUsers(users []*model.User) error {
+func (s *RetryLayerUserStore) GetByRemoteID(remoteID string) (*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.InsertUsers(users)
+		result, err := s.UserStore.GetByRemoteID(remoteID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) InvalidateProfileCacheForUser(userID string) {
-
-	s.UserStore.InvalidateProfileCacheForUser(userID)
-
-}
-
-func (s *RetryLayerUserStore) InvalidateProfilesInChannelCache(channelID string) {
-
-	s.UserStore.InvalidateProfilesInChannelCache(channelID)
-
-}
-
-func (s *RetryLayerUserStore) InvalidateProfilesInChannelCacheByUser(userID string) {
-
-	s.UserStore.InvalidateProfilesInChannelCacheByUser(userID)
-
-}
-
-func (s *RetryLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
+func (s *RetryLayerUserStore) GetByUsername(username string) (*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.IsEmpty(excludeBots)
+		result, err := s.UserStore.GetByUsername(username)
 		if err == nil {
 			return result, nil
 		}
@@ -13946,74 +14180,92 @@ func (s *RetryLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 
 }
 
-func (s *RetryLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+func (s *RetryLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.PermanentDelete(rctx, userID)
+		result, err := s.UserStore.GetChannelGroupUsers(channelID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) PromoteGuestToUser(userID string) error {
+func (s *RetryLayerUserStore) GetEtagForAllProfiles() string {
 
-	tries := 0
-	for {
-		err := s.UserStore.PromoteGuestToUser(userID)
-		if err == nil {
-			return nil
+	return s.UserStore.GetEtagForAllProfiles()
+
+}
+
+func (s *RetryLayerUserStore) GetEtagForProfiles(teamID string) string {
+
+	return s.UserStore.GetEtagForProfiles(teamID)
+
+}
+
+func (s *RetryLayerUserStore) GetEtagForProfilesNotInTeam(teamID string) string {
+
+	return s.UserStore.GetEtagForProfilesNotInTeam(teamID)
+
+}
+
+func (s *RetryLayerUserStore) GetForLogin(loginID string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetForLogin(loginID, allowSignInWithUsername, allowSignInWithEmail)
+		if err == nil {
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) RefreshPostStatsForUsers() error {
+func (s *RetryLayerUserStore) GetIdsWithMembershipsUpdatedSince(since int64, includeArchivedChannels bool) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.RefreshPostStatsForUsers()
+		result, err := s.UserStore.GetIdsWithMembershipsUpdatedSince(since, includeArchivedChannels)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) ResetAuthDataToEmailForUsers(service string, userIDs []string, includeDeleted bool, dryRun bool) (int, error) {
+func (s *RetryLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.ResetAuthDataToEmailForUsers(service, userIDs, includeDeleted, dryRun)
+		result, err := s.UserStore.GetKnownUsers(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -14030,32 +14282,32 @@ func (s *RetryLayerUserStore) ResetAuthDataToEmailForUsers(service string, userI
 
 }
 
-func (s *RetryLayerUserStore) ResetLastPictureUpdate(userID string) error {
+func (s *RetryLayerUserStore) GetMany(ctx context.Context, ids []string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.ResetLastPictureUpdate(userID)
+		result, err := s.UserStore.GetMany(ctx, ids)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+func (s *RetryLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.Save(rctx, user)
+		result, err := s.UserStore.GetNewUsersForTeam(teamID, offset, limit, viewRestrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -14072,11 +14324,11 @@ func (s *RetryLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.U
 
 }
 
-func (s *RetryLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.Search(rctx, teamID, term, options)
+		result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userID, channelIds)
 		if err == nil {
 			return result, nil
 		}
@@ -14093,11 +14345,11 @@ func (s *RetryLayerUserStore) Search(rctx request.CTX, teamID string, term strin
 
 }
 
-func (s *RetryLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfileByIds(ctx context.Context, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchInChannel(channelID, term, options)
+		result, err := s.UserStore.GetProfileByIds(ctx, userIds, options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -14114,11 +14366,11 @@ func (s *RetryLayerUserStore) SearchInChannel(channelID string, term string, opt
 
 }
 
-func (s *RetryLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchInGroup(groupID, term, options)
+		result, err := s.UserStore.GetProfiles(options)
 		if err == nil {
 			return result, nil
 		}
@@ -14135,11 +14387,11 @@ func (s *RetryLayerUserStore) SearchInGroup(groupID string, term string, options
 
 }
 
-func (s *RetryLayerUserStore) SearchNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchNotInChannel(teamID, channelID, term, options)
+		result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -14156,11 +14408,11 @@ func (s *RetryLayerUserStore) SearchNotInChannel(teamID string, channelID string
 
 }
 
-func (s *RetryLayerUserStore) SearchNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchNotInGroup(groupID, term, options)
+		result, err := s.UserStore.GetProfilesInChannel(options)
 		if err == nil {
 			return result, nil
 		}
@@ -14177,11 +14429,11 @@ func (s *RetryLayerUserStore) SearchNotInGroup(groupID string, term string, opti
 
 }
 
-func (s *RetryLayerUserStore) SearchNotInTeam(notInTeamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfilesInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchNotInTeam(notInTeamID, term, options)
+		result, err := s.UserStore.GetProfilesInChannelByAdmin(options)
 		if err == nil {
 			return result, nil
 		}
@@ -14198,11 +14450,11 @@ func (s *RetryLayerUserStore) SearchNotInTeam(notInTeamID string, term string, o
 
 }
 
-func (s *RetryLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+func (s *RetryLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.SearchWithoutTeam(term, options)
+		result, err := s.UserStore.GetProfilesInChannelByStatus(options)
 		if err == nil {
 			return result, nil
 		}
@@ -14219,11 +14471,11 @@ func (s *RetryLayerUserStore) SearchWithoutTeam(term string, options *model.User
 
 }
 
-func (s *RetryLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+func (s *RetryLayerUserStore) GetProfilesNotInChannel(teamID string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
+		result, err := s.UserStore.GetProfilesNotInChannel(teamID, channelId, groupConstrained, offset, limit, viewRestrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -14240,11 +14492,11 @@ func (s *RetryLayerUserStore) Update(rctx request.CTX, user *model.User, allowRo
 
 }
 
-func (s *RetryLayerUserStore) UpdateAuthData(userID string, service string, authData *string, email string, resetMfa bool) (string, error) {
+func (s *RetryLayerUserStore) GetProfilesNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.UpdateAuthData(userID, service, authData, email, resetMfa)
+		result, err := s.UserStore.GetProfilesNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -14261,95 +14513,974 @@ func (s *RetryLayerUserStore) UpdateAuthData(userID string, service string, auth
 
 }
 
-func (s *RetryLayerUserStore) UpdateFailedPasswordAttempts(userID string, attempts int) error {
+func (s *RetryLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateFailedPasswordAttempts(userID, attempts)
+		result, err := s.UserStore.GetProfilesWithoutTeam(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamID, offset, limit, viewRestrictions)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetSystemAdminProfiles()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetTeamGroupUsers(teamID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUnreadCount(userID string, isCRTEnabled bool) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUnreadCount(userID, isCRTEnabled)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUnreadCountForChannel(userID string, channelID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUnreadCountForChannel(userID, channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUserCountForReport(filter *model.UserReportOptions) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUserCountForReport(filter)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUserReport(filter)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUsersBatchForIndexing(startTime, startFileID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUsersWithInvalidEmails(page, perPage, restrictedDomains)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) InferSystemInstallDate() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.InferSystemInstallDate()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) InsertUsers(users []*model.User) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.InsertUsers(users)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) InvalidateProfileCacheForUser(userID string) {
+
+	s.UserStore.InvalidateProfileCacheForUser(userID)
+
+}
+
+func (s *RetryLayerUserStore) InvalidateProfilesInChannelCache(channelID string) {
+
+	s.UserStore.InvalidateProfilesInChannelCache(channelID)
+
+}
+
+func (s *RetryLayerUserStore) InvalidateProfilesInChannelCacheByUser(userID string) {
+
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userID)
+
+}
+
+func (s *RetryLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.IsEmpty(excludeBots)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.PermanentDelete(rctx, userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) PromoteGuestToUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.PromoteGuestToUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) RefreshPostStatsForUsers() error {
+
+	tries := 0
+	for {
+		err := s.UserStore.RefreshPostStatsForUsers()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) ResetAuthDataToEmailForUsers(service string, userIDs []string, includeDeleted bool, dryRun bool) (int, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.ResetAuthDataToEmailForUsers(service, userIDs, includeDeleted, dryRun)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) ResetLastPictureUpdate(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.ResetLastPictureUpdate(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.Save(rctx, user)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.Search(rctx, teamID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchInChannel(channelID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchInGroup(groupID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchNotInChannel(teamID, channelID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchNotInGroup(groupID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchNotInTeam(notInTeamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchNotInTeam(notInTeamID, term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.SearchWithoutTeam(term, options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateAuthData(userID string, service string, authData *string, email string, resetMfa bool) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.UpdateAuthData(userID, service, authData, email, resetMfa)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateFailedPasswordAttempts(userID string, attempts int) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateFailedPasswordAttempts(userID, attempts)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateLastLogin(userID string, lastLogin int64) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateLastLogin(userID, lastLogin)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateLastPictureUpdate(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateLastPictureUpdate(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateMfaActive(userID string, active bool) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateMfaActive(userID, active)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateMfaSecret(userID string, secret string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateMfaSecret(userID, secret)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateNotifyProps(userID string, props map[string]string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdateNotifyProps(userID, props)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdatePassword(userID string, newPassword string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdatePassword(userID, newPassword)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.UpdateUpdateAt(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.VerifyEmail(userID, email)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.Delete(tokenID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.Get(tokenID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetByToken(tokenString)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) UpdateLastLogin(userID string, lastLogin int64) error {
+func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateLastLogin(userID, lastLogin)
+		result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) UpdateLastPictureUpdate(userID string) error {
+func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateLastPictureUpdate(userID)
+		result, err := s.UserAccessTokenStore.Save(token)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) UpdateMfaActive(userID string, active bool) error {
+func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateMfaActive(userID, active)
+		result, err := s.UserAccessTokenStore.Search(term)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserStore) UpdateMfaSecret(userID string, secret string) error {
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateMfaSecret(userID, secret)
+		err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
 		if err == nil {
 			return nil
 		}
@@ -14366,11 +15497,11 @@ func (s *RetryLayerUserStore) UpdateMfaSecret(userID string, secret string) erro
 
 }
 
-func (s *RetryLayerUserStore) UpdateNotifyProps(userID string, props map[string]string) error {
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdateNotifyProps(userID, props)
+		err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
 		if err == nil {
 			return nil
 		}
@@ -14387,11 +15518,11 @@ func (s *RetryLayerUserStore) UpdateNotifyProps(userID string, props map[string]
 
 }
 
-func (s *RetryLayerUserStore) UpdatePassword(userID string, newPassword string) error {
+func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceId string) error {
 
 	tries := 0
 	for {
-		err := s.UserStore.UpdatePassword(userID, newPassword)
+		err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceId)
 		if err == nil {
 			return nil
 		}
@@ -14408,11 +15539,11 @@ func (s *RetryLayerUserStore) UpdatePassword(userID string, newPassword string)
 
 }
 
-func (s *RetryLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
+func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.UpdateUpdateAt(userID)
+		result, err := s.UserTermsOfServiceStore.GetByUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -14429,11 +15560,11 @@ func (s *RetryLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.VerifyEmail(userID, email)
+		result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
 		if err == nil {
 			return result, nil
 		}
@@ -14450,11 +15581,11 @@ func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string,
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
+func (s *RetryLayerWebAuthnCredentialStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.Delete(tokenID)
+		err := s.WebAuthnCredentialStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -14471,32 +15602,32 @@ func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+func (s *RetryLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+		result, err := s.WebAuthnCredentialStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+func (s *RetryLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Get(tokenID)
+		result, err := s.WebAuthnCredentialStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -14513,32 +15644,32 @@ func (s *RetryLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessT
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+		err := s.WebAuthnCredentialStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+func (s *RetryLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+		result, err := s.WebAuthnCredentialStore.Save(credential)
 		if err == nil {
 			return result, nil
 		}
@@ -14555,32 +15686,32 @@ func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
+		err := s.WebAuthnCredentialStore.UpdateLastUsed(id, signCount, lastUsedAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+func (s *RetryLayerWebhookStore) AnalyticsIncomingCount(teamID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Save(token)
+		result, err := s.WebhookStore.AnalyticsIncomingCount(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -14597,11 +15728,11 @@ func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*mo
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Search(term)
+		result, err := s.WebhookStore.AnalyticsOutgoingCount(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -14618,32 +15749,38 @@ func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
+func (s *RetryLayerWebhookStore) ClaimOutgoingDelivery(id string, nextAttemptAt int64, until int64) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
+		result, err := s.WebhookStore.ClaimOutgoingDelivery(id, nextAttemptAt, until)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
+func (s *RetryLayerWebhookStore) ClearCaches() {
+
+	s.WebhookStore.ClearCaches()
+
+}
+
+func (s *RetryLayerWebhookStore) DeleteIncoming(webhookID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
+		err := s.WebhookStore.DeleteIncoming(webhookID, timestamp)
 		if err == nil {
 			return nil
 		}
@@ -14660,11 +15797,11 @@ func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceId string) error {
+func (s *RetryLayerWebhookStore) DeleteOutgoing(webhookID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceId)
+		err := s.WebhookStore.DeleteOutgoing(webhookID, timestamp)
 		if err == nil {
 			return nil
 		}
@@ -14681,11 +15818,11 @@ func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfService
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
+func (s *RetryLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserTermsOfServiceStore.GetByUser(userID)
+		result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -14702,11 +15839,11 @@ func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.Use
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+func (s *RetryLayerWebhookStore) GetIncomingByChannel(channelID string) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+		result, err := s.WebhookStore.GetIncomingByChannel(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -14723,11 +15860,11 @@ func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 
 }
 
-func (s *RetryLayerWebhookStore) AnalyticsIncomingCount(teamID string) (int64, error) {
+func (s *RetryLayerWebhookStore) GetIncomingByTeam(teamID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.AnalyticsIncomingCount(teamID)
+		result, err := s.WebhookStore.GetIncomingByTeam(teamID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14744,11 +15881,11 @@ func (s *RetryLayerWebhookStore) AnalyticsIncomingCount(teamID string) (int64, e
 
 }
 
-func (s *RetryLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, error) {
+func (s *RetryLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.AnalyticsOutgoingCount(teamID)
+		result, err := s.WebhookStore.GetIncomingByTeamByUser(teamID, userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14765,59 +15902,53 @@ func (s *RetryLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, e
 
 }
 
-func (s *RetryLayerWebhookStore) ClearCaches() {
-
-	s.WebhookStore.ClearCaches()
-
-}
-
-func (s *RetryLayerWebhookStore) DeleteIncoming(webhookID string, timestamp int64) error {
+func (s *RetryLayerWebhookStore) GetIncomingDeletedSince(teamID string, since int64) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		err := s.WebhookStore.DeleteIncoming(webhookID, timestamp)
+		result, err := s.WebhookStore.GetIncomingDeletedSince(teamID, since)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerWebhookStore) DeleteOutgoing(webhookID string, timestamp int64) error {
+func (s *RetryLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		err := s.WebhookStore.DeleteOutgoing(webhookID, timestamp)
+		result, err := s.WebhookStore.GetIncomingList(offset, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetIncomingListByUser(userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
+		result, err := s.WebhookStore.GetIncomingListByUser(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14834,11 +15965,11 @@ func (s *RetryLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*m
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncomingByChannel(channelID string) ([]*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncomingByChannel(channelID)
+		result, err := s.WebhookStore.GetOutgoing(id)
 		if err == nil {
 			return result, nil
 		}
@@ -14855,11 +15986,11 @@ func (s *RetryLayerWebhookStore) GetIncomingByChannel(channelID string) ([]*mode
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncomingByTeam(teamID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingByChannel(channelID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncomingByTeam(teamID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingByChannel(channelID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14876,11 +16007,11 @@ func (s *RetryLayerWebhookStore) GetIncomingByTeam(teamID string, offset int, li
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingByChannelByUser(channelID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncomingByTeamByUser(teamID, userID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelID, userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14897,11 +16028,11 @@ func (s *RetryLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID s
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingByTeam(teamID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncomingList(offset, limit)
+		result, err := s.WebhookStore.GetOutgoingByTeam(teamID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14918,11 +16049,11 @@ func (s *RetryLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*mode
 
 }
 
-func (s *RetryLayerWebhookStore) GetIncomingListByUser(userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetIncomingListByUser(userID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamID, userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14939,11 +16070,11 @@ func (s *RetryLayerWebhookStore) GetIncomingListByUser(userID string, offset int
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingDeletedSince(teamID string, since int64) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoing(id)
+		result, err := s.WebhookStore.GetOutgoingDeletedSince(teamID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -14960,11 +16091,11 @@ func (s *RetryLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook,
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingByChannel(channelID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingByChannel(channelID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingDeliveriesByHook(hookID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -14981,11 +16112,11 @@ func (s *RetryLayerWebhookStore) GetOutgoingByChannel(channelID string, offset i
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingByChannelByUser(channelID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelID, userID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingDelivery(id)
 		if err == nil {
 			return result, nil
 		}
@@ -15002,11 +16133,11 @@ func (s *RetryLayerWebhookStore) GetOutgoingByChannelByUser(channelID string, us
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingByTeam(teamID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingByTeam(teamID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingList(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -15023,11 +16154,11 @@ func (s *RetryLayerWebhookStore) GetOutgoingByTeam(teamID string, offset int, li
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetOutgoingListByUser(userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamID, userID, offset, limit)
+		result, err := s.WebhookStore.GetOutgoingListByUser(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -15044,11 +16175,11 @@ func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) GetPendingOutgoingDeliveries(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingList(offset, limit)
+		result, err := s.WebhookStore.GetPendingOutgoingDeliveries(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -15065,11 +16196,11 @@ func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*mode
 
 }
 
-func (s *RetryLayerWebhookStore) GetOutgoingListByUser(userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+func (s *RetryLayerWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.WebhookStore.GetOutgoingListByUser(userID, offset, limit)
+		result, err := s.WebhookStore.ImportIncoming(webhook)
 		if err == nil {
 			return result, nil
 		}
@@ -15176,6 +16307,27 @@ func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 
 }
 
+func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -15218,6 +16370,27 @@ func (s *RetryLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 
 }
 
+func (s *RetryLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -15260,6 +16433,27 @@ func (s *RetryLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 
 }
 
+func (s *RetryLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayer) Close() {
 	s.Store.Close()
 }
@@ -15302,6 +16496,8 @@ func New(childStore store.Store) *RetryLayer {
 	}
 
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditRecordStore = &RetryLayerAuditRecordStore{AuditRecordStore: childStore.AuditRecord(), Root: &newStore}
+	newStore.BatchedNotificationStore = &RetryLayerBatchedNotificationStore{BatchedNotificationStore: childStore.BatchedNotification(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &RetryLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
@@ -15318,6 +16514,8 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &RetryLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
+	newStore.MfaResetRequestStore = &RetryLayerMfaResetRequestStore{MfaResetRequestStore: childStore.MfaResetRequest(), Root: &newStore}
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
@@ -15332,6 +16530,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.RemoteClusterStore = &RetryLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &RetryLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledPostStore = &RetryLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
 	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &RetryLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
@@ -15345,6 +16544,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.UserStore = &RetryLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &RetryLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &RetryLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &RetryLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &RetryLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
@@ -275,6 +275,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchAcrossTeams,
 		Tags: []string{EngineAll},
 	},
+	{
+		Name: "Should order results by relevance and highlight matches",
+		Fn:   testSearchOrderByRelevance,
+		Tags: []string{EnginePostgres, EngineMySQL},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -1955,3 +1960,47 @@ func testSearchAcrossTeams(t *testing.T, th *SearchTestHelper) {
 
 	require.Len(t, results.Posts, 2)
 }
+
+func testSearchOrderByRelevance(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "deploy deploy deploy the release", "", model.PostTypeDefault, 1000000, false)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "we should deploy soon", "", model.PostTypeDefault, 2000000, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	t.Run("Should order by date by default", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "deploy"}
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{p2.Id, p1.Id}, results.Order)
+	})
+
+	t.Run("Should order by relevance", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "deploy", OrderBy: model.SearchOrderByRelevance}
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{p1.Id, p2.Id}, results.Order)
+	})
+
+	t.Run("Should merge the results of several terms by relevance", func(t *testing.T) {
+		paramsList := []*model.SearchParams{
+			{Terms: "soon", OrderBy: model.SearchOrderByRelevance},
+			{Terms: "deploy", OrderBy: model.SearchOrderByRelevance},
+		}
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, paramsList, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{p1.Id, p2.Id}, results.Order)
+	})
+
+	t.Run("Should highlight matches", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "deploy"}
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"deploy"}, results.Matches[p2.Id])
+		require.Equal(t, []string{"we should <mark>deploy</mark> soon"}, results.Highlights[p2.Id])
+	})
+}
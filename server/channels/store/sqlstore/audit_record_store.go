@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlAuditRecordStore struct {
+	*SqlStore
+}
+
+func newSqlAuditRecordStore(sqlStore *SqlStore) store.AuditRecordStore {
+	return &SqlAuditRecordStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func auditRecordSliceColumns() []string {
+	return []string{
+		"Id",
+		"ChainId",
+		"Sequence",
+		"CreateAt",
+		"Level",
+		"EventName",
+		"Status",
+		"UserId",
+		"Data",
+		"PrevHash",
+		"Hash",
+	}
+}
+
+func auditCheckpointSliceColumns() []string {
+	return []string{
+		"Id",
+		"ChainId",
+		"Sequence",
+		"Hash",
+		"CreateAt",
+		"Signature",
+	}
+}
+
+func (s *SqlAuditRecordStore) Save(record *model.AuditRecord) (*model.AuditRecord, error) {
+	record.PreSave()
+	if err := record.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("AuditRecords").
+		Columns(auditRecordSliceColumns()...).
+		Values(record.Id, record.ChainId, record.Sequence, record.CreateAt, record.Level, record.EventName, record.Status, record.UserId, record.Data, record.PrevHash, record.Hash)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save AuditRecord with chain_id=%s sequence=%d", record.ChainId, record.Sequence)
+	}
+
+	return record, nil
+}
+
+func (s *SqlAuditRecordStore) GetAll(filter *model.AuditRecordFilter) ([]*model.AuditRecord, error) {
+	query := s.getQueryBuilder().
+		Select(auditRecordSliceColumns()...).
+		From("AuditRecords").
+		OrderBy("CreateAt", "ChainId", "Sequence").
+		Offset(uint64(filter.Page * filter.PerPage)).
+		Limit(uint64(filter.PerPage))
+
+	if filter.ChainId != "" {
+		query = query.Where(sq.Eq{"ChainId": filter.ChainId})
+	}
+	if filter.UserId != "" {
+		query = query.Where(sq.Eq{"UserId": filter.UserId})
+	}
+	if filter.EventName != "" {
+		query = query.Where(sq.Eq{"EventName": filter.EventName})
+	}
+	if filter.Since > 0 {
+		query = query.Where(sq.GtOrEq{"CreateAt": filter.Since})
+	}
+	if filter.Until > 0 {
+		query = query.Where(sq.LtOrEq{"CreateAt": filter.Until})
+	}
+
+	records := []*model.AuditRecord{}
+	if err := s.GetReplicaX().SelectBuilder(&records, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get AuditRecords")
+	}
+
+	return records, nil
+}
+
+func (s *SqlAuditRecordStore) SaveCheckpoint(checkpoint *model.AuditCheckpoint) (*model.AuditCheckpoint, error) {
+	checkpoint.PreSave()
+	if err := checkpoint.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("AuditCheckpoints").
+		Columns(auditCheckpointSliceColumns()...).
+		Values(checkpoint.Id, checkpoint.ChainId, checkpoint.Sequence, checkpoint.Hash, checkpoint.CreateAt, checkpoint.Signature)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save AuditCheckpoint with chain_id=%s sequence=%d", checkpoint.ChainId, checkpoint.Sequence)
+	}
+
+	return checkpoint, nil
+}
+
+func (s *SqlAuditRecordStore) GetCheckpoints(chainID string, offset, limit int) ([]*model.AuditCheckpoint, error) {
+	query := s.getQueryBuilder().
+		Select(auditCheckpointSliceColumns()...).
+		From("AuditCheckpoints").
+		OrderBy("CreateAt", "ChainId", "Sequence").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	if chainID != "" {
+		query = query.Where(sq.Eq{"ChainId": chainID})
+	}
+
+	checkpoints := []*model.AuditCheckpoint{}
+	if err := s.GetReplicaX().SelectBuilder(&checkpoints, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get AuditCheckpoints")
+	}
+
+	return checkpoints, nil
+}
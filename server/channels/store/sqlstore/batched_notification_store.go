@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlBatchedNotificationStore struct {
+	*SqlStore
+}
+
+func newSqlBatchedNotificationStore(sqlStore *SqlStore) store.BatchedNotificationStore {
+	return &SqlBatchedNotificationStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func batchedNotificationSliceColumns() []string {
+	return []string{
+		"Id",
+		"UserId",
+		"PostId",
+		"TeamName",
+		"CreateAt",
+	}
+}
+
+func (s *SqlBatchedNotificationStore) Save(notification *model.BatchedNotification) (*model.BatchedNotification, error) {
+	notification.PreSave()
+	if err := notification.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("BatchedNotifications").
+		Columns(batchedNotificationSliceColumns()...).
+		Values(notification.Id, notification.UserId, notification.PostId, notification.TeamName, notification.CreateAt)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save BatchedNotification with id=%s", notification.Id)
+	}
+
+	return notification, nil
+}
+
+func (s *SqlBatchedNotificationStore) GetUsersWithPending(afterUserID string, limit int) ([]string, error) {
+	query := s.getQueryBuilder().
+		Select("DISTINCT UserId").
+		From("BatchedNotifications").
+		Where(sq.Gt{"UserId": afterUserID}).
+		OrderBy("UserId").
+		Limit(uint64(limit))
+
+	userIDs := []string{}
+	if err := s.GetMasterX().SelectBuilder(&userIDs, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get users with pending BatchedNotifications")
+	}
+
+	return userIDs, nil
+}
+
+func (s *SqlBatchedNotificationStore) GetForUser(userID string) ([]*model.BatchedNotification, error) {
+	query := s.getQueryBuilder().
+		Select(batchedNotificationSliceColumns()...).
+		From("BatchedNotifications").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt", "Id")
+
+	notifications := []*model.BatchedNotification{}
+	if err := s.GetMasterX().SelectBuilder(&notifications, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get BatchedNotifications for user_id=%s", userID)
+	}
+
+	return notifications, nil
+}
+
+func (s *SqlBatchedNotificationStore) Delete(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder().
+		Delete("BatchedNotifications").
+		Where(sq.Eq{"Id": ids})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to delete BatchedNotifications")
+	}
+
+	return nil
+}
+
+func (s *SqlBatchedNotificationStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("BatchedNotifications").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete BatchedNotifications for user_id=%s", userID)
+	}
+
+	return nil
+}
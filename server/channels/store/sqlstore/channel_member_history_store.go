@@ -267,3 +267,53 @@ func (s SqlChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since
 
 	return channelIds, nil
 }
+
+// GetMembershipChangesSince returns the channel membership history records for users that
+// joined or left the channel at or after the specified time, ordered by join time.
+func (s SqlChannelMemberHistoryStore) GetMembershipChangesSince(channelID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	query, params, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "JoinTime", "LeaveTime").
+		From("ChannelMemberHistory").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Or{
+				sq.GtOrEq{"JoinTime": since},
+				sq.GtOrEq{"LeaveTime": since},
+			},
+		}).
+		OrderBy("JoinTime ASC").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_history_to_sql")
+	}
+
+	histories := []*model.ChannelMemberHistoryResult{}
+	if err := s.GetReplicaX().Select(&histories, query, params...); err != nil {
+		return nil, errors.Wrapf(err, "GetMembershipChangesSince channelId=%s since=%d", channelID, since)
+	}
+	return histories, nil
+}
+
+// GetMembersLeftSince returns the users that left a channel of the team at or after the
+// specified time and haven't joined it again, along with the time they last left it.
+func (s SqlChannelMemberHistoryStore) GetMembersLeftSince(teamID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	query, params, err := s.getQueryBuilder().
+		Select("ChannelMemberHistory.ChannelId", "ChannelMemberHistory.UserId", "MAX(ChannelMemberHistory.LeaveTime) AS LeaveTime").
+		From("ChannelMemberHistory").
+		Join("Channels ON Channels.Id = ChannelMemberHistory.ChannelId").
+		LeftJoin("ChannelMembers ON ChannelMembers.ChannelId = ChannelMemberHistory.ChannelId AND ChannelMembers.UserId = ChannelMemberHistory.UserId").
+		Where(sq.And{
+			sq.Eq{"Channels.TeamId": teamID},
+			sq.GtOrEq{"ChannelMemberHistory.LeaveTime": since},
+			sq.Eq{"ChannelMembers.UserId": nil},
+		}).
+		GroupBy("ChannelMemberHistory.ChannelId", "ChannelMemberHistory.UserId").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_history_to_sql")
+	}
+
+	histories := []*model.ChannelMemberHistoryResult{}
+	if err := s.GetReplicaX().Select(&histories, query, params...); err != nil {
+		return nil, errors.Wrapf(err, "GetMembersLeftSince teamId=%s since=%d", teamID, since)
+	}
+	return histories, nil
+}
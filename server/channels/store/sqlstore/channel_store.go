@@ -4035,6 +4035,63 @@ func (s SqlChannelStore) GetChannelMembersForExport(userId string, teamId string
 	return members, nil
 }
 
+func (s SqlChannelStore) GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"Channels.Id AS ChannelId",
+			"Channels.Name AS ChannelName",
+			"Channels.DisplayName AS ChannelDisplayName",
+			"Channels.Type AS ChannelType",
+			"Channels.TeamId",
+			"COALESCE(Teams.Name, '') AS TeamName",
+			"Users.Id AS UserId",
+			"Users.Username",
+			"Users.Email",
+			"ChannelMembers.SchemeAdmin",
+			"(ChannelMembers.SchemeGuest IS NOT NULL AND ChannelMembers.SchemeGuest) AS SchemeGuest",
+			"ChannelMembers.LastViewedAt",
+		).
+		From("ChannelMembers").
+		Join("Channels ON ChannelMembers.ChannelId = Channels.Id").
+		Join("Users ON ChannelMembers.UserId = Users.Id").
+		LeftJoin("Teams ON Channels.TeamId = Teams.Id").
+		Where(sq.Eq{
+			"Channels.Type":     []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate},
+			"Channels.DeleteAt": 0,
+		}).
+		OrderBy("ChannelMembers.ChannelId", "ChannelMembers.UserId")
+
+	if filter.TeamId != "" {
+		query = query.Where(sq.Eq{"Channels.TeamId": filter.TeamId})
+	}
+
+	if filter.FromColumnValue != "" {
+		query = query.Where(sq.Or{
+			sq.Gt{"ChannelMembers.ChannelId": filter.FromColumnValue},
+			sq.And{
+				sq.Eq{"ChannelMembers.ChannelId": filter.FromColumnValue},
+				sq.Gt{"ChannelMembers.UserId": filter.FromId},
+			},
+		})
+	}
+
+	if filter.PageSize > 0 {
+		query = query.Limit(uint64(filter.PageSize))
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_membership_report_tosql")
+	}
+
+	rows := []*model.ChannelMembershipReport{}
+	if err := s.GetReplicaX().Select(&rows, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get the channel membership report")
+	}
+
+	return rows, nil
+}
+
 func (s SqlChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectChannelForExport, error) {
 	directChannelsForExport := []*model.DirectChannelForExport{}
 	query := s.getQueryBuilder().
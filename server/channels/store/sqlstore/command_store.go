@@ -87,6 +87,26 @@ func (s SqlCommandStore) GetByTeam(teamId string) ([]*model.Command, error) {
 	return commands, nil
 }
 
+// GetDeletedByTeamSince returns the commands of the team deleted at or after the given time.
+func (s SqlCommandStore) GetDeletedByTeamSince(teamId string, since int64) ([]*model.Command, error) {
+	commands := []*model.Command{}
+
+	sql, args, err := s.commandsQuery.
+		Where(sq.And{
+			sq.Eq{"TeamId": teamId},
+			sq.NotEq{"DeleteAt": 0},
+			sq.GtOrEq{"DeleteAt": since},
+		}).ToSql()
+	if err != nil {
+		return nil, errors.Wrapf(err, "commands_tosql")
+	}
+	if err := s.GetReplicaX().Select(&commands, sql, args...); err != nil {
+		return nil, errors.Wrapf(err, "select: team_id=%s since=%d", teamId, since)
+	}
+
+	return commands, nil
+}
+
 func (s SqlCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, error) {
 	var command model.Command
 	var triggerStr string
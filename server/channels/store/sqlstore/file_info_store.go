@@ -720,6 +720,37 @@ func (fs SqlFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID
 	return files, nil
 }
 
+// GetChannelFilesBatchForIndexing returns, like GetFilesBatchForIndexing, the next batch of
+// files to index, restricted to the given channel.
+func (fs SqlFileInfoStore) GetChannelFilesBatchForIndexing(channelID string, startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+	files := []*model.FileForIndexing{}
+
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Where(sq.Eq{"FileInfo.ChannelId": channelID}).
+		Where(sq.Or{
+			sq.Gt{"FileInfo.CreateAt": startTime},
+			sq.And{
+				sq.Eq{"FileInfo.CreateAt": startTime},
+				sq.Gt{"FileInfo.Id": startFileID},
+			},
+		}).
+		OrderBy("FileInfo.CreateAt ASC, FileInfo.Id ASC").
+		Limit(uint64(limit))
+
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"FileInfo.DeleteAt": 0})
+	}
+
+	err := fs.GetSearchReplicaX().SelectBuilder(&files, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find Files with channelId=%s", channelID)
+	}
+
+	return files, nil
+}
+
 func (fs SqlFileInfoStore) GetStorageUsage(allowFromCache, includeDeleted bool) (int64, error) {
 	query := fs.getQueryBuilder().
 		Select("COALESCE(SUM(Size), 0)").
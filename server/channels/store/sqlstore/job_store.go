@@ -42,8 +42,8 @@ func (jss SqlJobStore) Save(job *model.Job) (*model.Job, error) {
 	}
 	query := jss.getQueryBuilder().
 		Insert("Jobs").
-		Columns("Id", "Type", "Priority", "CreateAt", "StartAt", "LastActivityAt", "Status", "Progress", "Data").
-		Values(job.Id, job.Type, job.Priority, job.CreateAt, job.StartAt, job.LastActivityAt, job.Status, job.Progress, jsonData)
+		Columns("Id", "Type", "Priority", "CreateAt", "StartAt", "LastActivityAt", "Status", "Progress", "Data", "ParentId", "RootId", "RetryCount", "RunAfter").
+		Values(job.Id, job.Type, job.Priority, job.CreateAt, job.StartAt, job.LastActivityAt, job.Status, job.Progress, jsonData, job.ParentId, job.RootId, job.RetryCount, job.RunAfter)
 
 	queryString, args, err := query.ToSql()
 	if err != nil {
@@ -97,8 +97,8 @@ func (jss SqlJobStore) SaveOnce(job *model.Job) (*model.Job, error) {
 
 	query, args, err = jss.getQueryBuilder().
 		Insert("Jobs").
-		Columns("Id", "Type", "Priority", "CreateAt", "StartAt", "LastActivityAt", "Status", "Progress", "Data").
-		Values(job.Id, job.Type, job.Priority, job.CreateAt, job.StartAt, job.LastActivityAt, job.Status, job.Progress, jsonData).ToSql()
+		Columns("Id", "Type", "Priority", "CreateAt", "StartAt", "LastActivityAt", "Status", "Progress", "Data", "ParentId", "RootId", "RetryCount", "RunAfter").
+		Values(job.Id, job.Type, job.Priority, job.CreateAt, job.StartAt, job.LastActivityAt, job.Status, job.Progress, jsonData, job.ParentId, job.RootId, job.RetryCount, job.RunAfter).ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate sqlquery")
 	}
@@ -316,6 +316,29 @@ func (jss SqlJobStore) GetAllByStatus(c request.CTX, status string) ([]*model.Jo
 	return statuses, nil
 }
 
+// GetAllByRootId returns the jobs of the lineage started by the given job, including
+// that job, from the oldest to the newest.
+func (jss SqlJobStore) GetAllByRootId(c request.CTX, rootID string) ([]*model.Job, error) {
+	query, args, err := jss.getQueryBuilder().
+		Select("*").
+		From("Jobs").
+		Where(sq.Or{
+			sq.Eq{"Id": rootID},
+			sq.Eq{"RootId": rootID},
+		}).
+		OrderBy("CreateAt ASC", "Id ASC").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "job_tosql")
+	}
+
+	jobs := []*model.Job{}
+	if err = jss.GetReplicaX().Select(&jobs, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Jobs with rootId=%s", rootID)
+	}
+
+	return jobs, nil
+}
+
 func (jss SqlJobStore) GetAllByTypeAndStatusPage(c request.CTX, jobType []string, status string, offset int, limit int) ([]*model.Job, error) {
 	query, args, err := jss.getQueryBuilder().
 		Select("*").
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlMfaRecoveryCodeStore struct {
+	*SqlStore
+}
+
+func newSqlMfaRecoveryCodeStore(sqlStore *SqlStore) store.MfaRecoveryCodeStore {
+	return &SqlMfaRecoveryCodeStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func mfaRecoveryCodeSliceColumns() []string {
+	return []string{
+		"Id",
+		"UserId",
+		"CodeHash",
+		"CreateAt",
+		"UsedAt",
+	}
+}
+
+func (s *SqlMfaRecoveryCodeStore) SaveForUser(userID string, codes []*model.MfaRecoveryCode) (err error) {
+	query := s.getQueryBuilder().
+		Insert("MfaRecoveryCodes").
+		Columns(mfaRecoveryCodeSliceColumns()...)
+
+	for _, code := range codes {
+		code.UserId = userID
+		code.PreSave()
+		if appErr := code.IsValid(); appErr != nil {
+			return appErr
+		}
+		query = query.Values(code.Id, code.UserId, code.CodeHash, code.CreateAt, code.UsedAt)
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID})
+	if _, err = transaction.ExecBuilder(deleteQuery); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes for user_id=%s", userID)
+	}
+
+	if len(codes) > 0 {
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save MfaRecoveryCodes for user_id=%s", userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) GetForUser(userID string) ([]*model.MfaRecoveryCode, error) {
+	query := s.getQueryBuilder().
+		Select(mfaRecoveryCodeSliceColumns()...).
+		From("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt", "Id")
+
+	codes := []*model.MfaRecoveryCode{}
+	if err := s.GetMasterX().SelectBuilder(&codes, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get MfaRecoveryCodes for user_id=%s", userID)
+	}
+
+	return codes, nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) MarkUsed(id string, usedAt int64) error {
+	query := s.getQueryBuilder().
+		Update("MfaRecoveryCodes").
+		Set("UsedAt", usedAt).
+		Where(sq.Eq{"Id": id, "UsedAt": 0})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update MfaRecoveryCode with id=%s", id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get the number of updated MfaRecoveryCodes")
+	} else if rows == 0 {
+		return store.NewErrNotFound("MfaRecoveryCode", id)
+	}
+
+	return nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes for user_id=%s", userID)
+	}
+
+	return nil
+}
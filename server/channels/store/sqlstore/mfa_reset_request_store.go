@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlMfaResetRequestStore struct {
+	*SqlStore
+}
+
+func newSqlMfaResetRequestStore(sqlStore *SqlStore) store.MfaResetRequestStore {
+	return &SqlMfaResetRequestStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func mfaResetRequestSliceColumns() []string {
+	return []string{
+		"Id",
+		"UserId",
+		"Status",
+		"CreateAt",
+		"UpdateAt",
+		"ResolvedBy",
+	}
+}
+
+func (s *SqlMfaResetRequestStore) Save(request *model.MfaResetRequest) (*model.MfaResetRequest, error) {
+	request.PreSave()
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("MfaResetRequests").
+		Columns(mfaResetRequestSliceColumns()...).
+		Values(request.Id, request.UserId, request.Status, request.CreateAt, request.UpdateAt, request.ResolvedBy)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save MfaResetRequest with id=%s", request.Id)
+	}
+
+	return request, nil
+}
+
+func (s *SqlMfaResetRequestStore) Get(id string) (*model.MfaResetRequest, error) {
+	query := s.getQueryBuilder().
+		Select(mfaResetRequestSliceColumns()...).
+		From("MfaResetRequests").
+		Where(sq.Eq{"Id": id})
+
+	var request model.MfaResetRequest
+	if err := s.GetMasterX().GetBuilder(&request, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("MfaResetRequest", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get MfaResetRequest with id=%s", id)
+	}
+
+	return &request, nil
+}
+
+func (s *SqlMfaResetRequestStore) GetPendingForUser(userID string) (*model.MfaResetRequest, error) {
+	query := s.getQueryBuilder().
+		Select(mfaResetRequestSliceColumns()...).
+		From("MfaResetRequests").
+		Where(sq.Eq{"UserId": userID, "Status": model.MfaResetRequestStatusPending}).
+		OrderBy("CreateAt DESC").
+		Limit(1)
+
+	var request model.MfaResetRequest
+	if err := s.GetMasterX().GetBuilder(&request, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("MfaResetRequest", "user_id="+userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get the pending MfaResetRequest for user_id=%s", userID)
+	}
+
+	return &request, nil
+}
+
+func (s *SqlMfaResetRequestStore) GetAll(status string, offset, limit int) ([]*model.MfaResetRequest, error) {
+	query := s.getQueryBuilder().
+		Select(mfaResetRequestSliceColumns()...).
+		From("MfaResetRequests").
+		OrderBy("CreateAt DESC", "Id").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	if status != "" {
+		query = query.Where(sq.Eq{"Status": status})
+	}
+
+	requests := []*model.MfaResetRequest{}
+	if err := s.GetReplicaX().SelectBuilder(&requests, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get MfaResetRequests")
+	}
+
+	return requests, nil
+}
+
+func (s *SqlMfaResetRequestStore) Resolve(id, status, resolvedBy string, updateAt int64) error {
+	query := s.getQueryBuilder().
+		Update("MfaResetRequests").
+		Set("Status", status).
+		Set("ResolvedBy", resolvedBy).
+		Set("UpdateAt", updateAt).
+		Where(sq.Eq{"Id": id, "Status": model.MfaResetRequestStatusPending})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update MfaResetRequest with id=%s", id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get the number of updated MfaResetRequests")
+	} else if rows == 0 {
+		return store.NewErrNotFound("MfaResetRequest", id)
+	}
+
+	return nil
+}
+
+func (s *SqlMfaResetRequestStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("MfaResetRequests").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaResetRequests for user_id=%s", userID)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/store/searchlayer"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 )
 
 // Regex to get quoted strings
@@ -2019,13 +2021,28 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 	return s.search(teamId, userId, params, true, true)
 }
 
+// searchPost is a post found by a search, along with its relevance score when the search is
+// ordered by relevance.
+type searchPost struct {
+	model.Post
+	Score float64
+}
+
 func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, error) {
+	list, _, err := s.searchWithScores(teamId, userId, params, channelsByName, userByUsername)
+	return list, err
+}
+
+// searchWithScores searches posts like search, also returning the relevance score of each
+// post by id when the search is ordered by relevance.
+func (s *SqlPostStore) searchWithScores(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, map[string]float64, error) {
 	list := model.NewPostList()
+	scores := map[string]float64{}
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
 		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" {
-		return list, nil
+		return list, scores, nil
 	}
 
 	baseQuery := s.getQueryBuilder().Select(
@@ -2034,13 +2051,12 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	).From("Posts q2").
 		Where("q2.DeleteAt = 0").
 		Where(fmt.Sprintf("q2.Type NOT LIKE '%s%%'", model.PostSystemMessagePrefix)).
-		OrderByClause("q2.CreateAt DESC").
 		Limit(100)
 
 	var err error
 	baseQuery, err = s.buildSearchPostFilterClause(teamId, params.FromUsers, params.ExcludedUsers, userByUsername, baseQuery)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to build search post filter clause")
+		return nil, nil, errors.Wrap(err, "failed to build search post filter clause")
 	}
 	baseQuery = s.buildCreateDateFilterClause(params, baseQuery)
 
@@ -2105,15 +2121,20 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 
 		searchClause := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@  to_tsquery('%[1]s', ?)", s.pgDefaultTextSearchConfig, searchType)
 		baseQuery = baseQuery.Where(searchClause, tsQueryClause)
+
+		if params.IsOrderedByRelevance() && terms != "" {
+			rankClause := fmt.Sprintf("ts_rank(to_tsvector('%[1]s', %[2]s), to_tsquery('%[1]s', ?)) AS Score", s.pgDefaultTextSearchConfig, searchType)
+			baseQuery = baseQuery.Column(rankClause, tsQueryClause).OrderBy("Score DESC")
+		}
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		if searchType == "Message" {
 			terms, err = removeMysqlStopWordsFromTerms(terms)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to remove Mysql stop-words from terms")
+				return nil, nil, errors.Wrap(err, "failed to remove Mysql stop-words from terms")
 			}
 
 			if terms == "" {
-				return list, nil
+				return list, scores, nil
 			}
 		}
 
@@ -2140,7 +2161,12 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		}
 
 		baseQuery = baseQuery.Where(searchClause, termsClause)
+
+		if params.IsOrderedByRelevance() && terms != "" {
+			baseQuery = baseQuery.Column(searchClause+" AS Score", termsClause).OrderBy("Score DESC")
+		}
 	}
+	baseQuery = baseQuery.OrderByClause("q2.CreateAt DESC")
 
 	inQuery := s.getSubQueryBuilder().Select("Id").
 		From("Channels, ChannelMembers").
@@ -2160,17 +2186,17 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 
 	inQueryClause, inQueryClauseArgs, err := inQuery.ToSql()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	baseQuery = baseQuery.Where(fmt.Sprintf("ChannelId IN (%s)", inQueryClause), inQueryClauseArgs...)
 
 	searchQuery, searchQueryArgs, err := baseQuery.ToSql()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var posts []*model.Post
+	var posts []*searchPost
 
 	if err := s.GetSearchReplicaX().Select(&posts, searchQuery, searchQueryArgs...); err != nil {
 		mlog.Warn("Query error searching posts.", mlog.String("error", trimInput(err.Error())))
@@ -2189,12 +2215,13 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 					continue
 				}
 			}
-			list.AddPost(p)
+			list.AddPost(&p.Post)
 			list.AddOrder(p.Id)
+			scores[p.Id] = p.Score
 		}
 	}
 	list.MakeNonNil()
-	return list, nil
+	return list, scores, nil
 }
 
 func removeMysqlStopWordsFromTerms(terms string) (string, error) {
@@ -2511,6 +2538,31 @@ func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, startPostID str
 	return posts, nil
 }
 
+// GetChannelPostsBatchForIndexing returns, like GetPostsBatchForIndexing, the next batch of posts
+// to index, restricted to the given channel.
+func (s *SqlPostStore) GetChannelPostsBatchForIndexing(channelID string, startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+	query := s.getQueryBuilder().
+		Select("Posts.*", "Channels.TeamId").
+		From("Posts").
+		LeftJoin("Channels ON Posts.ChannelId = Channels.Id").
+		Where(sq.Eq{"Posts.ChannelId": channelID}).
+		Where(sq.Or{
+			sq.Gt{"Posts.CreateAt": startTime},
+			sq.And{
+				sq.Eq{"Posts.CreateAt": startTime},
+				sq.Gt{"Posts.Id": startPostID},
+			},
+		}).
+		OrderBy("Posts.CreateAt ASC", "Posts.Id ASC").
+		Limit(uint64(limit))
+
+	posts := []*model.PostForIndexing{}
+	if err := s.GetSearchReplicaX().SelectBuilder(&posts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", channelID)
+	}
+	return posts, nil
+}
+
 // PermanentDeleteBatchForRetentionPolicies deletes a batch of records which are affected by
 // the global or a granular retention policy.
 // See `genericPermanentDeleteBatchForRetentionPolicies` for details.
@@ -2626,21 +2678,27 @@ func (s *SqlPostStore) GetMaxPostSize() int {
 	return s.maxPostSizeCached
 }
 
-func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool) ([]*model.PostForExport, error) {
+func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, since int64, includeArchivedChannel bool) ([]*model.PostForExport, error) {
 	for {
+		rootIdsQuery := s.getQueryBuilder().
+			Select("Id").
+			From("Posts").
+			Where(sq.And{
+				sq.Gt{"Posts.Id": afterId},
+				sq.Eq{"Posts.RootId": ""},
+				sq.Eq{"Posts.DeleteAt": 0},
+			}).
+			OrderBy("Posts.Id").
+			Limit(uint64(limit))
+
+		// The UpdateAt of a root post is bumped when its thread changes,
+		// so the replies don't need to be checked.
+		if since > 0 {
+			rootIdsQuery = rootIdsQuery.Where(sq.GtOrEq{"Posts.UpdateAt": since})
+		}
+
 		rootIds := []string{}
-		err := s.GetReplicaX().Select(&rootIds,
-			`SELECT
-				Id
-			FROM
-				Posts
-			WHERE
-				Posts.Id > ?
-				AND Posts.RootId = ''
-				AND Posts.DeleteAt = 0
-			ORDER BY Posts.Id
-			LIMIT ?`,
-			afterId, limit)
+		err := s.GetReplicaX().SelectBuilder(&rootIds, rootIdsQuery)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
@@ -2687,6 +2745,31 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 	}
 }
 
+// GetDeletedForExportAfter returns the root posts and replies deleted since the given time,
+// with the names needed to identify them in an import file.
+func (s *SqlPostStore) GetDeletedForExportAfter(limit int, afterId string, since int64) ([]*model.DeletedPostForExport, error) {
+	query := s.getQueryBuilder().
+		Select("p.Id", "p.ChannelId", "p.CreateAt", "p.DeleteAt", "Users.Username as Username", "Channels.Type as ChannelType", "Channels.Name as ChannelName", "COALESCE(Teams.Name, '') as TeamName").
+		From("Posts p").
+		InnerJoin("Channels ON p.ChannelId = Channels.Id").
+		InnerJoin("Users ON p.UserId = Users.Id").
+		LeftJoin("Teams ON Channels.TeamId = Teams.Id").
+		Where(sq.And{
+			sq.Gt{"p.Id": afterId},
+			sq.GtOrEq{"p.DeleteAt": since},
+			sq.NotEq{"p.DeleteAt": 0},
+		}).
+		OrderBy("p.Id").
+		Limit(uint64(limit))
+
+	posts := []*model.DeletedPostForExport{}
+	if err := s.GetReplicaX().SelectBuilder(&posts, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find deleted Posts")
+	}
+
+	return posts, nil
+}
+
 func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExport, error) {
 	posts := []*model.ReplyForExport{}
 	err := s.GetSearchReplicaX().Select(&posts, `
@@ -2709,7 +2792,7 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 	return posts, nil
 }
 
-func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, since int64, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
 	query := s.getQueryBuilder().
 		Select("p.*", "Users.Username as User").
 		From("Posts p").
@@ -2730,6 +2813,10 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 		)
 	}
 
+	if since > 0 {
+		query = query.Where(sq.GtOrEq{"p.UpdateAt": since})
+	}
+
 	queryString, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "post_tosql")
@@ -2797,6 +2884,7 @@ func (s *SqlPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.
 	var wg sync.WaitGroup
 
 	pchan := make(chan store.StoreResult[*model.PostList], len(paramsList))
+	schan := make(chan map[string]float64, len(paramsList))
 
 	for _, params := range paramsList {
 		// remove any unquoted term that contains only non-alphanumeric chars
@@ -2807,13 +2895,15 @@ func (s *SqlPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.
 
 		go func(params *model.SearchParams) {
 			defer wg.Done()
-			postList, err := s.search(teamId, userId, params, false, false)
+			postList, postScores, err := s.searchWithScores(teamId, userId, params, false, false)
 			pchan <- store.StoreResult[*model.PostList]{Data: postList, NErr: err}
+			schan <- postScores
 		}(params)
 	}
 
 	wg.Wait()
 	close(pchan)
+	close(schan)
 
 	posts := model.NewPostList()
 
@@ -2824,9 +2914,38 @@ func (s *SqlPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.
 		posts.Extend(result.Data)
 	}
 
+	// A post found by several params keeps its best score.
+	scores := map[string]float64{}
+	for postScores := range schan {
+		for id, score := range postScores {
+			if best, ok := scores[id]; !ok || score > best {
+				scores[id] = score
+			}
+		}
+	}
+
 	posts.SortByCreateAt()
+	if paramsList[0].IsOrderedByRelevance() {
+		// The sort is stable so that posts with the same score stay sorted by date.
+		sort.SliceStable(posts.Order, func(i, j int) bool {
+			return scores[posts.Order[i]] > scores[posts.Order[j]]
+		})
+	}
+
+	searchResults := model.MakePostSearchResults(posts, nil)
+	if highlighter := searchengine.NewSearchHighlighter(paramsList); !highlighter.IsEmpty() {
+		searchResults.Matches = model.PostSearchMatches{}
+		searchResults.Highlights = model.PostSearchHighlights{}
+		for id, post := range posts.Posts {
+			matches, fragments := highlighter.Highlight(post.Message)
+			if len(matches) > 0 {
+				searchResults.Matches[id] = matches
+				searchResults.Highlights[id] = fragments
+			}
+		}
+	}
 
-	return model.MakePostSearchResults(posts, nil), nil
+	return searchResults, nil
 }
 
 func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, error) {
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlScheduledPostStore struct {
+	*SqlStore
+}
+
+func newSqlScheduledPostStore(sqlStore *SqlStore) store.ScheduledPostStore {
+	return &SqlScheduledPostStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func scheduledPostSliceColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"UpdateAt",
+		"UserId",
+		"ChannelId",
+		"RootId",
+		"Message",
+		"Props",
+		"FileIds",
+		"Priority",
+		"ScheduledAt",
+		"ProcessedAt",
+		"ErrorCode",
+	}
+}
+
+func scheduledPostToSlice(scheduledPost *model.ScheduledPost) []any {
+	return []any{
+		scheduledPost.Id,
+		scheduledPost.CreateAt,
+		scheduledPost.UpdateAt,
+		scheduledPost.UserId,
+		scheduledPost.ChannelId,
+		scheduledPost.RootId,
+		scheduledPost.Message,
+		model.StringInterfaceToJSON(scheduledPost.GetProps()),
+		model.ArrayToJSON(scheduledPost.FileIds),
+		model.StringInterfaceToJSON(scheduledPost.Priority),
+		scheduledPost.ScheduledAt,
+		scheduledPost.ProcessedAt,
+		scheduledPost.ErrorCode,
+	}
+}
+
+func (s *SqlScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	scheduledPost.PreSave()
+	if err := scheduledPost.IsValid(s.Post().GetMaxPostSize()); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ScheduledPosts").
+		Columns(scheduledPostSliceColumns()...).
+		Values(scheduledPostToSlice(scheduledPost)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ScheduledPost with id=%s", scheduledPost.Id)
+	}
+
+	return scheduledPost, nil
+}
+
+func (s *SqlScheduledPostStore) Update(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	scheduledPost.PreUpdate()
+	if err := scheduledPost.IsValid(s.Post().GetMaxPostSize()); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Update("ScheduledPosts").
+		SetMap(map[string]any{
+			"UpdateAt":    scheduledPost.UpdateAt,
+			"Message":     scheduledPost.Message,
+			"Props":       model.StringInterfaceToJSON(scheduledPost.GetProps()),
+			"FileIds":     model.ArrayToJSON(scheduledPost.FileIds),
+			"Priority":    model.StringInterfaceToJSON(scheduledPost.Priority),
+			"ScheduledAt": scheduledPost.ScheduledAt,
+			"ProcessedAt": scheduledPost.ProcessedAt,
+			"ErrorCode":   scheduledPost.ErrorCode,
+		}).
+		Where(sq.Eq{"Id": scheduledPost.Id})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update ScheduledPost with id=%s", scheduledPost.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affected rows")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("ScheduledPost", scheduledPost.Id)
+	}
+
+	return scheduledPost, nil
+}
+
+func (s *SqlScheduledPostStore) Get(id string) (*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(scheduledPostSliceColumns()...).
+		From("ScheduledPosts").
+		Where(sq.Eq{"Id": id})
+
+	var scheduledPost model.ScheduledPost
+	if err := s.GetReplicaX().GetBuilder(&scheduledPost, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ScheduledPost", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ScheduledPost with id=%s", id)
+	}
+
+	return &scheduledPost, nil
+}
+
+// GetForUser returns the scheduled posts of the user in the channels of the team, and
+// in the direct and group messages, which have no team.
+func (s *SqlScheduledPostStore) GetForUser(userID, teamID string) ([]*model.ScheduledPost, error) {
+	columns := make([]string, 0, len(scheduledPostSliceColumns()))
+	for _, column := range scheduledPostSliceColumns() {
+		columns = append(columns, "ScheduledPosts."+column)
+	}
+
+	query := s.getQueryBuilder().
+		Select(columns...).
+		From("ScheduledPosts").
+		Where(sq.Eq{"ScheduledPosts.UserId": userID}).
+		OrderBy("ScheduledPosts.ScheduledAt", "ScheduledPosts.Id")
+
+	if teamID != "" {
+		query = query.
+			Join("Channels ON ScheduledPosts.ChannelId = Channels.Id").
+			Where(sq.Or{
+				sq.Eq{"Channels.TeamId": teamID},
+				sq.Eq{"Channels.TeamId": ""},
+			})
+	}
+
+	scheduledPosts := []*model.ScheduledPost{}
+	if err := s.GetReplicaX().SelectBuilder(&scheduledPosts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ScheduledPosts for user_id=%s", userID)
+	}
+
+	return scheduledPosts, nil
+}
+
+func (s *SqlScheduledPostStore) GetPendingScheduledPosts(beforeTime, afterTime int64, afterID string, limit int) ([]*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(scheduledPostSliceColumns()...).
+		From("ScheduledPosts").
+		Where(sq.And{
+			sq.LtOrEq{"ScheduledAt": beforeTime},
+			sq.Eq{"ProcessedAt": 0},
+			sq.Or{
+				sq.Gt{"ScheduledAt": afterTime},
+				sq.And{
+					sq.Eq{"ScheduledAt": afterTime},
+					sq.Gt{"Id": afterID},
+				},
+			},
+		}).
+		OrderBy("ScheduledAt", "Id").
+		Limit(uint64(limit))
+
+	scheduledPosts := []*model.ScheduledPost{}
+	if err := s.GetMasterX().SelectBuilder(&scheduledPosts, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get pending ScheduledPosts")
+	}
+
+	return scheduledPosts, nil
+}
+
+func (s *SqlScheduledPostStore) PermanentDelete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("ScheduledPosts").
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete ScheduledPost with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlScheduledPostStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("ScheduledPosts").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete ScheduledPosts for user_id=%s", userID)
+	}
+
+	return nil
+}
@@ -336,9 +336,9 @@ func (s SqlSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*m
 
 	query, args, err := s.getQueryBuilder().Insert("SharedChannelRemotes").
 		Columns("Id", "ChannelId", "CreatorId", "CreateAt", "UpdateAt", "IsInviteAccepted", "IsInviteConfirmed", "RemoteId",
-			"LastPostCreateAt", "LastPostCreateId", "LastPostUpdateAt", "LastPostId").
+			"LastPostCreateAt", "LastPostCreateId", "LastPostUpdateAt", "LastPostId", "LastChannelSyncAt").
 		Values(remote.Id, remote.ChannelId, remote.CreatorId, remote.CreateAt, remote.UpdateAt, remote.IsInviteAccepted, remote.IsInviteConfirmed, remote.RemoteId,
-			remote.LastPostCreateAt, remote.LastPostCreateID, remote.LastPostUpdateAt, remote.LastPostUpdateID).
+			remote.LastPostCreateAt, remote.LastPostCreateID, remote.LastPostUpdateAt, remote.LastPostUpdateID, remote.LastChannelSyncAt).
 		ToSql()
 	if err != nil {
 		return nil, errors.Wrapf(err, "savesharedchannelremote_tosql")
@@ -367,6 +367,7 @@ func (s SqlSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (
 		Set("LastPostCreateId", remote.LastPostCreateID).
 		Set("LastPostUpdateAt", remote.LastPostUpdateAt).
 		Set("LastPostId", remote.LastPostUpdateID).
+		Set("LastChannelSyncAt", remote.LastChannelSyncAt).
 		Where(sq.And{
 			sq.Eq{"Id": remote.Id},
 			sq.Eq{"ChannelId": remote.ChannelId},
@@ -408,6 +409,7 @@ func sharedChannelRemoteFields(prefix string) []string {
 		"COALESCE(" + prefix + "LastPostCreateID,'') AS LastPostCreateID",
 		prefix + "LastPostUpdateAt",
 		"COALESCE(" + prefix + "LastPostId,'') AS LastPostUpdateID",
+		prefix + "LastChannelSyncAt",
 	}
 }
 
@@ -585,6 +587,32 @@ func (s SqlSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPos
 	return nil
 }
 
+// UpdateRemoteLastChannelSyncAt updates the LastChannelSyncAt timestamp for the specified SharedChannelRemote.
+func (s SqlSharedChannelStore) UpdateRemoteLastChannelSyncAt(id string, syncTime int64) error {
+	squery, args, err := s.getQueryBuilder().
+		Update("SharedChannelRemotes").
+		Set("LastChannelSyncAt", syncTime).
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "update_shared_channel_remote_last_channel_sync_at_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(squery, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update LastChannelSyncAt for SharedChannelRemote")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine rows affected")
+	}
+	if count == 0 {
+		return fmt.Errorf("id not found: %s", id)
+	}
+	return nil
+}
+
 // DeleteRemote deletes a single shared channel remote.
 // Returns true if remote found and deleted, false if not found.
 func (s SqlSharedChannelStore) DeleteRemote(id string) (bool, error) {
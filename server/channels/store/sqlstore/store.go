@@ -111,6 +111,12 @@ type SqlStoreStores struct {
 	postPersistentNotification store.PostPersistentNotificationStore
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
+	scheduledPost              store.ScheduledPostStore
+	batchedNotification        store.BatchedNotificationStore
+	webAuthnCredential         store.WebAuthnCredentialStore
+	mfaRecoveryCode            store.MfaRecoveryCodeStore
+	mfaResetRequest            store.MfaResetRequestStore
+	auditRecord                store.AuditRecordStore
 }
 
 type SqlStore struct {
@@ -236,6 +242,12 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.postPersistentNotification = newSqlPostPersistentNotificationStore(store)
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
+	store.stores.scheduledPost = newSqlScheduledPostStore(store)
+	store.stores.batchedNotification = newSqlBatchedNotificationStore(store)
+	store.stores.webAuthnCredential = newSqlWebAuthnCredentialStore(store)
+	store.stores.mfaRecoveryCode = newSqlMfaRecoveryCodeStore(store)
+	store.stores.mfaResetRequest = newSqlMfaResetRequestStore(store)
+	store.stores.auditRecord = newSqlAuditRecordStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -1039,6 +1051,30 @@ func (ss *SqlStore) ChannelBookmark() store.ChannelBookmarkStore {
 	return ss.stores.channelBookmarks
 }
 
+func (ss *SqlStore) ScheduledPost() store.ScheduledPostStore {
+	return ss.stores.scheduledPost
+}
+
+func (ss *SqlStore) BatchedNotification() store.BatchedNotificationStore {
+	return ss.stores.batchedNotification
+}
+
+func (ss *SqlStore) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return ss.stores.webAuthnCredential
+}
+
+func (ss *SqlStore) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return ss.stores.mfaRecoveryCode
+}
+
+func (ss *SqlStore) MfaResetRequest() store.MfaResetRequestStore {
+	return ss.stores.mfaResetRequest
+}
+
+func (ss *SqlStore) AuditRecord() store.AuditRecordStore {
+	return ss.stores.auditRecord
+}
+
 func (ss *SqlStore) DropAllTables() {
 	if ss.DriverName() == model.DatabaseDriverPostgres {
 		ss.masterX.Exec(`DO
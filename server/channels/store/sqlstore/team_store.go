@@ -1569,6 +1569,79 @@ func (s SqlTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMembe
 	return members, nil
 }
 
+// GetMembersLeftSince returns the memberships of the team that were removed at or after the given time.
+func (s SqlTeamStore) GetMembersLeftSince(teamID string, since int64) ([]*model.TeamMember, error) {
+	members := []*model.TeamMember{}
+	query, args, err := s.getQueryBuilder().
+		Select("TeamId", "UserId", "DeleteAt").
+		From("TeamMembers").
+		Where(sq.And{
+			sq.Eq{"TeamId": teamID},
+			sq.NotEq{"DeleteAt": 0},
+			sq.GtOrEq{"DeleteAt": since},
+		}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_tosql")
+	}
+	if err := s.GetReplicaX().Select(&members, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find TeamMembers left with teamId=%s", teamID)
+	}
+	return members, nil
+}
+
+func (s SqlTeamStore) GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error) {
+	postsInRange := sq.And{
+		sq.Expr("Channels.TeamId = Teams.Id"),
+		sq.Eq{"Posts.DeleteAt": 0},
+	}
+	if filter.StartAt > 0 {
+		postsInRange = append(postsInRange, sq.GtOrEq{"Posts.CreateAt": filter.StartAt})
+	}
+	if filter.EndAt > 0 {
+		postsInRange = append(postsInRange, sq.Lt{"Posts.CreateAt": filter.EndAt})
+	}
+	postsInRangeSQL, postsInRangeArgs, err := postsInRange.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_activity_report_tosql")
+	}
+
+	query := s.getQueryBuilder().
+		Select(
+			"Teams.Id AS TeamId",
+			"Teams.Name AS TeamName",
+			"Teams.DisplayName",
+			"Teams.Type",
+			"(SELECT COUNT(*) FROM TeamMembers WHERE TeamMembers.TeamId = Teams.Id AND TeamMembers.DeleteAt = 0) AS MemberCount",
+			"(SELECT COUNT(*) FROM Channels WHERE Channels.TeamId = Teams.Id AND Channels.DeleteAt = 0) AS ChannelCount",
+			"(SELECT COALESCE(MAX(Channels.LastPostAt), 0) FROM Channels WHERE Channels.TeamId = Teams.Id) AS LastPostAt",
+		).
+		Column(sq.Expr("(SELECT COUNT(*) FROM Posts JOIN Channels ON Posts.ChannelId = Channels.Id WHERE "+postsInRangeSQL+") AS PostCount", postsInRangeArgs...)).
+		Column(sq.Expr("(SELECT COUNT(DISTINCT Posts.UserId) FROM Posts JOIN Channels ON Posts.ChannelId = Channels.Id WHERE "+postsInRangeSQL+") AS ActiveUsers", postsInRangeArgs...)).
+		From("Teams").
+		Where(sq.Eq{"Teams.DeleteAt": 0}).
+		OrderBy("Teams.Id")
+
+	if filter.FromId != "" {
+		query = query.Where(sq.Gt{"Teams.Id": filter.FromId})
+	}
+
+	if filter.PageSize > 0 {
+		query = query.Limit(uint64(filter.PageSize))
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_activity_report_tosql")
+	}
+
+	rows := []*model.TeamActivityReport{}
+	if err := s.GetReplicaX().Select(&rows, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get the team activity report")
+	}
+
+	return rows, nil
+}
+
 // UserBelongsToTeams returns true if the user denoted by userId is a member of the teams in the teamIds string array.
 func (s SqlTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, error) {
 	idQuery := sq.Eq{
@@ -527,6 +527,35 @@ func (us SqlUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, er
 	return users, nil
 }
 
+// GetIdsWithMembershipsUpdatedSince returns the ids of the users who joined or left a team,
+// or had a channel membership updated, since the given time. Channel memberships whose
+// only change is a view of the channel are skipped, as viewing sets LastUpdateAt to LastViewedAt.
+func (us SqlUserStore) GetIdsWithMembershipsUpdatedSince(since int64, includeArchivedChannels bool) ([]string, error) {
+	archivedFilter := ""
+	if !includeArchivedChannels {
+		archivedFilter = "AND Channels.DeleteAt = 0"
+	}
+
+	query := `
+		SELECT TeamMembers.UserId
+		FROM TeamMembers
+		WHERE TeamMembers.CreateAt >= ? OR TeamMembers.DeleteAt >= ?
+		UNION
+		SELECT ChannelMembers.UserId
+		FROM ChannelMembers
+		INNER JOIN Channels ON Channels.Id = ChannelMembers.ChannelId
+		WHERE ChannelMembers.LastUpdateAt >= ?
+			AND ChannelMembers.LastUpdateAt <> ChannelMembers.LastViewedAt
+			` + archivedFilter
+
+	userIds := []string{}
+	if err := us.GetReplicaX().Select(&userIds, query, since, since, since); err != nil {
+		return nil, errors.Wrap(err, "failed to find Users with updated memberships")
+	}
+
+	return userIds, nil
+}
+
 func (us SqlUserStore) GetEtagForAllProfiles() string {
 	var updateAt int64
 	err := us.GetReplicaX().Get(&updateAt, "SELECT UpdateAt FROM Users ORDER BY UpdateAt DESC LIMIT 1")
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlWebAuthnCredentialStore struct {
+	*SqlStore
+}
+
+func newSqlWebAuthnCredentialStore(sqlStore *SqlStore) store.WebAuthnCredentialStore {
+	return &SqlWebAuthnCredentialStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func webAuthnCredentialSliceColumns() []string {
+	return []string{
+		"Id",
+		"UserId",
+		"Name",
+		"CredentialId",
+		"PublicKey",
+		"SignCount",
+		"CreateAt",
+		"LastUsedAt",
+	}
+}
+
+func (s *SqlWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	credential.PreSave()
+	if err := credential.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("WebAuthnCredentials").
+		Columns(webAuthnCredentialSliceColumns()...).
+		Values(credential.Id, credential.UserId, credential.Name, credential.CredentialId, credential.PublicKey, credential.SignCount, credential.CreateAt, credential.LastUsedAt)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save WebAuthnCredential with id=%s", credential.Id)
+	}
+
+	return credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	query := s.getQueryBuilder().
+		Select(webAuthnCredentialSliceColumns()...).
+		From("WebAuthnCredentials").
+		Where(sq.Eq{"Id": id})
+
+	var credential model.WebAuthnCredential
+	if err := s.GetMasterX().GetBuilder(&credential, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WebAuthnCredential", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredential with id=%s", id)
+	}
+
+	return &credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	query := s.getQueryBuilder().
+		Select(webAuthnCredentialSliceColumns()...).
+		From("WebAuthnCredentials").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt", "Id")
+
+	credentials := []*model.WebAuthnCredential{}
+	if err := s.GetMasterX().SelectBuilder(&credentials, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredentials for user_id=%s", userID)
+	}
+
+	return credentials, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
+	query := s.getQueryBuilder().
+		Update("WebAuthnCredentials").
+		Set("SignCount", signCount).
+		Set("LastUsedAt", lastUsedAt).
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to update WebAuthnCredential with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredential with id=%s", id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get the number of deleted WebAuthnCredentials")
+	} else if rows == 0 {
+		return store.NewErrNotFound("WebAuthnCredential", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredentials for user_id=%s", userID)
+	}
+
+	return nil
+}
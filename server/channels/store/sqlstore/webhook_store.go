@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
@@ -37,6 +38,19 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 		return nil, store.NewErrInvalidInput("IncomingWebhook", "id", webhook.Id)
 	}
 
+	return s.insertIncoming(webhook)
+}
+
+// ImportIncoming saves an imported webhook, keeping its ID as it is part of the webhook URL.
+func (s SqlWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if !model.IsValidId(webhook.Id) {
+		return nil, store.NewErrInvalidInput("IncomingWebhook", "id", webhook.Id)
+	}
+
+	return s.insertIncoming(webhook)
+}
+
+func (s SqlWebhookStore) insertIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	webhook.PreSave()
 	if err := webhook.IsValid(); err != nil {
 		return nil, err
@@ -46,6 +60,9 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 		(Id, CreateAt, UpdateAt, DeleteAt, UserId, ChannelId, TeamId, DisplayName, Description, Username, IconURL, ChannelLocked)
 		VALUES
 		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :UserId, :ChannelId, :TeamId, :DisplayName, :Description, :Username, :IconURL, :ChannelLocked)`, webhook); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "incomingwebhooks_pkey"}) {
+			return nil, store.NewErrConflict("IncomingWebhook", err, "id="+webhook.Id)
+		}
 		return nil, errors.Wrapf(err, "failed to save IncomingWebhook with id=%s", webhook.Id)
 	}
 
@@ -164,6 +181,29 @@ func (s SqlWebhookStore) GetIncomingByTeam(teamId string, offset, limit int) ([]
 	return s.GetIncomingByTeamByUser(teamId, "", offset, limit)
 }
 
+// GetIncomingDeletedSince returns the incoming webhooks of the team deleted at or after the given time.
+func (s SqlWebhookStore) GetIncomingDeletedSince(teamId string, since int64) ([]*model.IncomingWebhook, error) {
+	webhooks := []*model.IncomingWebhook{}
+
+	queryString, args, err := s.getQueryBuilder().
+		Select("*").
+		From("IncomingWebhooks").
+		Where(sq.And{
+			sq.Eq{"TeamId": teamId},
+			sq.NotEq{"DeleteAt": 0},
+			sq.GtOrEq{"DeleteAt": since},
+		}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "incoming_webhook_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&webhooks, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find deleted IncomingWebhooks with teamId=%s", teamId)
+	}
+
+	return webhooks, nil
+}
+
 func (s SqlWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, error) {
 	webhooks := []*model.IncomingWebhook{}
 
@@ -308,6 +348,29 @@ func (s SqlWebhookStore) GetOutgoingByTeam(teamId string, offset, limit int) ([]
 	return s.GetOutgoingByTeamByUser(teamId, "", offset, limit)
 }
 
+// GetOutgoingDeletedSince returns the outgoing webhooks of the team deleted at or after the given time.
+func (s SqlWebhookStore) GetOutgoingDeletedSince(teamId string, since int64) ([]*model.OutgoingWebhook, error) {
+	webhooks := []*model.OutgoingWebhook{}
+
+	queryString, args, err := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhooks").
+		Where(sq.And{
+			sq.Eq{"TeamId": teamId},
+			sq.NotEq{"DeleteAt": 0},
+			sq.GtOrEq{"DeleteAt": since},
+		}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_webhook_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&webhooks, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find deleted OutgoingWebhooks with teamId=%s", teamId)
+	}
+
+	return webhooks, nil
+}
+
 func (s SqlWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
 	_, err := s.GetMasterX().Exec("Update OutgoingWebhooks SET DeleteAt = ?, UpdateAt = ? WHERE Id = ?", time, time, webhookId)
 	if err != nil {
@@ -397,3 +460,179 @@ func (s SqlWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, error) {
 	}
 	return count, nil
 }
+
+func (s SqlWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	if delivery.Id != "" {
+		return nil, store.NewErrInvalidInput("OutgoingWebhookDelivery", "id", delivery.Id)
+	}
+
+	delivery.PreSave()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMasterX().NamedExec(`INSERT INTO OutgoingWebhookDeliveries
+		(Id, HookId, PostId, ChannelId, CallbackURL, ContentType, Payload, Status, Attempts, StatusCode, Latency, LastError, CreateAt, UpdateAt, NextAttemptAt)
+		VALUES
+		(:Id, :HookId, :PostId, :ChannelId, :CallbackURL, :ContentType, :Payload, :Status, :Attempts, :StatusCode, :Latency, :LastError, :CreateAt, :UpdateAt, :NextAttemptAt)`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	delivery.PreUpdate()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMasterX().NamedExec(`UPDATE OutgoingWebhookDeliveries SET
+			Status = :Status, Attempts = :Attempts, StatusCode = :StatusCode, Latency = :Latency,
+			LastError = :LastError, UpdateAt = :UpdateAt, NextAttemptAt = :NextAttemptAt
+			WHERE Id = :Id`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	var delivery model.OutgoingWebhookDelivery
+
+	if err := s.GetReplicaX().Get(&delivery, "SELECT * FROM OutgoingWebhookDeliveries WHERE Id = ?", id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingWebhookDelivery", id)
+		}
+
+		return nil, errors.Wrapf(err, "failed to get OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	return &delivery, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDeliveriesByHook(hookId string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"HookId": hookId}).
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(limit)).Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&deliveries, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find OutgoingWebhookDeliveries with hookId=%s", hookId)
+	}
+
+	return deliveries, nil
+}
+
+func (s SqlWebhookStore) GetPendingOutgoingDeliveries(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhookDeliveries").
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingWebhookDeliveryStatusPending},
+			sq.LtOrEq{"NextAttemptAt": before},
+		}).
+		OrderBy("NextAttemptAt ASC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	if err := s.GetMasterX().Select(&deliveries, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find pending OutgoingWebhookDeliveries")
+	}
+
+	return deliveries, nil
+}
+
+// ClaimOutgoingDelivery postpones the next attempt of a pending delivery to until,
+// provided it hasn't changed since it was read. It returns false if the delivery was
+// claimed by someone else in the meantime.
+func (s SqlWebhookStore) ClaimOutgoingDelivery(id string, nextAttemptAt, until int64) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("OutgoingWebhookDeliveries").
+		Set("NextAttemptAt", until).
+		Where(sq.Eq{
+			"Id":            id,
+			"Status":        model.OutgoingWebhookDeliveryStatusPending,
+			"NextAttemptAt": nextAttemptAt,
+		}).ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (s SqlWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int) (int64, error) {
+	var (
+		query string
+		args  []any
+		err   error
+	)
+
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		var innerSelect string
+		innerSelect, args, err = s.getQueryBuilder().
+			Select("Id").
+			From("OutgoingWebhookDeliveries").
+			Where(sq.And{
+				sq.Lt{"CreateAt": before},
+				sq.NotEq{"Status": model.OutgoingWebhookDeliveryStatusPending},
+			}).Limit(uint64(limit)).
+			ToSql()
+		if err != nil {
+			return 0, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+		}
+		query, _, err = s.getQueryBuilder().
+			Delete("OutgoingWebhookDeliveries").
+			Where(fmt.Sprintf("Id IN (%s)", innerSelect)).
+			ToSql()
+	} else {
+		query, args, err = s.getQueryBuilder().
+			Delete("OutgoingWebhookDeliveries").
+			Where(sq.And{
+				sq.Lt{"CreateAt": before},
+				sq.NotEq{"Status": model.OutgoingWebhookDeliveryStatusPending},
+			}).
+			Limit(uint64(limit)).ToSql()
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected, nil
+}
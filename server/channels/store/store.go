@@ -91,6 +91,12 @@ type Store interface {
 	PostPersistentNotification() PostPersistentNotificationStore
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
+	ScheduledPost() ScheduledPostStore
+	BatchedNotification() BatchedNotificationStore
+	WebAuthnCredential() WebAuthnCredentialStore
+	MfaRecoveryCode() MfaRecoveryCodeStore
+	MfaResetRequest() MfaResetRequestStore
+	AuditRecord() AuditRecordStore
 }
 
 type RetentionPolicyStore interface {
@@ -161,6 +167,8 @@ type TeamStore interface {
 	AnalyticsGetTeamCountForScheme(schemeID string) (int64, error)
 	GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error)
 	GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error)
+	GetMembersLeftSince(teamID string, since int64) ([]*model.TeamMember, error)
+	GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error)
 	UserBelongsToTeams(userID string, teamIds []string) (bool, error)
 	GetUserTeamIds(userID string, allowFromCache bool) ([]string, error)
 	InvalidateAllTeamIdsForUser(userID string)
@@ -294,6 +302,7 @@ type ChannelStore interface {
 	GetAllChannelsForExportAfter(limit int, afterID string) ([]*model.ChannelForExport, error)
 	GetAllDirectChannelsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectChannelForExport, error)
 	GetChannelMembersForExport(userID string, teamID string, includeArchivedChannel bool) ([]*model.ChannelMemberForExport, error)
+	GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error)
 	RemoveAllDeactivatedMembers(ctx request.CTX, channelID string) error
 	GetChannelsBatchForIndexing(startTime int64, startChannelID string, limit int) ([]*model.Channel, error)
 	UserBelongsToChannels(userID string, channelIds []string) (bool, error)
@@ -318,6 +327,8 @@ type ChannelMemberHistoryStore interface {
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetChannelsLeftSince(userID string, since int64) ([]string, error)
+	GetMembershipChangesSince(channelID string, since int64) ([]*model.ChannelMemberHistoryResult, error)
+	GetMembersLeftSince(teamID string, since int64) ([]*model.ChannelMemberHistoryResult, error)
 }
 type ThreadStore interface {
 	GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error)
@@ -382,13 +393,15 @@ type PostStore interface {
 	GetPostsByIds(postIds []string) ([]*model.Post, error)
 	GetEditHistoryForPost(postId string) ([]*model.Post, error)
 	GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error)
+	GetChannelPostsBatchForIndexing(channelID string, startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error)
 	PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
 	GetMaxPostSize() int
-	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error)
+	GetParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
-	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetDirectPostParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.DeletedPostForExport, error)
 	SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error)
@@ -467,6 +480,7 @@ type UserStore interface {
 	ClearAllCustomRoleAssignments() error
 	InferSystemInstallDate() (int64, error)
 	GetAllAfter(limit int, afterID string) ([]*model.User, error)
+	GetIdsWithMembershipsUpdatedSince(since int64, includeArchivedChannels bool) ([]string, error)
 	GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error)
 	Count(options model.UserCountOptions) (int64, error)
 	GetTeamGroupUsers(teamID string) ([]*model.User, error)
@@ -591,6 +605,7 @@ type SystemStore interface {
 
 type WebhookStore interface {
 	SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
 	GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error)
 	GetIncomingList(offset, limit int) ([]*model.IncomingWebhook, error)
 	GetIncomingListByUser(userID string, offset, limit int) ([]*model.IncomingWebhook, error)
@@ -601,6 +616,7 @@ type WebhookStore interface {
 	DeleteIncoming(webhookID string, timestamp int64) error
 	PermanentDeleteIncomingByChannel(channelID string) error
 	PermanentDeleteIncomingByUser(userID string) error
+	GetIncomingDeletedSince(teamID string, since int64) ([]*model.IncomingWebhook, error)
 
 	SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error)
 	GetOutgoing(id string) (*model.OutgoingWebhook, error)
@@ -614,6 +630,15 @@ type WebhookStore interface {
 	PermanentDeleteOutgoingByChannel(channelID string) error
 	PermanentDeleteOutgoingByUser(userID string) error
 	UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error)
+	GetOutgoingDeletedSince(teamID string, since int64) ([]*model.OutgoingWebhook, error)
+
+	SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDeliveriesByHook(hookID string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	GetPendingOutgoingDeliveries(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	ClaimOutgoingDelivery(id string, nextAttemptAt, until int64) (bool, error)
+	PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int) (int64, error)
 
 	AnalyticsIncomingCount(teamID string) (int64, error)
 	AnalyticsOutgoingCount(teamID string) (int64, error)
@@ -626,6 +651,7 @@ type CommandStore interface {
 	GetByTrigger(teamID string, trigger string) (*model.Command, error)
 	Get(id string) (*model.Command, error)
 	GetByTeam(teamID string) ([]*model.Command, error)
+	GetDeletedByTeamSince(teamID string, since int64) ([]*model.Command, error)
 	Delete(commandID string, timestamp int64) error
 	PermanentDeleteByTeam(teamID string) error
 	PermanentDeleteByUser(userID string) error
@@ -717,6 +743,7 @@ type FileInfoStore interface {
 	Search(ctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.FileInfoList, error)
 	CountAll() (int64, error)
 	GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error)
+	GetChannelFilesBatchForIndexing(channelID string, startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error)
 	ClearCaches()
 	GetStorageUsage(allowFromCache, includeDeleted bool) (int64, error)
 	// GetUptoNSizeFileTime returns the CreateAt time of the last accessible file with a running-total size upto n bytes.
@@ -761,6 +788,9 @@ type JobStore interface {
 	GetAllByTypePage(c request.CTX, jobType string, offset int, limit int) ([]*model.Job, error)
 	GetAllByTypesPage(c request.CTX, jobTypes []string, offset int, limit int) ([]*model.Job, error)
 	GetAllByStatus(c request.CTX, status string) ([]*model.Job, error)
+	// GetAllByRootId returns the jobs of the lineage started by the given job, including
+	// that job, from the oldest to the newest.
+	GetAllByRootId(c request.CTX, rootID string) ([]*model.Job, error)
 	GetAllByTypeAndStatusPage(c request.CTX, jobType []string, status string, offset int, limit int) ([]*model.Job, error)
 	GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error)
 	GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error)
@@ -981,6 +1011,7 @@ type SharedChannelStore interface {
 	GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error)
 	GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error)
 	UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
+	UpdateRemoteLastChannelSyncAt(id string, syncTime int64) error
 	DeleteRemote(remoteId string) (bool, error)
 	GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error)
 
@@ -1012,6 +1043,74 @@ type DraftStore interface {
 	DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userId string) error
 }
 
+type ScheduledPostStore interface {
+	Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error)
+	Update(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error)
+	Get(id string) (*model.ScheduledPost, error)
+	GetForUser(userID, teamID string) ([]*model.ScheduledPost, error)
+	// GetPendingScheduledPosts returns the scheduled posts due at the given time which
+	// haven't been processed yet, ordered by their schedule and id after the given ones.
+	GetPendingScheduledPosts(beforeTime, afterTime int64, afterID string, limit int) ([]*model.ScheduledPost, error)
+	PermanentDelete(id string) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type BatchedNotificationStore interface {
+	Save(notification *model.BatchedNotification) (*model.BatchedNotification, error)
+	// GetUsersWithPending returns the ids of the users with batched notifications, in
+	// order after the given one.
+	GetUsersWithPending(afterUserID string, limit int) ([]string, error)
+	GetForUser(userID string) ([]*model.BatchedNotification, error)
+	Delete(ids []string) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type WebAuthnCredentialStore interface {
+	Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error)
+	Get(id string) (*model.WebAuthnCredential, error)
+	GetForUser(userID string) ([]*model.WebAuthnCredential, error)
+	// UpdateLastUsed records a credential was used to sign in, with the new signature
+	// counter of the security key.
+	UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error
+	Delete(id string) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type MfaRecoveryCodeStore interface {
+	// SaveForUser replaces the recovery codes of a user.
+	SaveForUser(userID string, codes []*model.MfaRecoveryCode) error
+	GetForUser(userID string) ([]*model.MfaRecoveryCode, error)
+	// MarkUsed records a recovery code was used, failing with ErrNotFound if it was
+	// already, so that each code is only used once.
+	MarkUsed(id string, usedAt int64) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type MfaResetRequestStore interface {
+	Save(request *model.MfaResetRequest) (*model.MfaResetRequest, error)
+	Get(id string) (*model.MfaResetRequest, error)
+	GetPendingForUser(userID string) (*model.MfaResetRequest, error)
+	// GetAll returns the requests with the given status, or all of them if it's empty,
+	// most recent first.
+	GetAll(status string, offset, limit int) ([]*model.MfaResetRequest, error)
+	// Resolve approves or denies a pending request, failing with ErrNotFound if it isn't
+	// pending anymore.
+	Resolve(id, status, resolvedBy string, updateAt int64) error
+	PermanentDeleteByUser(userID string) error
+}
+
+// AuditRecordStore stores the hash chained audit records and their checkpoints. Records
+// are never updated nor deleted.
+type AuditRecordStore interface {
+	Save(record *model.AuditRecord) (*model.AuditRecord, error)
+	// GetAll returns the records matching the filter, ordered by time then sequence.
+	GetAll(filter *model.AuditRecordFilter) ([]*model.AuditRecord, error)
+	SaveCheckpoint(checkpoint *model.AuditCheckpoint) (*model.AuditCheckpoint, error)
+	// GetCheckpoints returns the checkpoints of a chain, or of all chains if chainID is
+	// empty, ordered by time then sequence.
+	GetCheckpoints(chainID string, offset, limit int) ([]*model.AuditCheckpoint, error)
+}
+
 type PostAcknowledgementStore interface {
 	Get(postID, userID string) (*model.PostAcknowledgement, error)
 	GetForPost(postID string) ([]*model.PostAcknowledgement, error)
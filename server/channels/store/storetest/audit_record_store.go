@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestAuditRecordStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGetAll", func(t *testing.T) { testSaveAndGetAllAuditRecords(t, rctx, ss) })
+	t.Run("Checkpoints", func(t *testing.T) { testAuditCheckpoints(t, rctx, ss) })
+}
+
+func newTestAuditRecord(chainID string, sequence int64, prevHash, userID, eventName string, createAt int64) *model.AuditRecord {
+	record := &model.AuditRecord{
+		ChainId:   chainID,
+		Sequence:  sequence,
+		CreateAt:  createAt,
+		Level:     "audit-api",
+		EventName: eventName,
+		Status:    "success",
+		UserId:    userID,
+		Data:      `{"event_name":"` + eventName + `"}`,
+		PrevHash:  prevHash,
+	}
+	record.Hash = record.ComputeHash()
+	return record
+}
+
+func testSaveAndGetAllAuditRecords(t *testing.T, rctx request.CTX, ss store.Store) {
+	chainID := model.NewId()
+	userID := model.NewId()
+
+	first, err := ss.AuditRecord().Save(newTestAuditRecord(chainID, 1, "", userID, "login", 1000))
+	require.NoError(t, err)
+	second, err := ss.AuditRecord().Save(newTestAuditRecord(chainID, 2, first.Hash, model.NewId(), "login", 2000))
+	require.NoError(t, err)
+	third, err := ss.AuditRecord().Save(newTestAuditRecord(chainID, 3, second.Hash, userID, "updateUser", 3000))
+	require.NoError(t, err)
+
+	t.Run("a sequence is only saved once", func(t *testing.T) {
+		_, err := ss.AuditRecord().Save(newTestAuditRecord(chainID, 3, second.Hash, userID, "logout", 3000))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid records aren't saved", func(t *testing.T) {
+		_, err := ss.AuditRecord().Save(&model.AuditRecord{ChainId: chainID, Sequence: 4})
+		require.Error(t, err)
+	})
+
+	ids := func(filter model.AuditRecordFilter) []string {
+		filter.ChainId = chainID
+		if filter.PerPage == 0 {
+			filter.PerPage = 100
+		}
+
+		records, err := ss.AuditRecord().GetAll(&filter)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.Id)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{first.Id, second.Id, third.Id}, ids(model.AuditRecordFilter{}))
+	assert.Equal(t, []string{first.Id, third.Id}, ids(model.AuditRecordFilter{UserId: userID}))
+	assert.Equal(t, []string{first.Id, second.Id}, ids(model.AuditRecordFilter{EventName: "login"}))
+	assert.Equal(t, []string{second.Id, third.Id}, ids(model.AuditRecordFilter{Since: 2000}))
+	assert.Equal(t, []string{first.Id, second.Id}, ids(model.AuditRecordFilter{Until: 2000}))
+	assert.Equal(t, []string{second.Id}, ids(model.AuditRecordFilter{Page: 1, PerPage: 1}))
+
+	records, err := ss.AuditRecord().GetAll(&model.AuditRecordFilter{ChainId: chainID, PerPage: 100})
+	require.NoError(t, err)
+	assert.Equal(t, third, records[2])
+}
+
+func testAuditCheckpoints(t *testing.T, rctx request.CTX, ss store.Store) {
+	chainID := model.NewId()
+	hash := model.NewId() + model.NewId() + "012345678901"
+
+	first, err := ss.AuditRecord().SaveCheckpoint(&model.AuditCheckpoint{ChainId: chainID, Sequence: 10, Hash: hash, CreateAt: 1000, Signature: "signature"})
+	require.NoError(t, err)
+	second, err := ss.AuditRecord().SaveCheckpoint(&model.AuditCheckpoint{ChainId: chainID, Sequence: 20, Hash: hash, CreateAt: 2000})
+	require.NoError(t, err)
+	_, err = ss.AuditRecord().SaveCheckpoint(&model.AuditCheckpoint{ChainId: model.NewId(), Sequence: 10, Hash: hash, CreateAt: 1500})
+	require.NoError(t, err)
+
+	_, err = ss.AuditRecord().SaveCheckpoint(&model.AuditCheckpoint{ChainId: chainID, Sequence: 30, Hash: "junk", CreateAt: 3000})
+	require.Error(t, err)
+
+	checkpoints, err := ss.AuditRecord().GetCheckpoints(chainID, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.AuditCheckpoint{first, second}, checkpoints)
+
+	checkpoints, err = ss.AuditRecord().GetCheckpoints(chainID, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.AuditCheckpoint{second}, checkpoints)
+}
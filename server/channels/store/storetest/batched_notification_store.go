@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestBatchedNotificationStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGetForUser", func(t *testing.T) { testSaveAndGetBatchedNotifications(t, rctx, ss) })
+	t.Run("GetUsersWithPending", func(t *testing.T) { testGetUsersWithPendingBatchedNotifications(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testDeleteBatchedNotifications(t, rctx, ss) })
+}
+
+func testSaveAndGetBatchedNotifications(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.BatchedNotification().PermanentDeleteByUser(userID)
+
+	first, err := ss.BatchedNotification().Save(&model.BatchedNotification{
+		UserId:   userID,
+		PostId:   model.NewId(),
+		TeamName: "team",
+		CreateAt: 1000,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Id)
+
+	second, err := ss.BatchedNotification().Save(&model.BatchedNotification{
+		UserId:   userID,
+		PostId:   model.NewId(),
+		TeamName: "team",
+		CreateAt: 2000,
+	})
+	require.NoError(t, err)
+
+	_, err = ss.BatchedNotification().Save(&model.BatchedNotification{
+		UserId: userID,
+		PostId: "invalid",
+	})
+	require.Error(t, err)
+
+	notifications, err := ss.BatchedNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, first, notifications[0])
+	assert.Equal(t, second, notifications[1])
+
+	notifications, err = ss.BatchedNotification().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func testGetUsersWithPendingBatchedNotifications(t *testing.T, rctx request.CTX, ss store.Store) {
+	userIDs := []string{model.NewId(), model.NewId(), model.NewId()}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		defer ss.BatchedNotification().PermanentDeleteByUser(userID)
+
+		for i := 0; i < 2; i++ {
+			_, err := ss.BatchedNotification().Save(&model.BatchedNotification{
+				UserId: userID,
+				PostId: model.NewId(),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	got, err := ss.BatchedNotification().GetUsersWithPending(userIDs[0], 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, userIDs[1], got[0])
+
+	got, err = ss.BatchedNotification().GetUsersWithPending(userIDs[1], 10)
+	require.NoError(t, err)
+	assert.Contains(t, got, userIDs[2])
+	assert.NotContains(t, got, userIDs[0])
+	assert.NotContains(t, got, userIDs[1])
+}
+
+func testDeleteBatchedNotifications(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	defer ss.BatchedNotification().PermanentDeleteByUser(otherUserID)
+
+	var saved []*model.BatchedNotification
+	for i := 0; i < 3; i++ {
+		notification, err := ss.BatchedNotification().Save(&model.BatchedNotification{
+			UserId:   userID,
+			PostId:   model.NewId(),
+			CreateAt: int64(1000 + i),
+		})
+		require.NoError(t, err)
+		saved = append(saved, notification)
+	}
+
+	_, err := ss.BatchedNotification().Save(&model.BatchedNotification{
+		UserId: otherUserID,
+		PostId: model.NewId(),
+	})
+	require.NoError(t, err)
+
+	err = ss.BatchedNotification().Delete([]string{saved[0].Id, saved[1].Id})
+	require.NoError(t, err)
+
+	notifications, err := ss.BatchedNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, saved[2].Id, notifications[0].Id)
+
+	err = ss.BatchedNotification().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+
+	notifications, err = ss.BatchedNotification().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	notifications, err = ss.BatchedNotification().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, notifications, 1)
+}
@@ -24,6 +24,8 @@ func TestChannelMemberHistoryStore(t *testing.T, rctx request.CTX, ss store.Stor
 	t.Run("TestPermanentDeleteBatch", func(t *testing.T) { testPermanentDeleteBatch(t, rctx, ss) })
 	t.Run("TestPermanentDeleteBatchForRetentionPolicies", func(t *testing.T) { testPermanentDeleteBatchForRetentionPolicies(t, rctx, ss) })
 	t.Run("TestGetChannelsLeftSince", func(t *testing.T) { testGetChannelsLeftSince(t, rctx, ss) })
+	t.Run("TestGetMembershipChangesSince", func(t *testing.T) { testGetMembershipChangesSince(t, rctx, ss) })
+	t.Run("TestGetMembersLeftSince", func(t *testing.T) { testGetMembersLeftSince(t, rctx, ss) })
 }
 
 func testLogJoinEvent(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -445,3 +447,105 @@ func testGetChannelsLeftSince(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{channel.Id}, ids)
 }
+
+func testGetMembershipChangesSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	userID3 := model.NewId()
+
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID1, channel.Id, 1000))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID2, channel.Id, 1100))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID1, channel.Id, 1300))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID3, channel.Id, 1400))
+
+	t.Run("all changes", func(t *testing.T) {
+		changes, err := ss.ChannelMemberHistory().GetMembershipChangesSince(channel.Id, 1000)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		assert.Equal(t, userID1, changes[0].UserId)
+		require.NotNil(t, changes[0].LeaveTime)
+		assert.Equal(t, int64(1300), *changes[0].LeaveTime)
+		assert.Equal(t, userID2, changes[1].UserId)
+		assert.Equal(t, userID3, changes[2].UserId)
+	})
+
+	t.Run("only recent joins and leaves", func(t *testing.T) {
+		changes, err := ss.ChannelMemberHistory().GetMembershipChangesSince(channel.Id, 1200)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, userID1, changes[0].UserId)
+		assert.Equal(t, userID3, changes[1].UserId)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := ss.ChannelMemberHistory().GetMembershipChangesSince(channel.Id, 1500)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+func testGetMembersLeftSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	userID3 := model.NewId()
+
+	// The first user left twice, the second one joined again and the third one left earlier.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID1, channel.Id, 1000))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID1, channel.Id, 1100))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID1, channel.Id, 1200))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID1, channel.Id, 1300))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID2, channel.Id, 1000))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID2, channel.Id, 1200))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID2, channel.Id, 1300))
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID3, channel.Id, 1000))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID3, channel.Id, 1050))
+
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      userID2,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	left, err := ss.ChannelMemberHistory().GetMembersLeftSince(team.Id, 1100)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	assert.Equal(t, channel.Id, left[0].ChannelId)
+	assert.Equal(t, userID1, left[0].UserId)
+	require.NotNil(t, left[0].LeaveTime)
+	assert.Equal(t, int64(1300), *left[0].LeaveTime)
+
+	left, err = ss.ChannelMemberHistory().GetMembersLeftSince(team.Id, 1400)
+	require.NoError(t, err)
+	assert.Empty(t, left)
+}
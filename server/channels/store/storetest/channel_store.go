@@ -137,6 +137,7 @@ func TestChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore
 	t.Run("MaterializedPublicChannels", func(t *testing.T) { testMaterializedPublicChannels(t, rctx, ss, s) })
 	t.Run("GetAllChannelsForExportAfter", func(t *testing.T) { testChannelStoreGetAllChannelsForExportAfter(t, rctx, ss) })
 	t.Run("GetChannelMembersForExport", func(t *testing.T) { testChannelStoreGetChannelMembersForExport(t, rctx, ss) })
+	t.Run("GetChannelMembershipReport", func(t *testing.T) { testChannelStoreGetChannelMembershipReport(t, rctx, ss) })
 	t.Run("RemoveAllDeactivatedMembers", func(t *testing.T) { testChannelStoreRemoveAllDeactivatedMembers(t, rctx, ss, s) })
 	t.Run("ExportAllDirectChannels", func(t *testing.T) { testChannelStoreExportAllDirectChannels(t, rctx, ss, s) })
 	t.Run("ExportAllDirectChannelsExcludePrivateAndPublic", func(t *testing.T) { testChannelStoreExportAllDirectChannelsExcludePrivateAndPublic(t, rctx, ss, s) })
@@ -7593,6 +7594,114 @@ func testChannelStoreGetChannelMembersForExport(t *testing.T, rctx request.CTX,
 	assert.Equal(t, u1.Id, cmfe1.UserId)
 }
 
+func testChannelStoreGetChannelMembershipReport(t *testing.T, rctx request.CTX, ss store.Store) {
+	team := &model.Team{
+		DisplayName: "Name",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	}
+	team, err := ss.Team().Save(team)
+	require.NoError(t, err)
+
+	newChannel := func(teamID string, channelType model.ChannelType) *model.Channel {
+		channel, nErr := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Channel",
+			Name:        NewTestId(),
+			Type:        channelType,
+		}, -1)
+		require.NoError(t, nErr)
+		return channel
+	}
+	open := newChannel(team.Id, model.ChannelTypeOpen)
+	private := newChannel(team.Id, model.ChannelTypePrivate)
+	deleted := newChannel(team.Id, model.ChannelTypeOpen)
+	otherTeam := newChannel(model.NewId(), model.ChannelTypeOpen)
+
+	newUser := func() *model.User {
+		user, nErr := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: model.NewId(),
+		})
+		require.NoError(t, nErr)
+		return user
+	}
+	u1 := newUser()
+	u2 := newUser()
+
+	addMember := func(channel *model.Channel, user *model.User, admin, guest bool) {
+		_, nErr := ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:    channel.Id,
+			UserId:       user.Id,
+			NotifyProps:  model.GetDefaultChannelNotifyProps(),
+			SchemeAdmin:  admin,
+			SchemeUser:   !guest,
+			SchemeGuest:  guest,
+			LastViewedAt: 1234,
+		})
+		require.NoError(t, nErr)
+	}
+	addMember(open, u1, true, false)
+	addMember(open, u2, false, true)
+	addMember(private, u1, false, false)
+	addMember(deleted, u1, false, false)
+	addMember(otherTeam, u1, false, false)
+	require.NoError(t, ss.Channel().Delete(deleted.Id, model.GetMillis()))
+
+	type member struct{ channelID, userID string }
+	expected := []member{{open.Id, u1.Id}, {open.Id, u2.Id}, {private.Id, u1.Id}}
+	sort.Slice(expected, func(i, j int) bool {
+		if expected[i].channelID != expected[j].channelID {
+			return expected[i].channelID < expected[j].channelID
+		}
+		return expected[i].userID < expected[j].userID
+	})
+
+	t.Run("members of the open and private channels of the team", func(t *testing.T) {
+		rows, err := ss.Channel().GetChannelMembershipReport(&model.ChannelMembershipReportOptions{TeamId: team.Id})
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		for i, row := range rows {
+			assert.Equal(t, expected[i].channelID, row.ChannelId)
+			assert.Equal(t, expected[i].userID, row.UserId)
+			assert.Equal(t, team.Name, row.TeamName)
+
+			if row.ChannelId == open.Id && row.UserId == u1.Id {
+				assert.True(t, row.SchemeAdmin)
+				assert.False(t, row.SchemeGuest)
+				assert.Equal(t, u1.Username, row.Username)
+				assert.Equal(t, u1.Email, row.Email)
+				assert.Equal(t, open.Name, row.ChannelName)
+				assert.EqualValues(t, model.ChannelTypeOpen, row.ChannelType)
+				assert.EqualValues(t, 1234, row.LastViewedAt)
+			}
+			if row.ChannelId == open.Id && row.UserId == u2.Id {
+				assert.False(t, row.SchemeAdmin)
+				assert.True(t, row.SchemeGuest)
+			}
+		}
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		options := &model.ChannelMembershipReportOptions{TeamId: team.Id}
+		options.PageSize = 2
+
+		rows, err := ss.Channel().GetChannelMembershipReport(options)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		options.FromColumnValue = rows[1].ChannelId
+		options.FromId = rows[1].UserId
+		rows, err = ss.Channel().GetChannelMembershipReport(options)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, expected[2].channelID, rows[0].ChannelId)
+		assert.Equal(t, expected[2].userID, rows[0].UserId)
+	})
+}
+
 func testChannelStoreRemoveAllDeactivatedMembers(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	// Set up all the objects needed in the store.
 	t1 := model.Team{}
@@ -18,6 +18,7 @@ func TestCommandStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("Save", func(t *testing.T) { testCommandStoreSave(t, rctx, ss) })
 	t.Run("Get", func(t *testing.T) { testCommandStoreGet(t, rctx, ss) })
 	t.Run("GetByTeam", func(t *testing.T) { testCommandStoreGetByTeam(t, rctx, ss) })
+	t.Run("GetDeletedByTeamSince", func(t *testing.T) { testCommandStoreGetDeletedByTeamSince(t, rctx, ss) })
 	t.Run("GetByTrigger", func(t *testing.T) { testCommandStoreGetByTrigger(t, rctx, ss) })
 	t.Run("Delete", func(t *testing.T) { testCommandStoreDelete(t, rctx, ss) })
 	t.Run("DeleteByTeam", func(t *testing.T) { testCommandStoreDeleteByTeam(t, rctx, ss) })
@@ -83,6 +84,31 @@ func testCommandStoreGetByTeam(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Empty(t, result, "no commands should have returned")
 }
 
+func testCommandStoreGetDeletedByTeamSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+	cmds := make([]*model.Command, 3)
+	for i := range cmds {
+		cmd, nErr := ss.Command().Save(&model.Command{
+			CreatorId: model.NewId(),
+			Method:    model.CommandMethodPost,
+			TeamId:    teamID,
+			URL:       "http://nowhere.com/",
+			Trigger:   "trigger" + model.NewId(),
+		})
+		require.NoError(t, nErr)
+		cmds[i] = cmd
+	}
+
+	require.NoError(t, ss.Command().Delete(cmds[0].Id, 1000))
+	require.NoError(t, ss.Command().Delete(cmds[1].Id, 2000))
+
+	deleted, nErr := ss.Command().GetDeletedByTeamSince(teamID, 1500)
+	require.NoError(t, nErr)
+	require.Len(t, deleted, 1)
+	require.Equal(t, cmds[1].Id, deleted[0].Id)
+	require.Equal(t, int64(2000), deleted[0].DeleteAt)
+}
+
 func testCommandStoreGetByTrigger(t *testing.T, rctx request.CTX, ss store.Store) {
 	o1 := &model.Command{}
 	o1.CreatorId = model.NewId()
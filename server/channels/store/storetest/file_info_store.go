@@ -34,6 +34,7 @@ func TestFileInfoStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStor
 	t.Run("FileInfoPermanentDeleteByUser", func(t *testing.T) { testFileInfoPermanentDeleteByUser(t, rctx, ss) })
 	t.Run("FileInfoUpdateMinipreview", func(t *testing.T) { testFileInfoUpdateMinipreview(t, rctx, ss) })
 	t.Run("GetFilesBatchForIndexing", func(t *testing.T) { testFileInfoStoreGetFilesBatchForIndexing(t, rctx, ss) })
+	t.Run("GetChannelFilesBatchForIndexing", func(t *testing.T) { testFileInfoStoreGetChannelFilesBatchForIndexing(t, rctx, ss) })
 	t.Run("CountAll", func(t *testing.T) { testFileInfoStoreCountAll(t, rctx, ss) })
 	t.Run("GetStorageUsage", func(t *testing.T) { testFileInfoGetStorageUsage(t, rctx, ss) })
 	t.Run("GetUptoNSizeFileTime", func(t *testing.T) { testGetUptoNSizeFileTime(t, rctx, ss, s) })
@@ -785,6 +786,56 @@ func testFileInfoStoreGetFilesBatchForIndexing(t *testing.T, rctx request.CTX, s
 	require.Len(t, r, 0, "Expected 0 posts in results. Got %v", len(r))
 }
 
+func testFileInfoStoreGetChannelFilesBatchForIndexing(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+
+	var files []*model.FileInfo
+	for i, id := range []string{channelID, otherChannelID, channelID, channelID} {
+		f, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+			PostId:    model.NewId(),
+			ChannelId: id,
+			CreatorId: model.NewId(),
+			Path:      fmt.Sprintf("file%d.txt", i),
+		})
+		require.NoError(t, err)
+		defer func() {
+			ss.FileInfo().PermanentDelete(rctx, f.Id)
+		}()
+		files = append(files, f)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Soft-deleting one file info
+	_, err := ss.FileInfo().DeleteForPost(rctx, files[3].PostId)
+	require.NoError(t, err)
+
+	// Getting all the files of the channel
+	r, err := ss.FileInfo().GetChannelFilesBatchForIndexing(channelID, files[0].CreateAt-1, "", true, 100)
+	require.NoError(t, err)
+	require.Len(t, r, 3, "Expected 3 files in results. Got %v", len(r))
+	for _, file := range r {
+		assert.Equal(t, channelID, file.ChannelId)
+	}
+
+	r, err = ss.FileInfo().GetChannelFilesBatchForIndexing(channelID, files[0].CreateAt-1, "", false, 100)
+	require.NoError(t, err)
+	require.Len(t, r, 2, "Expected 2 files in results. Got %v", len(r))
+
+	// Testing pagination
+	r, err = ss.FileInfo().GetChannelFilesBatchForIndexing(channelID, files[0].CreateAt-1, "", true, 2)
+	require.NoError(t, err)
+	require.Len(t, r, 2, "Expected 2 files in results. Got %v", len(r))
+
+	r, err = ss.FileInfo().GetChannelFilesBatchForIndexing(channelID, r[1].CreateAt, r[1].Id, true, 2)
+	require.NoError(t, err)
+	require.Len(t, r, 1, "Expected 1 file in results. Got %v", len(r))
+
+	r, err = ss.FileInfo().GetChannelFilesBatchForIndexing(channelID, r[0].CreateAt, r[0].Id, true, 2)
+	require.NoError(t, err)
+	require.Len(t, r, 0, "Expected 0 files in results. Got %v", len(r))
+}
+
 func testFileInfoStoreCountAll(t *testing.T, rctx request.CTX, ss store.Store) {
 	_, err := ss.FileInfo().PermanentDeleteBatch(rctx, model.GetMillis(), 100000)
 	require.NoError(t, err)
@@ -26,6 +26,7 @@ func TestJobStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("JobGetAllByTypesPage", func(t *testing.T) { testJobGetAllByTypesPage(t, rctx, ss) })
 	t.Run("JobGetAllByTypeAndStatusPage", func(t *testing.T) { testJobGetAllByTypeAndStatusPage(t, rctx, ss) })
 	t.Run("JobGetAllByStatus", func(t *testing.T) { testJobGetAllByStatus(t, rctx, ss) })
+	t.Run("JobGetAllByRootId", func(t *testing.T) { testJobGetAllByRootId(t, rctx, ss) })
 	t.Run("GetNewestJobByStatusAndType", func(t *testing.T) { testJobStoreGetNewestJobByStatusAndType(t, rctx, ss) })
 	t.Run("GetNewestJobByStatusesAndType", func(t *testing.T) { testJobStoreGetNewestJobByStatusesAndType(t, rctx, ss) })
 	t.Run("GetCountByStatusAndType", func(t *testing.T) { testJobStoreGetCountByStatusAndType(t, rctx, ss) })
@@ -365,6 +366,63 @@ func testJobGetAllByStatus(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Equal(t, "data", received[1].Data["test"], "should've received job data field back as saved")
 }
 
+func testJobGetAllByRootId(t *testing.T, rctx request.CTX, ss store.Store) {
+	jobType := model.NewId()
+	root := &model.Job{
+		Id:       model.NewId(),
+		Type:     jobType,
+		CreateAt: 1000,
+		Status:   model.JobStatusError,
+	}
+	retry := &model.Job{
+		Id:         model.NewId(),
+		Type:       jobType,
+		CreateAt:   1001,
+		Status:     model.JobStatusSuccess,
+		ParentId:   root.Id,
+		RootId:     root.Id,
+		RetryCount: 1,
+		RunAfter:   2000,
+	}
+	followUp := &model.Job{
+		Id:       model.NewId(),
+		Type:     model.NewId(),
+		CreateAt: 1002,
+		Status:   model.JobStatusPending,
+		ParentId: retry.Id,
+		RootId:   root.Id,
+	}
+	other := &model.Job{
+		Id:       model.NewId(),
+		Type:     jobType,
+		CreateAt: 1003,
+		Status:   model.JobStatusPending,
+	}
+
+	for _, job := range []*model.Job{followUp, root, other, retry} {
+		_, err := ss.Job().Save(job)
+		require.NoError(t, err)
+		defer ss.Job().Delete(job.Id)
+	}
+
+	lineage, err := ss.Job().GetAllByRootId(rctx, root.Id)
+	require.NoError(t, err)
+	require.Len(t, lineage, 3)
+	assert.Equal(t, root.Id, lineage[0].Id)
+	assert.Equal(t, retry.Id, lineage[1].Id)
+	assert.Equal(t, followUp.Id, lineage[2].Id)
+
+	assert.Equal(t, root.Id, lineage[1].ParentId)
+	assert.Equal(t, root.Id, lineage[1].RootId)
+	assert.Equal(t, 1, lineage[1].RetryCount)
+	assert.Equal(t, int64(2000), lineage[1].RunAfter)
+
+	lineage, err = ss.Job().GetAllByRootId(rctx, other.Id)
+	require.NoError(t, err)
+	require.Len(t, lineage, 1)
+	assert.Equal(t, other.Id, lineage[0].Id)
+}
+
 func testJobStoreGetNewestJobByStatusAndType(t *testing.T, rctx request.CTX, ss store.Store) {
 	jobType1 := model.NewId()
 	jobType2 := model.NewId()
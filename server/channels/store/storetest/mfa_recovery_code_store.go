@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaRecoveryCodeStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveForUser", func(t *testing.T) { testSaveMfaRecoveryCodesForUser(t, rctx, ss) })
+	t.Run("MarkUsed", func(t *testing.T) { testMarkMfaRecoveryCodeUsed(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPermanentDeleteMfaRecoveryCodesByUser(t, rctx, ss) })
+}
+
+func newTestMfaRecoveryCodes(count int) []*model.MfaRecoveryCode {
+	codes := make([]*model.MfaRecoveryCode, 0, count)
+	for i := 0; i < count; i++ {
+		codes = append(codes, &model.MfaRecoveryCode{CodeHash: model.HashMfaRecoveryCode([]byte("key"), model.NewId(), model.NewMfaRecoveryCode())})
+	}
+	return codes
+}
+
+func testSaveMfaRecoveryCodesForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.MfaRecoveryCode().PermanentDeleteByUser(userID)
+
+	first := newTestMfaRecoveryCodes(3)
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, first))
+
+	codes, err := ss.MfaRecoveryCode().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	for _, code := range codes {
+		assert.Equal(t, userID, code.UserId)
+		assert.Zero(t, code.UsedAt)
+	}
+
+	t.Run("replaces the previous codes", func(t *testing.T) {
+		second := newTestMfaRecoveryCodes(2)
+		require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, second))
+
+		codes, err := ss.MfaRecoveryCode().GetForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, codes, 2)
+		assert.ElementsMatch(t, []string{second[0].CodeHash, second[1].CodeHash}, []string{codes[0].CodeHash, codes[1].CodeHash})
+	})
+
+	t.Run("keeps the previous codes on failure", func(t *testing.T) {
+		invalid := []*model.MfaRecoveryCode{{CodeHash: "invalid"}}
+		require.Error(t, ss.MfaRecoveryCode().SaveForUser(userID, invalid))
+
+		codes, err := ss.MfaRecoveryCode().GetForUser(userID)
+		require.NoError(t, err)
+		assert.Len(t, codes, 2)
+	})
+}
+
+func testMarkMfaRecoveryCodeUsed(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.MfaRecoveryCode().PermanentDeleteByUser(userID)
+
+	codes := newTestMfaRecoveryCodes(2)
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, codes))
+
+	require.NoError(t, ss.MfaRecoveryCode().MarkUsed(codes[0].Id, 1000))
+
+	err := ss.MfaRecoveryCode().MarkUsed(codes[0].Id, 2000)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	saved, err := ss.MfaRecoveryCode().GetForUser(userID)
+	require.NoError(t, err)
+	for _, code := range saved {
+		if code.Id == codes[0].Id {
+			assert.Equal(t, int64(1000), code.UsedAt)
+		} else {
+			assert.Zero(t, code.UsedAt)
+		}
+	}
+}
+
+func testPermanentDeleteMfaRecoveryCodesByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	defer ss.MfaRecoveryCode().PermanentDeleteByUser(otherUserID)
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, newTestMfaRecoveryCodes(2)))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(otherUserID, newTestMfaRecoveryCodes(2)))
+
+	require.NoError(t, ss.MfaRecoveryCode().PermanentDeleteByUser(userID))
+
+	codes, err := ss.MfaRecoveryCode().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, codes)
+
+	codes, err = ss.MfaRecoveryCode().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, codes, 2)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaResetRequestStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testSaveAndGetMfaResetRequest(t, rctx, ss) })
+	t.Run("GetAll", func(t *testing.T) { testGetAllMfaResetRequests(t, rctx, ss) })
+	t.Run("Resolve", func(t *testing.T) { testResolveMfaResetRequest(t, rctx, ss) })
+}
+
+func testSaveAndGetMfaResetRequest(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.MfaResetRequest().PermanentDeleteByUser(userID)
+
+	request, err := ss.MfaResetRequest().Save(&model.MfaResetRequest{UserId: userID})
+	require.NoError(t, err)
+	assert.Equal(t, model.MfaResetRequestStatusPending, request.Status)
+
+	_, err = ss.MfaResetRequest().Save(&model.MfaResetRequest{UserId: "junk"})
+	require.Error(t, err)
+
+	saved, err := ss.MfaResetRequest().Get(request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, request, saved)
+
+	pending, err := ss.MfaResetRequest().GetPendingForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, request.Id, pending.Id)
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.MfaResetRequest().Get(model.NewId())
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.MfaResetRequest().GetPendingForUser(model.NewId())
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testGetAllMfaResetRequests(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.MfaResetRequest().PermanentDeleteByUser(userID)
+
+	first, err := ss.MfaResetRequest().Save(&model.MfaResetRequest{UserId: userID, CreateAt: 1000})
+	require.NoError(t, err)
+	second, err := ss.MfaResetRequest().Save(&model.MfaResetRequest{UserId: userID, CreateAt: 2000})
+	require.NoError(t, err)
+	require.NoError(t, ss.MfaResetRequest().Resolve(first.Id, model.MfaResetRequestStatusDenied, model.NewId(), 3000))
+
+	filter := func(requests []*model.MfaResetRequest) []string {
+		ids := []string{}
+		for _, request := range requests {
+			if request.UserId == userID {
+				ids = append(ids, request.Id)
+			}
+		}
+		return ids
+	}
+
+	requests, err := ss.MfaResetRequest().GetAll("", 0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.Id, first.Id}, filter(requests))
+
+	requests, err = ss.MfaResetRequest().GetAll(model.MfaResetRequestStatusPending, 0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.Id}, filter(requests))
+
+	requests, err = ss.MfaResetRequest().GetAll(model.MfaResetRequestStatusDenied, 0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Id}, filter(requests))
+}
+
+func testResolveMfaResetRequest(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	adminID := model.NewId()
+	defer ss.MfaResetRequest().PermanentDeleteByUser(userID)
+
+	request, err := ss.MfaResetRequest().Save(&model.MfaResetRequest{UserId: userID})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.MfaResetRequest().Resolve(request.Id, model.MfaResetRequestStatusApproved, adminID, request.CreateAt+1))
+
+	err = ss.MfaResetRequest().Resolve(request.Id, model.MfaResetRequestStatusDenied, adminID, request.CreateAt+2)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	saved, err := ss.MfaResetRequest().Get(request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MfaResetRequestStatusApproved, saved.Status)
+	assert.Equal(t, adminID, saved.ResolvedBy)
+	assert.Equal(t, request.CreateAt+1, saved.UpdateAt)
+
+	_, err = ss.MfaResetRequest().GetPendingForUser(userID)
+	require.ErrorAs(t, err, &nfErr)
+}
@@ -72,6 +72,66 @@ func (_m *ChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since i
 	return r0, r1
 }
 
+// GetMembersLeftSince provides a mock function with given fields: teamID, since
+func (_m *ChannelMemberHistoryStore) GetMembersLeftSince(teamID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	ret := _m.Called(teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembersLeftSince")
+	}
+
+	var r0 []*model.ChannelMemberHistoryResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.ChannelMemberHistoryResult, error)); ok {
+		return rf(teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.ChannelMemberHistoryResult); ok {
+		r0 = rf(teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistoryResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembershipChangesSince provides a mock function with given fields: channelID, since
+func (_m *ChannelMemberHistoryStore) GetMembershipChangesSince(channelID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	ret := _m.Called(channelID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembershipChangesSince")
+	}
+
+	var r0 []*model.ChannelMemberHistoryResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.ChannelMemberHistoryResult, error)); ok {
+		return rf(channelID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.ChannelMemberHistoryResult); ok {
+		r0 = rf(channelID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistoryResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(channelID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsersInChannelDuring provides a mock function with given fields: startTime, endTime, channelID
 func (_m *ChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
 	ret := _m.Called(startTime, endTime, channelID)
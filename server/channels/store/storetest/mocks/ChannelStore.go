@@ -922,6 +922,36 @@ func (_m *ChannelStore) GetChannelMembersTimezones(channelID string) ([]model.St
 	return r0, r1
 }
 
+// GetChannelMembershipReport provides a mock function with given fields: filter
+func (_m *ChannelStore) GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelMembershipReport")
+	}
+
+	var r0 []*model.ChannelMembershipReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*model.ChannelMembershipReportOptions) []*model.ChannelMembershipReport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMembershipReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.ChannelMembershipReportOptions) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelUnread provides a mock function with given fields: channelID, userID
 func (_m *ChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 	ret := _m.Called(channelID, userID)
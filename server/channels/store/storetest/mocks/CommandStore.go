@@ -150,6 +150,36 @@ func (_m *CommandStore) GetByTrigger(teamID string, trigger string) (*model.Comm
 	return r0, r1
 }
 
+// GetDeletedByTeamSince provides a mock function with given fields: teamID, since
+func (_m *CommandStore) GetDeletedByTeamSince(teamID string, since int64) ([]*model.Command, error) {
+	ret := _m.Called(teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedByTeamSince")
+	}
+
+	var r0 []*model.Command
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.Command, error)); ok {
+		return rf(teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.Command); ok {
+		r0 = rf(teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Command)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByTeam provides a mock function with given fields: teamID
 func (_m *CommandStore) PermanentDeleteByTeam(teamID string) error {
 	ret := _m.Called(teamID)
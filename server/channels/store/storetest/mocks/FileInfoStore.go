@@ -184,6 +184,36 @@ func (_m *FileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetChannelFilesBatchForIndexing provides a mock function with given fields: channelID, startTime, startFileID, includeDeleted, limit
+func (_m *FileInfoStore) GetChannelFilesBatchForIndexing(channelID string, startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+	ret := _m.Called(channelID, startTime, startFileID, includeDeleted, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelFilesBatchForIndexing")
+	}
+
+	var r0 []*model.FileForIndexing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, string, bool, int) ([]*model.FileForIndexing, error)); ok {
+		return rf(channelID, startTime, startFileID, includeDeleted, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, string, bool, int) []*model.FileForIndexing); ok {
+		r0 = rf(channelID, startTime, startFileID, includeDeleted, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileForIndexing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, string, bool, int) error); ok {
+		r1 = rf(channelID, startTime, startFileID, includeDeleted, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFilesBatchForIndexing provides a mock function with given fields: startTime, startFileID, includeDeleted, limit
 func (_m *FileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 	ret := _m.Called(startTime, startFileID, includeDeleted, limit)
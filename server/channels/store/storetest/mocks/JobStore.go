@@ -91,6 +91,36 @@ func (_m *JobStore) Get(c request.CTX, id string) (*model.Job, error) {
 	return r0, r1
 }
 
+// GetAllByRootId provides a mock function with given fields: c, rootID
+func (_m *JobStore) GetAllByRootId(c request.CTX, rootID string) ([]*model.Job, error) {
+	ret := _m.Called(c, rootID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByRootId")
+	}
+
+	var r0 []*model.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(request.CTX, string) ([]*model.Job, error)); ok {
+		return rf(c, rootID)
+	}
+	if rf, ok := ret.Get(0).(func(request.CTX, string) []*model.Job); ok {
+		r0 = rf(c, rootID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(request.CTX, string) error); ok {
+		r1 = rf(c, rootID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllByStatus provides a mock function with given fields: c, status
 func (_m *JobStore) GetAllByStatus(c request.CTX, status string) ([]*model.Job, error) {
 	ret := _m.Called(c, status)
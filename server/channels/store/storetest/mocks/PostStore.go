@@ -161,9 +161,69 @@ func (_m *PostStore) Get(ctx context.Context, id string, opts model.GetPostsOpti
 	return r0, r1
 }
 
-// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetChannelPostsBatchForIndexing provides a mock function with given fields: channelID, startTime, startPostID, limit
+func (_m *PostStore) GetChannelPostsBatchForIndexing(channelID string, startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+	ret := _m.Called(channelID, startTime, startPostID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelPostsBatchForIndexing")
+	}
+
+	var r0 []*model.PostForIndexing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, string, int) ([]*model.PostForIndexing, error)); ok {
+		return rf(channelID, startTime, startPostID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, string, int) []*model.PostForIndexing); ok {
+		r0 = rf(channelID, startTime, startPostID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostForIndexing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, string, int) error); ok {
+		r1 = rf(channelID, startTime, startPostID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeletedForExportAfter provides a mock function with given fields: limit, afterID, since
+func (_m *PostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.DeletedPostForExport, error) {
+	ret := _m.Called(limit, afterID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedForExportAfter")
+	}
+
+	var r0 []*model.DeletedPostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, int64) ([]*model.DeletedPostForExport, error)); ok {
+		return rf(limit, afterID, since)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, int64) []*model.DeletedPostForExport); ok {
+		r0 = rf(limit, afterID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DeletedPostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, int64) error); ok {
+		r1 = rf(limit, afterID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, since, includeArchivedChannels
+func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+	ret := _m.Called(limit, afterID, since, includeArchivedChannels)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDirectPostParentsForExportAfter")
@@ -171,19 +231,19 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 
 	var r0 []*model.DirectPostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.DirectPostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, int64, bool) ([]*model.DirectPostForExport, error)); ok {
+		return rf(limit, afterID, since, includeArchivedChannels)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.DirectPostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, int64, bool) []*model.DirectPostForExport); ok {
+		r0 = rf(limit, afterID, since, includeArchivedChannels)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.DirectPostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, int64, bool) error); ok {
+		r1 = rf(limit, afterID, since, includeArchivedChannels)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -433,9 +493,9 @@ func (_m *PostStore) GetOldestEntityCreationTime() (int64, error) {
 	return r0, r1
 }
 
-// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, since, includeArchivedChannels
+func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, since, includeArchivedChannels)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetParentsForExportAfter")
@@ -443,19 +503,19 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, include
 
 	var r0 []*model.PostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.PostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, int64, bool) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, since, includeArchivedChannels)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.PostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, int64, bool) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, since, includeArchivedChannels)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.PostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, int64, bool) error); ok {
+		r1 = rf(limit, afterID, since, includeArchivedChannels)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -700,6 +700,24 @@ func (_m *SharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPost
 	return r0
 }
 
+// UpdateRemoteLastChannelSyncAt provides a mock function with given fields: id, syncTime
+func (_m *SharedChannelStore) UpdateRemoteLastChannelSyncAt(id string, syncTime int64) error {
+	ret := _m.Called(id, syncTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemoteLastChannelSyncAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, syncTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserLastSyncAt provides a mock function with given fields: userID, channelID, remoteID
 func (_m *SharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 	ret := _m.Called(userID, channelID, remoteID)
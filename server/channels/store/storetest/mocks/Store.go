@@ -44,6 +44,42 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditRecord provides a mock function with given fields:
+func (_m *Store) AuditRecord() store.AuditRecordStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditRecord")
+	}
+
+	var r0 store.AuditRecordStore
+	if rf, ok := ret.Get(0).(func() store.AuditRecordStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.AuditRecordStore)
+	}
+
+	return r0
+}
+
+// BatchedNotification provides a mock function with given fields:
+func (_m *Store) BatchedNotification() store.BatchedNotificationStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchedNotification")
+	}
+
+	var r0 store.BatchedNotificationStore
+	if rf, ok := ret.Get(0).(func() store.BatchedNotificationStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.BatchedNotificationStore)
+	}
+
+	return r0
+}
+
 // Bot provides a mock function with given fields:
 func (_m *Store) Bot() store.BotStore {
 	ret := _m.Called()
@@ -598,6 +634,42 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MfaRecoveryCode provides a mock function with given fields:
+func (_m *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MfaRecoveryCode")
+	}
+
+	var r0 store.MfaRecoveryCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaRecoveryCodeStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.MfaRecoveryCodeStore)
+	}
+
+	return r0
+}
+
+// MfaResetRequest provides a mock function with given fields:
+func (_m *Store) MfaResetRequest() store.MfaResetRequestStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MfaResetRequest")
+	}
+
+	var r0 store.MfaResetRequestStore
+	if rf, ok := ret.Get(0).(func() store.MfaResetRequestStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.MfaResetRequestStore)
+	}
+
+	return r0
+}
+
 // NotifyAdmin provides a mock function with given fields:
 func (_m *Store) NotifyAdmin() store.NotifyAdminStore {
 	ret := _m.Called()
@@ -919,6 +991,24 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// ScheduledPost provides a mock function with given fields:
+func (_m *Store) ScheduledPost() store.ScheduledPostStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduledPost")
+	}
+
+	var r0 store.ScheduledPostStore
+	if rf, ok := ret.Get(0).(func() store.ScheduledPostStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.ScheduledPostStore)
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -1243,6 +1333,24 @@ func (_m *Store) UserTermsOfService() store.UserTermsOfServiceStore {
 	return r0
 }
 
+// WebAuthnCredential provides a mock function with given fields:
+func (_m *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebAuthnCredential")
+	}
+
+	var r0 store.WebAuthnCredentialStore
+	if rf, ok := ret.Get(0).(func() store.WebAuthnCredentialStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.WebAuthnCredentialStore)
+	}
+
+	return r0
+}
+
 // Webhook provides a mock function with given fields:
 func (_m *Store) Webhook() store.WebhookStore {
 	ret := _m.Called()
@@ -662,6 +662,66 @@ func (_m *TeamStore) GetMembersByIds(teamID string, userIds []string, restrictio
 	return r0, r1
 }
 
+// GetMembersLeftSince provides a mock function with given fields: teamID, since
+func (_m *TeamStore) GetMembersLeftSince(teamID string, since int64) ([]*model.TeamMember, error) {
+	ret := _m.Called(teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembersLeftSince")
+	}
+
+	var r0 []*model.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.TeamMember, error)); ok {
+		return rf(teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.TeamMember); ok {
+		r0 = rf(teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeamActivityReport provides a mock function with given fields: filter
+func (_m *TeamStore) GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamActivityReport")
+	}
+
+	var r0 []*model.TeamActivityReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*model.TeamActivityReportOptions) []*model.TeamActivityReport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamActivityReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.TeamActivityReportOptions) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamMembersForExport provides a mock function with given fields: userID
 func (_m *TeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	ret := _m.Called(userID)
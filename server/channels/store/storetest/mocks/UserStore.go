@@ -801,6 +801,36 @@ func (_m *UserStore) GetForLogin(loginID string, allowSignInWithUsername bool, a
 	return r0, r1
 }
 
+// GetIdsWithMembershipsUpdatedSince provides a mock function with given fields: since, includeArchivedChannels
+func (_m *UserStore) GetIdsWithMembershipsUpdatedSince(since int64, includeArchivedChannels bool) ([]string, error) {
+	ret := _m.Called(since, includeArchivedChannels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdsWithMembershipsUpdatedSince")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, bool) ([]string, error)); ok {
+		return rf(since, includeArchivedChannels)
+	}
+	if rf, ok := ret.Get(0).(func(int64, bool) []string); ok {
+		r0 = rf(since, includeArchivedChannels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(since, includeArchivedChannels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKnownUsers provides a mock function with given fields: userID
 func (_m *UserStore) GetKnownUsers(userID string) ([]string, error) {
 	ret := _m.Called(userID)
@@ -48,10 +48,12 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("OverwriteMultiple", func(t *testing.T) { testPostStoreOverwriteMultiple(t, rctx, ss) })
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, rctx, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, rctx, ss) })
+	t.Run("GetChannelPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetChannelPostsBatchForIndexing(t, rctx, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testPostStorePermanentDeleteBatch(t, rctx, ss) })
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, rctx, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, rctx, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, rctx, ss) })
+	t.Run("GetDeletedForExportAfter", func(t *testing.T) { testPostStoreGetDeletedForExportAfter(t, rctx, ss) })
 	t.Run("GetRepliesForExport", func(t *testing.T) { testPostStoreGetRepliesForExport(t, rctx, ss) })
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, rctx, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, rctx, ss, s) })
@@ -3957,6 +3959,66 @@ func testPostStoreGetPostsBatchForIndexing(t *testing.T, rctx request.CTX, ss st
 	require.Len(t, r, 0, "Expected 0 post in results. Got %v", len(r))
 }
 
+func testPostStoreGetChannelPostsBatchForIndexing(t *testing.T, rctx request.CTX, ss store.Store) {
+	c1 := &model.Channel{}
+	c1.TeamId = model.NewId()
+	c1.DisplayName = "Channel1"
+	c1.Name = NewTestId()
+	c1.Type = model.ChannelTypeOpen
+	c1, _ = ss.Channel().Save(rctx, c1, -1)
+
+	c2 := &model.Channel{}
+	c2.TeamId = model.NewId()
+	c2.DisplayName = "Channel2"
+	c2.Name = NewTestId()
+	c2.Type = model.ChannelTypeOpen
+	c2, _ = ss.Channel().Save(rctx, c2, -1)
+
+	o1 := &model.Post{}
+	o1.ChannelId = c1.Id
+	o1.UserId = model.NewId()
+	o1.Message = NewTestId()
+	o1, err := ss.Post().Save(rctx, o1)
+	require.NoError(t, err)
+
+	o2 := &model.Post{}
+	o2.ChannelId = c2.Id
+	o2.UserId = model.NewId()
+	o2.Message = NewTestId()
+	_, err = ss.Post().Save(rctx, o2)
+	require.NoError(t, err)
+
+	o3 := &model.Post{}
+	o3.ChannelId = c1.Id
+	o3.UserId = model.NewId()
+	o3.RootId = o1.Id
+	o3.Message = NewTestId()
+	_, err = ss.Post().Save(rctx, o3)
+	require.NoError(t, err)
+
+	// Getting all the posts of the channel
+	r, err := ss.Post().GetChannelPostsBatchForIndexing(c1.Id, o1.CreateAt-1, "", 100)
+	require.NoError(t, err)
+	require.Len(t, r, 2, "Expected 2 posts in results. Got %v", len(r))
+	for _, post := range r {
+		assert.Equal(t, c1.Id, post.ChannelId)
+		assert.Equal(t, c1.TeamId, post.TeamId)
+	}
+
+	// Testing pagination
+	r, err = ss.Post().GetChannelPostsBatchForIndexing(c1.Id, o1.CreateAt-1, "", 1)
+	require.NoError(t, err)
+	require.Len(t, r, 1, "Expected 1 post in results. Got %v", len(r))
+
+	r, err = ss.Post().GetChannelPostsBatchForIndexing(c1.Id, r[0].CreateAt, r[0].Id, 1)
+	require.NoError(t, err)
+	require.Len(t, r, 1, "Expected 1 post in results. Got %v", len(r))
+
+	r, err = ss.Post().GetChannelPostsBatchForIndexing(c1.Id, r[0].CreateAt, r[0].Id, 1)
+	require.NoError(t, err)
+	require.Len(t, r, 0, "Expected 0 post in results. Got %v", len(r))
+}
+
 func testPostStorePermanentDeleteBatch(t *testing.T, rctx request.CTX, ss store.Store) {
 	team, err := ss.Team().Save(&model.Team{
 		DisplayName: "DisplayName",
@@ -4305,7 +4367,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	require.NoError(t, nErr)
 
 	t.Run("without archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), 0, false)
 		assert.NoError(t, err)
 
 		found := false
@@ -4328,7 +4390,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	})
 
 	t.Run("with archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), 0, true)
 		assert.NoError(t, err)
 
 		found := false
@@ -4344,6 +4406,76 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 		}
 		assert.True(t, found)
 	})
+
+	t.Run("updated since", func(t *testing.T) {
+		p3 := &model.Post{}
+		p3.ChannelId = c1.Id
+		p3.UserId = u1.Id
+		p3.Message = NewTestId()
+		p3.CreateAt = 3000
+		p3, nErr = ss.Post().Save(rctx, p3)
+		require.NoError(t, nErr)
+
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), 2000, false)
+		assert.NoError(t, err)
+
+		var ids []string
+		for _, p := range posts {
+			ids = append(ids, p.Id)
+		}
+		assert.Contains(t, ids, p3.Id)
+		assert.NotContains(t, ids, p1.Id, "posts not updated since the given time should not be returned")
+	})
+}
+
+func testPostStoreGetDeletedForExportAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	t1 := model.Team{}
+	t1.DisplayName = "Name"
+	t1.Name = NewTestId()
+	t1.Email = MakeEmail()
+	t1.Type = model.TeamOpen
+	_, err := ss.Team().Save(&t1)
+	require.NoError(t, err)
+
+	c1 := model.Channel{}
+	c1.TeamId = t1.Id
+	c1.DisplayName = "Channel1"
+	c1.Name = NewTestId()
+	c1.Type = model.ChannelTypeOpen
+	_, nErr := ss.Channel().Save(rctx, &c1, -1)
+	require.NoError(t, nErr)
+
+	u1 := model.User{}
+	u1.Username = model.NewId()
+	u1.Email = MakeEmail()
+	_, err = ss.User().Save(rctx, &u1)
+	require.NoError(t, err)
+
+	p1, nErr := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u1.Id, Message: NewTestId(), CreateAt: 1000})
+	require.NoError(t, nErr)
+	p2, nErr := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u1.Id, Message: NewTestId(), CreateAt: 1001, RootId: p1.Id})
+	require.NoError(t, nErr)
+	p3, nErr := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u1.Id, Message: NewTestId(), CreateAt: 1002})
+	require.NoError(t, nErr)
+
+	require.NoError(t, ss.Post().Delete(rctx, p2.Id, 5000, u1.Id))
+	require.NoError(t, ss.Post().Delete(rctx, p3.Id, 1500, u1.Id))
+
+	posts, err := ss.Post().GetDeletedForExportAfter(10000, strings.Repeat("0", 26), 2000)
+	require.NoError(t, err)
+
+	found := map[string]*model.DeletedPostForExport{}
+	for _, p := range posts {
+		found[p.Id] = p
+	}
+	require.Contains(t, found, p2.Id)
+	assert.Equal(t, p2.CreateAt, found[p2.Id].CreateAt)
+	assert.Equal(t, int64(5000), found[p2.Id].DeleteAt)
+	assert.Equal(t, u1.Username, found[p2.Id].Username)
+	assert.Equal(t, c1.Name, found[p2.Id].ChannelName)
+	assert.Equal(t, t1.Name, found[p2.Id].TeamName)
+	assert.NotContains(t, found, p1.Id, "posts that are not deleted should not be returned")
+	assert.NotContains(t, found, p3.Id, "posts deleted before the given time should not be returned")
 }
 
 func testPostStoreGetRepliesForExport(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4457,7 +4589,7 @@ func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, rctx request.
 	p1, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), 0, false)
 	assert.NoError(t, nErr)
 
 	assert.Equal(t, p1.Message, r1[0].Message)
@@ -4517,11 +4649,11 @@ func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, rctx r
 	_, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), 0, false)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 0, len(r1))
 
-	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), 0, true)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 1, len(r1))
 
@@ -4580,7 +4712,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	sort.Slice(postIds, func(i, j int) bool { return postIds[i] < postIds[j] })
 
 	// Get all posts
-	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), 0, false)
 	assert.NoError(t, err)
 	assert.Equal(t, len(postIds), len(r1))
 	var exportedPostIds []string
@@ -4591,7 +4723,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	assert.ElementsMatch(t, postIds, exportedPostIds)
 
 	// Get 100
-	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false)
+	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), 0, false)
 	assert.NoError(t, err)
 	assert.Equal(t, 100, len(r1))
 	exportedPostIds = []string{}
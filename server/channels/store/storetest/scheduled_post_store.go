@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestScheduledPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testSaveAndGetScheduledPost(t, rctx, ss) })
+	t.Run("Update", func(t *testing.T) { testUpdateScheduledPost(t, rctx, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testGetScheduledPostsForUser(t, rctx, ss) })
+	t.Run("GetPendingScheduledPosts", func(t *testing.T) { testGetPendingScheduledPosts(t, rctx, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testPermanentDeleteScheduledPost(t, rctx, ss) })
+}
+
+func newTestScheduledPost(userID, channelID string, scheduledAt int64) *model.ScheduledPost {
+	return &model.ScheduledPost{
+		Draft: model.Draft{
+			UserId:    userID,
+			ChannelId: channelID,
+			Message:   "scheduled message",
+		},
+		ScheduledAt: scheduledAt,
+	}
+}
+
+func testSaveAndGetScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	channelID := model.NewId()
+
+	t.Run("save and get a scheduled post", func(t *testing.T) {
+		scheduledPost := newTestScheduledPost(userID, channelID, model.GetMillis()+100000)
+		scheduledPost.SetProps(model.StringInterface{"key": "value"})
+		scheduledPost.FileIds = []string{model.NewId()}
+
+		saved, err := ss.ScheduledPost().Save(scheduledPost)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+		defer ss.ScheduledPost().PermanentDelete(saved.Id)
+
+		got, err := ss.ScheduledPost().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved.Message, got.Message)
+		assert.Equal(t, saved.ScheduledAt, got.ScheduledAt)
+		assert.Equal(t, saved.FileIds, got.FileIds)
+		assert.Equal(t, "value", got.GetProps()["key"])
+		assert.Zero(t, got.ProcessedAt)
+		assert.Empty(t, got.ErrorCode)
+	})
+
+	t.Run("fail to save an invalid scheduled post", func(t *testing.T) {
+		scheduledPost := newTestScheduledPost(userID, channelID, 0)
+
+		_, err := ss.ScheduledPost().Save(scheduledPost)
+		require.Error(t, err)
+	})
+
+	t.Run("get a missing scheduled post", func(t *testing.T) {
+		_, err := ss.ScheduledPost().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testUpdateScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	saved, err := ss.ScheduledPost().Save(newTestScheduledPost(model.NewId(), model.NewId(), model.GetMillis()+100000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(saved.Id)
+
+	saved.Message = "updated message"
+	saved.ScheduledAt += 1000
+	saved.ProcessedAt = model.GetMillis()
+	saved.ErrorCode = model.ScheduledPostErrorCodeChannelArchived
+
+	_, err = ss.ScheduledPost().Update(saved)
+	require.NoError(t, err)
+
+	got, err := ss.ScheduledPost().Get(saved.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "updated message", got.Message)
+	assert.Equal(t, saved.ScheduledAt, got.ScheduledAt)
+	assert.Equal(t, saved.ProcessedAt, got.ProcessedAt)
+	assert.Equal(t, model.ScheduledPostErrorCodeChannelArchived, got.ErrorCode)
+
+	t.Run("update a missing scheduled post", func(t *testing.T) {
+		missing := newTestScheduledPost(model.NewId(), model.NewId(), model.GetMillis())
+		missing.PreSave()
+
+		_, err := ss.ScheduledPost().Update(missing)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testGetScheduledPostsForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	teamID := model.NewId()
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      teamID,
+		DisplayName: "Channel",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	otherChannel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Other channel",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	directChannel, err := ss.Channel().Save(rctx, &model.Channel{
+		DisplayName: "Direct channel",
+		Name:        model.GetDMNameFromIds(userID, model.NewId()),
+		Type:        model.ChannelTypeDirect,
+	}, -1)
+	require.NoError(t, err)
+
+	now := model.GetMillis()
+	first, err := ss.ScheduledPost().Save(newTestScheduledPost(userID, channel.Id, now+1000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(first.Id)
+	second, err := ss.ScheduledPost().Save(newTestScheduledPost(userID, directChannel.Id, now+2000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(second.Id)
+	other, err := ss.ScheduledPost().Save(newTestScheduledPost(userID, otherChannel.Id, now+3000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(other.Id)
+	notMine, err := ss.ScheduledPost().Save(newTestScheduledPost(model.NewId(), channel.Id, now+1000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(notMine.Id)
+
+	t.Run("all teams", func(t *testing.T) {
+		scheduledPosts, err := ss.ScheduledPost().GetForUser(userID, "")
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 3)
+		assert.Equal(t, first.Id, scheduledPosts[0].Id)
+		assert.Equal(t, second.Id, scheduledPosts[1].Id)
+		assert.Equal(t, other.Id, scheduledPosts[2].Id)
+	})
+
+	t.Run("a team and the direct channels", func(t *testing.T) {
+		scheduledPosts, err := ss.ScheduledPost().GetForUser(userID, teamID)
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 2)
+		assert.Equal(t, first.Id, scheduledPosts[0].Id)
+		assert.Equal(t, second.Id, scheduledPosts[1].Id)
+	})
+}
+
+func testGetPendingScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	// Far in the future, so that the scheduled posts of the other tests are left out.
+	now := model.GetMillis() + 1000*1000*1000
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		saved, err := ss.ScheduledPost().Save(newTestScheduledPost(model.NewId(), model.NewId(), now))
+		require.NoError(t, err)
+		defer ss.ScheduledPost().PermanentDelete(saved.Id)
+		ids = append(ids, saved.Id)
+	}
+
+	processed, err := ss.ScheduledPost().Save(newTestScheduledPost(model.NewId(), model.NewId(), now))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(processed.Id)
+	processed.ProcessedAt = model.GetMillis()
+	processed.ErrorCode = model.ScheduledPostErrorCodeUnknown
+	_, err = ss.ScheduledPost().Update(processed)
+	require.NoError(t, err)
+
+	future, err := ss.ScheduledPost().Save(newTestScheduledPost(model.NewId(), model.NewId(), now+1000))
+	require.NoError(t, err)
+	defer ss.ScheduledPost().PermanentDelete(future.Id)
+
+	var pending []*model.ScheduledPost
+	afterTime, afterID := now-1, ""
+	for {
+		page, err := ss.ScheduledPost().GetPendingScheduledPosts(now, afterTime, afterID, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		pending = append(pending, page...)
+		afterTime, afterID = page[len(page)-1].ScheduledAt, page[len(page)-1].Id
+	}
+
+	var pendingIDs []string
+	for _, scheduledPost := range pending {
+		pendingIDs = append(pendingIDs, scheduledPost.Id)
+	}
+	assert.ElementsMatch(t, ids, pendingIDs)
+}
+
+func testPermanentDeleteScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	first, err := ss.ScheduledPost().Save(newTestScheduledPost(userID, model.NewId(), model.GetMillis()))
+	require.NoError(t, err)
+	second, err := ss.ScheduledPost().Save(newTestScheduledPost(userID, model.NewId(), model.GetMillis()))
+	require.NoError(t, err)
+
+	err = ss.ScheduledPost().PermanentDelete(first.Id)
+	require.NoError(t, err)
+	_, err = ss.ScheduledPost().Get(first.Id)
+	require.Error(t, err)
+
+	err = ss.ScheduledPost().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+	_, err = ss.ScheduledPost().Get(second.Id)
+	require.Error(t, err)
+}
@@ -32,6 +32,7 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("HasRemote", func(t *testing.T) { testHasRemote(t, rctx, ss) })
 	t.Run("GetRemoteForUser", func(t *testing.T) { testGetRemoteForUser(t, rctx, ss) })
 	t.Run("UpdateSharedChannelRemoteNextSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteCursor(t, rctx, ss) })
+	t.Run("UpdateSharedChannelRemoteLastChannelSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteLastChannelSyncAt(t, rctx, ss) })
 	t.Run("DeleteSharedChannelRemote", func(t *testing.T) { testDeleteSharedChannelRemote(t, rctx, ss) })
 
 	t.Run("SaveSharedChannelUser", func(t *testing.T) { testSaveSharedChannelUser(t, rctx, ss) })
@@ -813,6 +814,36 @@ func testUpdateSharedChannelRemoteCursor(t *testing.T, rctx request.CTX, ss stor
 	})
 }
 
+func testUpdateSharedChannelRemoteLastChannelSyncAt(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createTestChannel(ss, rctx, "test_remote_update_last_channel_sync_at")
+	require.NoError(t, err)
+
+	remote := &model.SharedChannelRemote{
+		ChannelId: channel.Id,
+		CreatorId: model.NewId(),
+		RemoteId:  model.NewId(),
+	}
+
+	remoteSaved, err := ss.SharedChannel().SaveRemote(remote)
+	require.NoError(t, err, "couldn't save remote", err)
+	require.Zero(t, remoteSaved.LastChannelSyncAt)
+
+	t.Run("Update LastChannelSyncAt for remote", func(t *testing.T) {
+		syncTime := model.GetMillis()
+		err := ss.SharedChannel().UpdateRemoteLastChannelSyncAt(remoteSaved.Id, syncTime)
+		require.NoError(t, err, "update LastChannelSyncAt should not error", err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, syncTime, r.LastChannelSyncAt)
+	})
+
+	t.Run("Update LastChannelSyncAt for non-existent shared channel remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteLastChannelSyncAt(model.NewId(), model.GetMillis())
+		require.Error(t, err, "update non-existent remote should error", err)
+	})
+}
+
 func testDeleteSharedChannelRemote(t *testing.T, rctx request.CTX, ss store.Store) {
 	channel, err := createTestChannel(ss, rctx, "test_remote_delete")
 	require.NoError(t, err)
@@ -65,6 +65,12 @@ type Store struct {
 	PostPersistentNotificationStore mocks.PostPersistentNotificationStore
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
+	ScheduledPostStore              mocks.ScheduledPostStore
+	BatchedNotificationStore        mocks.BatchedNotificationStore
+	WebAuthnCredentialStore         mocks.WebAuthnCredentialStore
+	MfaRecoveryCodeStore            mocks.MfaRecoveryCodeStore
+	MfaResetRequestStore            mocks.MfaResetRequestStore
+	AuditRecordStore                mocks.AuditRecordStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -123,6 +129,23 @@ func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 func (s *Store) PostPersistentNotification() store.PostPersistentNotificationStore {
 	return &s.PostPersistentNotificationStore
 }
+func (s *Store) ScheduledPost() store.ScheduledPostStore { return &s.ScheduledPostStore }
+func (s *Store) BatchedNotification() store.BatchedNotificationStore {
+	return &s.BatchedNotificationStore
+}
+func (s *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return &s.WebAuthnCredentialStore
+}
+func (s *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return &s.MfaRecoveryCodeStore
+}
+func (s *Store) MfaResetRequest() store.MfaResetRequestStore {
+	return &s.MfaResetRequestStore
+}
+
+func (s *Store) AuditRecord() store.AuditRecordStore {
+	return &s.AuditRecordStore
+}
 func (s *Store) MarkSystemRanUnitTests()             { /* do nothing */ }
 func (s *Store) Close()                              { /* do nothing */ }
 func (s *Store) LockToMaster()                       { /* do nothing */ }
@@ -188,5 +211,11 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.PostPersistentNotificationStore,
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
+		&s.ScheduledPostStore,
+		&s.BatchedNotificationStore,
+		&s.WebAuthnCredentialStore,
+		&s.MfaRecoveryCodeStore,
+		&s.MfaResetRequestStore,
+		&s.AuditRecordStore,
 	)
 }
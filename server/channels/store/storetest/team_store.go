@@ -72,6 +72,8 @@ func TestTeamStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("AnalyticsGetTeamCountForScheme", func(t *testing.T) { testTeamStoreAnalyticsGetTeamCountForScheme(t, rctx, ss) })
 	t.Run("GetAllForExportAfter", func(t *testing.T) { testTeamStoreGetAllForExportAfter(t, rctx, ss) })
 	t.Run("GetTeamMembersForExport", func(t *testing.T) { testTeamStoreGetTeamMembersForExport(t, rctx, ss) })
+	t.Run("GetMembersLeftSince", func(t *testing.T) { testTeamStoreGetMembersLeftSince(t, rctx, ss) })
+	t.Run("GetTeamActivityReport", func(t *testing.T) { testTeamStoreGetTeamActivityReport(t, rctx, ss) })
 	t.Run("GetTeamsForUserWithPagination", func(t *testing.T) { testTeamMembersWithPagination(t, rctx, ss) })
 	t.Run("GroupSyncedTeamCount", func(t *testing.T) { testGroupSyncedTeamCount(t, rctx, ss) })
 	t.Run("GetCommonTeamIDsForMultipleUsers", func(t *testing.T) { testGetCommonTeamIDsForMultipleUsers(t, rctx, ss) })
@@ -3583,6 +3585,30 @@ func testTeamStoreGetTeamMembersForExport(t *testing.T, rctx request.CTX, ss sto
 	assert.Equal(t, t1.Name, tmfe1.TeamName)
 }
 
+func testTeamStoreGetMembersLeftSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+	m1 := &model.TeamMember{TeamId: teamID, UserId: model.NewId()}
+	m2 := &model.TeamMember{TeamId: teamID, UserId: model.NewId()}
+	m3 := &model.TeamMember{TeamId: teamID, UserId: model.NewId()}
+	_, nErr := ss.Team().SaveMultipleMembers([]*model.TeamMember{m1, m2, m3}, -1)
+	require.NoError(t, nErr)
+
+	m1.DeleteAt = 1000
+	m2.DeleteAt = 2000
+	_, nErr = ss.Team().UpdateMultipleMembers([]*model.TeamMember{m1, m2})
+	require.NoError(t, nErr)
+
+	members, err := ss.Team().GetMembersLeftSince(teamID, 1500)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, m2.UserId, members[0].UserId)
+	assert.Equal(t, int64(2000), members[0].DeleteAt)
+
+	members, err = ss.Team().GetMembersLeftSince(teamID, 3000)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
 func testGroupSyncedTeamCount(t *testing.T, rctx request.CTX, ss store.Store) {
 	team1, err := ss.Team().Save(&model.Team{
 		DisplayName:      NewTestId(),
@@ -3818,3 +3844,108 @@ func testGetCommonTeamIDsForMultipleUsers(t *testing.T, rctx request.CTX, ss sto
 		require.NoError(t, err)
 	})
 }
+
+func testTeamStoreGetTeamActivityReport(t *testing.T, rctx request.CTX, ss store.Store) {
+	newTeam := func() *model.Team {
+		team, err := ss.Team().Save(&model.Team{
+			DisplayName: "DisplayName",
+			Name:        NewTestId(),
+			Email:       MakeEmail(),
+			Type:        model.TeamOpen,
+		})
+		require.NoError(t, err)
+		return team
+	}
+	team := newTeam()
+	emptyTeam := newTeam()
+
+	var users []*model.User
+	for i := 0; i < 3; i++ {
+		user, err := ss.User().Save(rctx, &model.User{Username: model.NewId(), Email: MakeEmail()})
+		require.NoError(t, err)
+		_, err = ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: team.Id, UserId: user.Id}, -1)
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+	// The last user left the team.
+	_, err := ss.Team().UpdateMember(rctx, &model.TeamMember{TeamId: team.Id, UserId: users[2].Id, DeleteAt: model.GetMillis()})
+	require.NoError(t, err)
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{TeamId: team.Id, DisplayName: "Channel", Name: NewTestId(), Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	deletedChannel, err := ss.Channel().Save(rctx, &model.Channel{TeamId: team.Id, DisplayName: "Channel", Name: NewTestId(), Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	require.NoError(t, ss.Channel().Delete(deletedChannel.Id, model.GetMillis()))
+
+	base := model.GetMillis() - 100000
+	newPost := func(user *model.User, createAt int64) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "message", CreateAt: createAt})
+		require.NoError(t, err)
+		return post
+	}
+	newPost(users[0], base+100)
+	newPost(users[0], base+200)
+	newPost(users[1], base+300)
+	deletedPost := newPost(users[1], base+400)
+	require.NoError(t, ss.Post().Delete(rctx, deletedPost.Id, model.GetMillis(), users[1].Id))
+	// Before the date range of the report.
+	newPost(users[2], base-50000)
+
+	findRow := func(t *testing.T, rows []*model.TeamActivityReport, teamID string) *model.TeamActivityReport {
+		t.Helper()
+		for _, row := range rows {
+			if row.TeamId == teamID {
+				return row
+			}
+		}
+		require.Failf(t, "missing row", "no row for the team %s", teamID)
+		return nil
+	}
+
+	t.Run("date range", func(t *testing.T) {
+		options := &model.TeamActivityReportOptions{}
+		options.StartAt = base
+		options.EndAt = base + 1000
+		rows, err := ss.Team().GetTeamActivityReport(options)
+		require.NoError(t, err)
+
+		row := findRow(t, rows, team.Id)
+		assert.Equal(t, team.Name, row.TeamName)
+		assert.Equal(t, team.DisplayName, row.DisplayName)
+		assert.EqualValues(t, 2, row.MemberCount)
+		assert.EqualValues(t, 1, row.ChannelCount)
+		assert.EqualValues(t, 3, row.PostCount)
+		assert.EqualValues(t, 2, row.ActiveUsers)
+		assert.GreaterOrEqual(t, row.LastPostAt, base+300)
+
+		row = findRow(t, rows, emptyTeam.Id)
+		assert.Zero(t, row.MemberCount)
+		assert.Zero(t, row.PostCount)
+		assert.Zero(t, row.LastPostAt)
+	})
+
+	t.Run("all time", func(t *testing.T) {
+		rows, err := ss.Team().GetTeamActivityReport(&model.TeamActivityReportOptions{})
+		require.NoError(t, err)
+
+		row := findRow(t, rows, team.Id)
+		assert.EqualValues(t, 4, row.PostCount)
+		assert.EqualValues(t, 3, row.ActiveUsers)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		options := &model.TeamActivityReportOptions{}
+		options.PageSize = 1
+		rows, err := ss.Team().GetTeamActivityReport(options)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+
+		options.PageSize = 0
+		options.FromId = team.Id
+		rows, err = ss.Team().GetTeamActivityReport(options)
+		require.NoError(t, err)
+		for _, row := range rows {
+			assert.Greater(t, row.TeamId, team.Id)
+		}
+	})
+}
@@ -88,6 +88,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetProfilesNotInTeam", func(t *testing.T) { testUserStoreGetProfilesNotInTeam(t, rctx, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testUserStoreClearAllCustomRoleAssignments(t, rctx, ss) })
 	t.Run("GetAllAfter", func(t *testing.T) { testUserStoreGetAllAfter(t, rctx, ss) })
+	t.Run("GetIdsWithMembershipsUpdatedSince", func(t *testing.T) { testUserStoreGetIdsWithMembershipsUpdatedSince(t, rctx, ss) })
 	t.Run("GetUsersBatchForIndexing", func(t *testing.T) { testUserStoreGetUsersBatchForIndexing(t, rctx, ss) })
 	t.Run("GetTeamGroupUsers", func(t *testing.T) { testUserStoreGetTeamGroupUsers(t, rctx, ss) })
 	t.Run("GetChannelGroupUsers", func(t *testing.T) { testUserStoreGetChannelGroupUsers(t, rctx, ss) })
@@ -4894,6 +4895,63 @@ func testUserStoreGetAllAfter(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 }
 
+func testUserStoreGetIdsWithMembershipsUpdatedSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamId := model.NewId()
+	users := make([]*model.User, 3)
+	for i := range users {
+		u, err := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: "u" + model.NewId(),
+		})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u.Id)) }()
+		users[i] = u
+	}
+	u1, u2, u3 := users[0], users[1], users[2]
+
+	since := model.GetMillis()
+
+	_, nErr := ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamId, UserId: u1.Id, CreateAt: since}, -1)
+	require.NoError(t, nErr)
+	_, nErr = ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamId, UserId: u2.Id, CreateAt: since - 1000}, -1)
+	require.NoError(t, nErr)
+	_, nErr = ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: teamId, UserId: u3.Id, CreateAt: since - 1000}, -1)
+	require.NoError(t, nErr)
+
+	c1, nErr := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Memberships updated",
+		Name:        "memberships-" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+	defer func() { require.NoError(t, ss.Channel().PermanentDelete(rctx, c1.Id)) }()
+
+	for _, u := range []*model.User{u2, u3} {
+		_, nErr = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:   c1.Id,
+			UserId:      u.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, nErr)
+	}
+
+	// Viewing the channel alone isn't a membership update.
+	_, nErr = ss.Channel().UpdateLastViewedAt([]string{c1.Id}, u2.Id)
+	require.NoError(t, nErr)
+
+	userIds, err := ss.User().GetIdsWithMembershipsUpdatedSince(since, false)
+	require.NoError(t, err)
+	assert.Contains(t, userIds, u1.Id)
+	assert.NotContains(t, userIds, u2.Id)
+	assert.Contains(t, userIds, u3.Id)
+
+	userIds, err = ss.User().GetIdsWithMembershipsUpdatedSince(model.GetMillis()+1000, false)
+	require.NoError(t, err)
+	assert.NotContains(t, userIds, u1.Id)
+	assert.NotContains(t, userIds, u3.Id)
+}
+
 func testUserStoreGetUsersBatchForIndexing(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Set up all the objects needed
 	t1, err := ss.Team().Save(&model.Team{
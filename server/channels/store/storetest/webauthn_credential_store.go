@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebAuthnCredentialStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testSaveAndGetWebAuthnCredentials(t, rctx, ss) })
+	t.Run("UpdateLastUsed", func(t *testing.T) { testUpdateWebAuthnCredentialLastUsed(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testDeleteWebAuthnCredentials(t, rctx, ss) })
+}
+
+func newTestWebAuthnCredential(userID, name string) *model.WebAuthnCredential {
+	return &model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         name,
+		CredentialId: model.EncodeWebAuthnBase64([]byte(model.NewId())),
+		PublicKey:    []byte{0xa5, 0x01, 0x02, 0x03, 0x26},
+	}
+}
+
+func testSaveAndGetWebAuthnCredentials(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.WebAuthnCredential().PermanentDeleteByUser(userID)
+
+	first, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID, "first"))
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Id)
+
+	second := newTestWebAuthnCredential(userID, "second")
+	second.CreateAt = first.CreateAt + 1
+	second, err = ss.WebAuthnCredential().Save(second)
+	require.NoError(t, err)
+
+	_, err = ss.WebAuthnCredential().Save(newTestWebAuthnCredential(model.NewId(), ""))
+	require.Error(t, err, "should fail to save an invalid credential")
+
+	credential, err := ss.WebAuthnCredential().Get(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, first, credential)
+
+	_, err = ss.WebAuthnCredential().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 2)
+	assert.Equal(t, first.Id, credentials[0].Id)
+	assert.Equal(t, second.Id, credentials[1].Id)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func testUpdateWebAuthnCredentialLastUsed(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	defer ss.WebAuthnCredential().PermanentDeleteByUser(userID)
+
+	credential, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID, "key"))
+	require.NoError(t, err)
+
+	err = ss.WebAuthnCredential().UpdateLastUsed(credential.Id, 42, 1000)
+	require.NoError(t, err)
+
+	credential, err = ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), credential.SignCount)
+	assert.Equal(t, int64(1000), credential.LastUsedAt)
+}
+
+func testDeleteWebAuthnCredentials(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	defer ss.WebAuthnCredential().PermanentDeleteByUser(otherUserID)
+
+	first, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID, "first"))
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID, "second"))
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newTestWebAuthnCredential(otherUserID, "other"))
+	require.NoError(t, err)
+
+	err = ss.WebAuthnCredential().Delete(first.Id)
+	require.NoError(t, err)
+
+	err = ss.WebAuthnCredential().Delete(first.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+
+	err = ss.WebAuthnCredential().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, credentials, 1)
+}
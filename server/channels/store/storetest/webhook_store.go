@@ -17,6 +17,7 @@ import (
 
 func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("SaveIncoming", func(t *testing.T) { testWebhookStoreSaveIncoming(t, rctx, ss) })
+	t.Run("ImportIncoming", func(t *testing.T) { testWebhookStoreImportIncoming(t, rctx, ss) })
 	t.Run("UpdateIncoming", func(t *testing.T) { testWebhookStoreUpdateIncoming(t, rctx, ss) })
 	t.Run("GetIncoming", func(t *testing.T) { testWebhookStoreGetIncoming(t, rctx, ss) })
 	t.Run("GetIncomingList", func(t *testing.T) { testWebhookStoreGetIncomingList(t, rctx, ss) })
@@ -24,6 +25,7 @@ func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("GetIncomingByTeam", func(t *testing.T) { testWebhookStoreGetIncomingByTeam(t, rctx, ss) })
 	t.Run("GetIncomingByTeamByUser", func(t *testing.T) { TestWebhookStoreGetIncomingByTeamByUser(t, rctx, ss) })
 	t.Run("GetIncomingByTeamByChannel", func(t *testing.T) { testWebhookStoreGetIncomingByChannel(t, rctx, ss) })
+	t.Run("GetIncomingDeletedSince", func(t *testing.T) { testWebhookStoreGetIncomingDeletedSince(t, rctx, ss) })
 	t.Run("DeleteIncoming", func(t *testing.T) { testWebhookStoreDeleteIncoming(t, rctx, ss) })
 	t.Run("DeleteIncomingByChannel", func(t *testing.T) { testWebhookStoreDeleteIncomingByChannel(t, rctx, ss) })
 	t.Run("DeleteIncomingByUser", func(t *testing.T) { testWebhookStoreDeleteIncomingByUser(t, rctx, ss) })
@@ -35,12 +37,17 @@ func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("GetOutgoingByChannelByUser", func(t *testing.T) { testWebhookStoreGetOutgoingByChannelByUser(t, rctx, ss) })
 	t.Run("GetOutgoingByTeam", func(t *testing.T) { testWebhookStoreGetOutgoingByTeam(t, rctx, ss) })
 	t.Run("GetOutgoingByTeamByUser", func(t *testing.T) { testWebhookStoreGetOutgoingByTeamByUser(t, rctx, ss) })
+	t.Run("GetOutgoingDeletedSince", func(t *testing.T) { testWebhookStoreGetOutgoingDeletedSince(t, rctx, ss) })
 	t.Run("DeleteOutgoing", func(t *testing.T) { testWebhookStoreDeleteOutgoing(t, rctx, ss) })
 	t.Run("DeleteOutgoingByChannel", func(t *testing.T) { testWebhookStoreDeleteOutgoingByChannel(t, rctx, ss) })
 	t.Run("DeleteOutgoingByUser", func(t *testing.T) { testWebhookStoreDeleteOutgoingByUser(t, rctx, ss) })
 	t.Run("UpdateOutgoing", func(t *testing.T) { testWebhookStoreUpdateOutgoing(t, rctx, ss) })
 	t.Run("CountIncoming", func(t *testing.T) { testWebhookStoreCountIncoming(t, rctx, ss) })
 	t.Run("CountOutgoing", func(t *testing.T) { testWebhookStoreCountOutgoing(t, rctx, ss) })
+	t.Run("SaveOutgoingDelivery", func(t *testing.T) { testWebhookStoreSaveOutgoingDelivery(t, rctx, ss) })
+	t.Run("GetOutgoingDeliveriesByHook", func(t *testing.T) { testWebhookStoreGetOutgoingDeliveriesByHook(t, rctx, ss) })
+	t.Run("GetPendingOutgoingDeliveries", func(t *testing.T) { testWebhookStoreGetPendingOutgoingDeliveries(t, rctx, ss) })
+	t.Run("PermanentDeleteOutgoingDeliveriesBefore", func(t *testing.T) { testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t, rctx, ss) })
 }
 
 func testWebhookStoreSaveIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -53,6 +60,28 @@ func testWebhookStoreSaveIncoming(t *testing.T, rctx request.CTX, ss store.Store
 	require.Error(t, err, "shouldn't be able to update from save")
 }
 
+func testWebhookStoreImportIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
+	o1 := buildIncomingWebhook()
+	o1.Id = model.NewId()
+	id := o1.Id
+
+	_, err := ss.Webhook().ImportIncoming(o1)
+	require.NoError(t, err)
+
+	webhook, err := ss.Webhook().GetIncoming(id, false)
+	require.NoError(t, err)
+	require.Equal(t, id, webhook.Id)
+
+	o2 := buildIncomingWebhook()
+	o2.Id = id
+	_, err = ss.Webhook().ImportIncoming(o2)
+	var cErr *store.ErrConflict
+	require.True(t, errors.As(err, &cErr), "should conflict with the existing webhook")
+
+	_, err = ss.Webhook().ImportIncoming(buildIncomingWebhook())
+	require.Error(t, err, "should require an id")
+}
+
 func testWebhookStoreUpdateIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
 	var err error
 
@@ -165,6 +194,28 @@ func testWebhookStoreGetIncomingByTeam(t *testing.T, rctx request.CTX, ss store.
 	require.Empty(t, hooks, "no webhooks should have returned")
 }
 
+func testWebhookStoreGetIncomingDeletedSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	o1, err := ss.Webhook().SaveIncoming(buildIncomingWebhook())
+	require.NoError(t, err)
+	o2 := buildIncomingWebhook()
+	o2.TeamId = o1.TeamId
+	o2, err = ss.Webhook().SaveIncoming(o2)
+	require.NoError(t, err)
+	o3 := buildIncomingWebhook()
+	o3.TeamId = o1.TeamId
+	_, err = ss.Webhook().SaveIncoming(o3)
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Webhook().DeleteIncoming(o1.Id, 1000))
+	require.NoError(t, ss.Webhook().DeleteIncoming(o2.Id, 2000))
+
+	hooks, err := ss.Webhook().GetIncomingDeletedSince(o1.TeamId, 1500)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	require.Equal(t, o2.Id, hooks[0].Id)
+	require.Equal(t, int64(2000), hooks[0].DeleteAt)
+}
+
 func TestWebhookStoreGetIncomingByTeamByUser(t *testing.T, rctx request.CTX, ss store.Store) {
 	var err error
 
@@ -450,6 +501,30 @@ func testWebhookStoreGetOutgoingByTeam(t *testing.T, rctx request.CTX, ss store.
 	require.Empty(t, result, "no webhooks should have returned")
 }
 
+func testWebhookStoreGetOutgoingDeletedSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+	hooks := make([]*model.OutgoingWebhook, 3)
+	for i := range hooks {
+		hook, err := ss.Webhook().SaveOutgoing(&model.OutgoingWebhook{
+			ChannelId:    model.NewId(),
+			CreatorId:    model.NewId(),
+			TeamId:       teamID,
+			CallbackURLs: []string{"http://nowhere.com/"},
+		})
+		require.NoError(t, err)
+		hooks[i] = hook
+	}
+
+	require.NoError(t, ss.Webhook().DeleteOutgoing(hooks[0].Id, 1000))
+	require.NoError(t, ss.Webhook().DeleteOutgoing(hooks[1].Id, 2000))
+
+	deleted, err := ss.Webhook().GetOutgoingDeletedSince(teamID, 1500)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	require.Equal(t, hooks[1].Id, deleted[0].Id)
+	require.Equal(t, int64(2000), deleted[0].DeleteAt)
+}
+
 func testWebhookStoreGetOutgoingByTeamByUser(t *testing.T, rctx request.CTX, ss store.Store) {
 	var err error
 
@@ -597,3 +672,145 @@ func testWebhookStoreCountOutgoing(t *testing.T, rctx request.CTX, ss store.Stor
 	require.NoError(t, err)
 	require.NotEqual(t, 0, r, "should have at least 1 outgoing hook")
 }
+
+func buildOutgoingWebhookDelivery(hookID string) *model.OutgoingWebhookDelivery {
+	return &model.OutgoingWebhookDelivery{
+		HookId:      hookID,
+		PostId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		CallbackURL: "http://nowhere.com/",
+		ContentType: "application/json",
+		Payload:     `{"text":"payload"}`,
+	}
+}
+
+func testWebhookStoreSaveOutgoingDelivery(t *testing.T, rctx request.CTX, ss store.Store) {
+	d1 := buildOutgoingWebhookDelivery(model.NewId())
+
+	d1, err := ss.Webhook().SaveOutgoingDelivery(d1)
+	require.NoError(t, err, "couldn't save item")
+	require.Equal(t, model.OutgoingWebhookDeliveryStatusPending, d1.Status)
+
+	_, err = ss.Webhook().SaveOutgoingDelivery(d1)
+	require.Error(t, err, "shouldn't be able to update from save")
+
+	d1.Status = model.OutgoingWebhookDeliveryStatusFailed
+	d1.Attempts = 2
+	d1.StatusCode = 503
+	d1.Latency = 120
+	d1.LastError = "unavailable"
+	_, err = ss.Webhook().UpdateOutgoingDelivery(d1)
+	require.NoError(t, err)
+
+	d2, err := ss.Webhook().GetOutgoingDelivery(d1.Id)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2)
+
+	_, err = ss.Webhook().GetOutgoingDelivery(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testWebhookStoreGetOutgoingDeliveriesByHook(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		d, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+		require.NoError(t, err)
+		ids = append([]string{d.Id}, ids...)
+		time.Sleep(2 * time.Millisecond)
+	}
+	_, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(model.NewId()))
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	for i, d := range deliveries {
+		require.Equal(t, ids[i], d.Id, "deliveries should be sorted from the most recent")
+	}
+
+	deliveries, err = ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, ids[2], deliveries[0].Id)
+}
+
+func testWebhookStoreGetPendingOutgoingDeliveries(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+
+	due := buildOutgoingWebhookDelivery(model.NewId())
+	due.NextAttemptAt = now - 1000
+	due, err := ss.Webhook().SaveOutgoingDelivery(due)
+	require.NoError(t, err)
+
+	later := buildOutgoingWebhookDelivery(model.NewId())
+	later.NextAttemptAt = now + 60000
+	_, err = ss.Webhook().SaveOutgoingDelivery(later)
+	require.NoError(t, err)
+
+	done := buildOutgoingWebhookDelivery(model.NewId())
+	done.NextAttemptAt = now - 1000
+	done.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	_, err = ss.Webhook().SaveOutgoingDelivery(done)
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetPendingOutgoingDeliveries(now, 1000)
+	require.NoError(t, err)
+	var found []string
+	for _, d := range deliveries {
+		found = append(found, d.Id)
+	}
+	require.Contains(t, found, due.Id)
+	require.NotContains(t, found, later.Id)
+	require.NotContains(t, found, done.Id)
+
+	// Only one claim of the same attempt succeeds.
+	claimed, err := ss.Webhook().ClaimOutgoingDelivery(due.Id, due.NextAttemptAt, now+30000)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = ss.Webhook().ClaimOutgoingDelivery(due.Id, due.NextAttemptAt, now+30000)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	deliveries, err = ss.Webhook().GetPendingOutgoingDeliveries(now, 1000)
+	require.NoError(t, err)
+	for _, d := range deliveries {
+		require.NotEqual(t, due.Id, d.Id)
+	}
+}
+
+func testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+
+	old, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	old.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	_, err = ss.Webhook().UpdateOutgoingDelivery(old)
+	require.NoError(t, err)
+
+	pending, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+	cutoff := model.GetMillis()
+	time.Sleep(2 * time.Millisecond)
+
+	recent, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	recent.Status = model.OutgoingWebhookDeliveryStatusFailed
+	_, err = ss.Webhook().UpdateOutgoingDelivery(recent)
+	require.NoError(t, err)
+
+	deleted, err := ss.Webhook().PermanentDeleteOutgoingDeliveriesBefore(cutoff, 1000)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, recent.Id, deliveries[0].Id)
+	require.Equal(t, pending.Id, deliveries[1].Id, "pending deliveries should be kept")
+}
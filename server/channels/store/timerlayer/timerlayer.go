@@ -20,6 +20,8 @@ type TimerLayer struct {
 	store.Store
 	Metrics                         einterfaces.MetricsInterface
 	AuditStore                      store.AuditStore
+	AuditRecordStore                store.AuditRecordStore
+	BatchedNotificationStore        store.BatchedNotificationStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
@@ -36,6 +38,8 @@ type TimerLayer struct {
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
+	MfaResetRequestStore            store.MfaResetRequestStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
@@ -50,6 +54,7 @@ type TimerLayer struct {
 	RemoteClusterStore              store.RemoteClusterStore
 	RetentionPolicyStore            store.RetentionPolicyStore
 	RoleStore                       store.RoleStore
+	ScheduledPostStore              store.ScheduledPostStore
 	SchemeStore                     store.SchemeStore
 	SessionStore                    store.SessionStore
 	SharedChannelStore              store.SharedChannelStore
@@ -63,6 +68,7 @@ type TimerLayer struct {
 	UserStore                       store.UserStore
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -70,6 +76,14 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditRecord() store.AuditRecordStore {
+	return s.AuditRecordStore
+}
+
+func (s *TimerLayer) BatchedNotification() store.BatchedNotificationStore {
+	return s.BatchedNotificationStore
+}
+
 func (s *TimerLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -134,6 +148,14 @@ func (s *TimerLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
+func (s *TimerLayer) MfaResetRequest() store.MfaResetRequestStore {
+	return s.MfaResetRequestStore
+}
+
 func (s *TimerLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -190,6 +212,10 @@ func (s *TimerLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
 func (s *TimerLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -242,6 +268,10 @@ func (s *TimerLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
 
+func (s *TimerLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -251,6 +281,16 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditRecordStore struct {
+	store.AuditRecordStore
+	Root *TimerLayer
+}
+
+type TimerLayerBatchedNotificationStore struct {
+	store.BatchedNotificationStore
+	Root *TimerLayer
+}
+
 type TimerLayerBotStore struct {
 	store.BotStore
 	Root *TimerLayer
@@ -331,6 +371,16 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *TimerLayer
+}
+
+type TimerLayerMfaResetRequestStore struct {
+	store.MfaResetRequestStore
+	Root *TimerLayer
+}
+
 type TimerLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *TimerLayer
@@ -401,6 +451,11 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	store.SchemeStore
 	Root *TimerLayer
@@ -466,6 +521,11 @@ type TimerLayerUserTermsOfServiceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *TimerLayer
+}
+
 type TimerLayerWebhookStore struct {
 	store.WebhookStore
 	Root *TimerLayer
@@ -519,6 +579,150 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 	return err
 }
 
+func (s *TimerLayerAuditRecordStore) GetAll(filter *model.AuditRecordFilter) ([]*model.AuditRecord, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.GetAll(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) GetCheckpoints(chainID string, offset int, limit int) ([]*model.AuditCheckpoint, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.GetCheckpoints(chainID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.GetCheckpoints", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) Save(record *model.AuditRecord) (*model.AuditRecord, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.Save(record)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) SaveCheckpoint(checkpoint *model.AuditCheckpoint) (*model.AuditCheckpoint, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.SaveCheckpoint(checkpoint)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.SaveCheckpoint", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerBatchedNotificationStore) Delete(ids []string) error {
+	start := time.Now()
+
+	err := s.BatchedNotificationStore.Delete(ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BatchedNotificationStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerBatchedNotificationStore) GetForUser(userID string) ([]*model.BatchedNotification, error) {
+	start := time.Now()
+
+	result, err := s.BatchedNotificationStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BatchedNotificationStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerBatchedNotificationStore) GetUsersWithPending(afterUserID string, limit int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.BatchedNotificationStore.GetUsersWithPending(afterUserID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BatchedNotificationStore.GetUsersWithPending", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerBatchedNotificationStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.BatchedNotificationStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BatchedNotificationStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerBatchedNotificationStore) Save(notification *model.BatchedNotification) (*model.BatchedNotification, error) {
+	start := time.Now()
+
+	result, err := s.BatchedNotificationStore.Save(notification)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BatchedNotificationStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
 	start := time.Now()
 
@@ -1141,6 +1345,22 @@ func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelID string) ([
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelMembershipReport(filter *model.ChannelMembershipReportOptions) ([]*model.ChannelMembershipReport, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetChannelMembershipReport(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembershipReport", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
 	start := time.Now()
 
@@ -2622,6 +2842,38 @@ func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string
 	return result, err
 }
 
+func (s *TimerLayerChannelMemberHistoryStore) GetMembersLeftSince(teamID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetMembersLeftSince(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetMembersLeftSince", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) GetMembershipChangesSince(channelID string, since int64) ([]*model.ChannelMemberHistoryResult, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetMembershipChangesSince(channelID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetMembershipChangesSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
 	start := time.Now()
 
@@ -2878,6 +3130,22 @@ func (s *TimerLayerCommandStore) GetByTrigger(teamID string, trigger string) (*m
 	return result, err
 }
 
+func (s *TimerLayerCommandStore) GetDeletedByTeamSince(teamID string, since int64) ([]*model.Command, error) {
+	start := time.Now()
+
+	result, err := s.CommandStore.GetDeletedByTeamSince(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetDeletedByTeamSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
 	start := time.Now()
 
@@ -3532,6 +3800,22 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) GetChannelFilesBatchForIndexing(channelID string, startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.GetChannelFilesBatchForIndexing(channelID, startTime, startFileID, includeDeleted, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetChannelFilesBatchForIndexing", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 	start := time.Now()
 
@@ -4683,6 +4967,22 @@ func (s *TimerLayerJobStore) Get(c request.CTX, id string) (*model.Job, error) {
 	return result, err
 }
 
+func (s *TimerLayerJobStore) GetAllByRootId(c request.CTX, rootID string) ([]*model.Job, error) {
+	start := time.Now()
+
+	result, err := s.JobStore.GetAllByRootId(c, rootID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByRootId", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerJobStore) GetAllByStatus(c request.CTX, status string) ([]*model.Job, error) {
 	start := time.Now()
 
@@ -4987,10 +5287,10 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 	return result, err
 }
 
-func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
+func (s *TimerLayerMfaRecoveryCodeStore) GetForUser(userID string) ([]*model.MfaRecoveryCode, error) {
 	start := time.Now()
 
-	err := s.NotifyAdminStore.DeleteBefore(trial, now)
+	result, err := s.MfaRecoveryCodeStore.GetForUser(userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -4998,15 +5298,15 @@ func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.DeleteBefore", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.GetForUser", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
+func (s *TimerLayerMfaRecoveryCodeStore) MarkUsed(id string, usedAt int64) error {
 	start := time.Now()
 
-	result, err := s.NotifyAdminStore.Get(trial)
+	err := s.MfaRecoveryCodeStore.MarkUsed(id, usedAt)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5014,15 +5314,15 @@ func (s *TimerLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.MarkUsed", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerNotifyAdminStore) GetDataByUserIdAndFeature(userId string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
+func (s *TimerLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
 	start := time.Now()
 
-	result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userId, feature)
+	err := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5030,15 +5330,15 @@ func (s *TimerLayerNotifyAdminStore) GetDataByUserIdAndFeature(userId string, fe
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.GetDataByUserIdAndFeature", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
+func (s *TimerLayerMfaRecoveryCodeStore) SaveForUser(userID string, codes []*model.MfaRecoveryCode) error {
 	start := time.Now()
 
-	result, err := s.NotifyAdminStore.Save(data)
+	err := s.MfaRecoveryCodeStore.SaveForUser(userID, codes)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5046,15 +5346,15 @@ func (s *TimerLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.N
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.SaveForUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerNotifyAdminStore) Update(userId string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
+func (s *TimerLayerMfaResetRequestStore) Get(id string) (*model.MfaResetRequest, error) {
 	start := time.Now()
 
-	err := s.NotifyAdminStore.Update(userId, requiredPlan, requiredFeature, now)
+	result, err := s.MfaResetRequestStore.Get(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5062,15 +5362,15 @@ func (s *TimerLayerNotifyAdminStore) Update(userId string, requiredPlan string,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
+func (s *TimerLayerMfaResetRequestStore) GetAll(status string, offset int, limit int) ([]*model.MfaResetRequest, error) {
 	start := time.Now()
 
-	err := s.OAuthStore.DeleteApp(id)
+	result, err := s.MfaResetRequestStore.GetAll(status, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5078,12 +5378,172 @@ func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.GetAll", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+func (s *TimerLayerMfaResetRequestStore) GetPendingForUser(userID string) (*model.MfaResetRequest, error) {
+	start := time.Now()
+
+	result, err := s.MfaResetRequestStore.GetPendingForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.GetPendingForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMfaResetRequestStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.MfaResetRequestStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaResetRequestStore) Resolve(id string, status string, resolvedBy string, updateAt int64) error {
+	start := time.Now()
+
+	err := s.MfaResetRequestStore.Resolve(id, status, resolvedBy, updateAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.Resolve", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaResetRequestStore) Save(request *model.MfaResetRequest) (*model.MfaResetRequest, error) {
+	start := time.Now()
+
+	result, err := s.MfaResetRequestStore.Save(request)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaResetRequestStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
+	start := time.Now()
+
+	err := s.NotifyAdminStore.DeleteBefore(trial, now)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.DeleteBefore", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
+	start := time.Now()
+
+	result, err := s.NotifyAdminStore.Get(trial)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotifyAdminStore) GetDataByUserIdAndFeature(userId string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
+	start := time.Now()
+
+	result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userId, feature)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.GetDataByUserIdAndFeature", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
+	start := time.Now()
+
+	result, err := s.NotifyAdminStore.Save(data)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotifyAdminStore) Update(userId string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
+	start := time.Now()
+
+	err := s.NotifyAdminStore.Update(userId, requiredPlan, requiredFeature, now)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotifyAdminStore.Update", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
+	start := time.Now()
+
+	err := s.OAuthStore.DeleteApp(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 	start := time.Now()
 
 	result, err := s.OAuthStore.GetAccessData(token)
@@ -5722,10 +6182,42 @@ func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *TimerLayerPostStore) GetChannelPostsBatchForIndexing(channelID string, startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetChannelPostsBatchForIndexing(channelID, startTime, startPostID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetChannelPostsBatchForIndexing", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.DeletedPostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDeletedForExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, since, includeArchivedChannels)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5882,10 +6374,10 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, since int64, includeArchivedChannels bool) ([]*model.PostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, since, includeArchivedChannels)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7641,6 +8133,118 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 	return result, err
 }
 
+func (s *TimerLayerScheduledPostStore) Get(id string) (*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledPostStore) GetForUser(userID string, teamID string) ([]*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.GetForUser(userID, teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64, afterTime int64, afterID string, limit int) ([]*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.GetPendingScheduledPosts(beforeTime, afterTime, afterID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.GetPendingScheduledPosts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledPostStore) PermanentDelete(id string) error {
+	start := time.Now()
+
+	err := s.ScheduledPostStore.PermanentDelete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.PermanentDelete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerScheduledPostStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.ScheduledPostStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.Save(scheduledPost)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledPostStore) Update(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.Update(scheduledPost)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 	start := time.Now()
 
@@ -8396,7 +9000,23 @@ func (s *TimerLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syn
 func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 	start := time.Now()
 
-	result, err := s.SharedChannelStore.UpdateRemote(remote)
+	result, err := s.SharedChannelStore.UpdateRemote(remote)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -8404,15 +9024,15 @@ func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteCursor", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+func (s *TimerLayerSharedChannelStore) UpdateRemoteLastChannelSyncAt(id string, syncTime int64) error {
 	start := time.Now()
 
-	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+	err := s.SharedChannelStore.UpdateRemoteLastChannelSyncAt(id, syncTime)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -8420,7 +9040,7 @@ func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor mode
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteCursor", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteLastChannelSyncAt", success, elapsed)
 	}
 	return err
 }
@@ -9048,6 +9668,38 @@ func (s *TimerLayerTeamStore) GetMembersByIds(teamID string, userIds []string, r
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) GetMembersLeftSince(teamID string, since int64) ([]*model.TeamMember, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetMembersLeftSince(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersLeftSince", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerTeamStore) GetTeamActivityReport(filter *model.TeamActivityReportOptions) ([]*model.TeamActivityReport, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetTeamActivityReport(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamActivityReport", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	start := time.Now()
 
@@ -10565,6 +11217,22 @@ func (s *TimerLayerUserStore) GetForLogin(loginID string, allowSignInWithUsernam
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetIdsWithMembershipsUpdatedSince(since int64, includeArchivedChannels bool) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetIdsWithMembershipsUpdatedSince(since, includeArchivedChannels)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetIdsWithMembershipsUpdatedSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 	start := time.Now()
 
@@ -11602,6 +12270,102 @@ func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 	return result, err
 }
 
+func (s *TimerLayerWebAuthnCredentialStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Save(credential)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.UpdateLastUsed(id, signCount, lastUsedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.UpdateLastUsed", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamID string) (int64, error) {
 	start := time.Now()
 
@@ -11634,6 +12398,22 @@ func (s *TimerLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, e
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) ClaimOutgoingDelivery(id string, nextAttemptAt int64, until int64) (bool, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.ClaimOutgoingDelivery(id, nextAttemptAt, until)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.ClaimOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) ClearCaches() {
 	start := time.Now()
 
@@ -11745,6 +12525,22 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID s
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) GetIncomingDeletedSince(teamID string, since int64) ([]*model.IncomingWebhook, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetIncomingDeletedSince(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingDeletedSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -11857,6 +12653,54 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) GetOutgoingDeletedSince(teamID string, since int64) ([]*model.OutgoingWebhook, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeletedSince(teamID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeletedSince", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeliveriesByHook(hookID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeliveriesByHook", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDelivery(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	start := time.Now()
 
@@ -11889,6 +12733,38 @@ func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userID string, offset int
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) GetPendingOutgoingDeliveries(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetPendingOutgoingDeliveries(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetPendingOutgoingDeliveries", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.ImportIncoming(webhook)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.ImportIncoming", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 	start := time.Now()
 
@@ -11968,6 +12844,22 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 	return err
 }
 
+func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingDeliveriesBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -12000,6 +12892,22 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -12032,6 +12940,22 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayer) Close() {
 	s.Store.Close()
 }
@@ -12075,6 +12999,8 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	}
 
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditRecordStore = &TimerLayerAuditRecordStore{AuditRecordStore: childStore.AuditRecord(), Root: &newStore}
+	newStore.BatchedNotificationStore = &TimerLayerBatchedNotificationStore{BatchedNotificationStore: childStore.BatchedNotification(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
@@ -12091,6 +13017,8 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &TimerLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
+	newStore.MfaResetRequestStore = &TimerLayerMfaResetRequestStore{MfaResetRequestStore: childStore.MfaResetRequest(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
@@ -12105,6 +13033,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.RemoteClusterStore = &TimerLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &TimerLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledPostStore = &TimerLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &TimerLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
@@ -12118,6 +13047,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &TimerLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
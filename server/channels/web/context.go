@@ -186,7 +186,18 @@ func (c *Context) MfaRequired() {
 		return
 	}
 
-	if !user.MfaActive {
+	if user.MfaActive {
+		return
+	}
+
+	// Security keys satisfy the requirement as well
+	hasCredentials, appErr := c.App.HasWebAuthnCredentials(user.Id)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !hasCredentials {
 		c.Err = model.NewAppError("MfaRequired", "api.context.mfa_required.app_error", nil, "", http.StatusForbidden)
 		return
 	}
@@ -632,6 +643,50 @@ func (c *Context) RequireHookId() *Context {
 	return c
 }
 
+func (c *Context) RequireDeliveryId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.DeliveryId) {
+		c.SetInvalidURLParam("delivery_id")
+	}
+	return c
+}
+
+func (c *Context) RequireScheduledPostId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ScheduledPostId) {
+		c.SetInvalidURLParam("scheduled_post_id")
+	}
+	return c
+}
+
+func (c *Context) RequireWebAuthnCredentialId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.WebAuthnCredentialId) {
+		c.SetInvalidURLParam("credential_id")
+	}
+	return c
+}
+
+func (c *Context) RequireMfaResetRequestId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.MfaResetRequestId) {
+		c.SetInvalidURLParam("mfa_reset_request_id")
+	}
+	return c
+}
+
 func (c *Context) RequireCommandId() *Context {
 	if c.Err != nil {
 		return c
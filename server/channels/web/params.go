@@ -41,6 +41,10 @@ type Params struct {
 	PluginId                  string
 	CommandId                 string
 	HookId                    string
+	DeliveryId                string
+	ScheduledPostId           string
+	WebAuthnCredentialId      string
+	MfaResetRequestId         string
 	ReportId                  string
 	EmojiId                   string
 	AppId                     string
@@ -88,6 +92,7 @@ type Params struct {
 	FilterParentTeamPermitted bool
 	CategoryId                string
 	ExportName                string
+	IndexName                 string
 	ExcludePolicyConstrained  bool
 	GroupSource               model.GroupSource
 	FilterHasMember           string
@@ -129,6 +134,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.PluginId = props["plugin_id"]
 	params.CommandId = props["command_id"]
 	params.HookId = props["hook_id"]
+	params.DeliveryId = props["delivery_id"]
+	params.ScheduledPostId = props["scheduled_post_id"]
+	params.WebAuthnCredentialId = props["credential_id"]
+	params.MfaResetRequestId = props["mfa_reset_request_id"]
 	params.ReportId = props["report_id"]
 	params.EmojiId = props["emoji_id"]
 	params.AppId = props["app_id"]
@@ -232,6 +241,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.IncludeTotalCount, _ = strconv.ParseBool(query.Get("include_total_count"))
 	params.IncludeDeleted, _ = strconv.ParseBool(query.Get("include_deleted"))
 	params.ExportName = props["export_name"]
+	params.IndexName = props["index_name"]
 	params.ExcludePolicyConstrained, _ = strconv.ParseBool(query.Get("exclude_policy_constrained"))
 
 	if val := query.Get("group_source"); val != "" {
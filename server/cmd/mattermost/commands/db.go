@@ -329,6 +329,19 @@ func ConfigToFileBackendSettings(s *model.FileSettings, enableComplianceFeature
 			Directory:  *s.Directory,
 		}
 	}
+	if *s.DriverName == model.ImageDriverAzure {
+		return filestore.FileBackendSettings{
+			DriverName:                      *s.DriverName,
+			AzureStorageAccount:             *s.AzureStorageAccount,
+			AzureAccessKey:                  *s.AzureAccessKey,
+			AzureContainer:                  *s.AzureContainer,
+			AzurePathPrefix:                 *s.AzurePathPrefix,
+			AzureEndpoint:                   *s.AzureEndpoint,
+			AzureRequestTimeoutMilliseconds: *s.AzureRequestTimeoutMilliseconds,
+			AzurePresignExpiresSeconds:      *s.AzurePresignExpiresSeconds,
+			SkipVerify:                      skipVerify,
+		}
+	}
 	return filestore.FileBackendSettings{
 		DriverName:                         *s.DriverName,
 		AmazonS3AccessKeyId:                *s.AmazonS3AccessKeyId,
@@ -52,6 +52,9 @@ type Client interface {
 	UploadLicenseFile(ctx context.Context, data []byte) (*model.Response, error)
 	RemoveLicenseFile(ctx context.Context) (*model.Response, error)
 	GetLogs(ctx context.Context, page, perPage int) ([]string, *model.Response, error)
+	GetAuditRecords(ctx context.Context, filter *model.AuditRecordFilter) ([]*model.AuditRecord, *model.Response, error)
+	GetAuditCheckpoints(ctx context.Context, chainID string, page, perPage int) ([]*model.AuditCheckpoint, *model.Response, error)
+	GetAuditSigningKey(ctx context.Context) (*model.AuditSigningKey, *model.Response, error)
 	GetRoleByName(ctx context.Context, name string) (*model.Role, *model.Response, error)
 	PatchRole(ctx context.Context, roleID string, patch *model.RolePatch) (*model.Role, *model.Response, error)
 	UploadPlugin(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
@@ -128,11 +131,21 @@ type Client interface {
 	UploadData(ctx context.Context, uploadID string, data io.Reader) (*model.FileInfo, *model.Response, error)
 	ListImports(ctx context.Context) ([]string, *model.Response, error)
 	GetJob(ctx context.Context, id string) (*model.Job, *model.Response, error)
+	DownloadJob(ctx context.Context, jobId string) ([]byte, *model.Response, error)
 	GetJobs(ctx context.Context, jobType string, status string, page int, perPage int) ([]*model.Job, *model.Response, error)
 	GetJobsByType(ctx context.Context, jobType string, page int, perPage int) ([]*model.Job, *model.Response, error)
 	CreateJob(ctx context.Context, job *model.Job) (*model.Job, *model.Response, error)
 	CancelJob(ctx context.Context, jobID string) (*model.Response, error)
 	UpdateJobStatus(ctx context.Context, jobId string, status string, force bool) (*model.Response, error)
+	GetJobLineage(ctx context.Context, jobId string) ([]*model.Job, *model.Response, error)
+	GetJobSchedules(ctx context.Context) ([]*model.JobSchedule, *model.Response, error)
+	GetBleveIndexStats(ctx context.Context) ([]*model.BleveIndexStats, *model.Response, error)
+	VerifyBleveIndex(ctx context.Context, indexName string) (*model.BleveIndexStats, *model.Response, error)
+	CreateBleveReindexJob(ctx context.Context, req *model.BleveReindexRequest) (*model.Job, *model.Response, error)
+	PurgeBleveIndexes(ctx context.Context) (*model.Response, error)
+	PurgeBleveIndexList(ctx context.Context, indexes []string) (*model.Response, error)
+	PauseJobType(ctx context.Context, jobType string) (*model.Response, error)
+	ResumeJobType(ctx context.Context, jobType string) (*model.Response, error)
 	CreateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error)
 	UpdateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error)
 	GetIncomingWebhooks(ctx context.Context, page int, perPage int, etag string) ([]*model.IncomingWebhook, *model.Response, error)
@@ -147,6 +160,8 @@ type Client interface {
 	GetOutgoingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	RegenOutgoingHookToken(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
 	DeleteOutgoingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	GetOutgoingWebhookDeliveries(ctx context.Context, hookID string, page int, perPage int) ([]*model.OutgoingWebhookDelivery, *model.Response, error)
+	ReplayOutgoingWebhookDelivery(ctx context.Context, hookID, deliveryID string) (*model.OutgoingWebhookDelivery, *model.Response, error)
 	ListExports(ctx context.Context) ([]string, *model.Response, error)
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
@@ -159,4 +174,7 @@ type Client interface {
 	GetPreferenceByCategoryAndName(ctx context.Context, userId, category, preferenceName string) (*model.Preference, *model.Response, error)
 	UpdatePreferences(ctx context.Context, userId string, preferences model.Preferences) (*model.Response, error)
 	DeletePreferences(ctx context.Context, userId string, preferences model.Preferences) (*model.Response, error)
+	StartUsersBatchExport(ctx context.Context, format string, dateRange string) (*model.Response, error)
+	StartChannelMembershipReport(ctx context.Context, format string, teamId string) (*model.Response, error)
+	StartTeamActivityReport(ctx context.Context, format string, dateRange string) (*model.Response, error)
 }
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of the audit records",
+	Long:  "Management of the hash chained audit records the server writes to the database when ExperimentalAuditSettings.DatabaseEnabled is set.",
+}
+
+var AuditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audit records",
+	Example: `  audit list
+  audit list --user-id userID --event updateUserActive
+  audit list --since 2024-01-01T00:00:00+00:00 --until 2024-02-01T00:00:00+00:00`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditListCmdF),
+}
+
+var AuditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the hash chains of the audit records",
+	Long: `Verify that no audit record was altered, removed or inserted: the hash of each record and of the record preceding it are checked, as well as the signatures of the checkpoints of each chain.
+
+The checkpoints are checked against the public key given with --public-key-file, as a PEM file. It must be a copy kept by the operator of the public key matching ExperimentalAuditSettings.CheckpointSigningKeyFile, rather than one fetched from the server being verified.`,
+	Example: `  audit verify --public-key-file audit_signing_key.pem
+  audit verify --public-key-file audit_signing_key.pem --chain chainID`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditVerifyCmdF),
+}
+
+func init() {
+	AuditListCmd.Flags().String("user-id", "", "Filter by the ID of the user who triggered the event")
+	AuditListCmd.Flags().String("event", "", "Filter by event name")
+	AuditListCmd.Flags().String("since", "", "List the records created from a certain time (ISO 8601)")
+	AuditListCmd.Flags().String("until", "", "List the records created until a certain time (ISO 8601)")
+	AuditListCmd.Flags().String("chain", "", "Filter by hash chain ID")
+	AuditListCmd.Flags().Int("page", 0, "Page number to fetch")
+	AuditListCmd.Flags().Int("per-page", DefaultPageSize, "Number of records to fetch")
+
+	AuditVerifyCmd.Flags().String("chain", "", "Only verify the hash chain of the given ID")
+	AuditVerifyCmd.Flags().String("public-key-file", "", "PEM file of the public key to check the signatures of the checkpoints against")
+	_ = AuditVerifyCmd.MarkFlagRequired("public-key-file")
+
+	AuditCmd.AddCommand(
+		AuditListCmd,
+		AuditVerifyCmd,
+	)
+
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func parseAuditTimeFlag(cmd *cobra.Command, name string) (int64, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse(ISO8601Layout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s time '%s'", name, value)
+	}
+	return model.GetMillisForTime(t), nil
+}
+
+func auditListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	filter := &model.AuditRecordFilter{}
+	filter.UserId, _ = cmd.Flags().GetString("user-id")
+	filter.EventName, _ = cmd.Flags().GetString("event")
+	filter.ChainId, _ = cmd.Flags().GetString("chain")
+	filter.Page, _ = cmd.Flags().GetInt("page")
+	filter.PerPage, _ = cmd.Flags().GetInt("per-page")
+
+	var err error
+	if filter.Since, err = parseAuditTimeFlag(cmd, "since"); err != nil {
+		return err
+	}
+	if filter.Until, err = parseAuditTimeFlag(cmd, "until"); err != nil {
+		return err
+	}
+
+	records, _, err := c.GetAuditRecords(context.TODO(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to get audit records: %w", err)
+	}
+
+	if len(records) == 0 {
+		printer.Print("No audit records found")
+		return nil
+	}
+
+	for _, record := range records {
+		printer.PrintT(fmt.Sprintf("%s {{.EventName}} ({{.Status}}) by {{.UserId}}, record {{.Sequence}} of chain {{.ChainId}}",
+			time.UnixMilli(record.CreateAt).Format(ISO8601Layout)), record)
+	}
+
+	return nil
+}
+
+func getAuditPublicKey(publicKeyFile string) (*ecdsa.PublicKey, error) {
+	if publicKeyFile == "" {
+		return nil, errors.New("the public key to check the checkpoints against must be given with --public-key-file")
+	}
+
+	data, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the public key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("the public key file isn't a PEM file")
+	}
+
+	signingKey := &model.AuditSigningKey{PublicKey: base64.StdEncoding.EncodeToString(block.Bytes)}
+	publicKey, err := signingKey.ECDSAPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+func auditVerifyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	chainID, _ := cmd.Flags().GetString("chain")
+	publicKeyFile, _ := cmd.Flags().GetString("public-key-file")
+
+	publicKey, err := getAuditPublicKey(publicKeyFile)
+	if err != nil {
+		return err
+	}
+
+	// The checkpoints are fetched first, so that the records they were taken of are all
+	// included in the records fetched next.
+	var checkpoints []*model.AuditCheckpoint
+	for page := 0; ; page++ {
+		pageCheckpoints, _, err := c.GetAuditCheckpoints(context.TODO(), chainID, page, DefaultPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get audit checkpoints: %w", err)
+		}
+		checkpoints = append(checkpoints, pageCheckpoints...)
+		if len(pageCheckpoints) < DefaultPageSize {
+			break
+		}
+	}
+
+	var records []*model.AuditRecord
+	for page := 0; ; page++ {
+		pageRecords, _, err := c.GetAuditRecords(context.TODO(), &model.AuditRecordFilter{ChainId: chainID, Page: page, PerPage: DefaultPageSize})
+		if err != nil {
+			return fmt.Errorf("failed to get audit records: %w", err)
+		}
+		records = append(records, pageRecords...)
+		if len(pageRecords) < DefaultPageSize {
+			break
+		}
+	}
+
+	reports := model.VerifyAuditChains(records, checkpoints, publicKey)
+	if len(reports) == 0 {
+		printer.Print("No audit records found")
+		return nil
+	}
+
+	invalid := 0
+	for _, report := range reports {
+		printer.PrintT(`Chain {{.ChainId}}: {{.Records}} records from {{.FirstSequence}} to {{.LastSequence}}, {{.Checkpoints}} checkpoints{{if not .Complete}}, the first record is trusted{{end}}`, report)
+		for _, reportErr := range report.Errors {
+			printer.PrintError(fmt.Sprintf("  %s", reportErr))
+		}
+		if !report.IsValid() {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d audit chains failed verification", invalid, len(reports))
+	}
+	return nil
+}
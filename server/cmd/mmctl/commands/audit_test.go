@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) newTestAuditChain(key *ecdsa.PrivateKey, n int) ([]*model.AuditRecord, *model.AuditCheckpoint) {
+	chainID := model.NewId()
+	records := make([]*model.AuditRecord, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		record := &model.AuditRecord{
+			Id:        model.NewId(),
+			ChainId:   chainID,
+			Sequence:  int64(i),
+			CreateAt:  model.GetMillis(),
+			EventName: "updateUserActive",
+			Status:    "success",
+			UserId:    model.NewId(),
+			Data:      "{}",
+			PrevHash:  prevHash,
+		}
+		record.Hash = record.ComputeHash()
+		prevHash = record.Hash
+		records = append(records, record)
+	}
+
+	checkpoint := &model.AuditCheckpoint{
+		Id:       model.NewId(),
+		ChainId:  chainID,
+		Sequence: int64(n),
+		Hash:     prevHash,
+		CreateAt: model.GetMillis(),
+	}
+	s.Require().NoError(checkpoint.Sign(key))
+
+	return records, checkpoint
+}
+
+func (s *MmctlUnitTestSuite) TestAuditListCmd() {
+	s.Run("filters the records", func() {
+		printer.Clean()
+
+		userID := model.NewId()
+		record := &model.AuditRecord{Id: model.NewId(), ChainId: model.NewId(), Sequence: 1, EventName: "login", UserId: userID}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("user-id", userID, "")
+		cmd.Flags().String("event", "login", "")
+		cmd.Flags().String("since", "2024-01-01T00:00:00+00:00", "")
+		cmd.Flags().String("until", "", "")
+		cmd.Flags().String("chain", "", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 10, "")
+
+		s.client.
+			EXPECT().
+			GetAuditRecords(context.TODO(), &model.AuditRecordFilter{UserId: userID, EventName: "login", Since: 1704067200000, PerPage: 10}).
+			Return([]*model.AuditRecord{record}, &model.Response{}, nil).
+			Times(1)
+
+		err := auditListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(record, printer.GetLines()[0])
+	})
+
+	s.Run("rejects an invalid time", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "yesterday", "")
+
+		err := auditListCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "invalid since time 'yesterday'")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestAuditVerifyCmd() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	writePublicKeyFile := func(key *ecdsa.PublicKey) string {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		s.Require().NoError(err)
+		publicKeyFile := filepath.Join(s.T().TempDir(), "key.pem")
+		s.Require().NoError(os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+		return publicKeyFile
+	}
+	publicKeyFile := writePublicKeyFile(&key.PublicKey)
+
+	newCmd := func(publicKeyFile string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("chain", "", "")
+		cmd.Flags().String("public-key-file", publicKeyFile, "")
+		return cmd
+	}
+
+	s.Run("verifies the chains", func() {
+		printer.Clean()
+		records, checkpoint := s.newTestAuditChain(key, 3)
+
+		s.client.
+			EXPECT().
+			GetAuditCheckpoints(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.AuditCheckpoint{checkpoint}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetAuditRecords(context.TODO(), &model.AuditRecordFilter{PerPage: DefaultPageSize}).
+			Return(records, &model.Response{}, nil).
+			Times(1)
+
+		err := auditVerifyCmdF(s.client, newCmd(publicKeyFile), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Empty(printer.GetErrorLines())
+
+		report := printer.GetLines()[0].(*model.AuditChainReport)
+		s.Require().Equal(records[0].ChainId, report.ChainId)
+		s.Require().Equal(3, report.Records)
+		s.Require().True(report.Complete)
+	})
+
+	s.Run("reports tampered records", func() {
+		printer.Clean()
+		records, checkpoint := s.newTestAuditChain(key, 3)
+		records[1].UserId = model.NewId()
+
+		s.client.
+			EXPECT().
+			GetAuditCheckpoints(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.AuditCheckpoint{checkpoint}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetAuditRecords(context.TODO(), &model.AuditRecordFilter{PerPage: DefaultPageSize}).
+			Return(records, &model.Response{}, nil).
+			Times(1)
+
+		err := auditVerifyCmdF(s.client, newCmd(publicKeyFile), []string{})
+		s.Require().EqualError(err, "1 of 1 audit chains failed verification")
+		s.Require().Equal([]any{"  record 2: hash mismatch"}, printer.GetErrorLines())
+	})
+
+	s.Run("checks the signatures against the given public key", func() {
+		printer.Clean()
+		records, checkpoint := s.newTestAuditChain(key, 3)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		s.Require().NoError(err)
+		otherPublicKeyFile := writePublicKeyFile(&otherKey.PublicKey)
+
+		s.client.
+			EXPECT().
+			GetAuditCheckpoints(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.AuditCheckpoint{checkpoint}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetAuditRecords(context.TODO(), &model.AuditRecordFilter{PerPage: DefaultPageSize}).
+			Return(records, &model.Response{}, nil).
+			Times(1)
+
+		err = auditVerifyCmdF(s.client, newCmd(otherPublicKeyFile), []string{})
+		s.Require().Error(err)
+		s.Require().Equal([]any{"  checkpoint 3: invalid signature"}, printer.GetErrorLines())
+	})
+
+	s.Run("requires the public key file", func() {
+		printer.Clean()
+
+		err := auditVerifyCmdF(s.client, newCmd(""), []string{})
+		s.Require().ErrorContains(err, "--public-key-file")
+		s.Require().Empty(printer.GetLines())
+	})
+}
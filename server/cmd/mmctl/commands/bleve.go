@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+var BleveCmd = &cobra.Command{
+	Use:   "bleve",
+	Short: "Management of the Bleve indexes",
+}
+
+var BleveStatsCmd = &cobra.Command{
+	Use:     "stats",
+	Example: "  bleve stats",
+	Short:   "Show the document count, size and health of the Bleve indexes",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(bleveStatsCmdF),
+}
+
+var BleveVerifyCmd = &cobra.Command{
+	Use:     "verify [indexes]",
+	Example: "  bleve verify posts files",
+	Short:   "Verify the health of Bleve indexes",
+	Long:    "Verify that the given Bleve indexes, or all of them if none is given, can be read and hold a consistent number of documents.",
+	RunE:    withClient(bleveVerifyCmdF),
+}
+
+var BleveReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Start a partial reindex of the Bleve indexes",
+	Long:  "Start a job reindexing the entities created in a time range. When a channel is given, only the channel, its posts and its files are reindexed.",
+	Example: `  bleve reindex --from 1704067200
+  bleve reindex --channel myteam:mychannel --from 1704067200 --to 1706745600`,
+	Args: cobra.NoArgs,
+	RunE: withClient(bleveReindexCmdF),
+}
+
+var BlevePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Purge the Bleve indexes",
+	Long:  "Delete the given Bleve indexes, or all of them if no index is given. A new indexing job is needed to fill them again.",
+	Example: `  bleve purge
+  bleve purge --index posts --index files`,
+	Args: cobra.NoArgs,
+	RunE: withClient(blevePurgeCmdF),
+}
+
+func init() {
+	BleveReindexCmd.Flags().String("channel", "", "The channel to reindex, by team:channel name or channel ID.")
+	BleveReindexCmd.Flags().Int64("from", 0, "The timestamp of the earliest entity to reindex, expressed in seconds since the unix epoch. Defaults to the oldest entity.")
+	BleveReindexCmd.Flags().Int64("to", 0, "The timestamp of the latest entity to reindex, expressed in seconds since the unix epoch. Defaults to the current time.")
+	BlevePurgeCmd.Flags().StringSlice("index", nil, "The index to purge. Can be repeated. Defaults to all the indexes.")
+	BlevePurgeCmd.Flags().Bool("confirm", false, "Confirm you really want to purge the indexes.")
+
+	BleveCmd.AddCommand(
+		BleveStatsCmd,
+		BleveVerifyCmd,
+		BleveReindexCmd,
+		BlevePurgeCmd,
+	)
+	RootCmd.AddCommand(BleveCmd)
+}
+
+func printBleveIndexStats(stats *model.BleveIndexStats) {
+	if stats.Healthy {
+		printer.PrintT("{{.Name}}: {{.DocCount}} documents, {{.SizeBytes}} bytes, healthy", stats)
+	} else {
+		printer.PrintT("{{.Name}}: {{.DocCount}} documents, {{.SizeBytes}} bytes, unhealthy: {{.Error}}", stats)
+	}
+}
+
+func bleveStatsCmdF(c client.Client, command *cobra.Command, args []string) error {
+	stats, _, err := c.GetBleveIndexStats(context.TODO())
+	if err != nil {
+		return fmt.Errorf("failed to get the Bleve index stats: %w", err)
+	}
+
+	for _, indexStats := range stats {
+		printBleveIndexStats(indexStats)
+	}
+
+	return nil
+}
+
+func bleveVerifyCmdF(c client.Client, command *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		stats, _, err := c.GetBleveIndexStats(context.TODO())
+		if err != nil {
+			return fmt.Errorf("failed to get the Bleve index stats: %w", err)
+		}
+		for _, indexStats := range stats {
+			args = append(args, indexStats.Name)
+		}
+	}
+
+	var result *multierror.Error
+	for _, indexName := range args {
+		stats, _, err := c.VerifyBleveIndex(context.TODO(), indexName)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to verify the Bleve index %q: %w", indexName, err))
+			continue
+		}
+
+		printBleveIndexStats(stats)
+		if !stats.Healthy {
+			result = multierror.Append(result, fmt.Errorf("the Bleve index %q is unhealthy", indexName))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func bleveReindexCmdF(c client.Client, command *cobra.Command, args []string) error {
+	from, err := command.Flags().GetInt64("from")
+	if err != nil {
+		return err
+	}
+	to, err := command.Flags().GetInt64("to")
+	if err != nil {
+		return err
+	}
+
+	req := &model.BleveReindexRequest{
+		StartTime: from * 1000,
+		EndTime:   to * 1000,
+	}
+
+	if channelArg, _ := command.Flags().GetString("channel"); channelArg != "" {
+		channel := getChannelFromChannelArg(c, channelArg)
+		if channel == nil {
+			return fmt.Errorf("unable to find channel %q", channelArg)
+		}
+		req.ChannelId = channel.Id
+	}
+
+	job, _, err := c.CreateBleveReindexJob(context.TODO(), req)
+	if err != nil {
+		return fmt.Errorf("failed to create the Bleve reindex job: %w", err)
+	}
+
+	printer.PrintT("Bleve reindex job successfully created, ID: {{.Id}}", job)
+
+	return nil
+}
+
+func blevePurgeCmdF(c client.Client, command *cobra.Command, args []string) error {
+	indexes, err := command.Flags().GetStringSlice("index")
+	if err != nil {
+		return err
+	}
+
+	confirmFlag, _ := command.Flags().GetBool("confirm")
+	if !confirmFlag {
+		if err := getConfirmation("Are you sure you want to purge the Bleve indexes?", false); err != nil {
+			return err
+		}
+	}
+
+	if len(indexes) == 0 {
+		if _, err := c.PurgeBleveIndexes(context.TODO()); err != nil {
+			return fmt.Errorf("failed to purge the Bleve indexes: %w", err)
+		}
+	} else if _, err := c.PurgeBleveIndexList(context.TODO(), indexes); err != nil {
+		return fmt.Errorf("failed to purge the Bleve indexes: %w", err)
+	}
+
+	printer.PrintT("Bleve indexes successfully purged", map[string]string{"status": "ok"})
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestBleveStatsCmd() {
+	s.Run("Show the stats of every index", func() {
+		printer.Clean()
+		stats := []*model.BleveIndexStats{
+			{Name: "posts", DocCount: 10, SizeBytes: 2048, Healthy: true},
+			{Name: "files", DocCount: 0, SizeBytes: 1024, Error: "broken"},
+		}
+
+		s.client.
+			EXPECT().
+			GetBleveIndexStats(context.TODO()).
+			Return(stats, &model.Response{}, nil).
+			Times(1)
+
+		err := bleveStatsCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(stats[0], printer.GetLines()[0])
+		s.Require().Equal(stats[1], printer.GetLines()[1])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Fail to get the stats", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetBleveIndexStats(context.TODO()).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := bleveStatsCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestBleveVerifyCmd() {
+	s.Run("Verify the given indexes", func() {
+		printer.Clean()
+		stats := &model.BleveIndexStats{Name: "posts", DocCount: 10, Healthy: true}
+
+		s.client.
+			EXPECT().
+			VerifyBleveIndex(context.TODO(), "posts").
+			Return(stats, &model.Response{}, nil).
+			Times(1)
+
+		err := bleveVerifyCmdF(s.client, &cobra.Command{}, []string{"posts"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(stats, printer.GetLines()[0])
+	})
+
+	s.Run("Verify all the indexes and report the unhealthy ones", func() {
+		printer.Clean()
+		postStats := &model.BleveIndexStats{Name: "posts", Healthy: true}
+		fileStats := &model.BleveIndexStats{Name: "files", Error: "broken"}
+
+		s.client.
+			EXPECT().
+			GetBleveIndexStats(context.TODO()).
+			Return([]*model.BleveIndexStats{postStats, fileStats}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			VerifyBleveIndex(context.TODO(), "posts").
+			Return(postStats, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			VerifyBleveIndex(context.TODO(), "files").
+			Return(fileStats, &model.Response{}, nil).
+			Times(1)
+
+		err := bleveVerifyCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().ErrorContains(err, `the Bleve index "files" is unhealthy`)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestBleveReindexCmd() {
+	s.Run("Reindex a channel in a time range", func() {
+		printer.Clean()
+		channel := &model.Channel{Id: model.NewId()}
+		job := &model.Job{Id: model.NewId()}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("channel", channel.Id, "")
+		cmd.Flags().Int64("from", 1000, "")
+		cmd.Flags().Int64("to", 2000, "")
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateBleveReindexJob(context.TODO(), &model.BleveReindexRequest{ChannelId: channel.Id, StartTime: 1000000, EndTime: 2000000}).
+			Return(job, &model.Response{}, nil).
+			Times(1)
+
+		err := bleveReindexCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(job, printer.GetLines()[0])
+	})
+
+	s.Run("Fail with an unknown channel", func() {
+		printer.Clean()
+		channelID := model.NewId()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("channel", channelID, "")
+		cmd.Flags().Int64("from", 0, "")
+		cmd.Flags().Int64("to", 0, "")
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channelID, "").
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := bleveReindexCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestBlevePurgeCmd() {
+	s.Run("Purge all the indexes", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("index", nil, "")
+		cmd.Flags().Bool("confirm", true, "")
+
+		s.client.
+			EXPECT().
+			PurgeBleveIndexes(context.TODO()).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := blevePurgeCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Purge the given indexes", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("index", []string{"posts", "files"}, "")
+		cmd.Flags().Bool("confirm", true, "")
+
+		s.client.
+			EXPECT().
+			PurgeBleveIndexList(context.TODO(), []string{"posts", "files"}).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := blevePurgeCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+}
@@ -87,6 +87,44 @@ func (s *MmctlUnitTestSuite) TestExportCreateCmdF() {
 		s.Empty(printer.GetErrorLines())
 		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 	})
+
+	s.Run("create incremental export", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"since":                     "1704067200000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int64("since", 1704067200, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("fail with a negative since timestamp", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int64("since", -1, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Error(err)
+		s.Empty(printer.GetLines())
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestExportDeleteCmdF() {
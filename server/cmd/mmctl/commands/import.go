@@ -4,10 +4,13 @@
 package commands
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -17,11 +20,15 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/spf13/cobra"
 
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/importer"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+	"github.com/mattermost/mattermost/server/v8/platform/services/discordimport"
+	"github.com/mattermost/mattermost/server/v8/platform/services/teamsimport"
 )
 
 var ImportCmd = &cobra.Command{
@@ -82,6 +89,15 @@ var ImportJobShowCmd = &cobra.Command{
 	RunE:    withClient(importJobShowCmdF),
 }
 
+var ImportJobReportCmd = &cobra.Command{
+	Use:     "report [importJobID]",
+	Example: " import job report f3d68qkkm7n8xgsfxwuo498rah",
+	Short:   "Show the report of an import dry run",
+	Long:    "Show what an import would change, as reported by an import job started with the --dry-run flag.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(importJobReportCmdF),
+}
+
 var ImportProcessCmd = &cobra.Command{
 	Use:     "process [importname]",
 	Example: "  import process 35uy6cwrqfnhdx3genrhqqznxc_import.zip",
@@ -100,6 +116,35 @@ var ImportValidateCmd = &cobra.Command{
 	},
 }
 
+var ImportConvertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert the exports of other platforms into import files",
+}
+
+var ImportConvertDiscordCmd = &cobra.Command{
+	Use:   "discord [exportfile] [importfile]",
+	Short: "Convert a Discord export into an import file",
+	Long: `Convert a zip archive of DiscordChatExporter JSON exports, along with their downloaded media, into an import file.
+The Discord users are mapped by email to the users of the server, who keep their username and aren't imported again.`,
+	Example: "  import convert discord discord_export.zip import_file.zip --team myteam --user-emails emails.json",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(command *cobra.Command, args []string) error {
+		return importConvertDiscordCmdF(nil, command, args)
+	},
+}
+
+var ImportConvertTeamsCmd = &cobra.Command{
+	Use:   "teams [exportfile] [importfile]",
+	Short: "Convert a Microsoft Teams export into an import file",
+	Long: `Convert a zip archive of Microsoft Graph exports of Microsoft Teams into an import file.
+The Microsoft Teams users are mapped by email to the users of the server, who keep their username and aren't imported again.`,
+	Example: "  import convert teams teams_export.zip import_file.zip --team myteam",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(command *cobra.Command, args []string) error {
+		return importConvertTeamsCmdF(nil, command, args)
+	},
+}
+
 func init() {
 	ImportUploadCmd.Flags().Bool("resume", false, "Set to true to resume an incomplete import upload.")
 	ImportUploadCmd.Flags().String("upload", "", "The ID of the import upload to resume.")
@@ -114,8 +159,17 @@ func init() {
 	ImportValidateCmd.Flags().Bool("check-server-duplicates", true, "Set to false to ignore teams, channels, and users already present on the server")
 
 	ImportProcessCmd.Flags().Bool("bypass-upload", false, "If this is set, the file is not processed from the server, but rather directly read from the filesystem. Works only in --local mode.")
+	ImportProcessCmd.Flags().Bool("dry-run", false, "If this is set, nothing is imported. Instead, the job reports what the import would change, which can be shown with the \"import job report\" command.")
 	ImportProcessCmd.Flags().Bool("extract-content", true, "If this is set, document attachments will be extracted and indexed during the import process. It is advised to disable it to improve performance.")
 
+	ImportConvertDiscordCmd.Flags().String("team", "", "The name of the team the channels are imported in. Defaults to the name of the Discord server.")
+	ImportConvertDiscordCmd.Flags().String("user-emails", "", "A JSON file mapping the Discord user IDs or names to their email. The users without an email get a placeholder one.")
+	ImportConvertTeamsCmd.Flags().String("team", "", "The name of the team all the channels are imported in. Defaults to a team for every team of Microsoft Teams.")
+
+	ImportConvertCmd.AddCommand(
+		ImportConvertDiscordCmd,
+		ImportConvertTeamsCmd,
+	)
 	ImportListCmd.AddCommand(
 		ImportListAvailableCmd,
 		ImportListIncompleteCmd,
@@ -123,6 +177,7 @@ func init() {
 	ImportJobCmd.AddCommand(
 		ImportJobListCmd,
 		ImportJobShowCmd,
+		ImportJobReportCmd,
 	)
 	ImportCmd.AddCommand(
 		ImportUploadCmd,
@@ -130,6 +185,7 @@ func init() {
 		ImportProcessCmd,
 		ImportJobCmd,
 		ImportValidateCmd,
+		ImportConvertCmd,
 	)
 	RootCmd.AddCommand(ImportCmd)
 }
@@ -291,13 +347,18 @@ func importProcessCmdF(c client.Client, command *cobra.Command, args []string) e
 
 	extractContent, _ := command.Flags().GetBool("extract-content")
 
+	data := map[string]string{
+		"import_file":     importFile,
+		"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
+		"extract_content": strconv.FormatBool(extractContent),
+	}
+	if dryRun, _ := command.Flags().GetBool("dry-run"); dryRun {
+		data["dry_run"] = "true"
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeImportProcess,
-		Data: map[string]string{
-			"import_file":     importFile,
-			"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
-			"extract_content": strconv.FormatBool(extractContent),
-		},
+		Data: data,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create import process job: %w", err)
@@ -319,6 +380,39 @@ func importJobShowCmdF(c client.Client, command *cobra.Command, args []string) e
 	return nil
 }
 
+func importJobReportCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if job.Type != model.JobTypeImportProcess || job.Data["dry_run"] != "true" {
+		return fmt.Errorf("job %s is not an import dry run", job.Id)
+	}
+	if job.Status != model.JobStatusSuccess {
+		return fmt.Errorf("the report of job %s is not available, the job status is %s", job.Id, job.Status)
+	}
+
+	data, _, err := c.DownloadJob(context.TODO(), job.Id)
+	if err != nil {
+		return fmt.Errorf("failed to download import report: %w", err)
+	}
+
+	var report model.BulkImportReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return fmt.Errorf("failed to decode import report: %w", err)
+	}
+
+	printer.PrintT(`Lines: {{.Lines}}
+{{range $type, $counts := .Entities}}{{$type}}: {{$counts.Creates}} to create, {{$counts.Updates}} to update, {{$counts.NoOps}} unchanged, {{$counts.Deletes}} to delete
+{{end}}{{range .Conflicts}}Conflict on line {{.Line}}: {{.Message}}
+{{end}}{{range .MissingReferences}}Missing reference on line {{.Line}}: {{.Message}}
+{{end}}{{range .Errors}}Invalid line {{.Line}}: {{.Message}}
+{{end}}`, &report)
+
+	return nil
+}
+
 func importJobListCmdF(c client.Client, command *cobra.Command, args []string) error {
 	return jobListCmdF(c, command, model.JobTypeImportProcess, "")
 }
@@ -520,6 +614,103 @@ func importValidateCmdF(c client.Client, command *cobra.Command, args []string)
 	return nil
 }
 
+// clientUserResolver maps the users of converted exports to the users of the server.
+type clientUserResolver struct {
+	c client.Client
+}
+
+func (r *clientUserResolver) UsernameByEmail(email string) (string, error) {
+	user, resp, err := r.c.GetUserByEmail(context.TODO(), email, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+func (r *clientUserResolver) UsernameExists(username string) (bool, error) {
+	_, resp, err := r.c.GetUserByUsername(context.TODO(), username, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type importConverter func(logger mlog.LoggerIFace, input *zip.Reader, output io.Writer, users imports.UserResolver) error
+
+func importConvert(c client.Client, command *cobra.Command, args []string, convert importConverter) error {
+	var users imports.UserResolver
+	initUsers := func(c client.Client, cmd *cobra.Command, args []string) error {
+		users = &clientUserResolver{c: c}
+		return nil
+	}
+	if c != nil {
+		_ = initUsers(c, command, args)
+	} else if err := withClient(initUsers)(command, args); err != nil {
+		printer.PrintWarning(fmt.Sprintf("could not initialize client (%s), the users aren't mapped to the users of the server", err.Error()))
+	}
+
+	input, err := zip.OpenReader(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer input.Close()
+
+	output, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create import file: %w", err)
+	}
+	defer output.Close()
+
+	logger := mlog.CreateConsoleLogger()
+	defer logger.Shutdown()
+
+	if err := convert(logger, &input.Reader, output, users); err != nil {
+		return fmt.Errorf("failed to convert export file: %w", err)
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to write import file: %w", err)
+	}
+
+	printer.PrintT("Import file successfully created: {{.Path}}", map[string]string{"Path": args[1]})
+
+	return nil
+}
+
+func importConvertDiscordCmdF(c client.Client, command *cobra.Command, args []string) error {
+	opts := discordimport.Options{}
+	opts.TeamName, _ = command.Flags().GetString("team")
+
+	if emailsFile, _ := command.Flags().GetString("user-emails"); emailsFile != "" {
+		b, err := os.ReadFile(emailsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read user emails file: %w", err)
+		}
+		if err := json.Unmarshal(b, &opts.UserEmails); err != nil {
+			return fmt.Errorf("failed to decode user emails file: %w", err)
+		}
+	}
+
+	return importConvert(c, command, args, func(logger mlog.LoggerIFace, input *zip.Reader, output io.Writer, users imports.UserResolver) error {
+		opts.Users = users
+		return discordimport.Convert(logger, input, output, opts)
+	})
+}
+
+func importConvertTeamsCmdF(c client.Client, command *cobra.Command, args []string) error {
+	opts := teamsimport.Options{}
+	opts.TeamName, _ = command.Flags().GetString("team")
+
+	return importConvert(c, command, args, func(logger mlog.LoggerIFace, input *zip.Reader, output io.Writer, users imports.UserResolver) error {
+		opts.Users = users
+		return teamsimport.Convert(logger, input, output, opts)
+	})
+}
+
 func configurePrinter() {
 	// we want to manage the newlines ourselves
 	printer.SetNoNewline(true)
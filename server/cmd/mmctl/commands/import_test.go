@@ -6,6 +6,7 @@ package commands
 import (
 	"archive/zip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -150,6 +151,62 @@ func (s *MmctlUnitTestSuite) TestImportJobShowCmdF() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestImportJobReportCmdF() {
+	s.Run("not a dry run", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeImportProcess,
+			Status: model.JobStatusSuccess,
+			Data:   map[string]string{"import_file": "import.zip"},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := importJobReportCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().EqualError(err, "job "+mockJob.Id+" is not an import dry run")
+		s.Empty(printer.GetLines())
+		s.Empty(printer.GetErrorLines())
+	})
+
+	s.Run("report", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeImportProcess,
+			Status: model.JobStatusSuccess,
+			Data:   map[string]string{"import_file": "import.zip", "dry_run": "true", "is_downloadable": "true"},
+		}
+		report := model.NewBulkImportReport()
+		report.Lines = 3
+		report.Entity("user").Creates = 2
+		report.MissingReferences = append(report.MissingReferences, &model.BulkImportIssue{Line: 3, Type: "channel", Name: "team/channel", Message: "Channel \"channel\" does not exist in team \"team\"."})
+		data, err := json.Marshal(report)
+		s.Require().NoError(err)
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DownloadJob(context.TODO(), mockJob.Id).
+			Return(data, &model.Response{}, nil).
+			Times(1)
+
+		err = importJobReportCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(report, printer.GetLines()[0].(*model.BulkImportReport))
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestImportJobListCmdF() {
 	s.Run("no import jobs", func() {
 		printer.Clean()
@@ -233,6 +290,34 @@ func (s *MmctlUnitTestSuite) TestImportProcessCmdF() {
 	s.Len(printer.GetLines(), 1)
 	s.Empty(printer.GetErrorLines())
 	s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+
+	s.Run("dry run", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"import_file":     importFile,
+				"local_mode":      "false",
+				"extract_content": "false",
+				"dry_run":         "true",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("dry-run", true, "")
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
@@ -476,3 +561,96 @@ func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
 		s.Equal("Validation complete\n", printer.GetLines()[2])
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestImportConvertDiscordCmdF() {
+	exportFilePath := filepath.Join(os.TempDir(), "discord_export.zip")
+	importFilePath := filepath.Join(os.TempDir(), "discord_import.zip")
+	emailsFilePath := filepath.Join(os.TempDir(), "discord_emails.json")
+	defer os.Remove(exportFilePath)
+	defer os.Remove(importFilePath)
+	defer os.Remove(emailsFilePath)
+
+	file, err := os.Create(exportFilePath)
+	s.Require().NoError(err)
+	zipWr := zip.NewWriter(file)
+	wr, err := zipWr.Create("general.json")
+	s.Require().NoError(err)
+	_, err = wr.Write([]byte(`{
+  "guild": {"id": "1", "name": "Game Night"},
+  "channel": {"id": "2", "type": "GuildTextChat", "name": "general"},
+  "messages": [
+    {"id": "3", "type": "Default", "timestamp": "2023-01-02T10:00:00+00:00", "content": "Hello", "author": {"id": "4", "name": "alice"}},
+    {"id": "5", "type": "Default", "timestamp": "2023-01-02T10:01:00+00:00", "content": "Hi", "author": {"id": "6", "name": "bob"}}
+  ]
+}`))
+	s.Require().NoError(err)
+	s.Require().NoError(zipWr.Close())
+	s.Require().NoError(file.Close())
+	s.Require().NoError(os.WriteFile(emailsFilePath, []byte(`{"4": "alice@example.com", "bob": "bob@example.com"}`), 0600))
+
+	s.Run("convert the export, mapping the users of the server", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("team", "myteam", "")
+		cmd.Flags().String("user-emails", emailsFilePath, "")
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "alice@example.com", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("mock error")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), "alice", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("mock error")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "bob@example.com", "").
+			Return(&model.User{Username: "robert"}, &model.Response{}, nil).
+			Times(1)
+
+		err := importConvertDiscordCmdF(s.client, cmd, []string{exportFilePath, importFilePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Empty(printer.GetErrorLines())
+
+		r, err := zip.OpenReader(importFilePath)
+		s.Require().NoError(err)
+		defer r.Close()
+		s.Require().Len(r.File, 1)
+		s.Require().Equal("import.jsonl", r.File[0].Name)
+	})
+
+	s.Run("fail to look up the users", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("team", "", "")
+		cmd.Flags().String("user-emails", emailsFilePath, "")
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "alice@example.com", "").
+			Return(nil, &model.Response{StatusCode: http.StatusInternalServerError}, errors.New("mock error")).
+			Times(1)
+
+		err := importConvertDiscordCmdF(s.client, cmd, []string{exportFilePath, importFilePath})
+		s.Require().ErrorContains(err, "mock error")
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestImportConvertTeamsCmdF() {
+	s.Run("fail with a missing export file", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("team", "", "")
+
+		err := importConvertTeamsCmdF(s.client, cmd, []string{filepath.Join(os.TempDir(), "missing_teams_export.zip"), filepath.Join(os.TempDir(), "teams_import.zip")})
+		s.Require().ErrorContains(err, "failed to open export file")
+		s.Require().Empty(printer.GetLines())
+	})
+}
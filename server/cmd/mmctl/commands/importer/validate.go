@@ -77,16 +77,23 @@ type Validator struct { //nolint:govet
 }
 
 const (
-	LineTypeVersion       = "version"
-	LineTypeRole          = "role"
-	LineTypeScheme        = "scheme"
-	LineTypeTeam          = "team"
-	LineTypeChannel       = "channel"
-	LineTypeUser          = "user"
-	LineTypePost          = "post"
-	LineTypeDirectChannel = "direct_channel"
-	LineTypeDirectPost    = "direct_post"
-	LineTypeEmoji         = "emoji"
+	LineTypeVersion         = "version"
+	LineTypeRole            = "role"
+	LineTypeScheme          = "scheme"
+	LineTypeTeam            = "team"
+	LineTypeChannel         = "channel"
+	LineTypeUser            = "user"
+	LineTypeBot             = "bot"
+	LineTypeGroup           = "group"
+	LineTypeChannelBookmark = "channel_bookmark"
+	LineTypeIncomingWebhook = "incoming_webhook"
+	LineTypeOutgoingWebhook = "outgoing_webhook"
+	LineTypeCommand         = "command"
+	LineTypePost            = "post"
+	LineTypeDirectChannel   = "direct_channel"
+	LineTypeDirectPost      = "direct_post"
+	LineTypeEmoji           = "emoji"
+	LineTypeDelete          = "delete"
 )
 
 func NewValidator(
@@ -410,6 +417,18 @@ func (v *Validator) validateLine(info ImportFileInfo, line imports.LineImportDat
 		err = v.validateChannel(info, line)
 	case LineTypeUser:
 		err = v.validateUser(info, line)
+	case LineTypeBot:
+		err = v.validateBot(info, line)
+	case LineTypeGroup:
+		err = v.validateGroup(info, line)
+	case LineTypeChannelBookmark:
+		err = v.validateChannelBookmark(info, line)
+	case LineTypeIncomingWebhook:
+		err = v.validateIncomingWebhook(info, line)
+	case LineTypeOutgoingWebhook:
+		err = v.validateOutgoingWebhook(info, line)
+	case LineTypeCommand:
+		err = v.validateCommand(info, line)
 	case LineTypePost:
 		err = v.validatePost(info, line)
 	case LineTypeDirectChannel:
@@ -418,6 +437,8 @@ func (v *Validator) validateLine(info ImportFileInfo, line imports.LineImportDat
 		err = v.validateDirectPost(info, line)
 	case LineTypeEmoji:
 		err = v.validateEmoji(info, line)
+	case LineTypeDelete:
+		err = v.validateDelete(info, line)
 	default:
 		err = v.onError(&ImportValidationError{
 			ImportFileInfo: info,
@@ -725,6 +746,141 @@ func (v *Validator) validateUser(info ImportFileInfo, line imports.LineImportDat
 	return nil
 }
 
+func (v *Validator) validateBot(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "bot", line.Bot, func(data imports.BotImportData) *ImportValidationError {
+		if appErr := imports.ValidateBotImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "bot",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateGroup(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "group", line.Group, func(data imports.GroupImportData) *ImportValidationError {
+		if appErr := imports.ValidateGroupImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "group",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateChannelBookmark(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "channel_bookmark", line.ChannelBookmark, func(data imports.ChannelBookmarkImportData) *ImportValidationError {
+		if appErr := imports.ValidateChannelBookmarkImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "channel_bookmark",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateIncomingWebhook(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "incoming_webhook", line.IncomingWebhook, func(data imports.IncomingWebhookImportData) *ImportValidationError {
+		if appErr := imports.ValidateIncomingWebhookImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "incoming_webhook",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateOutgoingWebhook(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "outgoing_webhook", line.OutgoingWebhook, func(data imports.OutgoingWebhookImportData) *ImportValidationError {
+		if appErr := imports.ValidateOutgoingWebhookImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "outgoing_webhook",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateCommand(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "command", line.Command, func(data imports.CommandImportData) *ImportValidationError {
+		if appErr := imports.ValidateCommandImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "command",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+// validateDelete doesn't check that the deleted entities exist, as the
+// tombstones of an incremental export refer to entities of earlier exports.
+func (v *Validator) validateDelete(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "delete", line.Delete, func(data imports.DeleteImportData) *ImportValidationError {
+		if appErr := imports.ValidateDeleteImportData(&data); appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "delete",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
 func (v *Validator) validatePost(info ImportFileInfo, line imports.LineImportData) (err error) {
 	ivErr := validateNotNil(info, "post", line.Post, func(data imports.PostImportData) *ImportValidationError {
 		appErr := imports.ValidatePostImportData(&data, v.maxPostSize)
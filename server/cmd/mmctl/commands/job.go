@@ -48,6 +48,41 @@ var updateJobCmd = &cobra.Command{
 	RunE: withClient(updateJobCmdF),
 }
 
+var listJobSchedulesCmd = &cobra.Command{
+	Use:     "schedules",
+	Short:   "List the job schedules",
+	Long:    "List the job types run on a schedule, along with their cron schedule if set in JobSettings.CronSchedules, whether they are paused, and the time of their next run.",
+	Example: `  job schedules`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(listJobSchedulesCmdF),
+}
+
+var pauseJobTypeCmd = &cobra.Command{
+	Use:     "pause [job type]",
+	Short:   "Pause a job type",
+	Long:    "Pause a job type on the whole cluster. No job of that type is scheduled or run until the job type is resumed, while the jobs already in progress are left to complete.",
+	Example: `  job pause message_export`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(pauseJobTypeCmdF),
+}
+
+var resumeJobTypeCmd = &cobra.Command{
+	Use:     "resume [job type]",
+	Short:   "Resume a paused job type",
+	Example: `  job resume message_export`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(resumeJobTypeCmdF),
+}
+
+var jobLineageCmd = &cobra.Command{
+	Use:     "lineage [job]",
+	Short:   "List the lineage of a job",
+	Long:    "List the jobs sharing the lineage of a job: the job it was first created from, along with its retries and follow-up jobs, in the order they were created.",
+	Example: `  job lineage myJobID`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(jobLineageCmdF),
+}
+
 func init() {
 	listJobsCmd.Flags().Int("page", 0, "Page number to fetch for the list of import jobs")
 	listJobsCmd.Flags().Int("per-page", 5, "Number of import jobs to be fetched")
@@ -61,6 +96,10 @@ func init() {
 	JobCmd.AddCommand(
 		listJobsCmd,
 		updateJobCmd,
+		listJobSchedulesCmd,
+		pauseJobTypeCmd,
+		resumeJobTypeCmd,
+		jobLineageCmd,
 	)
 
 	RootCmd.AddCommand(JobCmd)
@@ -129,6 +168,60 @@ func updateJobCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func listJobSchedulesCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	schedules, _, err := c.GetJobSchedules(context.TODO())
+	if err != nil {
+		return fmt.Errorf("failed to get job schedules: %w", err)
+	}
+
+	if len(schedules) == 0 {
+		printer.Print("No job schedules found")
+		return nil
+	}
+
+	for _, schedule := range schedules {
+		printJobSchedule(schedule)
+	}
+
+	return nil
+}
+
+func pauseJobTypeCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	if _, err := c.PauseJobType(context.TODO(), args[0]); err != nil {
+		return fmt.Errorf("failed to pause job type %s: %w", args[0], err)
+	}
+
+	printer.Print(fmt.Sprintf("Job type %s paused", args[0]))
+	return nil
+}
+
+func resumeJobTypeCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	if _, err := c.ResumeJobType(context.TODO(), args[0]); err != nil {
+		return fmt.Errorf("failed to resume job type %s: %w", args[0], err)
+	}
+
+	printer.Print(fmt.Sprintf("Job type %s resumed", args[0]))
+	return nil
+}
+
+func jobLineageCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	jobId := args[0]
+	if !model.IsValidId(jobId) {
+		return fmt.Errorf("invalid job ID: %s", jobId)
+	}
+
+	jobs, _, err := c.GetJobLineage(context.TODO(), jobId)
+	if err != nil {
+		return fmt.Errorf("failed to get the lineage of job %s: %w", jobId, err)
+	}
+
+	for _, job := range jobs {
+		printJob(job)
+	}
+
+	return nil
+}
+
 func jobListCmdF(c client.Client, command *cobra.Command, jobType string, status string) error {
 	page, err := command.Flags().GetInt("page")
 	if err != nil {
@@ -190,7 +283,10 @@ func printJob(job *model.Job) {
   Created: %s
   Started: %s
   Data: {{.Data}}
-`,
+{{if .ParentId}}  Parent: {{.ParentId}}
+  Root: {{.RootId}}
+{{end}}{{if .RetryCount}}  Retry count: {{.RetryCount}}
+{{end}}`,
 			time.Unix(job.CreateAt/1000, 0), time.Unix(job.StartAt/1000, 0)), job)
 	} else {
 		printer.PrintT(fmt.Sprintf(`  ID: {{.Id}}
@@ -200,3 +296,18 @@ func printJob(job *model.Job) {
 			time.Unix(job.CreateAt/1000, 0)), job)
 	}
 }
+
+func printJobSchedule(schedule *model.JobSchedule) {
+	nextRun := "none"
+	if schedule.NextRunTime > 0 {
+		nextRun = time.UnixMilli(schedule.NextRunTime).String()
+	}
+
+	printer.PrintT(fmt.Sprintf(`  Type: {{.JobType}}
+  Enabled: {{.Enabled}}
+  Paused: {{.Paused}}
+  Cron schedule: {{if .CronSchedule}}{{.CronSchedule}}{{else}}default{{end}}
+  Next run: %s
+`,
+		nextRun), schedule)
+}
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -202,3 +203,144 @@ func (s *MmctlUnitTestSuite) TestUpdateJobCmdF() {
 		s.Require().Nil(err)
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListJobSchedulesCmdF() {
+	s.Run("no job schedules found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetJobSchedules(context.TODO()).
+			Return([]*model.JobSchedule{}, &model.Response{}, nil).
+			Times(1)
+
+		err := listJobSchedulesCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal("No job schedules found", printer.GetLines()[0])
+	})
+
+	s.Run("job schedules found", func() {
+		printer.Clean()
+		mockSchedules := []*model.JobSchedule{
+			{
+				JobType:      model.JobTypeMessageExport,
+				Enabled:      true,
+				CronSchedule: "0 2 * * *",
+				NextRunTime:  model.GetMillis() + 60*60*1000,
+			},
+			{
+				JobType: model.JobTypeDataRetention,
+				Paused:  true,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJobSchedules(context.TODO()).
+			Return(mockSchedules, &model.Response{}, nil).
+			Times(1)
+
+		err := listJobSchedulesCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), len(mockSchedules))
+		s.Empty(printer.GetErrorLines())
+		for i, line := range printer.GetLines() {
+			s.Equal(mockSchedules[i], line.(*model.JobSchedule))
+		}
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPauseJobTypeCmdF() {
+	s.Run("pause job type", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			PauseJobType(context.TODO(), model.JobTypeMessageExport).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := pauseJobTypeCmdF(s.client, &cobra.Command{}, []string{model.JobTypeMessageExport})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Equal("Job type message_export paused", printer.GetLines()[0])
+	})
+
+	s.Run("resume job type", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			ResumeJobType(context.TODO(), model.JobTypeMessageExport).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := resumeJobTypeCmdF(s.client, &cobra.Command{}, []string{model.JobTypeMessageExport})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Equal("Job type message_export resumed", printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestJobLineageCmdF() {
+	s.Run("invalid job ID", func() {
+		printer.Clean()
+
+		err := jobLineageCmdF(s.client, &cobra.Command{}, []string{"invalid"})
+		s.Require().EqualError(err, "invalid job ID: invalid")
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("lineage found", func() {
+		printer.Clean()
+		rootId := model.NewId()
+		mockJobs := []*model.Job{
+			{
+				Id:       rootId,
+				Type:     model.JobTypeExportProcess,
+				Status:   model.JobStatusError,
+				CreateAt: model.GetMillis(),
+			},
+			{
+				Id:         model.NewId(),
+				Type:       model.JobTypeExportProcess,
+				Status:     model.JobStatusSuccess,
+				CreateAt:   model.GetMillis(),
+				ParentId:   rootId,
+				RootId:     rootId,
+				RetryCount: 1,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJobLineage(context.TODO(), rootId).
+			Return(mockJobs, &model.Response{}, nil).
+			Times(1)
+
+		err := jobLineageCmdF(s.client, &cobra.Command{}, []string{rootId})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), len(mockJobs))
+		s.Empty(printer.GetErrorLines())
+		for i, line := range printer.GetLines() {
+			s.Equal(mockJobs[i], line.(*model.Job))
+		}
+	})
+
+	s.Run("failed to get lineage", func() {
+		printer.Clean()
+		jobId := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetJobLineage(context.TODO(), jobId).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := jobLineageCmdF(s.client, &cobra.Command{}, []string{jobId})
+		s.Require().Error(err)
+		s.Empty(printer.GetLines())
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Management of reports",
+	Long:  "Generate reports, which are sent as a direct message to the requesting user once ready.",
+}
+
+var ReportUsersCmd = &cobra.Command{
+	Use:     "users",
+	Short:   "Generate a report of the users",
+	Long:    "Start generating a report of the users, with their activity over the date range.",
+	Example: "  report users --format xlsx --date-range last_30_days",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(reportUsersCmdF),
+}
+
+var ReportChannelMembershipCmd = &cobra.Command{
+	Use:     "channel-membership",
+	Short:   "Generate a report of the channel members",
+	Long:    "Start generating a report of the members of the public and private channels, of all teams or of the given team.",
+	Example: "  report channel-membership --format jsonl --team myteam",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(reportChannelMembershipCmdF),
+}
+
+var ReportTeamActivityCmd = &cobra.Command{
+	Use:     "team-activity",
+	Short:   "Generate a report of the team activity",
+	Long:    "Start generating a report of the members, channels and posts of the teams over the date range.",
+	Example: "  report team-activity --date-range last_6_months",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(reportTeamActivityCmdF),
+}
+
+func init() {
+	formatUsage := fmt.Sprintf("The format of the report, one of: %s.", strings.Join(model.ReportExportFormats, ", "))
+	dateRangeUsage := "The date range of the report, one of: all_time, last_30_days, previous_month, last_6_months."
+
+	ReportUsersCmd.Flags().String("format", model.ReportFormatCSV, formatUsage)
+	ReportUsersCmd.Flags().String("date-range", model.ReportDurationAllTime, dateRangeUsage)
+
+	ReportChannelMembershipCmd.Flags().String("format", model.ReportFormatCSV, formatUsage)
+	ReportChannelMembershipCmd.Flags().String("team", "", "The name or ID of the team whose channels are reported. All teams are reported if omitted.")
+
+	ReportTeamActivityCmd.Flags().String("format", model.ReportFormatCSV, formatUsage)
+	ReportTeamActivityCmd.Flags().String("date-range", model.ReportDurationAllTime, dateRangeUsage)
+
+	ReportCmd.AddCommand(
+		ReportUsersCmd,
+		ReportChannelMembershipCmd,
+		ReportTeamActivityCmd,
+	)
+
+	RootCmd.AddCommand(ReportCmd)
+}
+
+func getReportFormatFlag(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if !model.IsValidReportExportFormat(format) {
+		return "", fmt.Errorf("invalid format %q, must be one of: %s", format, strings.Join(model.ReportExportFormats, ", "))
+	}
+	return format, nil
+}
+
+func reportUsersCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	format, err := getReportFormatFlag(cmd)
+	if err != nil {
+		return err
+	}
+	dateRange, _ := cmd.Flags().GetString("date-range")
+
+	if _, err := c.StartUsersBatchExport(context.TODO(), format, dateRange); err != nil {
+		return errors.Wrap(err, "failed to start the users report")
+	}
+
+	printer.Print("The users report has been started. It will be sent to you as a direct message once ready.")
+	return nil
+}
+
+func reportChannelMembershipCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	format, err := getReportFormatFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	var teamID string
+	if teamArg, _ := cmd.Flags().GetString("team"); teamArg != "" {
+		team := getTeamFromTeamArg(c, teamArg)
+		if team == nil {
+			return fmt.Errorf("unable to find team %q", teamArg)
+		}
+		teamID = team.Id
+	}
+
+	if _, err := c.StartChannelMembershipReport(context.TODO(), format, teamID); err != nil {
+		return errors.Wrap(err, "failed to start the channel membership report")
+	}
+
+	printer.Print("The channel membership report has been started. It will be sent to you as a direct message once ready.")
+	return nil
+}
+
+func reportTeamActivityCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	format, err := getReportFormatFlag(cmd)
+	if err != nil {
+		return err
+	}
+	dateRange, _ := cmd.Flags().GetString("date-range")
+
+	if _, err := c.StartTeamActivityReport(context.TODO(), format, dateRange); err != nil {
+		return errors.Wrap(err, "failed to start the team activity report")
+	}
+
+	printer.Print("The team activity report has been started. It will be sent to you as a direct message once ready.")
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestReportUsersCmd() {
+	s.Run("Start users report", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "xlsx", "")
+		cmd.Flags().String("date-range", "last_30_days", "")
+
+		s.client.
+			EXPECT().
+			StartUsersBatchExport(context.TODO(), "xlsx", "last_30_days").
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := reportUsersCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Invalid format", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "pdf", "")
+		cmd.Flags().String("date-range", "all_time", "")
+
+		err := reportUsersCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Fail to start the report", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "csv", "")
+		cmd.Flags().String("date-range", "all_time", "")
+
+		s.client.
+			EXPECT().
+			StartUsersBatchExport(context.TODO(), "csv", "all_time").
+			Return(&model.Response{StatusCode: http.StatusBadRequest}, errors.New("mock error")).
+			Times(1)
+
+		err := reportUsersCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestReportChannelMembershipCmd() {
+	s.Run("Start report of all teams", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "jsonl", "")
+		cmd.Flags().String("team", "", "")
+
+		s.client.
+			EXPECT().
+			StartChannelMembershipReport(context.TODO(), "jsonl", "").
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := reportChannelMembershipCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Start report of a team", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "myteam"}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "csv", "")
+		cmd.Flags().String("team", team.Name, "")
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Name, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), team.Name, "").
+			Return(team, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			StartChannelMembershipReport(context.TODO(), "csv", team.Id).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := reportChannelMembershipCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Team not found", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "csv", "")
+		cmd.Flags().String("team", "missing", "")
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "missing", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "missing", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := reportChannelMembershipCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, `unable to find team "missing"`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestReportTeamActivityCmd() {
+	s.Run("Start team activity report", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "csv", "")
+		cmd.Flags().String("date-range", "last_6_months", "")
+
+		s.client.
+			EXPECT().
+			StartTeamActivityReport(context.TODO(), "csv", "last_6_months").
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := reportTeamActivityCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+}
@@ -86,6 +86,24 @@ var DeleteWebhookCmd = &cobra.Command{
 	RunE:    withClient(deleteWebhookCmdF),
 }
 
+var ListWebhookDeliveriesCmd = &cobra.Command{
+	Use:     "deliveries [webhookId]",
+	Short:   "List outgoing webhook deliveries",
+	Long:    "List the deliveries of the outgoing webhook specified by [webhookId], most recent first",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook deliveries w16zb5tu3n1zkqo18goqry1je --page 0 --per-page 20",
+	RunE:    withClient(listWebhookDeliveriesCmdF),
+}
+
+var ReplayWebhookDeliveryCmd = &cobra.Command{
+	Use:     "replay [webhookId] [deliveryId]",
+	Short:   "Replay an outgoing webhook delivery",
+	Long:    "Send the payload of the delivery specified by [deliveryId] of the outgoing webhook specified by [webhookId] again",
+	Args:    cobra.ExactArgs(2),
+	Example: "  webhook replay w16zb5tu3n1zkqo18goqry1je 4xp9fdt77pncbef59f4k1qe83o",
+	RunE:    withClient(replayWebhookDeliveryCmdF),
+}
+
 func listWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	var teams []*model.Team
 
@@ -425,6 +443,34 @@ func showWebhookCmdF(c client.Client, command *cobra.Command, args []string) err
 	return errors.New("Webhook with id '" + webhookID + "' not found")
 }
 
+func listWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+
+	deliveries, _, err := c.GetOutgoingWebhookDeliveries(context.TODO(), args[0], page, perPage)
+	if err != nil {
+		return errors.Wrap(err, "unable to get the deliveries of webhook '"+args[0]+"'")
+	}
+
+	for _, delivery := range deliveries {
+		printer.PrintT("{{.Id}}: {{.Status}} (attempts: {{.Attempts}}, status code: {{.StatusCode}}) {{.CallbackURL}}", delivery)
+	}
+
+	return nil
+}
+
+func replayWebhookDeliveryCmdF(c client.Client, command *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	delivery, _, err := c.ReplayOutgoingWebhookDelivery(context.TODO(), args[0], args[1])
+	if err != nil {
+		return errors.Wrap(err, "unable to replay delivery '"+args[1]+"'")
+	}
+
+	printer.PrintT("Delivery {{.Id}} successfully queued", delivery)
+	return nil
+}
+
 func init() {
 	CreateIncomingWebhookCmd.Flags().String("channel", "", "Channel ID (required)")
 	_ = CreateIncomingWebhookCmd.MarkFlagRequired("channel")
@@ -466,6 +512,9 @@ func init() {
 	ModifyOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL")
 	ModifyOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
 
+	ListWebhookDeliveriesCmd.Flags().Int("page", 0, "Page number to fetch for the list of deliveries")
+	ListWebhookDeliveriesCmd.Flags().Int("per-page", DefaultPageSize, "Number of deliveries to be fetched")
+
 	WebhookCmd.AddCommand(
 		ListWebhookCmd,
 		CreateIncomingWebhookCmd,
@@ -474,6 +523,8 @@ func init() {
 		ModifyOutgoingWebhookCmd,
 		DeleteWebhookCmd,
 		ShowWebhookCmd,
+		ListWebhookDeliveriesCmd,
+		ReplayWebhookDeliveryCmd,
 	)
 
 	RootCmd.AddCommand(WebhookCmd)
@@ -709,3 +709,84 @@ func (s *MmctlUnitTestSuite) TestShowWebhookCmd() {
 		s.Require().Equal("Webhook with id '"+nonExistentID+"' not found", err.Error())
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListWebhookDeliveriesCmd() {
+	hookID := model.NewId()
+
+	s.Run("Successfully list deliveries", func() {
+		printer.Clean()
+
+		deliveries := []*model.OutgoingWebhookDelivery{
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusFailed, Attempts: 6, StatusCode: 500},
+			{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusSuccess, Attempts: 1, StatusCode: 200},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 2, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, 1, 2).
+			Return(deliveries, &model.Response{}, nil).
+			Times(1)
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{hookID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(deliveries[0], printer.GetLines()[0])
+		s.Require().Equal(deliveries[1], printer.GetLines()[1])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Unable to list deliveries", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), hookID, 0, 0).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := listWebhookDeliveriesCmdF(s.client, &cobra.Command{}, []string{hookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestReplayWebhookDeliveryCmd() {
+	hookID := model.NewId()
+	deliveryID := model.NewId()
+
+	s.Run("Successfully replay a delivery", func() {
+		printer.Clean()
+
+		replay := &model.OutgoingWebhookDelivery{Id: model.NewId(), HookId: hookID, Status: model.OutgoingWebhookDeliveryStatusPending}
+
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), hookID, deliveryID).
+			Return(replay, &model.Response{}, nil).
+			Times(1)
+
+		err := replayWebhookDeliveryCmdF(s.client, &cobra.Command{}, []string{hookID, deliveryID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(replay, printer.GetLines()[0])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Unable to replay a delivery", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), hookID, deliveryID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := replayWebhookDeliveryCmdF(s.client, &cobra.Command{}, []string{hookID, deliveryID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
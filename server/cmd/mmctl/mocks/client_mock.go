@@ -166,6 +166,22 @@ func (mr *MockClientMockRecorder) ConvertUserToBot(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertUserToBot", reflect.TypeOf((*MockClient)(nil).ConvertUserToBot), arg0, arg1)
 }
 
+// CreateBleveReindexJob mocks base method.
+func (m *MockClient) CreateBleveReindexJob(arg0 context.Context, arg1 *model.BleveReindexRequest) (*model.Job, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBleveReindexJob", arg0, arg1)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBleveReindexJob indicates an expected call of CreateBleveReindexJob.
+func (mr *MockClientMockRecorder) CreateBleveReindexJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBleveReindexJob", reflect.TypeOf((*MockClient)(nil).CreateBleveReindexJob), arg0, arg1)
+}
+
 // CreateBot mocks base method.
 func (m *MockClient) CreateBot(arg0 context.Context, arg1 *model.Bot) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -509,6 +525,22 @@ func (mr *MockClientMockRecorder) DownloadExport(arg0, arg1, arg2, arg3 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadExport", reflect.TypeOf((*MockClient)(nil).DownloadExport), arg0, arg1, arg2, arg3)
 }
 
+// DownloadJob mocks base method.
+func (m *MockClient) DownloadJob(arg0 context.Context, arg1 string) ([]byte, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadJob", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadJob indicates an expected call of DownloadJob.
+func (mr *MockClientMockRecorder) DownloadJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadJob", reflect.TypeOf((*MockClient)(nil).DownloadJob), arg0, arg1)
+}
+
 // EnableBot mocks base method.
 func (m *MockClient) EnableBot(arg0 context.Context, arg1 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -588,6 +620,70 @@ func (mr *MockClientMockRecorder) GetAllTeams(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockClient)(nil).GetAllTeams), arg0, arg1, arg2, arg3)
 }
 
+// GetAuditCheckpoints mocks base method.
+func (m *MockClient) GetAuditCheckpoints(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.AuditCheckpoint, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditCheckpoints", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.AuditCheckpoint)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAuditCheckpoints indicates an expected call of GetAuditCheckpoints.
+func (mr *MockClientMockRecorder) GetAuditCheckpoints(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditCheckpoints", reflect.TypeOf((*MockClient)(nil).GetAuditCheckpoints), arg0, arg1, arg2, arg3)
+}
+
+// GetAuditRecords mocks base method.
+func (m *MockClient) GetAuditRecords(arg0 context.Context, arg1 *model.AuditRecordFilter) ([]*model.AuditRecord, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditRecords", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuditRecord)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAuditRecords indicates an expected call of GetAuditRecords.
+func (mr *MockClientMockRecorder) GetAuditRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecords", reflect.TypeOf((*MockClient)(nil).GetAuditRecords), arg0, arg1)
+}
+
+// GetAuditSigningKey mocks base method.
+func (m *MockClient) GetAuditSigningKey(arg0 context.Context) (*model.AuditSigningKey, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditSigningKey", arg0)
+	ret0, _ := ret[0].(*model.AuditSigningKey)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAuditSigningKey indicates an expected call of GetAuditSigningKey.
+func (mr *MockClientMockRecorder) GetAuditSigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditSigningKey", reflect.TypeOf((*MockClient)(nil).GetAuditSigningKey), arg0)
+}
+
+// GetBleveIndexStats mocks base method.
+func (m *MockClient) GetBleveIndexStats(arg0 context.Context) ([]*model.BleveIndexStats, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBleveIndexStats", arg0)
+	ret0, _ := ret[0].([]*model.BleveIndexStats)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBleveIndexStats indicates an expected call of GetBleveIndexStats.
+func (mr *MockClientMockRecorder) GetBleveIndexStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBleveIndexStats", reflect.TypeOf((*MockClient)(nil).GetBleveIndexStats), arg0)
+}
+
 // GetBots mocks base method.
 func (m *MockClient) GetBots(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -862,6 +958,38 @@ func (mr *MockClientMockRecorder) GetJob(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockClient)(nil).GetJob), arg0, arg1)
 }
 
+// GetJobLineage mocks base method.
+func (m *MockClient) GetJobLineage(arg0 context.Context, arg1 string) ([]*model.Job, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobLineage", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Job)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJobLineage indicates an expected call of GetJobLineage.
+func (mr *MockClientMockRecorder) GetJobLineage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobLineage", reflect.TypeOf((*MockClient)(nil).GetJobLineage), arg0, arg1)
+}
+
+// GetJobSchedules mocks base method.
+func (m *MockClient) GetJobSchedules(arg0 context.Context) ([]*model.JobSchedule, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobSchedules", arg0)
+	ret0, _ := ret[0].([]*model.JobSchedule)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJobSchedules indicates an expected call of GetJobSchedules.
+func (mr *MockClientMockRecorder) GetJobSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobSchedules", reflect.TypeOf((*MockClient)(nil).GetJobSchedules), arg0)
+}
+
 // GetJobs mocks base method.
 func (m *MockClient) GetJobs(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]*model.Job, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -990,6 +1118,22 @@ func (mr *MockClientMockRecorder) GetOutgoingWebhook(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhook), arg0, arg1)
 }
 
+// GetOutgoingWebhookDeliveries mocks base method.
+func (m *MockClient) GetOutgoingWebhookDeliveries(arg0 context.Context, arg1 string, arg2 int, arg3 int) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOutgoingWebhookDeliveries indicates an expected call of GetOutgoingWebhookDeliveries.
+func (mr *MockClientMockRecorder) GetOutgoingWebhookDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhookDeliveries), arg0, arg1, arg2, arg3)
+}
+
 // GetOutgoingWebhooks mocks base method.
 func (m *MockClient) GetOutgoingWebhooks(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1720,6 +1864,21 @@ func (mr *MockClientMockRecorder) PatchTeam(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTeam", reflect.TypeOf((*MockClient)(nil).PatchTeam), arg0, arg1, arg2)
 }
 
+// PauseJobType mocks base method.
+func (m *MockClient) PauseJobType(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseJobType", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseJobType indicates an expected call of PauseJobType.
+func (mr *MockClientMockRecorder) PauseJobType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseJobType", reflect.TypeOf((*MockClient)(nil).PauseJobType), arg0, arg1)
+}
+
 // PermanentDeleteAllUsers mocks base method.
 func (m *MockClient) PermanentDeleteAllUsers(arg0 context.Context) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1795,6 +1954,36 @@ func (mr *MockClientMockRecorder) PromoteGuestToUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteGuestToUser", reflect.TypeOf((*MockClient)(nil).PromoteGuestToUser), arg0, arg1)
 }
 
+// PurgeBleveIndexList mocks base method.
+func (m *MockClient) PurgeBleveIndexList(arg0 context.Context, arg1 []string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBleveIndexList", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeBleveIndexList indicates an expected call of PurgeBleveIndexList.
+func (mr *MockClientMockRecorder) PurgeBleveIndexList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBleveIndexList", reflect.TypeOf((*MockClient)(nil).PurgeBleveIndexList), arg0, arg1)
+}
+
+// PurgeBleveIndexes mocks base method.
+func (m *MockClient) PurgeBleveIndexes(arg0 context.Context) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBleveIndexes", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeBleveIndexes indicates an expected call of PurgeBleveIndexes.
+func (mr *MockClientMockRecorder) PurgeBleveIndexes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBleveIndexes", reflect.TypeOf((*MockClient)(nil).PurgeBleveIndexes), arg0)
+}
+
 // RegenOutgoingHookToken mocks base method.
 func (m *MockClient) RegenOutgoingHookToken(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1886,6 +2075,22 @@ func (mr *MockClientMockRecorder) RemoveUserFromChannel(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromChannel", reflect.TypeOf((*MockClient)(nil).RemoveUserFromChannel), arg0, arg1, arg2)
 }
 
+// ReplayOutgoingWebhookDelivery mocks base method.
+func (m *MockClient) ReplayOutgoingWebhookDelivery(arg0 context.Context, arg1 string, arg2 string) (*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayOutgoingWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReplayOutgoingWebhookDelivery indicates an expected call of ReplayOutgoingWebhookDelivery.
+func (mr *MockClientMockRecorder) ReplayOutgoingWebhookDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayOutgoingWebhookDelivery", reflect.TypeOf((*MockClient)(nil).ReplayOutgoingWebhookDelivery), arg0, arg1, arg2)
+}
+
 // ResetSamlAuthDataToEmail mocks base method.
 func (m *MockClient) ResetSamlAuthDataToEmail(arg0 context.Context, arg1, arg2 bool, arg3 []string) (int64, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1950,6 +2155,21 @@ func (mr *MockClientMockRecorder) RestoreTeam(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTeam", reflect.TypeOf((*MockClient)(nil).RestoreTeam), arg0, arg1)
 }
 
+// ResumeJobType mocks base method.
+func (m *MockClient) ResumeJobType(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeJobType", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeJobType indicates an expected call of ResumeJobType.
+func (mr *MockClientMockRecorder) ResumeJobType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeJobType", reflect.TypeOf((*MockClient)(nil).ResumeJobType), arg0, arg1)
+}
+
 // RevokeUserAccessToken mocks base method.
 func (m *MockClient) RevokeUserAccessToken(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2026,6 +2246,51 @@ func (mr *MockClientMockRecorder) SoftDeleteTeam(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteTeam", reflect.TypeOf((*MockClient)(nil).SoftDeleteTeam), arg0, arg1)
 }
 
+// StartChannelMembershipReport mocks base method.
+func (m *MockClient) StartChannelMembershipReport(arg0 context.Context, arg1 string, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartChannelMembershipReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartChannelMembershipReport indicates an expected call of StartChannelMembershipReport.
+func (mr *MockClientMockRecorder) StartChannelMembershipReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartChannelMembershipReport", reflect.TypeOf((*MockClient)(nil).StartChannelMembershipReport), arg0, arg1, arg2)
+}
+
+// StartTeamActivityReport mocks base method.
+func (m *MockClient) StartTeamActivityReport(arg0 context.Context, arg1 string, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTeamActivityReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTeamActivityReport indicates an expected call of StartTeamActivityReport.
+func (mr *MockClientMockRecorder) StartTeamActivityReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTeamActivityReport", reflect.TypeOf((*MockClient)(nil).StartTeamActivityReport), arg0, arg1, arg2)
+}
+
+// StartUsersBatchExport mocks base method.
+func (m *MockClient) StartUsersBatchExport(arg0 context.Context, arg1 string, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartUsersBatchExport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartUsersBatchExport indicates an expected call of StartUsersBatchExport.
+func (mr *MockClientMockRecorder) StartUsersBatchExport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartUsersBatchExport", reflect.TypeOf((*MockClient)(nil).StartUsersBatchExport), arg0, arg1, arg2)
+}
+
 // SyncLdap mocks base method.
 func (m *MockClient) SyncLdap(arg0 context.Context, arg1 bool) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2337,6 +2602,22 @@ func (mr *MockClientMockRecorder) UploadPluginForced(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPluginForced", reflect.TypeOf((*MockClient)(nil).UploadPluginForced), arg0, arg1)
 }
 
+// VerifyBleveIndex mocks base method.
+func (m *MockClient) VerifyBleveIndex(arg0 context.Context, arg1 string) (*model.BleveIndexStats, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyBleveIndex", arg0, arg1)
+	ret0, _ := ret[0].(*model.BleveIndexStats)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyBleveIndex indicates an expected call of VerifyBleveIndex.
+func (mr *MockClientMockRecorder) VerifyBleveIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBleveIndex", reflect.TypeOf((*MockClient)(nil).VerifyBleveIndex), arg0, arg1)
+}
+
 // VerifyUserEmailWithoutToken mocks base method.
 func (m *MockClient) VerifyUserEmailWithoutToken(arg0 context.Context, arg1 string) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -40,6 +40,11 @@ var configSensitivePaths = map[string]bool{
 	"LdapSettings.BindPassword":                              true,
 	"FileSettings.PublicLinkSalt":                            true,
 	"FileSettings.AmazonS3SecretAccessKey":                   true,
+	"FileSettings.AzureAccessKey":                            true,
+	"FileSettings.ArchiveAmazonS3SecretAccessKey":            true,
+	"FileSettings.EncryptionAtRestKey":                       true,
+	"FileSettings.EncryptionAtRestRetiredKeys":               true,
+	"CacheSettings.RedisPassword":                            true,
 	"SqlSettings.DataSource":                                 true,
 	"SqlSettings.AtRestEncryptKey":                           true,
 	"SqlSettings.DataSourceReplicas":                         true,
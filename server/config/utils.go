@@ -33,6 +33,26 @@ func desanitize(actual, target *model.Config) {
 	if *target.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		target.FileSettings.AmazonS3SecretAccessKey = actual.FileSettings.AmazonS3SecretAccessKey
 	}
+	if target.FileSettings.AzureAccessKey != nil && *target.FileSettings.AzureAccessKey == model.FakeSetting {
+		target.FileSettings.AzureAccessKey = actual.FileSettings.AzureAccessKey
+	}
+	if target.FileSettings.ArchiveAmazonS3SecretAccessKey != nil && *target.FileSettings.ArchiveAmazonS3SecretAccessKey == model.FakeSetting {
+		target.FileSettings.ArchiveAmazonS3SecretAccessKey = actual.FileSettings.ArchiveAmazonS3SecretAccessKey
+	}
+	if target.FileSettings.EncryptionAtRestKey != nil && *target.FileSettings.EncryptionAtRestKey == model.FakeSetting {
+		target.FileSettings.EncryptionAtRestKey = actual.FileSettings.EncryptionAtRestKey
+	}
+	if len(target.FileSettings.EncryptionAtRestRetiredKeys) == len(actual.FileSettings.EncryptionAtRestRetiredKeys) {
+		for i, value := range target.FileSettings.EncryptionAtRestRetiredKeys {
+			if value == model.FakeSetting {
+				target.FileSettings.EncryptionAtRestRetiredKeys[i] = actual.FileSettings.EncryptionAtRestRetiredKeys[i]
+			}
+		}
+	}
+
+	if target.CacheSettings.RedisPassword != nil && *target.CacheSettings.RedisPassword == model.FakeSetting {
+		target.CacheSettings.RedisPassword = actual.CacheSettings.RedisPassword
+	}
 
 	if *target.EmailSettings.SMTPPassword == model.FakeSetting {
 		target.EmailSettings.SMTPPassword = actual.EmailSettings.SMTPPassword
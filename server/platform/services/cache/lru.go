@@ -143,15 +143,7 @@ func (l *LRU) set(key string, value any, ttl time.Duration) error {
 		expires = time.Now().Add(ttl)
 	}
 
-	var buf []byte
-	var err error
-	// We use a fast path for hot structs.
-	if msgpVal, ok := value.(msgp.Marshaler); ok {
-		buf, err = msgpVal.MarshalMsg(nil)
-	} else {
-		// Slow path for other structs.
-		buf, err = msgpack.Marshal(value)
-	}
+	buf, err := encode(value)
 	if err != nil {
 		return err
 	}
@@ -190,6 +182,48 @@ func (l *LRU) get(key string, value any) error {
 		return err
 	}
 
+	return decode(val, value)
+}
+
+func (l *LRU) getItem(key string) ([]byte, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	ent, ok := l.items[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	e := ent.Value.(*entry)
+	if e.generation != l.currentGeneration || (!e.expires.IsZero() && time.Now().After(e.expires)) {
+		l.removeElement(ent)
+		return nil, ErrKeyNotFound
+	}
+	l.evictList.MoveToFront(ent)
+	return e.value, nil
+}
+
+func (l *LRU) removeElement(e *list.Element) {
+	l.evictList.Remove(e)
+	kv := e.Value.(*entry)
+	if kv.generation == l.currentGeneration {
+		l.len--
+	}
+	delete(l.items, kv.key)
+}
+
+// encode serializes value the way it's stored by the caches.
+func encode(value any) ([]byte, error) {
+	// We use a fast path for hot structs.
+	if msgpVal, ok := value.(msgp.Marshaler); ok {
+		return msgpVal.MarshalMsg(nil)
+	}
+
+	// Slow path for other structs.
+	return msgpack.Marshal(value)
+}
+
+// decode deserializes val, as returned by encode, into value.
+func decode(val []byte, value any) error {
 	// We use a fast path for hot structs.
 	if msgpVal, ok := value.(msgp.Unmarshaler); ok {
 		_, err := msgpVal.UnmarshalMsg(val)
@@ -220,29 +254,3 @@ func (l *LRU) get(key string, value any) error {
 	// Slow path for other structs.
 	return msgpack.Unmarshal(val, value)
 }
-
-func (l *LRU) getItem(key string) ([]byte, error) {
-	l.lock.Lock()
-	defer l.lock.Unlock()
-
-	ent, ok := l.items[key]
-	if !ok {
-		return nil, ErrKeyNotFound
-	}
-	e := ent.Value.(*entry)
-	if e.generation != l.currentGeneration || (!e.expires.IsZero() && time.Now().After(e.expires)) {
-		l.removeElement(ent)
-		return nil, ErrKeyNotFound
-	}
-	l.evictList.MoveToFront(ent)
-	return e.value, nil
-}
-
-func (l *LRU) removeElement(e *list.Element) {
-	l.evictList.Remove(e)
-	kv := e.Value.(*entry)
-	if kv.generation == l.currentGeneration {
-		l.len--
-	}
-	delete(l.items, kv.key)
-}
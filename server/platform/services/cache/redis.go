@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// scanCount is the number of keys requested per SCAN iteration.
+const scanCount = 1000
+
+// RedisOptions contains options for connecting to a Redis server.
+type RedisOptions struct {
+	Address  string
+	Password string
+	DB       int
+}
+
+type redisProvider struct {
+	client *redis.Client
+}
+
+// NewRedisProvider creates a new Provider storing the caches in a Redis server,
+// or any server speaking the same protocol. The caches are shared by all the nodes
+// connected to the same server, so that an invalidation made by one node is seen
+// by all of them.
+func NewRedisProvider(opts RedisOptions) Provider {
+	return &redisProvider{
+		client: redis.NewClient(&redis.Options{
+			Addr:     opts.Address,
+			Password: opts.Password,
+			DB:       opts.DB,
+		}),
+	}
+}
+
+// NewCache creates a new cache with given opts. Caches without a name can't be
+// told apart in the server, so they are kept in memory instead.
+func (r *redisProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	if opts.Name == "" {
+		return NewProvider().NewCache(opts)
+	}
+
+	return &Redis{
+		client:                 r.client,
+		name:                   opts.Name,
+		prefix:                 opts.Name + ":",
+		defaultExpiry:          opts.DefaultExpiry,
+		invalidateClusterEvent: opts.InvalidateClusterEvent,
+	}, nil
+}
+
+// Connect checks that the Redis server can be reached.
+func (r *redisProvider) Connect() error {
+	return r.client.Ping(context.Background()).Err()
+}
+
+// Close releases the connections to the Redis server.
+func (r *redisProvider) Close() error {
+	return r.client.Close()
+}
+
+// Redis is a cache stored in a Redis server. Its keys are prefixed with the
+// name of the cache, and their eviction is left to the server, so the size of
+// the cache isn't enforced.
+type Redis struct {
+	client                 *redis.Client
+	name                   string
+	prefix                 string
+	defaultExpiry          time.Duration
+	invalidateClusterEvent model.ClusterEvent
+}
+
+// Purge is used to completely clear the cache.
+func (r *Redis) Purge() error {
+	ctx := context.Background()
+	return r.scan(ctx, func(keys []string) error {
+		return r.client.Unlink(ctx, keys...).Err()
+	})
+}
+
+// Set adds the given key and value to the store without an expiry. If the key already exists,
+// it will overwrite the previous value.
+func (r *Redis) Set(key string, value any) error {
+	return r.SetWithExpiry(key, value, 0)
+}
+
+// SetWithDefaultExpiry adds the given key and value to the store with the default expiry. If
+// the key already exists, it will overwrite the previous value
+func (r *Redis) SetWithDefaultExpiry(key string, value any) error {
+	return r.SetWithExpiry(key, value, r.defaultExpiry)
+}
+
+// SetWithExpiry adds the given key and value to the cache with the given expiry. If the key
+// already exists, it will overwrite the previous value
+func (r *Redis) SetWithExpiry(key string, value any, ttl time.Duration) error {
+	buf, err := encode(value)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(context.Background(), r.prefix+key, buf, ttl).Err()
+}
+
+// Get the content stored in the cache for the given key, and decode it into the value interface.
+// return ErrKeyNotFound if the key is missing from the cache
+func (r *Redis) Get(key string, value any) error {
+	val, err := r.client.Get(context.Background(), r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrKeyNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return decode(val, value)
+}
+
+// Remove deletes the value for a key.
+func (r *Redis) Remove(key string) error {
+	return r.client.Del(context.Background(), r.prefix+key).Err()
+}
+
+// Keys returns a slice of the keys in the cache.
+func (r *Redis) Keys() ([]string, error) {
+	var keys []string
+	err := r.scan(context.Background(), func(batch []string) error {
+		for _, key := range batch {
+			keys = append(keys, strings.TrimPrefix(key, r.prefix))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetInvalidateClusterEvent returns the cluster event configured when this cache was created.
+func (r *Redis) GetInvalidateClusterEvent() model.ClusterEvent {
+	return r.invalidateClusterEvent
+}
+
+// Name returns the name of the cache
+func (r *Redis) Name() string {
+	return r.name
+}
+
+// scan calls fn with the keys of the cache, one batch at a time.
+func (r *Redis) scan(ctx context.Context, fn func(keys []string) error) error {
+	match := escapePattern(r.prefix) + "*"
+
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, match, scanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// escapePattern escapes the characters having a special meaning in the
+// patterns matched by SCAN.
+func escapePattern(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"net"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestRedisProvider(t *testing.T) Provider {
+	redisHost := os.Getenv("CI_REDIS_HOST")
+	if redisHost == "" {
+		t.Skip("CI_REDIS_HOST is not set, skipping the Redis tests")
+	}
+
+	redisPort := os.Getenv("CI_REDIS_PORT")
+	if redisPort == "" {
+		redisPort = "6379"
+	}
+
+	p := NewRedisProvider(RedisOptions{
+		Address: net.JoinHostPort(redisHost, redisPort),
+	})
+	require.NoError(t, p.Connect())
+	t.Cleanup(func() {
+		require.NoError(t, p.Close())
+	})
+	return p
+}
+
+func TestRedis(t *testing.T) {
+	p := newTestRedisProvider(t)
+
+	c, err := p.NewCache(&CacheOptions{
+		Name:                   "test" + model.NewId(),
+		DefaultExpiry:          time.Minute,
+		InvalidateClusterEvent: model.ClusterEvent("clusterEvent"),
+	})
+	require.NoError(t, err)
+	defer c.Purge()
+
+	assert.Equal(t, model.ClusterEvent("clusterEvent"), c.GetInvalidateClusterEvent())
+
+	var s string
+	require.Equal(t, ErrKeyNotFound, c.Get("key1", &s))
+
+	require.NoError(t, c.Set("key1", "val1"))
+	require.NoError(t, c.SetWithDefaultExpiry("key2", "val2"))
+	require.NoError(t, c.SetWithExpiry("key3", "val3", 50*time.Millisecond))
+
+	require.NoError(t, c.Get("key1", &s))
+	assert.Equal(t, "val1", s)
+
+	keys, err := c.Keys()
+	require.NoError(t, err)
+	sort.Strings(keys)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, ErrKeyNotFound, c.Get("key3", &s))
+
+	require.NoError(t, c.Remove("key1"))
+	require.Equal(t, ErrKeyNotFound, c.Get("key1", &s))
+
+	require.NoError(t, c.Purge())
+	keys, err = c.Keys()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestRedisSharedBetweenProviders(t *testing.T) {
+	p1 := newTestRedisProvider(t)
+	p2 := newTestRedisProvider(t)
+
+	opts := &CacheOptions{Name: "test" + model.NewId()}
+	c1, err := p1.NewCache(opts)
+	require.NoError(t, err)
+	defer c1.Purge()
+	c2, err := p2.NewCache(opts)
+	require.NoError(t, err)
+
+	u := &model.User{Id: model.NewId(), Username: "user"}
+	require.NoError(t, c1.Set(u.Id, u))
+
+	var cached *model.User
+	require.NoError(t, c2.Get(u.Id, &cached))
+	assert.Equal(t, u.Username, cached.Username)
+
+	// Invalidating the key on one node invalidates it on all of them.
+	require.NoError(t, c2.Remove(u.Id))
+	require.Equal(t, ErrKeyNotFound, c1.Get(u.Id, &cached))
+}
+
+func TestRedisUnnamedCache(t *testing.T) {
+	p := NewRedisProvider(RedisOptions{Address: "localhost:6379"})
+
+	c, err := p.NewCache(&CacheOptions{Size: 1})
+	require.NoError(t, err)
+	assert.IsType(t, &LRU{}, c)
+}
+
+func TestEscapePattern(t *testing.T) {
+	assert.Equal(t, "Name:", escapePattern("Name:"))
+	assert.Equal(t, `a\*b\?c\[d\]e\\`, escapePattern(`a*b?c[d]e\`))
+}
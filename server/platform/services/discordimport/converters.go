@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package discordimport
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var (
+	discordUserMentionRegexp    = regexp.MustCompile(`<@!?(\d+)>`)
+	discordChannelMentionRegexp = regexp.MustCompile(`<#(\d+)>`)
+	discordCustomEmojiRegexp    = regexp.MustCompile(`<a?:(\w+):\d+>`)
+	discordEveryoneRegexp       = regexp.MustCompile(`(^|\W)@everyone\b`)
+	invalidChannelNameRegexp    = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// convertMentions rewrites the user, channel and emoji references of a Discord message
+// with the syntax of Mattermost.
+func convertMentions(text string, usernames, channelNames map[string]string) string {
+	text = discordUserMentionRegexp.ReplaceAllStringFunc(text, func(mention string) string {
+		if username := usernames[discordUserMentionRegexp.FindStringSubmatch(mention)[1]]; username != "" {
+			return "@" + username
+		}
+		return mention
+	})
+
+	text = discordChannelMentionRegexp.ReplaceAllStringFunc(text, func(mention string) string {
+		if channelName := channelNames[discordChannelMentionRegexp.FindStringSubmatch(mention)[1]]; channelName != "" {
+			return "~" + channelName
+		}
+		return mention
+	})
+
+	text = discordCustomEmojiRegexp.ReplaceAllString(text, ":$1:")
+	text = discordEveryoneRegexp.ReplaceAllString(text, "$1@all")
+
+	return text
+}
+
+func convertNickname(user discordUser) *string {
+	if user.Nickname == "" || user.Nickname == user.Name {
+		return nil
+	}
+	return model.NewString(truncateRunes(user.Nickname, model.UserNicknameMaxRunes))
+}
+
+func convertChannelName(channelName string, channelId string) string {
+	name := invalidChannelNameRegexp.ReplaceAllString(strings.ToLower(channelName), "-")
+	name = strings.Trim(name, "_-")
+	if len(name) > model.ChannelNameMaxLength {
+		name = strings.Trim(name[:model.ChannelNameMaxLength], "_-")
+	}
+
+	if len(name) < 2 || !model.IsValidChannelIdentifier(name) {
+		return "discord-" + channelId
+	}
+	return name
+}
+
+func uniqueChannelName(name string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(name)+len(suffix) > model.ChannelNameMaxLength {
+		name = name[:model.ChannelNameMaxLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// convertEmojiName returns the name of the emoji of a reaction, or an empty string if
+// it can't be used in Mattermost.
+func convertEmojiName(emoji discordEmoji) string {
+	name := emoji.Code
+	if emoji.Id != "" || name == "" {
+		// Custom emojis keep their name, and have to be created separately.
+		name = emoji.Name
+	}
+	name = strings.ToLower(strings.Trim(name, ":"))
+
+	if emoji.Id == "" && !model.IsSystemEmojiName(name) {
+		return ""
+	}
+	if len(name) > model.EmojiNameMaxLength || !model.IsValidAlphaNumHyphenUnderscorePlus(name) {
+		return ""
+	}
+	return name
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes])
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,685 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package discordimport converts the JSON exports of DiscordChatExporter into bulk import archives.
+package discordimport
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	discordMessageTypeDefault = "Default"
+	discordMessageTypeReply   = "Reply"
+
+	discordChannelTypeDirect = "DirectTextChat"
+	discordChannelTypeGroup  = "DirectGroupTextChat"
+)
+
+type discordGuild struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type discordChannel struct {
+	Id         string `json:"id"`
+	Type       string `json:"type"`
+	CategoryId string `json:"categoryId"`
+	Category   string `json:"category"`
+	Name       string `json:"name"`
+	Topic      string `json:"topic"`
+}
+
+type discordUser struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Discriminator string `json:"discriminator"`
+	Nickname      string `json:"nickname"`
+	IsBot         bool   `json:"isBot"`
+}
+
+type discordAttachment struct {
+	Id            string `json:"id"`
+	Url           string `json:"url"`
+	FileName      string `json:"fileName"`
+	FileSizeBytes int64  `json:"fileSizeBytes"`
+}
+
+type discordEmoji struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type discordReaction struct {
+	Emoji discordEmoji  `json:"emoji"`
+	Count int           `json:"count"`
+	Users []discordUser `json:"users"`
+}
+
+type discordReference struct {
+	MessageId string `json:"messageId"`
+	ChannelId string `json:"channelId"`
+}
+
+type discordMessage struct {
+	Id              string              `json:"id"`
+	Type            string              `json:"type"`
+	Timestamp       time.Time           `json:"timestamp"`
+	TimestampEdited *time.Time          `json:"timestampEdited"`
+	IsPinned        bool                `json:"isPinned"`
+	Content         string              `json:"content"`
+	Author          discordUser         `json:"author"`
+	Attachments     []discordAttachment `json:"attachments"`
+	Reactions       []discordReaction   `json:"reactions"`
+	Mentions        []discordUser       `json:"mentions"`
+	Reference       *discordReference   `json:"reference"`
+}
+
+type discordExport struct {
+	Guild    discordGuild     `json:"guild"`
+	Channel  discordChannel   `json:"channel"`
+	Messages []discordMessage `json:"messages"`
+
+	// path is the path of the export in the archive, which its local attachments are relative to.
+	path string
+}
+
+func (e *discordExport) isThread() bool {
+	return strings.HasSuffix(e.Channel.Type, "Thread")
+}
+
+func (e *discordExport) isDirect() bool {
+	return e.Channel.Type == discordChannelTypeDirect || e.Channel.Type == discordChannelTypeGroup
+}
+
+// Options configures the conversion of a Discord export.
+type Options struct {
+	// TeamName is the name of the team the channels are imported in. Defaults to the name of the guild.
+	TeamName string
+	// UserEmails maps the Discord users, by ID or by name, to their email. The users without
+	// an email get a placeholder one.
+	UserEmails map[string]string
+	// Users resolves the Discord users against the users of the destination server. Optional.
+	Users imports.UserResolver
+}
+
+// thread is a post which messages can be replied to.
+type thread struct {
+	channelId string
+	replies   *[]imports.ReplyImportData
+}
+
+type converter struct {
+	logger mlog.LoggerIFace
+	opts   Options
+	files  map[string]*zip.File
+	out    *imports.ArchiveWriter
+
+	team        string
+	teamDisplay string
+	mapper      *imports.UserMapper
+	usernames   map[string]string
+	users       []*imports.UserImportData
+	userData    map[string]*imports.UserImportData
+	memberships map[string][]string
+
+	channelNames map[string]string
+	channels     []*imports.ChannelImportData
+
+	threads     map[string]*thread
+	posts       []*imports.PostImportData
+	directs     []*imports.DirectChannelImportData
+	directPosts []*imports.DirectPostImportData
+}
+
+// Convert reads the DiscordChatExporter JSON exports contained in the input archive, along
+// with their downloaded attachments, and writes the equivalent bulk import archive to output.
+func Convert(logger mlog.LoggerIFace, input *zip.Reader, output io.Writer, opts Options) error {
+	c := &converter{
+		logger:       logger,
+		opts:         opts,
+		files:        make(map[string]*zip.File, len(input.File)),
+		out:          imports.NewArchiveWriter(output),
+		mapper:       imports.NewUserMapper(logger, opts.Users),
+		usernames:    make(map[string]string),
+		userData:     make(map[string]*imports.UserImportData),
+		memberships:  make(map[string][]string),
+		channelNames: make(map[string]string),
+		threads:      make(map[string]*thread),
+	}
+
+	exports, err := c.readExports(input)
+	if err != nil {
+		return err
+	}
+	if len(exports) == 0 {
+		return fmt.Errorf("no Discord export found in the archive")
+	}
+
+	if err := c.convertUsers(exports); err != nil {
+		return err
+	}
+	c.convertTeam(exports)
+	c.convertChannels(exports)
+	for _, export := range exports {
+		if err := c.convertMessages(export); err != nil {
+			return err
+		}
+	}
+
+	if err := c.writeLines(); err != nil {
+		return err
+	}
+
+	return c.out.Close()
+}
+
+func (c *converter) readExports(input *zip.Reader) ([]*discordExport, error) {
+	var exports []*discordExport
+	for _, file := range input.File {
+		c.files[file.Name] = file
+		if file.FileInfo().IsDir() || path.Ext(file.Name) != ".json" {
+			continue
+		}
+
+		export, err := readExport(file)
+		if err != nil {
+			return nil, err
+		}
+		if export.Channel.Id == "" {
+			c.logger.Warn("Discord Import: Skipping a JSON file which isn't a channel export.", mlog.String("file", file.Name))
+			continue
+		}
+		exports = append(exports, export)
+	}
+
+	// Threads are converted last, as their messages are replies to the messages of other channels.
+	sort.SliceStable(exports, func(i, j int) bool {
+		if exports[i].isThread() != exports[j].isThread() {
+			return exports[j].isThread()
+		}
+		return exports[i].path < exports[j].path
+	})
+
+	return exports, nil
+}
+
+func readExport(file *zip.File) (*discordExport, error) {
+	r, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", file.Name, err)
+	}
+	defer r.Close()
+
+	var export discordExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", file.Name, err)
+	}
+	export.path = file.Name
+
+	return &export, nil
+}
+
+func (c *converter) convertUsers(exports []*discordExport) error {
+	for _, export := range exports {
+		for _, message := range export.Messages {
+			if err := c.convertUser(message.Author); err != nil {
+				return err
+			}
+			for _, user := range message.Mentions {
+				if err := c.convertUser(user); err != nil {
+					return err
+				}
+			}
+			for _, reaction := range message.Reactions {
+				for _, user := range reaction.Users {
+					if err := c.convertUser(user); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertUser(user discordUser) error {
+	if data := c.userData[user.Id]; data != nil {
+		// Only the messages of guild channels carry the nickname of their author.
+		if data.Nickname == nil {
+			data.Nickname = convertNickname(user)
+		}
+		return nil
+	}
+	if user.Id == "" || c.usernames[user.Id] != "" {
+		return nil
+	}
+
+	email := c.opts.UserEmails[user.Id]
+	if email == "" {
+		email = c.opts.UserEmails[user.Name]
+	}
+
+	username, err := c.mapper.Username(email, user.Name)
+	if err != nil {
+		return err
+	}
+	c.usernames[user.Id] = username
+
+	if c.mapper.Existing(username) {
+		c.logger.Info("Discord Import: Mapping to an existing user, who isn't added to the imported channels.", mlog.String("user_name", username))
+		return nil
+	}
+
+	if email == "" {
+		email = username + "@example.com"
+		c.logger.Warn("Discord Import: The user has no email, a placeholder one is used.", mlog.String("user_name", username), mlog.String("email", email))
+	}
+
+	data := &imports.UserImportData{
+		Username: model.NewString(username),
+		Email:    model.NewString(email),
+		Nickname: convertNickname(user),
+		Teams:    &[]imports.UserTeamImportData{},
+	}
+	c.users = append(c.users, data)
+	c.userData[user.Id] = data
+
+	return nil
+}
+
+func (c *converter) convertTeam(exports []*discordExport) {
+	for _, export := range exports {
+		if !export.isDirect() && export.Guild.Name != "" {
+			c.teamDisplay = truncateRunes(export.Guild.Name, model.TeamDisplayNameMaxRunes)
+			break
+		}
+	}
+
+	c.team = c.opts.TeamName
+	if c.team == "" && c.teamDisplay != "" {
+		c.team = model.CleanTeamName(c.teamDisplay)
+	} else if c.team == "" {
+		c.team = "discord"
+	}
+
+	if c.teamDisplay == "" {
+		c.teamDisplay = c.team
+	}
+}
+
+func (c *converter) convertChannels(exports []*discordExport) {
+	names := make(map[string]bool)
+	for _, export := range exports {
+		if export.isDirect() || export.isThread() {
+			continue
+		}
+
+		name := convertChannelName(export.Channel.Name, export.Channel.Id)
+		for i := 2; names[name]; i++ {
+			name = uniqueChannelName(convertChannelName(export.Channel.Name, export.Channel.Id), i)
+		}
+		names[name] = true
+		c.channelNames[export.Channel.Id] = name
+
+		displayName := export.Channel.Name
+		if displayName == "" {
+			displayName = name
+		}
+
+		channelType := model.ChannelTypeOpen
+		data := &imports.ChannelImportData{
+			Team:        model.NewString(c.team),
+			Name:        model.NewString(name),
+			DisplayName: model.NewString(truncateRunes(displayName, model.ChannelDisplayNameMaxRunes)),
+			Type:        &channelType,
+		}
+		if export.Channel.Topic != "" {
+			data.Header = model.NewString(truncateRunes(export.Channel.Topic, model.ChannelHeaderMaxRunes))
+		}
+		c.channels = append(c.channels, data)
+	}
+}
+
+func (c *converter) convertMessages(export *discordExport) error {
+	if export.isDirect() {
+		return c.convertDirectMessages(export)
+	}
+
+	channelName := c.channelNames[export.Channel.Id]
+	var threadRoot *thread
+	if export.isThread() {
+		threadRoot = c.threads[export.Channel.Id]
+		channelName = c.channelNames[export.Channel.CategoryId]
+		if channelName == "" {
+			c.logger.Warn("Discord Import: Skipping a thread whose channel wasn't exported.", mlog.String("thread", export.Channel.Name))
+			return nil
+		}
+	}
+
+	for _, message := range export.Messages {
+		if !isImportedMessage(message) {
+			continue
+		}
+
+		post, err := c.convertPost(export, message)
+		if err != nil {
+			return err
+		}
+		if post == nil {
+			continue
+		}
+		c.addMembership(post.User, channelName)
+
+		root := threadRoot
+		if root == nil {
+			root = c.replyThread(message, export.Channel.Id)
+		}
+		if root != nil {
+			*root.replies = append(*root.replies, replyFromPost(post))
+			c.threads[message.Id] = root
+			continue
+		}
+
+		data := &imports.PostImportData{
+			Team:        model.NewString(c.team),
+			Channel:     model.NewString(channelName),
+			User:        post.User,
+			Message:     post.Message,
+			CreateAt:    post.CreateAt,
+			EditAt:      post.EditAt,
+			Reactions:   post.Reactions,
+			Replies:     &[]imports.ReplyImportData{},
+			Attachments: post.Attachments,
+			IsPinned:    post.IsPinned,
+		}
+		c.posts = append(c.posts, data)
+		c.threads[message.Id] = &thread{channelId: export.Channel.Id, replies: data.Replies}
+
+		// Threads started from a message have the ID of that message. The other ones
+		// are rooted at their first message.
+		if threadRoot == nil && export.isThread() {
+			threadRoot = c.threads[message.Id]
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertDirectMessages(export *discordExport) error {
+	var members []string
+	for _, message := range export.Messages {
+		if username := c.usernames[message.Author.Id]; username != "" && !containsString(members, username) {
+			members = append(members, username)
+		}
+	}
+
+	if len(members) < 2 || len(members) > model.ChannelGroupMaxUsers {
+		c.logger.Warn("Discord Import: Skipping a direct conversation with an unsupported number of participants.", mlog.String("channel", export.Channel.Name), mlog.Int("participants", len(members)))
+		return nil
+	}
+	sort.Strings(members)
+	c.directs = append(c.directs, &imports.DirectChannelImportData{Members: &members})
+
+	for _, message := range export.Messages {
+		if !isImportedMessage(message) {
+			continue
+		}
+
+		post, err := c.convertPost(export, message)
+		if err != nil {
+			return err
+		}
+		if post == nil {
+			continue
+		}
+
+		if root := c.replyThread(message, export.Channel.Id); root != nil {
+			*root.replies = append(*root.replies, replyFromPost(post))
+			c.threads[message.Id] = root
+			continue
+		}
+
+		data := &imports.DirectPostImportData{
+			ChannelMembers: &members,
+			User:           post.User,
+			Message:        post.Message,
+			CreateAt:       post.CreateAt,
+			EditAt:         post.EditAt,
+			Reactions:      post.Reactions,
+			Replies:        &[]imports.ReplyImportData{},
+			Attachments:    post.Attachments,
+			IsPinned:       post.IsPinned,
+		}
+		c.directPosts = append(c.directPosts, data)
+		c.threads[message.Id] = &thread{channelId: export.Channel.Id, replies: data.Replies}
+	}
+
+	return nil
+}
+
+// replyThread returns the thread a message replies to, if it's in the same channel.
+func (c *converter) replyThread(message discordMessage, channelId string) *thread {
+	if message.Type != discordMessageTypeReply || message.Reference == nil {
+		return nil
+	}
+
+	root := c.threads[message.Reference.MessageId]
+	if root == nil || root.channelId != channelId {
+		return nil
+	}
+
+	return root
+}
+
+func isImportedMessage(message discordMessage) bool {
+	return message.Type == discordMessageTypeDefault || message.Type == discordMessageTypeReply
+}
+
+// convertPost converts the content of a message, returning nil if the message has nothing to import.
+func (c *converter) convertPost(export *discordExport, message discordMessage) (*imports.PostImportData, error) {
+	username := c.usernames[message.Author.Id]
+	if username == "" {
+		return nil, nil
+	}
+
+	text := convertMentions(message.Content, c.usernames, c.channelNames)
+	var attachments []imports.AttachmentImportData
+	for _, attachment := range message.Attachments {
+		data, err := c.convertAttachment(export, attachment)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			// The attachment wasn't downloaded with the export, so it's linked instead.
+			text = strings.TrimSpace(text + "\n" + attachment.Url)
+			continue
+		}
+		attachments = append(attachments, *data)
+	}
+
+	if text == "" && len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if len([]rune(text)) > model.PostMessageMaxRunesV2 {
+		c.logger.Warn("Discord Import: Truncating a message which is too long.", mlog.String("message_id", message.Id))
+		text = truncateRunes(text, model.PostMessageMaxRunesV2)
+	}
+
+	createAt := message.Timestamp.UnixMilli()
+	post := &imports.PostImportData{
+		User:      model.NewString(username),
+		Message:   model.NewString(text),
+		CreateAt:  model.NewInt64(createAt),
+		Reactions: c.convertReactions(message.Reactions, createAt),
+	}
+	if message.TimestampEdited != nil {
+		post.EditAt = model.NewInt64(message.TimestampEdited.UnixMilli())
+	}
+	if len(attachments) > 0 {
+		post.Attachments = &attachments
+	}
+	if message.IsPinned {
+		post.IsPinned = model.NewBool(true)
+	}
+
+	return post, nil
+}
+
+// convertAttachment copies an attachment downloaded with the export into the output
+// archive, returning nil if it wasn't downloaded.
+func (c *converter) convertAttachment(export *discordExport, attachment discordAttachment) (*imports.AttachmentImportData, error) {
+	if strings.HasPrefix(attachment.Url, "http://") || strings.HasPrefix(attachment.Url, "https://") {
+		return nil, nil
+	}
+
+	filePath := attachment.Url
+	if unescaped, err := url.PathUnescape(filePath); err == nil {
+		filePath = unescaped
+	}
+
+	file := c.files[path.Join(path.Dir(export.path), filePath)]
+	if file == nil {
+		c.logger.Warn("Discord Import: The attachment is missing from the archive.", mlog.String("path", filePath))
+		return nil, nil
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the attachment %q: %w", file.Name, err)
+	}
+	defer r.Close()
+
+	fileName := attachment.FileName
+	if fileName == "" {
+		fileName = path.Base(file.Name)
+	}
+
+	return c.out.AddAttachment(path.Join("discord", attachment.Id, fileName), r)
+}
+
+func (c *converter) convertReactions(reactions []discordReaction, createAt int64) *[]imports.ReactionImportData {
+	var data []imports.ReactionImportData
+	for _, reaction := range reactions {
+		emojiName := convertEmojiName(reaction.Emoji)
+		if emojiName == "" {
+			c.logger.Warn("Discord Import: Skipping a reaction with an unsupported emoji.", mlog.String("emoji", reaction.Emoji.Name))
+			continue
+		}
+
+		for _, user := range reaction.Users {
+			username := c.usernames[user.Id]
+			if username == "" {
+				continue
+			}
+			data = append(data, imports.ReactionImportData{
+				User:      model.NewString(username),
+				CreateAt:  model.NewInt64(createAt),
+				EmojiName: model.NewString(emojiName),
+			})
+		}
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	return &data
+}
+
+func (c *converter) addMembership(username *string, channelName string) {
+	if !containsString(c.memberships[*username], channelName) {
+		c.memberships[*username] = append(c.memberships[*username], channelName)
+	}
+}
+
+func replyFromPost(post *imports.PostImportData) imports.ReplyImportData {
+	return imports.ReplyImportData{
+		User:        post.User,
+		Message:     post.Message,
+		CreateAt:    post.CreateAt,
+		EditAt:      post.EditAt,
+		Reactions:   post.Reactions,
+		Attachments: post.Attachments,
+	}
+}
+
+func (c *converter) writeLines() error {
+	if err := c.out.WriteLine(&imports.LineImportData{Type: "version", Version: model.NewInt(1)}); err != nil {
+		return err
+	}
+
+	team := &imports.TeamImportData{
+		Name:        model.NewString(c.team),
+		DisplayName: model.NewString(c.teamDisplay),
+		Type:        model.NewString(model.TeamOpen),
+	}
+	if err := c.out.WriteLine(&imports.LineImportData{Type: "team", Team: team}); err != nil {
+		return err
+	}
+
+	for _, channel := range c.channels {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "channel", Channel: channel}); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range c.users {
+		channels := []imports.UserChannelImportData{}
+		for _, channelName := range c.memberships[*user.Username] {
+			channels = append(channels, imports.UserChannelImportData{
+				Name:  model.NewString(channelName),
+				Roles: model.NewString(model.ChannelUserRoleId),
+			})
+		}
+		*user.Teams = append(*user.Teams, imports.UserTeamImportData{
+			Name:     model.NewString(c.team),
+			Roles:    model.NewString(model.TeamUserRoleId),
+			Channels: &channels,
+		})
+
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "user", User: user}); err != nil {
+			return err
+		}
+	}
+
+	for _, direct := range c.directs {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "direct_channel", DirectChannel: direct}); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range c.posts {
+		if len(*post.Replies) == 0 {
+			post.Replies = nil
+		}
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "post", Post: post}); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range c.directPosts {
+		if len(*post.Replies) == 0 {
+			post.Replies = nil
+		}
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "direct_post", DirectPost: post}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package discordimport
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const testGeneralExport = `{
+  "guild": {"id": "100", "name": "Game Night"},
+  "channel": {"id": "200", "type": "GuildTextChat", "categoryId": "300", "category": "Text Channels", "name": "general", "topic": "Anything goes"},
+  "messages": [
+    {
+      "id": "1001", "type": "Default", "timestamp": "2023-01-02T10:00:00+00:00", "timestampEdited": null, "isPinned": true,
+      "content": "Welcome <@11>, see <#200> <:party:999> @everyone",
+      "author": {"id": "10", "name": "alice", "nickname": "Alice"},
+      "attachments": [{"id": "501", "url": "general_Files/rules-501.txt", "fileName": "rules.txt", "fileSizeBytes": 5}],
+      "reactions": [
+        {"emoji": {"id": "", "name": "👍", "code": "thumbsup"}, "count": 1, "users": [{"id": "11", "name": "bob"}]},
+        {"emoji": {"id": "", "name": "x", "code": "not_an_emoji"}, "count": 1, "users": [{"id": "10", "name": "alice"}]}
+      ],
+      "mentions": [{"id": "11", "name": "bob"}]
+    },
+    {
+      "id": "1002", "type": "Reply", "timestamp": "2023-01-02T10:05:00+00:00", "timestampEdited": "2023-01-02T10:06:00+00:00", "isPinned": false,
+      "content": "Thanks!", "author": {"id": "11", "name": "bob"},
+      "attachments": [{"id": "502", "url": "https://cdn.discordapp.com/attachments/502/photo.png", "fileName": "photo.png"}],
+      "reactions": [], "mentions": [], "reference": {"messageId": "1001", "channelId": "200"}
+    },
+    {
+      "id": "1003", "type": "ChannelPinnedMessage", "timestamp": "2023-01-02T10:07:00+00:00",
+      "content": "", "author": {"id": "10", "name": "alice"}, "attachments": [], "reactions": [], "mentions": []
+    }
+  ]
+}`
+
+const testThreadExport = `{
+  "guild": {"id": "100", "name": "Game Night"},
+  "channel": {"id": "1001", "type": "GuildPublicThread", "categoryId": "200", "category": "general", "name": "Welcome thread"},
+  "messages": [
+    {
+      "id": "1101", "type": "Default", "timestamp": "2023-01-02T11:00:00+00:00",
+      "content": "In the thread", "author": {"id": "12", "name": "carol"}, "attachments": [], "reactions": [], "mentions": []
+    }
+  ]
+}`
+
+const testDirectExport = `{
+  "guild": {"id": "0", "name": "Direct Messages"},
+  "channel": {"id": "400", "type": "DirectTextChat", "name": "bob"},
+  "messages": [
+    {
+      "id": "2001", "type": "Default", "timestamp": "2023-01-03T10:00:00+00:00",
+      "content": "Hi Bob", "author": {"id": "10", "name": "alice"}, "attachments": [], "reactions": [], "mentions": []
+    },
+    {
+      "id": "2002", "type": "Default", "timestamp": "2023-01-03T10:01:00+00:00",
+      "content": "Hi Alice", "author": {"id": "11", "name": "bob"}, "attachments": [], "reactions": [], "mentions": []
+    }
+  ]
+}`
+
+type testUserResolver map[string]string
+
+func (r testUserResolver) UsernameByEmail(email string) (string, error) {
+	return r[email], nil
+}
+
+func (r testUserResolver) UsernameExists(username string) (bool, error) {
+	for _, existing := range r {
+		if existing == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func makeTestArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	wr := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := wr.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, wr.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return r
+}
+
+func readTestLines(t *testing.T, output []byte) ([]imports.LineImportData, map[string]string) {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(output), int64(len(output)))
+	require.NoError(t, err)
+
+	var lines []imports.LineImportData
+	files := make(map[string]string)
+	for _, file := range r.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		if file.Name != "import.jsonl" {
+			b, err := io.ReadAll(f)
+			require.NoError(t, err)
+			files[file.Name] = string(b)
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var line imports.LineImportData
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.NoError(t, scanner.Err())
+	}
+
+	return lines, files
+}
+
+func validateTestLine(t *testing.T, line imports.LineImportData) {
+	t.Helper()
+
+	var appErr *model.AppError
+	switch line.Type {
+	case "team":
+		appErr = imports.ValidateTeamImportData(line.Team)
+	case "channel":
+		appErr = imports.ValidateChannelImportData(line.Channel)
+	case "user":
+		appErr = imports.ValidateUserImportData(line.User)
+	case "direct_channel":
+		appErr = imports.ValidateDirectChannelImportData(line.DirectChannel)
+	case "post":
+		appErr = imports.ValidatePostImportData(line.Post, model.PostMessageMaxRunesV2)
+	case "direct_post":
+		appErr = imports.ValidateDirectPostImportData(line.DirectPost, model.PostMessageMaxRunesV2)
+	}
+	require.Nil(t, appErr, "invalid %s line", line.Type)
+}
+
+func TestConvert(t *testing.T) {
+	input := makeTestArchive(t, map[string]string{
+		"general.json":                      testGeneralExport,
+		"general_Files/rules-501.txt":       "rules",
+		"thread.json":                       testThreadExport,
+		"direct.json":                       testDirectExport,
+		"general_Files/unrelated-file.json": `{"not": "an export"}`,
+	})
+
+	var output bytes.Buffer
+	err := Convert(mlog.CreateConsoleTestLogger(t), input, &output, Options{
+		UserEmails: map[string]string{"10": "alice@example.org", "bob": "bob@example.org"},
+		Users:      testUserResolver{"bob@example.org": "robert"},
+	})
+	require.NoError(t, err)
+
+	lines, files := readTestLines(t, output.Bytes())
+	var types []string
+	for _, line := range lines {
+		validateTestLine(t, line)
+		types = append(types, line.Type)
+	}
+	require.Equal(t, []string{"version", "team", "channel", "user", "user", "direct_channel", "post", "direct_post", "direct_post"}, types)
+	assert.Equal(t, map[string]string{"data/discord/501/rules.txt": "rules"}, files)
+
+	t.Run("team and channel", func(t *testing.T) {
+		assert.Equal(t, "game-night", *lines[1].Team.Name)
+		assert.Equal(t, "Game Night", *lines[1].Team.DisplayName)
+		assert.Equal(t, "general", *lines[2].Channel.Name)
+		assert.Equal(t, "Anything goes", *lines[2].Channel.Header)
+		assert.Equal(t, model.ChannelTypeOpen, *lines[2].Channel.Type)
+	})
+
+	t.Run("users", func(t *testing.T) {
+		// bob is an existing user, so only alice and carol are imported.
+		alice := lines[3].User
+		assert.Equal(t, "alice", *alice.Username)
+		assert.Equal(t, "alice@example.org", *alice.Email)
+		assert.Equal(t, "Alice", *alice.Nickname)
+		require.Len(t, *alice.Teams, 1)
+		assert.Equal(t, "general", *(*(*alice.Teams)[0].Channels)[0].Name)
+
+		carol := lines[4].User
+		assert.Equal(t, "carol", *carol.Username)
+		assert.Equal(t, "carol@example.com", *carol.Email)
+	})
+
+	t.Run("posts", func(t *testing.T) {
+		post := lines[6].Post
+		assert.Equal(t, "alice", *post.User)
+		assert.Equal(t, "Welcome @robert, see ~general :party: @all", *post.Message)
+		assert.Equal(t, int64(1672653600000), *post.CreateAt)
+		assert.True(t, *post.IsPinned)
+		require.Len(t, *post.Attachments, 1)
+		assert.Equal(t, "discord/501/rules.txt", *(*post.Attachments)[0].Path)
+		require.Len(t, *post.Reactions, 1)
+		assert.Equal(t, "robert", *(*post.Reactions)[0].User)
+		assert.Equal(t, "thumbsup", *(*post.Reactions)[0].EmojiName)
+
+		require.Len(t, *post.Replies, 2)
+		reply := (*post.Replies)[0]
+		assert.Equal(t, "robert", *reply.User)
+		assert.Equal(t, "Thanks!\nhttps://cdn.discordapp.com/attachments/502/photo.png", *reply.Message)
+		assert.Equal(t, int64(1672653960000), *reply.EditAt)
+		assert.Equal(t, "In the thread", *(*post.Replies)[1].Message)
+	})
+
+	t.Run("direct posts", func(t *testing.T) {
+		assert.Equal(t, []string{"alice", "robert"}, *lines[5].DirectChannel.Members)
+		assert.Equal(t, []string{"alice", "robert"}, *lines[7].DirectPost.ChannelMembers)
+		assert.Equal(t, "Hi Bob", *lines[7].DirectPost.Message)
+		assert.Equal(t, "robert", *lines[8].DirectPost.User)
+	})
+}
+
+func TestConvertWithoutExports(t *testing.T) {
+	input := makeTestArchive(t, map[string]string{"readme.txt": "nothing"})
+
+	var output bytes.Buffer
+	err := Convert(mlog.CreateConsoleTestLogger(t), input, &output, Options{})
+	require.Error(t, err)
+}
+
+func TestConvertChannelName(t *testing.T) {
+	for _, tc := range []struct {
+		nameInput string
+		idInput   string
+		output    string
+	}{
+		{"general", "1", "general"},
+		{"Off Topic 🎲", "2", "off-topic"},
+		{"--news--", "3", "news"},
+		{"a", "4", "discord-4"},
+		{"случайный", "5", "discord-5"},
+	} {
+		assert.Equal(t, tc.output, convertChannelName(tc.nameInput, tc.idInput), "nameInput = %v", tc.nameInput)
+	}
+}
+
+func TestConvertEmojiName(t *testing.T) {
+	assert.Equal(t, "thumbsup", convertEmojiName(discordEmoji{Name: "👍", Code: "thumbsup"}))
+	assert.Equal(t, "partyparrot", convertEmojiName(discordEmoji{Id: "1", Name: "PartyParrot", Code: "PartyParrot"}))
+	assert.Equal(t, "", convertEmojiName(discordEmoji{Name: "x", Code: "not_an_emoji"}))
+	assert.Equal(t, "", convertEmojiName(discordEmoji{Id: "1", Name: "bad name"}))
+}
@@ -4,7 +4,13 @@
 package docextractor
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -14,6 +20,26 @@ type ExtractSettings struct {
 	ArchiveRecursion bool
 	MMPreviewURL     string
 	MMPreviewSecret  string
+	// Limits are the limits of the files of the given extensions, e.g. "xlsx", which apply
+	// in place of DefaultLimits.
+	Limits        map[string]ExtractLimits
+	DefaultLimits ExtractLimits
+}
+
+// ExtractLimits bounds the extraction of the text of a file. Zero values disable a limit.
+type ExtractLimits struct {
+	// MaxFileSize is the size in bytes above which the text of a file isn't extracted.
+	MaxFileSize int64
+	// Timeout is the time after which the extraction is abandoned.
+	Timeout time.Duration
+}
+
+func (s ExtractSettings) limitsFor(filename string) ExtractLimits {
+	extension := strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+	if limits, ok := s.Limits[extension]; ok {
+		return limits
+	}
+	return s.DefaultLimits
 }
 
 // Extract extract the text from a document using the system default extractors
@@ -29,12 +55,17 @@ func ExtractWithExtraExtractors(logger mlog.LoggerIFace, filename string, r io.R
 	for _, extraExtractor := range extraExtractors {
 		enabledExtractors.Add(extraExtractor)
 	}
+	enabledExtractors.Add(&spreadsheetExtractor{})
+	enabledExtractors.Add(&presentationExtractor{})
+	enabledExtractors.Add(&epubExtractor{})
 	enabledExtractors.Add(&documentExtractor{})
 	enabledExtractors.Add(&pdfExtractor{})
 
 	if settings.ArchiveRecursion {
+		enabledExtractors.Add(&emailExtractor{SubExtractor: enabledExtractors})
 		enabledExtractors.Add(&archiveExtractor{SubExtractor: enabledExtractors})
 	} else {
+		enabledExtractors.Add(&emailExtractor{})
 		enabledExtractors.Add(&archiveExtractor{})
 	}
 
@@ -43,8 +74,84 @@ func ExtractWithExtraExtractors(logger mlog.LoggerIFace, filename string, r io.R
 	}
 	enabledExtractors.Add(&plainExtractor{})
 
-	if enabledExtractors.Match(filename) {
+	if !enabledExtractors.Match(filename) {
+		return "", nil
+	}
+
+	limits := settings.limitsFor(filename)
+	if limits.MaxFileSize > 0 {
+		size, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return "", fmt.Errorf("error getting the file size: %w", err)
+		}
+		if size > limits.MaxFileSize {
+			logger.Debug("Skipping the extraction of a file above the size limit", mlog.String("file_name", filename), mlog.Int("size", size), mlog.Int("max_file_size", limits.MaxFileSize))
+			return "", nil
+		}
+	}
+
+	if limits.Timeout <= 0 {
 		return enabledExtractors.Extract(filename, r)
 	}
-	return "", nil
+	return extractWithTimeout(enabledExtractors, filename, r, limits.Timeout)
+}
+
+// extractWithTimeout returns an error if the extraction takes longer than the timeout. The
+// reader fails once the timeout is reached, so that the extraction stops using it and the
+// caller may close the file as soon as this returns.
+func extractWithTimeout(extractor Extractor, filename string, r io.ReadSeeker, timeout time.Duration) (string, error) {
+	type result struct {
+		text string
+		err  error
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cr := &contextReader{ctx: ctx, r: r}
+	done := make(chan result, 1)
+	go func() {
+		text, err := extractor.Extract(filename, cr)
+		done <- result{text, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.text, res.err
+	case <-ctx.Done():
+		cr.wait()
+		return "", fmt.Errorf("extraction timed out after %s", timeout)
+	}
+}
+
+// contextReader is a reader failing once its context is done.
+type contextReader struct {
+	ctx context.Context
+	mut sync.Mutex
+	r   io.ReadSeeker
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	cr.mut.Lock()
+	defer cr.mut.Unlock()
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+func (cr *contextReader) Seek(offset int64, whence int) (int64, error) {
+	cr.mut.Lock()
+	defer cr.mut.Unlock()
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Seek(offset, whence)
+}
+
+// wait waits for the read in progress, if any, to complete. Once the context is done, the
+// underlying reader isn't used anymore after this returns.
+func (cr *contextReader) wait() {
+	cr.mut.Lock()
+	defer cr.mut.Unlock()
 }
@@ -4,11 +4,15 @@
 package docextractor
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -212,3 +216,130 @@ func TestExtractWithExtraExtractors(t *testing.T) {
 		assert.Contains(t, text, "contains")
 	})
 }
+
+type slowExtractor struct{}
+
+func (te *slowExtractor) Name() string {
+	return "slowExtractor"
+}
+
+func (te *slowExtractor) Match(filename string) bool {
+	return true
+}
+
+func (te *slowExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	time.Sleep(time.Second)
+	return "this is a slowly generated content", nil
+}
+
+// readingExtractor keeps reading the file until it fails.
+type readingExtractor struct {
+	done chan error
+}
+
+func (te *readingExtractor) Name() string {
+	return "readingExtractor"
+}
+
+func (te *readingExtractor) Match(filename string) bool {
+	return true
+}
+
+func (te *readingExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	buf := make([]byte, 1)
+	for {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			te.done <- err
+			return "", err
+		}
+		if _, err := r.Read(buf); err != nil {
+			te.done <- err
+			return "", err
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type countingReader struct {
+	io.ReadSeeker
+	reads atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads.Add(1)
+	return r.ReadSeeker.Read(p)
+}
+
+func TestExtractLimits(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	data := []byte("this is a plain text file")
+
+	t.Run("file above the default size limit", func(t *testing.T) {
+		text, err := Extract(logger, "test.txt", bytes.NewReader(data), ExtractSettings{
+			DefaultLimits: ExtractLimits{MaxFileSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, text)
+	})
+
+	t.Run("file below the size limit of its extension", func(t *testing.T) {
+		text, err := Extract(logger, "test.TXT", bytes.NewReader(data), ExtractSettings{
+			Limits:        map[string]ExtractLimits{"txt": {MaxFileSize: 100}},
+			DefaultLimits: ExtractLimits{MaxFileSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "this is a plain text file", text)
+	})
+
+	t.Run("extraction timing out", func(t *testing.T) {
+		text, err := ExtractWithExtraExtractors(logger, "test.txt", bytes.NewReader(data), ExtractSettings{
+			DefaultLimits: ExtractLimits{Timeout: 10 * time.Millisecond},
+		}, []Extractor{&slowExtractor{}})
+		require.EqualError(t, err, "extraction timed out after 10ms")
+		assert.Empty(t, text)
+	})
+
+	t.Run("reader failing once timed out", func(t *testing.T) {
+		r := &countingReader{ReadSeeker: bytes.NewReader(data)}
+		extractor := &readingExtractor{done: make(chan error, 1)}
+		_, err := ExtractWithExtraExtractors(logger, "test.txt", r, ExtractSettings{
+			DefaultLimits: ExtractLimits{Timeout: 10 * time.Millisecond},
+		}, []Extractor{extractor})
+		require.EqualError(t, err, "extraction timed out after 10ms")
+		reads := r.reads.Load()
+
+		select {
+		case err := <-extractor.done:
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the extraction should have stopped")
+		}
+		assert.Equal(t, reads, r.reads.Load(), "the file shouldn't be read after the timeout")
+	})
+
+	t.Run("extraction within the timeout", func(t *testing.T) {
+		text, err := Extract(logger, "test.txt", bytes.NewReader(data), ExtractSettings{
+			DefaultLimits: ExtractLimits{Timeout: time.Minute},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "this is a plain text file", text)
+	})
+}
+
+// newTestZip returns a zip archive of the given files, as pairs of names and contents.
+func newTestZip(t *testing.T, files ...string) []byte {
+	t.Helper()
+	require.Zero(t, len(files)%2)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		w, err := zw.Create(files[i])
+		require.NoError(t, err)
+		_, err = w.Write([]byte(files[i+1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"path"
+	"strings"
+)
+
+type emailExtractor struct {
+	// SubExtractor, when set, extracts the content of the attachments.
+	SubExtractor Extractor
+}
+
+// emailHeader is implemented by the headers of both messages and their parts.
+type emailHeader interface {
+	Get(key string) string
+}
+
+var emailHeaderNames = []string{"From", "To", "Cc", "Subject"}
+
+func (ee *emailExtractor) Name() string {
+	return "emailExtractor"
+}
+
+func (ee *emailExtractor) Match(filename string) bool {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".eml", ".mbox":
+		return true
+	}
+	return false
+}
+
+func (ee *emailExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	var text strings.Builder
+	var err error
+	if strings.ToLower(path.Ext(filename)) == ".mbox" {
+		err = ee.writeMailbox(&text, r)
+	} else {
+		err = ee.writeMessage(&text, r)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return text.String(), nil
+}
+
+// writeMailbox writes the text of each message of an mbox file, where every message starts
+// with a "From " line, and the lines of the messages starting with "From " are escaped
+// with ">".
+func (ee *emailExtractor) writeMailbox(text *strings.Builder, r io.Reader) error {
+	br := bufio.NewReader(r)
+	var message bytes.Buffer
+	messages := 0
+
+	flush := func() error {
+		if message.Len() == 0 {
+			return nil
+		}
+		messages++
+		err := ee.writeMessage(text, &message)
+		message.Reset()
+		text.WriteString("\n")
+		return err
+	}
+
+	prevBlank := true
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			switch {
+			case prevBlank && strings.HasPrefix(line, "From "):
+				if flushErr := flush(); flushErr != nil {
+					return flushErr
+				}
+			case strings.HasPrefix(line, ">") && strings.HasPrefix(strings.TrimLeft(line, ">"), "From "):
+				message.WriteString(line[1:])
+			default:
+				message.WriteString(line)
+			}
+			prevBlank = strings.TrimRight(line, "\r\n") == ""
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if messages == 0 {
+		return errors.New("no messages found")
+	}
+	return nil
+}
+
+// writeMessage writes the main headers of a message followed by the text of its parts.
+func (ee *emailExtractor) writeMessage(text *strings.Builder, r io.Reader) error {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return err
+	}
+
+	decoder := &mime.WordDecoder{}
+	for _, name := range emailHeaderNames {
+		value := msg.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if decoded, err := decoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		text.WriteString(name + ": " + value + "\n")
+	}
+	text.WriteString("\n")
+
+	return ee.writePart(text, msg.Header, msg.Body)
+}
+
+func (ee *emailExtractor) writePart(text *strings.Builder, header emailHeader, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return ee.writeMultipart(text, mediaType, params["boundary"], body)
+	}
+
+	_, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	if filename := dispositionParams["filename"]; filename != "" || params["name"] != "" {
+		if filename == "" {
+			filename = params["name"]
+		}
+		return ee.writeAttachment(text, filename, body)
+	}
+
+	switch mediaType {
+	case "text/plain":
+		_, err = io.Copy(text, body)
+		text.WriteString("\n")
+		return err
+	case "text/html":
+		htmlBody, err := htmlText(body)
+		if err != nil {
+			return err
+		}
+		text.WriteString(htmlBody + "\n")
+	case "message/rfc822":
+		return ee.writeMessage(text, body)
+	}
+
+	return nil
+}
+
+// writeMultipart writes the text of each part, except for multipart/alternative messages
+// where only the first part giving some text is written.
+func (ee *emailExtractor) writeMultipart(text *strings.Builder, mediaType, boundary string, body io.Reader) error {
+	if boundary == "" {
+		return errors.New("multipart message without boundary")
+	}
+
+	mr := multipart.NewReader(body, boundary)
+	for {
+		part, err := mr.NextRawPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if mediaType != "multipart/alternative" {
+			if err := ee.writePart(text, part.Header, part); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var partText strings.Builder
+		if err := ee.writePart(&partText, part.Header, part); err != nil {
+			return err
+		}
+		if strings.TrimSpace(partText.String()) != "" {
+			text.WriteString(partText.String())
+			return nil
+		}
+	}
+}
+
+func (ee *emailExtractor) writeAttachment(text *strings.Builder, filename string, body io.Reader) error {
+	text.WriteString(filename + "\n")
+	if ee.SubExtractor == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	subtext, err := ee.SubExtractor.Extract(filename, bytes.NewReader(data))
+	if err == nil && subtext != "" {
+		text.WriteString(subtext + "\n")
+	}
+	return nil
+}
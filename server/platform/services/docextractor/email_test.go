@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var testEmail = strings.ReplaceAll(`From: Alice <alice@example.com>
+To: bob@example.com
+Subject: =?UTF-8?Q?Caf=C3=A9_meeting?=
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="mixed"
+
+--mixed
+Content-Type: multipart/alternative; boundary="alternative"
+
+--alternative
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Let's discuss the quarterly =
+forecast.
+
+--alternative
+Content-Type: text/html; charset=utf-8
+
+<p>Let's discuss the <b>quarterly</b> forecast.</p>
+--alternative--
+
+--mixed
+Content-Type: text/plain; name="agenda.txt"
+Content-Disposition: attachment; filename="agenda.txt"
+Content-Transfer-Encoding: base64
+
+UmV2aWV3IHRoZSBtaWxlc3RvbmVz
+--mixed--
+`, "\n", "\r\n")
+
+func TestEmailExtractor(t *testing.T) {
+	t.Run("eml", func(t *testing.T) {
+		extractor := &emailExtractor{}
+
+		require.True(t, extractor.Match("test.eml"))
+		text, err := extractor.Extract("test.eml", strings.NewReader(testEmail))
+		require.NoError(t, err)
+		assert.Contains(t, text, "From: Alice <alice@example.com>\n")
+		assert.Contains(t, text, "Subject: Café meeting\n")
+		assert.Contains(t, text, "Let's discuss the quarterly forecast.")
+		assert.Equal(t, 1, strings.Count(text, "quarterly"), "only one part of the alternative is expected")
+		assert.Contains(t, text, "agenda.txt")
+		assert.NotContains(t, text, "milestones")
+	})
+
+	t.Run("eml with attachments content", func(t *testing.T) {
+		logger := mlog.CreateConsoleTestLogger(t)
+		text, err := Extract(logger, "test.eml", strings.NewReader(testEmail), ExtractSettings{ArchiveRecursion: true})
+		require.NoError(t, err)
+		assert.Contains(t, text, "agenda.txt")
+		assert.Contains(t, text, "Review the milestones")
+	})
+
+	t.Run("html eml", func(t *testing.T) {
+		extractor := &emailExtractor{}
+		email := "Subject: Newsletter\r\nContent-Type: text/html\r\n\r\n" +
+			"<html><head><style>p { color: red; }</style></head><body><p>Release &amp; <b>roadmap</b> update</p></body></html>"
+
+		text, err := extractor.Extract("test.eml", strings.NewReader(email))
+		require.NoError(t, err)
+		assert.Equal(t, "Subject: Newsletter\n\nRelease & roadmap update\n\n", text)
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		extractor := &emailExtractor{}
+		mbox := `From alice@example.com Mon Jan  1 00:00:00 2024
+From: alice@example.com
+Subject: First message
+
+Hello Bob,
+>From now on the meetings are on Mondays.
+
+From bob@example.com Tue Jan  2 00:00:00 2024
+From: bob@example.com
+Subject: Second message
+
+Noted.
+`
+
+		require.True(t, extractor.Match("archive.mbox"))
+		text, err := extractor.Extract("archive.mbox", strings.NewReader(mbox))
+		require.NoError(t, err)
+		assert.Contains(t, text, "Subject: First message\n\nHello Bob,\nFrom now on the meetings are on Mondays.\n")
+		assert.Contains(t, text, "Subject: Second message\n\nNoted.\n")
+		assert.NotContains(t, text, "Mon Jan")
+	})
+
+	t.Run("empty mbox", func(t *testing.T) {
+		extractor := &emailExtractor{}
+		_, err := extractor.Extract("archive.mbox", bytes.NewReader(nil))
+		require.EqualError(t, err, "no messages found")
+	})
+}
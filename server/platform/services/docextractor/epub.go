@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+type epubExtractor struct{}
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Titles   []string `xml:"metadata>title"`
+	Creators []string `xml:"metadata>creator"`
+	Items    []struct {
+		Id        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	ItemRefs []struct {
+		IdRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+func (ee *epubExtractor) Name() string {
+	return "epubExtractor"
+}
+
+func (ee *epubExtractor) Match(filename string) bool {
+	return strings.ToLower(path.Ext(filename)) == ".epub"
+}
+
+// Extract returns the title and the authors of the book, followed by the text of its
+// documents in reading order.
+func (ee *epubExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	zr, err := readZip(r)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := readZipFile(zr, "META-INF/container.xml")
+	if err != nil {
+		return "", err
+	}
+	var container epubContainer
+	if err = xml.Unmarshal(data, &container); err != nil {
+		return "", err
+	}
+	if len(container.Rootfiles) == 0 {
+		return "", errors.New("no package document found")
+	}
+
+	packagePath := container.Rootfiles[0].FullPath
+	data, err = readZipFile(zr, packagePath)
+	if err != nil {
+		return "", err
+	}
+	var pkg epubPackage
+	if err = xml.Unmarshal(data, &pkg); err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, line := range append(pkg.Titles, pkg.Creators...) {
+		text.WriteString(line + "\n")
+	}
+
+	hrefs := make(map[string]string, len(pkg.Items))
+	for _, item := range pkg.Items {
+		if item.MediaType == "application/xhtml+xml" {
+			hrefs[item.Id] = item.Href
+		}
+	}
+
+	for _, itemRef := range pkg.ItemRefs {
+		href, ok := hrefs[itemRef.IdRef]
+		if !ok {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+
+		data, err = readZipFile(zr, path.Join(path.Dir(packagePath), href))
+		if err != nil {
+			return "", err
+		}
+
+		documentText, err := htmlText(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(documentText + "\n")
+	}
+
+	return text.String(), nil
+}
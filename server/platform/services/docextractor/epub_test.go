@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpubExtractor(t *testing.T) {
+	extractor := &epubExtractor{}
+
+	t.Run("epub", func(t *testing.T) {
+		data := newTestZip(t,
+			"mimetype", "application/epub+zip",
+			"META-INF/container.xml", `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`,
+			"OEBPS/content.opf", `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>The Handbook</dc:title>
+    <dc:creator>Alice</dc:creator>
+  </metadata>
+  <manifest>
+    <item id="chapter1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="chapter2" href="text/chapter2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="cover" href="cover.jpg" media-type="image/jpeg"/>
+  </manifest>
+  <spine><itemref idref="chapter2"/><itemref idref="cover"/><itemref idref="chapter1"/></spine>
+</package>`,
+			"OEBPS/text/chapter 1.xhtml", `<html xmlns="http://www.w3.org/1999/xhtml"><body><h1>Onboarding</h1><p>Welcome aboard.</p></body></html>`,
+			"OEBPS/text/chapter2.xhtml", `<html xmlns="http://www.w3.org/1999/xhtml"><body><p>Preface</p></body></html>`,
+		)
+
+		require.True(t, extractor.Match("handbook.epub"))
+		text, err := extractor.Extract("handbook.epub", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Regexp(t, `(?s)^The Handbook\nAlice\n.*Preface.*Onboarding.*Welcome aboard\.`, text)
+	})
+
+	t.Run("missing container", func(t *testing.T) {
+		data := newTestZip(t, "mimetype", "application/epub+zip")
+		_, err := extractor.Extract("handbook.epub", bytes.NewReader(data))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var htmlBlockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Li: true, atom.Tr: true, atom.Td: true, atom.Th: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Section: true, atom.Article: true, atom.Title: true,
+}
+
+var htmlWhitespaceRegexp = regexp.MustCompile(`\s+`)
+
+var htmlSkippedElements = map[atom.Atom]bool{atom.Script: true, atom.Style: true}
+
+// htmlText returns the text of an HTML or XHTML document, with a line break after each
+// block element.
+func htmlText(r io.Reader) (string, error) {
+	tokenizer := html.NewTokenizer(r)
+
+	var text strings.Builder
+	skipped := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return text.String(), nil
+		case html.StartTagToken:
+			if htmlSkippedElements[tokenizer.Token().DataAtom] {
+				skipped++
+			}
+		case html.EndTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if htmlSkippedElements[token.DataAtom] && skipped > 0 {
+				skipped--
+			}
+			if htmlBlockElements[token.DataAtom] {
+				text.WriteString("\n")
+			}
+		case html.TextToken:
+			if skipped == 0 {
+				text.WriteString(htmlWhitespaceRegexp.ReplaceAllString(tokenizer.Token().Data, " "))
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type presentationExtractor struct{}
+
+func (pe *presentationExtractor) Name() string {
+	return "presentationExtractor"
+}
+
+func (pe *presentationExtractor) Match(filename string) bool {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".pptx", ".odp":
+		return true
+	}
+	return false
+}
+
+func (pe *presentationExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".pptx":
+		return extractPPTX(r)
+	case ".odp":
+		return extractOpenDocument(r)
+	}
+	return "", errors.New("unknown presentation format")
+}
+
+var pptxSlideRegexp = regexp.MustCompile(`^ppt/(slides/slide|notesSlides/notesSlide)(\d+)\.xml$`)
+
+// extractPPTX returns the text of each slide followed by its notes, in the order of the
+// slides.
+func extractPPTX(r io.Reader) (string, error) {
+	zr, err := readZip(r)
+	if err != nil {
+		return "", err
+	}
+
+	type slidePart struct {
+		file   *zip.File
+		number int
+		notes  bool
+	}
+
+	var parts []slidePart
+	for _, f := range zr.File {
+		match := pptxSlideRegexp.FindStringSubmatch(f.Name)
+		if match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[2])
+		parts = append(parts, slidePart{file: f, number: number, notes: match[1] != "slides/slide"})
+	}
+	if len(parts) == 0 {
+		return "", errors.New("no slides found")
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		if parts[i].number != parts[j].number {
+			return parts[i].number < parts[j].number
+		}
+		return !parts[i].notes && parts[j].notes
+	})
+
+	var text strings.Builder
+	for _, part := range parts {
+		content, err := readZipFile(zr, part.file.Name)
+		if err != nil {
+			return "", err
+		}
+
+		partText, err := xmlText(bytes.NewReader(content), map[string]bool{"t": true}, map[string]string{"p": "\n"})
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(partText)
+	}
+
+	return text.String(), nil
+}
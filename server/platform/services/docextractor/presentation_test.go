@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPPTXSlide(paragraphs ...string) string {
+	xml := `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree><p:sp><p:txBody>`
+	for _, paragraph := range paragraphs {
+		xml += `<a:p><a:r><a:t>` + paragraph + `</a:t></a:r></a:p>`
+	}
+	return xml + `</p:txBody></p:sp></p:spTree></p:cSld></p:sld>`
+}
+
+func TestPresentationExtractor(t *testing.T) {
+	extractor := &presentationExtractor{}
+
+	t.Run("pptx", func(t *testing.T) {
+		data := newTestZip(t,
+			"ppt/slides/slide10.xml", testPPTXSlide("Closing"),
+			"ppt/slides/slide2.xml", testPPTXSlide("Roadmap", "Milestones"),
+			"ppt/notesSlides/notesSlide2.xml", testPPTXSlide("Speaker notes"),
+			"ppt/slides/slide1.xml", testPPTXSlide("Welcome"),
+			"ppt/slideLayouts/slideLayout1.xml", testPPTXSlide("Layout placeholder"),
+		)
+
+		require.True(t, extractor.Match("test.pptx"))
+		text, err := extractor.Extract("test.pptx", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome\nRoadmap\nMilestones\nSpeaker notes\nClosing\n", text)
+	})
+
+	t.Run("pptx without slides", func(t *testing.T) {
+		data := newTestZip(t, "ppt/presentation.xml", "<p:presentation/>")
+		_, err := extractor.Extract("test.pptx", bytes.NewReader(data))
+		require.EqualError(t, err, "no slides found")
+	})
+
+	t.Run("entry above the size limit", func(t *testing.T) {
+		data := newTestZip(t, "content.xml", strings.Repeat(" ", maxZipEntrySize+1))
+		_, err := extractor.Extract("test.odp", bytes.NewReader(data))
+		require.ErrorContains(t, err, "content.xml is larger than")
+	})
+
+	t.Run("odp", func(t *testing.T) {
+		data := newTestZip(t, "content.xml", `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+  <office:body><office:presentation>
+    <draw:page draw:name="page1"><draw:frame><draw:text-box><text:p>Welcome<text:tab/>everyone</text:p></draw:text-box></draw:frame></draw:page>
+    <draw:page draw:name="page2"><draw:frame><draw:text-box><text:h>Roadmap</text:h></draw:text-box></draw:frame></draw:page>
+  </office:presentation></office:body>
+</office:document-content>`)
+
+		require.True(t, extractor.Match("test.odp"))
+		text, err := extractor.Extract("test.odp", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome\teveryone\nRoadmap\n", text)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type spreadsheetExtractor struct{}
+
+func (se *spreadsheetExtractor) Name() string {
+	return "spreadsheetExtractor"
+}
+
+func (se *spreadsheetExtractor) Match(filename string) bool {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".xlsx", ".ods":
+		return true
+	}
+	return false
+}
+
+func (se *spreadsheetExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".xlsx":
+		return extractXLSX(r)
+	case ".ods":
+		return extractOpenDocument(r)
+	}
+	return "", errors.New("unknown spreadsheet format")
+}
+
+// extractXLSX returns the name and the cell values of each sheet, with one line per row.
+func extractXLSX(r io.Reader) (string, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{
+		UnzipSizeLimit:    maxZipSize,
+		UnzipXMLSizeLimit: maxZipEntrySize,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var text strings.Builder
+	for _, sheet := range f.GetSheetList() {
+		rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return "", err
+		}
+
+		text.WriteString(sheet + "\n")
+		for _, row := range rows {
+			values := make([]string, 0, len(row))
+			for _, value := range row {
+				if value != "" {
+					values = append(values, value)
+				}
+			}
+			if len(values) > 0 {
+				text.WriteString(strings.Join(values, " ") + "\n")
+			}
+		}
+	}
+
+	return text.String(), nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+const testODSContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+  <office:body>
+    <office:spreadsheet>
+      <table:table table:name="Budget">
+        <table:table-row>
+          <table:table-cell><text:p>Quarterly</text:p></table:table-cell>
+          <table:table-cell><text:p>forecast<text:s/>figures</text:p></table:table-cell>
+        </table:table-row>
+      </table:table>
+    </office:spreadsheet>
+  </office:body>
+</office:document-content>`
+
+func TestSpreadsheetExtractor(t *testing.T) {
+	extractor := &spreadsheetExtractor{}
+
+	t.Run("xlsx", func(t *testing.T) {
+		f := excelize.NewFile()
+		defer f.Close()
+		require.NoError(t, f.SetCellValue("Sheet1", "A1", "Quarterly"))
+		require.NoError(t, f.SetCellValue("Sheet1", "C1", "forecast"))
+		require.NoError(t, f.SetCellValue("Sheet1", "A2", 42))
+		_, err := f.NewSheet("Budget")
+		require.NoError(t, err)
+		require.NoError(t, f.SetCellValue("Budget", "B3", "figures"))
+		buf, err := f.WriteToBuffer()
+		require.NoError(t, err)
+
+		require.True(t, extractor.Match("test.XLSX"))
+		text, err := extractor.Extract("test.xlsx", bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, "Sheet1\nQuarterly forecast\n42\nBudget\nfigures\n", text)
+	})
+
+	t.Run("ods", func(t *testing.T) {
+		data := newTestZip(t, "content.xml", testODSContent)
+
+		require.True(t, extractor.Match("test.ods"))
+		text, err := extractor.Extract("test.ods", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "Quarterly\nforecast figures\n", text)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := extractor.Extract("test.xlsx", bytes.NewReader([]byte("not a spreadsheet")))
+		require.Error(t, err)
+	})
+}
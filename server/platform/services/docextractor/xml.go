@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// xmlText returns the character data found within the elements of the given local names,
+// writing the separator of an element after each of its end tags.
+func xmlText(r io.Reader, textElements map[string]bool, separators map[string]string) (string, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+
+	var text strings.Builder
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if textElements[t.Name.Local] {
+				depth++
+			}
+		case xml.EndElement:
+			if textElements[t.Name.Local] && depth > 0 {
+				depth--
+			}
+			if separator, ok := separators[t.Name.Local]; ok {
+				text.WriteString(separator)
+			}
+		case xml.CharData:
+			if depth > 0 {
+				text.Write(t)
+			}
+		}
+	}
+
+	return text.String(), nil
+}
+
+// The uncompressed sizes above which the entries of a zip archive, and the archive as a whole,
+// aren't read, so that a small archive can't exhaust the memory or the disk once decompressed.
+const (
+	maxZipEntrySize = 64 * 1024 * 1024
+	maxZipSize      = 256 * 1024 * 1024
+)
+
+// readZip reads the whole content of r as a zip archive, as the document formats based on
+// zip archives need random access to their entries.
+func readZip(r io.Reader) (*zip.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// readZipFile returns the content of the entry of the given name in the zip archive.
+func readZipFile(zr *zip.Reader, name string) ([]byte, error) {
+	f, err := zr.Open(path.Clean(name))
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", name, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxZipEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	if len(data) > maxZipEntrySize {
+		return nil, fmt.Errorf("%s is larger than %d bytes once uncompressed", name, maxZipEntrySize)
+	}
+	return data, nil
+}
+
+var openDocumentTextElements = map[string]bool{"p": true, "h": true}
+
+var openDocumentSeparators = map[string]string{
+	"p":          "\n",
+	"h":          "\n",
+	"s":          " ",
+	"tab":        "\t",
+	"line-break": "\n",
+}
+
+// extractOpenDocument returns the text of the paragraphs and headings of an OpenDocument
+// file, such as an ODS spreadsheet or an ODP presentation.
+func extractOpenDocument(r io.Reader) (string, error) {
+	zr, err := readZip(r)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := readZipFile(zr, "content.xml")
+	if err != nil {
+		return "", err
+	}
+
+	return xmlText(bytes.NewReader(content), openDocumentTextElements, openDocumentSeparators)
+}
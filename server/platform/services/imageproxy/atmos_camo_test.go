@@ -33,8 +33,9 @@ func makeTestAtmosCamoProxy() *ImageProxy {
 			},
 		},
 	}
+	configService.Cfg.ImageProxySettings.SetDefaults()
 
-	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil)
+	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil, nil)
 }
 
 func TestAtmosCamoBackend_GetImage(t *testing.T) {
@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imageproxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const cacheDirectory = "imageproxy"
+
+// cachedHeaders are the headers of the remote response kept along with a cached image.
+var cachedHeaders = []string{"Cache-Control", "Last-Modified", "Expires", "Etag", "Link"}
+
+// imageCache stores proxied images in the file store. Each entry is a single file holding a
+// JSON line of metadata followed by the image data.
+type imageCache struct {
+	backend filestore.FileBackend
+	ttl     time.Duration
+	maxSize int64
+
+	// index holds the size and creation time of the cached images by path, so that pruning
+	// the cache doesn't go through the file store.
+	mut     sync.Mutex
+	index   map[string]indexedImage
+	size    int64
+	pruning atomic.Bool
+}
+
+type indexedImage struct {
+	size     int64
+	createAt time.Time
+}
+
+type cacheEntry struct {
+	ContentType string      `json:"content_type"`
+	Header      http.Header `json:"header"`
+	CreateAt    int64       `json:"create_at"`
+}
+
+func newImageCache(backend filestore.FileBackend, settings model.ImageProxySettings) *imageCache {
+	cache := &imageCache{
+		backend: backend,
+		ttl:     time.Duration(*settings.LocalCacheTTLMinutes) * time.Minute,
+		maxSize: *settings.LocalCacheMaxSize,
+		index:   map[string]indexedImage{},
+	}
+
+	go func() {
+		cache.load()
+		cache.prune()
+	}()
+
+	return cache
+}
+
+func cacheKey(imageURL string, opts Options) string {
+	sum := sha256.Sum256([]byte(opts.String() + "/" + imageURL))
+	return hex.EncodeToString(sum[:])
+}
+
+func cachePath(key string) string {
+	return path.Join(cacheDirectory, key[:2], key)
+}
+
+// get returns the cached image for the key, unless it is missing or expired.
+func (c *imageCache) get(key string) (*cacheEntry, []byte, bool) {
+	p := cachePath(key)
+	data, err := c.backend.ReadFile(p)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	r := bufio.NewReader(bytes.NewReader(data))
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil, nil, false
+	}
+	// Images cached by another server of the cluster are indexed once read.
+	c.track(p, int64(len(data)), time.UnixMilli(entry.CreateAt), false)
+	if time.Since(time.UnixMilli(entry.CreateAt)) > c.ttl {
+		return nil, nil, false
+	}
+
+	return &entry, data[len(line):], true
+}
+
+func (c *imageCache) set(key string, entry *cacheEntry, data []byte) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		mlog.Warn("Failed to encode cached image metadata", mlog.Err(err))
+		return
+	}
+
+	p := cachePath(key)
+	written, err := c.backend.WriteFile(io.MultiReader(bytes.NewReader(line), bytes.NewReader([]byte{'\n'}), bytes.NewReader(data)), p)
+	if err != nil {
+		mlog.Warn("Failed to cache proxied image", mlog.Err(err))
+		return
+	}
+
+	if c.track(p, written, time.UnixMilli(entry.CreateAt), true) > c.maxSize {
+		go c.prune()
+	}
+}
+
+// track adds the image to the index, or updates it when replace is set, returning the total
+// size of the cache.
+func (c *imageCache) track(p string, size int64, createAt time.Time, replace bool) int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if old, ok := c.index[p]; ok {
+		if !replace {
+			return c.size
+		}
+		c.size -= old.size
+	}
+	c.index[p] = indexedImage{size: size, createAt: createAt}
+	c.size += size
+	return c.size
+}
+
+// load indexes the images cached before the server started. Their creation time isn't
+// known without reading them, so they are kept for a TTL from now.
+func (c *imageCache) load() {
+	paths, err := c.backend.ListDirectoryRecursively(cacheDirectory)
+	if err != nil {
+		mlog.Debug("Failed to list the image proxy cache", mlog.Err(err))
+		return
+	}
+
+	now := time.Now()
+	for _, p := range paths {
+		size, err := c.backend.FileSize(p)
+		if err != nil {
+			continue
+		}
+		c.track(p, size, now, false)
+	}
+}
+
+// prune removes the expired images, then the oldest ones until the cache is back under
+// 90% of its maximum size. Only one prune runs at a time.
+func (c *imageCache) prune() {
+	if !c.pruning.CompareAndSwap(false, true) {
+		return
+	}
+	defer c.pruning.Store(false)
+
+	type cachedFile struct {
+		path string
+		indexedImage
+	}
+
+	c.mut.Lock()
+	var removed, files []cachedFile
+	for p, cached := range c.index {
+		if time.Since(cached.createAt) > c.ttl {
+			removed = append(removed, cachedFile{p, cached})
+			continue
+		}
+		files = append(files, cachedFile{p, cached})
+	}
+	for _, f := range removed {
+		delete(c.index, f.path)
+		c.size -= f.size
+	}
+
+	if c.size > c.maxSize {
+		sort.Slice(files, func(i, j int) bool {
+			return files[i].createAt.Before(files[j].createAt)
+		})
+		for _, f := range files {
+			if c.size <= c.maxSize*9/10 {
+				break
+			}
+			removed = append(removed, f)
+			delete(c.index, f.path)
+			c.size -= f.size
+		}
+	}
+	c.mut.Unlock()
+
+	for _, f := range removed {
+		if err := c.backend.RemoveFile(f.path); err != nil {
+			mlog.Debug("Failed to remove cached image", mlog.String("path", f.path), mlog.Err(err))
+		}
+	}
+}
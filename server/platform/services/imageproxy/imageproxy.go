@@ -16,6 +16,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
 	"github.com/mattermost/mattermost/server/public/shared/httpservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
 var ErrNotEnabled = Error{errors.New("imageproxy.ImageProxy: image proxy not enabled")}
@@ -28,6 +29,9 @@ type ImageProxy struct {
 
 	HTTPService httpservice.HTTPService
 
+	// FileBackend stores the images cached by the local backend. Caching is disabled when nil.
+	FileBackend filestore.FileBackend
+
 	Logger *mlog.Logger
 
 	siteURL *url.URL
@@ -45,10 +49,11 @@ type ImageProxyBackend interface {
 	GetImageDirect(imageURL string) (io.ReadCloser, string, error)
 }
 
-func MakeImageProxy(configService configservice.ConfigService, httpService httpservice.HTTPService, logger *mlog.Logger) *ImageProxy {
+func MakeImageProxy(configService configservice.ConfigService, httpService httpservice.HTTPService, fileBackend filestore.FileBackend, logger *mlog.Logger) *ImageProxy {
 	proxy := &ImageProxy{
 		ConfigService: configService,
 		HTTPService:   httpService,
+		FileBackend:   fileBackend,
 		Logger:        logger,
 	}
 
@@ -72,7 +77,7 @@ func (proxy *ImageProxy) makeBackend(proxySettings model.ImageProxySettings) Ima
 
 	switch *proxySettings.ImageProxyType {
 	case model.ImageProxyTypeLocal:
-		return makeLocalBackend(proxy)
+		return makeLocalBackend(proxy, proxySettings)
 	case model.ImageProxyTypeAtmosCamo:
 		return makeAtmosCamoBackend(proxy, proxySettings)
 	default:
@@ -156,6 +161,17 @@ func (proxy *ImageProxy) GetProxiedImageURL(imageURL string) string {
 	return proxy.siteURL.String() + "/api/v4/image?url=" + url.QueryEscape(parsedURL.String())
 }
 
+// GetProxiedImageURLWithOptions works like GetProxiedImageURL, additionally asking the
+// local backend to transform the image according to the given options.
+func (proxy *ImageProxy) GetProxiedImageURLWithOptions(imageURL string, opts Options) string {
+	proxiedURL := proxy.GetProxiedImageURL(imageURL)
+	if opts.IsZero() || proxy.siteURL == nil || !strings.HasPrefix(proxiedURL, proxy.siteURL.String()+"/api/v4/image?url=") {
+		return proxiedURL
+	}
+
+	return proxiedURL + "&" + opts.Query().Encode()
+}
+
 // GetUnproxiedImageURL takes the URL of an image on the image proxy and returns the original URL of the image.
 func (proxy *ImageProxy) GetUnproxiedImageURL(proxiedURL string) string {
 	return getUnproxiedImageURL(proxiedURL, *proxy.ConfigService.Config().ServiceSettings.SiteURL)
@@ -185,3 +185,18 @@ func TestOnConfigChange(t *testing.T) {
 		// require.Equal(t, "some other random hash", proxy.backend.(*AtmosCamoBackend).remoteOptions)
 	})
 }
+
+func TestGetProxiedImageURLWithOptions(t *testing.T) {
+	parsedURL, err := url.Parse("https://mattermost.example.com")
+	require.NoError(t, err)
+
+	proxy := ImageProxy{siteURL: parsedURL}
+
+	imageURL := "https://mattermost.com/logo.png"
+	proxiedURL := "https://mattermost.example.com/api/v4/image?url=https%3A%2F%2Fmattermost.com%2Flogo.png"
+
+	assert.Equal(t, proxiedURL+"&format=png&width=400", proxy.GetProxiedImageURLWithOptions(imageURL, Options{Width: 400, Format: "png"}))
+	assert.Equal(t, proxiedURL, proxy.GetProxiedImageURLWithOptions(imageURL, Options{}))
+	assert.Equal(t, "https://mattermost.example.com/static/logo.png", proxy.GetProxiedImageURLWithOptions("/static/logo.png", Options{Width: 400}))
+	assert.Equal(t, imageURL, getUnproxiedImageURL(proxy.GetProxiedImageURLWithOptions(imageURL, Options{Height: 10}), "https://mattermost.example.com"))
+}
@@ -16,10 +16,15 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/configservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 )
 
 var imageContentTypes = []string{
@@ -37,11 +42,19 @@ var imageContentTypes = []string{
 
 var msgNotAllowed = "requested URL is not allowed"
 
+var msgTooLarge = "requested image is too large"
+
 var ErrLocalRequestFailed = Error{errors.New("imageproxy.LocalBackend: failed to request proxied image")}
 
 type LocalBackend struct {
 	client  *http.Client
 	baseURL *url.URL
+
+	configService configservice.ConfigService
+	maxImageSize  int64
+	cache         *imageCache
+	decoder       *imaging.Decoder
+	encoder       *imaging.Encoder
 }
 
 // URLError reports a malformed URL error.
@@ -54,7 +67,7 @@ func (e URLError) Error() string {
 	return fmt.Sprintf("malformed URL %q: %s", e.URL, e.Message)
 }
 
-func makeLocalBackend(proxy *ImageProxy) *LocalBackend {
+func makeLocalBackend(proxy *ImageProxy, proxySettings model.ImageProxySettings) *LocalBackend {
 	baseURL := proxy.siteURL
 	if baseURL == nil {
 		mlog.Warn("Failed to set base URL for image proxy. Relative image links may not work.")
@@ -62,10 +75,26 @@ func makeLocalBackend(proxy *ImageProxy) *LocalBackend {
 
 	client := proxy.HTTPService.MakeClient(false)
 
-	return &LocalBackend{
-		client:  client,
-		baseURL: baseURL,
+	backend := &LocalBackend{
+		client:        client,
+		baseURL:       baseURL,
+		configService: proxy.ConfigService,
+		maxImageSize:  *proxySettings.MaxImageSize,
 	}
+
+	if *proxySettings.EnableLocalCache && proxy.FileBackend != nil {
+		backend.cache = newImageCache(proxy.FileBackend, proxySettings)
+	}
+
+	// The options are validated, so creating them cannot fail.
+	backend.decoder, _ = imaging.NewDecoder(imaging.DecoderOptions{ConcurrencyLevel: runtime.NumCPU()})
+	backend.encoder, _ = imaging.NewEncoder(imaging.EncoderOptions{ConcurrencyLevel: runtime.NumCPU()})
+
+	return backend
+}
+
+func (backend *LocalBackend) maxImageResolution() int64 {
+	return *backend.configService.Config().FileSettings.MaxImageResolution
 }
 
 type contentTypeRecorder struct {
@@ -87,8 +116,17 @@ func (rec *contentTypeRecorder) WriteHeader(code int) {
 }
 
 func (backend *LocalBackend) GetImage(w http.ResponseWriter, r *http.Request, imageURL string) {
+	opts, err := optionsFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid image options: %v", err), http.StatusBadRequest)
+		return
+	}
+
 	// The interface to the proxy only exposes a ServeHTTP method, so fake a request to it
 	req, err := http.NewRequest(http.MethodGet, "/"+imageURL, nil)
+	if !opts.IsZero() {
+		req, err = http.NewRequest(http.MethodGet, "/"+opts.String()+"/"+imageURL, nil)
+	}
 	if err != nil {
 		// http.NewRequest should only return an error on an invalid URL
 		mlog.Debug("Failed to create request for proxied image", mlog.String("url", imageURL), mlog.Err(err))
@@ -97,6 +135,7 @@ func (backend *LocalBackend) GetImage(w http.ResponseWriter, r *http.Request, im
 		w.Write([]byte{})
 		return
 	}
+	copyHeader(req.Header, r.Header, "If-None-Match", "If-Modified-Since")
 
 	u, err := url.Parse(imageURL)
 	if err != nil {
@@ -140,6 +179,20 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 		return
 	}
 
+	var key string
+	if backend.cache != nil {
+		key = cacheKey(proxyReq.String(), proxyReq.Options)
+		if entry, data, ok := backend.cache.get(key); ok {
+			copyHeader(w.Header(), entry.Header)
+			if should304(req, &http.Response{Header: entry.Header}) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			writeImage(w, entry.ContentType, data, http.StatusOK)
+			return
+		}
+	}
+
 	actualReq, err := http.NewRequest("GET", proxyReq.String(), nil)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -160,7 +213,7 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 	// close the original resp.Body, even if we wrap it in a NopCloser below
 	defer resp.Body.Close()
 
-	copyHeader(w.Header(), resp.Header, "Cache-Control", "Last-Modified", "Expires", "Etag", "Link")
+	copyHeader(w.Header(), resp.Header, cachedHeaders...)
 
 	if should304(req, resp) {
 		w.WriteHeader(http.StatusNotModified)
@@ -178,9 +231,44 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 		http.Error(w, msgNotAllowed, http.StatusForbidden)
 		return
 	}
-	w.Header().Set("Content-Type", contentType)
 
-	copyHeader(w.Header(), resp.Header, "Content-Length")
+	if resp.ContentLength > backend.maxImageSize {
+		http.Error(w, msgTooLarge, http.StatusForbidden)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, backend.maxImageSize+1))
+	if err != nil {
+		mlog.Warn("error reading remote image", mlog.Err(err))
+		http.Error(w, fmt.Sprintf("error reading remote image: %v", err), http.StatusBadGateway)
+		return
+	}
+	if int64(len(data)) > backend.maxImageSize {
+		http.Error(w, msgTooLarge, http.StatusForbidden)
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		data, contentType, err = backend.transformImage(data, contentType, proxyReq.Options)
+		if err != nil {
+			mlog.Debug("Failed to transform proxied image", mlog.String("url", proxyReq.String()), mlog.Err(err))
+			http.Error(w, fmt.Sprintf("failed to transform image: %v", err), http.StatusUnprocessableEntity)
+			return
+		}
+
+		if backend.cache != nil {
+			header := http.Header{}
+			copyHeader(header, resp.Header, cachedHeaders...)
+			backend.cache.set(key, &cacheEntry{ContentType: contentType, Header: header, CreateAt: model.GetMillis()}, data)
+		}
+	}
+
+	writeImage(w, contentType, data, resp.StatusCode)
+}
+
+// writeImage writes the image with the headers protecting clients from what it may contain.
+func writeImage(w http.ResponseWriter, contentType string, data []byte, statusCode int) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
 
 	// Enable CORS for 3rd party applications
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -194,8 +282,8 @@ func (backend *LocalBackend) ServeImage(w http.ResponseWriter, req *http.Request
 	// Block potential XSS attacks especially in legacy browsers which do not support CSP
 	w.Header().Set("X-XSS-Protection", "1; mode=block")
 
-	w.WriteHeader(resp.StatusCode)
-	if _, err := io.Copy(w, resp.Body); err != nil {
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(data); err != nil {
 		mlog.Warn("error copying response", mlog.Err(err))
 	}
 }
@@ -267,6 +355,7 @@ func contentTypeMatches(patterns []string, contentType string) bool {
 // proxy.
 type proxyRequest struct {
 	URL      *url.URL      // URL of the image to proxy
+	Options  Options       // Transformations to apply to the image
 	Original *http.Request // The original HTTP request
 }
 
@@ -289,7 +378,11 @@ func newProxyRequest(r *http.Request, baseURL *url.URL) (*proxyRequest, error) {
 			return nil, URLError{"too few path segments", r.URL}
 		}
 
-		var err error
+		req.Options, err = parseOptions(parts[0])
+		if err != nil {
+			return nil, URLError{fmt.Sprintf("invalid options: %v", err), r.URL}
+		}
+
 		req.URL, err = parseURL(parts[1])
 		if err != nil {
 			return nil, URLError{fmt.Sprintf("unable to parse remote URL: %v", err), r.URL}
@@ -4,9 +4,13 @@
 package imageproxy
 
 import (
+	"bytes"
+	"image"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,9 +20,14 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/httpservice"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
 func makeTestLocalProxy() *ImageProxy {
+	return makeTestLocalProxyWithFileBackend(nil)
+}
+
+func makeTestLocalProxyWithFileBackend(fileBackend filestore.FileBackend) *ImageProxy {
 	configService := &testutils.StaticConfigService{
 		Cfg: &model.Config{
 			ServiceSettings: model.ServiceSettings{
@@ -31,8 +40,11 @@ func makeTestLocalProxy() *ImageProxy {
 			},
 		},
 	}
+	configService.Cfg.ImageProxySettings.SetDefaults()
+	configService.Cfg.ImageProxySettings.EnableLocalCache = model.NewBool(fileBackend != nil)
+	configService.Cfg.FileSettings.SetDefaults(false)
 
-	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), nil)
+	return MakeImageProxy(configService, httpservice.MakeHTTPService(configService), fileBackend, nil)
 }
 
 func TestLocalBackend_GetImage(t *testing.T) {
@@ -353,3 +365,205 @@ func TestLocalBackend_GetImageDirect(t *testing.T) {
 		wait <- true
 	})
 }
+
+func makeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestLocalBackend_Transform(t *testing.T) {
+	data := makeTestPNG(t, 100, 50)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+
+	mock := httptest.NewServer(handler)
+	defer mock.Close()
+
+	proxy := makeTestLocalProxy()
+
+	getImage := func(t *testing.T, query string) *http.Response {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/api/v4/image?"+query, nil)
+		require.NoError(t, err)
+		proxy.GetImage(recorder, request, mock.URL+"/image.png")
+		return recorder.Result()
+	}
+
+	t.Run("resize", func(t *testing.T) {
+		resp := getImage(t, "width=40")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+		config, format, err := image.DecodeConfig(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 40, config.Width)
+		assert.Equal(t, 20, config.Height)
+	})
+
+	t.Run("no upscaling", func(t *testing.T) {
+		resp := getImage(t, "width=400&height=400")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("format", func(t *testing.T) {
+		resp := getImage(t, "height=10&format=jpeg")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+
+		config, format, err := image.DecodeConfig(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 20, config.Width)
+		assert.Equal(t, 10, config.Height)
+	})
+
+	t.Run("webp resized to png", func(t *testing.T) {
+		webpData, err := testutils.ReadTestFile("testwebp.webp")
+		require.NoError(t, err)
+		config, _, err := image.DecodeConfig(bytes.NewReader(webpData))
+		require.NoError(t, err)
+
+		backend := proxy.backend.(*LocalBackend)
+		resized, contentType, err := backend.transformImage(webpData, "image/webp", Options{Width: config.Width / 2})
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		_, format, err := image.DecodeConfig(bytes.NewReader(resized))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+
+		unchanged, contentType, err := backend.transformImage(webpData, "image/webp", Options{Width: config.Width})
+		require.NoError(t, err)
+		assert.Equal(t, "image/webp", contentType)
+		assert.Equal(t, webpData, unchanged)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, query := range []string{"width=abc", "width=-1", "height=100000", "format=bmp", "format=webp"} {
+			resp := getImage(t, query)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestLocalBackend_MaxImageSize(t *testing.T) {
+	for name, contentLength := range map[string]bool{"with content length": true, "without content length": false} {
+		t.Run(name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+				if contentLength {
+					w.Header().Set("Content-Length", "10")
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("1111111111"))
+			})
+
+			mock := httptest.NewServer(handler)
+			defer mock.Close()
+
+			proxy := makeTestLocalProxy()
+			proxy.backend.(*LocalBackend).maxImageSize = 5
+
+			body, _, err := proxy.GetImageDirect(mock.URL + "/image.png")
+			assert.Equal(t, ErrLocalRequestFailed, err)
+			assert.Nil(t, body)
+		})
+	}
+}
+
+func TestLocalBackend_Cache(t *testing.T) {
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Etag", `"abc"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("1111111111"))
+	})
+
+	mock := httptest.NewServer(handler)
+	defer mock.Close()
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	proxy := makeTestLocalProxyWithFileBackend(fileBackend)
+	cache := proxy.backend.(*LocalBackend).cache
+	require.NotNil(t, cache)
+
+	t.Run("served from the cache", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			body, contentType, err := proxy.GetImageDirect(mock.URL + "/image.png")
+			require.NoError(t, err)
+			assert.Equal(t, "image/png", contentType)
+			respBody, _ := io.ReadAll(body)
+			assert.Equal(t, []byte("1111111111"), respBody)
+		}
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "", nil)
+		request.Header.Set("If-None-Match", `"abc"`)
+		proxy.GetImage(recorder, request, mock.URL+"/image.png")
+
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		key := cacheKey(mock.URL+"/image.png", Options{})
+		cache.set(key, &cacheEntry{ContentType: "image/png", CreateAt: model.GetMillis() - 2*cache.ttl.Milliseconds()}, []byte("2222222222"))
+
+		body, _, err := proxy.GetImageDirect(mock.URL + "/image.png")
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(body)
+		assert.Equal(t, []byte("1111111111"), respBody)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("pruned when too large", func(t *testing.T) {
+		small := &imageCache{backend: fileBackend, ttl: time.Hour, maxSize: 1, index: map[string]indexedImage{}}
+		small.load()
+		require.NotEmpty(t, small.index)
+		small.prune()
+		assert.Empty(t, small.index)
+		assert.Zero(t, small.size)
+
+		paths, err := fileBackend.ListDirectoryRecursively(cacheDirectory)
+		require.NoError(t, err)
+		assert.Empty(t, paths)
+	})
+}
+
+func TestParseOptions(t *testing.T) {
+	for _, opts := range []Options{{Width: 400}, {Height: 10, Format: "jpeg"}, {Width: 1, Height: 2, Format: "png"}} {
+		parsed, err := parseOptions(opts.String())
+		require.NoError(t, err)
+		assert.Equal(t, opts, parsed)
+	}
+
+	for _, s := range []string{"400", "ax1", "1xb", "5000x0", "1x1,gif", "1x1,webp"} {
+		_, err := parseOptions(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imageproxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
+)
+
+const (
+	// MaxResizeDimension is the largest width or height an image can be resized to.
+	MaxResizeDimension = 4096
+
+	jpegQuality = 90
+)
+
+var transformFormats = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+}
+
+// Options are the transformations the local backend applies to a proxied image. Images are
+// only ever scaled down to fit in the given width and height, keeping their aspect ratio.
+type Options struct {
+	Width  int
+	Height int
+	// Format is the format to encode the image with: jpeg or png.
+	Format string
+}
+
+// IsZero returns whether the options leave the image untouched.
+func (o Options) IsZero() bool {
+	return o == Options{}
+}
+
+// String encodes the options as the first path segment of a proxied image request,
+// e.g. "400x0,png".
+func (o Options) String() string {
+	if o.IsZero() {
+		return ""
+	}
+
+	s := strconv.Itoa(o.Width) + "x" + strconv.Itoa(o.Height)
+	if o.Format != "" {
+		s += "," + o.Format
+	}
+	return s
+}
+
+// Query returns the query parameters requesting the options from the image API.
+func (o Options) Query() url.Values {
+	query := url.Values{}
+	if o.Width > 0 {
+		query.Set("width", strconv.Itoa(o.Width))
+	}
+	if o.Height > 0 {
+		query.Set("height", strconv.Itoa(o.Height))
+	}
+	if o.Format != "" {
+		query.Set("format", o.Format)
+	}
+	return query
+}
+
+func (o Options) validate() error {
+	if o.Width < 0 || o.Width > MaxResizeDimension || o.Height < 0 || o.Height > MaxResizeDimension {
+		return fmt.Errorf("dimensions must be between 0 and %d", MaxResizeDimension)
+	}
+	if _, ok := transformFormats[o.Format]; o.Format != "" && !ok {
+		return fmt.Errorf("unsupported format %q", o.Format)
+	}
+	return nil
+}
+
+// parseOptions parses options encoded by Options.String.
+func parseOptions(s string) (Options, error) {
+	var opts Options
+	if s == "" {
+		return opts, nil
+	}
+
+	size, format, _ := strings.Cut(s, ",")
+	width, height, ok := strings.Cut(size, "x")
+	if !ok {
+		return opts, fmt.Errorf("invalid size %q", size)
+	}
+
+	var err error
+	if opts.Width, err = strconv.Atoi(width); err != nil {
+		return opts, fmt.Errorf("invalid width %q", width)
+	}
+	if opts.Height, err = strconv.Atoi(height); err != nil {
+		return opts, fmt.Errorf("invalid height %q", height)
+	}
+	opts.Format = format
+
+	return opts, opts.validate()
+}
+
+// optionsFromQuery reads the options from the width, height and format query parameters.
+func optionsFromQuery(query url.Values) (Options, error) {
+	var opts Options
+
+	var err error
+	if width := query.Get("width"); width != "" {
+		if opts.Width, err = strconv.Atoi(width); err != nil {
+			return opts, fmt.Errorf("invalid width %q", width)
+		}
+	}
+	if height := query.Get("height"); height != "" {
+		if opts.Height, err = strconv.Atoi(height); err != nil {
+			return opts, fmt.Errorf("invalid height %q", height)
+		}
+	}
+	opts.Format = query.Get("format")
+
+	return opts, opts.validate()
+}
+
+// transformImage applies the options to the image data, returning the data unchanged when
+// there is nothing to do. Vector and animated images are never transformed.
+func (backend *LocalBackend) transformImage(data []byte, contentType string, opts Options) ([]byte, string, error) {
+	if opts.IsZero() || contentType == "image/svg+xml" || contentType == "image/gif" {
+		return data, contentType, nil
+	}
+
+	width, height, err := imaging.GetDimensions(bytes.NewReader(data))
+	if err != nil {
+		// Not a format we can decode, serve it as is.
+		return data, contentType, nil
+	}
+	if int64(width)*int64(height) > backend.maxImageResolution() {
+		return nil, "", fmt.Errorf("image resolution %dx%d is too large", width, height)
+	}
+
+	outputType := transformFormats[opts.Format]
+	if outputType == "" {
+		outputType = "image/jpeg"
+		if contentType == "image/png" || contentType == "image/webp" {
+			outputType = contentType
+		}
+	}
+
+	orientation, err := imaging.GetImageOrientation(bytes.NewReader(data))
+	if err != nil {
+		orientation = imaging.Upright
+	}
+	if orientation >= imaging.RotatedCWMirrored {
+		width, height = height, width
+	}
+
+	fitsWidth := opts.Width == 0 || width <= opts.Width
+	fitsHeight := opts.Height == 0 || height <= opts.Height
+	if fitsWidth && fitsHeight && outputType == contentType {
+		return data, contentType, nil
+	}
+	// WebP images can't be encoded, so they are resized to PNG to keep their transparency.
+	if outputType == "image/webp" {
+		outputType = "image/png"
+	}
+
+	img, _, release, err := backend.decoder.DecodeMemBounded(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	defer release()
+
+	img = imaging.MakeImageUpright(img, orientation)
+	img = imaging.FitImage(img, opts.Width, opts.Height)
+
+	var buf bytes.Buffer
+	switch outputType {
+	case "image/png":
+		err = backend.encoder.EncodePNG(&buf, img)
+	default:
+		err = backend.encoder.EncodeJPEG(&buf, img, jpegQuality)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), outputType, nil
+}
@@ -293,7 +293,31 @@ func (b *BleveEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
 }
 
 func (b *BleveEngine) PurgeIndexList(rctx request.CTX, indexes []string) *model.AppError {
-	return model.NewAppError("Bleve.PurgeIndex", "bleveengine.purge_list.not_implemented", nil, "not implemented", http.StatusNotFound)
+	if *b.cfg.BleveSettings.IndexDir == "" {
+		return nil
+	}
+
+	for _, index := range indexes {
+		if !isIndexName(index) {
+			return model.NewAppError("Bleveengine.PurgeIndexList", "bleveengine.purge_list.invalid_index.error", map[string]any{"Index": index}, "", http.StatusBadRequest)
+		}
+	}
+
+	b.Mutex.Lock()
+	defer b.Mutex.Unlock()
+
+	rctx.Logger().Info("PurgeIndexList Bleve", mlog.Array("indexes", indexes))
+	if err := b.closeIndexes(); err != nil {
+		return err
+	}
+
+	for _, index := range indexes {
+		if err := os.RemoveAll(b.getIndexDir(index)); err != nil {
+			return model.NewAppError("Bleveengine.PurgeIndexList", "bleveengine.purge_list.error", map[string]any{"Index": index}, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return b.openIndexes()
 }
 
 func (b *BleveEngine) DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError {
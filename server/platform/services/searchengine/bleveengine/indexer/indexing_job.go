@@ -61,6 +61,8 @@ type IndexingProgress struct {
 	StartAtTime    int64
 	EndAtTime      int64
 	LastEntityTime int64
+	// ChannelID restricts the indexing to a channel, its posts and its files.
+	ChannelID string
 
 	TotalPostsCount int64
 	DonePostsCount  int64
@@ -84,7 +86,11 @@ type IndexingProgress struct {
 }
 
 func (ip *IndexingProgress) CurrentProgress() int64 {
-	return (ip.DonePostsCount + ip.DoneChannelsCount + ip.DoneUsersCount + ip.DoneFilesCount) * 100 / (ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount + ip.TotalFilesCount)
+	total := ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount + ip.TotalFilesCount
+	if total == 0 {
+		return 0
+	}
+	return min((ip.DonePostsCount+ip.DoneChannelsCount+ip.DoneUsersCount+ip.DoneFilesCount)*100/total, 100)
 }
 
 func (ip *IndexingProgress) IsDone() bool {
@@ -233,41 +239,17 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 		progress.LastFileID = id
 	}
 
-	// Counting all posts may fail or timeout when the posts table is large. If this happens, log a warning, but carry
-	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
-	if count, err := worker.jobServer.Store.Post().AnalyticsPostCount(&model.PostCountOptions{}); err != nil {
-		logger.Warn("Worker: Failed to fetch total post count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
-		progress.TotalPostsCount = estimatedPostCount
-	} else {
-		progress.TotalPostsCount = count
-	}
-
-	// Same possible fail as above can happen when counting channels
-	if count, err := worker.jobServer.Store.Channel().AnalyticsTypeCount("", ""); err != nil {
-		logger.Warn("Worker: Failed to fetch total channel count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
-		progress.TotalChannelsCount = estimatedChannelCount
-	} else {
-		progress.TotalChannelsCount = count
-	}
-
-	// Same possible fail as above can happen when counting users
-	if count, err := worker.jobServer.Store.User().Count(model.UserCountOptions{
-		IncludeBotAccounts: true, // This actually doesn't join with the bots table
-		// since ExcludeRegularUsers is set to false
-	}); err != nil {
-		logger.Warn("Worker: Failed to fetch total user count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
-		progress.TotalUsersCount = estimatedUserCount
-	} else {
-		progress.TotalUsersCount = count
-	}
-
-	// Counting all files may fail or timeout when the file_info table is large. If this happens, log a warning, but carry
-	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
-	if count, err := worker.jobServer.Store.FileInfo().CountAll(); err != nil {
-		logger.Warn("Worker: Failed to fetch total file info count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
-		progress.TotalFilesCount = estimatedFilesCount
+	if channelID := job.Data["channel_id"]; channelID != "" {
+		progress.ChannelID = channelID
+		if err := worker.countChannelEntities(&progress); err != nil {
+			logger.Error("Worker: Failed to fetch the channel to index for job", mlog.String("channel_id", channelID), mlog.Err(err))
+			if err2 := worker.jobServer.SetJobError(job, err); err2 != nil {
+				logger.Error("Worker: Failed to set job error", mlog.Err(err2), mlog.NamedErr("set_error", err))
+			}
+			return
+		}
 	} else {
-		progress.TotalFilesCount = count
+		worker.countEntities(logger, &progress)
 	}
 
 	var cancelContext request.CTX = request.EmptyContext(worker.logger)
@@ -338,6 +320,67 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 	}
 }
 
+// countEntities sets the totals used to report the progress of a job indexing every entity.
+func (worker *BleveIndexerWorker) countEntities(logger mlog.LoggerIFace, progress *IndexingProgress) {
+	// Counting all posts may fail or timeout when the posts table is large. If this happens, log a warning, but carry
+	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
+	if count, err := worker.jobServer.Store.Post().AnalyticsPostCount(&model.PostCountOptions{}); err != nil {
+		logger.Warn("Worker: Failed to fetch total post count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
+		progress.TotalPostsCount = estimatedPostCount
+	} else {
+		progress.TotalPostsCount = count
+	}
+
+	// Same possible fail as above can happen when counting channels
+	if count, err := worker.jobServer.Store.Channel().AnalyticsTypeCount("", ""); err != nil {
+		logger.Warn("Worker: Failed to fetch total channel count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
+		progress.TotalChannelsCount = estimatedChannelCount
+	} else {
+		progress.TotalChannelsCount = count
+	}
+
+	// Same possible fail as above can happen when counting users
+	if count, err := worker.jobServer.Store.User().Count(model.UserCountOptions{
+		IncludeBotAccounts: true, // This actually doesn't join with the bots table
+		// since ExcludeRegularUsers is set to false
+	}); err != nil {
+		logger.Warn("Worker: Failed to fetch total user count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
+		progress.TotalUsersCount = estimatedUserCount
+	} else {
+		progress.TotalUsersCount = count
+	}
+
+	// Counting all files may fail or timeout when the file_info table is large. If this happens, log a warning, but carry
+	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
+	if count, err := worker.jobServer.Store.FileInfo().CountAll(); err != nil {
+		logger.Warn("Worker: Failed to fetch total file info count for job. An estimated value will be used for progress reporting.", mlog.Err(err))
+		progress.TotalFilesCount = estimatedFilesCount
+	} else {
+		progress.TotalFilesCount = count
+	}
+}
+
+// countChannelEntities sets the totals used to report the progress of a job indexing a channel.
+func (worker *BleveIndexerWorker) countChannelEntities(progress *IndexingProgress) *model.AppError {
+	channel, err := worker.jobServer.Store.Channel().Get(progress.ChannelID, true)
+	if err != nil {
+		return model.NewAppError("BleveIndexerWorker", "bleveengine.indexer.do_job.get_channel.error", map[string]any{"ChannelId": progress.ChannelID}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	fileCount, err := worker.jobServer.Store.Channel().GetFileCount(progress.ChannelID)
+	if err != nil {
+		return model.NewAppError("BleveIndexerWorker", "bleveengine.indexer.do_job.get_channel.error", map[string]any{"ChannelId": progress.ChannelID}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	progress.TotalPostsCount = channel.TotalMsgCount
+	progress.TotalChannelsCount = 1
+	progress.TotalFilesCount = fileCount
+	// Users don't belong to a channel's index entries, so they are left untouched.
+	progress.DoneUsers = true
+
+	return nil
+}
+
 func (worker *BleveIndexerWorker) IndexBatch(logger mlog.LoggerIFace, progress IndexingProgress) (IndexingProgress, *model.AppError) {
 	if !progress.DonePosts {
 		return worker.IndexPostsBatch(logger, progress)
@@ -360,7 +403,11 @@ func (worker *BleveIndexerWorker) IndexPostsBatch(logger mlog.LoggerIFace, progr
 	tries := 0
 	for posts == nil {
 		var err error
-		posts, err = worker.jobServer.Store.Post().GetPostsBatchForIndexing(progress.LastEntityTime, progress.LastPostID, *worker.jobServer.Config().BleveSettings.BatchSize)
+		if progress.ChannelID != "" {
+			posts, err = worker.jobServer.Store.Post().GetChannelPostsBatchForIndexing(progress.ChannelID, progress.LastEntityTime, progress.LastPostID, *worker.jobServer.Config().BleveSettings.BatchSize)
+		} else {
+			posts, err = worker.jobServer.Store.Post().GetPostsBatchForIndexing(progress.LastEntityTime, progress.LastPostID, *worker.jobServer.Config().BleveSettings.BatchSize)
+		}
 		if err != nil {
 			if tries >= 10 {
 				return progress, model.NewAppError("IndexPostsBatch", "app.post.get_posts_batch_for_indexing.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -428,7 +475,11 @@ func (worker *BleveIndexerWorker) IndexFilesBatch(logger mlog.LoggerIFace, progr
 	tries := 0
 	for files == nil {
 		var err error
-		files, err = worker.jobServer.Store.FileInfo().GetFilesBatchForIndexing(progress.LastEntityTime, progress.LastFileID, true, *worker.jobServer.Config().BleveSettings.BatchSize)
+		if progress.ChannelID != "" {
+			files, err = worker.jobServer.Store.FileInfo().GetChannelFilesBatchForIndexing(progress.ChannelID, progress.LastEntityTime, progress.LastFileID, true, *worker.jobServer.Config().BleveSettings.BatchSize)
+		} else {
+			files, err = worker.jobServer.Store.FileInfo().GetFilesBatchForIndexing(progress.LastEntityTime, progress.LastFileID, true, *worker.jobServer.Config().BleveSettings.BatchSize)
+		}
 		if err != nil {
 			if tries >= 10 {
 				return progress, model.NewAppError("IndexFilesBatch", "app.post.get_files_batch_for_indexing.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -490,6 +541,10 @@ func (worker *BleveIndexerWorker) BulkIndexFiles(files []*model.FileForIndexing,
 }
 
 func (worker *BleveIndexerWorker) IndexChannelsBatch(logger mlog.LoggerIFace, progress IndexingProgress) (IndexingProgress, *model.AppError) {
+	if progress.ChannelID != "" {
+		return worker.indexSingleChannel(logger, progress)
+	}
+
 	var channels []*model.Channel
 
 	tries := 0
@@ -535,6 +590,24 @@ func (worker *BleveIndexerWorker) IndexChannelsBatch(logger mlog.LoggerIFace, pr
 	return progress, nil
 }
 
+func (worker *BleveIndexerWorker) indexSingleChannel(logger mlog.LoggerIFace, progress IndexingProgress) (IndexingProgress, *model.AppError) {
+	channel, err := worker.jobServer.Store.Channel().Get(progress.ChannelID, true)
+	if err != nil {
+		return progress, model.NewAppError("BleveIndexerWorker.IndexChannelsBatch", "bleveengine.indexer.do_job.get_channel.error", map[string]any{"ChannelId": progress.ChannelID}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, appErr := worker.BulkIndexChannels(logger, []*model.Channel{channel}, progress); appErr != nil {
+		return progress, appErr
+	}
+
+	progress.DoneChannels = true
+	progress.LastEntityTime = progress.StartAtTime
+	progress.LastChannelID = channel.Id
+	progress.DoneChannelsCount++
+
+	return progress, nil
+}
+
 func (worker *BleveIndexerWorker) BulkIndexChannels(logger mlog.LoggerIFace, channels []*model.Channel, progress IndexingProgress) (*model.Channel, *model.AppError) {
 	batch := worker.engine.ChannelIndex.NewBatch()
 
@@ -68,3 +68,76 @@ func TestBleveIndexer(t *testing.T) {
 		worker.DoJob(job)
 	})
 }
+
+func TestBleveIndexerChannelScope(t *testing.T) {
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+
+	cfg := &model.Config{
+		BleveSettings: model.BleveSettings{
+			EnableIndexing: model.NewBool(true),
+			IndexDir:       model.NewString(t.TempDir()),
+			BatchSize:      model.NewInt(100),
+		},
+	}
+
+	bleveEngine := bleveengine.NewBleveEngine(cfg)
+	require.Nil(t, bleveEngine.Start())
+	t.Cleanup(func() {
+		require.Nil(t, bleveEngine.Stop())
+	})
+
+	worker := &BleveIndexerWorker{
+		jobServer: &jobs.JobServer{
+			Store: mockStore,
+			ConfigService: &testutils.StaticConfigService{
+				Cfg: cfg,
+			},
+		},
+		engine: bleveEngine,
+		logger: mlog.CreateConsoleTestLogger(t),
+	}
+
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.ChannelTypeOpen, Name: "indexed", CreateAt: 1}
+	post := &model.PostForIndexing{TeamId: channel.TeamId}
+	post.Id = model.NewId()
+	post.ChannelId = channel.Id
+	post.UserId = model.NewId()
+	post.Message = "indexed post"
+	post.CreateAt = 20
+
+	mockStore.PostStore.On("GetChannelPostsBatchForIndexing", channel.Id, int64(10), "", 100).Return([]*model.PostForIndexing{post}, nil)
+	mockStore.ChannelStore.On("Get", channel.Id, true).Return(channel, nil)
+	mockStore.ChannelStore.On("GetTeamMembersForChannel", channel.Id).Return([]string{}, nil)
+	mockStore.FileInfoStore.On("GetChannelFilesBatchForIndexing", channel.Id, int64(10), "", true, 100).Return([]*model.FileForIndexing{}, nil)
+
+	progress := IndexingProgress{
+		StartAtTime:     10,
+		EndAtTime:       20,
+		LastEntityTime:  10,
+		ChannelID:       channel.Id,
+		DoneUsers:       true,
+		TotalPostsCount: 1,
+	}
+
+	var appErr *model.AppError
+	for !progress.IsDone() {
+		progress, appErr = worker.IndexBatch(worker.logger, progress)
+		require.Nil(t, appErr)
+	}
+
+	require.Equal(t, int64(1), progress.DonePostsCount)
+	require.Equal(t, int64(1), progress.DoneChannelsCount)
+
+	count, err := bleveEngine.PostIndex.DocCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	count, err = bleveEngine.ChannelIndex.DocCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	count, err = bleveEngine.UserIndex.DocCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+}
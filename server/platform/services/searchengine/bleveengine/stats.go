@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+
+	"github.com/blevesearch/bleve/v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+var _ searchengine.IndexStatsReporter = (*BleveEngine)(nil)
+
+// IndexNames lists the indexes managed by the Bleve engine.
+var IndexNames = []string{PostIndex, FileIndex, UserIndex, ChannelIndex}
+
+func isIndexName(name string) bool {
+	for _, indexName := range IndexNames {
+		if name == indexName {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BleveEngine) getIndex(name string) bleve.Index {
+	switch name {
+	case PostIndex:
+		return b.PostIndex
+	case FileIndex:
+		return b.FileIndex
+	case UserIndex:
+		return b.UserIndex
+	case ChannelIndex:
+		return b.ChannelIndex
+	}
+	return nil
+}
+
+// GetIndexStats returns the document count, size on disk and health of every index.
+func (b *BleveEngine) GetIndexStats() ([]*model.BleveIndexStats, *model.AppError) {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if !b.IsActive() {
+		return nil, model.NewAppError("Bleveengine.GetIndexStats", "bleveengine.index_stats.not_active.error", nil, "", http.StatusServiceUnavailable)
+	}
+
+	stats := make([]*model.BleveIndexStats, 0, len(IndexNames))
+	for _, name := range IndexNames {
+		stats = append(stats, b.indexStats(name))
+	}
+	return stats, nil
+}
+
+// VerifyIndex checks that the given index can be read and that its document count is consistent,
+// returning its stats.
+func (b *BleveEngine) VerifyIndex(name string) (*model.BleveIndexStats, *model.AppError) {
+	if !isIndexName(name) {
+		return nil, model.NewAppError("Bleveengine.VerifyIndex", "bleveengine.index_stats.invalid_index.error", map[string]any{"Index": name}, "", http.StatusNotFound)
+	}
+
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if !b.IsActive() {
+		return nil, model.NewAppError("Bleveengine.VerifyIndex", "bleveengine.index_stats.not_active.error", nil, "", http.StatusServiceUnavailable)
+	}
+
+	return b.indexStats(name), nil
+}
+
+func (b *BleveEngine) indexStats(name string) *model.BleveIndexStats {
+	stats := &model.BleveIndexStats{Name: name}
+
+	size, err := dirSize(b.getIndexDir(name))
+	if err != nil {
+		stats.Error = fmt.Sprintf("failed to compute the size of the index: %s", err)
+		return stats
+	}
+	stats.SizeBytes = size
+
+	index := b.getIndex(name)
+	count, err := index.DocCount()
+	if err != nil {
+		stats.Error = fmt.Sprintf("failed to count the documents of the index: %s", err)
+		return stats
+	}
+	stats.DocCount = count
+
+	// Searching for every document reads all the segments of the index, which catches most
+	// corruptions, and the number of hits must match the document count.
+	result, err := index.Search(bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), 0, 0, false))
+	if err != nil {
+		stats.Error = fmt.Sprintf("failed to search the index: %s", err)
+		return stats
+	}
+	if result.Total != count {
+		stats.Error = fmt.Sprintf("the index holds %d documents but %d were found", count, result.Total)
+		return stats
+	}
+
+	stats.Healthy = true
+	return stats
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
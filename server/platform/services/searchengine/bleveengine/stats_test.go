@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func newTestBleveEngine(t *testing.T) *BleveEngine {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model.NewBool(true)
+	cfg.BleveSettings.IndexDir = model.NewString(t.TempDir())
+
+	engine := NewBleveEngine(cfg)
+	require.Nil(t, engine.Start())
+	t.Cleanup(func() {
+		require.Nil(t, engine.Stop())
+	})
+	return engine
+}
+
+func TestGetIndexStats(t *testing.T) {
+	t.Run("Inactive engine", func(t *testing.T) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+
+		_, appErr := NewBleveEngine(cfg).GetIndexStats()
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("Active engine", func(t *testing.T) {
+		engine := newTestBleveEngine(t)
+		post := createPost(model.NewId(), model.NewId())
+		require.NoError(t, engine.PostIndex.Index(post.Id, BLVPostFromPost(post, model.NewId())))
+
+		stats, appErr := engine.GetIndexStats()
+		require.Nil(t, appErr)
+		require.Len(t, stats, len(IndexNames))
+
+		for i, indexStats := range stats {
+			assert.Equal(t, IndexNames[i], indexStats.Name)
+			assert.True(t, indexStats.Healthy, indexStats.Error)
+			assert.Positive(t, indexStats.SizeBytes)
+		}
+		assert.Equal(t, uint64(1), stats[0].DocCount)
+		assert.Equal(t, uint64(0), stats[1].DocCount)
+	})
+}
+
+func TestVerifyIndex(t *testing.T) {
+	engine := newTestBleveEngine(t)
+
+	stats, appErr := engine.VerifyIndex(ChannelIndex)
+	require.Nil(t, appErr)
+	assert.Equal(t, ChannelIndex, stats.Name)
+	assert.True(t, stats.Healthy, stats.Error)
+
+	_, appErr = engine.VerifyIndex("unknown")
+	require.NotNil(t, appErr)
+}
+
+func TestPurgeIndexList(t *testing.T) {
+	engine := newTestBleveEngine(t)
+	rctx := request.TestContext(t)
+
+	post := createPost(model.NewId(), model.NewId())
+	require.NoError(t, engine.PostIndex.Index(post.Id, BLVPostFromPost(post, model.NewId())))
+	user := &model.User{Id: model.NewId(), Username: "purgeuser"}
+	require.NoError(t, engine.UserIndex.Index(user.Id, BLVUserFromUserAndTeams(user, nil, nil)))
+
+	require.NotNil(t, engine.PurgeIndexList(rctx, []string{"unknown"}))
+
+	require.Nil(t, engine.PurgeIndexList(rctx, []string{PostIndex}))
+
+	count, err := engine.PostIndex.DocCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	count, err = engine.UserIndex.DocCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
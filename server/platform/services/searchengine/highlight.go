@@ -0,0 +1,258 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// HighlightFragmentSize is the approximate length, in bytes, of a highlighted fragment.
+	HighlightFragmentSize = 200
+	// HighlightMaxFragments is the maximum number of fragments returned for a post.
+	HighlightMaxFragments = 3
+
+	highlightPreTag   = "<mark>"
+	highlightPostTag  = "</mark>"
+	highlightEllipsis = "…"
+)
+
+var searchTermRegex = regexp.MustCompile(`"[^"]*"|\S+`)
+
+// Suffixes a word may have on top of a search term and still match it, approximating the
+// stemming done by the full-text search of the databases.
+var highlightSuffixes = []string{"s", "es", "ed", "d", "ing", "er", "ers", "ly"}
+
+type highlightTerm struct {
+	words   []string
+	prefix  bool
+	hashtag bool
+}
+
+type highlightToken struct {
+	word       string
+	start, end int
+	hashStart  int
+}
+
+type highlightSpan struct {
+	start, end int
+}
+
+// SearchHighlighter finds the terms of a search in post messages, returning the matched words
+// and fragments of the messages with the matches wrapped in <mark> tags, the way a search engine
+// highlighter would.
+type SearchHighlighter struct {
+	terms []highlightTerm
+}
+
+// NewSearchHighlighter creates a highlighter for the terms of the given search params. Excluded
+// terms are never highlighted.
+func NewSearchHighlighter(paramsList []*model.SearchParams) *SearchHighlighter {
+	h := &SearchHighlighter{}
+	for _, params := range paramsList {
+		for _, term := range searchTermRegex.FindAllString(params.Terms, -1) {
+			t := highlightTerm{hashtag: params.IsHashtag}
+			term = strings.ToLower(strings.Trim(term, `"`))
+			if strings.HasSuffix(term, "*") {
+				t.prefix = true
+				term = strings.TrimRight(term, "*")
+			}
+			for _, token := range tokenizeForHighlight(term) {
+				t.words = append(t.words, token.word)
+			}
+			if len(t.words) > 0 {
+				h.terms = append(h.terms, t)
+			}
+		}
+	}
+	return h
+}
+
+// IsEmpty returns whether there is no term to highlight.
+func (h *SearchHighlighter) IsEmpty() bool {
+	return len(h.terms) == 0
+}
+
+// Highlight returns the distinct words of the message matching the search terms, in their
+// order of appearance, and up to HighlightMaxFragments HTML-escaped fragments of the message.
+func (h *SearchHighlighter) Highlight(message string) ([]string, []string) {
+	tokens := tokenizeForHighlight(message)
+
+	var spans []highlightSpan
+	for _, term := range h.terms {
+		for i := 0; i+len(term.words) <= len(tokens); i++ {
+			if !term.matches(tokens[i : i+len(term.words)]) {
+				continue
+			}
+			start := tokens[i].start
+			if term.hashtag {
+				start = tokens[i].hashStart
+			}
+			spans = append(spans, highlightSpan{start: start, end: tokens[i+len(term.words)-1].end})
+		}
+	}
+	if len(spans) == 0 {
+		return nil, nil
+	}
+	spans = mergeHighlightSpans(spans)
+
+	matches := []string{}
+	seen := map[string]bool{}
+	for _, span := range spans {
+		match := message[span.start:span.end]
+		if !seen[match] {
+			seen[match] = true
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, highlightFragments(message, spans)
+}
+
+func (t highlightTerm) matches(tokens []highlightToken) bool {
+	if t.hashtag && tokens[0].hashStart == tokens[0].start {
+		return false
+	}
+	for i, word := range t.words {
+		token := tokens[i].word
+		if t.prefix && i == len(t.words)-1 {
+			if !strings.HasPrefix(token, word) {
+				return false
+			}
+			continue
+		}
+		if token == word {
+			continue
+		}
+		if t.hashtag || !strings.HasPrefix(token, word) || !isHighlightSuffix(token[len(word):]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHighlightSuffix(s string) bool {
+	for _, suffix := range highlightSuffixes {
+		if s == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeForHighlight splits the text into lowercase words, keeping their offsets. The
+// hashStart of a word preceded by a # is the offset of the #.
+func tokenizeForHighlight(text string) []highlightToken {
+	var tokens []highlightToken
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		if isWordRune && start < 0 {
+			start = i
+		} else if !isWordRune && start >= 0 {
+			tokens = append(tokens, newHighlightToken(text, start, i))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, newHighlightToken(text, start, len(text)))
+	}
+	return tokens
+}
+
+func newHighlightToken(text string, start, end int) highlightToken {
+	token := highlightToken{word: strings.ToLower(text[start:end]), start: start, end: end, hashStart: start}
+	if start > 0 && text[start-1] == '#' {
+		token.hashStart = start - 1
+	}
+	return token
+}
+
+func mergeHighlightSpans(spans []highlightSpan) []highlightSpan {
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.start <= last.end {
+			last.end = max(last.end, span.end)
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// highlightFragments cuts fragments of about HighlightFragmentSize bytes around the spans,
+// at word boundaries, wrapping the spans in <mark> tags.
+func highlightFragments(message string, spans []highlightSpan) []string {
+	var fragments []string
+	for i := 0; i < len(spans) && len(fragments) < HighlightMaxFragments; {
+		first := spans[i]
+		context := max(0, HighlightFragmentSize-(first.end-first.start)) / 2
+		start := fragmentBoundary(message, first.start-context, true)
+		end := fragmentBoundary(message, max(first.end, start+HighlightFragmentSize), false)
+
+		var b strings.Builder
+		if start > 0 {
+			b.WriteString(highlightEllipsis)
+		}
+		offset := start
+		for ; i < len(spans) && spans[i].end <= end; i++ {
+			b.WriteString(html.EscapeString(message[offset:spans[i].start]))
+			b.WriteString(highlightPreTag)
+			b.WriteString(html.EscapeString(message[spans[i].start:spans[i].end]))
+			b.WriteString(highlightPostTag)
+			offset = spans[i].end
+		}
+		b.WriteString(html.EscapeString(message[offset:end]))
+		if end < len(message) {
+			b.WriteString(highlightEllipsis)
+		}
+
+		fragments = append(fragments, strings.TrimSpace(b.String()))
+	}
+	return fragments
+}
+
+// fragmentBoundary moves the offset to the closest whitespace, looking backward for the
+// start of a fragment and forward for its end.
+func fragmentBoundary(message string, offset int, backward bool) int {
+	if offset <= 0 {
+		return 0
+	}
+	if offset >= len(message) {
+		return len(message)
+	}
+
+	if backward {
+		for offset > 0 {
+			r, size := utf8.DecodeLastRuneInString(message[:offset])
+			if unicode.IsSpace(r) {
+				return offset
+			}
+			offset -= size
+		}
+		return 0
+	}
+
+	for offset < len(message) {
+		r, size := utf8.DecodeRuneInString(message[offset:])
+		if unicode.IsSpace(r) {
+			return offset
+		}
+		offset += size
+	}
+	return len(message)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSearchHighlighter(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Params            []*model.SearchParams
+		Message           string
+		ExpectedMatches   []string
+		ExpectedFragments []string
+	}{
+		{
+			Name:              "Single term",
+			Params:            []*model.SearchParams{{Terms: "deploy"}},
+			Message:           "Deploy the release, then deploy the docs",
+			ExpectedMatches:   []string{"Deploy", "deploy"},
+			ExpectedFragments: []string{"<mark>Deploy</mark> the release, then <mark>deploy</mark> the docs"},
+		},
+		{
+			Name:              "Stemmed word",
+			Params:            []*model.SearchParams{{Terms: "deploy"}},
+			Message:           "We deployed and are deploying",
+			ExpectedMatches:   []string{"deployed", "deploying"},
+			ExpectedFragments: []string{"We <mark>deployed</mark> and are <mark>deploying</mark>"},
+		},
+		{
+			Name:              "Wildcard",
+			Params:            []*model.SearchParams{{Terms: "dep*"}},
+			Message:           "The departure of the deputy",
+			ExpectedMatches:   []string{"departure", "deputy"},
+			ExpectedFragments: []string{"The <mark>departure</mark> of the <mark>deputy</mark>"},
+		},
+		{
+			Name:              "Phrase",
+			Params:            []*model.SearchParams{{Terms: `"release notes"`}},
+			Message:           "Notes: the release notes are out, release soon",
+			ExpectedMatches:   []string{"release notes"},
+			ExpectedFragments: []string{"Notes: the <mark>release notes</mark> are out, release soon"},
+		},
+		{
+			Name:              "Hashtag",
+			Params:            []*model.SearchParams{{Terms: "#bug", IsHashtag: true}},
+			Message:           "a bug, #bug and #bugs",
+			ExpectedMatches:   []string{"#bug"},
+			ExpectedFragments: []string{"a bug, <mark>#bug</mark> and #bugs"},
+		},
+		{
+			Name:              "Escaped HTML",
+			Params:            []*model.SearchParams{{Terms: "script"}},
+			Message:           "<script>alert(1)</script>",
+			ExpectedMatches:   []string{"script"},
+			ExpectedFragments: []string{"&lt;<mark>script</mark>&gt;alert(1)&lt;/<mark>script</mark>&gt;"},
+		},
+		{
+			Name:    "No match",
+			Params:  []*model.SearchParams{{Terms: "deploy", ExcludedTerms: "release"}},
+			Message: "The release",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			matches, fragments := NewSearchHighlighter(tc.Params).Highlight(tc.Message)
+			assert.Equal(t, tc.ExpectedMatches, matches)
+			assert.Equal(t, tc.ExpectedFragments, fragments)
+		})
+	}
+
+	t.Run("Long message", func(t *testing.T) {
+		filler := strings.Repeat("lorem ipsum ", 50)
+		message := "first match " + filler + "second match " + filler + "third match " + filler + "fourth match"
+
+		_, fragments := NewSearchHighlighter([]*model.SearchParams{{Terms: "match"}}).Highlight(message)
+		assert.Len(t, fragments, HighlightMaxFragments)
+		assert.True(t, strings.HasPrefix(fragments[0], "first <mark>match</mark> lorem"))
+		assert.True(t, strings.HasSuffix(fragments[0], "…"))
+		assert.True(t, strings.HasPrefix(fragments[1], "…"))
+		assert.Contains(t, fragments[1], "second <mark>match</mark>")
+		for _, fragment := range fragments {
+			assert.Less(t, len(fragment), HighlightFragmentSize+50)
+		}
+	})
+}
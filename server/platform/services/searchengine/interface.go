@@ -52,3 +52,10 @@ type SearchEngineInterface interface {
 	DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError
 	IsChannelsIndexVerified() bool
 }
+
+// IndexStatsReporter is implemented by the search engines reporting on the health of their
+// indexes, as Bleve does.
+type IndexStatsReporter interface {
+	GetIndexStats() ([]*model.BleveIndexStats, *model.AppError)
+	VerifyIndex(name string) (*model.BleveIndexStats, *model.AppError)
+}
@@ -166,6 +166,26 @@ func (_m *MockAppIface) CreateUploadSession(c request.CTX, us *model.UploadSessi
 	return r0, r1
 }
 
+// DeleteAcknowledgementForPost provides a mock function with given fields: c, postID, userID
+func (_m *MockAppIface) DeleteAcknowledgementForPost(c request.CTX, postID string, userID string) *model.AppError {
+	ret := _m.Called(c, postID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAcknowledgementForPost")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) *model.AppError); ok {
+		r0 = rf(c, postID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeletePost provides a mock function with given fields: c, postID, deleteByID
 func (_m *MockAppIface) DeletePost(c request.CTX, postID string, deleteByID string) (*model.Post, *model.AppError) {
 	ret := _m.Called(c, postID, deleteByID)
@@ -474,6 +494,58 @@ func (_m *MockAppIface) Publish(message *model.WebSocketEvent) {
 	_m.Called(message)
 }
 
+// RemoveRemoteUserFromChannel provides a mock function with given fields: c, userID, channel
+func (_m *MockAppIface) RemoveRemoteUserFromChannel(c request.CTX, userID string, channel *model.Channel) *model.AppError {
+	ret := _m.Called(c, userID, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRemoteUserFromChannel")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(request.CTX, string, *model.Channel) *model.AppError); ok {
+		r0 = rf(c, userID, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// SaveAcknowledgementForPost provides a mock function with given fields: c, postID, userID
+func (_m *MockAppIface) SaveAcknowledgementForPost(c request.CTX, postID string, userID string) (*model.PostAcknowledgement, *model.AppError) {
+	ret := _m.Called(c, postID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAcknowledgementForPost")
+	}
+
+	var r0 *model.PostAcknowledgement
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) (*model.PostAcknowledgement, *model.AppError)); ok {
+		return rf(c, postID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) *model.PostAcknowledgement); ok {
+		r0 = rf(c, postID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostAcknowledgement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string) *model.AppError); ok {
+		r1 = rf(c, postID, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SaveReactionForPost provides a mock function with given fields: c, reaction
 func (_m *MockAppIface) SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError) {
 	ret := _m.Called(c, reaction)
@@ -58,12 +58,15 @@ type AppIface interface {
 	UserCanSeeOtherUser(c request.CTX, userID string, otherUserId string) (bool, *model.AppError)
 	AddUserToChannel(c request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
 	AddUserToTeamByTeamId(c request.CTX, teamId string, user *model.User) *model.AppError
+	RemoveRemoteUserFromChannel(c request.CTX, userID string, channel *model.Channel) *model.AppError
 	PermanentDeleteChannel(c request.CTX, channel *model.Channel) *model.AppError
 	CreatePost(c request.CTX, post *model.Post, channel *model.Channel, triggerWebhooks bool, setOnline bool) (savedPost *model.Post, err *model.AppError)
 	UpdatePost(c request.CTX, post *model.Post, safeUpdate bool) (*model.Post, *model.AppError)
 	DeletePost(c request.CTX, postID, deleteByID string) (*model.Post, *model.AppError)
 	SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	DeleteReactionForPost(c request.CTX, reaction *model.Reaction) *model.AppError
+	SaveAcknowledgementForPost(c request.CTX, postID, userID string) (*model.PostAcknowledgement, *model.AppError)
+	DeleteAcknowledgementForPost(c request.CTX, postID, userID string) *model.AppError
 	PatchChannelModerationsForChannel(c request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
 	CreateUploadSession(c request.CTX, us *model.UploadSession) (*model.UploadSession, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
@@ -99,16 +102,18 @@ type Service struct {
 	inviteTopicListenerId     string
 	uploadTopicListenerId     string
 	siteURL                   *url.URL
+	remoteFeatures            map[string][]string // remoteId -> optional sync features supported by the remote
 }
 
 // NewSharedChannelService creates a RemoteClusterService instance.
 func NewSharedChannelService(server ServerIface, platform PlatformIface, app AppIface) (*Service, error) {
 	service := &Service{
-		server:       server,
-		platform:     platform,
-		app:          app,
-		changeSignal: make(chan struct{}, 1),
-		tasks:        make(map[string]syncTask),
+		server:         server,
+		platform:       platform,
+		app:            app,
+		changeSignal:   make(chan struct{}, 1),
+		tasks:          make(map[string]syncTask),
+		remoteFeatures: make(map[string][]string),
 	}
 	parsed, err := url.Parse(*server.Config().ServiceSettings.SiteURL)
 	if err != nil {
@@ -244,6 +249,9 @@ func (scs *Service) makeChannelReadOnly(channel *model.Channel) *model.AppError
 // onConnectionStateChange is called whenever the connection state of a remote cluster changes,
 // for example when one comes back online.
 func (scs *Service) onConnectionStateChange(rc *model.RemoteCluster, online bool) {
+	// the remote may have been upgraded or downgraded while offline.
+	scs.clearRemoteFeatures(rc.RemoteId)
+
 	if online {
 		// when a previously offline remote comes back online force a sync.
 		scs.ForceSyncForRemote(rc)
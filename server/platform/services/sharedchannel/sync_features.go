@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// getRemoteFeatures returns the optional sync features supported by a remote cluster.
+// Features are cached per remote and refreshed from every sync response, so an upgraded
+// or downgraded remote is picked up on its next sync. When not yet known, the remote is
+// probed with an empty sync message for the channel since every remote responds to a sync
+// message with the features it supports. Remotes that predate feature negotiation respond
+// without any.
+// Plugin remotes are not probed and are only sent posts, reactions and users.
+func (scs *Service) getRemoteFeatures(rc *model.RemoteCluster, channelID string) []string {
+	if rc.IsPlugin() {
+		return nil
+	}
+
+	scs.mux.RLock()
+	features, ok := scs.remoteFeatures[rc.RemoteId]
+	scs.mux.RUnlock()
+	if ok {
+		return features
+	}
+
+	var probed bool
+	msg := model.NewSyncMsg(channelID)
+	err := scs.sendSyncMsgToRemote(msg, rc, func(syncResp model.SyncResponse, errResp error) {
+		if errResp != nil {
+			return
+		}
+		features = syncResp.Features
+		probed = true
+	})
+	if err != nil || !probed {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Cannot determine sync features supported by remote",
+			mlog.String("remote", rc.DisplayName),
+			mlog.String("channel_id", channelID),
+			mlog.Err(err),
+		)
+		return nil
+	}
+
+	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Sync features supported by remote",
+		mlog.String("remote", rc.DisplayName),
+		mlog.Array("features", features),
+	)
+	return features
+}
+
+// setRemoteFeatures caches the sync features a remote cluster reported in a sync response.
+func (scs *Service) setRemoteFeatures(remoteID string, features []string) {
+	scs.mux.Lock()
+	defer scs.mux.Unlock()
+	if scs.remoteFeatures == nil {
+		scs.remoteFeatures = make(map[string][]string)
+	}
+	scs.remoteFeatures[remoteID] = features
+}
+
+// clearRemoteFeatures forgets the cached sync features for a remote cluster, forcing them
+// to be determined again on the next sync.
+func (scs *Service) clearRemoteFeatures(remoteID string) {
+	scs.mux.Lock()
+	defer scs.mux.Unlock()
+	delete(scs.remoteFeatures, remoteID)
+}
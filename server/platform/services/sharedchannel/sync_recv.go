@@ -57,6 +57,7 @@ func (scs *Service) processSyncMessage(c request.CTX, syncMsg *model.SyncMsg, rc
 		UsersSyncd:     make([]string, 0),
 		PostErrors:     make([]string, 0),
 		ReactionErrors: make([]string, 0),
+		Features:       model.SupportedSyncFeatures(),
 	}
 
 	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Sync msg received",
@@ -65,6 +66,10 @@ func (scs *Service) processSyncMessage(c request.CTX, syncMsg *model.SyncMsg, rc
 		mlog.Int("user_count", len(syncMsg.Users)),
 		mlog.Int("post_count", len(syncMsg.Posts)),
 		mlog.Int("reaction_count", len(syncMsg.Reactions)),
+		mlog.Int("acknowledgement_count", len(syncMsg.Acknowledgements)),
+		mlog.Bool("channel_info", syncMsg.Channel != nil),
+		mlog.Int("membership_change_count", len(syncMsg.MembershipChanges)),
+		mlog.Int("bookmark_count", len(syncMsg.Bookmarks)),
 	)
 
 	if targetChannel, err = scs.server.GetStore().Channel().Get(syncMsg.ChannelId, true); err != nil {
@@ -175,6 +180,56 @@ func (scs *Service) processSyncMessage(c request.CTX, syncMsg *model.SyncMsg, rc
 		}
 	}
 
+	// add/remove acknowledgements
+	for postID, acks := range syncMsg.Acknowledgements {
+		if err := scs.upsertSyncAcknowledgements(c, postID, acks, targetChannel, rc); err != nil {
+			syncResp.AcknowledgementErrors = append(syncResp.AcknowledgementErrors, postID)
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error upserting sync acknowledgements",
+				mlog.String("remote", rc.Name),
+				mlog.String("post_id", postID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// update channel header/purpose
+	if syncMsg.Channel != nil {
+		if err := scs.updateSyncChannelInfo(c, syncMsg.Channel, targetChannel); err != nil {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error updating sync channel header/purpose",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// add/remove channel members
+	for _, change := range syncMsg.MembershipChanges {
+		if err := scs.processSyncMembershipChange(c, change, targetChannel, rc); err != nil {
+			syncResp.MembershipErrors = append(syncResp.MembershipErrors, change.UserId)
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error processing sync membership change",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("user_id", change.UserId),
+				mlog.Bool("is_add", change.IsAdd),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// add/update/delete bookmarks
+	for _, bookmark := range syncMsg.Bookmarks {
+		if err := scs.upsertSyncBookmark(bookmark, targetChannel, rc); err != nil {
+			syncResp.BookmarkErrors = append(syncResp.BookmarkErrors, bookmark.Id)
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error upserting sync bookmark",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("bookmark_id", bookmark.Id),
+				mlog.Err(err),
+			)
+		}
+	}
+
 	response.SetPayload(syncResp)
 
 	return nil
@@ -463,3 +518,248 @@ func (scs *Service) upsertSyncReaction(reaction *model.Reaction, targetChannel *
 	}
 	return savedReaction, retErr
 }
+
+// upsertSyncAcknowledgements reconciles the acknowledgements of a post with the full set of acknowledgements
+// sent by the remote. Only acknowledgements by users belonging to the remote are added or removed.
+func (scs *Service) upsertSyncAcknowledgements(c request.CTX, postID string, acks []*model.PostAcknowledgement, targetChannel *model.Channel, rc *model.RemoteCluster) error {
+	post, err := scs.server.GetStore().Post().GetSingle(c, postID, false)
+	if err != nil {
+		return fmt.Errorf("error fetching post for acknowledgement sync: %w", err)
+	}
+	if post.ChannelId != targetChannel.Id {
+		return fmt.Errorf("acknowledgement sync failed: %w", ErrChannelIDMismatch)
+	}
+
+	existing, err := scs.server.GetStore().PostAcknowledgement().GetForPost(postID)
+	if err != nil {
+		return fmt.Errorf("error fetching acknowledgements for sync: %w", err)
+	}
+
+	remoteUsers := make(map[string]bool)
+	isRemoteUser := func(userID string) (bool, error) {
+		if isRemote, ok := remoteUsers[userID]; ok {
+			return isRemote, nil
+		}
+		user, err := scs.server.GetStore().User().Get(context.TODO(), userID)
+		if err != nil {
+			return false, fmt.Errorf("error fetching user for acknowledgement sync: %w", err)
+		}
+		remoteUsers[userID] = user.GetRemoteID() == rc.RemoteId
+		return remoteUsers[userID], nil
+	}
+
+	acked := make(map[string]bool, len(existing))
+	for _, ack := range existing {
+		acked[ack.UserId] = true
+	}
+
+	wanted := make(map[string]bool, len(acks))
+	for _, ack := range acks {
+		if ack.PostId != postID {
+			return fmt.Errorf("acknowledgement sync failed: post id mismatch")
+		}
+		isRemote, err := isRemoteUser(ack.UserId)
+		if err != nil {
+			return err
+		}
+		if !isRemote {
+			continue
+		}
+		wanted[ack.UserId] = true
+
+		if !acked[ack.UserId] {
+			if _, appErr := scs.app.SaveAcknowledgementForPost(c, postID, ack.UserId); appErr != nil {
+				return fmt.Errorf("error saving acknowledgement for user %s: %w", ack.UserId, appErr)
+			}
+		}
+	}
+
+	for _, ack := range existing {
+		if wanted[ack.UserId] {
+			continue
+		}
+		isRemote, err := isRemoteUser(ack.UserId)
+		if err != nil {
+			return err
+		}
+		if isRemote {
+			if appErr := scs.app.DeleteAcknowledgementForPost(c, postID, ack.UserId); appErr != nil {
+				return fmt.Errorf("error deleting acknowledgement for user %s: %w", ack.UserId, appErr)
+			}
+		}
+	}
+	return nil
+}
+
+// updateSyncChannelInfo updates the channel header and purpose unless the channel was updated
+// locally more recently than on the remote.
+func (scs *Service) updateSyncChannelInfo(c request.CTX, info *model.SyncChannelInfo, targetChannel *model.Channel) error {
+	if info.Header == targetChannel.Header && info.Purpose == targetChannel.Purpose {
+		return nil
+	}
+
+	if info.UpdateAt < targetChannel.UpdateAt {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Update to sync channel header/purpose ignored; local channel is newer",
+			mlog.String("channel_id", targetChannel.Id),
+		)
+		return nil
+	}
+
+	channel := targetChannel.DeepCopy()
+	channel.Header = info.Header
+	channel.Purpose = info.Purpose
+
+	updated, err := scs.server.GetStore().Channel().Update(c, channel)
+	if err != nil {
+		return fmt.Errorf("error updating channel: %w", err)
+	}
+
+	scs.platform.InvalidateCacheForChannel(updated)
+	scs.notifyClientsForSharedChannelUpdate(updated)
+	return nil
+}
+
+// processSyncMembershipChange adds or removes a user belonging to the remote to/from the channel.
+func (scs *Service) processSyncMembershipChange(c request.CTX, change *model.SyncMembershipChange, targetChannel *model.Channel, rc *model.RemoteCluster) error {
+	// membership of DMs and GMs is fixed.
+	if targetChannel.IsGroupOrDirect() {
+		return nil
+	}
+
+	user, err := scs.server.GetStore().User().Get(context.TODO(), change.UserId)
+	if err != nil {
+		return fmt.Errorf("error fetching user for membership sync: %w", err)
+	}
+	if user.GetRemoteID() != rc.RemoteId {
+		return fmt.Errorf("membership sync failed: %w", ErrRemoteIDMismatch)
+	}
+
+	if change.IsAdd {
+		if err := scs.app.AddUserToTeamByTeamId(c, targetChannel.TeamId, user); err != nil {
+			return fmt.Errorf("error adding sync user to Team: %w", err)
+		}
+		if _, err := scs.app.AddUserToChannel(c, user, targetChannel, false); err != nil {
+			return fmt.Errorf("error adding sync user to ChannelMembers: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := scs.server.GetStore().Channel().GetMember(context.TODO(), targetChannel.Id, user.Id); err != nil {
+		if isNotFoundError(err) {
+			return nil // already removed
+		}
+		return fmt.Errorf("error fetching channel member for membership sync: %w", err)
+	}
+
+	if appErr := scs.app.RemoveRemoteUserFromChannel(c, user.Id, targetChannel); appErr != nil {
+		return fmt.Errorf("error removing sync user from ChannelMembers: %w", appErr)
+	}
+	return nil
+}
+
+// upsertSyncBookmark creates, updates or deletes a link bookmark. The bookmark must be owned by a user
+// belonging to the remote, so a remote can't change the bookmarks of local users or of other remotes.
+func (scs *Service) upsertSyncBookmark(bookmark *model.ChannelBookmark, targetChannel *model.Channel, rc *model.RemoteCluster) error {
+	if bookmark.ChannelId != targetChannel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+	if bookmark.Type != model.ChannelBookmarkLink {
+		return fmt.Errorf("bookmark sync failed: unsupported bookmark type %s", bookmark.Type)
+	}
+
+	existing, err := scs.server.GetStore().ChannelBookmark().Get(bookmark.Id, true)
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("error fetching bookmark for sync: %w", err)
+	}
+
+	if existing == nil {
+		if bookmark.DeleteAt > 0 {
+			return nil // nothing to delete
+		}
+
+		if err := scs.checkSyncBookmarkOwner(bookmark.OwnerId, rc); err != nil {
+			return err
+		}
+
+		saved, err := scs.server.GetStore().ChannelBookmark().Save(bookmark.Clone(), false)
+		if err != nil {
+			return fmt.Errorf("error saving bookmark: %w", err)
+		}
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, "bookmark", saved)
+		return nil
+	}
+
+	if existing.ChannelId != targetChannel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+
+	// bookmarks deleted locally stay deleted.
+	if existing.DeleteAt > 0 {
+		return nil
+	}
+
+	if bookmark.DeleteAt == 0 && existing.DisplayName == bookmark.DisplayName && existing.LinkUrl == bookmark.LinkUrl &&
+		existing.ImageUrl == bookmark.ImageUrl && existing.Emoji == bookmark.Emoji && existing.SortOrder == bookmark.SortOrder {
+		return nil // nothing to update
+	}
+
+	if err := scs.checkSyncBookmarkOwner(existing.OwnerId, rc); err != nil {
+		return err
+	}
+
+	if bookmark.DeleteAt > 0 {
+		if err := scs.server.GetStore().ChannelBookmark().Delete(bookmark.Id, false); err != nil {
+			return fmt.Errorf("error deleting bookmark: %w", err)
+		}
+		existing.DeleteAt = bookmark.DeleteAt
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, "bookmark", existing)
+		return nil
+	}
+
+	update := existing.ChannelBookmark.Clone()
+	update.DisplayName = bookmark.DisplayName
+	update.LinkUrl = bookmark.LinkUrl
+	update.ImageUrl = bookmark.ImageUrl
+	update.Emoji = bookmark.Emoji
+	update.SortOrder = bookmark.SortOrder
+
+	if err := scs.server.GetStore().ChannelBookmark().Update(update); err != nil {
+		return fmt.Errorf("error updating bookmark: %w", err)
+	}
+	scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, "bookmarks", &model.UpdateChannelBookmarkResponse{
+		Updated: update.ToBookmarkWithFileInfo(nil),
+	})
+	return nil
+}
+
+// checkSyncBookmarkOwner checks that a bookmark synced from a remote is owned by a user of that remote.
+func (scs *Service) checkSyncBookmarkOwner(ownerID string, rc *model.RemoteCluster) error {
+	user, err := scs.server.GetStore().User().Get(context.TODO(), ownerID)
+	if err != nil {
+		return fmt.Errorf("error fetching user for bookmark sync: %w", err)
+	}
+	if user.GetRemoteID() != rc.RemoteId {
+		return fmt.Errorf("bookmark sync failed: %w", ErrRemoteIDMismatch)
+	}
+	return nil
+}
+
+func (scs *Service) publishBookmarkEvent(event model.WebsocketEventType, key string, data any) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Cannot marshal bookmark for websocket event", mlog.Err(err))
+		return
+	}
+
+	var channelID string
+	switch v := data.(type) {
+	case *model.ChannelBookmarkWithFileInfo:
+		channelID = v.ChannelId
+	case *model.UpdateChannelBookmarkResponse:
+		channelID = v.Updated.ChannelId
+	}
+
+	message := model.NewWebSocketEvent(event, "", channelID, "", nil, "")
+	message.Add(key, string(b))
+	scs.app.Publish(message)
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+)
+
+func setupSyncRecvTest(t *testing.T) (*Service, *mocks.Store, *MockAppIface) {
+	mockServer := &MockServerIface{}
+	logger := mlog.CreateConsoleTestLogger(t)
+	mockServer.On("Log").Return(logger)
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+	mockApp := &MockAppIface{}
+
+	scs := &Service{
+		server: mockServer,
+		app:    mockApp,
+	}
+	return scs, mockStore, mockApp
+}
+
+func TestProcessSyncMessageFeatures(t *testing.T) {
+	scs, mockStore, _ := setupSyncRecvTest(t)
+
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.ChannelTypeOpen}
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+
+	mockChannelStore := &mocks.ChannelStore{}
+	mockChannelStore.On("Get", channel.Id, true).Return(channel, nil)
+	mockSharedChannelStore := &mocks.SharedChannelStore{}
+	mockSharedChannelStore.On("HasRemote", channel.Id, rc.RemoteId).Return(true, nil)
+	mockStore.On("Channel").Return(mockChannelStore)
+	mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+	response := &remotecluster.Response{}
+	err := scs.processSyncMessage(request.TestContext(t), model.NewSyncMsg(channel.Id), rc, response)
+	require.NoError(t, err)
+
+	var syncResp model.SyncResponse
+	require.NoError(t, json.Unmarshal(response.Payload, &syncResp))
+	assert.ElementsMatch(t, model.SupportedSyncFeatures(), syncResp.Features)
+}
+
+func TestProcessSyncMembershipChange(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.ChannelTypeOpen}
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+
+	t.Run("user not belonging to the remote is rejected", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		user := &model.User{Id: model.NewId()}
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, user.Id).Return(user, nil)
+		mockStore.On("User").Return(mockUserStore)
+
+		change := &model.SyncMembershipChange{UserId: user.Id, IsAdd: false, ChangeTime: model.GetMillis()}
+		err := scs.processSyncMembershipChange(request.TestContext(t), change, channel, rc)
+		require.ErrorIs(t, err, ErrRemoteIDMismatch)
+		mockApp.AssertNotCalled(t, "RemoveRemoteUserFromChannel", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("remote user is added to team and channel", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		user := &model.User{Id: model.NewId(), RemoteId: model.NewString(rc.RemoteId)}
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, user.Id).Return(user, nil)
+		mockStore.On("User").Return(mockUserStore)
+
+		mockApp.On("AddUserToTeamByTeamId", mock.Anything, channel.TeamId, user).Return(nil).Once()
+		mockApp.On("AddUserToChannel", mock.Anything, user, channel, false).Return(&model.ChannelMember{}, nil).Once()
+		defer mockApp.AssertExpectations(t)
+
+		change := &model.SyncMembershipChange{UserId: user.Id, IsAdd: true, ChangeTime: model.GetMillis()}
+		err := scs.processSyncMembershipChange(request.TestContext(t), change, channel, rc)
+		require.NoError(t, err)
+	})
+
+	t.Run("remote user is removed from channel", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		user := &model.User{Id: model.NewId(), RemoteId: model.NewString(rc.RemoteId)}
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, user.Id).Return(user, nil)
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("GetMember", mockTypeContext, channel.Id, user.Id).Return(&model.ChannelMember{}, nil)
+		mockStore.On("User").Return(mockUserStore)
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		mockApp.On("RemoveRemoteUserFromChannel", mock.Anything, user.Id, channel).Return(nil).Once()
+		defer mockApp.AssertExpectations(t)
+
+		change := &model.SyncMembershipChange{UserId: user.Id, IsAdd: false, ChangeTime: model.GetMillis()}
+		err := scs.processSyncMembershipChange(request.TestContext(t), change, channel, rc)
+		require.NoError(t, err)
+	})
+
+	t.Run("remote user that is not a member is ignored", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		user := &model.User{Id: model.NewId(), RemoteId: model.NewString(rc.RemoteId)}
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, user.Id).Return(user, nil)
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("GetMember", mockTypeContext, channel.Id, user.Id).Return(nil, store.NewErrNotFound("ChannelMember", user.Id))
+		mockStore.On("User").Return(mockUserStore)
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		change := &model.SyncMembershipChange{UserId: user.Id, IsAdd: false, ChangeTime: model.GetMillis()}
+		err := scs.processSyncMembershipChange(request.TestContext(t), change, channel, rc)
+		require.NoError(t, err)
+		mockApp.AssertNotCalled(t, "RemoveRemoteUserFromChannel", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateSyncChannelInfo(t *testing.T) {
+	t.Run("unchanged header and purpose are ignored", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		channel := &model.Channel{Id: model.NewId(), Header: "header", Purpose: "purpose", UpdateAt: 100}
+		info := &model.SyncChannelInfo{Header: "header", Purpose: "purpose", UpdateAt: 200}
+
+		err := scs.updateSyncChannelInfo(request.TestContext(t), info, channel)
+		require.NoError(t, err)
+		mockStore.AssertNotCalled(t, "Channel")
+	})
+
+	t.Run("older remote update is ignored", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		channel := &model.Channel{Id: model.NewId(), Header: "new header", UpdateAt: 200}
+		info := &model.SyncChannelInfo{Header: "old header", UpdateAt: 100}
+
+		err := scs.updateSyncChannelInfo(request.TestContext(t), info, channel)
+		require.NoError(t, err)
+		mockStore.AssertNotCalled(t, "Channel")
+	})
+}
+
+func TestUpsertSyncBookmark(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.ChannelTypeOpen}
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+
+	newBookmark := func(ownerID string) *model.ChannelBookmark {
+		return &model.ChannelBookmark{
+			Id:          model.NewId(),
+			CreateAt:    model.GetMillis(),
+			UpdateAt:    model.GetMillis(),
+			ChannelId:   channel.Id,
+			OwnerId:     ownerID,
+			DisplayName: "Mattermost",
+			LinkUrl:     "https://mattermost.com",
+			Type:        model.ChannelBookmarkLink,
+		}
+	}
+
+	t.Run("new bookmark owned by a remote user is saved", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		owner := &model.User{Id: model.NewId(), RemoteId: model.NewString(rc.RemoteId)}
+		bookmark := newBookmark(owner.Id)
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id))
+		mockBookmarkStore.On("Save", mock.AnythingOfType("*model.ChannelBookmark"), false).Return(bookmark.ToBookmarkWithFileInfo(nil), nil).Once()
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, owner.Id).Return(owner, nil)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+		mockStore.On("User").Return(mockUserStore)
+
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelBookmarkCreated && ev.GetBroadcast().ChannelId == channel.Id
+		})).Once()
+		defer mockApp.AssertExpectations(t)
+
+		err := scs.upsertSyncBookmark(bookmark, channel, rc)
+		require.NoError(t, err)
+		mockBookmarkStore.AssertExpectations(t)
+	})
+
+	t.Run("new bookmark owned by a local user is rejected", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		owner := &model.User{Id: model.NewId()}
+		bookmark := newBookmark(owner.Id)
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id))
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, owner.Id).Return(owner, nil)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+		mockStore.On("User").Return(mockUserStore)
+
+		err := scs.upsertSyncBookmark(bookmark, channel, rc)
+		require.ErrorIs(t, err, ErrRemoteIDMismatch)
+		mockBookmarkStore.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unchanged bookmark is ignored", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		bookmark := newBookmark(model.NewId())
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(bookmark.ToBookmarkWithFileInfo(nil), nil)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		err := scs.upsertSyncBookmark(bookmark, channel, rc)
+		require.NoError(t, err)
+		mockBookmarkStore.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("deleted bookmark is deleted", func(t *testing.T) {
+		scs, mockStore, mockApp := setupSyncRecvTest(t)
+
+		owner := &model.User{Id: model.NewId(), RemoteId: model.NewString(rc.RemoteId)}
+		bookmark := newBookmark(owner.Id)
+		existing := bookmark.ToBookmarkWithFileInfo(nil)
+		deleted := bookmark.Clone()
+		deleted.DeleteAt = model.GetMillis()
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing, nil)
+		mockBookmarkStore.On("Delete", bookmark.Id, false).Return(nil).Once()
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, owner.Id).Return(owner, nil)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+		mockStore.On("User").Return(mockUserStore)
+
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelBookmarkDeleted
+		})).Once()
+		defer mockApp.AssertExpectations(t)
+
+		err := scs.upsertSyncBookmark(deleted, channel, rc)
+		require.NoError(t, err)
+		mockBookmarkStore.AssertExpectations(t)
+	})
+
+	t.Run("bookmark owned by a local user can't be updated or deleted", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		owner := &model.User{Id: model.NewId()}
+		bookmark := newBookmark(owner.Id)
+		existing := bookmark.ToBookmarkWithFileInfo(nil)
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing, nil)
+		mockUserStore := &mocks.UserStore{}
+		mockUserStore.On("Get", mockTypeContext, owner.Id).Return(owner, nil)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+		mockStore.On("User").Return(mockUserStore)
+
+		updated := bookmark.Clone()
+		updated.LinkUrl = "https://example.com"
+		err := scs.upsertSyncBookmark(updated, channel, rc)
+		require.ErrorIs(t, err, ErrRemoteIDMismatch)
+
+		deleted := bookmark.Clone()
+		deleted.DeleteAt = model.GetMillis()
+		err = scs.upsertSyncBookmark(deleted, channel, rc)
+		require.ErrorIs(t, err, ErrRemoteIDMismatch)
+
+		mockBookmarkStore.AssertNotCalled(t, "Update", mock.Anything)
+		mockBookmarkStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("file bookmarks are rejected", func(t *testing.T) {
+		scs, mockStore, _ := setupSyncRecvTest(t)
+
+		bookmark := newBookmark(model.NewId())
+		bookmark.Type = model.ChannelBookmarkFile
+
+		err := scs.upsertSyncBookmark(bookmark, channel, rc)
+		require.Error(t, err)
+		mockStore.AssertNotCalled(t, "ChannelBookmark")
+	})
+}
@@ -385,6 +385,21 @@ func (scs *Service) updateCursorForRemote(scrId string, rc *model.RemoteCluster,
 	)
 }
 
+func (scs *Service) updateLastChannelSyncAtForRemote(scrId string, rc *model.RemoteCluster, syncAt int64) {
+	if err := scs.server.GetStore().SharedChannel().UpdateRemoteLastChannelSyncAt(scrId, syncAt); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error updating last channel sync for shared channel remote",
+			mlog.String("remote", rc.DisplayName),
+			mlog.Err(err),
+		)
+		return
+	}
+	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "updated last channel sync for remote",
+		mlog.String("remote_id", rc.RemoteId),
+		mlog.String("remote", rc.DisplayName),
+		mlog.Int("last_channel_sync_at", syncAt),
+	)
+}
+
 func (scs *Service) getUserTranslations(userId string) i18n.TranslateFunc {
 	var locale string
 	user, err := scs.server.GetStore().User().Get(context.Background(), userId)
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -31,23 +32,31 @@ type syncData struct {
 	rc   *model.RemoteCluster
 	scr  *model.SharedChannelRemote
 
-	users         map[string]*model.User
-	profileImages map[string]*model.User
-	posts         []*model.Post
-	reactions     []*model.Reaction
-	attachments   []attachment
-
-	resultRepeat     bool
-	resultNextCursor model.GetPostsSinceForSyncCursor
+	features []string
+
+	users             map[string]*model.User
+	profileImages     map[string]*model.User
+	posts             []*model.Post
+	reactions         []*model.Reaction
+	attachments       []attachment
+	acknowledgements  map[string][]*model.PostAcknowledgement
+	channelInfo       *model.SyncChannelInfo
+	membershipChanges []*model.SyncMembershipChange
+	bookmarks         []*model.ChannelBookmark
+
+	resultRepeat        bool
+	resultNextCursor    model.GetPostsSinceForSyncCursor
+	resultChannelSyncAt int64
 }
 
 func newSyncData(task syncTask, rc *model.RemoteCluster, scr *model.SharedChannelRemote) *syncData {
 	return &syncData{
-		task:          task,
-		rc:            rc,
-		scr:           scr,
-		users:         make(map[string]*model.User),
-		profileImages: make(map[string]*model.User),
+		task:             task,
+		rc:               rc,
+		scr:              scr,
+		users:            make(map[string]*model.User),
+		profileImages:    make(map[string]*model.User),
+		acknowledgements: make(map[string][]*model.PostAcknowledgement),
 		resultNextCursor: model.GetPostsSinceForSyncCursor{
 			LastPostUpdateAt: scr.LastPostUpdateAt, LastPostUpdateID: scr.LastPostUpdateID,
 			LastPostCreateAt: scr.LastPostCreateAt, LastPostCreateID: scr.LastPostCreateID,
@@ -56,7 +65,17 @@ func newSyncData(task syncTask, rc *model.RemoteCluster, scr *model.SharedChanne
 }
 
 func (sd *syncData) isEmpty() bool {
-	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.attachments) == 0
+	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.attachments) == 0 &&
+		len(sd.acknowledgements) == 0 && !sd.hasChannelChanges()
+}
+
+func (sd *syncData) hasChannelChanges() bool {
+	return sd.channelInfo != nil || len(sd.membershipChanges) != 0 || len(sd.bookmarks) != 0
+}
+
+// supports returns true if the remote advertised support for the optional sync feature.
+func (sd *syncData) supports(feature string) bool {
+	return slices.Contains(sd.features, feature)
 }
 
 func (sd *syncData) isCursorChanged() bool {
@@ -146,6 +165,9 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return nil
 	}
 
+	// determine which optional content the remote can accept.
+	sd.features = scs.getRemoteFeatures(rc, task.channelID)
+
 	// fetch users that have updated their user profile or image.
 	if err := scs.fetchUsersForSync(sd); err != nil {
 		return fmt.Errorf("cannot fetch users for sync %v: %w", sd, err)
@@ -156,7 +178,17 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch reactions for sync %v: %w", sd, err)
 	}
 
-	// fetch users associated with posts & reactions
+	// fetch acknowledgements for posts
+	if err := scs.fetchAcknowledgementsForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch acknowledgements for sync %v: %w", sd, err)
+	}
+
+	// fetch channel header/purpose, membership and bookmark changes
+	if err := scs.fetchChannelChangesForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch channel changes for sync %v: %w", sd, err)
+	}
+
+	// fetch users associated with posts, reactions, acknowledgements, membership & bookmarks
 	if err := scs.fetchPostUsersForSync(sd); err != nil {
 		return fmt.Errorf("cannot fetch post users for sync %v: %w", sd, err)
 	}
@@ -169,6 +201,11 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch post attachments for sync %v: %w", sd, err)
 	}
 
+	// fetch priority for posts
+	if err := scs.fetchPostPrioritiesForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch post priorities for sync %v: %w", sd, err)
+	}
+
 	if sd.isEmpty() {
 		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Not sending sync data; everything filtered out",
 			mlog.String("remote", rc.DisplayName),
@@ -190,6 +227,10 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		mlog.Int("posts", len(sd.posts)),
 		mlog.Int("reactions", len(sd.reactions)),
 		mlog.Int("attachments", len(sd.attachments)),
+		mlog.Int("acknowledgements", len(sd.acknowledgements)),
+		mlog.Bool("channel_info", sd.channelInfo != nil),
+		mlog.Int("membership_changes", len(sd.membershipChanges)),
+		mlog.Int("bookmarks", len(sd.bookmarks)),
 	)
 
 	if !metricsRecorded && metrics != nil {
@@ -345,6 +386,154 @@ func (scs *Service) fetchReactionsForSync(sd *syncData) error {
 	return merr.ErrorOrNil()
 }
 
+// fetchAcknowledgementsForSync populates the sync data with the current acknowledgements of each post
+// being synchronized. Acknowledgements are fetched before posts are filtered since acknowledging a
+// post only changes its UpdateAt.
+func (scs *Service) fetchAcknowledgementsForSync(sd *syncData) error {
+	if !sd.supports(model.SyncFeatureAcknowledgements) || len(sd.posts) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncCollectionStepDuration(sd.rc.RemoteId, "Acknowledgements", time.Since(start).Seconds())
+		}
+	}()
+
+	postIDs := make([]string, 0, len(sd.posts))
+	for _, post := range sd.posts {
+		if post.DeleteAt == 0 {
+			postIDs = append(postIDs, post.Id)
+		}
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	acks, err := scs.server.GetStore().PostAcknowledgement().GetForPosts(postIDs)
+	if err != nil {
+		return fmt.Errorf("could not get acknowledgements for posts: %w", err)
+	}
+
+	// every post gets an entry, even when empty, so the remote can reconcile removed acknowledgements.
+	for _, postID := range postIDs {
+		sd.acknowledgements[postID] = []*model.PostAcknowledgement{}
+	}
+	for _, ack := range acks {
+		sd.acknowledgements[ack.PostId] = append(sd.acknowledgements[ack.PostId], ack)
+	}
+	return nil
+}
+
+// fetchChannelChangesForSync populates the sync data with any channel header/purpose, membership
+// and bookmark changes since the last channel sync, limited to the features supported by the remote.
+func (scs *Service) fetchChannelChangesForSync(sd *syncData) error {
+	if !sd.supports(model.SyncFeatureChannelInfo) && !sd.supports(model.SyncFeatureMembership) && !sd.supports(model.SyncFeatureBookmarks) {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncCollectionStepDuration(sd.rc.RemoteId, "Channel", time.Since(start).Seconds())
+		}
+	}()
+
+	// the next channel sync picks up anything changed after the fetch started.
+	sd.resultChannelSyncAt = model.GetMillis()
+	since := sd.scr.LastChannelSyncAt
+
+	channel, err := scs.server.GetStore().Channel().Get(sd.task.channelID, true)
+	if err != nil {
+		return fmt.Errorf("could not get channel: %w", err)
+	}
+
+	if sd.supports(model.SyncFeatureChannelInfo) && channel.UpdateAt >= since {
+		sd.channelInfo = &model.SyncChannelInfo{
+			Header:   channel.Header,
+			Purpose:  channel.Purpose,
+			UpdateAt: channel.UpdateAt,
+		}
+	}
+
+	// membership of DMs and GMs is fixed. On the first channel sync membership is skipped since
+	// existing members are added on the remote as they are synchronized.
+	if sd.supports(model.SyncFeatureMembership) && !channel.IsGroupOrDirect() && since > 0 {
+		history, err := scs.server.GetStore().ChannelMemberHistory().GetMembershipChangesSince(sd.task.channelID, since)
+		if err != nil {
+			return fmt.Errorf("could not get membership changes: %w", err)
+		}
+		sd.membershipChanges = membershipChangesForSync(history, since)
+		if err := scs.filterMembershipChangesForSync(sd); err != nil {
+			return err
+		}
+	}
+
+	if sd.supports(model.SyncFeatureBookmarks) {
+		bookmarks, err := scs.server.GetStore().ChannelBookmark().GetBookmarksForChannelSince(sd.task.channelID, since)
+		if err != nil {
+			return fmt.Errorf("could not get bookmarks: %w", err)
+		}
+		for _, b := range bookmarks {
+			// file attachments of bookmarks are not synchronized.
+			if b.Type == model.ChannelBookmarkLink {
+				sd.bookmarks = append(sd.bookmarks, b.ChannelBookmark)
+			}
+		}
+	}
+	return nil
+}
+
+// membershipChangesForSync reduces the channel member history to the latest join or leave
+// at or after `since` for each user.
+func membershipChangesForSync(history []*model.ChannelMemberHistoryResult, since int64) []*model.SyncMembershipChange {
+	latest := make(map[string]*model.SyncMembershipChange)
+	var order []string
+
+	for _, h := range history {
+		change := &model.SyncMembershipChange{UserId: h.UserId, IsAdd: true, ChangeTime: h.JoinTime}
+		if h.LeaveTime != nil && *h.LeaveTime >= h.JoinTime {
+			change.IsAdd = false
+			change.ChangeTime = *h.LeaveTime
+		}
+		if change.ChangeTime < since {
+			continue
+		}
+
+		existing, ok := latest[h.UserId]
+		if !ok {
+			order = append(order, h.UserId)
+		}
+		if !ok || existing.ChangeTime <= change.ChangeTime {
+			latest[h.UserId] = change
+		}
+	}
+
+	changes := make([]*model.SyncMembershipChange, 0, len(order))
+	for _, userID := range order {
+		changes = append(changes, latest[userID])
+	}
+	return changes
+}
+
+// filterMembershipChangesForSync removes membership changes for users belonging to the remote
+// since the remote manages the membership of its own users.
+func (scs *Service) filterMembershipChangesForSync(sd *syncData) error {
+	filtered := make([]*model.SyncMembershipChange, 0, len(sd.membershipChanges))
+	for _, change := range sd.membershipChanges {
+		user, err := scs.server.GetStore().User().Get(context.Background(), change.UserId)
+		if err != nil {
+			return fmt.Errorf("could not get user %s: %w", change.UserId, err)
+		}
+		if user.GetRemoteID() != sd.rc.RemoteId {
+			filtered = append(filtered, change)
+		}
+	}
+	sd.membershipChanges = filtered
+	return nil
+}
+
 // fetchPostUsersForSync populates the sync data with all users associated with posts.
 func (scs *Service) fetchPostUsersForSync(sd *syncData) error {
 	start := time.Now()
@@ -370,6 +559,22 @@ func (scs *Service) fetchPostUsersForSync(sd *syncData) error {
 		userIDs[reaction.UserId] = p2mm{}
 	}
 
+	for _, acks := range sd.acknowledgements {
+		for _, ack := range acks {
+			userIDs[ack.UserId] = p2mm{}
+		}
+	}
+
+	for _, change := range sd.membershipChanges {
+		if change.IsAdd {
+			userIDs[change.UserId] = p2mm{}
+		}
+	}
+
+	for _, bookmark := range sd.bookmarks {
+		userIDs[bookmark.OwnerId] = p2mm{}
+	}
+
 	for _, post := range sd.posts {
 		// add author
 		userIDs[post.UserId] = p2mm{}
@@ -442,6 +647,49 @@ func (scs *Service) fetchPostAttachmentsForSync(sd *syncData) error {
 	return merr.ErrorOrNil()
 }
 
+// fetchPostPrioritiesForSync adds the priority metadata to root posts being synchronized.
+func (scs *Service) fetchPostPrioritiesForSync(sd *syncData) error {
+	if !sd.supports(model.SyncFeaturePriority) || len(sd.posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, 0, len(sd.posts))
+	for _, post := range sd.posts {
+		if post.RootId == "" {
+			postIDs = append(postIDs, post.Id)
+		}
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	priorities, err := scs.server.GetStore().PostPriority().GetForPosts(postIDs)
+	if err != nil {
+		return fmt.Errorf("could not get priority for posts: %w", err)
+	}
+
+	byPost := make(map[string]*model.PostPriority, len(priorities))
+	for _, p := range priorities {
+		byPost[p.PostId] = p
+	}
+
+	for _, post := range sd.posts {
+		priority, ok := byPost[post.Id]
+		if !ok {
+			continue
+		}
+		if post.Metadata == nil {
+			post.Metadata = &model.PostMetadata{}
+		}
+		post.Metadata.Priority = &model.PostPriority{
+			Priority:                priority.Priority,
+			RequestedAck:            priority.RequestedAck,
+			PersistentNotifications: priority.PersistentNotifications,
+		}
+	}
+	return nil
+}
+
 // filterPostsforSync removes any posts that do not need to sync.
 func (scs *Service) filterPostsForSync(sd *syncData) {
 	filtered := make([]*model.Post, 0, len(sd.posts))
@@ -476,7 +724,8 @@ func (scs *Service) filterPostsForSync(sd *syncData) {
 
 // sendSyncData sends all the collected users, posts, reactions, images, and attachments to the
 // remote cluster.
-// The order of items sent is important: users -> attachments -> posts -> reactions -> profile images
+// The order of items sent is important: users -> attachments -> posts -> reactions -> acknowledgements ->
+// channel changes -> profile images
 func (scs *Service) sendSyncData(sd *syncData) error {
 	start := time.Now()
 	defer func() {
@@ -516,6 +765,20 @@ func (scs *Service) sendSyncData(sd *syncData) error {
 		}
 	}
 
+	// send acknowledgements
+	if len(sd.acknowledgements) != 0 {
+		if err := scs.sendAcknowledgementSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send acknowledgement sync data: %w", err))
+		}
+	}
+
+	// send channel header/purpose, membership and bookmarks
+	if sd.hasChannelChanges() {
+		if err := scs.sendChannelSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send channel sync data: %w", err))
+		}
+	}
+
 	// send user profile images
 	if len(sd.profileImages) != 0 {
 		scs.sendProfileImageSyncData(sd)
@@ -624,6 +887,65 @@ func (scs *Service) sendReactionSyncData(sd *syncData) error {
 	})
 }
 
+// sendAcknowledgementSyncData sends the collected post acknowledgements to the remote cluster.
+func (scs *Service) sendAcknowledgementSyncData(sd *syncData) error {
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncSendStepDuration(sd.rc.RemoteId, "Acknowledgements", time.Since(start).Seconds())
+		}
+	}()
+
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.Acknowledgements = sd.acknowledgements
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		if len(syncResp.AcknowledgementErrors) != 0 {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for acknowledgement(s) sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("acknowledgement_posts", syncResp.AcknowledgementErrors),
+			)
+		}
+	})
+}
+
+// sendChannelSyncData sends the collected channel header/purpose, membership and bookmark changes
+// to the remote cluster.
+func (scs *Service) sendChannelSyncData(sd *syncData) error {
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncSendStepDuration(sd.rc.RemoteId, "Channel", time.Since(start).Seconds())
+		}
+	}()
+
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.Channel = sd.channelInfo
+	msg.MembershipChanges = sd.membershipChanges
+	msg.Bookmarks = sd.bookmarks
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		if len(syncResp.MembershipErrors) != 0 {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for membership sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("users", syncResp.MembershipErrors),
+			)
+		}
+		if len(syncResp.BookmarkErrors) != 0 {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for bookmark(s) sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("bookmarks", syncResp.BookmarkErrors),
+			)
+		}
+		if errResp == nil {
+			scs.updateLastChannelSyncAtForRemote(sd.scr.Id, sd.rc, sd.resultChannelSyncAt)
+		}
+	})
+}
+
 // sendProfileImageSyncData sends the collected user profile image updates to the remote cluster.
 func (scs *Service) sendProfileImageSyncData(sd *syncData) {
 	for _, user := range sd.profileImages {
@@ -667,6 +989,10 @@ func (scs *Service) sendSyncMsgToRemote(msg *model.SyncMsg, rc *model.RemoteClus
 			return
 		}
 
+		if errResp == nil {
+			scs.setRemoteFeatures(rc.RemoteId, syncResp.Features)
+		}
+
 		if f != nil {
 			f(syncResp, errResp)
 		}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestMembershipChangesForSync(t *testing.T) {
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	userID3 := model.NewId()
+
+	history := []*model.ChannelMemberHistoryResult{
+		{UserId: userID1, JoinTime: 100, LeaveTime: model.NewInt64(300)},
+		{UserId: userID2, JoinTime: 200},
+		{UserId: userID1, JoinTime: 400},
+		{UserId: userID3, JoinTime: 50, LeaveTime: model.NewInt64(250)},
+		{UserId: userID2, JoinTime: 10, LeaveTime: model.NewInt64(20)},
+	}
+
+	changes := membershipChangesForSync(history, 150)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, &model.SyncMembershipChange{UserId: userID1, IsAdd: true, ChangeTime: 400}, changes[0])
+	assert.Equal(t, &model.SyncMembershipChange{UserId: userID2, IsAdd: true, ChangeTime: 200}, changes[1])
+	assert.Equal(t, &model.SyncMembershipChange{UserId: userID3, IsAdd: false, ChangeTime: 250}, changes[2])
+
+	assert.Empty(t, membershipChangesForSync(history, 500))
+}
+
+func TestGetRemoteFeatures(t *testing.T) {
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	scs := &Service{
+		server: mockServer,
+		app:    &MockAppIface{},
+	}
+
+	t.Run("plugin remotes support no optional features", func(t *testing.T) {
+		rc := &model.RemoteCluster{RemoteId: model.NewId(), PluginID: "plugin"}
+		assert.Empty(t, scs.getRemoteFeatures(rc, model.NewId()))
+		mockServer.AssertNotCalled(t, "GetRemoteClusterService")
+	})
+
+	t.Run("cached features are returned until cleared", func(t *testing.T) {
+		rc := &model.RemoteCluster{RemoteId: model.NewId()}
+		scs.remoteFeatures = map[string][]string{
+			rc.RemoteId: {model.SyncFeatureBookmarks},
+		}
+
+		assert.Equal(t, []string{model.SyncFeatureBookmarks}, scs.getRemoteFeatures(rc, model.NewId()))
+		mockServer.AssertNotCalled(t, "GetRemoteClusterService")
+
+		scs.clearRemoteFeatures(rc.RemoteId)
+		assert.NotContains(t, scs.remoteFeatures, rc.RemoteId)
+	})
+
+	t.Run("features are replaced by the ones of a later sync response", func(t *testing.T) {
+		rc := &model.RemoteCluster{RemoteId: model.NewId()}
+		scs.setRemoteFeatures(rc.RemoteId, []string{model.SyncFeatureBookmarks})
+		assert.Equal(t, []string{model.SyncFeatureBookmarks}, scs.getRemoteFeatures(rc, model.NewId()))
+
+		scs.setRemoteFeatures(rc.RemoteId, nil)
+		assert.Empty(t, scs.getRemoteFeatures(rc, model.NewId()))
+		mockServer.AssertNotCalled(t, "GetRemoteClusterService")
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package teamsimport
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// teamsReactionEmojis maps the reaction types of Microsoft Teams to system emojis.
+var teamsReactionEmojis = map[string]string{
+	"like":      "+1",
+	"heart":     "heart",
+	"laugh":     "laughing",
+	"surprised": "open_mouth",
+	"sad":       "cry",
+	"angry":     "angry",
+}
+
+var (
+	teamsMentionRegexp       = regexp.MustCompile(`(?s)<at id="(\d+)">.*?</at>`)
+	teamsLinkRegexp          = regexp.MustCompile(`(?is)<a [^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	teamsLineBreakRegexp     = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	teamsBoldRegexp          = regexp.MustCompile(`(?i)</?(b|strong)>`)
+	teamsItalicRegexp        = regexp.MustCompile(`(?i)</?(i|em)>`)
+	teamsStrikeRegexp        = regexp.MustCompile(`(?i)</?(s|strike|del)>`)
+	teamsCodeRegexp          = regexp.MustCompile(`(?i)</?code>`)
+	teamsListItemRegexp      = regexp.MustCompile(`(?i)<li[^>]*>`)
+	teamsTagRegexp           = regexp.MustCompile(`(?s)<[^>]*>`)
+	teamsBlankLinesRegexp    = regexp.MustCompile(`\n{3,}`)
+	invalidChannelNameRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// convertHTML converts the HTML body of a message into markdown, replacing its mentions
+// with the given texts.
+func convertHTML(body string, mentions map[int]string) string {
+	text := teamsMentionRegexp.ReplaceAllStringFunc(body, func(mention string) string {
+		id, _ := strconv.Atoi(teamsMentionRegexp.FindStringSubmatch(mention)[1])
+		return mentions[id]
+	})
+
+	text = teamsLinkRegexp.ReplaceAllStringFunc(text, func(link string) string {
+		match := teamsLinkRegexp.FindStringSubmatch(link)
+		href, label := match[1], teamsTagRegexp.ReplaceAllString(match[2], "")
+		if label == "" || label == href {
+			return href
+		}
+		return "[" + label + "](" + href + ")"
+	})
+
+	text = strings.ReplaceAll(text, "\n", "")
+	text = teamsLineBreakRegexp.ReplaceAllString(text, "\n")
+	text = teamsListItemRegexp.ReplaceAllString(text, "- ")
+	text = teamsBoldRegexp.ReplaceAllString(text, "**")
+	text = teamsItalicRegexp.ReplaceAllString(text, "_")
+	text = teamsStrikeRegexp.ReplaceAllString(text, "~~")
+	text = teamsCodeRegexp.ReplaceAllString(text, "`")
+	text = teamsTagRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	text = teamsBlankLinesRegexp.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text)
+}
+
+func convertChannelName(channelName string, channelId string) string {
+	if name := cleanChannelName(channelName); name != "" {
+		return name
+	}
+	if name := cleanChannelName(channelId); name != "" {
+		return name
+	}
+	return "teams-channel"
+}
+
+func cleanChannelName(s string) string {
+	name := invalidChannelNameRegexp.ReplaceAllString(strings.ToLower(s), "-")
+	name = strings.Trim(name, "_-")
+	if len(name) > model.ChannelNameMaxLength {
+		name = strings.Trim(name[:model.ChannelNameMaxLength], "_-")
+	}
+
+	if len(name) < 2 || !model.IsValidChannelIdentifier(name) {
+		return ""
+	}
+	return name
+}
+
+func uniqueChannelName(name string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(name)+len(suffix) > model.ChannelNameMaxLength {
+		name = name[:model.ChannelNameMaxLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes])
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,655 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package teamsimport converts the Microsoft Graph exports of Microsoft Teams into bulk import archives.
+//
+// The input archive holds the Graph list responses, as {"value": [...]} objects:
+//
+//	users.json                    the users
+//	teams.json                    the teams
+//	channels/<team id>.json       the channels of a team
+//	messages/<channel id>.json    the messages of a channel, with their replies expanded
+//	chats.json                    the chats, with their members expanded
+//	chatMessages/<chat id>.json   the messages of a chat
+//	attachments/<id>/<file name>  the files attached to the messages
+package teamsimport
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	teamsMessageTypeMessage = "message"
+
+	teamsChannelMembershipPrivate = "private"
+
+	teamsChatTypeOneOnOne = "oneOnOne"
+
+	teamsAttachmentTypeReference = "reference"
+)
+
+type teamsList[T any] struct {
+	Value []T `json:"value"`
+}
+
+type teamsUser struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+	Mail              string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	JobTitle          string `json:"jobTitle"`
+}
+
+type teamsTeam struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+}
+
+type teamsChannel struct {
+	Id             string `json:"id"`
+	DisplayName    string `json:"displayName"`
+	Description    string `json:"description"`
+	MembershipType string `json:"membershipType"`
+}
+
+type teamsChatMember struct {
+	UserId string `json:"userId"`
+}
+
+type teamsChat struct {
+	Id       string            `json:"id"`
+	ChatType string            `json:"chatType"`
+	Topic    string            `json:"topic"`
+	Members  []teamsChatMember `json:"members"`
+}
+
+type teamsIdentity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type teamsIdentitySet struct {
+	User *teamsIdentity `json:"user"`
+}
+
+type teamsItemBody struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type teamsAttachment struct {
+	Id          string `json:"id"`
+	ContentType string `json:"contentType"`
+	ContentUrl  string `json:"contentUrl"`
+	Name        string `json:"name"`
+}
+
+type teamsMention struct {
+	Id          int              `json:"id"`
+	MentionText string           `json:"mentionText"`
+	Mentioned   teamsIdentitySet `json:"mentioned"`
+}
+
+type teamsReaction struct {
+	ReactionType    string           `json:"reactionType"`
+	CreatedDateTime time.Time        `json:"createdDateTime"`
+	User            teamsIdentitySet `json:"user"`
+}
+
+type teamsMessage struct {
+	Id                 string            `json:"id"`
+	MessageType        string            `json:"messageType"`
+	CreatedDateTime    time.Time         `json:"createdDateTime"`
+	LastEditedDateTime *time.Time        `json:"lastEditedDateTime"`
+	DeletedDateTime    *time.Time        `json:"deletedDateTime"`
+	From               *teamsIdentitySet `json:"from"`
+	Body               teamsItemBody     `json:"body"`
+	Attachments        []teamsAttachment `json:"attachments"`
+	Mentions           []teamsMention    `json:"mentions"`
+	Reactions          []teamsReaction   `json:"reactions"`
+	Replies            []teamsMessage    `json:"replies"`
+}
+
+// Options configures the conversion of a Microsoft Teams export.
+type Options struct {
+	// TeamName is the name of the team all the channels are imported in. By default, every
+	// team of Microsoft Teams is imported as a team of its own.
+	TeamName string
+	// Users resolves the Microsoft Teams users against the users of the destination server. Optional.
+	Users imports.UserResolver
+}
+
+type converter struct {
+	logger mlog.LoggerIFace
+	opts   Options
+	files  map[string]*zip.File
+	out    *imports.ArchiveWriter
+
+	mapper      *imports.UserMapper
+	usernames   map[string]string
+	users       []*imports.UserImportData
+	memberships map[string]map[string][]string
+
+	teams        []*imports.TeamImportData
+	teamNames    map[string]string
+	channelNames map[string]map[string]bool
+	channels     []*imports.ChannelImportData
+
+	posts       []*imports.PostImportData
+	directs     []*imports.DirectChannelImportData
+	directPosts []*imports.DirectPostImportData
+}
+
+// Convert reads the Microsoft Teams export contained in the input archive and writes the
+// equivalent bulk import archive to output.
+func Convert(logger mlog.LoggerIFace, input *zip.Reader, output io.Writer, opts Options) error {
+	c := &converter{
+		logger:       logger,
+		opts:         opts,
+		files:        make(map[string]*zip.File, len(input.File)),
+		out:          imports.NewArchiveWriter(output),
+		mapper:       imports.NewUserMapper(logger, opts.Users),
+		usernames:    make(map[string]string),
+		memberships:  make(map[string]map[string][]string),
+		teamNames:    make(map[string]string),
+		channelNames: make(map[string]map[string]bool),
+	}
+	for _, file := range input.File {
+		c.files[file.Name] = file
+	}
+
+	var users teamsList[teamsUser]
+	if err := c.readJSON("users.json", &users); err != nil {
+		return err
+	}
+	if err := c.convertUsers(users.Value); err != nil {
+		return err
+	}
+
+	var teams teamsList[teamsTeam]
+	if err := c.readJSON("teams.json", &teams); err != nil {
+		return err
+	}
+	for _, team := range teams.Value {
+		if err := c.convertTeam(team); err != nil {
+			return err
+		}
+	}
+
+	var chats teamsList[teamsChat]
+	if err := c.readJSON("chats.json", &chats); err != nil {
+		return err
+	}
+	for _, chat := range chats.Value {
+		if err := c.convertChat(chat); err != nil {
+			return err
+		}
+	}
+
+	if len(c.posts) == 0 && len(c.directPosts) == 0 {
+		return fmt.Errorf("no Microsoft Teams message found in the archive")
+	}
+
+	if err := c.writeLines(); err != nil {
+		return err
+	}
+
+	return c.out.Close()
+}
+
+// readJSON decodes a file of the archive, leaving v untouched if the file doesn't exist.
+func (c *converter) readJSON(name string, v any) error {
+	file := c.files[name]
+	if file == nil {
+		return nil
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", name, err)
+	}
+	defer r.Close()
+
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func (c *converter) convertUsers(users []teamsUser) error {
+	for _, user := range users {
+		email := user.Mail
+		if email == "" {
+			email = user.UserPrincipalName
+		}
+		if email == "" {
+			c.logger.Warn("Teams Import: Skipping a user without an email.", mlog.String("user_id", user.Id))
+			continue
+		}
+
+		username, err := c.mapper.Username(email, strings.SplitN(email, "@", 2)[0])
+		if err != nil {
+			return err
+		}
+		c.usernames[user.Id] = username
+
+		if c.mapper.Existing(username) {
+			c.logger.Info("Teams Import: Mapping to an existing user, who isn't added to the imported channels.", mlog.String("user_name", username))
+			continue
+		}
+
+		data := &imports.UserImportData{
+			Username: model.NewString(username),
+			Email:    model.NewString(email),
+		}
+		if user.GivenName != "" {
+			data.FirstName = model.NewString(truncateRunes(user.GivenName, model.UserFirstNameMaxRunes))
+		}
+		if user.Surname != "" {
+			data.LastName = model.NewString(truncateRunes(user.Surname, model.UserLastNameMaxRunes))
+		}
+		if user.JobTitle != "" {
+			data.Position = model.NewString(truncateRunes(user.JobTitle, model.UserPositionMaxRunes))
+		}
+		c.users = append(c.users, data)
+	}
+
+	return nil
+}
+
+func (c *converter) convertTeam(team teamsTeam) error {
+	teamName := c.opts.TeamName
+	if teamName == "" {
+		teamName = model.CleanTeamName(team.DisplayName)
+	}
+
+	if _, ok := c.channelNames[teamName]; !ok {
+		displayName := team.DisplayName
+		if c.opts.TeamName != "" || displayName == "" {
+			displayName = teamName
+		}
+
+		data := &imports.TeamImportData{
+			Name:        model.NewString(teamName),
+			DisplayName: model.NewString(truncateRunes(displayName, model.TeamDisplayNameMaxRunes)),
+			Type:        model.NewString(model.TeamInvite),
+		}
+		if team.Description != "" && c.opts.TeamName == "" {
+			data.Description = model.NewString(truncateRunes(team.Description, model.TeamDescriptionMaxLength))
+		}
+		c.teams = append(c.teams, data)
+		c.channelNames[teamName] = make(map[string]bool)
+	}
+	c.teamNames[team.Id] = teamName
+
+	var channels teamsList[teamsChannel]
+	if err := c.readJSON(path.Join("channels", team.Id+".json"), &channels); err != nil {
+		return err
+	}
+	for _, channel := range channels.Value {
+		if err := c.convertChannel(teamName, channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertChannel(teamName string, channel teamsChannel) error {
+	names := c.channelNames[teamName]
+	name := convertChannelName(channel.DisplayName, channel.Id)
+	for i := 2; names[name]; i++ {
+		name = uniqueChannelName(convertChannelName(channel.DisplayName, channel.Id), i)
+	}
+	names[name] = true
+
+	channelType := model.ChannelTypeOpen
+	if channel.MembershipType == teamsChannelMembershipPrivate {
+		channelType = model.ChannelTypePrivate
+	}
+
+	data := &imports.ChannelImportData{
+		Team:        model.NewString(teamName),
+		Name:        model.NewString(name),
+		DisplayName: model.NewString(truncateRunes(channel.DisplayName, model.ChannelDisplayNameMaxRunes)),
+		Type:        &channelType,
+	}
+	if channel.Description != "" {
+		data.Purpose = model.NewString(truncateRunes(channel.Description, model.ChannelPurposeMaxRunes))
+	}
+	c.channels = append(c.channels, data)
+
+	var messages teamsList[teamsMessage]
+	if err := c.readJSON(path.Join("messages", channel.Id+".json"), &messages); err != nil {
+		return err
+	}
+
+	for _, message := range messages.Value {
+		post, err := c.convertPost(message)
+		if err != nil {
+			return err
+		}
+		if post == nil {
+			continue
+		}
+		c.addMembership(*post.User, teamName, name)
+
+		post.Team = model.NewString(teamName)
+		post.Channel = model.NewString(name)
+		c.posts = append(c.posts, post)
+
+		var replies []imports.ReplyImportData
+		for _, message := range message.Replies {
+			reply, err := c.convertPost(message)
+			if err != nil {
+				return err
+			}
+			if reply == nil {
+				continue
+			}
+			c.addMembership(*reply.User, teamName, name)
+			replies = append(replies, replyFromPost(reply))
+		}
+		if len(replies) > 0 {
+			sort.Slice(replies, func(i, j int) bool {
+				return *replies[i].CreateAt < *replies[j].CreateAt
+			})
+			post.Replies = &replies
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertChat(chat teamsChat) error {
+	var members []string
+	for _, member := range chat.Members {
+		if username := c.usernames[member.UserId]; username != "" && !containsString(members, username) {
+			members = append(members, username)
+		}
+	}
+
+	if len(members) < 2 || len(members) > model.ChannelGroupMaxUsers || (chat.ChatType == teamsChatTypeOneOnOne && len(members) != 2) {
+		c.logger.Warn("Teams Import: Skipping a chat with an unsupported number of members.", mlog.String("chat_id", chat.Id), mlog.Int("members", len(members)))
+		return nil
+	}
+	sort.Strings(members)
+
+	data := &imports.DirectChannelImportData{Members: &members}
+	if chat.Topic != "" {
+		data.Header = model.NewString(truncateRunes(chat.Topic, model.ChannelHeaderMaxRunes))
+	}
+	c.directs = append(c.directs, data)
+
+	var messages teamsList[teamsMessage]
+	if err := c.readJSON(path.Join("chatMessages", chat.Id+".json"), &messages); err != nil {
+		return err
+	}
+
+	for _, message := range messages.Value {
+		post, err := c.convertPost(message)
+		if err != nil {
+			return err
+		}
+		if post == nil {
+			continue
+		}
+
+		c.directPosts = append(c.directPosts, &imports.DirectPostImportData{
+			ChannelMembers: &members,
+			User:           post.User,
+			Message:        post.Message,
+			CreateAt:       post.CreateAt,
+			EditAt:         post.EditAt,
+			Reactions:      post.Reactions,
+			Attachments:    post.Attachments,
+		})
+	}
+
+	return nil
+}
+
+// convertPost converts the content of a message, returning nil if the message has nothing to import.
+func (c *converter) convertPost(message teamsMessage) (*imports.PostImportData, error) {
+	if message.MessageType != teamsMessageTypeMessage || message.DeletedDateTime != nil {
+		return nil, nil
+	}
+	if message.From == nil || message.From.User == nil {
+		return nil, nil
+	}
+
+	username := c.usernames[message.From.User.Id]
+	if username == "" {
+		c.logger.Warn("Teams Import: Skipping a message from an unknown user.", mlog.String("message_id", message.Id))
+		return nil, nil
+	}
+
+	text := message.Body.Content
+	if message.Body.ContentType == "html" {
+		text = convertHTML(text, c.mentions(message.Mentions))
+	}
+
+	var attachments []imports.AttachmentImportData
+	for _, attachment := range message.Attachments {
+		if attachment.ContentType != teamsAttachmentTypeReference {
+			continue
+		}
+
+		data, err := c.convertAttachment(attachment)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			// The file wasn't exported, so it's linked instead.
+			text = strings.TrimSpace(text + "\n" + attachment.ContentUrl)
+			continue
+		}
+		attachments = append(attachments, *data)
+	}
+
+	if text == "" && len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if len([]rune(text)) > model.PostMessageMaxRunesV2 {
+		c.logger.Warn("Teams Import: Truncating a message which is too long.", mlog.String("message_id", message.Id))
+		text = truncateRunes(text, model.PostMessageMaxRunesV2)
+	}
+
+	createAt := message.CreatedDateTime.UnixMilli()
+	post := &imports.PostImportData{
+		User:      model.NewString(username),
+		Message:   model.NewString(text),
+		CreateAt:  model.NewInt64(createAt),
+		Reactions: c.convertReactions(message.Reactions, createAt),
+	}
+	if message.LastEditedDateTime != nil {
+		post.EditAt = model.NewInt64(message.LastEditedDateTime.UnixMilli())
+	}
+	if len(attachments) > 0 {
+		post.Attachments = &attachments
+	}
+
+	return post, nil
+}
+
+// mentions maps the mention IDs of a message to the text replacing them.
+func (c *converter) mentions(mentions []teamsMention) map[int]string {
+	texts := make(map[int]string, len(mentions))
+	for _, mention := range mentions {
+		if mention.Mentioned.User != nil {
+			if username := c.usernames[mention.Mentioned.User.Id]; username != "" {
+				texts[mention.Id] = "@" + username
+				continue
+			}
+		}
+		texts[mention.Id] = mention.MentionText
+	}
+	return texts
+}
+
+// convertAttachment copies an exported file into the output archive, returning nil if
+// the file wasn't exported.
+func (c *converter) convertAttachment(attachment teamsAttachment) (*imports.AttachmentImportData, error) {
+	dir := path.Join("attachments", attachment.Id)
+	file := c.files[path.Join(dir, attachment.Name)]
+	if file == nil {
+		for name, f := range c.files {
+			if path.Dir(name) == dir && !f.FileInfo().IsDir() {
+				file = f
+				break
+			}
+		}
+	}
+	if file == nil {
+		c.logger.Warn("Teams Import: The attachment is missing from the archive.", mlog.String("attachment_id", attachment.Id))
+		return nil, nil
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the attachment %q: %w", file.Name, err)
+	}
+	defer r.Close()
+
+	fileName := attachment.Name
+	if fileName == "" {
+		fileName = path.Base(file.Name)
+	}
+
+	return c.out.AddAttachment(path.Join("teams", attachment.Id, fileName), r)
+}
+
+func (c *converter) convertReactions(reactions []teamsReaction, createAt int64) *[]imports.ReactionImportData {
+	var data []imports.ReactionImportData
+	for _, reaction := range reactions {
+		emojiName := teamsReactionEmojis[reaction.ReactionType]
+		if emojiName == "" {
+			c.logger.Warn("Teams Import: Skipping a reaction with an unsupported type.", mlog.String("reaction_type", reaction.ReactionType))
+			continue
+		}
+		if reaction.User.User == nil || c.usernames[reaction.User.User.Id] == "" {
+			continue
+		}
+
+		reactionCreateAt := reaction.CreatedDateTime.UnixMilli()
+		if reactionCreateAt < createAt {
+			reactionCreateAt = createAt
+		}
+		data = append(data, imports.ReactionImportData{
+			User:      model.NewString(c.usernames[reaction.User.User.Id]),
+			CreateAt:  model.NewInt64(reactionCreateAt),
+			EmojiName: model.NewString(emojiName),
+		})
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	return &data
+}
+
+func (c *converter) addMembership(username, teamName, channelName string) {
+	if c.mapper.Existing(username) {
+		return
+	}
+
+	if c.memberships[username] == nil {
+		c.memberships[username] = make(map[string][]string)
+	}
+	if !containsString(c.memberships[username][teamName], channelName) {
+		c.memberships[username][teamName] = append(c.memberships[username][teamName], channelName)
+	}
+}
+
+func replyFromPost(post *imports.PostImportData) imports.ReplyImportData {
+	return imports.ReplyImportData{
+		User:        post.User,
+		Message:     post.Message,
+		CreateAt:    post.CreateAt,
+		EditAt:      post.EditAt,
+		Reactions:   post.Reactions,
+		Attachments: post.Attachments,
+	}
+}
+
+func (c *converter) writeLines() error {
+	if err := c.out.WriteLine(&imports.LineImportData{Type: "version", Version: model.NewInt(1)}); err != nil {
+		return err
+	}
+
+	for _, team := range c.teams {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "team", Team: team}); err != nil {
+			return err
+		}
+	}
+
+	for _, channel := range c.channels {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "channel", Channel: channel}); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range c.users {
+		teams := []imports.UserTeamImportData{}
+		for _, team := range c.teams {
+			channelNames, ok := c.memberships[*user.Username][*team.Name]
+			if !ok {
+				continue
+			}
+
+			channels := []imports.UserChannelImportData{}
+			for _, channelName := range channelNames {
+				channels = append(channels, imports.UserChannelImportData{
+					Name:  model.NewString(channelName),
+					Roles: model.NewString(model.ChannelUserRoleId),
+				})
+			}
+			teams = append(teams, imports.UserTeamImportData{
+				Name:     team.Name,
+				Roles:    model.NewString(model.TeamUserRoleId),
+				Channels: &channels,
+			})
+		}
+		user.Teams = &teams
+
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "user", User: user}); err != nil {
+			return err
+		}
+	}
+
+	for _, direct := range c.directs {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "direct_channel", DirectChannel: direct}); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range c.posts {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "post", Post: post}); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range c.directPosts {
+		if err := c.out.WriteLine(&imports.LineImportData{Type: "direct_post", DirectPost: post}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
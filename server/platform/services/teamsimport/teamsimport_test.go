@@ -0,0 +1,269 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package teamsimport
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+var testExport = map[string]string{
+	"users.json": `{"value": [
+		{"id": "u1", "displayName": "Alice Smith", "givenName": "Alice", "surname": "Smith", "mail": "alice.smith@contoso.com", "jobTitle": "Engineer"},
+		{"id": "u2", "displayName": "Bob Jones", "mail": "bob@contoso.com"},
+		{"id": "u3", "displayName": "Carol", "userPrincipalName": "carol@contoso.com"},
+		{"id": "u4", "displayName": "No Email"}
+	]}`,
+	"teams.json": `{"value": [{"id": "t1", "displayName": "Contoso Engineering", "description": "Builders"}]}`,
+	"channels/t1.json": `{"value": [
+		{"id": "19:general@thread.tacv2", "displayName": "General", "membershipType": "standard"},
+		{"id": "19:secret@thread.tacv2", "displayName": "Secret Plans", "description": "Shh", "membershipType": "private"}
+	]}`,
+	"messages/19:general@thread.tacv2.json": `{"value": [
+		{
+			"id": "m1", "messageType": "message", "createdDateTime": "2023-05-01T09:00:00Z",
+			"from": {"user": {"id": "u1", "displayName": "Alice Smith"}},
+			"body": {"contentType": "html", "content": "<p>Hi <at id=\"0\">Bob Jones</at>, see <a href=\"https://contoso.com\">the <b>site</b></a>&amp; the file</p><attachment id=\"a1\"></attachment>"},
+			"mentions": [{"id": 0, "mentionText": "Bob Jones", "mentioned": {"user": {"id": "u2"}}}],
+			"attachments": [
+				{"id": "a1", "contentType": "reference", "contentUrl": "https://contoso.sharepoint.com/plan.docx", "name": "plan.docx"},
+				{"id": "a2", "contentType": "reference", "contentUrl": "https://contoso.sharepoint.com/missing.docx", "name": "missing.docx"}
+			],
+			"reactions": [
+				{"reactionType": "like", "createdDateTime": "2023-05-01T09:01:00Z", "user": {"user": {"id": "u2"}}},
+				{"reactionType": "custom", "createdDateTime": "2023-05-01T09:01:00Z", "user": {"user": {"id": "u3"}}}
+			],
+			"replies": [
+				{
+					"id": "m3", "messageType": "message", "createdDateTime": "2023-05-01T09:10:00Z", "lastEditedDateTime": "2023-05-01T09:11:00Z",
+					"from": {"user": {"id": "u3"}}, "body": {"contentType": "text", "content": "Second reply"}
+				},
+				{
+					"id": "m2", "messageType": "message", "createdDateTime": "2023-05-01T09:05:00Z",
+					"from": {"user": {"id": "u2"}}, "body": {"contentType": "html", "content": "<div>First reply</div>"}
+				},
+				{
+					"id": "m4", "messageType": "message", "createdDateTime": "2023-05-01T09:15:00Z", "deletedDateTime": "2023-05-01T09:16:00Z",
+					"from": {"user": {"id": "u3"}}, "body": {"contentType": "text", "content": "Deleted"}
+				}
+			]
+		},
+		{
+			"id": "m5", "messageType": "systemEventMessage", "createdDateTime": "2023-05-01T10:00:00Z",
+			"body": {"contentType": "html", "content": "<systemEventMessage/>"}
+		}
+	]}`,
+	"messages/19:secret@thread.tacv2.json": `{"value": [
+		{
+			"id": "m6", "messageType": "message", "createdDateTime": "2023-05-02T09:00:00Z",
+			"from": {"user": {"id": "u3"}}, "body": {"contentType": "text", "content": "Secret"}
+		}
+	]}`,
+	"chats.json": `{"value": [
+		{"id": "c1", "chatType": "oneOnOne", "members": [{"userId": "u1"}, {"userId": "u2"}]},
+		{"id": "c2", "chatType": "group", "members": [{"userId": "u1"}]}
+	]}`,
+	"chatMessages/c1.json": `{"value": [
+		{
+			"id": "m7", "messageType": "message", "createdDateTime": "2023-05-03T09:00:00Z",
+			"from": {"user": {"id": "u2"}}, "body": {"contentType": "text", "content": "Lunch?"}
+		}
+	]}`,
+	"attachments/a1/plan.docx": "the plan",
+}
+
+type testUserResolver map[string]string
+
+func (r testUserResolver) UsernameByEmail(email string) (string, error) {
+	return r[email], nil
+}
+
+func (r testUserResolver) UsernameExists(username string) (bool, error) {
+	for _, existing := range r {
+		if existing == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func makeTestArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	wr := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := wr.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, wr.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return r
+}
+
+func readTestLines(t *testing.T, output []byte) ([]imports.LineImportData, map[string]string) {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(output), int64(len(output)))
+	require.NoError(t, err)
+
+	var lines []imports.LineImportData
+	files := make(map[string]string)
+	for _, file := range r.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		if file.Name != "import.jsonl" {
+			b, err := io.ReadAll(f)
+			require.NoError(t, err)
+			files[file.Name] = string(b)
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var line imports.LineImportData
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.NoError(t, scanner.Err())
+	}
+
+	return lines, files
+}
+
+func TestConvert(t *testing.T) {
+	var output bytes.Buffer
+	err := Convert(mlog.CreateConsoleTestLogger(t), makeTestArchive(t, testExport), &output, Options{
+		Users: testUserResolver{"carol@contoso.com": "carol.existing"},
+	})
+	require.NoError(t, err)
+
+	lines, files := readTestLines(t, output.Bytes())
+	var types []string
+	for _, line := range lines {
+		var appErr *model.AppError
+		switch line.Type {
+		case "team":
+			appErr = imports.ValidateTeamImportData(line.Team)
+		case "channel":
+			appErr = imports.ValidateChannelImportData(line.Channel)
+		case "user":
+			appErr = imports.ValidateUserImportData(line.User)
+		case "direct_channel":
+			appErr = imports.ValidateDirectChannelImportData(line.DirectChannel)
+		case "post":
+			appErr = imports.ValidatePostImportData(line.Post, model.PostMessageMaxRunesV2)
+		case "direct_post":
+			appErr = imports.ValidateDirectPostImportData(line.DirectPost, model.PostMessageMaxRunesV2)
+		}
+		require.Nil(t, appErr, "invalid %s line", line.Type)
+		types = append(types, line.Type)
+	}
+	require.Equal(t, []string{"version", "team", "channel", "channel", "user", "user", "direct_channel", "post", "post", "direct_post"}, types)
+	assert.Equal(t, map[string]string{"data/teams/a1/plan.docx": "the plan"}, files)
+
+	t.Run("team and channels", func(t *testing.T) {
+		assert.Equal(t, "contoso-engineering", *lines[1].Team.Name)
+		assert.Equal(t, "Contoso Engineering", *lines[1].Team.DisplayName)
+		assert.Equal(t, "Builders", *lines[1].Team.Description)
+
+		assert.Equal(t, "general", *lines[2].Channel.Name)
+		assert.Equal(t, model.ChannelTypeOpen, *lines[2].Channel.Type)
+		assert.Equal(t, "secret-plans", *lines[3].Channel.Name)
+		assert.Equal(t, model.ChannelTypePrivate, *lines[3].Channel.Type)
+		assert.Equal(t, "Shh", *lines[3].Channel.Purpose)
+	})
+
+	t.Run("users", func(t *testing.T) {
+		// carol is an existing user, and the user without an email is skipped.
+		alice := lines[4].User
+		assert.Equal(t, "alice.smith", *alice.Username)
+		assert.Equal(t, "alice.smith@contoso.com", *alice.Email)
+		assert.Equal(t, "Alice", *alice.FirstName)
+		assert.Equal(t, "Smith", *alice.LastName)
+		assert.Equal(t, "Engineer", *alice.Position)
+		require.Len(t, *alice.Teams, 1)
+		assert.Equal(t, "contoso-engineering", *(*alice.Teams)[0].Name)
+		require.Len(t, *(*alice.Teams)[0].Channels, 1)
+		assert.Equal(t, "general", *(*(*alice.Teams)[0].Channels)[0].Name)
+
+		assert.Equal(t, "bob", *lines[5].User.Username)
+	})
+
+	t.Run("posts", func(t *testing.T) {
+		post := lines[7].Post
+		assert.Equal(t, "contoso-engineering", *post.Team)
+		assert.Equal(t, "general", *post.Channel)
+		assert.Equal(t, "alice.smith", *post.User)
+		assert.Equal(t, "Hi @bob, see [the site](https://contoso.com)& the file\nhttps://contoso.sharepoint.com/missing.docx", *post.Message)
+		require.Len(t, *post.Attachments, 1)
+		assert.Equal(t, "teams/a1/plan.docx", *(*post.Attachments)[0].Path)
+		require.Len(t, *post.Reactions, 1)
+		assert.Equal(t, "bob", *(*post.Reactions)[0].User)
+		assert.Equal(t, "+1", *(*post.Reactions)[0].EmojiName)
+
+		require.Len(t, *post.Replies, 2)
+		assert.Equal(t, "First reply", *(*post.Replies)[0].Message)
+		assert.Equal(t, "carol.existing", *(*post.Replies)[1].User)
+		assert.NotNil(t, (*post.Replies)[1].EditAt)
+
+		assert.Equal(t, "secret-plans", *lines[8].Post.Channel)
+	})
+
+	t.Run("direct posts", func(t *testing.T) {
+		assert.Equal(t, []string{"alice.smith", "bob"}, *lines[6].DirectChannel.Members)
+		assert.Equal(t, "Lunch?", *lines[9].DirectPost.Message)
+		assert.Equal(t, "bob", *lines[9].DirectPost.User)
+	})
+}
+
+func TestConvertIntoTeam(t *testing.T) {
+	var output bytes.Buffer
+	err := Convert(mlog.CreateConsoleTestLogger(t), makeTestArchive(t, testExport), &output, Options{TeamName: "imported"})
+	require.NoError(t, err)
+
+	lines, _ := readTestLines(t, output.Bytes())
+	assert.Equal(t, "imported", *lines[1].Team.Name)
+	assert.Equal(t, "imported", *lines[1].Team.DisplayName)
+	assert.Nil(t, lines[1].Team.Description)
+	assert.Equal(t, "imported", *lines[2].Channel.Team)
+}
+
+func TestConvertHTML(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		output string
+	}{
+		{"plain", "plain"},
+		{"<p>one</p><p>two<br>three</p>", "one\ntwo\nthree"},
+		{"<i>it</i> <strong>bold</strong> <s>gone</s> <code>x</code>", "_it_ **bold** ~~gone~~ `x`"},
+		{"<ul><li>a</li><li>b</li></ul>", "- a\n- b"},
+		{`<a href="https://a.com">https://a.com</a>`, "https://a.com"},
+		{`<at id="1">Someone</at> &lt;3&nbsp;you`, "@someone <3 you"},
+		{`<img src="https://a.com/x.png"><span>text</span>`, "text"},
+	} {
+		assert.Equal(t, tc.output, convertHTML(tc.input, map[int]string{1: "@someone"}), "input = %v", tc.input)
+	}
+}
+
+func TestConvertChannelName(t *testing.T) {
+	assert.Equal(t, "general", convertChannelName("General", "19:a@thread.tacv2"))
+	assert.Equal(t, "design-review", convertChannelName("Design & Review", "19:a@thread.tacv2"))
+	assert.Equal(t, "19-a-thread-tacv2", convertChannelName("設計", "19:a@thread.tacv2"))
+}
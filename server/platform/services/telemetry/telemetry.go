@@ -77,6 +77,7 @@ const (
 	TrackConfigBleve             = "config_bleve"
 	TrackConfigExport            = "config_export"
 	TrackConfigWrangler          = "config_wrangler"
+	TrackConfigCache             = "config_cache"
 	TrackFeatureFlags            = "config_feature_flags"
 	TrackPermissionsGeneral      = "permissions_general"
 	TrackPermissionsSystemScheme = "permissions_system_scheme"
@@ -410,6 +411,7 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_outgoing_oauth_connections":                       cfg.ServiceSettings.EnableOutgoingOAuthConnections,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"outgoing_integrations_requests_timeout":                  cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout,
+		"outgoing_webhook_max_delivery_attempts":                  cfg.ServiceSettings.OutgoingWebhookMaxDeliveryAttempts,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
 		"enable_post_icon_override":                               cfg.ServiceSettings.EnablePostIconOverride,
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
@@ -492,6 +494,7 @@ func (ts *TelemetryService) trackConfig() {
 		"persistent_notification_max_count":                       *cfg.ServiceSettings.PersistentNotificationMaxCount,
 		"persistent_notification_max_recipients":                  *cfg.ServiceSettings.PersistentNotificationMaxRecipients,
 		"allow_synced_drafts":                                     *cfg.ServiceSettings.AllowSyncedDrafts,
+		"scheduled_posts":                                         *cfg.ServiceSettings.ScheduledPosts,
 		"refresh_post_stats_run_time":                             *cfg.ServiceSettings.RefreshPostStatsRunTime,
 		"maximum_payload_size":                                    *cfg.ServiceSettings.MaximumPayloadSizeBytes,
 	})
@@ -564,6 +567,9 @@ func (ts *TelemetryService) trackConfig() {
 		"file_max_queue_size":     *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
 		"advanced_logging_json":   len(cfg.ExperimentalAuditSettings.AdvancedLoggingJSON) != 0,
 		"advanced_logging_config": cfg.ExperimentalAuditSettings.AdvancedLoggingConfig != nil && *cfg.ExperimentalAuditSettings.AdvancedLoggingConfig != "",
+		"hash_chain_enabled":      *cfg.ExperimentalAuditSettings.HashChainEnabled,
+		"database_enabled":        *cfg.ExperimentalAuditSettings.DatabaseEnabled,
+		"checkpoint_interval":     *cfg.ExperimentalAuditSettings.CheckpointInterval,
 	})
 
 	ts.SendTelemetry(TrackConfigNotificationLog, map[string]any{
@@ -593,10 +599,17 @@ func (ts *TelemetryService) trackConfig() {
 		"isabsolute_directory":          filepath.IsAbs(*cfg.FileSettings.Directory),
 		"extract_content":               *cfg.FileSettings.ExtractContent,
 		"archive_recursion":             *cfg.FileSettings.ArchiveRecursion,
+		"extract_content_max_file_size": *cfg.FileSettings.ExtractContentMaxFileSize,
+		"extract_content_timeout":       *cfg.FileSettings.ExtractContentTimeoutSeconds,
+		"extract_content_limits":        len(cfg.FileSettings.ExtractContentLimits),
 		"amazon_s3_ssl":                 *cfg.FileSettings.AmazonS3SSL,
 		"amazon_s3_sse":                 *cfg.FileSettings.AmazonS3SSE,
 		"amazon_s3_signv2":              *cfg.FileSettings.AmazonS3SignV2,
 		"amazon_s3_trace":               *cfg.FileSettings.AmazonS3Trace,
+		"enable_encryption_at_rest":     *cfg.FileSettings.EnableEncryptionAtRest,
+		"enable_archive_store":          *cfg.FileSettings.EnableArchiveStore,
+		"archive_driver_name":           *cfg.FileSettings.ArchiveDriverName,
+		"archive_after_days":            *cfg.FileSettings.ArchiveAfterDays,
 		"max_file_size":                 *cfg.FileSettings.MaxFileSize,
 		"max_image_resolution":          *cfg.FileSettings.MaxImageResolution,
 		"max_image_decoder_concurrency": *cfg.FileSettings.MaxImageDecoderConcurrency,
@@ -630,6 +643,7 @@ func (ts *TelemetryService) trackConfig() {
 		"isdefault_login_button_border_color":  isDefault(*cfg.EmailSettings.LoginButtonBorderColor, ""),
 		"isdefault_login_button_text_color":    isDefault(*cfg.EmailSettings.LoginButtonTextColor, ""),
 		"smtp_server_timeout":                  *cfg.EmailSettings.SMTPServerTimeout,
+		"enable_reply_by_email":                *cfg.EmailSettings.EnableReplyByEmail,
 	})
 
 	ts.SendTelemetry(TrackConfigRate, map[string]any{
@@ -640,6 +654,8 @@ func (ts *TelemetryService) trackConfig() {
 		"max_burst":                *cfg.RateLimitSettings.MaxBurst,
 		"memory_store_size":        *cfg.RateLimitSettings.MemoryStoreSize,
 		"isdefault_vary_by_header": isDefault(cfg.RateLimitSettings.VaryByHeader, ""),
+		"store_type":               *cfg.RateLimitSettings.StoreType,
+		"policy_count":             len(cfg.RateLimitSettings.Policies),
 	})
 
 	ts.SendTelemetry(TrackConfigPrivacy, map[string]any{
@@ -836,6 +852,9 @@ func (ts *TelemetryService) trackConfig() {
 		"retention_ids_batch_size":      *cfg.DataRetentionSettings.RetentionIdsBatchSize,
 		"cleanup_jobs_threshold_days":   *cfg.JobSettings.CleanupJobsThresholdDays,
 		"cleanup_config_threshold_days": *cfg.JobSettings.CleanupConfigThresholdDays,
+		"cron_schedules":                len(cfg.JobSettings.CronSchedules),
+		"retry_policies":                len(cfg.JobSettings.RetryPolicies),
+		"follow_up_jobs":                len(cfg.JobSettings.FollowUpJobs),
 	})
 
 	ts.SendTelemetry(TrackConfigMessageExport, map[string]any{
@@ -870,6 +889,10 @@ func (ts *TelemetryService) trackConfig() {
 		"image_proxy_type":                     *cfg.ImageProxySettings.ImageProxyType,
 		"isdefault_remote_image_proxy_url":     isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
 		"isdefault_remote_image_proxy_options": isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
+		"max_image_size":                       *cfg.ImageProxySettings.MaxImageSize,
+		"enable_local_cache":                   *cfg.ImageProxySettings.EnableLocalCache,
+		"local_cache_ttl_minutes":              *cfg.ImageProxySettings.LocalCacheTTLMinutes,
+		"local_cache_max_size":                 *cfg.ImageProxySettings.LocalCacheMaxSize,
 	})
 
 	ts.SendTelemetry(TrackConfigBleve, map[string]any{
@@ -893,6 +916,10 @@ func (ts *TelemetryService) trackConfig() {
 		"move_thread_from_group_message_channel_enable":  cfg.WranglerSettings.MoveThreadFromGroupMessageChannelEnable,
 	})
 
+	ts.SendTelemetry(TrackConfigCache, map[string]any{
+		"cache_type": *cfg.CacheSettings.CacheType,
+	})
+
 	// Convert feature flags to map[string]any for sending
 	flags := cfg.FeatureFlags.ToMap()
 	interfaceFlags := make(map[string]any)
@@ -0,0 +1,660 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// azureBlockSize is the size of the blocks staged when writing or appending to a blob.
+	azureBlockSize = 8 * 1024 * 1024
+	// azureCopyPollInterval is how often the status of a pending server-side copy is checked.
+	azureCopyPollInterval = 100 * time.Millisecond
+)
+
+// AzureFileBackend contains all necessary information to communicate with
+// an Azure Blob Storage account, or an emulator such as Azurite.
+type AzureFileBackend struct {
+	serviceURL     string
+	account        string
+	container      string
+	pathPrefix     string
+	credential     *container.SharedKeyCredential
+	client         *container.Client
+	skipVerify     bool
+	timeout        time.Duration
+	presignExpires time.Duration
+}
+
+// AzureFileBackendAuthError is returned when testing a connection fails
+// because the storage account rejected the credentials.
+type AzureFileBackendAuthError struct {
+	DetailedError string
+}
+
+// AzureFileBackendNoContainerError is returned when testing a connection and no container is found
+type AzureFileBackendNoContainerError struct{}
+
+var (
+	_ FileBackend                  = (*AzureFileBackend)(nil)
+	_ FileBackendWithLinkGenerator = (*AzureFileBackend)(nil)
+	_ ReadCloseSeeker              = (*azureBlobReader)(nil)
+	_ io.ReaderAt                  = (*azureBlobReader)(nil)
+)
+
+func (e *AzureFileBackendAuthError) Error() string {
+	return e.DetailedError
+}
+
+func (e *AzureFileBackendNoContainerError) Error() string {
+	return "no such container"
+}
+
+// NewAzureFileBackend returns an instance of an AzureFileBackend.
+func NewAzureFileBackend(settings FileBackendSettings) (*AzureFileBackend, error) {
+	if err := settings.CheckMandatoryAzureFields(); err != nil {
+		return nil, err
+	}
+
+	serviceURL := settings.AzureEndpoint
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net", settings.AzureStorageAccount)
+	}
+
+	backend := &AzureFileBackend{
+		serviceURL:     strings.TrimRight(serviceURL, "/"),
+		account:        settings.AzureStorageAccount,
+		container:      settings.AzureContainer,
+		pathPrefix:     settings.AzurePathPrefix,
+		skipVerify:     settings.SkipVerify,
+		timeout:        time.Duration(settings.AzureRequestTimeoutMilliseconds) * time.Millisecond,
+		presignExpires: time.Duration(settings.AzurePresignExpiresSeconds) * time.Second,
+	}
+
+	cred, err := container.NewSharedKeyCredential(settings.AzureStorageAccount, settings.AzureAccessKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid azure storage account credentials")
+	}
+	backend.credential = cred
+
+	client, err := backend.azureNew()
+	if err != nil {
+		return nil, err
+	}
+	backend.client = client
+
+	return backend, nil
+}
+
+func (b *AzureFileBackend) azureNew() (*container.Client, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if b.skipVerify {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	opts := &container.ClientOptions{}
+	opts.Transport = &http.Client{Transport: tr}
+
+	client, err := container.NewClientWithSharedKeyCredential(b.serviceURL+"/"+b.container, b.credential, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the azure blob storage client")
+	}
+
+	return client, nil
+}
+
+func (b *AzureFileBackend) DriverName() string {
+	return driverAzure
+}
+
+func (b *AzureFileBackend) TestConnection() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	if _, err := b.client.GetProperties(ctx, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return &AzureFileBackendNoContainerError{}
+		}
+		return &AzureFileBackendAuthError{DetailedError: "unable to get the properties of the Azure container"}
+	}
+
+	mlog.Debug("Connection to Azure Blob Storage is good. Container exists.")
+	return nil
+}
+
+// MakeContainer creates the configured container in the storage account.
+func (b *AzureFileBackend) MakeContainer() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	if _, err := b.client.Create(ctx, nil); err != nil {
+		return errors.Wrap(err, "unable to create the azure container")
+	}
+	return nil
+}
+
+// azureBlobReader implements ReadCloseSeeker on top of ranged blob
+// downloads. A new download is started lazily after each seek.
+type azureBlobReader struct {
+	client *blob.Client
+	ctx    context.Context
+	timer  *time.Timer
+	cancel context.CancelFunc
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *azureBlobReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		resp, err := r.client.DownloadStream(r.ctx, &blob.DownloadStreamOptions{
+			Range: blob.HTTPRange{Offset: r.offset},
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to download the blob")
+		}
+		r.body = resp.Body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *azureBlobReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if abs != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = abs
+
+	return abs, nil
+}
+
+// ReadAt downloads the requested range independently of the current
+// position of the reader.
+func (r *azureBlobReader) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	count := int64(len(p))
+	if off+count > r.size {
+		count = r.size - off
+	}
+
+	resp, err := r.client.DownloadStream(r.ctx, &blob.DownloadStreamOptions{
+		Range: blob.HTTPRange{Offset: off, Count: count},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to download the blob")
+	}
+	defer resp.Body.Close()
+
+	n, err := io.ReadFull(resp.Body, p[:count])
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *azureBlobReader) Close() error {
+	r.timer.Stop()
+	r.cancel()
+	if r.body != nil {
+		return r.body.Close()
+	}
+	return nil
+}
+
+// CancelTimeout attempts to cancel the timeout for this reader. It allows calling
+// code to ignore the timeout in case of longer running operations. The methods returns
+// false if the timeout has already fired.
+func (r *azureBlobReader) CancelTimeout() bool {
+	return r.timer.Stop()
+}
+
+// Caller must close the first return value
+func (b *AzureFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	path = b.prefixedPath(path)
+	blobClient := b.client.NewBlobClient(path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	props, err := blobClient.GetProperties(ctx, nil)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	var size int64
+	if props.ContentLength != nil {
+		size = *props.ContentLength
+	}
+
+	return &azureBlobReader{
+		client: blobClient,
+		ctx:    ctx,
+		timer:  time.AfterFunc(b.timeout, cancel),
+		cancel: cancel,
+		size:   size,
+	}, nil
+}
+
+func (b *AzureFileBackend) ReadFile(path string) ([]byte, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	resp, err := b.client.NewBlobClient(path).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+	defer resp.Body.Close()
+
+	f, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return f, nil
+}
+
+func (b *AzureFileBackend) FileExists(path string) (bool, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	_, err := b.client.NewBlobClient(path).GetProperties(ctx, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+
+	return false, errors.Wrapf(err, "unable to know if file %s exists", path)
+}
+
+func (b *AzureFileBackend) FileSize(path string) (int64, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	props, err := b.client.NewBlobClient(path).GetProperties(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	if props.ContentLength == nil {
+		return 0, nil
+	}
+
+	return *props.ContentLength, nil
+}
+
+func (b *AzureFileBackend) FileModTime(path string) (time.Time, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	props, err := b.client.NewBlobClient(path).GetProperties(ctx, nil)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+	if props.LastModified == nil {
+		return time.Time{}, nil
+	}
+
+	return *props.LastModified, nil
+}
+
+func (b *AzureFileBackend) CopyFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	if err := b.copyBlob(oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy file from %s to %s", oldPath, newPath)
+	}
+
+	return nil
+}
+
+// copyBlob starts a server-side copy of the blob at src to dst and waits
+// until the copy has completed. Copies within the same storage account are
+// authorized by the shared key of the destination request.
+func (b *AzureFileBackend) copyBlob(src, dst string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	dstClient := b.client.NewBlobClient(dst)
+	resp, err := dstClient.StartCopyFromURL(ctx, b.client.NewBlobClient(src).URL(), nil)
+	if err != nil {
+		return err
+	}
+
+	status := resp.CopyStatus
+	for status != nil && *status == blob.CopyStatusTypePending {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(azureCopyPollInterval):
+		}
+
+		props, err := dstClient.GetProperties(ctx, nil)
+		if err != nil {
+			return err
+		}
+		status = props.CopyStatus
+	}
+
+	if status != nil && *status != blob.CopyStatusTypeSuccess {
+		return errors.Errorf("copy finished with status %s", *status)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) MoveFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	if err := b.copyBlob(oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy the file to %s to the new destination", newPath)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.client.NewBlobClient(oldPath).Delete(ctx, nil); err != nil {
+		return errors.Wrapf(err, "unable to remove the file old file %s", oldPath)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	return b.WriteFileContext(ctx, fr, path)
+}
+
+// WriteFileContext writes the data as a list of staged blocks, so that the
+// resulting blob can later be extended by AppendFile without rewriting it.
+func (b *AzureFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	path = b.prefixedPath(path)
+	blockClient := b.client.NewBlockBlobClient(path)
+
+	blockIDs, written, err := b.stageBlocks(ctx, blockClient, fr)
+	if err != nil {
+		return written, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+
+	opts := &blockblob.CommitBlockListOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: model.NewString(azureContentType(path))},
+	}
+	if _, err := blockClient.CommitBlockList(ctx, blockIDs, opts); err != nil {
+		return written, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+
+	return written, nil
+}
+
+func (b *AzureFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	fp := b.prefixedPath(path)
+	blockClient := b.client.NewBlockBlobClient(fp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	blockList, err := blockClient.GetBlockList(ctx, blockblob.BlockListTypeCommitted, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	blockIDs := make([]string, 0, len(blockList.CommittedBlocks))
+	for _, block := range blockList.CommittedBlocks {
+		blockIDs = append(blockIDs, *block.Name)
+	}
+
+	// Blobs that were not uploaded by this backend may have been written
+	// in a single request and have no committed blocks. Their content is
+	// staged again as blocks before appending to it.
+	if len(blockIDs) == 0 && blockList.BlobContentLength != nil && *blockList.BlobContentLength > 0 {
+		resp, err2 := blockClient.DownloadStream(ctx, nil)
+		if err2 != nil {
+			return 0, errors.Wrapf(err2, "unable to read the file %s to append the data", path)
+		}
+		existingIDs, _, err2 := b.stageBlocks(ctx, blockClient, resp.Body)
+		resp.Body.Close()
+		if err2 != nil {
+			return 0, errors.Wrapf(err2, "unable append the data in the file %s", path)
+		}
+		blockIDs = existingIDs
+	}
+
+	newIDs, written, err := b.stageBlocks(ctx, blockClient, fr)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	opts := &blockblob.CommitBlockListOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: model.NewString(azureContentType(fp))},
+		AccessConditions: &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: blockList.ETag},
+		},
+	}
+	if _, err := blockClient.CommitBlockList(ctx, append(blockIDs, newIDs...), opts); err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	return written, nil
+}
+
+// stageBlocks uploads the content of fr as uncommitted blocks and returns
+// their IDs in order, along with the number of bytes staged.
+func (b *AzureFileBackend) stageBlocks(ctx context.Context, blockClient *blockblob.Client, fr io.Reader) ([]string, int64, error) {
+	var (
+		blockIDs []string
+		written  int64
+	)
+	buf := make([]byte, azureBlockSize)
+	for {
+		n, err := io.ReadFull(fr, buf)
+		if n > 0 {
+			// Block IDs must have the same length for every block of a blob.
+			blockID := base64.StdEncoding.EncodeToString([]byte(model.NewId()))
+			body := streaming.NopCloser(bytes.NewReader(buf[:n]))
+			if _, err2 := blockClient.StageBlock(ctx, blockID, body, nil); err2 != nil {
+				return nil, written, err2
+			}
+			blockIDs = append(blockIDs, blockID)
+			written += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, written, err
+		}
+	}
+
+	return blockIDs, written, nil
+}
+
+func (b *AzureFileBackend) RemoveFile(path string) error {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	if _, err := b.client.NewBlobClient(path).Delete(ctx, nil); err != nil {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) listDirectory(path string, recursion bool) ([]string, error) {
+	path = b.prefixedPath(path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// Blob names are only matched as a prefix, appending "/" makes the
+		// behaviour consistent across all filestores.
+		path = path + "/"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	var names []string
+	if recursion {
+		pager := b.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &path})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+			}
+			for _, item := range page.Segment.BlobItems {
+				names = append(names, *item.Name)
+			}
+		}
+	} else {
+		pager := b.client.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{Prefix: &path})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+			}
+			for _, prefix := range page.Segment.BlobPrefixes {
+				names = append(names, *prefix.Name)
+			}
+			for _, item := range page.Segment.BlobItems {
+				names = append(names, *item.Name)
+			}
+		}
+	}
+
+	var paths []string
+	for _, name := range names {
+		// We strip the path prefix that gets applied,
+		// so that it remains transparent to the application.
+		trimmed := strings.Trim(strings.TrimPrefix(name, b.pathPrefix), "/")
+		if trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+	}
+
+	return paths, nil
+}
+
+func (b *AzureFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.listDirectory(path, false)
+}
+
+func (b *AzureFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.listDirectory(path, true)
+}
+
+func (b *AzureFileBackend) RemoveDirectory(path string) error {
+	path = b.prefixedPath(path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// Without the trailing "/" the prefix would also match sibling
+		// directories sharing the same name prefix.
+		path = path + "/"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	pager := b.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &path})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+		for _, item := range page.Segment.BlobItems {
+			if _, err := b.client.NewBlobClient(*item.Name).Delete(ctx, nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return errors.Wrapf(err, "unable to remove the directory %s", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GeneratePublicLink returns a URL signed with a shared access signature
+// that grants read access to the file until the link expires.
+func (b *AzureFileBackend) GeneratePublicLink(path string) (string, time.Duration, error) {
+	path = b.prefixedPath(path)
+
+	values := sas.BlobSignatureValues{
+		Protocol:           sas.ProtocolHTTPSandHTTP,
+		ExpiryTime:         time.Now().UTC().Add(b.presignExpires),
+		Permissions:        (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName:      b.container,
+		BlobName:           path,
+		ContentDisposition: "attachment",
+	}
+	if strings.HasPrefix(b.serviceURL, "https://") {
+		values.Protocol = sas.ProtocolHTTPS
+	}
+
+	params, err := values.SignWithSharedKey(b.credential)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "unable to generate public link for %s", path)
+	}
+
+	return b.client.NewBlobClient(path).URL() + "?" + params.Encode(), b.presignExpires, nil
+}
+
+func (b *AzureFileBackend) prefixedPath(s string) string {
+	return filepath.Join(b.pathPrefix, s)
+}
+
+func azureContentType(path string) string {
+	if ext := filepath.Ext(path); isFileExtImage(ext) {
+		return getImageMimeType(ext)
+	}
+	return "binary/octet-stream"
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Well-known development credentials of the Azurite emulator.
+	azuriteAccount   = "devstoreaccount1"
+	azuriteAccessKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+func TestCheckMandatoryAzureFields(t *testing.T) {
+	cfg := FileBackendSettings{}
+
+	err := cfg.CheckMandatoryAzureFields()
+	require.Error(t, err)
+	require.Equal(t, "missing azure storage account settings", err.Error())
+
+	cfg.AzureStorageAccount = azuriteAccount
+	err = cfg.CheckMandatoryAzureFields()
+	require.Error(t, err)
+	require.Equal(t, "missing azure container settings", err.Error())
+
+	cfg.AzureContainer = "mattermost-test"
+	err = cfg.CheckMandatoryAzureFields()
+	require.NoError(t, err)
+}
+
+func TestNewAzureFileBackend(t *testing.T) {
+	t.Run("defaults to the public endpoint of the account", func(t *testing.T) {
+		backend, err := NewAzureFileBackend(FileBackendSettings{
+			DriverName:          driverAzure,
+			AzureStorageAccount: "mmaccount",
+			AzureAccessKey:      azuriteAccessKey,
+			AzureContainer:      "files",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://mmaccount.blob.core.windows.net", backend.serviceURL)
+		assert.Equal(t, "https://mmaccount.blob.core.windows.net/files", backend.client.URL())
+	})
+
+	t.Run("invalid access key", func(t *testing.T) {
+		_, err := NewAzureFileBackend(FileBackendSettings{
+			DriverName:          driverAzure,
+			AzureStorageAccount: "mmaccount",
+			AzureAccessKey:      "not base64!",
+			AzureContainer:      "files",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestAzureGeneratePublicLink(t *testing.T) {
+	backend, err := NewAzureFileBackend(FileBackendSettings{
+		DriverName:                 driverAzure,
+		AzureStorageAccount:        azuriteAccount,
+		AzureAccessKey:             azuriteAccessKey,
+		AzureContainer:             "mattermost-test",
+		AzurePathPrefix:            "prefix",
+		AzureEndpoint:              "https://localhost:10000/" + azuriteAccount + "/",
+		AzurePresignExpiresSeconds: 3600,
+	})
+	require.NoError(t, err)
+
+	link, expires, err := backend.GeneratePublicLink("exports/export.zip")
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, expires)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/"+azuriteAccount+"/mattermost-test/prefix/exports/export.zip", u.Path)
+
+	query := u.Query()
+	assert.Equal(t, "r", query.Get("sp"))
+	assert.Equal(t, "b", query.Get("sr"))
+	assert.Equal(t, "https", query.Get("spr"))
+	assert.Equal(t, "attachment", query.Get("rscd"))
+	assert.NotEmpty(t, query.Get("sig"))
+
+	expiry, err := time.Parse(time.RFC3339, query.Get("se"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiry, time.Minute)
+}
+
+func TestAzureBlobReader(t *testing.T) {
+	content := "0123456789abcdefghij"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/mattermost-test/file.txt") {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		// The whole blob is requested when reading from the start.
+		var start int
+		if rangeHeader := r.Header.Get("x-ms-range"); rangeHeader != "" {
+			end := len(content) - 1
+			_, err := fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end)
+			if err != nil {
+				_, err = fmt.Sscanf(rangeHeader, "bytes=%d-", &start)
+			}
+			require.NoError(t, err)
+			content := content[:end+1]
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+			w.WriteHeader(http.StatusPartialContent)
+			io.WriteString(w, content[start:])
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		io.WriteString(w, content)
+	}))
+	defer server.Close()
+
+	backend, err := NewAzureFileBackend(FileBackendSettings{
+		DriverName:                      driverAzure,
+		AzureStorageAccount:             azuriteAccount,
+		AzureAccessKey:                  azuriteAccessKey,
+		AzureContainer:                  "mattermost-test",
+		AzureEndpoint:                   server.URL + "/" + azuriteAccount,
+		AzureRequestTimeoutMilliseconds: 5000,
+	})
+	require.NoError(t, err)
+
+	t.Run("read and seek", func(t *testing.T) {
+		r, err := backend.Reader("file.txt")
+		require.NoError(t, err)
+		defer r.Close()
+
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(r, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "01234", string(buf))
+
+		pos, err := r.Seek(10, io.SeekStart)
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, pos)
+
+		_, err = io.ReadFull(r, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "abcde", string(buf))
+
+		pos, err = r.Seek(-3, io.SeekEnd)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(content)-3, pos)
+
+		rest, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "hij", string(rest))
+
+		_, err = r.Seek(-1, io.SeekStart)
+		require.Error(t, err)
+	})
+
+	t.Run("read at", func(t *testing.T) {
+		r, err := backend.Reader("file.txt")
+		require.NoError(t, err)
+		defer r.Close()
+
+		ra, ok := r.(io.ReaderAt)
+		require.True(t, ok)
+
+		buf := make([]byte, 4)
+		n, err := ra.ReadAt(buf, 12)
+		require.NoError(t, err)
+		assert.Equal(t, "cdef", string(buf[:n]))
+
+		n, err = ra.ReadAt(buf, 18)
+		require.Equal(t, io.EOF, err)
+		assert.Equal(t, "ij", string(buf[:n]))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := backend.Reader("missing.txt")
+		require.Error(t, err)
+
+		exists, err := backend.FileExists("missing.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestAzureRemoveDirectory(t *testing.T) {
+	blobs := []string{"foo/a.txt", "foo/b/c.txt", "foobar/d.txt"}
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			prefix := r.URL.Query().Get("prefix")
+			w.Header().Set("Content-Type", "application/xml")
+			io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+			for _, name := range blobs {
+				if strings.HasPrefix(name, prefix) {
+					fmt.Fprintf(w, "<Blob><Name>%s</Name><Properties></Properties></Blob>", name)
+				}
+			}
+			io.WriteString(w, `</Blobs><NextMarker /></EnumerationResults>`)
+		case http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/"+azuriteAccount+"/mattermost-test/"))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	backend, err := NewAzureFileBackend(FileBackendSettings{
+		DriverName:                      driverAzure,
+		AzureStorageAccount:             azuriteAccount,
+		AzureAccessKey:                  azuriteAccessKey,
+		AzureContainer:                  "mattermost-test",
+		AzureEndpoint:                   server.URL + "/" + azuriteAccount,
+		AzureRequestTimeoutMilliseconds: 5000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, backend.RemoveDirectory("foo"))
+	assert.ElementsMatch(t, []string{"foo/a.txt", "foo/b/c.txt"}, deleted)
+}
@@ -0,0 +1,758 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Files written through an EncryptedFileBackend are laid out as follows:
+//
+//	magic (5 bytes) | version (1 byte) | key ID length (1 byte) | key ID | wrapped data key | frames...
+//
+// The data key is unique to each file and is wrapped with AES-GCM using the
+// master key identified by the key ID. The content is split into frames of
+// encryptedFramePlainSize bytes, each sealed with AES-GCM using the data key,
+// so that any position of the file can be decrypted without reading what
+// precedes it. Each frame is stored after the random nonce it was sealed
+// with, and its index is part of the additional data so that frames can't be
+// reordered.
+//
+// The last frame is always partial, possibly empty, and is sealed with
+// encryptedFinalFrameAD as additional data so that a file truncated on a
+// frame boundary is detected rather than decrypted as a shorter file. Since
+// its nonce is random, appending to the file only requires the final frame
+// to be sealed again as a regular one, followed by the new frames.
+//
+// Files of the first version derived the nonce of each frame from its index
+// instead, and are still read, but are rewritten as a whole on append.
+const (
+	encryptedFramePlainSize = 64 * 1024
+	encryptedFrameOverhead  = 16 // AES-GCM tag
+	encryptedDataKeySize    = 32
+	encryptedNonceSize      = 12
+	encryptedFrameSize      = encryptedNonceSize + encryptedFramePlainSize + encryptedFrameOverhead
+	encryptedWrappedKeySize = encryptedNonceSize + encryptedDataKeySize + encryptedFrameOverhead
+
+	encryptedVersionIndexNonce = 1
+	encryptedVersion           = 2
+
+	// EncryptionKeySize is the size in bytes of the master keys.
+	EncryptionKeySize = 32
+)
+
+var (
+	encryptedMagic        = []byte{'M', 'M', 'E', 'N', 'C'}
+	encryptedFinalFrameAD = []byte("final")
+)
+
+var (
+	_ FileBackend     = (*EncryptedFileBackend)(nil)
+	_ ReadCloseSeeker = (*encryptedReader)(nil)
+	_ io.ReaderAt     = (*encryptedReader)(nil)
+)
+
+// EncryptedFileBackend decorates a FileBackend to transparently encrypt the
+// content of the files written through it, whatever the underlying driver is.
+//
+// Files that were written before encryption was enabled are read as-is, and
+// can be encrypted afterwards with ReEncryptFile.
+type EncryptedFileBackend struct {
+	FileBackend
+
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+// encryptedHeader holds the information stored at the start of an encrypted file.
+type encryptedHeader struct {
+	version byte
+	keyID   string
+	dataKey cipher.AEAD
+	size    int64 // length of the header in bytes
+}
+
+// NewEncryptedFileBackend wraps backend so that files are encrypted with
+// data keys wrapped by activeKey. Files encrypted with any of the retiredKeys
+// can still be read. Keys are base64 encoded 256 bit AES keys.
+func NewEncryptedFileBackend(backend FileBackend, activeKey string, retiredKeys []string) (*EncryptedFileBackend, error) {
+	b := &EncryptedFileBackend{
+		FileBackend: backend,
+		keys:        make(map[string]cipher.AEAD, len(retiredKeys)+1),
+	}
+
+	for _, key := range append([]string{activeKey}, retiredKeys...) {
+		keyID, aead, err := parseEncryptionKey(key)
+		if err != nil {
+			return nil, err
+		}
+		b.keys[keyID] = aead
+		if b.activeKeyID == "" {
+			b.activeKeyID = keyID
+		}
+	}
+
+	return b, nil
+}
+
+// EncryptionKeyID returns the identifier stored in the files encrypted with
+// the given base64 encoded master key.
+func EncryptionKeyID(key string) (string, error) {
+	keyID, _, err := parseEncryptionKey(key)
+	return keyID, err
+}
+
+func parseEncryptionKey(key string) (string, cipher.AEAD, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to decode the encryption key")
+	}
+	if len(raw) != EncryptionKeySize {
+		return "", nil, errors.Errorf("invalid encryption key size %d, expected %d bytes", len(raw), EncryptionKeySize)
+	}
+
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:4]), aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the cipher")
+	}
+	return aead, nil
+}
+
+// Unwrap returns the backend the encrypted files are stored in.
+func (b *EncryptedFileBackend) Unwrap() FileBackend {
+	return b.FileBackend
+}
+
+// newHeader generates a new data key and returns the header to write at the
+// start of a file encrypted with it.
+func (b *EncryptedFileBackend) newHeader() (*encryptedHeader, []byte, error) {
+	dataKey := make([]byte, encryptedDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate a data key")
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.Write(encryptedMagic)
+	buf.WriteByte(encryptedVersion)
+	buf.WriteByte(byte(len(b.activeKeyID)))
+	buf.WriteString(b.activeKeyID)
+
+	nonce := make([]byte, encryptedNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate a nonce")
+	}
+	buf.Write(nonce)
+	buf.Write(b.keys[b.activeKeyID].Seal(nil, nonce, dataKey, buf.Bytes()[:len(encryptedMagic)+2+len(b.activeKeyID)]))
+
+	header := &encryptedHeader{
+		version: encryptedVersion,
+		keyID:   b.activeKeyID,
+		dataKey: aead,
+		size:    int64(buf.Len()),
+	}
+	return header, buf.Bytes(), nil
+}
+
+// readHeader reads the header at the start of r. It returns a nil header
+// when the file was not written by an EncryptedFileBackend.
+func (b *EncryptedFileBackend) readHeader(r io.Reader) (*encryptedHeader, error) {
+	prefix := make([]byte, len(encryptedMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "unable to read the file header")
+	}
+	version := prefix[len(encryptedMagic)]
+	if !bytes.Equal(prefix[:len(encryptedMagic)], encryptedMagic) || version < encryptedVersionIndexNonce || version > encryptedVersion {
+		return nil, nil
+	}
+
+	keyIDLen := int(prefix[len(encryptedMagic)+1])
+	rest := make([]byte, keyIDLen+encryptedWrappedKeySize)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, errors.Wrap(err, "unable to read the file header")
+	}
+
+	keyID := string(rest[:keyIDLen])
+	masterKey, ok := b.keys[keyID]
+	if !ok {
+		return nil, errors.Errorf("the file was encrypted with the unknown key %s", keyID)
+	}
+
+	nonce := rest[keyIDLen : keyIDLen+encryptedNonceSize]
+	additionalData := append(prefix, rest[:keyIDLen]...)
+	dataKey, err := masterKey.Open(nil, nonce, rest[keyIDLen+encryptedNonceSize:], additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to unwrap the data key")
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedHeader{
+		version: version,
+		keyID:   keyID,
+		dataKey: aead,
+		size:    int64(len(prefix) + len(rest)),
+	}, nil
+}
+
+// frameSize returns the stored size of a full frame.
+func (h *encryptedHeader) frameSize() int64 {
+	if h.version == encryptedVersionIndexNonce {
+		return encryptedFramePlainSize + encryptedFrameOverhead
+	}
+	return encryptedFrameSize
+}
+
+// plainSize returns the size of the content of an encrypted file given the
+// size of the stored file.
+func (h *encryptedHeader) plainSize(storedSize int64) (int64, error) {
+	body := storedSize - h.size
+	if body < 0 {
+		return 0, errors.New("truncated encrypted file")
+	}
+	// The final frame is never full, so the stored size can not be a
+	// multiple of the frame size.
+	frameSize := h.frameSize()
+	overhead := frameSize - encryptedFramePlainSize
+	rem := body % frameSize
+	if rem < overhead {
+		return 0, errors.New("truncated encrypted file")
+	}
+	return (body/frameSize)*encryptedFramePlainSize + rem - overhead, nil
+}
+
+// lastFrame returns the index of the final frame of a file given the size of
+// the stored file.
+func (h *encryptedHeader) lastFrame(storedSize int64) int64 {
+	return (storedSize - h.size) / h.frameSize()
+}
+
+// frameOffset returns the position of the frame at index in the stored file.
+func (h *encryptedHeader) frameOffset(index int64) int64 {
+	return h.size + index*h.frameSize()
+}
+
+// sealFrame appends the frame at index, sealed with a random nonce, to dst.
+func (h *encryptedHeader) sealFrame(dst, plain []byte, index int64, final bool) ([]byte, error) {
+	nonce := make([]byte, encryptedNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "unable to generate a nonce")
+	}
+	dst = append(dst, nonce...)
+	return h.dataKey.Seal(dst, nonce, plain, frameAD(index, final)), nil
+}
+
+// openFrame appends the content of the stored frame at index to dst.
+func (h *encryptedHeader) openFrame(dst, frame []byte, index int64, final bool) ([]byte, error) {
+	if h.version == encryptedVersionIndexNonce {
+		var ad []byte
+		if final {
+			ad = encryptedFinalFrameAD
+		}
+		return h.dataKey.Open(dst, indexNonce(index), frame, ad)
+	}
+
+	if len(frame) < encryptedNonceSize {
+		return nil, errors.New("truncated encrypted frame")
+	}
+	return h.dataKey.Open(dst, frame[:encryptedNonceSize], frame[encryptedNonceSize:], frameAD(index, final))
+}
+
+// indexNonce returns the nonce of the frame at index in the files of the
+// first version.
+func indexNonce(index int64) []byte {
+	nonce := make([]byte, encryptedNonceSize)
+	binary.BigEndian.PutUint64(nonce[encryptedNonceSize-8:], uint64(index))
+	return nonce
+}
+
+func frameAD(index int64, final bool) []byte {
+	ad := binary.BigEndian.AppendUint64(nil, uint64(index))
+	if final {
+		ad = append(ad, encryptedFinalFrameAD...)
+	}
+	return ad
+}
+
+// encryptFrames seals the content of r into w as frames starting at index
+// first, terminated by a partial final frame. It returns the number of
+// plaintext bytes that were encrypted.
+func encryptFrames(w io.Writer, r io.Reader, h *encryptedHeader, first int64) (int64, error) {
+	var written int64
+	buf := make([]byte, encryptedFramePlainSize)
+	sealed := make([]byte, 0, encryptedFrameSize)
+	for index := first; ; index++ {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return written, err
+		}
+		final := err != nil
+		sealed, err = h.sealFrame(sealed[:0], buf[:n], index, final)
+		if err != nil {
+			return written, err
+		}
+		if _, werr := w.Write(sealed); werr != nil {
+			return written, werr
+		}
+		written += int64(n)
+		if final {
+			return written, nil
+		}
+	}
+}
+
+// encryptingReader returns a reader producing the header, if any, followed
+// by the encrypted frames of r starting at index first. The number of
+// plaintext bytes read from r is available through the returned function
+// once the reader is drained. The reader fails as soon as ctx is done.
+func encryptingReader(ctx context.Context, r io.Reader, header []byte, h *encryptedHeader, first int64) (io.Reader, func() (int64, error)) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	var (
+		written int64
+		err     error
+	)
+	go func() {
+		defer close(done)
+		if _, err = pw.Write(header); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		written, err = encryptFrames(pw, r, h, first)
+		pw.CloseWithError(err)
+	}()
+
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				pr.CloseWithError(ctx.Err())
+			case <-done:
+			}
+		}()
+	}
+
+	return pr, func() (int64, error) {
+		// Unblock the goroutine in case the reader was not drained. The source
+		// might still be blocked though, so there is no point waiting for it
+		// once ctx is done.
+		pr.Close()
+		select {
+		case <-done:
+			return written, err
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+func (b *EncryptedFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	r, err := b.FileBackend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := b.readHeader(r)
+	if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+	if h == nil {
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			r.Close()
+			return nil, errors.Wrapf(err, "unable to open file %s", path)
+		}
+		return r, nil
+	}
+
+	storedSize, err := b.FileBackend.FileSize(path)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	size, err := h.plainSize(storedSize)
+	if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	return &encryptedReader{
+		r:          r,
+		header:     h,
+		size:       size,
+		storedSize: storedSize,
+		frame:      -1,
+	}, nil
+}
+
+func (b *EncryptedFileBackend) ReadFile(path string) ([]byte, error) {
+	data, err := b.FileBackend.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bytes.NewReader(data)
+	h, err := b.readHeader(br)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	if h == nil {
+		return data, nil
+	}
+
+	size, err := h.plainSize(int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+
+	plain := make([]byte, 0, size)
+	body := data[h.size:]
+	frameSize := int(h.frameSize())
+	for index := int64(0); len(body) > 0; index++ {
+		frame := body
+		final := len(frame) < frameSize
+		if !final {
+			frame = frame[:frameSize]
+		}
+		plain, err = h.openFrame(plain, frame, index, final)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decrypt file %s", path)
+		}
+		body = body[len(frame):]
+	}
+
+	return plain, nil
+}
+
+func (b *EncryptedFileBackend) FileSize(path string) (int64, error) {
+	storedSize, err := b.FileBackend.FileSize(path)
+	if err != nil {
+		return 0, err
+	}
+
+	h, err := b.header(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	if h == nil {
+		return storedSize, nil
+	}
+
+	return h.plainSize(storedSize)
+}
+
+// header returns the header of the file at path, or nil if the file is not encrypted.
+func (b *EncryptedFileBackend) header(path string) (*encryptedHeader, error) {
+	r, err := b.FileBackend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return b.readHeader(r)
+}
+
+func (b *EncryptedFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	return b.WriteFileContext(context.Background(), fr, path)
+}
+
+func (b *EncryptedFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	h, header, err := b.newHeader()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+
+	er, result := encryptingReader(ctx, fr, header, h, 0)
+	_, err = TryWriteFileContext(ctx, b.FileBackend, er, path)
+	written, encErr := result()
+	if err != nil {
+		return 0, err
+	}
+	if encErr != nil {
+		return 0, errors.Wrapf(encErr, "unable write the data in the file %s", path)
+	}
+
+	return written, nil
+}
+
+func (b *EncryptedFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	h, err := b.header(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	if h == nil {
+		// The file predates encryption, it will be encrypted as a whole by ReEncryptFile.
+		return b.FileBackend.AppendFile(fr, path)
+	}
+
+	// Files of the first version can't have their final frame sealed again
+	// without reusing its nonce, so they are rewritten with a new data key,
+	// as are the files of the backends that can't drop the final frame.
+	type Truncater interface {
+		TruncateFile(path string, size int64) error
+	}
+	tb, ok := b.FileBackend.(Truncater)
+	if h.version == encryptedVersionIndexNonce || !ok {
+		return b.rewriteFile(path, fr)
+	}
+
+	storedSize, err := b.FileBackend.FileSize(path)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = h.plainSize(storedSize); err != nil {
+		return 0, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	last := h.lastFrame(storedSize)
+	tail, err := b.readFinalFrame(path, h, last, storedSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+
+	// The content of the final frame is sealed again along with the new data,
+	// in place of the final frame.
+	counter := &countingReader{r: fr}
+	er, result := encryptingReader(context.Background(), io.MultiReader(bytes.NewReader(tail), counter), nil, h, last)
+	if err = tb.TruncateFile(path, h.frameOffset(last)); err != nil {
+		result()
+		return 0, err
+	}
+	_, err = b.FileBackend.AppendFile(er, path)
+	_, encErr := result()
+	if err != nil {
+		return 0, err
+	}
+	if encErr != nil {
+		return 0, errors.Wrapf(encErr, "unable to append the data in the file %s", path)
+	}
+
+	return counter.n, nil
+}
+
+// readFinalFrame returns the content of the final frame, at index last, of
+// the file at path.
+func (b *EncryptedFileBackend) readFinalFrame(path string, h *encryptedHeader, last, storedSize int64) ([]byte, error) {
+	r, err := b.FileBackend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	start := h.frameOffset(last)
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	sealed := make([]byte, storedSize-start)
+	if _, err = io.ReadFull(r, sealed); err != nil {
+		return nil, err
+	}
+
+	return h.openFrame(nil, sealed, last, true)
+}
+
+// rewriteFile encrypts the current content of the file at path, followed by
+// the content of extra if any, with a new data key and the active master key.
+// It returns the number of bytes read from extra.
+func (b *EncryptedFileBackend) rewriteFile(path string, extra io.Reader) (int64, error) {
+	current, err := b.Reader(path)
+	if err != nil {
+		return 0, err
+	}
+	defer current.Close()
+
+	counter := &countingReader{r: extra}
+	content := io.Reader(current)
+	if extra != nil {
+		content = io.MultiReader(current, counter)
+	}
+
+	tmpPath := path + ".reencrypt"
+	if _, err := b.WriteFile(content, tmpPath); err != nil {
+		b.FileBackend.RemoveFile(tmpPath)
+		return 0, err
+	}
+
+	if err := b.FileBackend.MoveFile(tmpPath, path); err != nil {
+		b.FileBackend.RemoveFile(tmpPath)
+		return 0, err
+	}
+
+	return counter.n, nil
+}
+
+// ReEncryptFile encrypts the file at path with the active master key if it
+// is not encrypted yet or was encrypted with a retired key. It returns
+// whether the file had to be rewritten.
+func (b *EncryptedFileBackend) ReEncryptFile(path string) (bool, error) {
+	h, err := b.header(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to read the header of the file %s", path)
+	}
+	if h != nil && h.keyID == b.activeKeyID {
+		return false, nil
+	}
+
+	if _, err := b.rewriteFile(path, nil); err != nil {
+		return false, errors.Wrapf(err, "unable to encrypt the file %s", path)
+	}
+
+	return true, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// encryptedReader decrypts the frames of an encrypted file on demand.
+type encryptedReader struct {
+	mut        sync.Mutex
+	r          ReadCloseSeeker
+	header     *encryptedHeader
+	size       int64
+	storedSize int64
+	offset     int64
+
+	// frame is the index of the last decrypted frame held in plain.
+	frame int64
+	plain []byte
+}
+
+// readFrame decrypts the frame at index. The caller must hold the mutex.
+func (er *encryptedReader) readFrame(index int64) error {
+	if er.frame == index {
+		return nil
+	}
+
+	start := er.header.frameOffset(index)
+	length := er.header.frameSize()
+	if start+length > er.storedSize {
+		length = er.storedSize - start
+	}
+
+	sealed := make([]byte, length)
+	if ra, ok := er.r.(io.ReaderAt); ok {
+		if _, err := ra.ReadAt(sealed, start); err != nil && err != io.EOF {
+			return err
+		}
+	} else {
+		if _, err := er.r.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(er.r, sealed); err != nil {
+			return err
+		}
+	}
+
+	final := index == er.header.lastFrame(er.storedSize)
+	plain, err := er.header.openFrame(er.plain[:0], sealed, index, final)
+	if err != nil {
+		er.frame = -1
+		return errors.Wrap(err, "unable to decrypt the file")
+	}
+	er.plain = plain
+	er.frame = index
+
+	return nil
+}
+
+func (er *encryptedReader) ReadAt(p []byte, off int64) (int, error) {
+	er.mut.Lock()
+	defer er.mut.Unlock()
+
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= er.size {
+			return n, io.EOF
+		}
+		if err := er.readFrame(pos / encryptedFramePlainSize); err != nil {
+			return n, err
+		}
+		n += copy(p[n:], er.plain[pos%encryptedFramePlainSize:])
+	}
+
+	return n, nil
+}
+
+func (er *encryptedReader) Read(p []byte) (int, error) {
+	n, err := er.ReadAt(p, er.offset)
+	er.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (er *encryptedReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = er.offset + offset
+	case io.SeekEnd:
+		abs = er.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+	er.offset = abs
+
+	return abs, nil
+}
+
+func (er *encryptedReader) Close() error {
+	return er.r.Close()
+}
+
+// CancelTimeout cancels the timeout of the underlying reader, if it has any.
+func (er *encryptedReader) CancelTimeout() bool {
+	type TimeoutCanceler interface{ CancelTimeout() bool }
+	if tc, ok := er.r.(TimeoutCanceler); ok {
+		return tc.CancelTimeout()
+	}
+	return true
+}
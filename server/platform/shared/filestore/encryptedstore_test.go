@@ -0,0 +1,318 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEncryptionKey(t *testing.T) string {
+	key := make([]byte, EncryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestNewEncryptedFileBackend(t *testing.T) {
+	local := &LocalFileBackend{directory: t.TempDir()}
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := NewEncryptedFileBackend(local, "not base64!", nil)
+		require.Error(t, err)
+
+		_, err = NewEncryptedFileBackend(local, base64.StdEncoding.EncodeToString([]byte("too short")), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid retired key", func(t *testing.T) {
+		_, err := NewEncryptedFileBackend(local, newEncryptionKey(t), []string{"invalid"})
+		require.Error(t, err)
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		backend, err := NewEncryptedFileBackend(local, newEncryptionKey(t), nil)
+		require.NoError(t, err)
+		assert.Equal(t, local, backend.Unwrap())
+		assert.Equal(t, local, UnwrapFileBackend(backend))
+	})
+}
+
+func TestEncryptedFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	local := &LocalFileBackend{directory: dir}
+	activeKey := newEncryptionKey(t)
+	backend, err := NewEncryptedFileBackend(local, activeKey, nil)
+	require.NoError(t, err)
+
+	// Spans a few frames, the last one being partial.
+	data := make([]byte, 3*encryptedFramePlainSize+1234)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+
+	written, err := backend.WriteFile(bytes.NewReader(data), "file.bin")
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), written)
+
+	t.Run("content is encrypted", func(t *testing.T) {
+		stored, err := os.ReadFile(filepath.Join(dir, "file.bin"))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(stored, encryptedMagic))
+		assert.False(t, bytes.Contains(stored, data[:64]))
+
+		size, err := backend.FileSize("file.bin")
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data), size)
+	})
+
+	t.Run("read, seek and read at", func(t *testing.T) {
+		r, err := backend.Reader("file.bin")
+		require.NoError(t, err)
+		defer r.Close()
+
+		read, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+
+		offset := int64(encryptedFramePlainSize - 10)
+		pos, err := r.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		assert.Equal(t, offset, pos)
+
+		buf := make([]byte, 20)
+		_, err = io.ReadFull(r, buf)
+		require.NoError(t, err)
+		assert.Equal(t, data[offset:offset+20], buf)
+
+		ra, ok := r.(io.ReaderAt)
+		require.True(t, ok)
+		n, err := ra.ReadAt(buf, int64(len(data)-5))
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, data[len(data)-5:], buf[:n])
+
+		pos, err = r.Seek(-3, io.SeekEnd)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data)-3, pos)
+		rest, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data[len(data)-3:], rest)
+	})
+
+	t.Run("append to a partial frame", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(data), "append.bin")
+		require.NoError(t, err)
+
+		extra := bytes.Repeat([]byte{'B'}, 100)
+		written, err := backend.AppendFile(bytes.NewReader(extra), "append.bin")
+		require.NoError(t, err)
+		assert.EqualValues(t, len(extra), written)
+
+		read, err := backend.ReadFile("append.bin")
+		require.NoError(t, err)
+		assert.Equal(t, append(append([]byte{}, data...), extra...), read)
+
+		exists, err := local.FileExists("append.bin.reencrypt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("append keeps the data key and full frames", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(data), "keep.bin")
+		require.NoError(t, err)
+		before, err := os.ReadFile(filepath.Join(dir, "keep.bin"))
+		require.NoError(t, err)
+		h, err := backend.readHeader(bytes.NewReader(before))
+		require.NoError(t, err)
+
+		// Fills the final frame and spills over the next one.
+		extra := make([]byte, encryptedFramePlainSize)
+		_, err = rand.Read(extra)
+		require.NoError(t, err)
+		_, err = backend.AppendFile(bytes.NewReader(extra), "keep.bin")
+		require.NoError(t, err)
+		_, err = backend.AppendFile(bytes.NewReader(extra[:10]), "keep.bin")
+		require.NoError(t, err)
+
+		after, err := os.ReadFile(filepath.Join(dir, "keep.bin"))
+		require.NoError(t, err)
+		last := h.frameOffset(h.lastFrame(int64(len(before))))
+		assert.Equal(t, before[:last], after[:last])
+
+		read, err := backend.ReadFile("keep.bin")
+		require.NoError(t, err)
+		assert.Equal(t, append(append(append([]byte{}, data...), extra...), extra[:10]...), read)
+	})
+
+	t.Run("tampered content", func(t *testing.T) {
+		stored, err := os.ReadFile(filepath.Join(dir, "file.bin"))
+		require.NoError(t, err)
+		stored[len(stored)-1] ^= 0xff
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tampered.bin"), stored, 0600))
+
+		_, err = backend.ReadFile("tampered.bin")
+		require.Error(t, err)
+	})
+
+	t.Run("truncated on a frame boundary", func(t *testing.T) {
+		stored, err := os.ReadFile(filepath.Join(dir, "file.bin"))
+		require.NoError(t, err)
+		h, err := backend.readHeader(bytes.NewReader(stored))
+		require.NoError(t, err)
+		truncated := stored[:h.frameOffset(2)]
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "truncated.bin"), truncated, 0600))
+
+		_, err = backend.ReadFile("truncated.bin")
+		require.Error(t, err)
+		_, err = backend.Reader("truncated.bin")
+		require.Error(t, err)
+
+		// Dropping part of the final frame leaves a regular frame last.
+		truncated = stored[:h.frameOffset(2)+100]
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "truncated.bin"), truncated, 0600))
+
+		_, err = backend.ReadFile("truncated.bin")
+		require.Error(t, err)
+	})
+
+	t.Run("whole number of frames", func(t *testing.T) {
+		aligned := data[:2*encryptedFramePlainSize]
+		_, err := backend.WriteFile(bytes.NewReader(aligned), "aligned.bin")
+		require.NoError(t, err)
+
+		read, err := backend.ReadFile("aligned.bin")
+		require.NoError(t, err)
+		assert.Equal(t, aligned, read)
+
+		extra := bytes.Repeat([]byte{'C'}, 10)
+		_, err = backend.AppendFile(bytes.NewReader(extra), "aligned.bin")
+		require.NoError(t, err)
+
+		r, err := backend.Reader("aligned.bin")
+		require.NoError(t, err)
+		defer r.Close()
+		read, err = io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, append(append([]byte{}, aligned...), extra...), read)
+	})
+
+	t.Run("first version files", func(t *testing.T) {
+		old := data[:encryptedFramePlainSize+10]
+		stored := writeIndexNonceFile(t, backend, old)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "v1.bin"), stored, 0600))
+
+		read, err := backend.ReadFile("v1.bin")
+		require.NoError(t, err)
+		assert.Equal(t, old, read)
+
+		r, err := backend.Reader("v1.bin")
+		require.NoError(t, err)
+		read, err = io.ReadAll(r)
+		r.Close()
+		require.NoError(t, err)
+		assert.Equal(t, old, read)
+
+		extra := []byte("appended")
+		_, err = backend.AppendFile(bytes.NewReader(extra), "v1.bin")
+		require.NoError(t, err)
+
+		rewritten, err := os.ReadFile(filepath.Join(dir, "v1.bin"))
+		require.NoError(t, err)
+		h, err := backend.readHeader(bytes.NewReader(rewritten))
+		require.NoError(t, err)
+		assert.EqualValues(t, encryptedVersion, h.version)
+
+		read, err = backend.ReadFile("v1.bin")
+		require.NoError(t, err)
+		assert.Equal(t, append(append([]byte{}, old...), extra...), read)
+	})
+
+	t.Run("plaintext files", func(t *testing.T) {
+		plain := []byte("written before encryption was enabled")
+		_, err := local.WriteFile(bytes.NewReader(plain), "plain.txt")
+		require.NoError(t, err)
+
+		read, err := backend.ReadFile("plain.txt")
+		require.NoError(t, err)
+		assert.Equal(t, plain, read)
+
+		rewritten, err := backend.ReEncryptFile("plain.txt")
+		require.NoError(t, err)
+		assert.True(t, rewritten)
+
+		stored, err := local.ReadFile("plain.txt")
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(stored, encryptedMagic))
+
+		read, err = backend.ReadFile("plain.txt")
+		require.NoError(t, err)
+		assert.Equal(t, plain, read)
+
+		rewritten, err = backend.ReEncryptFile("plain.txt")
+		require.NoError(t, err)
+		assert.False(t, rewritten)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		rotated, err := NewEncryptedFileBackend(local, newEncryptionKey(t), []string{activeKey})
+		require.NoError(t, err)
+
+		read, err := rotated.ReadFile("file.bin")
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+
+		rewritten, err := rotated.ReEncryptFile("file.bin")
+		require.NoError(t, err)
+		assert.True(t, rewritten)
+
+		read, err = rotated.ReadFile("file.bin")
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+
+		// The file can't be read anymore without the new key.
+		_, err = backend.ReadFile("file.bin")
+		require.Error(t, err)
+		_, err = backend.Reader("file.bin")
+		require.Error(t, err)
+	})
+}
+
+// writeIndexNonceFile returns data encrypted in the first version of the
+// format, whose frame nonces are derived from their index.
+func writeIndexNonceFile(t *testing.T, b *EncryptedFileBackend, data []byte) []byte {
+	h, header, err := b.newHeader()
+	require.NoError(t, err)
+
+	// The wrapped data key is bound to the version, so it is sealed again.
+	keyIDEnd := len(encryptedMagic) + 2 + len(b.activeKeyID)
+	nonce := header[keyIDEnd : keyIDEnd+encryptedNonceSize]
+	dataKey, err := b.keys[b.activeKeyID].Open(nil, nonce, header[keyIDEnd+encryptedNonceSize:], header[:keyIDEnd])
+	require.NoError(t, err)
+	header[len(encryptedMagic)] = encryptedVersionIndexNonce
+	stored := b.keys[b.activeKeyID].Seal(header[:keyIDEnd+encryptedNonceSize], nonce, dataKey, header[:keyIDEnd])
+
+	for index := int64(0); ; index++ {
+		frame := data
+		final := len(frame) < encryptedFramePlainSize
+		if !final {
+			frame = frame[:encryptedFramePlainSize]
+		}
+		var ad []byte
+		if final {
+			ad = encryptedFinalFrameAD
+		}
+		stored = h.dataKey.Seal(stored, indexNonce(index), frame, ad)
+		data = data[len(frame):]
+		if final {
+			return stored
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 const (
 	driverS3    = "amazons3"
 	driverLocal = "local"
+	driverAzure = "azureblob"
 )
 
 type ReadCloseSeeker interface {
@@ -63,15 +64,46 @@ type FileBackendSettings struct {
 	AmazonS3RequestTimeoutMilliseconds int64
 	AmazonS3PresignExpiresSeconds      int64
 	AmazonS3UploadPartSizeBytes        int64
+	AzureStorageAccount                string
+	AzureAccessKey                     string
+	AzureContainer                     string
+	AzurePathPrefix                    string
+	AzureEndpoint                      string
+	AzureRequestTimeoutMilliseconds    int64
+	AzurePresignExpiresSeconds         int64
+	EncryptionKey                      string
+	EncryptionRetiredKeys              []string
 }
 
 func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
+	settings := newDriverSettingsFromConfig(fileSettings, enableComplianceFeature, skipVerify)
+	if fileSettings.EnableEncryptionAtRest != nil && *fileSettings.EnableEncryptionAtRest {
+		settings.EncryptionKey = *fileSettings.EncryptionAtRestKey
+		settings.EncryptionRetiredKeys = fileSettings.EncryptionAtRestRetiredKeys
+	}
+	return settings
+}
+
+func newDriverSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
 	if *fileSettings.DriverName == model.ImageDriverLocal {
 		return FileBackendSettings{
 			DriverName: *fileSettings.DriverName,
 			Directory:  *fileSettings.Directory,
 		}
 	}
+	if *fileSettings.DriverName == model.ImageDriverAzure {
+		return FileBackendSettings{
+			DriverName:                      *fileSettings.DriverName,
+			AzureStorageAccount:             *fileSettings.AzureStorageAccount,
+			AzureAccessKey:                  *fileSettings.AzureAccessKey,
+			AzureContainer:                  *fileSettings.AzureContainer,
+			AzurePathPrefix:                 *fileSettings.AzurePathPrefix,
+			AzureEndpoint:                   *fileSettings.AzureEndpoint,
+			AzureRequestTimeoutMilliseconds: *fileSettings.AzureRequestTimeoutMilliseconds,
+			AzurePresignExpiresSeconds:      *fileSettings.AzurePresignExpiresSeconds,
+			SkipVerify:                      skipVerify,
+		}
+	}
 	return FileBackendSettings{
 		DriverName:                         *fileSettings.DriverName,
 		AmazonS3AccessKeyId:                *fileSettings.AmazonS3AccessKeyId,
@@ -116,6 +148,42 @@ func NewExportFileBackendSettingsFromConfig(fileSettings *model.FileSettings, en
 	}
 }
 
+// NewArchiveFileBackendSettingsFromConfig returns the settings of the backend
+// older files are moved to when the archive store is enabled.
+func NewArchiveFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
+	var settings FileBackendSettings
+	if *fileSettings.ArchiveDriverName == model.ImageDriverLocal {
+		settings = FileBackendSettings{
+			DriverName: *fileSettings.ArchiveDriverName,
+			Directory:  *fileSettings.ArchiveDirectory,
+		}
+	} else {
+		settings = FileBackendSettings{
+			DriverName:                         *fileSettings.ArchiveDriverName,
+			AmazonS3AccessKeyId:                *fileSettings.ArchiveAmazonS3AccessKeyId,
+			AmazonS3SecretAccessKey:            *fileSettings.ArchiveAmazonS3SecretAccessKey,
+			AmazonS3Bucket:                     *fileSettings.ArchiveAmazonS3Bucket,
+			AmazonS3PathPrefix:                 *fileSettings.ArchiveAmazonS3PathPrefix,
+			AmazonS3Region:                     *fileSettings.ArchiveAmazonS3Region,
+			AmazonS3Endpoint:                   *fileSettings.ArchiveAmazonS3Endpoint,
+			AmazonS3SSL:                        fileSettings.ArchiveAmazonS3SSL == nil || *fileSettings.ArchiveAmazonS3SSL,
+			AmazonS3SignV2:                     fileSettings.ArchiveAmazonS3SignV2 != nil && *fileSettings.ArchiveAmazonS3SignV2,
+			AmazonS3SSE:                        fileSettings.ArchiveAmazonS3SSE != nil && *fileSettings.ArchiveAmazonS3SSE && enableComplianceFeature,
+			AmazonS3Trace:                      fileSettings.ArchiveAmazonS3Trace != nil && *fileSettings.ArchiveAmazonS3Trace,
+			AmazonS3RequestTimeoutMilliseconds: *fileSettings.ArchiveAmazonS3RequestTimeoutMilliseconds,
+			AmazonS3UploadPartSizeBytes:        *fileSettings.ArchiveAmazonS3UploadPartSizeBytes,
+			SkipVerify:                         skipVerify,
+		}
+	}
+
+	// Archived files are encrypted the same way as the other files.
+	if fileSettings.EnableEncryptionAtRest != nil && *fileSettings.EnableEncryptionAtRest {
+		settings.EncryptionKey = *fileSettings.EncryptionAtRestKey
+		settings.EncryptionRetiredKeys = fileSettings.EncryptionAtRestRetiredKeys
+	}
+	return settings
+}
+
 func (settings *FileBackendSettings) CheckMandatoryS3Fields() error {
 	if settings.AmazonS3Bucket == "" {
 		return errors.New("missing s3 bucket settings")
@@ -129,6 +197,18 @@ func (settings *FileBackendSettings) CheckMandatoryS3Fields() error {
 	return nil
 }
 
+func (settings *FileBackendSettings) CheckMandatoryAzureFields() error {
+	if settings.AzureStorageAccount == "" {
+		return errors.New("missing azure storage account settings")
+	}
+
+	if settings.AzureContainer == "" {
+		return errors.New("missing azure container settings")
+	}
+
+	return nil
+}
+
 // NewFileBackend creates a new file backend
 func NewFileBackend(settings FileBackendSettings) (FileBackend, error) {
 	return newFileBackend(settings, true)
@@ -140,6 +220,19 @@ func NewExportFileBackend(settings FileBackendSettings) (FileBackend, error) {
 }
 
 func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
+	backend, err := newDriverFileBackend(settings, canBeCloud)
+	if err != nil || settings.EncryptionKey == "" {
+		return backend, err
+	}
+
+	encrypted, err := NewEncryptedFileBackend(backend, settings.EncryptionKey, settings.EncryptionRetiredKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to set up the encryption of the files")
+	}
+	return encrypted, nil
+}
+
+func newDriverFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
 	switch settings.DriverName {
 	case driverS3:
 		newBackendFn := NewS3FileBackend
@@ -151,6 +244,12 @@ func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend,
 			return nil, errors.Wrap(err, "unable to connect to the s3 backend")
 		}
 		return backend, nil
+	case driverAzure:
+		backend, err := NewAzureFileBackend(settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to the azure backend")
+		}
+		return backend, nil
 	case driverLocal:
 		return &LocalFileBackend{
 			directory: settings.Directory,
@@ -173,3 +272,19 @@ func TryWriteFileContext(ctx context.Context, fb FileBackend, fr io.Reader, path
 
 	return fb.WriteFile(fr, path)
 }
+
+// UnwrapFileBackend returns the backend storing the files of fb when fb
+// decorates another backend, such as an EncryptedFileBackend.
+func UnwrapFileBackend(fb FileBackend) FileBackend {
+	type Unwrapper interface {
+		Unwrap() FileBackend
+	}
+
+	for {
+		u, ok := fb.(Unwrapper)
+		if !ok {
+			return fb
+		}
+		fb = u.Unwrap()
+	}
+}
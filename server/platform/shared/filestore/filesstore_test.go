@@ -6,6 +6,7 @@ package filestore
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"math"
@@ -53,6 +54,20 @@ func TestLocalFileBackendTestSuite(t *testing.T) {
 	})
 }
 
+func TestLocalEncryptedFileBackendTestSuite(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:    driverLocal,
+			Directory:     dir,
+			EncryptionKey: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, EncryptionKeySize)),
+		},
+	})
+}
+
 func TestS3FileBackendTestSuite(t *testing.T) {
 	runBackendTest(t, false)
 }
@@ -90,6 +105,31 @@ func runBackendTest(t *testing.T, encrypt bool) {
 	})
 }
 
+func TestAzureFileBackendTestSuite(t *testing.T) {
+	azuriteHost := os.Getenv("CI_AZURITE_HOST")
+	if azuriteHost == "" {
+		t.Skip("CI_AZURITE_HOST is not set, skipping the Azure Blob Storage tests")
+	}
+
+	azuritePort := os.Getenv("CI_AZURITE_PORT")
+	if azuritePort == "" {
+		azuritePort = "10000"
+	}
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:          driverAzure,
+			AzureStorageAccount: azuriteAccount,
+			AzureAccessKey:      azuriteAccessKey,
+			AzureContainer:      "mattermost-test",
+			// Azurite serves accounts as the first segment of the path.
+			AzureEndpoint:                   fmt.Sprintf("http://%s:%s/%s", azuriteHost, azuritePort, azuriteAccount),
+			AzureRequestTimeoutMilliseconds: 5000,
+			AzurePresignExpiresSeconds:      60,
+		},
+	})
+}
+
 func (s *FileBackendTestSuite) SetupTest() {
 	backend, err := NewFileBackend(s.settings)
 	require.NoError(s.T(), err)
@@ -100,6 +140,9 @@ func (s *FileBackendTestSuite) SetupTest() {
 	if _, ok := err.(*S3FileBackendNoBucketError); ok {
 		s3Backend := s.backend.(*S3FileBackend)
 		s.NoError(s3Backend.MakeBucket())
+	} else if _, ok := err.(*AzureFileBackendNoContainerError); ok {
+		azureBackend := s.backend.(*AzureFileBackend)
+		s.NoError(azureBackend.MakeContainer())
 	} else {
 		s.NoError(err)
 	}
@@ -185,6 +185,14 @@ func (b *LocalFileBackend) AppendFile(fr io.Reader, path string) (int64, error)
 	return written, nil
 }
 
+// TruncateFile shortens the file at path to size bytes.
+func (b *LocalFileBackend) TruncateFile(path string, size int64) error {
+	if err := os.Truncate(filepath.Join(b.directory, path), size); err != nil {
+		return errors.Wrapf(err, "unable to truncate the file %s", path)
+	}
+	return nil
+}
+
 func (b *LocalFileBackend) RemoveFile(path string) error {
 	if err := os.Remove(filepath.Join(b.directory, path)); err != nil {
 		return errors.Wrapf(err, "unable to remove the file %s", path)
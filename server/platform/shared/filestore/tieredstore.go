@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var _ FileBackend = (*TieredFileBackend)(nil)
+
+// TieredFileBackend routes the file operations between two backends. New
+// files are always written to the primary backend, while existing files are
+// read from the primary backend first and from the secondary backend if they
+// can't be found there. This allows keeping recent files on a fast backend and
+// moving older ones to a cheaper one without the application noticing.
+type TieredFileBackend struct {
+	primary   FileBackend
+	secondary FileBackend
+}
+
+// NewTieredFileBackend creates a backend writing to primary and reading from
+// secondary the files that are missing in primary.
+func NewTieredFileBackend(primary, secondary FileBackend) *TieredFileBackend {
+	return &TieredFileBackend{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// Primary returns the backend new files are written to.
+func (b *TieredFileBackend) Primary() FileBackend {
+	return b.primary
+}
+
+// Secondary returns the backend used as a fallback for reads.
+func (b *TieredFileBackend) Secondary() FileBackend {
+	return b.secondary
+}
+
+// Unwrap returns the primary backend.
+func (b *TieredFileBackend) Unwrap() FileBackend {
+	return b.primary
+}
+
+// locate returns the backend holding the file at path. The primary backend
+// is returned when the file doesn't exist, so that callers get its errors.
+func (b *TieredFileBackend) locate(path string) FileBackend {
+	if exists, err := b.primary.FileExists(path); err == nil && !exists {
+		if exists, err = b.secondary.FileExists(path); err == nil && exists {
+			return b.secondary
+		}
+	}
+	return b.primary
+}
+
+func (b *TieredFileBackend) DriverName() string {
+	return b.primary.DriverName()
+}
+
+func (b *TieredFileBackend) TestConnection() error {
+	if err := b.primary.TestConnection(); err != nil {
+		return err
+	}
+	if err := b.secondary.TestConnection(); err != nil {
+		return errors.Wrap(err, "unable to connect to the secondary file backend")
+	}
+	return nil
+}
+
+func (b *TieredFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	return b.locate(path).Reader(path)
+}
+
+func (b *TieredFileBackend) ReadFile(path string) ([]byte, error) {
+	return b.locate(path).ReadFile(path)
+}
+
+func (b *TieredFileBackend) FileExists(path string) (bool, error) {
+	exists, err := b.primary.FileExists(path)
+	if err != nil || exists {
+		return exists, err
+	}
+	return b.secondary.FileExists(path)
+}
+
+func (b *TieredFileBackend) FileSize(path string) (int64, error) {
+	return b.locate(path).FileSize(path)
+}
+
+func (b *TieredFileBackend) FileModTime(path string) (time.Time, error) {
+	return b.locate(path).FileModTime(path)
+}
+
+func (b *TieredFileBackend) CopyFile(oldPath, newPath string) error {
+	return b.locate(oldPath).CopyFile(oldPath, newPath)
+}
+
+func (b *TieredFileBackend) MoveFile(oldPath, newPath string) error {
+	return b.locate(oldPath).MoveFile(oldPath, newPath)
+}
+
+func (b *TieredFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	return b.primary.WriteFile(fr, path)
+}
+
+func (b *TieredFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	return TryWriteFileContext(ctx, b.primary, fr, path)
+}
+
+func (b *TieredFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	return b.locate(path).AppendFile(fr, path)
+}
+
+// RemoveFile removes the file from both backends.
+func (b *TieredFileBackend) RemoveFile(path string) error {
+	inSecondary, err := b.secondary.FileExists(path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+	if inSecondary {
+		if err = b.secondary.RemoveFile(path); err != nil {
+			return err
+		}
+		if exists, _ := b.primary.FileExists(path); !exists {
+			return nil
+		}
+	}
+	return b.primary.RemoveFile(path)
+}
+
+func (b *TieredFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.list(path, FileBackend.ListDirectory)
+}
+
+func (b *TieredFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.list(path, FileBackend.ListDirectoryRecursively)
+}
+
+// list merges the listings of both backends.
+func (b *TieredFileBackend) list(path string, listFn func(FileBackend, string) ([]string, error)) ([]string, error) {
+	paths, err := listFn(b.primary, path)
+	if err != nil {
+		return nil, err
+	}
+	secondaryPaths, err := listFn(b.secondary, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list the directory %s in the secondary file backend", path)
+	}
+
+	seen := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		seen[p] = true
+	}
+	for _, p := range secondaryPaths {
+		if !seen[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// RemoveDirectory removes the directory from both backends.
+func (b *TieredFileBackend) RemoveDirectory(path string) error {
+	if err := b.primary.RemoveDirectory(path); err != nil {
+		return err
+	}
+	if err := b.secondary.RemoveDirectory(path); err != nil {
+		return errors.Wrapf(err, "unable to remove the directory %s from the secondary file backend", path)
+	}
+	return nil
+}
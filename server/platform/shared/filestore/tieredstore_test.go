@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTieredFileBackendTestSuite(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	secondaryDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(secondaryDir)
+
+	suite.Run(t, &TieredFileBackendTestSuite{
+		FileBackendTestSuite: FileBackendTestSuite{
+			settings: FileBackendSettings{
+				DriverName: driverLocal,
+				Directory:  dir,
+			},
+		},
+		secondarySettings: FileBackendSettings{
+			DriverName: driverLocal,
+			Directory:  secondaryDir,
+		},
+	})
+}
+
+// TieredFileBackendTestSuite runs the generic backend tests against a tiered
+// backend, along with tests specific to the routing between the backends.
+type TieredFileBackendTestSuite struct {
+	FileBackendTestSuite
+
+	secondarySettings FileBackendSettings
+	primary           FileBackend
+	secondary         FileBackend
+}
+
+func (s *TieredFileBackendTestSuite) SetupTest() {
+	var err error
+	s.primary, err = NewFileBackend(s.settings)
+	require.NoError(s.T(), err)
+	s.secondary, err = NewFileBackend(s.secondarySettings)
+	require.NoError(s.T(), err)
+
+	s.backend = NewTieredFileBackend(s.primary, s.secondary)
+	s.NoError(s.backend.TestConnection())
+}
+
+func (s *TieredFileBackendTestSuite) TestReadWriteFileContext() {
+	// Contexts are passed through to the primary backend, which doesn't
+	// support them for the local driver.
+	s.T().Skip("the local backend doesn't support write contexts")
+}
+
+func (s *TieredFileBackendTestSuite) TestReadFromSecondary() {
+	path := "tests/" + randomString()
+	b := []byte("archived")
+
+	_, err := s.secondary.WriteFile(bytes.NewReader(b), path)
+	s.Require().NoError(err)
+	defer s.secondary.RemoveFile(path)
+
+	exists, err := s.backend.FileExists(path)
+	s.NoError(err)
+	s.True(exists)
+
+	read, err := s.backend.ReadFile(path)
+	s.NoError(err)
+	s.Equal(b, read)
+
+	r, err := s.backend.Reader(path)
+	s.Require().NoError(err)
+	read, err = io.ReadAll(r)
+	r.Close()
+	s.NoError(err)
+	s.Equal(b, read)
+
+	size, err := s.backend.FileSize(path)
+	s.NoError(err)
+	s.EqualValues(len(b), size)
+
+	// The primary backend takes precedence.
+	_, err = s.primary.WriteFile(bytes.NewReader([]byte("recent")), path)
+	s.Require().NoError(err)
+	read, err = s.backend.ReadFile(path)
+	s.NoError(err)
+	s.Equal("recent", string(read))
+
+	s.NoError(s.backend.RemoveFile(path))
+	for _, backend := range []FileBackend{s.primary, s.secondary, s.backend} {
+		exists, err = backend.FileExists(path)
+		s.NoError(err)
+		s.False(exists)
+	}
+}
+
+func (s *TieredFileBackendTestSuite) TestWritesGoToPrimary() {
+	path := "tests/" + randomString()
+
+	_, err := s.backend.WriteFile(bytes.NewReader([]byte("data")), path)
+	s.Require().NoError(err)
+	defer s.backend.RemoveFile(path)
+
+	exists, err := s.primary.FileExists(path)
+	s.NoError(err)
+	s.True(exists)
+
+	exists, err = s.secondary.FileExists(path)
+	s.NoError(err)
+	s.False(exists)
+}
+
+func (s *TieredFileBackendTestSuite) TestListBothBackends() {
+	dir := "tests/" + randomString()
+
+	_, err := s.primary.WriteFile(bytes.NewReader([]byte("a")), dir+"/a")
+	s.Require().NoError(err)
+	_, err = s.secondary.WriteFile(bytes.NewReader([]byte("b")), dir+"/b")
+	s.Require().NoError(err)
+	_, err = s.secondary.WriteFile(bytes.NewReader([]byte("a")), dir+"/a")
+	s.Require().NoError(err)
+	defer s.backend.RemoveDirectory(dir)
+
+	paths, err := s.backend.ListDirectory(dir)
+	s.NoError(err)
+	s.Equal([]string{dir + "/a", dir + "/b"}, paths)
+
+	paths, err = s.backend.ListDirectoryRecursively(dir)
+	s.NoError(err)
+	s.Equal([]string{dir + "/a", dir + "/b"}, paths)
+}
+
+func TestUnwrapTieredFileBackend(t *testing.T) {
+	primary := &LocalFileBackend{directory: t.TempDir()}
+	secondary := &LocalFileBackend{directory: t.TempDir()}
+
+	backend := NewTieredFileBackend(primary, secondary)
+	assert.Equal(t, primary, UnwrapFileBackend(backend))
+	assert.Equal(t, secondary, backend.Secondary())
+}
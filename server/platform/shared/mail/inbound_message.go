@@ -0,0 +1,352 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/jaytaylor/html2text"
+	"github.com/pkg/errors"
+	"golang.org/x/net/html/charset"
+)
+
+// The parts of a message are only looked for this deep, so that a crafted message can't
+// nest them indefinitely.
+const inboundMaxPartDepth = 5
+
+var (
+	replyHeaderRe        = regexp.MustCompile(`(?i)^on\s.+\s(wrote|writes):\s*$`)
+	originalMessageRe    = regexp.MustCompile(`(?i)^-{2,}\s*(original message|forwarded message)\s*-{2,}$`)
+	outlookSeparatorRe   = regexp.MustCompile(`^_{20,}$`)
+	outlookFromHeaderRe  = regexp.MustCompile(`(?i)^\*?from:\*?\s`)
+	outlookSentHeaderRe  = regexp.MustCompile(`(?i)^\*?(sent|date):\*?\s`)
+	mobileSignatureRe    = regexp.MustCompile(`(?i)^(sent from my |get outlook for )`)
+	signatureDelimiterRe = regexp.MustCompile(`^--\s?$`)
+)
+
+// InboundAttachment is a file attached to an InboundMessage.
+type InboundAttachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// InboundMessage is a message received by the server, reduced to what is needed to post
+// it as a reply.
+type InboundMessage struct {
+	// From is the address of the author of the message, from its From header.
+	From string
+	// Authenticated is whether the mail server relaying the message authenticated its
+	// sender, with a DMARC, DKIM or SPF check passing for the domain of From.
+	Authenticated bool
+	Subject       string
+	Text          string
+	Attachments   []*InboundAttachment
+}
+
+// ParseInboundMessage parses a raw message, extracting its text from the plain text part,
+// or the HTML one if there's none, along with its attachments. The quoted text and the
+// signature are left out of the text, so that it only has what was written in reply.
+// The sender is only authenticated from the Authentication-Results headers added by the
+// mail server of the given authserv-id.
+func ParseInboundMessage(data []byte, authservID string) (*InboundMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the message")
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 {
+		return nil, errors.New("the message must have a single sender")
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	message := &InboundMessage{
+		From:    strings.ToLower(from[0].Address),
+		Subject: subject,
+	}
+	if _, domain, ok := strings.Cut(message.From, "@"); ok {
+		message.Authenticated = senderAuthenticated(msg.Header, authservID, domain)
+	}
+
+	var plainText, htmlText string
+	err = walkInboundPart(msg.Header, msg.Body, 0, func(header inboundHeader, body []byte) error {
+		mediaType, params, _ := mime.ParseMediaType(header.Get("Content-Type"))
+		if mediaType == "" {
+			mediaType = "text/plain"
+		}
+
+		disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+		name := dispositionParams["filename"]
+		if name == "" {
+			name = params["name"]
+		}
+		if decoded, err := decoder.DecodeHeader(name); err == nil {
+			name = decoded
+		}
+
+		isText := mediaType == "text/plain" || mediaType == "text/html"
+		if disposition == "attachment" || (name != "" && (disposition == "inline" || !isText)) {
+			message.Attachments = append(message.Attachments, &InboundAttachment{
+				Name:        name,
+				ContentType: mediaType,
+				Data:        body,
+			})
+			return nil
+		}
+
+		if !isText {
+			return nil
+		}
+
+		text, err := decodeCharset(body, params["charset"])
+		if err != nil {
+			return err
+		}
+
+		if mediaType == "text/plain" && plainText == "" {
+			plainText = text
+		} else if mediaType == "text/html" && htmlText == "" {
+			htmlText = text
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	text := plainText
+	if text == "" && htmlText != "" {
+		if text, err = html2text.FromString(htmlText); err != nil {
+			return nil, errors.Wrap(err, "failed to convert the HTML part of the message")
+		}
+	}
+	message.Text = StripReply(text)
+
+	return message, nil
+}
+
+// senderAuthenticated returns whether the Authentication-Results header of a message has
+// a passing DMARC, DKIM or SPF result aligned with the domain of its sender. Since the
+// sender can add any header themselves, only the topmost header of the given authserv-id
+// is looked at, the one added by the trusted mail server, which is expected to remove
+// the headers of its own id found in the messages it receives.
+func senderAuthenticated(header mail.Header, authservID, fromDomain string) bool {
+	if authservID == "" {
+		return false
+	}
+
+	var resultInfos []string
+	for _, results := range header["Authentication-Results"] {
+		// The first field is the id of the mail server which added the results,
+		// optionally followed by a version.
+		infos := strings.Split(stripHeaderComments(results), ";")
+		if id := strings.Fields(infos[0]); len(id) > 0 && strings.EqualFold(id[0], authservID) {
+			resultInfos = infos
+			break
+		}
+	}
+	if resultInfos == nil {
+		return false
+	}
+
+	for _, resultInfo := range resultInfos[1:] {
+		fields := strings.Fields(resultInfo)
+		if len(fields) == 0 {
+			continue
+		}
+
+		method, result, _ := strings.Cut(fields[0], "=")
+		method, _, _ = strings.Cut(method, "/")
+		if !strings.EqualFold(result, "pass") {
+			continue
+		}
+
+		properties := make(map[string]string, len(fields)-1)
+		for _, field := range fields[1:] {
+			if key, value, ok := strings.Cut(field, "="); ok {
+				properties[strings.ToLower(key)] = strings.ToLower(strings.Trim(value, `"`))
+			}
+		}
+
+		var domain string
+		switch strings.ToLower(method) {
+		case "dmarc":
+			domain = properties["header.from"]
+		case "dkim":
+			domain = properties["header.d"]
+		case "spf":
+			domain = properties["smtp.mailfrom"]
+			if _, after, ok := strings.Cut(domain, "@"); ok {
+				domain = after
+			}
+		}
+
+		// A domain is aligned with its subdomains, as for DMARC.
+		if domain != "" && (fromDomain == domain || strings.HasSuffix(fromDomain, "."+domain)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stripHeaderComments removes the comments, in parentheses, from a header value.
+func stripHeaderComments(value string) string {
+	var sb strings.Builder
+	depth := 0
+	for _, r := range value {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// inboundHeader is implemented by the headers of a message and of its parts.
+type inboundHeader interface {
+	Get(key string) string
+}
+
+// walkInboundPart calls fn with the decoded body of each leaf part of the message.
+func walkInboundPart(header inboundHeader, body io.Reader, depth int, fn func(inboundHeader, []byte) error) error {
+	mediaType, params, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= inboundMaxPartDepth {
+			return nil
+		}
+
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return errors.Wrap(err, "failed to read the parts of the message")
+			}
+
+			if err := walkInboundPart(part.Header, part, depth+1, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The multipart reader decodes quoted-printable parts itself, and removes their
+	// Content-Transfer-Encoding header.
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode a part of the message")
+	}
+
+	return fn(header, data)
+}
+
+// base64Cleaner drops the line breaks and spaces from base64 encoded content, which the
+// decoder doesn't accept.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	j := 0
+	for i := 0; i < n; i++ {
+		switch p[i] {
+		case '\r', '\n', ' ', '\t':
+		default:
+			p[j] = p[i]
+			j++
+		}
+	}
+	return j, err
+}
+
+func decodeCharset(body []byte, label string) (string, error) {
+	if label == "" || strings.EqualFold(label, "utf-8") || strings.EqualFold(label, "us-ascii") {
+		return string(body), nil
+	}
+
+	r, err := charset.NewReaderLabel(label, bytes.NewReader(body))
+	if err != nil {
+		// An unknown charset is read as is, rather than losing the message.
+		return string(body), nil
+	}
+
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode the charset of the message")
+	}
+	return string(decoded), nil
+}
+
+// StripReply returns the text written in reply to a message, leaving out the quoted
+// message, whether it is quoted line by line or below a header such as "On ... wrote:",
+// and the signature of the author.
+func StripReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	kept := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if replyHeaderRe.MatchString(trimmed) || originalMessageRe.MatchString(trimmed) ||
+			outlookSeparatorRe.MatchString(trimmed) || signatureDelimiterRe.MatchString(line) {
+			break
+		}
+
+		// Some clients wrap the "On ... wrote:" header over two lines.
+		if i+1 < len(lines) && strings.HasPrefix(strings.ToLower(trimmed), "on ") &&
+			replyHeaderRe.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1])) {
+			break
+		}
+
+		// Outlook quotes the headers of the message being replied to.
+		if outlookFromHeaderRe.MatchString(trimmed) && i+1 < len(lines) && outlookSentHeaderRe.MatchString(strings.TrimSpace(lines[i+1])) {
+			break
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	// Drop the trailing blank lines, and the signatures added by mobile clients.
+	for len(kept) > 0 {
+		last := strings.TrimSpace(kept[len(kept)-1])
+		if last != "" && !mobileSignatureRe.MatchString(last) {
+			break
+		}
+		kept = kept[:len(kept)-1]
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
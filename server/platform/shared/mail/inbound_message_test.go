@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripReply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Text     string
+		Expected string
+	}{
+		"no quoted text": {
+			Text:     "Sounds good.\n\nSee you tomorrow.\n",
+			Expected: "Sounds good.\n\nSee you tomorrow.",
+		},
+		"quoted lines": {
+			Text:     "Sounds good.\n> Shall we meet tomorrow?\n>\n",
+			Expected: "Sounds good.",
+		},
+		"gmail header": {
+			Text:     "Sounds good.\n\nOn Mon, Mar 4, 2024 at 10:00 AM Mattermost <noreply@example.com> wrote:\n> Shall we meet tomorrow?\n",
+			Expected: "Sounds good.",
+		},
+		"wrapped header": {
+			Text:     "Sounds good.\n\nOn Mon, Mar 4, 2024 at 10:00 AM Mattermost\n<noreply@example.com> wrote:\n\nShall we meet tomorrow?\n",
+			Expected: "Sounds good.",
+		},
+		"original message": {
+			Text:     "Sounds good.\r\n\r\n-----Original Message-----\r\nShall we meet tomorrow?\r\n",
+			Expected: "Sounds good.",
+		},
+		"outlook headers": {
+			Text:     "Sounds good.\n\nFrom: Mattermost <noreply@example.com>\nSent: Monday, March 4, 2024 10:00 AM\nSubject: New message\n\nShall we meet tomorrow?\n",
+			Expected: "Sounds good.",
+		},
+		"outlook separator": {
+			Text:     "Sounds good.\n________________________________\nShall we meet tomorrow?\n",
+			Expected: "Sounds good.",
+		},
+		"signature": {
+			Text:     "Sounds good.\n-- \nJohn Doe\n",
+			Expected: "Sounds good.",
+		},
+		"mobile signature": {
+			Text:     "Sounds good.\n\nSent from my iPhone\n",
+			Expected: "Sounds good.",
+		},
+		"from line in the reply": {
+			Text:     "Sounds good.\nFrom: the team\n",
+			Expected: "Sounds good.\nFrom: the team",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, StripReply(tc.Text))
+		})
+	}
+}
+
+func TestParseInboundMessage(t *testing.T) {
+	crlf := func(s string) []byte {
+		return []byte(strings.ReplaceAll(s, "\n", "\r\n"))
+	}
+
+	t.Run("plain text", func(t *testing.T) {
+		message, err := ParseInboundMessage(crlf(`From: John Doe <John.Doe@Example.com>
+To: reply+postid-sig@example.com
+Subject: =?UTF-8?Q?Re:_Caf=C3=A9?=
+Content-Type: text/plain; charset=utf-8
+
+Sounds good.
+
+On Mon, Mar 4, 2024 at 10:00 AM Mattermost <noreply@example.com> wrote:
+> Shall we meet tomorrow?
+`), "mx.example.org")
+		require.NoError(t, err)
+
+		assert.Equal(t, "john.doe@example.com", message.From)
+		assert.False(t, message.Authenticated)
+		assert.Equal(t, "Re: Café", message.Subject)
+		assert.Equal(t, "Sounds good.", message.Text)
+		assert.Empty(t, message.Attachments)
+	})
+
+	t.Run("multipart with attachment", func(t *testing.T) {
+		message, err := ParseInboundMessage(crlf(`From: john.doe@example.com
+Subject: Re: New message
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Caf=E9 at noon.
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Ignored</p>
+--inner--
+--outer
+Content-Type: text/plain; name="notes.txt"
+Content-Disposition: attachment; filename="notes.txt"
+Content-Transfer-Encoding: base64
+
+VGhlIG5vdGVz
+LCB0aGVyZS4=
+--outer--
+`), "mx.example.org")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Café at noon.", message.Text)
+		require.Len(t, message.Attachments, 1)
+		assert.Equal(t, "notes.txt", message.Attachments[0].Name)
+		assert.Equal(t, "text/plain", message.Attachments[0].ContentType)
+		assert.Equal(t, "The notes, there.", string(message.Attachments[0].Data))
+	})
+
+	t.Run("html only", func(t *testing.T) {
+		message, err := ParseInboundMessage(crlf(`From: john.doe@example.com
+Subject: Re: New message
+Content-Type: text/html; charset=utf-8
+
+<html><body><p>Sounds good.</p></body></html>
+`), "mx.example.org")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Sounds good.", message.Text)
+	})
+
+	t.Run("no sender", func(t *testing.T) {
+		_, err := ParseInboundMessage(crlf(`Subject: Re: New message
+
+Sounds good.
+`), "mx.example.org")
+		require.Error(t, err)
+	})
+	t.Run("sender authentication", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			From          string
+			Headers       string
+			Authenticated bool
+		}{
+			"dkim pass":                      {"john.doe@example.com", "Authentication-Results: mx.example.org; dkim=pass (2048-bit key) header.d=example.com header.s=s1\n", true},
+			"dmarc pass":                     {"john.doe@example.com", "Authentication-Results: mx.example.org;\n spf=fail smtp.mailfrom=other.com;\n dmarc=pass header.from=example.com\n", true},
+			"spf pass":                       {"john.doe@example.com", "Authentication-Results: mx.example.org; spf=pass smtp.mailfrom=bounce@example.com\n", true},
+			"subdomain":                      {"john.doe@mail.example.com", "Authentication-Results: mx.example.org; dkim=pass header.d=example.com\n", true},
+			"other domain":                   {"john.doe@example.com", "Authentication-Results: mx.example.org; dkim=pass header.d=attacker.com\n", false},
+			"failed check":                   {"john.doe@example.com", "Authentication-Results: mx.example.org; dkim=fail header.d=example.com\n", false},
+			"no results":                     {"john.doe@example.com", "", false},
+			"only the topmost one is read":   {"john.doe@example.com", "Authentication-Results: mx.example.org; dkim=none\nAuthentication-Results: mx.example.org; dkim=pass header.d=example.com\n", false},
+			"pass in a comment is not taken": {"john.doe@example.com", "Authentication-Results: mx.example.org; dkim=fail (dkim=pass header.d=example.com) header.d=example.com\n", false},
+			"untrusted server":               {"john.doe@example.com", "Authentication-Results: mx.attacker.com; dkim=pass header.d=example.com\n", false},
+			"untrusted server on top":        {"john.doe@example.com", "Authentication-Results: mx.attacker.com; dkim=none\nAuthentication-Results: MX.example.org; dkim=pass header.d=example.com\n", true},
+			"versioned authserv-id":          {"john.doe@example.com", "Authentication-Results: mx.example.org 1; dkim=pass header.d=example.com\n", true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				message, err := ParseInboundMessage(crlf(tc.Headers+"From: "+tc.From+"\nSubject: Re: New message\n\nSounds good.\n"), "mx.example.org")
+				require.NoError(t, err)
+				assert.Equal(t, tc.Authenticated, message.Authenticated)
+			})
+		}
+
+		t.Run("no trusted server", func(t *testing.T) {
+			message, err := ParseInboundMessage(crlf("Authentication-Results: mx.example.org; dkim=pass header.d=example.com\nFrom: john.doe@example.com\n\nSounds good.\n"), "")
+			require.NoError(t, err)
+			assert.False(t, message.Authenticated)
+		})
+	})
+}
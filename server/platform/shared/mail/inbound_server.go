@@ -0,0 +1,302 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	inboundCommandTimeout = 5 * time.Minute
+	inboundMaxRecipients  = 100
+	// The messages are read in memory, so the sessions are capped to bound the memory
+	// used by the server.
+	inboundMaxSessions = 16
+)
+
+// InboundHandler handles a message received by an InboundServer, given the envelope
+// sender and recipients and the raw message. The message is rejected if it returns an
+// error.
+type InboundHandler func(from string, recipients []string, data []byte) error
+
+// InboundServer is a minimal SMTP server receiving the messages sent to the server,
+// such as the replies to notification emails. It doesn't relay messages: only the
+// recipients accepted by acceptRecipient are accepted.
+type InboundServer struct {
+	listener        net.Listener
+	hostname        string
+	maxMessageSize  int64
+	acceptRecipient func(recipient string) bool
+	handler         InboundHandler
+	logger          mlog.LoggerIFace
+
+	mut    sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewInboundServer starts listening for messages on the given address.
+func NewInboundServer(address string, maxMessageSize int64, acceptRecipient func(string) bool, handler InboundHandler, logger mlog.LoggerIFace) (*InboundServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	s := &InboundServer{
+		listener:        listener,
+		hostname:        hostname,
+		maxMessageSize:  maxMessageSize,
+		acceptRecipient: acceptRecipient,
+		handler:         handler,
+		logger:          logger,
+		conns:           make(map[net.Conn]struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *InboundServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Close stops the server, closing the connections in progress.
+func (s *InboundServer) Close() error {
+	s.mut.Lock()
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mut.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *InboundServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mut.Lock()
+			closed := s.closed
+			s.mut.Unlock()
+			if closed {
+				return
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			s.logger.Error("Inbound mail server stopped accepting connections", mlog.Err(err))
+			return
+		}
+
+		s.mut.Lock()
+		if s.closed {
+			s.mut.Unlock()
+			conn.Close()
+			return
+		}
+		if len(s.conns) >= inboundMaxSessions {
+			s.mut.Unlock()
+			conn.SetWriteDeadline(time.Now().Add(time.Second))
+			fmt.Fprintf(conn, "421 4.3.2 %s Too many connections, try again later\r\n", s.hostname)
+			conn.Close()
+			continue
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mut.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mut.Lock()
+				delete(s.conns, conn)
+				s.mut.Unlock()
+				conn.Close()
+			}()
+			s.handleConn(conn)
+		}()
+	}
+}
+
+// inboundSession is the state of an SMTP transaction.
+type inboundSession struct {
+	greeted    bool
+	from       string
+	hasFrom    bool
+	recipients []string
+}
+
+func (session *inboundSession) reset() {
+	session.from = ""
+	session.hasFrom = false
+	session.recipients = nil
+}
+
+func (s *InboundServer) handleConn(conn net.Conn) {
+	tp := textproto.NewConn(conn)
+	logger := s.logger.With(mlog.String("remote_addr", conn.RemoteAddr().String()))
+
+	reply := func(code int, format string, args ...any) bool {
+		conn.SetWriteDeadline(time.Now().Add(inboundCommandTimeout))
+		return tp.PrintfLine("%d %s", code, fmt.Sprintf(format, args...)) == nil
+	}
+
+	if !reply(220, "%s ESMTP ready", s.hostname) {
+		return
+	}
+
+	session := &inboundSession{}
+	for {
+		conn.SetReadDeadline(time.Now().Add(inboundCommandTimeout))
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			session.greeted = true
+			session.reset()
+			reply(250, "%s", s.hostname)
+		case "EHLO":
+			session.greeted = true
+			session.reset()
+			conn.SetWriteDeadline(time.Now().Add(inboundCommandTimeout))
+			tp.PrintfLine("250-%s", s.hostname)
+			tp.PrintfLine("250-SIZE %d", s.maxMessageSize)
+			tp.PrintfLine("250-8BITMIME")
+			reply(250, "PIPELINING")
+		case "MAIL":
+			if !session.greeted {
+				reply(503, "5.5.1 Send HELO or EHLO first")
+				continue
+			}
+			if session.hasFrom {
+				reply(503, "5.5.1 Sender already specified")
+				continue
+			}
+			from, ok := parsePathArgument(arg, "FROM:")
+			if !ok {
+				reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+				continue
+			}
+			session.from = from
+			session.hasFrom = true
+			reply(250, "2.1.0 OK")
+		case "RCPT":
+			if !session.hasFrom {
+				reply(503, "5.5.1 Send MAIL first")
+				continue
+			}
+			recipient, ok := parsePathArgument(arg, "TO:")
+			if !ok || recipient == "" {
+				reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+				continue
+			}
+			if len(session.recipients) >= inboundMaxRecipients {
+				reply(452, "4.5.3 Too many recipients")
+				continue
+			}
+			if !s.acceptRecipient(recipient) {
+				reply(550, "5.1.1 No such recipient")
+				continue
+			}
+			session.recipients = append(session.recipients, recipient)
+			reply(250, "2.1.5 OK")
+		case "DATA":
+			if len(session.recipients) == 0 {
+				reply(503, "5.5.1 Send RCPT first")
+				continue
+			}
+			if !reply(354, "Start mail input; end with <CRLF>.<CRLF>") {
+				return
+			}
+
+			conn.SetReadDeadline(time.Now().Add(inboundCommandTimeout))
+			dotReader := tp.DotReader()
+			data, err := io.ReadAll(io.LimitReader(dotReader, s.maxMessageSize+1))
+			if err != nil {
+				return
+			}
+			if int64(len(data)) > s.maxMessageSize {
+				// Discard the rest of the message before answering.
+				if _, err := io.Copy(io.Discard, dotReader); err != nil {
+					return
+				}
+				session.reset()
+				reply(552, "5.3.4 Message too big")
+				continue
+			}
+
+			if err := s.handler(session.from, session.recipients, data); err != nil {
+				logger.Info("Rejected inbound email", mlog.String("from", session.from), mlog.Err(err))
+				reply(554, "5.6.0 Message rejected")
+			} else {
+				reply(250, "2.0.0 OK")
+			}
+			session.reset()
+		case "RSET":
+			session.reset()
+			reply(250, "2.0.0 OK")
+		case "NOOP":
+			reply(250, "2.0.0 OK")
+		case "VRFY":
+			reply(252, "2.5.0 Cannot verify user")
+		case "QUIT":
+			reply(221, "2.0.0 Bye")
+			return
+		default:
+			reply(502, "5.5.2 Command not implemented")
+		}
+	}
+}
+
+// parsePathArgument parses the argument of the MAIL and RCPT commands, such as
+// "FROM:<user@example.com> SIZE=1024", returning the address.
+func parsePathArgument(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(path, "<") {
+		return "", false
+	}
+	end := strings.Index(path, ">")
+	if end == -1 {
+		return "", false
+	}
+
+	return path[1:end], true
+}
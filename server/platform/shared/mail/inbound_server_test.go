@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestInboundServer(t *testing.T) {
+	var mut sync.Mutex
+	var received []string
+	reject := false
+
+	server, err := NewInboundServer("127.0.0.1:0", 1024, func(recipient string) bool {
+		return strings.HasPrefix(recipient, "reply+")
+	}, func(from string, recipients []string, data []byte) error {
+		mut.Lock()
+		defer mut.Unlock()
+		if reject {
+			return errors.New("rejected")
+		}
+		received = append(received, from+" "+strings.Join(recipients, ",")+" "+string(data))
+		return nil
+	}, mlog.CreateConsoleTestLogger(t))
+	require.NoError(t, err)
+	defer server.Close()
+
+	addr := server.Addr().String()
+	message := []byte("Subject: Re: New message\r\n\r\nSounds good.\r\n")
+
+	t.Run("accepts a message", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "john.doe@example.com", []string{"reply+abc@example.com"}, message)
+		require.NoError(t, err)
+
+		mut.Lock()
+		defer mut.Unlock()
+		require.Len(t, received, 1)
+		assert.Equal(t, "john.doe@example.com reply+abc@example.com Subject: Re: New message\n\nSounds good.\n", received[0])
+	})
+
+	t.Run("rejects unknown recipients", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "john.doe@example.com", []string{"someone@example.com"}, message)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "550")
+	})
+
+	t.Run("rejects messages too big", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "john.doe@example.com", []string{"reply+abc@example.com"}, []byte(strings.Repeat("a", 2048)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "552")
+	})
+
+	t.Run("rejects messages refused by the handler", func(t *testing.T) {
+		mut.Lock()
+		reject = true
+		mut.Unlock()
+
+		err := smtp.SendMail(addr, nil, "john.doe@example.com", []string{"reply+abc@example.com"}, message)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "554")
+	})
+	t.Run("caps the sessions", func(t *testing.T) {
+		conns := make([]net.Conn, 0, inboundMaxSessions)
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+
+		for i := 0; i < inboundMaxSessions; i++ {
+			conn, err := net.Dial("tcp", addr)
+			require.NoError(t, err)
+			conns = append(conns, conn)
+
+			line, err := textproto.NewReader(bufio.NewReader(conn)).ReadLine()
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(line, "220 "), line)
+		}
+
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+		line, err := textproto.NewReader(bufio.NewReader(conn)).ReadLine()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(line, "421 "), line)
+	})
+}
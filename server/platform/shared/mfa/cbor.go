@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Authenticators encode their data with the CTAP2 canonical CBOR encoding, which only
+// has items of definite length, so only those are decoded. Items are only decoded this
+// deep.
+const cborMaxDepth = 16
+
+var errCBORTruncated = errors.New("truncated cbor data")
+
+// cborDecoder decodes the CBOR items of WebAuthn data: unsigned and negative integers
+// are decoded as int64, byte strings as []byte, text strings as string, arrays as []any
+// and maps as map[any]any.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// decodeCBOR decodes the first item of data, returning the number of bytes it takes,
+// since an item may be followed by other data, such as the extensions of an
+// authenticator.
+func decodeCBOR(data []byte) (any, int, error) {
+	d := &cborDecoder{data: data}
+	item, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return item, d.pos, nil
+}
+
+func (d *cborDecoder) readArgument(info byte) (uint64, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, errors.Errorf("unsupported cbor additional information %d", info)
+	}
+
+	if len(d.data)-d.pos < size {
+		return 0, errCBORTruncated
+	}
+
+	buf := d.data[d.pos : d.pos+size]
+	d.pos += size
+
+	switch size {
+	case 1:
+		return uint64(buf[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(buf)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(buf)), nil
+	default:
+		return binary.BigEndian.Uint64(buf), nil
+	}
+}
+
+func (d *cborDecoder) readBytes(length uint64) ([]byte, error) {
+	if length > uint64(len(d.data)-d.pos) {
+		return nil, errCBORTruncated
+	}
+
+	buf := d.data[d.pos : d.pos+int(length)]
+	d.pos += int(length)
+	return buf, nil
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor data nested too deeply")
+	}
+	if d.pos >= len(d.data) {
+		return nil, errCBORTruncated
+	}
+
+	initial := d.data[d.pos]
+	d.pos++
+	major, info := initial>>5, initial&0x1f
+
+	if major == 7 {
+		return d.decodeSimple(info)
+	}
+
+	argument, err := d.readArgument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("cbor integer overflows")
+		}
+		return int64(argument), nil
+	case 1:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("cbor integer overflows")
+		}
+		return -1 - int64(argument), nil
+	case 2:
+		return d.readBytes(argument)
+	case 3:
+		text, err := d.readBytes(argument)
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	case 4:
+		// Each item takes at least a byte, which bounds the length of a valid array.
+		if argument > uint64(len(d.data)-d.pos) {
+			return nil, errCBORTruncated
+		}
+		items := make([]any, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5:
+		if argument > uint64(len(d.data)-d.pos)/2 {
+			return nil, errCBORTruncated
+		}
+		items := make(map[any]any, argument)
+		for i := uint64(0); i < argument; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errors.New("unsupported cbor map key")
+			}
+
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items[key] = value
+		}
+		return items, nil
+	default:
+		// Tags aren't used by authenticators, their content is decoded as is.
+		return d.decode(depth + 1)
+	}
+}
+
+func (d *cborDecoder) decodeSimple(info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	default:
+		// Floating-point numbers aren't used by authenticators either.
+		return nil, errors.Errorf("unsupported cbor simple value %d", info)
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// InvalidWebAuthnResponse indicates the case where the response of an authenticator
+// to a registration or an authentication request failed to be verified.
+var InvalidWebAuthnResponse = errors.New("invalid webauthn response")
+
+const (
+	webAuthnTypeCreate = "webauthn.create"
+	webAuthnTypeGet    = "webauthn.get"
+
+	webAuthnFlagUserPresent            = 0x01
+	webAuthnFlagAttestedCredentialData = 0x40
+
+	// The authenticator data starts with the hash of the relying party id, the flags and
+	// the signature counter.
+	webAuthnAuthDataMinLength = 37
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	webAuthnMinRSAKeySize = 2048
+)
+
+// WebAuthnAlgorithms are the COSE algorithms of the public keys supported for security
+// keys, by order of preference.
+var WebAuthnAlgorithms = []int{coseAlgES256, coseAlgEdDSA, coseAlgRS256}
+
+// WebAuthnRelyingParty is the server security keys are registered with, identified by
+// the hostname of its site URL.
+type WebAuthnRelyingParty struct {
+	ID     string
+	Origin string
+}
+
+// NewWebAuthnRelyingParty returns the relying party of the server with the given site
+// URL.
+func NewWebAuthnRelyingParty(siteURL string) (*WebAuthnRelyingParty, error) {
+	u, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil || u.Scheme == "" || u.Hostname() == "" {
+		return nil, errors.New("the site url must be set to use security keys")
+	}
+
+	return &WebAuthnRelyingParty{
+		ID:     u.Hostname(),
+		Origin: u.Scheme + "://" + u.Host,
+	}, nil
+}
+
+// WebAuthnCredential is a security key registered by a user.
+type WebAuthnCredential struct {
+	ID []byte
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey []byte
+	SignCount uint32
+	AAGUID    []byte
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type webAuthnAuthData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	aaguid    []byte
+	credID    []byte
+	publicKey []byte
+}
+
+func invalidWebAuthnResponse(reason string) error {
+	return errors.Wrap(InvalidWebAuthnResponse, reason)
+}
+
+// WebAuthnChallenge returns the challenge the client data of a response was signed for,
+// to look up the request it is a response to.
+func WebAuthnChallenge(clientDataJSON []byte) ([]byte, error) {
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, invalidWebAuthnResponse("unable to parse the client data")
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(clientData.Challenge, "="))
+	if err != nil {
+		return nil, invalidWebAuthnResponse("unable to decode the challenge")
+	}
+
+	return challenge, nil
+}
+
+func (rp *WebAuthnRelyingParty) verifyClientData(clientDataJSON []byte, expectedType string, challenge []byte) error {
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return invalidWebAuthnResponse("unable to parse the client data")
+	}
+
+	if clientData.Type != expectedType {
+		return invalidWebAuthnResponse("unexpected client data type")
+	}
+
+	actual, err := WebAuthnChallenge(clientDataJSON)
+	if err != nil {
+		return err
+	}
+	if len(challenge) == 0 || !bytes.Equal(actual, challenge) {
+		return invalidWebAuthnResponse("challenge mismatch")
+	}
+
+	if clientData.Origin != rp.Origin {
+		return invalidWebAuthnResponse("origin mismatch")
+	}
+
+	return nil
+}
+
+func (rp *WebAuthnRelyingParty) parseAuthData(data []byte) (*webAuthnAuthData, error) {
+	if len(data) < webAuthnAuthDataMinLength {
+		return nil, invalidWebAuthnResponse("authenticator data too short")
+	}
+
+	authData := &webAuthnAuthData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData.rpIDHash, rpIDHash[:]) {
+		return nil, invalidWebAuthnResponse("relying party id mismatch")
+	}
+
+	if authData.flags&webAuthnFlagUserPresent == 0 {
+		return nil, invalidWebAuthnResponse("user not present")
+	}
+
+	if authData.flags&webAuthnFlagAttestedCredentialData != 0 {
+		rest := data[webAuthnAuthDataMinLength:]
+		if len(rest) < 18 {
+			return nil, invalidWebAuthnResponse("attested credential data too short")
+		}
+
+		authData.aaguid = rest[:16]
+		credIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < credIDLength {
+			return nil, invalidWebAuthnResponse("attested credential data too short")
+		}
+		authData.credID = rest[:credIDLength]
+		rest = rest[credIDLength:]
+
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, invalidWebAuthnResponse("unable to decode the credential public key")
+		}
+		authData.publicKey = rest[:n]
+	}
+
+	return authData, nil
+}
+
+// VerifyWebAuthnRegistration verifies the response of an authenticator to a registration
+// request with the given challenge, returning the credential it created. Attestation
+// statements aren't verified, since none is requested: the make of the security keys
+// users register isn't restricted.
+func (rp *WebAuthnRelyingParty) VerifyWebAuthnRegistration(challenge, clientDataJSON, attestationObject []byte) (*WebAuthnCredential, error) {
+	if err := rp.verifyClientData(clientDataJSON, webAuthnTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, invalidWebAuthnResponse("unable to decode the attestation object")
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, invalidWebAuthnResponse("unexpected attestation object")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, invalidWebAuthnResponse("missing authenticator data")
+	}
+
+	authData, err := rp.parseAuthData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credID == nil {
+		return nil, invalidWebAuthnResponse("missing attested credential data")
+	}
+
+	if _, _, err := parseCOSEKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredential{
+		ID:        bytes.Clone(authData.credID),
+		PublicKey: bytes.Clone(authData.publicKey),
+		SignCount: authData.signCount,
+		AAGUID:    bytes.Clone(authData.aaguid),
+	}, nil
+}
+
+// VerifyWebAuthnAssertion verifies the response of an authenticator to an authentication
+// request with the given challenge, using the credential it was signed with, returning
+// the new signature counter of the credential.
+func (rp *WebAuthnRelyingParty) VerifyWebAuthnAssertion(challenge []byte, credential *WebAuthnCredential, clientDataJSON, authenticatorData, signature []byte) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, webAuthnTypeGet, challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := rp.parseAuthData(authenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	publicKey, alg, err := parseCOSEKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(authenticatorData), clientDataHash[:]...)
+
+	if !verifyCOSESignature(publicKey, alg, signed, signature) {
+		return 0, invalidWebAuthnResponse("invalid signature")
+	}
+
+	// Authenticators that count their signatures always increase the counter, a lower
+	// one means the credential was cloned.
+	if (authData.signCount != 0 || credential.SignCount != 0) && authData.signCount <= credential.SignCount {
+		return 0, invalidWebAuthnResponse("signature counter did not increase")
+	}
+
+	return authData.signCount, nil
+}
+
+func coseInt(key map[any]any, label int64) (int64, bool) {
+	value, ok := key[label].(int64)
+	return value, ok
+}
+
+func coseBytes(key map[any]any, label int64) ([]byte, bool) {
+	value, ok := key[label].([]byte)
+	return value, ok
+}
+
+// parseCOSEKey parses a COSE encoded public key, returning it along with its algorithm.
+func parseCOSEKey(data []byte) (crypto.PublicKey, int64, error) {
+	decoded, _, err := decodeCBOR(data)
+	if err != nil {
+		return nil, 0, invalidWebAuthnResponse("unable to decode the credential public key")
+	}
+	key, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, 0, invalidWebAuthnResponse("unexpected credential public key")
+	}
+
+	keyType, _ := coseInt(key, 1)
+	alg, _ := coseInt(key, 3)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && alg == coseAlgES256:
+		curve, _ := coseInt(key, -1)
+		x, okX := coseBytes(key, -2)
+		y, okY := coseBytes(key, -3)
+		if curve != coseCurveP256 || !okX || !okY || len(x) != 32 || len(y) != 32 {
+			return nil, 0, invalidWebAuthnResponse("invalid ec2 public key")
+		}
+
+		// Parsing the point as an ECDH key checks it is on the curve.
+		point := append(append([]byte{0x04}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, 0, invalidWebAuthnResponse("invalid ec2 public key")
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, alg, nil
+	case keyType == coseKeyTypeOKP && alg == coseAlgEdDSA:
+		curve, _ := coseInt(key, -1)
+		x, ok := coseBytes(key, -2)
+		if curve != coseCurveEd25519 || !ok || len(x) != ed25519.PublicKeySize {
+			return nil, 0, invalidWebAuthnResponse("invalid okp public key")
+		}
+
+		return ed25519.PublicKey(x), alg, nil
+	case keyType == coseKeyTypeRSA && alg == coseAlgRS256:
+		n, okN := coseBytes(key, -1)
+		e, okE := coseBytes(key, -2)
+		if !okN || !okE || len(e) == 0 || len(e) > 4 {
+			return nil, 0, invalidWebAuthnResponse("invalid rsa public key")
+		}
+
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		if publicKey.N.BitLen() < webAuthnMinRSAKeySize || publicKey.E < 3 {
+			return nil, 0, invalidWebAuthnResponse("invalid rsa public key")
+		}
+
+		return publicKey, alg, nil
+	default:
+		return nil, 0, invalidWebAuthnResponse("unsupported public key algorithm")
+	}
+}
+
+func verifyCOSESignature(publicKey crypto.PublicKey, alg int64, signed, signature []byte) bool {
+	switch alg {
+	case coseAlgES256:
+		hash := sha256.Sum256(signed)
+		return ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), hash[:], signature)
+	case coseAlgEdDSA:
+		return ed25519.Verify(publicKey.(ed25519.PublicKey), signed, signature)
+	case coseAlgRS256:
+		hash := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA256, hash[:], signature) == nil
+	default:
+		return false
+	}
+}
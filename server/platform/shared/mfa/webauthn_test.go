@@ -0,0 +1,320 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeCBOR encodes the few types used by authenticators, with the keys of maps sorted
+// as by the canonical encoding.
+func encodeCBOR(value any) []byte {
+	head := func(major byte, argument uint64) []byte {
+		switch {
+		case argument < 24:
+			return []byte{major<<5 | byte(argument)}
+		case argument <= 0xff:
+			return []byte{major<<5 | 24, byte(argument)}
+		case argument <= 0xffff:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(argument))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(argument))
+		}
+	}
+
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[any]any:
+		keys := make([][]byte, 0, len(v))
+		values := map[string][]byte{}
+		for key, value := range v {
+			encodedKey := encodeCBOR(key)
+			keys = append(keys, encodedKey)
+			values[string(encodedKey)] = encodeCBOR(value)
+		}
+		sort.Slice(keys, func(i, j int) bool { return string(keys[i]) < string(keys[j]) })
+
+		data := head(5, uint64(len(v)))
+		for _, key := range keys {
+			data = append(data, key...)
+			data = append(data, values[string(key)]...)
+		}
+		return data
+	default:
+		panic("unsupported type")
+	}
+}
+
+// testAuthenticator is a security key creating a credential with the given key.
+type testAuthenticator struct {
+	rpID      string
+	origin    string
+	credID    []byte
+	key       crypto.Signer
+	signCount uint32
+}
+
+func newTestAuthenticator(t *testing.T, key crypto.Signer) *testAuthenticator {
+	credID := make([]byte, 16)
+	_, err := rand.Read(credID)
+	require.NoError(t, err)
+
+	return &testAuthenticator{
+		rpID:   "mattermost.example.com",
+		origin: "https://mattermost.example.com",
+		credID: credID,
+		key:    key,
+	}
+}
+
+func (a *testAuthenticator) coseKey() []byte {
+	switch key := a.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		return encodeCBOR(map[any]any{1: coseKeyTypeEC2, 3: coseAlgES256, -1: coseCurveP256, -2: key.X.FillBytes(make([]byte, 32)), -3: key.Y.FillBytes(make([]byte, 32))})
+	case ed25519.PublicKey:
+		return encodeCBOR(map[any]any{1: coseKeyTypeOKP, 3: coseAlgEdDSA, -1: coseCurveEd25519, -2: []byte(key)})
+	case *rsa.PublicKey:
+		return encodeCBOR(map[any]any{1: coseKeyTypeRSA, 3: coseAlgRS256, -1: key.N.Bytes(), -2: rsaExponentBytes(key.E)})
+	default:
+		panic("unsupported key")
+	}
+}
+
+func rsaExponentBytes(e int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(e))[1:]
+}
+
+func (a *testAuthenticator) authData(flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credID)))
+		data = append(data, a.credID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func (a *testAuthenticator) clientData(typ string, challenge []byte) []byte {
+	clientData, _ := json.Marshal(map[string]any{
+		"type":      typ,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    a.origin,
+	})
+	return clientData
+}
+
+func (a *testAuthenticator) create(challenge []byte) ([]byte, []byte) {
+	attestationObject := encodeCBOR(map[any]any{
+		"fmt":      "none",
+		"attStmt":  map[any]any{},
+		"authData": a.authData(webAuthnFlagUserPresent|webAuthnFlagAttestedCredentialData, true),
+	})
+	return a.clientData(webAuthnTypeCreate, challenge), attestationObject
+}
+
+func (a *testAuthenticator) get(t *testing.T, challenge []byte) ([]byte, []byte, []byte) {
+	a.signCount++
+	clientData := a.clientData(webAuthnTypeGet, challenge)
+	authData := a.authData(webAuthnFlagUserPresent, false)
+
+	clientDataHash := sha256.Sum256(clientData)
+	signed := append(authData, clientDataHash[:]...)
+
+	var signature []byte
+	var err error
+	if _, ok := a.key.(ed25519.PrivateKey); ok {
+		signature, err = a.key.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		hash := sha256.Sum256(signed)
+		signature, err = a.key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	}
+	require.NoError(t, err)
+
+	return clientData, authData, signature
+}
+
+func TestNewWebAuthnRelyingParty(t *testing.T) {
+	rp, err := NewWebAuthnRelyingParty("https://mattermost.example.com:8443/subpath")
+	require.NoError(t, err)
+	assert.Equal(t, "mattermost.example.com", rp.ID)
+	assert.Equal(t, "https://mattermost.example.com:8443", rp.Origin)
+
+	_, err = NewWebAuthnRelyingParty("")
+	require.Error(t, err)
+}
+
+func TestWebAuthn(t *testing.T) {
+	rp, err := NewWebAuthnRelyingParty("https://mattermost.example.com")
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"es256": ecKey, "eddsa": edKey, "rs256": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			authenticator := newTestAuthenticator(t, key)
+
+			challenge := []byte("registration challenge")
+			clientData, attestationObject := authenticator.create(challenge)
+			credential, err := rp.VerifyWebAuthnRegistration(challenge, clientData, attestationObject)
+			require.NoError(t, err)
+			assert.Equal(t, authenticator.credID, credential.ID)
+			assert.Equal(t, authenticator.coseKey(), credential.PublicKey)
+
+			challenge = []byte("login challenge")
+			clientData, authData, signature := authenticator.get(t, challenge)
+
+			actual, err := WebAuthnChallenge(clientData)
+			require.NoError(t, err)
+			assert.Equal(t, challenge, actual)
+
+			signCount, err := rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), signCount)
+		})
+	}
+}
+
+func TestWebAuthnRegistrationFailures(t *testing.T) {
+	rp, err := NewWebAuthnRelyingParty("https://mattermost.example.com")
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	challenge := []byte("registration challenge")
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		clientData, attestationObject := newTestAuthenticator(t, key).create([]byte("other"))
+		_, err := rp.VerifyWebAuthnRegistration(challenge, clientData, attestationObject)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, key)
+		authenticator.origin = "https://evil.example.com"
+		clientData, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyWebAuthnRegistration(challenge, clientData, attestationObject)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("wrong relying party", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, key)
+		authenticator.rpID = "evil.example.com"
+		clientData, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyWebAuthnRegistration(challenge, clientData, attestationObject)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, key)
+		_, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyWebAuthnRegistration(challenge, authenticator.clientData(webAuthnTypeGet, challenge), attestationObject)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("truncated attestation object", func(t *testing.T) {
+		clientData, attestationObject := newTestAuthenticator(t, key).create(challenge)
+		_, err := rp.VerifyWebAuthnRegistration(challenge, clientData, attestationObject[:len(attestationObject)-10])
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+}
+
+func TestWebAuthnAssertionFailures(t *testing.T) {
+	rp, err := NewWebAuthnRelyingParty("https://mattermost.example.com")
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	authenticator := newTestAuthenticator(t, key)
+
+	clientData, attestationObject := authenticator.create([]byte("registration challenge"))
+	credential, err := rp.VerifyWebAuthnRegistration([]byte("registration challenge"), clientData, attestationObject)
+	require.NoError(t, err)
+
+	challenge := []byte("login challenge")
+
+	t.Run("invalid signature", func(t *testing.T) {
+		clientData, authData, signature := authenticator.get(t, challenge)
+		signature[len(signature)-1] ^= 0xff
+		_, err := rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		other := newTestAuthenticator(t, otherKey)
+		clientData, authData, signature := other.get(t, challenge)
+		_, err = rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("user not present", func(t *testing.T) {
+		clientData, authData, signature := authenticator.get(t, challenge)
+		authData[32] = 0
+		_, err := rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("signature counter did not increase", func(t *testing.T) {
+		clientData, authData, signature := authenticator.get(t, challenge)
+		signCount, err := rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+		require.NoError(t, err)
+		credential.SignCount = signCount
+
+		_, err = rp.VerifyWebAuthnAssertion(challenge, credential, clientData, authData, signature)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	item, n, err := decodeCBOR(append(encodeCBOR(map[any]any{"a": -300, 1: []byte{1, 2}}), 0xff))
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": int64(-300), int64(1): []byte{1, 2}}, item)
+	assert.Equal(t, 10, n)
+
+	for name, data := range map[string][]byte{
+		"empty":               {},
+		"truncated bytes":     {0x43, 0x01},
+		"huge array":          {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"indefinite length":   {0x5f, 0x41, 0x01, 0xff},
+		"floating point":      {0xf9, 0x3c, 0x00},
+		"unsupported map key": {0xa1, 0x41, 0x01, 0x01},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := decodeCBOR(data)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// AuditCheckpointEventName is the event name of the audit log entries of checkpoints.
+const AuditCheckpointEventName = "auditCheckpoint"
+
+// AuditRecord is an audit record as written to the hash chain of a server: each record
+// holds the hash of the record preceding it in the chain, so that a record can't be
+// altered, removed or inserted without breaking the hashes of the records following it.
+type AuditRecord struct {
+	Id        string `json:"id"`
+	ChainId   string `json:"chain_id"`
+	Sequence  int64  `json:"sequence"`
+	CreateAt  int64  `json:"create_at"`
+	Level     string `json:"level"`
+	EventName string `json:"event_name"`
+	Status    string `json:"status"`
+	UserId    string `json:"user_id"`
+	// Data is the JSON encoded audit record.
+	Data     string `json:"data"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// ComputeHash returns the hash of the record, covering all of its fields but its id and
+// its hash.
+func (o *AuditRecord) ComputeHash() string {
+	// Encoding a struct always gives the fields in the same order.
+	content, _ := json.Marshal(struct {
+		ChainId   string `json:"chain_id"`
+		Sequence  int64  `json:"sequence"`
+		CreateAt  int64  `json:"create_at"`
+		Level     string `json:"level"`
+		EventName string `json:"event_name"`
+		Status    string `json:"status"`
+		UserId    string `json:"user_id"`
+		Data      string `json:"data"`
+		PrevHash  string `json:"prev_hash"`
+	}{o.ChainId, o.Sequence, o.CreateAt, o.Level, o.EventName, o.Status, o.UserId, o.Data, o.PrevHash})
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func (o *AuditRecord) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+}
+
+func (o *AuditRecord) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("AuditRecord.IsValid", "model.audit_record.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChainId) {
+		return NewAppError("AuditRecord.IsValid", "model.audit_record.is_valid.chain_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Sequence < 1 {
+		return NewAppError("AuditRecord.IsValid", "model.audit_record.is_valid.sequence.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("AuditRecord.IsValid", "model.audit_record.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Hash) != sha256.Size*2 {
+		return NewAppError("AuditRecord.IsValid", "model.audit_record.is_valid.hash.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AuditRecordFilter selects the audit records to return. Zero values match all records.
+type AuditRecordFilter struct {
+	ChainId   string
+	UserId    string
+	EventName string
+	Since     int64
+	Until     int64
+	Page      int
+	PerPage   int
+}
+
+// AuditCheckpoint is the head of a hash chain at a given sequence, signed by the server.
+// Records missing from the end of a chain are detected by the checkpoints taken after them.
+type AuditCheckpoint struct {
+	Id        string `json:"id"`
+	ChainId   string `json:"chain_id"`
+	Sequence  int64  `json:"sequence"`
+	Hash      string `json:"hash"`
+	CreateAt  int64  `json:"create_at"`
+	Signature string `json:"signature"`
+}
+
+func (o *AuditCheckpoint) signedContent() []byte {
+	content, _ := json.Marshal(struct {
+		ChainId  string `json:"chain_id"`
+		Sequence int64  `json:"sequence"`
+		Hash     string `json:"hash"`
+		CreateAt int64  `json:"create_at"`
+	}{o.ChainId, o.Sequence, o.Hash, o.CreateAt})
+
+	hash := sha256.Sum256(content)
+	return hash[:]
+}
+
+// Sign sets the signature of the checkpoint.
+func (o *AuditCheckpoint) Sign(s crypto.Signer) error {
+	signature, err := s.Sign(rand.Reader, o.signedContent(), crypto.SHA256)
+	if err != nil {
+		return err
+	}
+
+	o.Signature = base64.StdEncoding.EncodeToString(signature)
+	return nil
+}
+
+// VerifySignature returns whether the checkpoint was signed with the private key matching
+// the given public key.
+func (o *AuditCheckpoint) VerifySignature(key *ecdsa.PublicKey) bool {
+	signature, err := base64.StdEncoding.DecodeString(o.Signature)
+	if err != nil {
+		return false
+	}
+
+	return ecdsa.VerifyASN1(key, o.signedContent(), signature)
+}
+
+func (o *AuditCheckpoint) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+}
+
+func (o *AuditCheckpoint) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("AuditCheckpoint.IsValid", "model.audit_checkpoint.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChainId) {
+		return NewAppError("AuditCheckpoint.IsValid", "model.audit_checkpoint.is_valid.chain_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Sequence < 1 {
+		return NewAppError("AuditCheckpoint.IsValid", "model.audit_checkpoint.is_valid.sequence.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Hash) != sha256.Size*2 {
+		return NewAppError("AuditCheckpoint.IsValid", "model.audit_checkpoint.is_valid.hash.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("AuditCheckpoint.IsValid", "model.audit_checkpoint.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AuditSigningKey is the public key audit checkpoints are signed with.
+type AuditSigningKey struct {
+	// PublicKey is the base64 encoded PKIX public key.
+	PublicKey string `json:"public_key"`
+}
+
+func NewAuditSigningKey(key *ecdsa.PublicKey) (*AuditSigningKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditSigningKey{PublicKey: base64.StdEncoding.EncodeToString(der)}, nil
+}
+
+func (o *AuditSigningKey) ECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(o.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected public key type %T", key)
+	}
+	return ecdsaKey, nil
+}
+
+// AuditChainReport is the result of the verification of the records of a hash chain.
+type AuditChainReport struct {
+	ChainId       string `json:"chain_id"`
+	FirstSequence int64  `json:"first_sequence"`
+	LastSequence  int64  `json:"last_sequence"`
+	Records       int    `json:"records"`
+	Checkpoints   int    `json:"checkpoints"`
+	// Complete is true when the records start at the beginning of the chain, rather than
+	// being trusted from the first record given.
+	Complete bool     `json:"complete"`
+	Errors   []string `json:"errors"`
+}
+
+func (r *AuditChainReport) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *AuditChainReport) addError(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// VerifyAuditChains checks the hashes of the records of each chain, that no record is
+// missing between the first and last record given, and that the checkpoints match the
+// records and were signed with the given key. A nil key skips checking the signatures.
+func VerifyAuditChains(records []*AuditRecord, checkpoints []*AuditCheckpoint, key *ecdsa.PublicKey) []*AuditChainReport {
+	recordsByChain := map[string][]*AuditRecord{}
+	for _, record := range records {
+		recordsByChain[record.ChainId] = append(recordsByChain[record.ChainId], record)
+	}
+
+	checkpointsByChain := map[string][]*AuditCheckpoint{}
+	for _, checkpoint := range checkpoints {
+		checkpointsByChain[checkpoint.ChainId] = append(checkpointsByChain[checkpoint.ChainId], checkpoint)
+		if _, ok := recordsByChain[checkpoint.ChainId]; !ok {
+			recordsByChain[checkpoint.ChainId] = nil
+		}
+	}
+
+	chainIds := make([]string, 0, len(recordsByChain))
+	for chainId := range recordsByChain {
+		chainIds = append(chainIds, chainId)
+	}
+	sort.Strings(chainIds)
+
+	reports := make([]*AuditChainReport, 0, len(chainIds))
+	for _, chainId := range chainIds {
+		reports = append(reports, verifyAuditChain(chainId, recordsByChain[chainId], checkpointsByChain[chainId], key))
+	}
+	return reports
+}
+
+func verifyAuditChain(chainId string, records []*AuditRecord, checkpoints []*AuditCheckpoint, key *ecdsa.PublicKey) *AuditChainReport {
+	report := &AuditChainReport{
+		ChainId:     chainId,
+		Records:     len(records),
+		Checkpoints: len(checkpoints),
+		Errors:      []string{},
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Sequence < records[j].Sequence })
+	hashes := make(map[int64]string, len(records))
+
+	for i, record := range records {
+		if record.Hash != record.ComputeHash() {
+			report.addError("record %d: hash mismatch", record.Sequence)
+		}
+
+		if i == 0 {
+			report.FirstSequence = record.Sequence
+			report.Complete = record.Sequence == 1
+			if record.Sequence == 1 && record.PrevHash != "" {
+				report.addError("record 1: unexpected previous hash")
+			}
+		} else {
+			prev := records[i-1]
+			switch {
+			case record.Sequence == prev.Sequence:
+				report.addError("record %d: duplicate sequence", record.Sequence)
+			case record.Sequence != prev.Sequence+1:
+				report.addError("records %d to %d are missing", prev.Sequence+1, record.Sequence-1)
+			case record.PrevHash != prev.Hash:
+				report.addError("record %d: previous hash mismatch", record.Sequence)
+			}
+		}
+
+		report.LastSequence = record.Sequence
+		hashes[record.Sequence] = record.Hash
+	}
+
+	for _, checkpoint := range checkpoints {
+		if key != nil && !checkpoint.VerifySignature(key) {
+			report.addError("checkpoint %d: invalid signature", checkpoint.Sequence)
+		}
+
+		if checkpoint.Sequence > report.LastSequence {
+			report.addError("checkpoint %d: records after %d are missing", checkpoint.Sequence, report.LastSequence)
+			continue
+		}
+
+		if hash, ok := hashes[checkpoint.Sequence]; ok && hash != checkpoint.Hash {
+			report.addError("checkpoint %d: hash mismatch", checkpoint.Sequence)
+		}
+	}
+
+	return report
+}
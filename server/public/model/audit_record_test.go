@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditChain(t *testing.T, n int, key *ecdsa.PrivateKey) ([]*AuditRecord, *AuditCheckpoint) {
+	chainId := NewId()
+	records := make([]*AuditRecord, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		record := &AuditRecord{
+			Id:        NewId(),
+			ChainId:   chainId,
+			Sequence:  int64(i),
+			CreateAt:  GetMillis(),
+			EventName: "test",
+			Status:    "success",
+			UserId:    NewId(),
+			Data:      `{"event_name":"test"}`,
+			PrevHash:  prevHash,
+		}
+		record.Hash = record.ComputeHash()
+		require.Nil(t, record.IsValid())
+		prevHash = record.Hash
+		records = append(records, record)
+	}
+
+	checkpoint := &AuditCheckpoint{
+		Id:       NewId(),
+		ChainId:  chainId,
+		Sequence: int64(n),
+		Hash:     prevHash,
+		CreateAt: GetMillis(),
+	}
+	require.NoError(t, checkpoint.Sign(key))
+	require.Nil(t, checkpoint.IsValid())
+
+	return records, checkpoint
+}
+
+func TestVerifyAuditChains(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	verify := func(records []*AuditRecord, checkpoint *AuditCheckpoint) *AuditChainReport {
+		reports := VerifyAuditChains(records, []*AuditCheckpoint{checkpoint}, &key.PublicKey)
+		require.Len(t, reports, 1)
+		return reports[0]
+	}
+
+	t.Run("valid chain", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		report := verify(records, checkpoint)
+		assert.True(t, report.IsValid(), report.Errors)
+		assert.True(t, report.Complete)
+		assert.Equal(t, 5, report.Records)
+		assert.Equal(t, int64(5), report.LastSequence)
+	})
+
+	t.Run("partial chain", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		report := verify(records[2:], checkpoint)
+		assert.True(t, report.IsValid(), report.Errors)
+		assert.False(t, report.Complete)
+		assert.Equal(t, int64(3), report.FirstSequence)
+	})
+
+	t.Run("altered record", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		records[1].UserId = NewId()
+		report := verify(records, checkpoint)
+		assert.Equal(t, []string{"record 2: hash mismatch"}, report.Errors)
+	})
+
+	t.Run("rehashed record", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		records[1].UserId = NewId()
+		records[1].Hash = records[1].ComputeHash()
+		report := verify(records, checkpoint)
+		assert.Equal(t, []string{"record 3: previous hash mismatch"}, report.Errors)
+	})
+
+	t.Run("removed record", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		records = append(records[:2], records[3:]...)
+		report := verify(records, checkpoint)
+		assert.Equal(t, []string{"records 3 to 3 are missing"}, report.Errors)
+	})
+
+	t.Run("truncated chain", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		report := verify(records[:3], checkpoint)
+		assert.Equal(t, []string{"checkpoint 5: records after 3 are missing"}, report.Errors)
+	})
+
+	t.Run("forged checkpoint", func(t *testing.T) {
+		records, checkpoint := newTestAuditChain(t, 5, key)
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		require.NoError(t, checkpoint.Sign(otherKey))
+		report := verify(records, checkpoint)
+		assert.Equal(t, []string{"checkpoint 5: invalid signature"}, report.Errors)
+	})
+}
+
+func TestAuditSigningKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signingKey, err := NewAuditSigningKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	publicKey, err := signingKey.ECDSAPublicKey()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+}
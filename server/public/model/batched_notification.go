@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BatchedNotification is an email notification of a post waiting to be sent to a user
+// as part of their next digest email.
+type BatchedNotification struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	PostId   string `json:"post_id"`
+	TeamName string `json:"team_name"`
+	CreateAt int64  `json:"create_at"`
+}
+
+func (o *BatchedNotification) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *BatchedNotification) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("BatchedNotification.IsValid", "model.batched_notification.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("BatchedNotification.IsValid", "model.batched_notification.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("BatchedNotification.IsValid", "model.batched_notification.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TeamName) > TeamNameMaxLength {
+		return NewAppError("BatchedNotification.IsValid", "model.batched_notification.is_valid.team_name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("BatchedNotification.IsValid", "model.batched_notification.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// EmailDigestSchedule is when a user receives the digest of their batched email
+// notifications, as set by their email interval and digest time preferences.
+type EmailDigestSchedule struct {
+	// Interval is the time between the first notification of a digest and the digest
+	// being sent. Hourly digests are sent on the hour, and daily ones at Time instead.
+	Interval time.Duration
+	// Time is the time of the day daily digests are sent at, as hours and minutes.
+	Time     string
+	Location *time.Location
+}
+
+// NewEmailDigestSchedule returns the digest schedule for the given preference values,
+// falling back to the default batching interval and digest time if they are invalid.
+func NewEmailDigestSchedule(interval, digestTime string, location *time.Location) *EmailDigestSchedule {
+	seconds, err := strconv.ParseInt(interval, 10, 64)
+	if err != nil || seconds <= 0 {
+		seconds, _ = strconv.ParseInt(PreferenceEmailIntervalBatchingSeconds, 10, 64)
+	}
+
+	if _, err := time.Parse(PreferenceEmailDigestTimeLayout, digestTime); err != nil {
+		digestTime = PreferenceEmailDigestTimeDefault
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &EmailDigestSchedule{
+		Interval: time.Duration(seconds) * time.Second,
+		Time:     digestTime,
+		Location: location,
+	}
+}
+
+// NextDigestTime returns when the digest including a notification queued at the given
+// time is due to be sent.
+func (s *EmailDigestSchedule) NextDigestTime(queuedAt time.Time) time.Time {
+	local := queuedAt.In(s.Location)
+
+	switch s.Interval {
+	case time.Hour:
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, s.Location).Add(time.Hour)
+	case 24 * time.Hour:
+		digestTime, _ := time.Parse(PreferenceEmailDigestTimeLayout, s.Time)
+		next := time.Date(local.Year(), local.Month(), local.Day(), digestTime.Hour(), digestTime.Minute(), 0, 0, s.Location)
+		if !next.After(local) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	default:
+		return queuedAt.Add(s.Interval)
+	}
+}
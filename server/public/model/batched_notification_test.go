@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchedNotificationIsValid(t *testing.T) {
+	o := BatchedNotification{UserId: NewId(), PostId: NewId(), TeamName: "team"}
+	o.PreSave()
+	require.Nil(t, o.IsValid())
+
+	o.PostId = "invalid"
+	require.NotNil(t, o.IsValid())
+
+	o.PostId = NewId()
+	o.UserId = ""
+	require.NotNil(t, o.IsValid())
+}
+
+func TestEmailDigestScheduleNextDigestTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	queuedAt := time.Date(2024, time.March, 1, 14, 20, 0, 0, time.UTC)
+
+	t.Run("fifteen minutes", func(t *testing.T) {
+		schedule := NewEmailDigestSchedule(PreferenceEmailIntervalFifteenAsSeconds, "", nil)
+		assert.Equal(t, queuedAt.Add(15*time.Minute), schedule.NextDigestTime(queuedAt))
+	})
+
+	t.Run("invalid interval falls back to the default", func(t *testing.T) {
+		schedule := NewEmailDigestSchedule("notAnInteger", "", nil)
+		assert.Equal(t, queuedAt.Add(15*time.Minute), schedule.NextDigestTime(queuedAt))
+	})
+
+	t.Run("hourly on the hour", func(t *testing.T) {
+		schedule := NewEmailDigestSchedule(PreferenceEmailIntervalHourAsSeconds, "", nil)
+		assert.True(t, time.Date(2024, time.March, 1, 15, 0, 0, 0, time.UTC).Equal(schedule.NextDigestTime(queuedAt)))
+	})
+
+	t.Run("daily at the digest time in the user's timezone", func(t *testing.T) {
+		// 14:20 UTC is 09:20 in New York, so the 10:00 digest is sent the same day.
+		schedule := NewEmailDigestSchedule(PreferenceEmailIntervalDayAsSeconds, "10:00", newYork)
+		assert.True(t, time.Date(2024, time.March, 1, 10, 0, 0, 0, newYork).Equal(schedule.NextDigestTime(queuedAt)))
+
+		// The 09:00 digest was already sent that day, so the next one is on the next day.
+		schedule = NewEmailDigestSchedule(PreferenceEmailIntervalDayAsSeconds, "09:00", newYork)
+		assert.True(t, time.Date(2024, time.March, 2, 9, 0, 0, 0, newYork).Equal(schedule.NextDigestTime(queuedAt)))
+	})
+
+	t.Run("invalid digest time falls back to the default", func(t *testing.T) {
+		schedule := NewEmailDigestSchedule(PreferenceEmailIntervalDayAsSeconds, "25:99", nil)
+		assert.True(t, time.Date(2024, time.March, 2, 9, 0, 0, 0, time.UTC).Equal(schedule.NextDigestTime(queuedAt)))
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// BleveIndexStats describes the size and health of a Bleve index.
+type BleveIndexStats struct {
+	Name      string `json:"name"`
+	DocCount  uint64 `json:"doc_count"`
+	SizeBytes int64  `json:"size_bytes"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BleveReindexRequest describes a partial reindex of the Bleve indexes. Times are expressed in
+// milliseconds since the unix epoch. When ChannelId is set, only the channel, its posts and its
+// files are reindexed.
+type BleveReindexRequest struct {
+	ChannelId string `json:"channel_id"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+}
+
+func (r *BleveReindexRequest) IsValid() *AppError {
+	if r.ChannelId != "" && !IsValidId(r.ChannelId) {
+		return NewAppError("BleveReindexRequest.IsValid", "model.bleve_reindex_request.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.StartTime < 0 || r.EndTime < 0 || (r.EndTime > 0 && r.StartTime > r.EndTime) {
+		return NewAppError("BleveReindexRequest.IsValid", "model.bleve_reindex_request.is_valid.time_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBleveReindexRequestIsValid(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Request BleveReindexRequest
+		Valid   bool
+	}{
+		{"Empty", BleveReindexRequest{}, true},
+		{"Channel", BleveReindexRequest{ChannelId: NewId()}, true},
+		{"Invalid channel", BleveReindexRequest{ChannelId: "invalid"}, false},
+		{"Time range", BleveReindexRequest{StartTime: 1000, EndTime: 2000}, true},
+		{"Start time only", BleveReindexRequest{StartTime: 1000}, true},
+		{"Negative start time", BleveReindexRequest{StartTime: -1}, false},
+		{"Inverted time range", BleveReindexRequest{StartTime: 2000, EndTime: 1000}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			appErr := tc.Request.IsValid()
+			if tc.Valid {
+				assert.Nil(t, appErr)
+			} else {
+				assert.NotNil(t, appErr)
+			}
+		})
+	}
+}
@@ -13,4 +13,11 @@ type BulkExportOpts struct {
 	IncludeArchivedChannels bool
 	IncludeRolesAndSchemes  bool
 	CreateArchive           bool
+	// Since, when set, only exports the entities created, updated or deleted
+	// since the given time in milliseconds, with tombstone lines for the deletions.
+	// Tombstones cover teams, posts, channel bookmarks, left team and channel
+	// memberships, webhooks, commands and bots, but not removed reactions or group
+	// members. A channel membership whose roles or notify props changed and that
+	// was then viewed is also missed, as viewing the channel resets its LastUpdateAt.
+	Since int64
 }
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// BulkImportReport describes what a bulk import would change on the server. It's computed
+// by a dry run of the import_process job, which resolves every line against the existing
+// data without writing anything.
+type BulkImportReport struct {
+	Lines int `json:"lines"`
+	// Entities counts the changes by entity type, such as "team", "user" or "reply".
+	Entities          map[string]*BulkImportEntityReport `json:"entities"`
+	Conflicts         []*BulkImportIssue                 `json:"conflicts"`
+	MissingReferences []*BulkImportIssue                 `json:"missing_references"`
+	Errors            []*BulkImportIssue                 `json:"errors"`
+}
+
+// BulkImportEntityReport counts the entities of a type an import would create, update,
+// leave untouched or delete.
+type BulkImportEntityReport struct {
+	Creates int `json:"creates"`
+	Updates int `json:"updates"`
+	NoOps   int `json:"no_ops"`
+	Deletes int `json:"deletes"`
+}
+
+// BulkImportIssue is a problem found on a line of an import file.
+type BulkImportIssue struct {
+	Line    int    `json:"line"`
+	Type    string `json:"type"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+func NewBulkImportReport() *BulkImportReport {
+	return &BulkImportReport{
+		Entities:          make(map[string]*BulkImportEntityReport),
+		Conflicts:         []*BulkImportIssue{},
+		MissingReferences: []*BulkImportIssue{},
+		Errors:            []*BulkImportIssue{},
+	}
+}
+
+// Entity returns the counts of an entity type, creating them if needed.
+func (r *BulkImportReport) Entity(entityType string) *BulkImportEntityReport {
+	entity, ok := r.Entities[entityType]
+	if !ok {
+		entity = &BulkImportEntityReport{}
+		r.Entities[entityType] = entity
+	}
+	return entity
+}
@@ -1495,6 +1495,172 @@ func (c *Client4) GenerateMfaSecret(ctx context.Context, userId string) (*MfaSec
 	return &secret, BuildResponse(r), nil
 }
 
+// GenerateMfaRecoveryCodes replaces the MFA recovery codes of a user with new ones and
+// returns them.
+func (c *Client4) GenerateMfaRecoveryCodes(ctx context.Context, userId string) ([]string, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/mfa/recovery_codes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var result struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, nil, NewAppError("GenerateMfaRecoveryCodes", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return result.RecoveryCodes, BuildResponse(r), nil
+}
+
+// GetMfaRecoveryCodesStatus returns how many unused MFA recovery codes a user has left.
+func (c *Client4) GetMfaRecoveryCodesStatus(ctx context.Context, userId string) (*MfaRecoveryCodesStatus, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/mfa/recovery_codes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var status MfaRecoveryCodesStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, nil, NewAppError("GetMfaRecoveryCodesStatus", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &status, BuildResponse(r), nil
+}
+
+// RequestMfaReset emails the user with the given email a link to confirm they want MFA
+// reset on their account.
+func (c *Client4) RequestMfaReset(ctx context.Context, email string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.usersRoute()+"/mfa/reset/request", MapToJSON(map[string]string{"email": email}))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ConfirmMfaResetRequest submits the MFA reset request of the user the token was emailed
+// to, for an admin to approve or deny.
+func (c *Client4) ConfirmMfaResetRequest(ctx context.Context, token string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.usersRoute()+"/mfa/reset/confirm", MapToJSON(map[string]string{"token": token}))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetMfaResetRequests returns a page of the MFA reset requests with the given status, or
+// of all of them if it's empty.
+func (c *Client4) GetMfaResetRequests(ctx context.Context, status string, page, perPage int) ([]*MfaResetRequest, *Response, error) {
+	query := fmt.Sprintf("?status=%v&page=%v&per_page=%v", url.QueryEscape(status), page, perPage)
+	r, err := c.DoAPIGet(ctx, c.usersRoute()+"/mfa/reset/requests"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var requests []*MfaResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+		return nil, nil, NewAppError("GetMfaResetRequests", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return requests, BuildResponse(r), nil
+}
+
+// ApproveMfaResetRequest approves an MFA reset request, removing every second factor of
+// the user who made it.
+func (c *Client4) ApproveMfaResetRequest(ctx context.Context, requestId string) (*MfaResetRequest, *Response, error) {
+	return c.resolveMfaResetRequest(ctx, requestId, "approve")
+}
+
+// DenyMfaResetRequest denies an MFA reset request.
+func (c *Client4) DenyMfaResetRequest(ctx context.Context, requestId string) (*MfaResetRequest, *Response, error) {
+	return c.resolveMfaResetRequest(ctx, requestId, "deny")
+}
+
+func (c *Client4) resolveMfaResetRequest(ctx context.Context, requestId, action string) (*MfaResetRequest, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.usersRoute()+"/mfa/reset/requests/"+requestId+"/"+action, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var request MfaResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, nil, NewAppError("resolveMfaResetRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &request, BuildResponse(r), nil
+}
+
+// BeginWebAuthnRegistration returns the options for a user to register a new security
+// key with, to pass to navigator.credentials.create.
+func (c *Client4) BeginWebAuthnRegistration(ctx context.Context, userId string) (*WebAuthnCreationOptions, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/webauthn/register/begin", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnCreationOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnRegistration", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
+// FinishWebAuthnRegistration registers the security key that created the given credential.
+func (c *Client4) FinishWebAuthnRegistration(ctx context.Context, userId string, registration *WebAuthnRegistration) (*WebAuthnCredential, *Response, error) {
+	buf, err := json.Marshal(registration)
+	if err != nil {
+		return nil, nil, NewAppError("FinishWebAuthnRegistration", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.userRoute(userId)+"/webauthn/register/finish", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credential WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&credential); err != nil {
+		return nil, nil, NewAppError("FinishWebAuthnRegistration", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &credential, BuildResponse(r), nil
+}
+
+// GetWebAuthnCredentials returns the security keys registered by a user.
+func (c *Client4) GetWebAuthnCredentials(ctx context.Context, userId string) ([]*WebAuthnCredential, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/webauthn/credentials", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credentials []*WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		return nil, nil, NewAppError("GetWebAuthnCredentials", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return credentials, BuildResponse(r), nil
+}
+
+// DeleteWebAuthnCredential removes one of the security keys of a user.
+func (c *Client4) DeleteWebAuthnCredential(ctx context.Context, userId, credentialId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+"/webauthn/credentials/"+credentialId)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// BeginWebAuthnLogin returns the options for a user to sign in with one of their security
+// keys, to pass to navigator.credentials.get. The resulting assertion, encoded as JSON, is
+// then passed as the MFA token when logging in.
+func (c *Client4) BeginWebAuthnLogin(ctx context.Context, loginId string) (*WebAuthnRequestOptions, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.usersRoute()+"/webauthn/login/begin", MapToJSON(map[string]string{"login_id": loginId}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnRequestOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnLogin", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(ctx context.Context, userId, currentPassword, newPassword string) (*Response, error) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -2018,6 +2184,64 @@ func (c *Client4) GetUsersForReporting(ctx context.Context, options *UserReportO
 	return list, BuildResponse(r), nil
 }
 
+// StartUsersBatchExport starts a job generating a report of the users in the given
+// format, sent to the requesting user once ready.
+func (c *Client4) StartUsersBatchExport(ctx context.Context, format string, dateRange string) (*Response, error) {
+	values := url.Values{}
+	if format != "" {
+		values.Set("format", format)
+	}
+	if dateRange != "" {
+		values.Set("date_range", dateRange)
+	}
+
+	r, err := c.DoAPIPost(ctx, c.reportsRoute()+"/users/export?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// StartChannelMembershipReport starts a job generating a report of the members of the
+// channels of a team, or of all teams if teamId is empty, sent to the requesting user
+// once ready.
+func (c *Client4) StartChannelMembershipReport(ctx context.Context, format string, teamId string) (*Response, error) {
+	values := url.Values{}
+	if format != "" {
+		values.Set("format", format)
+	}
+	if teamId != "" {
+		values.Set("team_id", teamId)
+	}
+
+	r, err := c.DoAPIPost(ctx, c.reportsRoute()+"/channel_membership/export?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// StartTeamActivityReport starts a job generating a report of the activity of the teams
+// over the date range, sent to the requesting user once ready.
+func (c *Client4) StartTeamActivityReport(ctx context.Context, format string, dateRange string) (*Response, error) {
+	values := url.Values{}
+	if format != "" {
+		values.Set("format", format)
+	}
+	if dateRange != "" {
+		values.Set("date_range", dateRange)
+	}
+
+	r, err := c.DoAPIPost(ctx, c.reportsRoute()+"/team_activity/export?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Bots section
 
 // CreateBot creates a bot in the system based on the provided bot struct.
@@ -5135,6 +5359,37 @@ func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Re
 	return BuildResponse(r), nil
 }
 
+// GetOutgoingWebhookDeliveries returns a page of the deliveries of an outgoing webhook,
+// most recent first. Page counting starts at 0.
+func (c *Client4) GetOutgoingWebhookDeliveries(ctx context.Context, hookId string, page int, perPage int) ([]*OutgoingWebhookDelivery, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var deliveries []*OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, nil, NewAppError("GetOutgoingWebhookDeliveries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return deliveries, BuildResponse(r), nil
+}
+
+// ReplayOutgoingWebhookDelivery sends the payload of a delivery of an outgoing webhook
+// again, and returns the new delivery.
+func (c *Client4) ReplayOutgoingWebhookDelivery(ctx context.Context, hookId, deliveryId string) (*OutgoingWebhookDelivery, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries/"+deliveryId+"/replay", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var delivery OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		return nil, nil, NewAppError("ReplayOutgoingWebhookDelivery", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &delivery, BuildResponse(r), nil
+}
+
 // Preferences Section
 
 // GetPreferences returns the user's preferences.
@@ -5777,6 +6032,73 @@ func (c *Client4) GetAudits(ctx context.Context, page int, perPage int, etag str
 	return audits, BuildResponse(r), nil
 }
 
+// GetAuditRecords returns the hash chained audit records written to the database matching
+// the filter.
+func (c *Client4) GetAuditRecords(ctx context.Context, filter *AuditRecordFilter) ([]*AuditRecord, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(filter.Page))
+	values.Set("per_page", strconv.Itoa(filter.PerPage))
+	if filter.ChainId != "" {
+		values.Set("chain_id", filter.ChainId)
+	}
+	if filter.UserId != "" {
+		values.Set("user_id", filter.UserId)
+	}
+	if filter.EventName != "" {
+		values.Set("event_name", filter.EventName)
+	}
+	if filter.Since > 0 {
+		values.Set("since", strconv.FormatInt(filter.Since, 10))
+	}
+	if filter.Until > 0 {
+		values.Set("until", strconv.FormatInt(filter.Until, 10))
+	}
+
+	r, err := c.DoAPIGet(ctx, "/audits/records?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var records []*AuditRecord
+	if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetAuditRecords", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return records, BuildResponse(r), nil
+}
+
+// GetAuditCheckpoints returns the signed checkpoints of the audit records written to the
+// database, of a chain or of all of them if chainId is empty.
+func (c *Client4) GetAuditCheckpoints(ctx context.Context, chainId string, page, perPage int) ([]*AuditCheckpoint, *Response, error) {
+	query := fmt.Sprintf("?chain_id=%v&page=%v&per_page=%v", url.QueryEscape(chainId), page, perPage)
+	r, err := c.DoAPIGet(ctx, "/audits/checkpoints"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var checkpoints []*AuditCheckpoint
+	if err := json.NewDecoder(r.Body).Decode(&checkpoints); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetAuditCheckpoints", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return checkpoints, BuildResponse(r), nil
+}
+
+// GetAuditSigningKey returns the public key audit checkpoints are signed with.
+func (c *Client4) GetAuditSigningKey(ctx context.Context) (*AuditSigningKey, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/audits/signing_key", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var signingKey AuditSigningKey
+	if err := json.NewDecoder(r.Body).Decode(&signingKey); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetAuditSigningKey", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &signingKey, BuildResponse(r), nil
+}
+
 // Brand Section
 
 // GetBrandImage retrieves the previously uploaded brand image.
@@ -6164,6 +6486,67 @@ func (c *Client4) PurgeBleveIndexes(ctx context.Context) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// PurgeBleveIndexList immediately deletes the given Bleve indexes.
+func (c *Client4) PurgeBleveIndexList(ctx context.Context, indexes []string) (*Response, error) {
+	values := url.Values{}
+	for _, index := range indexes {
+		values.Add("index", index)
+	}
+	r, err := c.DoAPIPost(ctx, c.bleveRoute()+"/purge_indexes?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetBleveIndexStats returns the document count, size and health of every Bleve index.
+func (c *Client4) GetBleveIndexStats(ctx context.Context) ([]*BleveIndexStats, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.bleveRoute()+"/indexes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var stats []*BleveIndexStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		return nil, nil, NewAppError("GetBleveIndexStats", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return stats, BuildResponse(r), nil
+}
+
+// VerifyBleveIndex checks the health of a Bleve index, returning its stats.
+func (c *Client4) VerifyBleveIndex(ctx context.Context, indexName string) (*BleveIndexStats, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.bleveRoute()+"/indexes/"+url.PathEscape(indexName), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var stats BleveIndexStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		return nil, nil, NewAppError("VerifyBleveIndex", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &stats, BuildResponse(r), nil
+}
+
+// CreateBleveReindexJob creates a job reindexing a time range and, optionally, a single channel
+// in the Bleve indexes.
+func (c *Client4) CreateBleveReindexJob(ctx context.Context, req *BleveReindexRequest) (*Job, *Response, error) {
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, NewAppError("CreateBleveReindexJob", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.bleveRoute()+"/reindex", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, nil, NewAppError("CreateBleveReindexJob", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &job, BuildResponse(r), nil
+}
+
 // Data Retention Section
 
 // GetDataRetentionPolicy will get the current global data retention policy details.
@@ -6483,6 +6866,79 @@ func (c *Client4) DeleteDraft(ctx context.Context, userId, channelId, rootId str
 	return df, BuildResponse(r), nil
 }
 
+// Scheduled Posts Section
+
+// CreateScheduledPost schedules a post to be sent by the server at its ScheduledAt time.
+func (c *Client4) CreateScheduledPost(ctx context.Context, scheduledPost *ScheduledPost) (*ScheduledPost, *Response, error) {
+	buf, err := json.Marshal(scheduledPost)
+	if err != nil {
+		return nil, nil, NewAppError("CreateScheduledPost", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postsRoute()+"/schedule", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("CreateScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
+// GetUserScheduledPosts returns the scheduled posts of the current user in the channels of
+// the team and in their direct and group messages.
+func (c *Client4) GetUserScheduledPosts(ctx context.Context, teamId string) ([]*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postsRoute()+"/scheduled/team/"+teamId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var scheduledPosts []*ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPosts); err != nil {
+		return nil, nil, NewAppError("GetUserScheduledPosts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return scheduledPosts, BuildResponse(r), nil
+}
+
+// UpdateScheduledPost edits the message, files and schedule of a scheduled post.
+func (c *Client4) UpdateScheduledPost(ctx context.Context, scheduledPost *ScheduledPost) (*ScheduledPost, *Response, error) {
+	buf, err := json.Marshal(scheduledPost)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateScheduledPost", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, c.postsRoute()+"/schedule/"+scheduledPost.Id, buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("UpdateScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
+// DeleteScheduledPost cancels a scheduled post.
+func (c *Client4) DeleteScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.postsRoute()+"/schedule/"+scheduledPostId)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("DeleteScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
 // Commands Section
 
 // CreateCommand will create a new command if the user have the right permissions.
@@ -7045,6 +7501,57 @@ func (c *Client4) GetJobsByType(ctx context.Context, jobType string, page int, p
 	return list, BuildResponse(r), nil
 }
 
+// GetJobLineage gets the jobs of the lineage of a job, from the first one to the newest,
+// such as its retries and the jobs following up on it.
+func (c *Client4) GetJobLineage(ctx context.Context, jobId string) ([]*Job, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.jobsRoute()+fmt.Sprintf("/%v/lineage", jobId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*Job
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetJobLineage", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// GetJobSchedules gets the schedule of each job type the user is allowed to read,
+// including the time of its next run.
+func (c *Client4) GetJobSchedules(ctx context.Context) ([]*JobSchedule, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.jobsRoute()+"/schedules", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*JobSchedule
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetJobSchedules", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// PauseJobType pauses a job type on the whole cluster, so that no job of that type is
+// scheduled or run until it is resumed.
+func (c *Client4) PauseJobType(ctx context.Context, jobType string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.jobsRoute()+fmt.Sprintf("/type/%v/pause", jobType), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ResumeJobType resumes a paused job type.
+func (c *Client4) ResumeJobType(ctx context.Context, jobType string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.jobsRoute()+fmt.Sprintf("/type/%v/resume", jobType), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // CreateJob creates a job based on the provided job struct.
 func (c *Client4) CreateJob(ctx context.Context, job *Job) (*Job, *Response, error) {
 	buf, err := json.Marshal(job)
@@ -161,6 +161,105 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestConfigFileSettingsEncryptionAtRest(t *testing.T) {
+	validKey := "AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA="
+
+	for name, test := range map[string]struct {
+		Enable      bool
+		Key         string
+		RetiredKeys []string
+		ExpectError bool
+	}{
+		"disabled":             {Enable: false, Key: ""},
+		"enabled without key":  {Enable: true, Key: "", ExpectError: true},
+		"enabled with key":     {Enable: true, Key: validKey},
+		"key too short":        {Enable: true, Key: "AQIDBAUGBwg=", ExpectError: true},
+		"key not base64":       {Enable: true, Key: "not a key", ExpectError: true},
+		"valid retired keys":   {Enable: true, Key: validKey, RetiredKeys: []string{validKey}},
+		"invalid retired keys": {Enable: true, Key: validKey, RetiredKeys: []string{"not a key"}, ExpectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+
+			*c.FileSettings.EnableEncryptionAtRest = test.Enable
+			*c.FileSettings.EncryptionAtRestKey = test.Key
+			c.FileSettings.EncryptionAtRestRetiredKeys = test.RetiredKeys
+
+			appErr := c.FileSettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestConfigFileSettingsArchiveStore(t *testing.T) {
+	for name, test := range map[string]struct {
+		DriverName  string
+		Directory   string
+		AfterDays   int
+		ExpectError bool
+	}{
+		"s3":                     {DriverName: ImageDriverS3, AfterDays: 30},
+		"local":                  {DriverName: ImageDriverLocal, Directory: "./archive/", AfterDays: 30},
+		"local without dir":      {DriverName: ImageDriverLocal, AfterDays: 30, ExpectError: true},
+		"local same dir":         {DriverName: ImageDriverLocal, Directory: "./data", AfterDays: 30, ExpectError: true},
+		"unsupported driver":     {DriverName: ImageDriverAzure, AfterDays: 30, ExpectError: true},
+		"invalid number of days": {DriverName: ImageDriverS3, AfterDays: 0, ExpectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+
+			*c.FileSettings.EnableArchiveStore = true
+			*c.FileSettings.ArchiveDriverName = test.DriverName
+			*c.FileSettings.ArchiveDirectory = test.Directory
+			*c.FileSettings.ArchiveAfterDays = test.AfterDays
+
+			appErr := c.FileSettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestConfigCacheSettings(t *testing.T) {
+	for name, test := range map[string]struct {
+		CacheType    string
+		RedisAddress string
+		RedisDB      int
+		ExpectError  bool
+	}{
+		"lru":                {CacheType: CacheTypeLRU},
+		"redis":              {CacheType: CacheTypeRedis, RedisAddress: "localhost:6379"},
+		"redis without addr": {CacheType: CacheTypeRedis, ExpectError: true},
+		"invalid redis db":   {CacheType: CacheTypeRedis, RedisAddress: "localhost:6379", RedisDB: -1, ExpectError: true},
+		"unsupported type":   {CacheType: "memcached", ExpectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+
+			*c.CacheSettings.CacheType = test.CacheType
+			*c.CacheSettings.RedisAddress = test.RedisAddress
+			*c.CacheSettings.RedisDB = test.RedisDB
+
+			appErr := c.CacheSettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
 func TestConfigDefaultSignatureAlgorithm(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -905,6 +1004,7 @@ func TestImageProxySettingsIsValid(t *testing.T) {
 				RemoteImageProxyURL:     &test.RemoteImageProxyURL,
 				RemoteImageProxyOptions: &test.RemoteImageProxyOptions,
 			}
+			ips.SetDefaults()
 
 			appErr := ips.isValid()
 			if test.ExpectError {
@@ -1382,7 +1482,10 @@ func TestConfigSanitize(t *testing.T) {
 
 	*c.LdapSettings.BindPassword = "foo"
 	*c.FileSettings.AmazonS3SecretAccessKey = "bar"
+	*c.FileSettings.EncryptionAtRestKey = "qux"
+	c.FileSettings.EncryptionAtRestRetiredKeys = []string{"quux"}
 	*c.EmailSettings.SMTPPassword = "baz"
+	*c.CacheSettings.RedisPassword = "corge"
 	*c.GitLabSettings.Secret = "bingo"
 	*c.OpenIdSettings.Secret = "secret"
 	c.SqlSettings.DataSourceReplicas = []string{"stuff"}
@@ -1398,7 +1501,10 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FakeSetting, *c.LdapSettings.BindPassword)
 	assert.Equal(t, FakeSetting, *c.FileSettings.PublicLinkSalt)
 	assert.Equal(t, FakeSetting, *c.FileSettings.AmazonS3SecretAccessKey)
+	assert.Equal(t, FakeSetting, *c.FileSettings.EncryptionAtRestKey)
+	assert.Equal(t, FakeSetting, c.FileSettings.EncryptionAtRestRetiredKeys[0])
 	assert.Equal(t, FakeSetting, *c.EmailSettings.SMTPPassword)
+	assert.Equal(t, FakeSetting, *c.CacheSettings.RedisPassword)
 	assert.Equal(t, FakeSetting, *c.GitLabSettings.Secret)
 	assert.Equal(t, FakeSetting, *c.OpenIdSettings.Secret)
 	assert.Equal(t, FakeSetting, *c.SqlSettings.DataSource)
@@ -1767,3 +1873,125 @@ func TestConfigGetFileRetentionHours(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitPolicyMatches(t *testing.T) {
+	policy := &RateLimitPolicy{
+		Routes:  []string{"/api/v4/users/login", "/api/v4/oauth/"},
+		Methods: []string{"post"},
+	}
+
+	assert.True(t, policy.Matches("POST", "/api/v4/users/login"))
+	assert.True(t, policy.Matches("POST", "/api/v4/users/login/switch"))
+	assert.True(t, policy.Matches("POST", "/api/v4/oauth/apps"))
+	assert.False(t, policy.Matches("POST", "/api/v4/users/loginx"))
+	assert.False(t, policy.Matches("GET", "/api/v4/users/login"))
+	assert.False(t, policy.Matches("POST", "/api/v4/posts"))
+
+	policy.Methods = nil
+	assert.True(t, policy.Matches("GET", "/api/v4/users/login"))
+}
+
+func TestConfigRateLimitSettings(t *testing.T) {
+	validPolicy := func() *RateLimitPolicy {
+		return &RateLimitPolicy{
+			Name:     NewString("login"),
+			Routes:   []string{"/api/v4/users/login"},
+			PerMin:   NewInt(10),
+			MaxBurst: NewInt(5),
+		}
+	}
+
+	for name, test := range map[string]struct {
+		Modify      func(c *Config)
+		ExpectError bool
+	}{
+		"defaults": {Modify: func(c *Config) {}},
+		"valid policy": {Modify: func(c *Config) {
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{validPolicy()}
+		}},
+		"redis store": {Modify: func(c *Config) {
+			*c.RateLimitSettings.StoreType = RateLimitStoreTypeRedis
+			*c.CacheSettings.RedisAddress = "localhost:6379"
+		}},
+		"redis store without address": {Modify: func(c *Config) {
+			*c.RateLimitSettings.StoreType = RateLimitStoreTypeRedis
+		}, ExpectError: true},
+		"invalid store type": {Modify: func(c *Config) {
+			*c.RateLimitSettings.StoreType = "memcached"
+		}, ExpectError: true},
+		"duplicate policy name": {Modify: func(c *Config) {
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{validPolicy(), validPolicy()}
+		}, ExpectError: true},
+		"policy without routes": {Modify: func(c *Config) {
+			p := validPolicy()
+			p.Routes = nil
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{p}
+		}, ExpectError: true},
+		"policy with relative route": {Modify: func(c *Config) {
+			p := validPolicy()
+			p.Routes = []string{"api/v4/users/login"}
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{p}
+		}, ExpectError: true},
+		"policy without rate": {Modify: func(c *Config) {
+			p := validPolicy()
+			p.PerMin = nil
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{p}
+		}, ExpectError: true},
+		"policy without max burst": {Modify: func(c *Config) {
+			p := validPolicy()
+			p.MaxBurst = nil
+			c.RateLimitSettings.Policies = []*RateLimitPolicy{p}
+		}, ExpectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			test.Modify(&c)
+
+			appErr := c.IsValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestJobSettingsIsValidCronSchedules(t *testing.T) {
+	for name, test := range map[string]struct {
+		CronSchedules map[string]string
+		ExpectError   bool
+	}{
+		"no schedules": {
+			CronSchedules: map[string]string{},
+		},
+		"standard expression and descriptor": {
+			CronSchedules: map[string]string{
+				JobTypeDataRetention: "30 2 * * *",
+				JobTypeLdapSync:      "@daily",
+			},
+		},
+		"invalid expression": {
+			CronSchedules: map[string]string{JobTypeDataRetention: "30 2 * *"},
+			ExpectError:   true,
+		},
+		"out of range field": {
+			CronSchedules: map[string]string{JobTypeDataRetention: "61 * * * *"},
+			ExpectError:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			js := &JobSettings{CronSchedules: test.CronSchedules}
+			js.SetDefaults()
+
+			appErr := js.isValid()
+			if test.ExpectError {
+				require.NotNil(t, appErr)
+				assert.Equal(t, "model.config.is_valid.job_cron_schedule.app_error", appErr.Id)
+			} else {
+				require.Nil(t, appErr)
+			}
+		})
+	}
+}
@@ -39,6 +39,13 @@ const (
 	JobTypeRefreshPostStats             = "refresh_post_stats"
 	JobTypeDeleteOrphanDraftsMigration  = "delete_orphan_drafts_migration"
 	JobTypeExportUsersToCSV             = "export_users_to_csv"
+	JobTypeFileEncryption               = "file_encryption"
+	JobTypeFileTierMigration            = "file_tier_migration"
+	JobTypeOutgoingWebhookDelivery      = "outgoing_webhook_delivery"
+	JobTypeChannelMembershipReport      = "channel_membership_report"
+	JobTypeTeamActivityReport           = "team_activity_report"
+	JobTypeScheduledPosts               = "scheduled_posts"
+	JobTypeRegeneratePreviews           = "regenerate_previews"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -71,6 +78,9 @@ var AllJobTypes = [...]string{
 	JobTypeLastAccessibleFile,
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshPostStats,
+	JobTypeFileEncryption,
+	JobTypeFileTierMigration,
+	JobTypeRegeneratePreviews,
 }
 
 type Job struct {
@@ -83,6 +93,26 @@ type Job struct {
 	Status         string    `json:"status"`
 	Progress       int64     `json:"progress"`
 	Data           StringMap `json:"data"`
+	// ParentId is the job this one retries, or follows up on, and RootId the first job
+	// of the lineage they are part of.
+	ParentId   string `json:"parent_id"`
+	RootId     string `json:"root_id"`
+	RetryCount int    `json:"retry_count"`
+	// RunAfter is the time in milliseconds before which a pending job isn't run, such
+	// as when its retry is delayed.
+	RunAfter int64 `json:"run_after"`
+}
+
+// JobSchedule describes when the next job of a type is to be created by its scheduler.
+type JobSchedule struct {
+	JobType string `json:"job_type"`
+	Enabled bool   `json:"enabled"`
+	// Paused job types aren't scheduled, and their pending jobs aren't run, until resumed.
+	Paused bool `json:"paused"`
+	// The cron expression set in JobSettings.CronSchedules, if any.
+	CronSchedule string `json:"cron_schedule,omitempty"`
+	// The time of the next run in milliseconds, or 0 if none is scheduled.
+	NextRunTime int64 `json:"next_run_time"`
 }
 
 func (j *Job) Auditable() map[string]interface{} {
@@ -96,6 +126,9 @@ func (j *Job) Auditable() map[string]interface{} {
 		"status":           j.Status,
 		"progress":         j.Progress,
 		"data":             j.Data, // TODO do we want this here
+		"parent_id":        j.ParentId,
+		"root_id":          j.RootId,
+		"retry_count":      j.RetryCount,
 	}
 }
 
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	TokenTypeMfaReset = "mfa_reset"
+
+	// MfaResetTokenExpiry is how long, in milliseconds, the link to confirm an MFA reset
+	// request is valid for.
+	MfaResetTokenExpiry = 1000 * 60 * 60
+
+	MfaRecoveryCodeCount = 10
+	// MfaRecoveryCodeLength is the number of characters of a recovery code, not counting
+	// the dash separating its two halves.
+	MfaRecoveryCodeLength = 10
+
+	MfaResetRequestStatusPending  = "pending"
+	MfaResetRequestStatusApproved = "approved"
+	MfaResetRequestStatusDenied   = "denied"
+)
+
+// MfaRecoveryCode is a one-time code a user may sign in with instead of their TOTP code,
+// stored hashed.
+type MfaRecoveryCode struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	CodeHash string `json:"-"`
+	CreateAt int64  `json:"create_at"`
+	UsedAt   int64  `json:"used_at"`
+}
+
+// MfaRecoveryCodesStatus is the number of recovery codes a user has left.
+type MfaRecoveryCodesStatus struct {
+	Remaining int   `json:"remaining"`
+	CreateAt  int64 `json:"create_at"`
+}
+
+// NewMfaRecoveryCode returns a random recovery code, formatted as two groups of five
+// characters.
+func NewMfaRecoveryCode() string {
+	code := NewRandomString(MfaRecoveryCodeLength)
+	return code[:MfaRecoveryCodeLength/2] + "-" + code[MfaRecoveryCodeLength/2:]
+}
+
+func normalizeMfaRecoveryCode(code string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "-", "")
+}
+
+// IsMfaRecoveryCode returns whether an MFA token is a recovery code rather than a TOTP
+// code, which only has digits.
+func IsMfaRecoveryCode(token string) bool {
+	code := normalizeMfaRecoveryCode(token)
+	if len(code) != MfaRecoveryCodeLength {
+		return false
+	}
+
+	for _, c := range code {
+		if !strings.ContainsRune(base32Alphabet, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HashMfaRecoveryCode returns the hash of the recovery code of a user to store, an
+// HMAC-SHA256 keyed with a server secret since recovery codes are short enough to be
+// brute forced from a plain hash.
+func HashMfaRecoveryCode(key []byte, userID, code string) string {
+	hash := hmac.New(sha256.New, key)
+	hash.Write([]byte(userID + ":" + normalizeMfaRecoveryCode(code)))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// CompareMfaRecoveryCode returns whether the recovery code of a user matches a stored
+// hash, in constant time.
+func CompareMfaRecoveryCode(key []byte, hash, userID, code string) bool {
+	return hmac.Equal([]byte(hash), []byte(HashMfaRecoveryCode(key, userID, code)))
+}
+
+func (o *MfaRecoveryCode) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *MfaRecoveryCode) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if hash, err := hex.DecodeString(o.CodeHash); err != nil || len(hash) != sha256.Size {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.code_hash.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// MfaResetRequest is the request of a user who lost their second factor to have MFA
+// reset on their account, once they confirmed it from their email, for an admin to
+// approve or deny.
+type MfaResetRequest struct {
+	Id         string `json:"id"`
+	UserId     string `json:"user_id"`
+	Status     string `json:"status"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+	ResolvedBy string `json:"resolved_by"`
+}
+
+func (o *MfaResetRequest) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          o.Id,
+		"user_id":     o.UserId,
+		"status":      o.Status,
+		"create_at":   o.CreateAt,
+		"update_at":   o.UpdateAt,
+		"resolved_by": o.ResolvedBy,
+	}
+}
+
+func (o *MfaResetRequest) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = MfaResetRequestStatusPending
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *MfaResetRequest) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("MfaResetRequest.IsValid", "model.mfa_reset_request.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("MfaResetRequest.IsValid", "model.mfa_reset_request.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case MfaResetRequestStatusPending, MfaResetRequestStatusApproved, MfaResetRequestStatusDenied:
+	default:
+		return NewAppError("MfaResetRequest.IsValid", "model.mfa_reset_request.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ResolvedBy != "" && !IsValidId(o.ResolvedBy) {
+		return NewAppError("MfaResetRequest.IsValid", "model.mfa_reset_request.is_valid.resolved_by.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("MfaResetRequest.IsValid", "model.mfa_reset_request.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
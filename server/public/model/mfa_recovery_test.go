@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMfaRecoveryCode(t *testing.T) {
+	t.Run("new codes are recovery codes", func(t *testing.T) {
+		code := NewMfaRecoveryCode()
+		require.Len(t, code, MfaRecoveryCodeLength+1)
+		assert.Equal(t, byte('-'), code[MfaRecoveryCodeLength/2])
+		assert.True(t, IsMfaRecoveryCode(code))
+		assert.NotEqual(t, code, NewMfaRecoveryCode())
+	})
+
+	t.Run("IsMfaRecoveryCode", func(t *testing.T) {
+		assert.True(t, IsMfaRecoveryCode("ybndr-fg8ej"))
+		assert.True(t, IsMfaRecoveryCode(" YBNDRFG8EJ "))
+		assert.False(t, IsMfaRecoveryCode("123456"))
+		assert.False(t, IsMfaRecoveryCode("ybndr-fg8e"))
+		assert.False(t, IsMfaRecoveryCode("ybndr-fg8e0"))
+		assert.False(t, IsMfaRecoveryCode(""))
+	})
+
+	t.Run("hashes ignore the formatting", func(t *testing.T) {
+		key := []byte("key")
+		userID := NewId()
+		hash := HashMfaRecoveryCode(key, userID, "ybndr-fg8ej")
+		assert.True(t, CompareMfaRecoveryCode(key, hash, userID, "YBNDRFG8EJ"))
+		assert.False(t, CompareMfaRecoveryCode(key, hash, userID, "ybndr-fg8ek"))
+		assert.False(t, CompareMfaRecoveryCode(key, hash, NewId(), "ybndr-fg8ej"), "hashes should be bound to the user")
+		assert.False(t, CompareMfaRecoveryCode([]byte("other key"), hash, userID, "ybndr-fg8ej"), "hashes should be keyed")
+	})
+
+	t.Run("IsValid", func(t *testing.T) {
+		code := &MfaRecoveryCode{UserId: NewId()}
+		code.CodeHash = HashMfaRecoveryCode([]byte("key"), code.UserId, NewMfaRecoveryCode())
+		code.PreSave()
+		require.Nil(t, code.IsValid())
+
+		code.CodeHash = "plain"
+		assert.NotNil(t, code.IsValid())
+	})
+}
+
+func TestMfaResetRequestIsValid(t *testing.T) {
+	request := &MfaResetRequest{UserId: NewId()}
+	request.PreSave()
+	require.Nil(t, request.IsValid())
+	assert.Equal(t, MfaResetRequestStatusPending, request.Status)
+	assert.Equal(t, request.CreateAt, request.UpdateAt)
+
+	request.Status = "unknown"
+	assert.NotNil(t, request.IsValid())
+
+	request.Status = MfaResetRequestStatusApproved
+	request.ResolvedBy = "junk"
+	assert.NotNil(t, request.IsValid())
+
+	request.ResolvedBy = NewId()
+	assert.Nil(t, request.IsValid())
+}
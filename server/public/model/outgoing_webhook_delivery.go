@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OutgoingWebhookDeliveryStatusPending = "pending"
+	OutgoingWebhookDeliveryStatusSuccess = "success"
+	OutgoingWebhookDeliveryStatusFailed  = "failed"
+
+	// The headers sent along with each outgoing webhook request so that the receiver
+	// can check that the request was sent by the server, and deduplicate the retries.
+	OutgoingWebhookHeaderDeliveryId = "X-Mattermost-Delivery-Id"
+	OutgoingWebhookHeaderTimestamp  = "X-Mattermost-Request-Timestamp"
+	OutgoingWebhookHeaderSignature  = "X-Mattermost-Signature"
+
+	OutgoingWebhookSignatureVersion = "v1"
+
+	OutgoingWebhookDeliveryErrorMaxLength = 1024
+
+	outgoingWebhookDeliveryBaseRetryDelay = time.Minute
+	outgoingWebhookDeliveryMaxRetryDelay  = time.Hour
+)
+
+// OutgoingWebhookDelivery is a request made, or to be made, to one of the callback
+// URLs of an outgoing webhook. It is kept once done as the delivery history of the
+// webhook.
+type OutgoingWebhookDelivery struct {
+	Id          string `json:"id"`
+	HookId      string `json:"hook_id"`
+	PostId      string `json:"post_id"`
+	ChannelId   string `json:"channel_id"`
+	CallbackURL string `json:"callback_url"`
+	ContentType string `json:"content_type"`
+	Payload     string `json:"payload"`
+	Status      string `json:"status"`
+	Attempts    int    `json:"attempts"`
+	StatusCode  int    `json:"status_code"`
+	// The duration of the last attempt, in milliseconds.
+	Latency       int64  `json:"latency"`
+	LastError     string `json:"last_error"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+}
+
+func (o *OutgoingWebhookDelivery) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           o.Id,
+		"hook_id":      o.HookId,
+		"post_id":      o.PostId,
+		"channel_id":   o.ChannelId,
+		"callback_url": o.CallbackURL,
+		"status":       o.Status,
+		"attempts":     o.Attempts,
+		"status_code":  o.StatusCode,
+		"create_at":    o.CreateAt,
+		"update_at":    o.UpdateAt,
+	}
+}
+
+func (o *OutgoingWebhookDelivery) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = OutgoingWebhookDeliveryStatusPending
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *OutgoingWebhookDelivery) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	if len(o.LastError) > OutgoingWebhookDeliveryErrorMaxLength {
+		o.LastError = strings.ToValidUTF8(o.LastError[:OutgoingWebhookDeliveryErrorMaxLength], "")
+	}
+}
+
+func (o *OutgoingWebhookDelivery) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.HookId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.hook_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CallbackURL == "" || !IsValidHTTPURL(o.CallbackURL) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.callback_url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case OutgoingWebhookDeliveryStatusPending, OutgoingWebhookDeliveryStatusSuccess, OutgoingWebhookDeliveryStatusFailed:
+	default:
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// OutgoingWebhookRetryDelay returns how long to wait before making the next attempt
+// of a delivery that has already been attempted the given number of times. The delay
+// doubles with each attempt, up to an hour.
+func OutgoingWebhookRetryDelay(attempts int) time.Duration {
+	delay := outgoingWebhookDeliveryBaseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outgoingWebhookDeliveryMaxRetryDelay {
+			return outgoingWebhookDeliveryMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+// OutgoingWebhookSignature returns the signature of an outgoing webhook request,
+// as sent in the X-Mattermost-Signature header. It is the HMAC-SHA256 of the
+// version, the timestamp and the body of the request, keyed with the token of the
+// webhook, so that a receiver knowing the token can verify it with:
+//
+//	hmac.Equal([]byte(OutgoingWebhookSignature(token, timestamp, body)), []byte(signature))
+func OutgoingWebhookSignature(token string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(OutgoingWebhookSignatureVersion + ":" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+	return OutgoingWebhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
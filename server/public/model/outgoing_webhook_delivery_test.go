@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookDeliveryIsValid(t *testing.T) {
+	o := OutgoingWebhookDelivery{}
+	require.NotNil(t, o.IsValid())
+
+	o.Id = NewId()
+	require.NotNil(t, o.IsValid())
+
+	o.HookId = NewId()
+	require.NotNil(t, o.IsValid())
+
+	o.CallbackURL = "ftp://example.com"
+	require.NotNil(t, o.IsValid())
+
+	o.CallbackURL = "http://example.com/hook"
+	require.NotNil(t, o.IsValid())
+
+	o.PreSave()
+	require.Nil(t, o.IsValid())
+	assert.Equal(t, OutgoingWebhookDeliveryStatusPending, o.Status)
+
+	o.Status = "unknown"
+	require.NotNil(t, o.IsValid())
+}
+
+func TestOutgoingWebhookDeliveryPreUpdate(t *testing.T) {
+	o := OutgoingWebhookDelivery{LastError: strings.Repeat("é", OutgoingWebhookDeliveryErrorMaxLength)}
+	o.PreUpdate()
+
+	assert.NotZero(t, o.UpdateAt)
+	assert.LessOrEqual(t, len(o.LastError), OutgoingWebhookDeliveryErrorMaxLength)
+	assert.Equal(t, strings.Repeat("é", OutgoingWebhookDeliveryErrorMaxLength/2), o.LastError)
+}
+
+func TestOutgoingWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, OutgoingWebhookRetryDelay(0))
+	assert.Equal(t, time.Minute, OutgoingWebhookRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, OutgoingWebhookRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, OutgoingWebhookRetryDelay(3))
+	assert.Equal(t, time.Hour, OutgoingWebhookRetryDelay(20))
+}
+
+func TestOutgoingWebhookSignature(t *testing.T) {
+	body := []byte(`{"text":"hello"}`)
+	signature := OutgoingWebhookSignature("token", 1700000000, body)
+
+	assert.True(t, strings.HasPrefix(signature, "v1="))
+	assert.True(t, hmac.Equal([]byte(signature), []byte(OutgoingWebhookSignature("token", 1700000000, body))))
+	assert.NotEqual(t, signature, OutgoingWebhookSignature("other", 1700000000, body))
+	assert.NotEqual(t, signature, OutgoingWebhookSignature("token", 1700000001, body))
+	assert.NotEqual(t, signature, OutgoingWebhookSignature("token", 1700000000, []byte(`{"text":"bye"}`)))
+}
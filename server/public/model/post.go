@@ -204,6 +204,7 @@ type SearchParameter struct {
 	Page                   *int    `json:"page"`
 	PerPage                *int    `json:"per_page"`
 	IncludeDeletedChannels *bool   `json:"include_deleted_channels"`
+	OrderBy                *string `json:"order_by"`
 }
 
 type AnalyticsPostCountsOptions struct {
@@ -243,6 +244,18 @@ type DirectPostForExport struct {
 	ChannelMembers *[]string
 }
 
+// DeletedPostForExport identifies a deleted post in an incremental export.
+type DeletedPostForExport struct {
+	Id          string
+	ChannelId   string
+	CreateAt    int64
+	DeleteAt    int64
+	Username    string
+	ChannelType ChannelType
+	ChannelName string
+	TeamName    string
+}
+
 type ReplyForExport struct {
 	Post
 	Username string
@@ -10,15 +10,20 @@ import (
 
 type PostSearchMatches map[string][]string
 
+// PostSearchHighlights maps post ids to fragments of their message, with the matched terms
+// wrapped in <mark> tags and the rest of the text HTML-escaped.
+type PostSearchHighlights map[string][]string
+
 type PostSearchResults struct {
 	*PostList
-	Matches PostSearchMatches `json:"matches"`
+	Matches    PostSearchMatches    `json:"matches"`
+	Highlights PostSearchHighlights `json:"highlights,omitempty"`
 }
 
 func MakePostSearchResults(posts *PostList, matches PostSearchMatches) *PostSearchResults {
 	return &PostSearchResults{
-		posts,
-		matches,
+		PostList: posts,
+		Matches:  matches,
 	}
 }
 
@@ -51,6 +51,7 @@ const (
 
 	PreferenceCategoryNotifications = "notifications"
 	PreferenceNameEmailInterval     = "email_interval"
+	PreferenceNameEmailDigestTime   = "email_digest_time"
 
 	PreferenceEmailIntervalNoBatchingSeconds = "30"  // the "immediate" setting is actually 30s
 	PreferenceEmailIntervalBatchingSeconds   = "900" // fifteen minutes is 900 seconds
@@ -59,6 +60,10 @@ const (
 	PreferenceEmailIntervalFifteenAsSeconds  = "900"
 	PreferenceEmailIntervalHour              = "hour"
 	PreferenceEmailIntervalHourAsSeconds     = "3600"
+	PreferenceEmailIntervalDay               = "day"
+	PreferenceEmailIntervalDayAsSeconds      = "86400"
+	PreferenceEmailDigestTimeLayout          = "15:04" // the local time daily digests are sent at
+	PreferenceEmailDigestTimeDefault         = "09:00"
 	PreferenceCloudUserEphemeralInfo         = "cloud_user_ephemeral_info"
 
 	MaxPreferenceValueLength = 20000
@@ -17,10 +17,14 @@ const (
 	ReportDurationLast6Months   = "last_6_months"
 
 	ReportingMaxPageSize = 100
+
+	ReportFormatCSV   = "csv"
+	ReportFormatJSONL = "jsonl"
+	ReportFormatXLSX  = "xlsx"
 )
 
 var (
-	ReportExportFormats = []string{"csv"}
+	ReportExportFormats = []string{ReportFormatCSV, ReportFormatJSONL, ReportFormatXLSX}
 
 	UserReportSortColumns = []string{"CreateAt", "Username", "FirstName", "LastName", "Nickname", "Email", "Roles"}
 )
@@ -160,3 +164,107 @@ func IsValidReportExportFormat(format string) bool {
 
 	return false
 }
+
+// ChannelMembershipReport is a row of the channel membership report, listing the
+// members of the public and private channels.
+type ChannelMembershipReport struct {
+	ChannelId          string `json:"channel_id"`
+	ChannelName        string `json:"channel_name"`
+	ChannelDisplayName string `json:"channel_display_name"`
+	ChannelType        string `json:"channel_type"`
+	TeamId             string `json:"team_id"`
+	TeamName           string `json:"team_name"`
+	UserId             string `json:"user_id"`
+	Username           string `json:"username"`
+	Email              string `json:"email"`
+	SchemeAdmin        bool   `json:"scheme_admin"`
+	SchemeGuest        bool   `json:"scheme_guest"`
+	LastViewedAt       int64  `json:"last_viewed_at"`
+}
+
+func (r *ChannelMembershipReport) ToReport() []string {
+	role := "member"
+	if r.SchemeGuest {
+		role = "guest"
+	} else if r.SchemeAdmin {
+		role = "admin"
+	}
+
+	lastViewedAt := ""
+	if r.LastViewedAt > 0 {
+		lastViewedAt = time.UnixMilli(r.LastViewedAt).String()
+	}
+
+	return []string{
+		r.ChannelId,
+		r.ChannelName,
+		r.ChannelDisplayName,
+		r.ChannelType,
+		r.TeamId,
+		r.TeamName,
+		r.UserId,
+		r.Username,
+		r.Email,
+		role,
+		lastViewedAt,
+	}
+}
+
+// ChannelMembershipReportOptions selects the rows of the channel membership report. The
+// rows are sorted by channel and user, FromColumnValue and FromId being the ids of the
+// channel and user of the last row of the previous page.
+type ChannelMembershipReportOptions struct {
+	ReportingBaseOptions
+	TeamId string
+}
+
+func (o *ChannelMembershipReportOptions) IsValid() *AppError {
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("ChannelMembershipReportOptions.IsValid", "model.channel_membership_report_options.is_valid.team_id", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// TeamActivityReport is a row of the team activity report. The posts and active users
+// are counted over the date range of the report.
+type TeamActivityReport struct {
+	TeamId       string `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	DisplayName  string `json:"display_name"`
+	Type         string `json:"type"`
+	MemberCount  int64  `json:"member_count"`
+	ChannelCount int64  `json:"channel_count"`
+	PostCount    int64  `json:"post_count"`
+	ActiveUsers  int64  `json:"active_users"`
+	LastPostAt   int64  `json:"last_post_at"`
+}
+
+func (r *TeamActivityReport) ToReport() []string {
+	lastPostAt := ""
+	if r.LastPostAt > 0 {
+		lastPostAt = time.UnixMilli(r.LastPostAt).String()
+	}
+
+	return []string{
+		r.TeamId,
+		r.TeamName,
+		r.DisplayName,
+		r.Type,
+		strconv.FormatInt(r.MemberCount, 10),
+		strconv.FormatInt(r.ChannelCount, 10),
+		strconv.FormatInt(r.PostCount, 10),
+		strconv.FormatInt(r.ActiveUsers, 10),
+		lastPostAt,
+	}
+}
+
+// TeamActivityReportOptions selects the rows of the team activity report. The rows are
+// sorted by team, FromId being the id of the team of the last row of the previous page.
+type TeamActivityReportOptions struct {
+	ReportingBaseOptions
+}
+
+func (o *TeamActivityReportOptions) IsValid() *AppError {
+	return o.ReportingBaseOptions.IsValid()
+}
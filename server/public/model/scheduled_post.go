@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	// The reasons a scheduled post could not be sent, kept in its ErrorCode so that
+	// the author can see why and reschedule it.
+	ScheduledPostErrorCodeUnknown         = "unknown"
+	ScheduledPostErrorCodeChannelArchived = "channel_archived"
+	ScheduledPostErrorCodeChannelNotFound = "channel_not_found"
+	ScheduledPostErrorCodeUserDeleted     = "user_deleted"
+	ScheduledPostErrorCodeNoPermission    = "no_channel_permission"
+	ScheduledPostErrorCodeThreadDeleted   = "thread_deleted"
+	ScheduledPostErrorCodeInvalidPost     = "invalid_post"
+
+	// How far in the past a scheduled post may be scheduled, to allow for clock drift
+	// between the client and the server.
+	scheduledPostMaxPastSchedule = 60 * 1000
+)
+
+// ScheduledPost is a post composed by a user to be sent by the server at a later time.
+// Once sent, it is deleted, unless it could not be sent in which case ErrorCode tells
+// why.
+type ScheduledPost struct {
+	Draft
+	Id          string `json:"id"`
+	ScheduledAt int64  `json:"scheduled_at"`
+	ProcessedAt int64  `json:"processed_at"`
+	ErrorCode   string `json:"error_code"`
+}
+
+func (s *ScheduledPost) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           s.Id,
+		"create_at":    s.CreateAt,
+		"update_at":    s.UpdateAt,
+		"user_id":      s.UserId,
+		"channel_id":   s.ChannelId,
+		"root_id":      s.RootId,
+		"scheduled_at": s.ScheduledAt,
+		"processed_at": s.ProcessedAt,
+		"error_code":   s.ErrorCode,
+	}
+}
+
+// IsValid validates a scheduled post before it is saved. The time it is scheduled at is
+// checked by IsValidSchedule instead, since it is only relevant when it is scheduled.
+func (s *ScheduledPost) IsValid(maxMessageSize int) *AppError {
+	if !IsValidId(s.Id) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if s.Message == "" && len(s.FileIds) == 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.empty_post.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.ScheduledAt <= 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.scheduled_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.ProcessedAt < 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.processed_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	return s.Draft.IsValid(maxMessageSize)
+}
+
+// IsValidSchedule checks that the post is scheduled in the future.
+func (s *ScheduledPost) IsValidSchedule(now int64) *AppError {
+	if s.ScheduledAt < now-scheduledPostMaxPastSchedule {
+		return NewAppError("ScheduledPost.IsValidSchedule", "model.scheduled_post.is_valid.scheduled_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (s *ScheduledPost) PreSave() {
+	if s.Id == "" {
+		s.Id = NewId()
+	}
+
+	s.CreateAt = 0
+	s.ProcessedAt = 0
+	s.ErrorCode = ""
+
+	s.Draft.PreSave()
+}
+
+func (s *ScheduledPost) PreUpdate() {
+	s.UpdateAt = GetMillis()
+	s.PreCommit()
+}
+
+// ToPost returns the post to create when the scheduled post is sent.
+func (s *ScheduledPost) ToPost() *Post {
+	post := &Post{
+		UserId:    s.UserId,
+		ChannelId: s.ChannelId,
+		RootId:    s.RootId,
+		Message:   s.Message,
+		FileIds:   s.FileIds,
+	}
+	props := make(StringInterface, len(s.GetProps()))
+	for key, value := range s.GetProps() {
+		props[key] = value
+	}
+	post.SetProps(props)
+
+	if len(s.Priority) > 0 {
+		priority := &PostPriority{}
+		if value, ok := s.Priority["priority"].(string); ok {
+			priority.Priority = NewString(value)
+		}
+		if value, ok := s.Priority["requested_ack"].(bool); ok {
+			priority.RequestedAck = NewBool(value)
+		}
+		if value, ok := s.Priority["persistent_notifications"].(bool); ok {
+			priority.PersistentNotifications = NewBool(value)
+		}
+		post.Metadata = &PostMetadata{Priority: priority}
+	}
+
+	return post
+}
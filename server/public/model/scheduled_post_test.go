@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPostIsValid(t *testing.T) {
+	newScheduledPost := func() *ScheduledPost {
+		scheduledPost := &ScheduledPost{
+			Draft: Draft{
+				UserId:    NewId(),
+				ChannelId: NewId(),
+				Message:   "message",
+			},
+			ScheduledAt: GetMillis() + 1000,
+		}
+		scheduledPost.PreSave()
+		return scheduledPost
+	}
+
+	require.Nil(t, newScheduledPost().IsValid(PostMessageMaxRunesV2))
+
+	scheduledPost := newScheduledPost()
+	scheduledPost.Id = "invalid"
+	require.NotNil(t, scheduledPost.IsValid(PostMessageMaxRunesV2))
+
+	scheduledPost = newScheduledPost()
+	scheduledPost.Message = ""
+	require.NotNil(t, scheduledPost.IsValid(PostMessageMaxRunesV2))
+	scheduledPost.FileIds = []string{NewId()}
+	require.Nil(t, scheduledPost.IsValid(PostMessageMaxRunesV2))
+
+	scheduledPost = newScheduledPost()
+	scheduledPost.ScheduledAt = 0
+	require.NotNil(t, scheduledPost.IsValid(PostMessageMaxRunesV2))
+
+	scheduledPost = newScheduledPost()
+	scheduledPost.ChannelId = ""
+	require.NotNil(t, scheduledPost.IsValid(PostMessageMaxRunesV2))
+}
+
+func TestScheduledPostIsValidSchedule(t *testing.T) {
+	now := GetMillis()
+	scheduledPost := &ScheduledPost{ScheduledAt: now + 1000}
+	require.Nil(t, scheduledPost.IsValidSchedule(now))
+
+	// Allow for some clock drift between the client and the server.
+	scheduledPost.ScheduledAt = now - 1000
+	require.Nil(t, scheduledPost.IsValidSchedule(now))
+
+	scheduledPost.ScheduledAt = now - 24*60*60*1000
+	require.NotNil(t, scheduledPost.IsValidSchedule(now))
+}
+
+func TestScheduledPostPreSave(t *testing.T) {
+	scheduledPost := &ScheduledPost{
+		Draft: Draft{
+			CreateAt: 1,
+		},
+		ProcessedAt: 1,
+		ErrorCode:   ScheduledPostErrorCodeUnknown,
+	}
+	scheduledPost.PreSave()
+
+	assert.True(t, IsValidId(scheduledPost.Id))
+	assert.NotEqual(t, int64(1), scheduledPost.CreateAt)
+	assert.Equal(t, scheduledPost.CreateAt, scheduledPost.UpdateAt)
+	assert.Zero(t, scheduledPost.ProcessedAt)
+	assert.Empty(t, scheduledPost.ErrorCode)
+	assert.NotNil(t, scheduledPost.GetProps())
+	assert.NotNil(t, scheduledPost.FileIds)
+}
+
+func TestScheduledPostToPost(t *testing.T) {
+	scheduledPost := &ScheduledPost{
+		Draft: Draft{
+			UserId:    NewId(),
+			ChannelId: NewId(),
+			RootId:    NewId(),
+			Message:   "message",
+			FileIds:   []string{NewId()},
+			Priority: StringInterface{
+				"priority":      PostPriorityUrgent,
+				"requested_ack": true,
+			},
+		},
+	}
+	scheduledPost.SetProps(StringInterface{"key": "value"})
+
+	post := scheduledPost.ToPost()
+	assert.Equal(t, scheduledPost.UserId, post.UserId)
+	assert.Equal(t, scheduledPost.ChannelId, post.ChannelId)
+	assert.Equal(t, scheduledPost.RootId, post.RootId)
+	assert.Equal(t, scheduledPost.Message, post.Message)
+	assert.Equal(t, scheduledPost.FileIds, post.FileIds)
+	assert.Equal(t, "value", post.GetProp("key"))
+	require.NotNil(t, post.GetPriority())
+	assert.Equal(t, PostPriorityUrgent, *post.GetPriority().Priority)
+	assert.True(t, *post.GetPriority().RequestedAck)
+	assert.Nil(t, post.GetPriority().PersistentNotifications)
+
+	// The props of the post are a copy.
+	post.AddProp("other", "value")
+	assert.Nil(t, scheduledPost.GetProps()["other"])
+}
@@ -10,6 +10,11 @@ import (
 	"time"
 )
 
+const (
+	SearchOrderByDate      = "date"
+	SearchOrderByRelevance = "relevance"
+)
+
 var searchTermPuncStart = regexp.MustCompile(`^[^\pL\d\s#"]+`)
 var searchTermPuncEnd = regexp.MustCompile(`[^\pL\d\s*"]+$`)
 
@@ -35,6 +40,13 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_user_id,omitempty"`
 	Modifier            string `json:"modifier"`
+	// OrderBy is either SearchOrderByDate, the default, or SearchOrderByRelevance.
+	OrderBy string `json:"order_by,omitempty"`
+}
+
+// IsOrderedByRelevance returns whether the results are ranked by relevance rather than date.
+func (p *SearchParams) IsOrderedByRelevance() bool {
+	return p.OrderBy == SearchOrderByRelevance
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -393,6 +405,13 @@ func IsSearchParamsListValid(paramsList []*SearchParams) *AppError {
 		if params.IncludeDeletedChannels != paramsList[0].IncludeDeletedChannels {
 			return NewAppError("IsSearchParamsListValid", "model.search_params_list.is_valid.include_deleted_channels.app_error", nil, "", http.StatusInternalServerError)
 		}
+		if params.OrderBy != "" && params.OrderBy != SearchOrderByDate && params.OrderBy != SearchOrderByRelevance {
+			return NewAppError("IsSearchParamsListValid", "model.search_params_list.is_valid.order_by.app_error", map[string]any{"OrderBy": params.OrderBy}, "", http.StatusBadRequest)
+		}
+		// All SearchParams should be ordered the same way.
+		if params.OrderBy != paramsList[0].OrderBy {
+			return NewAppError("IsSearchParamsListValid", "model.search_params_list.is_valid.order_by.app_error", map[string]any{"OrderBy": params.OrderBy}, "", http.StatusInternalServerError)
+		}
 	}
 	return nil
 }
@@ -1796,4 +1796,13 @@ func TestIsSearchParamsListValid(t *testing.T) {
 
 	appErr = IsSearchParamsListValid([]*SearchParams{})
 	assert.Nil(t, appErr)
+
+	appErr = IsSearchParamsListValid([]*SearchParams{{OrderBy: SearchOrderByRelevance}, {OrderBy: SearchOrderByRelevance}})
+	assert.Nil(t, appErr)
+
+	appErr = IsSearchParamsListValid([]*SearchParams{{OrderBy: SearchOrderByRelevance}, {OrderBy: SearchOrderByDate}})
+	assert.NotNil(t, appErr)
+
+	appErr = IsSearchParamsListValid([]*SearchParams{{OrderBy: "popularity"}})
+	assert.NotNil(t, appErr)
 }
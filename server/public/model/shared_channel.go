@@ -112,6 +112,7 @@ type SharedChannelRemote struct {
 	LastPostUpdateID  string `json:"last_post_id"`
 	LastPostCreateAt  int64  `json:"last_post_create_at"`
 	LastPostCreateID  string `json:"last_post_create_id"`
+	LastChannelSyncAt int64  `json:"last_channel_sync_at"`
 }
 
 func (sc *SharedChannelRemote) IsValid() *AppError {
@@ -260,14 +261,56 @@ type SharedChannelRemoteFilterOpts struct {
 	InclUnconfirmed bool
 }
 
-// SyncMsg represents a change in content (post add/edit/delete, reaction add/remove, users).
+// Optional sync features. Each remote advertises the features it supports via
+// `SyncResponse.Features`; content for a feature is only sent to remotes that advertise it.
+// Remotes that predate feature negotiation advertise nothing and receive posts, reactions,
+// users, attachments and profile images only.
+const (
+	SyncFeatureChannelInfo      = "channel_info"     // channel header and purpose
+	SyncFeatureMembership       = "membership"       // channel member adds and removes
+	SyncFeatureAcknowledgements = "acknowledgements" // post acknowledgements
+	SyncFeaturePriority         = "priority"         // post priority metadata
+	SyncFeatureBookmarks        = "bookmarks"        // channel link bookmarks
+)
+
+// SupportedSyncFeatures returns the optional sync features supported by this server.
+func SupportedSyncFeatures() []string {
+	return []string{
+		SyncFeatureChannelInfo,
+		SyncFeatureMembership,
+		SyncFeatureAcknowledgements,
+		SyncFeaturePriority,
+		SyncFeatureBookmarks,
+	}
+}
+
+// SyncMsg represents a change in content (post add/edit/delete, reaction add/remove, users,
+// channel header/purpose, membership, acknowledgements, bookmarks).
 // It is sent to remote clusters as the payload of a `RemoteClusterMsg`.
 type SyncMsg struct {
-	Id        string           `json:"id"`
-	ChannelId string           `json:"channel_id"`
-	Users     map[string]*User `json:"users,omitempty"`
-	Posts     []*Post          `json:"posts,omitempty"`
-	Reactions []*Reaction      `json:"reactions,omitempty"`
+	Id                string                            `json:"id"`
+	ChannelId         string                            `json:"channel_id"`
+	Users             map[string]*User                  `json:"users,omitempty"`
+	Posts             []*Post                           `json:"posts,omitempty"`
+	Reactions         []*Reaction                       `json:"reactions,omitempty"`
+	Channel           *SyncChannelInfo                  `json:"channel,omitempty"`
+	MembershipChanges []*SyncMembershipChange           `json:"membership_changes,omitempty"`
+	Acknowledgements  map[string][]*PostAcknowledgement `json:"acknowledgements,omitempty"` // postId -> all current acknowledgements
+	Bookmarks         []*ChannelBookmark                `json:"bookmarks,omitempty"`
+}
+
+// SyncChannelInfo carries the channel properties that are kept in sync across remotes.
+type SyncChannelInfo struct {
+	Header   string `json:"header"`
+	Purpose  string `json:"purpose"`
+	UpdateAt int64  `json:"update_at"`
+}
+
+// SyncMembershipChange represents a user joining or leaving a shared channel.
+type SyncMembershipChange struct {
+	UserId     string `json:"user_id"`
+	IsAdd      bool   `json:"is_add"`
+	ChangeTime int64  `json:"change_time"`
 }
 
 func NewSyncMsg(channelID string) *SyncMsg {
@@ -304,6 +347,13 @@ type SyncResponse struct {
 
 	ReactionsLastUpdateAt int64    `json:"reactions_last_update_at"`
 	ReactionErrors        []string `json:"reaction_errors"`
+
+	MembershipErrors      []string `json:"membership_errors,omitempty"`
+	AcknowledgementErrors []string `json:"acknowledgement_errors,omitempty"`
+	BookmarkErrors        []string `json:"bookmark_errors,omitempty"`
+
+	// Features lists the optional sync features supported by the responding remote.
+	Features []string `json:"features,omitempty"`
 }
 
 // RegisterPluginOpts is passed by plugins to the `RegisterPluginForSharedChannels` plugin API
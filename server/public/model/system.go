@@ -36,6 +36,7 @@ const (
 	SystemLastAccessiblePostTime           = "LastAccessiblePostTime"
 	SystemLastAccessibleFileTime           = "LastAccessibleFileTime"
 	SystemHostedPurchaseNeedsScreening     = "HostedPurchaseNeedsScreening"
+	SystemJobTypePausedPrefix              = "JobTypePaused_"
 	AwsMeteringReportInterval              = 1
 	AwsMeteringDimensionUsageHrs           = "UsageHrs"
 	CloudRenewalEmail                      = "CloudRenewalEmail"
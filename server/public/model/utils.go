@@ -374,7 +374,10 @@ func NewAppError(where string, id string, params map[string]any, details string,
 	return ap
 }
 
-var encoding = base32.NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(base32.NoPadding)
+// base32Alphabet is the alphabet of ids and random strings.
+const base32Alphabet = "ybndrfg8ejkmcpqxot1uwisza345h769"
+
+var encoding = base32.NewEncoding(base32Alphabet).WithPadding(base32.NoPadding)
 
 // NewId is a globally unique identifier.  It is a [A-Z0-9] string 26
 // characters long.  It is a UUID version 4 Guid that is zbased32 encoded
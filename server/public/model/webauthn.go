@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TokenTypeWebAuthnRegistration = "webauthn_registration"
+	TokenTypeWebAuthnLogin        = "webauthn_login"
+
+	// WebAuthnTimeout is how long, in milliseconds, users have to use their security key
+	// once asked to.
+	WebAuthnTimeout = 1000 * 60 * 5
+
+	WebAuthnCredentialNameMaxRunes = 64
+	WebAuthnCredentialIdMaxLength  = 1024
+	WebAuthnCredentialMaxPerUser   = 20
+
+	WebAuthnPublicKeyCredentialType = "public-key"
+)
+
+// WebAuthnCredential is a security key registered by a user as a second factor.
+type WebAuthnCredential struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	// CredentialId is the base64url encoded id of the credential, as chosen by the
+	// security key.
+	CredentialId string `json:"credential_id"`
+	PublicKey    []byte `json:"-"`
+	SignCount    int64  `json:"-"`
+	CreateAt     int64  `json:"create_at"`
+	LastUsedAt   int64  `json:"last_used_at"`
+}
+
+func (o *WebAuthnCredential) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            o.Id,
+		"user_id":       o.UserId,
+		"name":          o.Name,
+		"credential_id": o.CredentialId,
+		"create_at":     o.CreateAt,
+		"last_used_at":  o.LastUsedAt,
+	}
+}
+
+func (o *WebAuthnCredential) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	o.Name = strings.TrimSpace(o.Name)
+}
+
+func (o *WebAuthnCredential) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Name == "" || utf8.RuneCountInString(o.Name) > WebAuthnCredentialNameMaxRunes {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.name.app_error", map[string]any{"MaxLength": WebAuthnCredentialNameMaxRunes}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CredentialId == "" || len(o.CredentialId) > WebAuthnCredentialIdMaxLength {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.credential_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.PublicKey) == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.public_key.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// The options below are those of the Web Authentication API, with their binary values
+// base64url encoded, for the client to pass them to navigator.credentials.create and
+// navigator.credentials.get once decoded.
+
+type WebAuthnRelyingPartyEntity struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions are the options to register a new security key with.
+type WebAuthnCreationOptions struct {
+	Rp                     WebAuthnRelyingPartyEntity     `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	Challenge              string                         `json:"challenge"`
+	PubKeyCredParams       []WebAuthnCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions are the options to sign in with a security key.
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RpId             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// WebAuthnRegistration is the credential created by a security key, along with the name
+// the user gave it.
+type WebAuthnRegistration struct {
+	Name     string                      `json:"name"`
+	Id       string                      `json:"id"`
+	Type     string                      `json:"type"`
+	Response WebAuthnAttestationResponse `json:"response"`
+}
+
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+}
+
+// WebAuthnAssertion is the response of a security key to sign in with, passed as the
+// MFA token when logging in.
+type WebAuthnAssertion struct {
+	Id       string                    `json:"id"`
+	Type     string                    `json:"type"`
+	Response WebAuthnAssertionResponse `json:"response"`
+}
+
+// IsWebAuthnAssertion returns whether an MFA token is a WebAuthn assertion rather than a
+// TOTP code.
+func IsWebAuthnAssertion(token string) bool {
+	return strings.HasPrefix(strings.TrimSpace(token), "{")
+}
+
+// DecodeWebAuthnBase64 decodes a base64url encoded WebAuthn value, with or without its
+// padding.
+func DecodeWebAuthnBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// EncodeWebAuthnBase64 encodes a WebAuthn value as base64url, without padding.
+func EncodeWebAuthnBase64(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredentialIsValid(t *testing.T) {
+	credential := &WebAuthnCredential{
+		UserId:       NewId(),
+		Name:         "  YubiKey ",
+		CredentialId: EncodeWebAuthnBase64([]byte("credential")),
+		PublicKey:    []byte{0xa1},
+	}
+	credential.PreSave()
+	require.Nil(t, credential.IsValid())
+	assert.Equal(t, "YubiKey", credential.Name)
+
+	for name, update := range map[string]func(*WebAuthnCredential){
+		"missing user id":       func(c *WebAuthnCredential) { c.UserId = "" },
+		"missing name":          func(c *WebAuthnCredential) { c.Name = "" },
+		"name too long":         func(c *WebAuthnCredential) { c.Name = strings.Repeat("a", WebAuthnCredentialNameMaxRunes+1) },
+		"missing credential id": func(c *WebAuthnCredential) { c.CredentialId = "" },
+		"missing public key":    func(c *WebAuthnCredential) { c.PublicKey = nil },
+		"missing create at":     func(c *WebAuthnCredential) { c.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := *credential
+			update(&invalid)
+			require.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestWebAuthnBase64(t *testing.T) {
+	encoded := EncodeWebAuthnBase64([]byte{0xfb, 0xff})
+	assert.Equal(t, "-_8", encoded)
+
+	for _, value := range []string{"-_8", "-_8="} {
+		decoded, err := DecodeWebAuthnBase64(value)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xfb, 0xff}, decoded)
+	}
+
+	assert.True(t, IsWebAuthnAssertion(` {"id": "abc"}`))
+	assert.False(t, IsWebAuthnAssertion("123456"))
+}
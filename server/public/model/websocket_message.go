@@ -83,6 +83,9 @@ const (
 	WebsocketEventDraftCreated                        WebsocketEventType = "draft_created"
 	WebsocketEventDraftUpdated                        WebsocketEventType = "draft_updated"
 	WebsocketEventDraftDeleted                        WebsocketEventType = "draft_deleted"
+	WebsocketEventScheduledPostCreated                WebsocketEventType = "scheduled_post_created"
+	WebsocketEventScheduledPostUpdated                WebsocketEventType = "scheduled_post_updated"
+	WebsocketEventScheduledPostDeleted                WebsocketEventType = "scheduled_post_deleted"
 	WebsocketEventAcknowledgementAdded                WebsocketEventType = "post_acknowledgement_added"
 	WebsocketEventAcknowledgementRemoved              WebsocketEventType = "post_acknowledgement_removed"
 	WebsocketEventPersistentNotificationTriggered     WebsocketEventType = "persistent_notification_triggered"
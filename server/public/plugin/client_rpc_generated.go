@@ -1161,6 +1161,43 @@ func (s *hooksRPCServer) GenerateSupportData(args *Z_GenerateSupportDataArgs, re
 	return nil
 }
 
+func init() {
+	hookNameToId["ExtractFileContent"] = ExtractFileContentID
+}
+
+type Z_ExtractFileContentArgs struct {
+	A *Context
+	B *model.FileInfo
+}
+
+type Z_ExtractFileContentReturns struct {
+	A string
+	B error
+}
+
+func (g *hooksRPCClient) ExtractFileContent(c *Context, fileInfo *model.FileInfo) (string, error) {
+	_args := &Z_ExtractFileContentArgs{c, fileInfo}
+	_returns := &Z_ExtractFileContentReturns{}
+	if g.implemented[ExtractFileContentID] {
+		if err := g.client.Call("Plugin.ExtractFileContent", _args, _returns); err != nil {
+			g.log.Error("RPC call ExtractFileContent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ExtractFileContent(args *Z_ExtractFileContentArgs, returns *Z_ExtractFileContentReturns) error {
+	if hook, ok := s.impl.(interface {
+		ExtractFileContent(c *Context, fileInfo *model.FileInfo) (string, error)
+	}); ok {
+		returns.A, returns.B = hook.ExtractFileContent(args.A, args.B)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ExtractFileContent called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
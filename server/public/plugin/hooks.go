@@ -61,6 +61,7 @@ const (
 	OnSharedChannelsAttachmentSyncMsgID       = 43
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
+	ExtractFileContentID                      = 46
 	TotalHooksID                              = iota
 )
 
@@ -389,4 +390,15 @@ type Hooks interface {
 	//
 	// Minimum server version: 9.8
 	GenerateSupportData(c *Context) ([]*model.FileData, error)
+
+	// ExtractFileContent is invoked when the text content of an uploaded file is extracted to
+	// make the file searchable, including for images whose content the server doesn't extract.
+	// The plugin can read the file with API.GetFile, e.g. to run it through a local OCR engine.
+	//
+	// To use the extracted text in place of the one of the server, return it. To let the
+	// server extract the content, return an empty string and a nil error. When several plugins
+	// implement this hook, the text of the first one returning some text is used.
+	//
+	// Minimum server version: 9.10
+	ExtractFileContent(c *Context, fileInfo *model.FileInfo) (string, error)
 }
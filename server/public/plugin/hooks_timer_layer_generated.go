@@ -291,3 +291,10 @@ func (hooks *hooksTimerLayer) GenerateSupportData(c *Context) ([]*model.FileData
 	hooks.recordTime(startTime, "GenerateSupportData", _returnsB == nil)
 	return _returnsA, _returnsB
 }
+
+func (hooks *hooksTimerLayer) ExtractFileContent(c *Context, fileInfo *model.FileInfo) (string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ExtractFileContent(c, fileInfo)
+	hooks.recordTime(startTime, "ExtractFileContent", _returnsB == nil)
+	return _returnsA, _returnsB
+}